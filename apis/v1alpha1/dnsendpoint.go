@@ -52,6 +52,9 @@ type DNSEndpointList struct {
 // DNSEndpointSpec defines the desired state of DNSEndpoint
 type DNSEndpointSpec struct {
 	Endpoints []*endpoint.Endpoint `json:"endpoints,omitempty"`
+	// Protected marks the records of all endpoints as never to be deleted or taken over by another resource.
+	// +optional
+	Protected bool `json:"protected,omitempty"`
 }
 
 // DNSEndpointStatus defines the observed state of DNSEndpoint
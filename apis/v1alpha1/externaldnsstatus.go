@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types of an ExternalDNSStatus.
+const (
+	// ExternalDNSStatusConditionReady is True when the last synchronization succeeded
+	ExternalDNSStatusConditionReady = "Ready"
+	// ExternalDNSStatusConditionChangesPending is True when planned changes weren't applied by the last
+	// synchronization, because it is paused, the changes wait for approval or were deferred
+	ExternalDNSStatusConditionChangesPending = "ChangesPending"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ExternalDNSStatus summarizes the synchronizations of an ExternalDNS instance, for health checks and dashboards.
+// +k8s:openapi-gen=true
+// +groupName=externaldns.k8s.io
+// +kubebuilder:resource:path=externaldnsstatuses,scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Owner",type=string,JSONPath=`.spec.ownerID`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Records",type=integer,JSONPath=`.status.records`
+// +kubebuilder:printcolumn:name="Pending",type=integer,JSONPath=`.status.pendingChanges`
+// +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+// +versionName=v1alpha1
+type ExternalDNSStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExternalDNSStatusSpec   `json:"spec,omitempty"`
+	Status ExternalDNSStatusStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// ExternalDNSStatusList is a list of ExternalDNSStatus objects
+type ExternalDNSStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExternalDNSStatus `json:"items"`
+}
+
+// ExternalDNSStatusSpec identifies the ExternalDNS instance
+type ExternalDNSStatusSpec struct {
+	// OwnerID identifies the ExternalDNS instance reporting its status.
+	// +optional
+	OwnerID string `json:"ownerID,omitempty"`
+}
+
+// ExternalDNSStatusStatus defines the observed state of the ExternalDNS instance
+type ExternalDNSStatusStatus struct {
+	// LastSyncTime is the time of the last successful synchronization of all records.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// LastAttemptTime is the time the last synchronization ended, whether it succeeded or not.
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	// Paused is set while applying changes is paused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// Records is the number of records owned by the instance.
+	// +optional
+	Records int `json:"records,omitempty"`
+	// PendingChanges is the number of planned changes the last synchronization didn't apply.
+	// +optional
+	PendingChanges int `json:"pendingChanges,omitempty"`
+	// Zones are the zones known from the domain filters.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Zones []ZoneStatus `json:"zones,omitempty"`
+	// Conditions are the Ready and ChangesPending conditions of the instance.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ZoneStatus is the status of the records of a zone
+type ZoneStatus struct {
+	// Name is the name of the zone.
+	Name string `json:"name"`
+	// Records is the number of records of the zone owned by the instance.
+	// +optional
+	Records int `json:"records,omitempty"`
+	// LastSyncTime is the time of the last successful synchronization of the zone.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Phases of a PendingChange.
+const (
+	// PendingChangePhasePending is for changes waiting for approval
+	PendingChangePhasePending = "Pending"
+	// PendingChangePhaseApplied is for approved changes which were applied
+	PendingChangePhaseApplied = "Applied"
+	// PendingChangePhaseSuperseded is for changes replaced by the changes of a later plan before being applied
+	PendingChangePhaseSuperseded = "Superseded"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PendingChange holds the changes of a plan which ExternalDNS only applies once approved, by setting the
+// external-dns.alpha.kubernetes.io/approved annotation to "true".
+// +k8s:openapi-gen=true
+// +groupName=externaldns.k8s.io
+// +kubebuilder:resource:path=pendingchanges
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Owner",type=string,JSONPath=`.spec.ownerID`
+// +kubebuilder:printcolumn:name="Risk",type=integer,JSONPath=`.spec.risk`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +versionName=v1alpha1
+type PendingChange struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PendingChangeSpec   `json:"spec,omitempty"`
+	Status PendingChangeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// PendingChangeList is a list of PendingChange objects
+type PendingChangeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PendingChange `json:"items"`
+}
+
+// PendingChangeSpec holds the changes waiting for approval
+type PendingChangeSpec struct {
+	// OwnerID identifies the ExternalDNS instance which planned the changes.
+	OwnerID string `json:"ownerID"`
+	// Risk is the number of changes, which reached the approval threshold.
+	Risk int `json:"risk"`
+	// Reasons explain why the changes need approval.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+	// Create are the records to create.
+	// +optional
+	Create []*endpoint.Endpoint `json:"create,omitempty"`
+	// UpdateOld are the records to update, before the update.
+	// +optional
+	UpdateOld []*endpoint.Endpoint `json:"updateOld,omitempty"`
+	// UpdateNew are the records to update, after the update.
+	// +optional
+	UpdateNew []*endpoint.Endpoint `json:"updateNew,omitempty"`
+	// Delete are the records to delete.
+	// +optional
+	Delete []*endpoint.Endpoint `json:"delete,omitempty"`
+}
+
+// PendingChangeStatus defines the observed state of PendingChange
+type PendingChangeStatus struct {
+	// Phase is Pending, Applied or Superseded.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// AppliedAt is the time the changes were applied.
+	// +optional
+	AppliedAt *metav1.Time `json:"appliedAt,omitempty"`
+}
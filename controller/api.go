@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/tokenreview"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// syncState is the state computed by the last synchronization.
+type syncState struct {
+	updatedAt   time.Time
+	desired     []*endpoint.Endpoint
+	actual      []*endpoint.Endpoint
+	changes     *plan.Changes
+	skipped     []plan.Skipped
+	annotations []plan.Annotation
+}
+
+// endpointsResponse is returned by the desired and actual endpoints of the API.
+type endpointsResponse struct {
+	UpdatedAt time.Time            `json:"updatedAt"`
+	Endpoints []*endpoint.Endpoint `json:"endpoints"`
+}
+
+// planResponse is returned by the plan endpoint of the API.
+type planResponse struct {
+	UpdatedAt time.Time     `json:"updatedAt"`
+	Changes   *plan.Changes `json:"changes"`
+	// Skipped lists the desired endpoints and the changes left out of the plan, along with the reason why
+	Skipped []plan.Skipped `json:"skipped,omitempty"`
+	// Annotations lists the messages policy rules attached to the changes, which are applied nonetheless
+	Annotations []plan.Annotation `json:"annotations,omitempty"`
+	// Paused is set when the changes are not applied because synchronization is paused
+	Paused bool `json:"paused"`
+}
+
+// pauseResponse is returned by the pause and resume endpoints of the API.
+type pauseResponse struct {
+	Paused bool `json:"paused"`
+}
+
+// recordState stores the state of a synchronization for the API.
+func (c *Controller) recordState(p *plan.Plan) {
+	c.stateMutex.Lock()
+	defer c.stateMutex.Unlock()
+	c.state = &syncState{
+		updatedAt:   time.Now(),
+		desired:     p.Desired,
+		actual:      p.Current,
+		changes:     p.Changes,
+		skipped:     p.Skipped,
+		annotations: p.Annotations,
+	}
+}
+
+func (c *Controller) lastState() *syncState {
+	c.stateMutex.RLock()
+	defer c.stateMutex.RUnlock()
+	return c.state
+}
+
+// APIHandler returns a handler serving the state of the last synchronization as JSON:
+// /api/v1/desired returns the endpoints computed from the sources, /api/v1/actual the records
+// returned by the registry and /api/v1/plan the changes to get from the latter to the former,
+// along with the endpoints and changes left out of them. The desired and actual endpoints are restricted to the
+// ones carrying the resource labels given as label=key=value query parameters, see --registry-resource-labels.
+// POST requests to /api/v1/pause and /api/v1/resume pause and resume applying changes, see Pause.
+// Requests must carry a bearer token accepted by auth, one allowed to write for the POST requests.
+func (c *Controller) APIHandler(auth APIAuth) http.Handler {
+	read := func(handler http.HandlerFunc) http.Handler { return requireBearerToken(auth, false, handler) }
+	write := func(handler http.HandlerFunc) http.Handler { return requireBearerToken(auth, true, handler) }
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/desired", read(func(w http.ResponseWriter, r *http.Request) {
+		c.serveEndpoints(w, r, func(s *syncState) []*endpoint.Endpoint { return s.desired })
+	}))
+	mux.Handle("GET /api/v1/actual", read(func(w http.ResponseWriter, r *http.Request) {
+		c.serveEndpoints(w, r, func(s *syncState) []*endpoint.Endpoint { return s.actual })
+	}))
+	mux.Handle("GET /api/v1/plan", read(func(w http.ResponseWriter, _ *http.Request) {
+		c.serveState(w, func(s *syncState) any {
+			return planResponse{UpdatedAt: s.updatedAt, Changes: s.changes, Skipped: s.skipped, Annotations: s.annotations, Paused: c.Paused()}
+		})
+	}))
+	mux.Handle("GET /api/v1/pause", read(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, pauseResponse{Paused: c.Paused()})
+	}))
+	mux.Handle("POST /api/v1/pause", write(func(w http.ResponseWriter, _ *http.Request) {
+		c.Pause()
+		writeJSON(w, pauseResponse{Paused: true})
+	}))
+	mux.Handle("POST /api/v1/resume", write(func(w http.ResponseWriter, _ *http.Request) {
+		c.Resume()
+		writeJSON(w, pauseResponse{Paused: false})
+	}))
+	return mux
+}
+
+// serveEndpoints serves the endpoints of the last synchronization returned by endpoints, keeping the ones which
+// carry all the resource labels given as label query parameters.
+func (c *Controller) serveEndpoints(w http.ResponseWriter, r *http.Request, endpoints func(*syncState) []*endpoint.Endpoint) {
+	selector := map[string]string{}
+	for _, label := range r.URL.Query()["label"] {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			http.Error(w, fmt.Sprintf("invalid label %q, expected key=value", label), http.StatusBadRequest)
+			return
+		}
+		selector[endpoint.ResourceLabelPrefix+key] = value
+	}
+	c.serveState(w, func(s *syncState) any {
+		eps := endpoints(s)
+		if len(selector) > 0 {
+			eps = slices.DeleteFunc(slices.Clone(eps), func(ep *endpoint.Endpoint) bool {
+				for key, value := range selector {
+					if v, ok := ep.Labels[key]; !ok || v != value {
+						return true
+					}
+				}
+				return false
+			})
+		}
+		return endpointsResponse{UpdatedAt: s.updatedAt, Endpoints: eps}
+	})
+}
+
+func (c *Controller) serveState(w http.ResponseWriter, response func(*syncState) any) {
+	s := c.lastState()
+	if s == nil {
+		http.Error(w, "no synchronization has completed yet", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, response(s))
+}
+
+func writeJSON(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Errorf("Failed to encode API response: %v", err)
+	}
+}
+
+// TokenAuthenticator authenticates bearer tokens, such as service account tokens with TokenReview.
+type TokenAuthenticator interface {
+	// Authenticate returns the user of the token, or an error wrapping tokenreview.ErrUnauthorized if the token
+	// isn't accepted.
+	Authenticate(ctx context.Context, token string) (string, error)
+}
+
+// APIAuth holds the bearer tokens accepted by the API. All of them may read the state of the last synchronization,
+// while only WriteToken and the tokens of Writers may change it, such as pausing synchronization.
+type APIAuth struct {
+	// Token is the static token allowed to read
+	Token string
+	// WriteToken is the static token allowed to read and write
+	WriteToken string
+	// Tokens authenticates the other tokens if set, which are allowed to read
+	Tokens TokenAuthenticator
+	// Writers are the users authenticated by Tokens which are also allowed to write
+	Writers []string
+}
+
+// errForbidden is returned for the tokens which are accepted, but aren't allowed to write.
+var errForbidden = errors.New("forbidden")
+
+// authenticate returns the user of the provided bearer token, empty for the static tokens, or an error wrapping
+// tokenreview.ErrUnauthorized if it isn't accepted, or errForbidden if write is set and it isn't allowed to write.
+func (a APIAuth) authenticate(ctx context.Context, provided string, write bool) (string, error) {
+	if provided == "" {
+		return "", tokenreview.ErrUnauthorized
+	}
+	if matchesToken(provided, a.WriteToken) {
+		return "", nil
+	}
+	if matchesToken(provided, a.Token) {
+		if write {
+			return "", errForbidden
+		}
+		return "", nil
+	}
+	if a.Tokens == nil {
+		return "", tokenreview.ErrUnauthorized
+	}
+	user, err := a.Tokens.Authenticate(ctx, provided)
+	if err == nil && write && !slices.Contains(a.Writers, user) {
+		return user, errForbidden
+	}
+	return user, err
+}
+
+func matchesToken(provided, token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// requireBearerToken rejects requests which don't carry a bearer token accepted by auth, or one allowed to write
+// if write is set.
+func requireBearerToken(auth APIAuth, write bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			provided = ""
+		}
+		user, err := auth.authenticate(r.Context(), provided, write)
+		switch {
+		case err == nil:
+			if user != "" {
+				log.Debugf("API request %s %s by %s", r.Method, r.URL.Path, user)
+			}
+			next.ServeHTTP(w, r)
+		case errors.Is(err, errForbidden):
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case !errors.Is(err, tokenreview.ErrUnauthorized):
+			log.Warnf("Failed to authenticate API request: %v", err)
+			http.Error(w, "failed to authenticate", http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}
+	})
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/tokenreview"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestAPIHandler(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+	handler := ctrl.APIHandler(APIAuth{Token: "secret"})
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, get("/api/v1/plan", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, get("/api/v1/plan", "wrong").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, get("/api/v1/plan", "secret").Code)
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	var desired, actual struct {
+		Endpoints []*endpoint.Endpoint `json:"endpoints"`
+	}
+	rec := get("/api/v1/desired", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &desired))
+	require.Len(t, desired.Endpoints, 1)
+	assert.Equal(t, "new.example.com", desired.Endpoints[0].DNSName)
+
+	rec = get("/api/v1/actual", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+	require.Len(t, actual.Endpoints, 1)
+	assert.Equal(t, "old.example.com", actual.Endpoints[0].DNSName)
+
+	var planned struct {
+		Changes plan.Changes `json:"changes"`
+	}
+	rec = get("/api/v1/plan", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &planned))
+	require.Len(t, planned.Changes.Create, 1)
+	assert.Equal(t, "new.example.com", planned.Changes.Create[0].DNSName)
+	require.Len(t, planned.Changes.Delete, 1)
+	assert.Equal(t, "old.example.com", planned.Changes.Delete[0].DNSName)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, func() int {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/plan", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}())
+}
+
+func TestAPIHandlerResourceLabels(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("pay.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel("label/team", "payments").
+			WithLabel("label/environment", "production"),
+		endpoint.NewEndpoint("pay-staging.example.com", endpoint.RecordTypeA, "1.2.3.5").
+			WithLabel("label/team", "payments").
+			WithLabel("label/environment", "staging"),
+		endpoint.NewEndpoint("shop.example.com", endpoint.RecordTypeA, "1.2.3.6").
+			WithLabel("label/team", "checkout"),
+	}, nil)
+	r, err := registry.NewNoopRegistry(&filteredMockProvider{})
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+	handler := ctrl.APIHandler(APIAuth{Token: "secret"})
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	get := func(query string) ([]string, int) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/desired"+query, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		var desired struct {
+			Endpoints []*endpoint.Endpoint `json:"endpoints"`
+		}
+		if rec.Code != http.StatusOK {
+			return nil, rec.Code
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &desired))
+		var names []string
+		for _, ep := range desired.Endpoints {
+			names = append(names, ep.DNSName)
+		}
+		return names, rec.Code
+	}
+
+	names, _ := get("")
+	assert.Len(t, names, 3)
+	names, _ = get("?label=team=payments")
+	assert.ElementsMatch(t, []string{"pay.example.com", "pay-staging.example.com"}, names)
+	names, _ = get("?label=team=payments&label=environment=staging")
+	assert.Equal(t, []string{"pay-staging.example.com"}, names)
+	names, _ = get("?label=team=platform")
+	assert.Empty(t, names)
+	_, code := get("?label=team")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	names, _ = get("")
+	assert.Len(t, names, 3, "filtering doesn't alter the state")
+}
+
+func TestAPIHandlerSkipped(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeCNAME, "lb.example.com"),
+	}, nil)
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.UpsertOnlyPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/plan", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	ctrl.APIHandler(APIAuth{Token: "secret"}).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var planned struct {
+		Skipped []plan.Skipped `json:"skipped"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &planned))
+	require.Len(t, planned.Skipped, 2)
+	assert.Equal(t, "new.example.com", planned.Skipped[0].Endpoint.DNSName)
+	assert.Equal(t, plan.SkipReasonRecordType, planned.Skipped[0].Reason)
+	assert.Empty(t, planned.Skipped[0].Action)
+	assert.Equal(t, "old.example.com", planned.Skipped[1].Endpoint.DNSName)
+	assert.Equal(t, plan.SkipReasonPolicy, planned.Skipped[1].Reason)
+	assert.Equal(t, plan.ActionDelete, planned.Skipped[1].Action)
+}
+
+func TestAPIHandlerPause(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+	handler := ctrl.APIHandler(APIAuth{WriteToken: "secret"})
+
+	call := func(method, path string) map[string]any {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return response
+	}
+
+	assert.Equal(t, false, call(http.MethodGet, "/api/v1/pause")["paused"])
+	assert.Equal(t, true, call(http.MethodPost, "/api/v1/pause")["paused"])
+	assert.True(t, ctrl.Paused())
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Empty(t, p.ApplyChangesCalls, "changes must not be applied while paused")
+	planned := call(http.MethodGet, "/api/v1/plan")
+	assert.Equal(t, true, planned["paused"])
+	assert.NotEmpty(t, planned["changes"])
+
+	assert.Equal(t, false, call(http.MethodPost, "/api/v1/resume")["paused"])
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 1)
+}
+
+type fakeTokenAuthenticator map[string]error
+
+func (f fakeTokenAuthenticator) Authenticate(_ context.Context, token string) (string, error) {
+	err, ok := f[token]
+	if !ok {
+		return "", tokenreview.ErrUnauthorized
+	}
+	return "system:serviceaccount:monitoring:" + token, err
+}
+
+func TestAPIHandlerTokenAuthenticator(t *testing.T) {
+	ctrl := &Controller{}
+	tokens := fakeTokenAuthenticator{"reader": nil, "unavailable": errors.New("apiserver unavailable")}
+
+	status := func(handler http.Handler, token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pause", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	handler := ctrl.APIHandler(APIAuth{Token: "secret", Tokens: tokens})
+	assert.Equal(t, http.StatusOK, status(handler, "secret"))
+	assert.Equal(t, http.StatusOK, status(handler, "reader"))
+	assert.Equal(t, http.StatusUnauthorized, status(handler, "other"))
+	assert.Equal(t, http.StatusUnauthorized, status(handler, ""))
+	assert.Equal(t, http.StatusServiceUnavailable, status(handler, "unavailable"))
+
+	// without a static token, only the authenticated tokens are accepted
+	handler = ctrl.APIHandler(APIAuth{Tokens: tokens})
+	assert.Equal(t, http.StatusOK, status(handler, "reader"))
+	assert.Equal(t, http.StatusUnauthorized, status(handler, "secret"))
+	assert.Equal(t, http.StatusUnauthorized, status(handler, ""))
+}
+
+func TestAPIHandlerWriteAuthorization(t *testing.T) {
+	ctrl := &Controller{}
+	handler := ctrl.APIHandler(APIAuth{
+		Token:      "read-secret",
+		WriteToken: "write-secret",
+		Tokens:     fakeTokenAuthenticator{"reader": nil, "deployer": nil},
+		Writers:    []string{"system:serviceaccount:monitoring:deployer"},
+	})
+
+	status := func(method, path, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for _, token := range []string{"read-secret", "write-secret", "reader", "deployer"} {
+		assert.Equal(t, http.StatusOK, status(http.MethodGet, "/api/v1/pause", token), token)
+	}
+	assert.Equal(t, http.StatusForbidden, status(http.MethodPost, "/api/v1/pause", "read-secret"))
+	assert.Equal(t, http.StatusForbidden, status(http.MethodPost, "/api/v1/pause", "reader"))
+	assert.False(t, ctrl.Paused())
+	assert.Equal(t, http.StatusUnauthorized, status(http.MethodPost, "/api/v1/pause", "other"))
+
+	assert.Equal(t, http.StatusOK, status(http.MethodPost, "/api/v1/pause", "deployer"))
+	assert.True(t, ctrl.Paused())
+	assert.Equal(t, http.StatusForbidden, status(http.MethodPost, "/api/v1/resume", "reader"))
+	assert.Equal(t, http.StatusOK, status(http.MethodPost, "/api/v1/resume", "write-secret"))
+	assert.False(t, ctrl.Paused())
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/audit"
+	"sigs.k8s.io/external-dns/provider/aws"
+)
+
+// auditStore returns the store of the hash-chained audit log: the --audit-log file, or the objects of
+// --audit-log-s3-bucket.
+func auditStore(cfg *externaldns.Config) audit.Store {
+	if cfg.AuditLogS3Bucket != "" {
+		return audit.NewS3Store(s3.NewFromConfig(aws.CreateDefaultV2Config(cfg)), cfg.AuditLogS3Bucket, cfg.AuditLogS3Prefix)
+	}
+	return audit.NewFileStore(cfg.AuditLog)
+}
+
+// VerifyAuditLog verifies the hash chain of the audit log in the store, and writes the number of chained entries
+// and the head, which must match the last head ExternalDNS logged for no entry to have been removed at the end.
+func VerifyAuditLog(ctx context.Context, w io.Writer, store audit.Store) error {
+	count, head, err := audit.Verify(ctx, store)
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		_, err = fmt.Fprintln(w, "No chained entries.")
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Verified %d chained entries.\nHead: entry %d with hash %s, written at %s\n",
+		count, head.Sequence, head.Hash, head.Time.Format("2006-01-02T15:04:05Z07:00"))
+	return err
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/audit"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestVerifyAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	store := audit.NewFileStore(path)
+
+	var buf bytes.Buffer
+	require.NoError(t, VerifyAuditLog(context.Background(), &buf, store))
+	assert.Equal(t, "No chained entries.\n", buf.String())
+
+	logger, err := audit.NewChainLogger(context.Background(), store, "owner", false)
+	require.NoError(t, err)
+	logger.Log(&plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "192.0.2.2"),
+	}})
+
+	buf.Reset()
+	require.NoError(t, VerifyAuditLog(context.Background(), &buf, store))
+	assert.Contains(t, buf.String(), "Verified 2 chained entries.\nHead: entry 2 with hash ")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), "192.0.2.2", "198.51.100.2", 1)), 0o600))
+	require.ErrorIs(t, VerifyAuditLog(context.Background(), &buf, store), audit.ErrBrokenChain)
+}
@@ -20,21 +20,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/approval"
+	"sigs.k8s.io/external-dns/pkg/audit"
 	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/inventory"
 	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/notify"
+	"sigs.k8s.io/external-dns/pkg/planpolicy"
+	"sigs.k8s.io/external-dns/pkg/publish"
+	"sigs.k8s.io/external-dns/pkg/status"
+	"sigs.k8s.io/external-dns/pkg/syncstatus"
+	"sigs.k8s.io/external-dns/pkg/tracing"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/registry"
 	"sigs.k8s.io/external-dns/source"
 )
 
+// defaultEventDebounce is the delay between an event and the synchronization it triggers if none is configured.
+const defaultEventDebounce = 5 * time.Second
+
 var (
 	registryErrorsTotal = metrics.NewCounterWithOpts(
 		prometheus.CounterOpts{
@@ -127,6 +144,57 @@ var (
 		[]string{"record_type"},
 	)
 
+	plannedChanges = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "planned_changes",
+			Help:      "Number of changes planned by the last synchronization, by zone, record type and action (vector).",
+		},
+		[]string{"zone", "record_type", "action"},
+	)
+
+	appliedChangesTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "applied_changes_total",
+			Help:      "Number of changes applied, by zone, record type and action (vector).",
+		},
+		[]string{"zone", "record_type", "action"},
+	)
+
+	deferredChangesTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "deferred_changes_total",
+			Help:      "Number of changes deferred to later synchronizations by the per-zone change budget, by zone (vector).",
+		},
+		[]string{"zone"},
+	)
+
+	changeRetriesTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "change_retries_total",
+			Help:      "Number of retried attempts to apply a subset of failed changes.",
+		},
+	)
+
+	controllerPaused = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "paused",
+			Help:      "Whether applying changes is paused (1) or not (0).",
+		},
+	)
+
+	syncTimeoutsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "sync_timeouts_total",
+			Help:      "Number of synchronizations cancelled because they exceeded the sync timeout.",
+		},
+	)
+
 	consecutiveSoftErrors = metrics.NewGaugeWithOpts(
 		prometheus.GaugeOpts{
 			Subsystem: "controller",
@@ -146,6 +214,12 @@ func init() {
 	metrics.RegisterMetric.MustRegister(deprecatedRegistryErrors)
 	metrics.RegisterMetric.MustRegister(deprecatedSourceErrors)
 	metrics.RegisterMetric.MustRegister(controllerNoChangesTotal)
+	metrics.RegisterMetric.MustRegister(plannedChanges)
+	metrics.RegisterMetric.MustRegister(appliedChangesTotal)
+	metrics.RegisterMetric.MustRegister(deferredChangesTotal)
+	metrics.RegisterMetric.MustRegister(changeRetriesTotal)
+	metrics.RegisterMetric.MustRegister(controllerPaused)
+	metrics.RegisterMetric.MustRegister(syncTimeoutsTotal)
 
 	metrics.RegisterMetric.MustRegister(registryRecords)
 	metrics.RegisterMetric.MustRegister(sourceRecords)
@@ -165,6 +239,12 @@ type Controller struct {
 	Registry registry.Registry
 	// The policy that defines which change to DNS records is allowed
 	Policy plan.Policy
+	// PolicyEvaluator, if set, evaluates policy rules over the calculated changes, which may deny or annotate them
+	PolicyEvaluator planpolicy.Evaluator
+	// The ConflictResolver decides which resource acquires a DNS name requested by several resources
+	ConflictResolver plan.ConflictResolver
+	// SourceGroup identifies the sources of this instance among several sharing the same owner
+	SourceGroup string
 	// The interval between individual synchronizations
 	Interval time.Duration
 	// The DomainFilter defines which DNS records to keep or exclude
@@ -180,39 +260,306 @@ type Controller struct {
 	ManagedRecordTypes []string
 	// ExcludeRecordTypes are DNS record types that will be excluded from management.
 	ExcludeRecordTypes []string
+	// WildcardPolicy decides whether wildcard records are published, rejected or published under WildcardPlaceholder
+	WildcardPolicy string
+	// WildcardPlaceholder is the label replacing the "*" label of wildcard records with the replace policy
+	WildcardPlaceholder string
 	// MinEventSyncInterval is used as a window for batching events
 	MinEventSyncInterval time.Duration
+	// EventDebounce is the delay between an event and the synchronization it triggers, during which further
+	// events are coalesced. Defaults to 5 seconds when not set.
+	EventDebounce time.Duration
+	// EventJitter adds a random delay of up to this duration to each event triggered synchronization
+	EventJitter time.Duration
+	// EventMaxBackoff enables backing off under continuous churn: the minimum spacing of event triggered
+	// synchronizations doubles with each consecutive one, up to this duration
+	EventMaxBackoff time.Duration
+	// EventCoalesceWindow replaces EventDebounce with a delay which restarts with every event, so that a burst of
+	// events triggers a single synchronization once it settles, no later than the periodic synchronization
+	EventCoalesceWindow time.Duration
+	// eventPending is set when an event scheduled the next synchronization
+	eventPending bool
+	// eventBackoff is the current minimum spacing of event triggered synchronizations
+	eventBackoff time.Duration
+	// state is the state computed by the last synchronization, served by the API
+	state *syncState
+	// stateMutex protects state
+	stateMutex sync.RWMutex
+	// ZoneBatching applies the changes of each zone independently, so that a failing zone doesn't block the others
+	ZoneBatching bool
+	// ZoneConcurrency is the number of zones whose changes are applied concurrently with zone batching
+	ZoneConcurrency int
+	// ChangeRetries is the number of times changes which failed to apply are split and retried within the interval
+	ChangeRetries int
+	// ChangeRetryBackoff is the delay before the first retry, doubled for each further retry
+	ChangeRetryBackoff time.Duration
+	// DrainTimeout is how long a synchronization in progress may continue after Run's context is cancelled
+	DrainTimeout time.Duration
+	// FinalSync runs one last synchronization within DrainTimeout when Run's context is cancelled
+	FinalSync bool
+	// SyncTimeout bounds each synchronization run by Run, after which it is cancelled
+	SyncTimeout time.Duration
+	// IncrementalSync reuses the records left by the previous synchronization instead of fetching them from the
+	// registry, and only plans the DNS names whose desired endpoints changed since. The sources are still listed
+	// in full, and their endpoints diffed with those of the previous synchronization
+	IncrementalSync bool
+	// StreamRecords fetches only the records of the desired DNS names and the records owned by this instance from
+	// registries supporting it, streaming the other records of the provider rather than holding them in memory.
+	// Synchronizations using the records of the previous one with IncrementalSync are unaffected
+	StreamRecords bool
+	// FullResyncInterval is the interval between full synchronizations with IncrementalSync
+	FullResyncInterval time.Duration
+	// lastSync is the outcome of the previous successful synchronization, kept with IncrementalSync
+	lastSync *syncedRecords
+	// paused is set while applying changes is paused
+	paused atomic.Bool
+	// StatusWriter, if set, reports back onto the source resources that their records were applied
+	StatusWriter status.Writer
+	// Inventory, if set, publishes the records managed by this instance after each synchronization
+	Inventory inventory.Writer
+	// Audit, if set, records every change applied
+	Audit audit.Logger
+	// Publisher, if set, publishes the applied and failed changes to a message broker
+	Publisher publish.Publisher
+	// Notifier, if set, is notified after each synchronization which applied changes or failed
+	Notifier notify.Notifier
+	// Approval, if set, holds back the changes of plans needing approval until they're approved
+	Approval approval.Gate
+	// SyncStatus, if set, reports the outcome of each synchronization
+	SyncStatus syncstatus.Reporter
+	// MaxChangesPerZone, if positive, is the number of changes applied to any single zone by one synchronization,
+	// the others being deferred to later ones
+	MaxChangesPerZone int
+	// FastSyncInterval, if positive, is the interval at which the desired TXT records of ACME challenges are checked,
+	// the DNS names whose records changed being reconciled right away rather than at the next synchronization
+	FastSyncInterval time.Duration
+	// FastSyncResources are patterns of resources, in kind/namespace/name format, whose TXT records are reconciled by
+	// the fast synchronization as well
+	FastSyncResources []string
+	// nextFastSyncAt is the time of the next fast synchronization
+	nextFastSyncAt time.Time
+	// fastSynced are the endpoints reconciled by the last fast or successful synchronization
+	fastSynced []*endpoint.Endpoint
+	// applied collects the changes applied by the synchronization in progress for the Notifier
+	applied plan.Changes
+	// appliedMutex protects applied
+	appliedMutex sync.Mutex
+	// health tracks the last successful synchronization of each zone
+	health syncHealth
+	// pendingSettings are the settings passed to Reload, applied before the next synchronization
+	pendingSettings atomic.Pointer[Settings]
+	// pendingRegistry is the registry passed to ReloadRegistry, replacing Registry before the next synchronization
+	pendingRegistry atomic.Pointer[registry.Registry]
 }
 
 // RunOnce runs a single iteration of a reconciliation loop.
-func (c *Controller) RunOnce(ctx context.Context) error {
+func (c *Controller) RunOnce(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "sync")
+	var report syncstatus.Report
+	defer func() {
+		c.notify(ctx, err)
+		c.reportStatus(ctx, &report, err)
+		tracing.End(span, err)
+	}()
+
 	lastReconcileTimestamp.Gauge.SetToCurrentTime()
 
 	c.runAtMutex.Lock()
 	c.lastRunAt = time.Now()
 	c.runAtMutex.Unlock()
 
-	regMetrics := newMetricsRecorder()
-
-	regRecords, err := c.Registry.Records(ctx)
+	previous := c.previousSync(time.Now())
+	plan, err := c.calculatePlan(ctx, previous)
 	if err != nil {
-		registryErrorsTotal.Counter.Inc()
-		deprecatedRegistryErrors.Counter.Inc()
+		c.forgetSync()
 		return err
 	}
+	c.recordState(plan)
+	emitRejectedEvents(c.EventEmitter, plan.Skipped)
+	plannedChanges.Gauge.Reset()
+	for key, count := range countChanges(plan.Changes, c.zones()) {
+		plannedChanges.SetWithLabels(float64(count), key.zone, key.recordType, key.action)
+	}
 
-	registryEndpointsTotal.Gauge.Set(float64(len(regRecords)))
+	if c.Paused() {
+		if plan.Changes.HasChanges() {
+			log.Infof("Synchronization is paused, not applying %d creations, %d updates and %d deletions",
+				len(plan.Changes.Create), len(plan.Changes.UpdateNew), len(plan.Changes.Delete))
+		}
+		report.Records = c.ownedRecords(plan.Current)
+		report.Pending, report.PendingReason = countPending(plan.Changes), syncstatus.ReasonPaused
+		return nil
+	}
 
-	countAddressRecords(regMetrics, regRecords, registryRecords)
+	var partial bool
+	if c.MaxChangesPerZone > 0 && plan.Changes.HasChanges() {
+		limited, deferred := plan.Changes.LimitPerZone(c.zones(), c.MaxChangesPerZone)
+		for zone, count := range deferred {
+			log.Warnf("Deferring %d changes of zone %q to later synchronizations, as they exceed the budget of %d changes per zone",
+				count, zone, c.MaxChangesPerZone)
+			deferredChangesTotal.CounterVec.WithLabelValues(zone).Add(float64(count))
+			report.Pending += count
+			report.PendingReason = syncstatus.ReasonDeferred
+			partial = true
+		}
+		plan.Changes = limited
+	}
+
+	if c.Approval != nil && plan.Changes.HasChanges() {
+		approved, err := c.Approval.Approved(ctx, plan.Changes)
+		if err != nil {
+			c.forgetSync()
+			return err
+		}
+		if !approved {
+			log.Infof("Waiting for approval, not applying %d creations, %d updates and %d deletions",
+				len(plan.Changes.Create), len(plan.Changes.UpdateNew), len(plan.Changes.Delete))
+			report.Records = c.ownedRecords(plan.Current)
+			report.Pending, report.PendingReason = report.Pending+countPending(plan.Changes), syncstatus.ReasonAwaitingApproval
+			return nil
+		}
+	}
 
-	ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
+	ctx = context.WithValue(ctx, provider.RecordsContextKey, plan.Current)
+	if plan.Changes.HasChanges() {
+		if err := c.applyChanges(ctx, plan.Changes, c.DomainFilter, c.Registry.GetDomainFilter()); err != nil {
+			c.forgetSync()
+			return err
+		}
+		if c.Approval != nil {
+			c.Approval.Applied(ctx, plan.Changes)
+		}
+	} else {
+		controllerNoChangesTotal.Counter.Inc()
+		log.Info("All records are already up to date")
+	}
+	if partial {
+		// the deferred changes are only planned again by a full synchronization
+		c.forgetSync()
+	} else {
+		c.rememberSync(plan, previous)
+		c.fastSynced = c.fastEndpoints(plan.Desired)
+	}
+	c.health.synced(time.Now(), true, c.zones()...)
+	report.Records = c.managedRecords(plan)
+	if c.Inventory != nil {
+		c.Inventory.Write(ctx, report.Records)
+	}
+
+	lastSyncTimestamp.Gauge.SetToCurrentTime()
 
+	return nil
+}
+
+// Pause stops applying changes: synchronizations still calculate the plan, which the API serves, but
+// leave the DNS records untouched until Resume is called.
+func (c *Controller) Pause() {
+	if !c.paused.Swap(true) {
+		log.Warn("Pausing synchronization, changes won't be applied until resumed")
+	}
+	controllerPaused.Gauge.Set(1)
+}
+
+// Resume applies changes again from the next synchronization on.
+func (c *Controller) Resume() {
+	if c.paused.Swap(false) {
+		log.Info("Resuming synchronization")
+	}
+	controllerPaused.Gauge.Set(0)
+}
+
+// Paused returns whether applying changes is paused.
+func (c *Controller) Paused() bool {
+	return c.paused.Load()
+}
+
+// Plan fetches the current records from the registry and the desired endpoints from the source, and
+// returns the calculated plan without applying it.
+func (c *Controller) Plan(ctx context.Context) (*plan.Plan, error) {
+	return c.calculatePlan(ctx, nil)
+}
+
+// registryRecords fetches the current records from the registry with the given function, in canonical form.
+func (c *Controller) registryRecords(ctx context.Context, records func(context.Context) ([]*endpoint.Endpoint, error)) ([]*endpoint.Endpoint, error) {
+	ctx, span := tracing.Start(ctx, "registry.records")
+	regRecords, err := records(ctx)
+	span.SetAttributes(attribute.Int("records", len(regRecords)))
+	tracing.End(span, err)
+	if err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		return nil, err
+	}
+	return endpoint.Canonicalize(regRecords), nil
+}
+
+// desiredEndpoints fetches the endpoints from the source, returning them in canonical form as well as adjusted by
+// the registry.
+func (c *Controller) desiredEndpoints(ctx context.Context) ([]*endpoint.Endpoint, []*endpoint.Endpoint, error) {
+	ctx, span := tracing.Start(ctx, "source.endpoints")
 	sourceEndpoints, err := c.Source.Endpoints(ctx)
+	span.SetAttributes(attribute.Int("endpoints", len(sourceEndpoints)))
+	tracing.End(span, err)
 	if err != nil {
 		sourceErrorsTotal.Counter.Inc()
 		deprecatedSourceErrors.Counter.Inc()
-		return err
+		return nil, nil, err
+	}
+
+	sourceEndpoints = endpoint.Canonicalize(sourceEndpoints)
+	endpoints, err := c.Registry.AdjustEndpoints(sourceEndpoints)
+	if err != nil {
+		return nil, nil, fmt.Errorf("adjusting endpoints: %w", err)
 	}
+	return sourceEndpoints, endpoints, nil
+}
+
+// endpointNames returns the distinct DNS names of the endpoints.
+func endpointNames(endpoints []*endpoint.Endpoint) []string {
+	seen := make(map[string]bool, len(endpoints))
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if name := normalizeName(ep.DNSName); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// calculatePlan calculates the plan. Given a previous synchronization, the records it left are used instead
+// of fetching them from the registry, and only the DNS names whose desired endpoints changed since are planned.
+func (c *Controller) calculatePlan(ctx context.Context, previous *syncedRecords) (*plan.Plan, error) {
+	var (
+		regRecords, sourceEndpoints, endpoints []*endpoint.Endpoint
+		err                                    error
+	)
+	if scoped, ok := c.Registry.(registry.ScopedRegistry); ok && c.StreamRecords && previous == nil {
+		// The desired endpoints come first, so that only the records they need are fetched from the registry.
+		if sourceEndpoints, endpoints, err = c.desiredEndpoints(ctx); err != nil {
+			return nil, err
+		}
+		if regRecords, err = c.registryRecords(ctx, func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+			return scoped.ScopedRecords(ctx, endpointNames(endpoints))
+		}); err != nil {
+			return nil, err
+		}
+	} else {
+		if previous != nil {
+			regRecords = previous.current
+		} else if regRecords, err = c.registryRecords(ctx, c.Registry.Records); err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
+		if sourceEndpoints, endpoints, err = c.desiredEndpoints(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	registryEndpointsTotal.Gauge.Set(float64(len(regRecords)))
+
+	regMetrics := newMetricsRecorder()
+	countAddressRecords(regMetrics, regRecords, registryRecords)
 
 	sourceEndpointsTotal.Gauge.Set(float64(len(sourceEndpoints)))
 
@@ -222,41 +569,249 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	vaMetrics := newMetricsRecorder()
 	countMatchingAddressRecords(vaMetrics, sourceEndpoints, regRecords, verifiedRecords)
 
-	endpoints, err := c.Registry.AdjustEndpoints(sourceEndpoints)
+	current, desired := regRecords, endpoints
+	if previous != nil {
+		changed := changedNames(previous.desired, endpoints)
+		log.Debugf("Incremental synchronization of %d DNS names whose desired endpoints changed", len(changed))
+		current, desired = filterNames(regRecords, changed), filterNames(endpoints, changed)
+	}
+
+	plan, err := c.plan(ctx, current, desired)
 	if err != nil {
-		return fmt.Errorf("adjusting endpoints: %w", err)
+		return nil, err
 	}
-	registryFilter := c.Registry.GetDomainFilter()
+	plan.Current, plan.Desired = regRecords, endpoints
+	return plan, nil
+}
 
-	plan := &plan.Plan{
-		Policies:       []plan.Policy{c.Policy},
-		Current:        regRecords,
-		Desired:        endpoints,
-		DomainFilter:   endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
-		ManagedRecords: c.ManagedRecordTypes,
-		ExcludeRecords: c.ExcludeRecordTypes,
-		OwnerID:        c.Registry.OwnerID(),
+// plan calculates the changes from the current records to the desired endpoints, and evaluates the policy rules
+// over them.
+func (c *Controller) plan(ctx context.Context, current, desired []*endpoint.Endpoint) (*plan.Plan, error) {
+	p := &plan.Plan{
+		Policies:            []plan.Policy{c.Policy},
+		Current:             current,
+		Desired:             desired,
+		DomainFilter:        endpoint.MatchAllDomainFilters{c.DomainFilter, c.Registry.GetDomainFilter()},
+		ManagedRecords:      c.ManagedRecordTypes,
+		ExcludeRecords:      c.ExcludeRecordTypes,
+		OwnerID:             c.Registry.OwnerID(),
+		ConflictResolver:    c.ConflictResolver,
+		SourceGroup:         c.SourceGroup,
+		ZoneApexes:          c.zones(),
+		WildcardPolicy:      c.WildcardPolicy,
+		WildcardPlaceholder: c.WildcardPlaceholder,
 	}
 
-	plan = plan.Calculate()
+	_, span := tracing.Start(ctx, "plan.calculate")
+	p = p.Calculate()
+	span.SetAttributes(
+		attribute.Int("creates", len(p.Changes.Create)),
+		attribute.Int("updates", len(p.Changes.UpdateNew)),
+		attribute.Int("deletes", len(p.Changes.Delete)),
+	)
+	span.End()
+	if c.PolicyEvaluator != nil {
+		if err := planpolicy.Apply(ctx, c.PolicyEvaluator, p, c.zones()); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
 
-	if plan.Changes.HasChanges() {
-		err = c.Registry.ApplyChanges(ctx, plan.Changes)
+// applyChanges applies the changes through the registry. With zone batching, the changes are split by the
+// zones known from the given domain filters and each zone is applied on its own, up to ZoneConcurrency zones
+// at a time; the errors of all failed zones are returned together once every zone has been tried.
+func (c *Controller) applyChanges(ctx context.Context, changes *plan.Changes, filters ...endpoint.DomainFilterInterface) error {
+	if !c.ZoneBatching {
+		return c.applyBatch(ctx, changes)
+	}
+	batches := changes.SplitByZone(zoneNames(filters...))
+	batchErrs := make([]error, len(batches))
+	var g errgroup.Group
+	g.SetLimit(max(c.ZoneConcurrency, 1))
+	for i, batch := range batches {
+		g.Go(func() error {
+			ctx, span := tracing.Start(ctx, "zone", attribute.String("zone", batch.Zone))
+			if err := c.applyBatch(ctx, batch.Changes); err != nil {
+				log.Errorf("Failed to apply changes for zone %q: %v", batch.Zone, err)
+				batchErrs[i] = err
+			}
+			tracing.End(span, batchErrs[i])
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	err := joinErrors(batchErrs)
+	if err != nil {
+		failed := map[string]bool{}
+		for i, batch := range batches {
+			failed[batch.Zone] = batchErrs[i] != nil
+		}
+		var synced []string
+		for _, zone := range c.zones() {
+			if !failed[zone] {
+				synced = append(synced, zone)
+			}
+		}
+		c.health.synced(time.Now(), false, synced...)
+	}
+	return err
+}
+
+// joinErrors joins the non-nil errors. The result is only a soft error if every error is soft.
+func joinErrors(allErrs []error) error {
+	var errs, hardErrs []error
+	for _, err := range allErrs {
+		if err == nil {
+			continue
+		}
+		errs = append(errs, err)
+		if !errors.Is(err, provider.SoftError) {
+			hardErrs = append(hardErrs, err)
+		}
+	}
+	switch {
+	case len(hardErrs) > 0:
+		errs = hardErrs
+	case len(errs) == 0:
+		return nil
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// failedChanges holds changes which failed to apply.
+type failedChanges struct {
+	changes *plan.Changes
+	err     error
+}
+
+// applyBatch applies the changes through the registry. With ChangeRetries, failed changes are split in halves
+// and retried with an exponential backoff, which isolates the changes the provider rejects, as long as the
+// next attempt starts within the interval.
+func (c *Controller) applyBatch(ctx context.Context, changes *plan.Changes) error {
+	failed := c.applySubsets(ctx, []*plan.Changes{changes})
+
+	start := time.Now()
+	backoff := c.ChangeRetryBackoff
+retry:
+	for attempt := 1; attempt <= c.ChangeRetries && len(failed) > 0; attempt++ {
+		if c.Interval > 0 && time.Since(start)+backoff >= c.Interval {
+			log.Warnf("Not retrying failed changes, as the next attempt would start after the interval")
+			break
+		}
+		log.Infof("Retrying %d failed change sets in %s (attempt %d of %d)", len(failed), backoff, attempt, c.ChangeRetries)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			break retry
+		}
+		backoff *= 2
+
+		var subsets []*plan.Changes
+		for _, f := range failed {
+			subsets = append(subsets, f.changes.Split()...)
+		}
+		changeRetriesTotal.Counter.Add(float64(len(subsets)))
+		failed = c.applySubsets(ctx, subsets)
+	}
+
+	errs := make([]error, 0, len(failed))
+	for _, f := range failed {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		emitFailedEvents(c.EventEmitter, *f.changes, f.err)
+		if c.Publisher != nil {
+			c.Publisher.Publish(ctx, f.changes, f.err)
+		}
+		errs = append(errs, f.err)
+	}
+	return joinErrors(errs)
+}
+
+// applySubsets applies each of the given changes through the registry and returns those which failed.
+func (c *Controller) applySubsets(ctx context.Context, subsets []*plan.Changes) []failedChanges {
+	var failed []failedChanges
+	for _, changes := range subsets {
+		applyCtx, span := tracing.Start(ctx, "registry.apply_changes",
+			attribute.Int("creates", len(changes.Create)),
+			attribute.Int("updates", len(changes.UpdateNew)),
+			attribute.Int("deletes", len(changes.Delete)),
+		)
+		err := c.Registry.ApplyChanges(applyCtx, changes)
+		tracing.End(span, err)
 		if err != nil {
-			registryErrorsTotal.Counter.Inc()
-			deprecatedRegistryErrors.Counter.Inc()
-			return err
-		} else {
-			emitChangeEvent(c.EventEmitter, *plan.Changes, events.RecordReady)
+			failed = append(failed, failedChanges{changes: changes, err: err})
+			continue
+		}
+		for key, count := range countChanges(changes, c.zones()) {
+			appliedChangesTotal.CounterVec.WithLabelValues(key.zone, key.recordType, key.action).Add(float64(count))
+		}
+		if c.Audit != nil {
+			c.Audit.Log(changes)
+		}
+		if c.Publisher != nil {
+			c.Publisher.Publish(ctx, changes, nil)
+		}
+		c.addApplied(changes)
+		emitChangeEvent(c.EventEmitter, *changes)
+		if c.StatusWriter != nil {
+			c.StatusWriter.Synced(ctx, syncedRefs(changes)...)
 		}
-	} else {
-		controllerNoChangesTotal.Counter.Inc()
-		log.Info("All records are already up to date")
 	}
+	return failed
+}
 
-	lastSyncTimestamp.Gauge.SetToCurrentTime()
+// managedRecords returns the records owned by this instance once the changes of the plan are applied.
+func (c *Controller) managedRecords(p *plan.Plan) []*endpoint.Endpoint {
+	return c.ownedRecords(applyToRecords(p.Current, p.Changes))
+}
 
-	return nil
+// ownedRecords returns the given records owned by this instance.
+func (c *Controller) ownedRecords(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+	ownerID := c.Registry.OwnerID()
+	owned := []*endpoint.Endpoint{}
+	for _, ep := range records {
+		if ownerID == "" || ep.IsOwnedBy(ownerID) {
+			owned = append(owned, ep)
+		}
+	}
+	return owned
+}
+
+// syncedRefs returns the references of the resources whose records were created or updated.
+func syncedRefs(changes *plan.Changes) []*events.ObjectReference {
+	var refs []*events.ObjectReference
+	for _, ep := range slices.Concat(changes.Create, changes.UpdateNew) {
+		if ref := ep.RefObject(); ref != nil {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// zoneNames returns the domains of the given domain filters, which for most providers are the managed zones. Glob
+// patterns are left out, as they don't name a zone.
+func zoneNames(filters ...endpoint.DomainFilterInterface) []string {
+	var zones []string
+	for _, f := range filters {
+		switch df := f.(type) {
+		case *endpoint.DomainFilter:
+			if df != nil {
+				for _, filter := range df.Filters {
+					if !endpoint.IsGlobDomain(filter) {
+						zones = append(zones, filter)
+					}
+				}
+			}
+		case endpoint.MatchAllDomainFilters:
+			zones = append(zones, zoneNames(df...)...)
+		}
+	}
+	return zones
 }
 
 func earliest(r time.Time, times ...time.Time) time.Time {
@@ -318,12 +873,26 @@ func countAddressRecords(rec *metricsRecorder, endpoints []*endpoint.Endpoint, m
 func (c *Controller) ScheduleRunOnce(now time.Time) {
 	c.runAtMutex.Lock()
 	defer c.runAtMutex.Unlock()
+	delay := c.EventDebounce
+	if delay <= 0 {
+		delay = defaultEventDebounce
+	}
+	if c.EventCoalesceWindow > 0 {
+		delay = c.EventCoalesceWindow
+	}
+	if c.EventJitter > 0 {
+		delay += rand.N(c.EventJitter)
+	}
+	// the first event schedules the synchronization, unless it's postponed by each event of a burst, up to the
+	// periodic synchronization
+	runAt := earliest(now.Add(delay), c.nextRunAt)
+	if c.EventCoalesceWindow > 0 {
+		runAt = earliest(now.Add(delay), c.lastRunAt.Add(c.Interval))
+	}
+	c.eventPending = true
 	c.nextRunAt = latest(
-		c.lastRunAt.Add(c.MinEventSyncInterval),
-		earliest(
-			now.Add(5*time.Second),
-			c.nextRunAt,
-		),
+		c.lastRunAt.Add(max(c.MinEventSyncInterval, c.eventBackoff)),
+		runAt,
 	)
 }
 
@@ -334,21 +903,43 @@ func (c *Controller) ShouldRunOnce(now time.Time) bool {
 		return false
 	}
 	c.nextRunAt = now.Add(c.Interval)
+	if !c.eventPending {
+		// a quiet period without events resets the backoff
+		c.eventBackoff = 0
+	} else if c.EventMaxBackoff > 0 {
+		c.eventBackoff = min(max(2*c.eventBackoff, c.MinEventSyncInterval), c.EventMaxBackoff)
+	}
+	c.eventPending = false
 	return true
 }
 
-// Run runs RunOnce in a loop with a delay until context is canceled
+// defaultFinalSyncTimeout bounds the final synchronization enabled by FinalSync without a DrainTimeout.
+const defaultFinalSyncTimeout = 30 * time.Second
+
+// Run runs RunOnce in a loop with a delay until context is canceled. With DrainTimeout, a synchronization in
+// progress is given that long to complete once the context is canceled, including the final synchronization
+// enabled by FinalSync, which is bounded by defaultFinalSyncTimeout without a DrainTimeout.
 func (c *Controller) Run(ctx context.Context) {
+	drainTimeout := c.DrainTimeout
+	if c.FinalSync && drainTimeout <= 0 {
+		log.Warnf("FinalSync is enabled without DrainTimeout, the final synchronization is bounded to %s", defaultFinalSyncTimeout)
+		drainTimeout = defaultFinalSyncTimeout
+	}
+	runCtx, cancelRun := drainContext(ctx, drainTimeout)
+	defer cancelRun()
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 	var softErrorCount int
 	for {
+		c.applySettings()
 		if c.ShouldRunOnce(time.Now()) {
-			if err := c.RunOnce(ctx); err != nil {
+			if err := c.runOnceWithTimeout(runCtx); err != nil {
 				if errors.Is(err, provider.SoftError) {
 					softErrorCount++
 					consecutiveSoftErrors.Gauge.Set(float64(softErrorCount))
 					log.Errorf("Failed to do run once: %v (consecutive soft errors: %d)", err, softErrorCount)
+				} else if ctx.Err() != nil {
+					log.Errorf("Synchronization interrupted by termination: %v", err)
 				} else {
 					log.Fatalf("Failed to do run once: %v", err)
 				}
@@ -359,12 +950,65 @@ func (c *Controller) Run(ctx context.Context) {
 				softErrorCount = 0
 				consecutiveSoftErrors.Gauge.Set(0)
 			}
+		} else if c.shouldFastSync(time.Now()) {
+			if err := c.FastSync(runCtx); err != nil {
+				log.Errorf("Failed to do fast synchronization: %v", err)
+			}
 		}
 		select {
 		case <-ticker.C:
 		case <-ctx.Done():
+			if c.FinalSync && runCtx.Err() == nil {
+				log.Info("Running a final synchronization before terminating")
+				if err := c.runOnceWithTimeout(runCtx); err != nil {
+					log.Errorf("Final synchronization failed: %v", err)
+				}
+			}
 			log.Info("Terminating main controller loop")
 			return
 		}
 	}
 }
+
+// errSyncTimeout is the cause of the cancellation of a synchronization exceeding SyncTimeout.
+var errSyncTimeout = errors.New("synchronization timed out")
+
+// runOnceWithTimeout runs RunOnce, cancelling it once SyncTimeout has elapsed. A synchronization which timed
+// out fails with a soft error, so that the loop carries on with a full synchronization at the next interval.
+func (c *Controller) runOnceWithTimeout(ctx context.Context) error {
+	if c.SyncTimeout <= 0 {
+		return c.RunOnce(ctx)
+	}
+	syncCtx, cancel := context.WithTimeoutCause(ctx, c.SyncTimeout, errSyncTimeout)
+	defer cancel()
+	err := c.RunOnce(syncCtx)
+	if err != nil && errors.Is(context.Cause(syncCtx), errSyncTimeout) {
+		syncTimeoutsTotal.Counter.Inc()
+		return provider.NewSoftErrorf("synchronization cancelled after %s: %w", c.SyncTimeout, err)
+	}
+	return err
+}
+
+// drainContext returns a context for synchronizations which is only cancelled once the given timeout has
+// elapsed after ctx is cancelled, so that a synchronization in progress can complete. Without a timeout,
+// ctx is returned as is.
+func drainContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	drainCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	go func() {
+		select {
+		case <-ctx.Done():
+			log.Infof("Waiting up to %s for the synchronization to complete", timeout)
+		case <-drainCtx.Done():
+			return
+		}
+		select {
+		case <-time.After(timeout):
+			cancel()
+		case <-drainCtx.Done():
+		}
+	}()
+	return drainCtx, cancel
+}
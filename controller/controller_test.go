@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -28,13 +30,20 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/pkg/events/fake"
+	"sigs.k8s.io/external-dns/pkg/planpolicy"
+	"sigs.k8s.io/external-dns/pkg/syncstatus"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/registry"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // mockProvider returns mock endpoints and validates changes.
@@ -334,6 +343,65 @@ func TestShouldRunOnce(t *testing.T) {
 	assert.True(t, ctrl.ShouldRunOnce(now))
 }
 
+func TestScheduleRunOnceEventDebounce(t *testing.T) {
+	ctrl := &Controller{Interval: 10 * time.Minute, EventDebounce: 30 * time.Second, EventJitter: 10 * time.Second}
+	now := time.Now()
+	assert.True(t, ctrl.ShouldRunOnce(now))
+	ctrl.lastRunAt = now
+
+	ctrl.ScheduleRunOnce(now)
+	assert.False(t, ctrl.ShouldRunOnce(now.Add(29*time.Second)))
+	assert.True(t, ctrl.nextRunAt.Before(now.Add(40*time.Second)), "jitter should be bounded")
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(40*time.Second)))
+}
+
+func TestScheduleRunOnceEventCoalesceWindow(t *testing.T) {
+	ctrl := &Controller{Interval: time.Minute, EventCoalesceWindow: 10 * time.Second}
+	now := time.Now()
+	assert.True(t, ctrl.ShouldRunOnce(now))
+	ctrl.lastRunAt = now
+
+	// each change of a burst postpones the synchronization
+	for _, at := range []time.Duration{time.Second, 5 * time.Second, 12 * time.Second} {
+		ctrl.ScheduleRunOnce(now.Add(at))
+		assert.False(t, ctrl.ShouldRunOnce(now.Add(at+9*time.Second)))
+	}
+	now = now.Add(22 * time.Second)
+	assert.True(t, ctrl.ShouldRunOnce(now))
+
+	// continuous changes don't postpone it past the periodic synchronization
+	ctrl.lastRunAt = now
+	for at := now.Add(5 * time.Second); at.Before(now.Add(ctrl.Interval)); at = at.Add(5 * time.Second) {
+		ctrl.ScheduleRunOnce(at)
+		assert.False(t, ctrl.ShouldRunOnce(at))
+	}
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(ctrl.Interval)))
+}
+
+func TestShouldRunOnceEventBackoff(t *testing.T) {
+	ctrl := &Controller{Interval: 10 * time.Minute, MinEventSyncInterval: 10 * time.Second, EventMaxBackoff: 35 * time.Second}
+	now := time.Now()
+	assert.True(t, ctrl.ShouldRunOnce(now))
+
+	// continuous churn: every run is triggered by events and the spacing grows up to the maximum backoff
+	for _, spacing := range []time.Duration{10 * time.Second, 10 * time.Second, 20 * time.Second, 35 * time.Second, 35 * time.Second} {
+		ctrl.lastRunAt = now
+		ctrl.ScheduleRunOnce(now)
+		assert.False(t, ctrl.ShouldRunOnce(now.Add(spacing-time.Second)), "should wait %s", spacing)
+		now = now.Add(spacing)
+		assert.True(t, ctrl.ShouldRunOnce(now), "should run after %s", spacing)
+	}
+
+	// a run without pending events resets the backoff
+	ctrl.lastRunAt = now
+	now = now.Add(ctrl.Interval)
+	assert.True(t, ctrl.ShouldRunOnce(now))
+	ctrl.lastRunAt = now
+	ctrl.ScheduleRunOnce(now)
+	now = now.Add(10 * time.Second)
+	assert.True(t, ctrl.ShouldRunOnce(now))
+}
+
 func testControllerFiltersDomains(t *testing.T, configuredEndpoints []*endpoint.Endpoint, domainFilter *endpoint.DomainFilter, providerEndpoints []*endpoint.Endpoint, expectedChanges []*plan.Changes) {
 	t.Helper()
 	cfg := externaldns.NewConfig()
@@ -559,3 +627,865 @@ func TestToggleRegistry(t *testing.T) {
 	r.failCountMu.Unlock()
 	assert.Equal(t, toggleRegistryFailureCount, finalCount, "failCount should be at least %d", toggleRegistryFailureCount)
 }
+
+// zoneFailingProvider fails to apply any changes touching the given zone.
+type zoneFailingProvider struct {
+	filteredMockProvider
+	failingZone string
+	err         error
+}
+
+func (p *zoneFailingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	p.ApplyChangesCalls = append(p.ApplyChangesCalls, changes)
+	for _, ep := range changes.Create {
+		if strings.HasSuffix(ep.DNSName, p.failingZone) {
+			return p.err
+		}
+	}
+	return nil
+}
+
+func TestRunOnceZoneBatching(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		zoneBatching bool
+		err          error
+		expectCalls  int
+		expectSoft   bool
+	}{
+		{name: "disabled", zoneBatching: false, err: errors.New("zone failure"), expectCalls: 1},
+		{name: "hard error", zoneBatching: true, err: errors.New("zone failure"), expectCalls: 2},
+		{name: "soft error", zoneBatching: true, err: provider.NewSoftErrorf("zone failure"), expectCalls: 2, expectSoft: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			source := new(testutils.MockSource)
+			source.On("Endpoints").Return([]*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.bad.com", endpoint.RecordTypeA, "1.1.1.1"),
+				endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+			}, nil)
+			p := &zoneFailingProvider{
+				filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"bad.com", "good.com"})},
+				failingZone:          "bad.com",
+				err:                  tc.err,
+			}
+			r, err := registry.NewNoopRegistry(p)
+			require.NoError(t, err)
+
+			ctrl := &Controller{
+				Source:             source,
+				Registry:           r,
+				Policy:             &plan.SyncPolicy{},
+				ManagedRecordTypes: []string{endpoint.RecordTypeA},
+				ZoneBatching:       tc.zoneBatching,
+			}
+
+			err = ctrl.RunOnce(context.Background())
+			require.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expectSoft, errors.Is(err, provider.SoftError))
+			require.Len(t, p.ApplyChangesCalls, tc.expectCalls)
+			if tc.zoneBatching {
+				assert.Equal(t, "a.bad.com", p.ApplyChangesCalls[0].Create[0].DNSName)
+				assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1")}, p.ApplyChangesCalls[1].Create)
+			}
+		})
+	}
+}
+
+func TestRunOnceChangeMetrics(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.bad.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("c.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &zoneFailingProvider{
+		filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"bad.com", "good.com"})},
+		failingZone:          "bad.com",
+		err:                  errors.New("zone failure"),
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneBatching:       true,
+	}
+
+	appliedGood := appliedChangesTotal.CounterVec.WithLabelValues("good.com", "a", "create")
+	appliedBad := appliedChangesTotal.CounterVec.WithLabelValues("bad.com", "a", "create")
+	good, bad := testutil.ToFloat64(appliedGood), testutil.ToFloat64(appliedBad)
+
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	assert.InDelta(t, 2, testutil.ToFloat64(plannedChanges.Gauge.WithLabelValues("good.com", "a", "create")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(plannedChanges.Gauge.WithLabelValues("bad.com", "a", "create")), 0)
+	assert.InDelta(t, good+2, testutil.ToFloat64(appliedGood), 0)
+	assert.InDelta(t, bad, testutil.ToFloat64(appliedBad), 0)
+}
+
+func TestRunOnceTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.bad.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &zoneFailingProvider{
+		filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"bad.com", "good.com"})},
+		failingZone:          "bad.com",
+		err:                  errors.New("zone failure"),
+	}
+	r, err := registry.NewNoopRegistry(provider.NewInstrumentedProvider(p, "test"))
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneBatching:       true,
+	}
+	require.Error(t, ctrl.RunOnce(context.Background()))
+
+	spans := map[string][]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = append(spans[span.Name()], span)
+	}
+	require.Len(t, spans["sync"], 1)
+	syncID := spans["sync"][0].SpanContext().SpanID()
+	for _, name := range []string{"registry.records", "source.endpoints", "plan.calculate"} {
+		require.Len(t, spans[name], 1, name)
+		assert.Equal(t, syncID, spans[name][0].Parent().SpanID(), name)
+	}
+	assert.Len(t, spans["zone"], 2)
+	assert.Len(t, spans["registry.apply_changes"], 2)
+	assert.Len(t, spans["provider.ApplyChanges"], 2)
+	assert.Equal(t, "Error", spans["sync"][0].Status().Code.String())
+}
+
+// rejectingProvider fails to apply any changes including the rejected DNS name.
+type rejectingProvider struct {
+	filteredMockProvider
+	rejected string
+	applied  []string
+}
+
+func (p *rejectingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	p.ApplyChangesCalls = append(p.ApplyChangesCalls, changes)
+	for _, ep := range changes.Create {
+		if ep.DNSName == p.rejected {
+			return provider.NewSoftErrorf("rejected %s", ep.DNSName)
+		}
+	}
+	for _, ep := range changes.Create {
+		p.applied = append(p.applied, ep.DNSName)
+	}
+	return nil
+}
+
+func TestRunOnceChangeRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		retries       int
+		interval      time.Duration
+		expectCalls   int
+		expectApplied int
+	}{
+		{name: "disabled", expectCalls: 1},
+		{name: "isolates the rejected change", retries: 3, interval: time.Minute, expectCalls: 6, expectApplied: 3},
+		{name: "bounded attempts", retries: 1, interval: time.Minute, expectCalls: 3, expectApplied: 2},
+		{name: "bounded by the interval", retries: 3, interval: time.Millisecond, expectCalls: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			source := new(testutils.MockSource)
+			source.On("Endpoints").Return([]*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+				endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+				endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+				endpoint.NewEndpoint("d.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			}, nil)
+			p := &rejectingProvider{rejected: "c.example.com"}
+			r, err := registry.NewNoopRegistry(p)
+			require.NoError(t, err)
+
+			ctrl := &Controller{
+				Source:             source,
+				Registry:           r,
+				Policy:             &plan.SyncPolicy{},
+				ManagedRecordTypes: []string{endpoint.RecordTypeA},
+				Interval:           tc.interval,
+				ChangeRetries:      tc.retries,
+				ChangeRetryBackoff: time.Millisecond,
+			}
+
+			err = ctrl.RunOnce(context.Background())
+			require.ErrorIs(t, err, provider.SoftError)
+			assert.Len(t, p.ApplyChangesCalls, tc.expectCalls)
+			// the order of the changes isn't stable, so neither is the half the rejected change falls in
+			assert.Len(t, p.applied, tc.expectApplied)
+			assert.NotContains(t, p.applied, "c.example.com")
+		})
+	}
+}
+
+// blockingProvider blocks ApplyChanges until released or until its context is canceled.
+type blockingProvider struct {
+	filteredMockProvider
+	started chan struct{}
+	release chan struct{}
+	mu      sync.Mutex
+	ctxErrs []error
+}
+
+func (p *blockingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	select {
+	case p.started <- struct{}{}:
+	default:
+	}
+	select {
+	case <-p.release:
+	case <-ctx.Done():
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ApplyChangesCalls = append(p.ApplyChangesCalls, changes)
+	p.ctxErrs = append(p.ctxErrs, ctx.Err())
+	return ctx.Err()
+}
+
+func TestRunDrainTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		drainTimeout time.Duration
+		finalSync    bool
+		expectErrs   []error
+	}{
+		{name: "interrupted without drain timeout", expectErrs: []error{context.Canceled}},
+		{name: "completed with drain timeout", drainTimeout: time.Minute, expectErrs: []error{nil}},
+		{name: "final synchronization", drainTimeout: time.Minute, finalSync: true, expectErrs: []error{nil, nil}},
+		{name: "final synchronization without drain timeout", finalSync: true, expectErrs: []error{nil, nil}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			source := new(testutils.MockSource)
+			source.On("Endpoints").Return([]*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			}, nil)
+			p := &blockingProvider{started: make(chan struct{}, 1), release: make(chan struct{})}
+			r, err := registry.NewNoopRegistry(p)
+			require.NoError(t, err)
+
+			ctrl := &Controller{
+				Source:             source,
+				Registry:           r,
+				Policy:             &plan.SyncPolicy{},
+				ManagedRecordTypes: []string{endpoint.RecordTypeA},
+				Interval:           time.Hour,
+				DrainTimeout:       tc.drainTimeout,
+				FinalSync:          tc.finalSync,
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				ctrl.Run(ctx)
+				close(done)
+			}()
+
+			<-p.started
+			cancel()
+			time.Sleep(50 * time.Millisecond)
+			close(p.release)
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("controller did not stop after context cancellation")
+			}
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			assert.Equal(t, tc.expectErrs, p.ctxErrs)
+		})
+	}
+}
+
+func TestRunOnceWithSyncTimeout(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &blockingProvider{started: make(chan struct{}, 1), release: make(chan struct{})}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Interval:           time.Hour,
+		SyncTimeout:        50 * time.Millisecond,
+	}
+
+	timeouts := testutil.ToFloat64(syncTimeoutsTotal.Counter)
+	err = ctrl.runOnceWithTimeout(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.InDelta(t, timeouts+1, testutil.ToFloat64(syncTimeoutsTotal.Counter), 0)
+
+	// synchronizations failing on their own are not counted as timeouts
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ctrl.runOnceWithTimeout(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, provider.SoftError)
+	assert.InDelta(t, timeouts+1, testutil.ToFloat64(syncTimeoutsTotal.Counter), 0)
+}
+
+func TestDrainContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	drainCtx, cancelDrain := drainContext(ctx, 50*time.Millisecond)
+	defer cancelDrain()
+
+	cancel()
+	assert.NoError(t, drainCtx.Err())
+	select {
+	case <-drainCtx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("drain context was not canceled after the timeout")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	sameCtx, _ := drainContext(ctx, 0)
+	cancel()
+	assert.ErrorIs(t, sameCtx.Err(), context.Canceled)
+}
+
+// recordingStatusWriter records the references of the resources reported as synced.
+type recordingStatusWriter struct {
+	mu   sync.Mutex
+	refs []*events.ObjectReference
+}
+
+func (w *recordingStatusWriter) Synced(_ context.Context, refs ...*events.ObjectReference) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.refs = append(w.refs, refs...)
+}
+
+func TestRunOnceStatusWriter(t *testing.T) {
+	badRef := &events.ObjectReference{Kind: "Service", ApiVersion: "v1", Namespace: "default", Name: "bad"}
+	goodRef := &events.ObjectReference{Kind: "Service", ApiVersion: "v1", Namespace: "default", Name: "good"}
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.bad.com", endpoint.RecordTypeA, "1.1.1.1").WithRefObject(badRef),
+		endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1").WithRefObject(goodRef),
+		endpoint.NewEndpoint("c.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &zoneFailingProvider{
+		filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"bad.com", "good.com"})},
+		failingZone:          "bad.com",
+		err:                  errors.New("zone failure"),
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	w := &recordingStatusWriter{}
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneBatching:       true,
+		StatusWriter:       w,
+	}
+
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, []*events.ObjectReference{goodRef}, w.refs)
+}
+
+// recordingAudit records the changes logged to the audit log.
+type recordingAudit struct {
+	changes []*plan.Changes
+}
+
+func (a *recordingAudit) Log(changes *plan.Changes) {
+	a.changes = append(a.changes, changes)
+}
+
+func TestRunOnceAudit(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.bad.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &zoneFailingProvider{
+		filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"bad.com", "good.com"})},
+		failingZone:          "bad.com",
+		err:                  errors.New("zone failure"),
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	a := &recordingAudit{}
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneBatching:       true,
+		Audit:              a,
+	}
+
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, a.changes, 1)
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1")}, a.changes[0].Create)
+}
+
+func TestRunOncePolicyEvaluator(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("*.prod.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("www.prod.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"prod.example.com"})}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	evaluator, err := planpolicy.NewCELEvaluator([]planpolicy.Rule{
+		{Name: "no-prod-wildcards", Expression: `record.dnsName.startsWith("*.") && zone == "prod.example.com"`, Verdict: planpolicy.VerdictDeny},
+	})
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		PolicyEvaluator:    evaluator,
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("www.prod.example.com", endpoint.RecordTypeA, "1.1.1.1")}, p.ApplyChangesCalls[0].Create)
+
+	state := ctrl.lastState()
+	require.Len(t, state.skipped, 1)
+	assert.Equal(t, plan.SkipReasonPolicyRule, state.skipped[0].Reason)
+	assert.Equal(t, "rule no-prod-wildcards", state.skipped[0].Message)
+}
+
+// recordingPublisher records the changes published, with their error.
+type recordingPublisher struct {
+	changes []*plan.Changes
+	errs    []error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, changes *plan.Changes, err error) {
+	p.changes = append(p.changes, changes)
+	p.errs = append(p.errs, err)
+}
+
+func TestRunOncePublisher(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.bad.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	zoneErr := errors.New("zone failure")
+	p := &zoneFailingProvider{
+		filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"bad.com", "good.com"})},
+		failingZone:          "bad.com",
+		err:                  zoneErr,
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	publisher := &recordingPublisher{}
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneBatching:       true,
+		Publisher:          publisher,
+	}
+
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, publisher.changes, 2)
+	published := map[string]error{}
+	for i, changes := range publisher.changes {
+		published[changes.Create[0].DNSName] = publisher.errs[i]
+	}
+	assert.Equal(t, map[string]error{"a.bad.com": zoneErr, "b.good.com": nil}, published)
+}
+
+// recordingNotifier records the applied changes and errors notified.
+type recordingNotifier struct {
+	applied []*plan.Changes
+	errs    []error
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, applied *plan.Changes, err error) {
+	n.applied = append(n.applied, applied)
+	n.errs = append(n.errs, err)
+}
+
+func TestRunOnceNotifier(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.bad.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	zoneErr := errors.New("zone failure")
+	p := &zoneFailingProvider{
+		filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"bad.com", "good.com"})},
+		failingZone:          "bad.com",
+		err:                  zoneErr,
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	n := &recordingNotifier{}
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneBatching:       true,
+		Notifier:           n,
+	}
+
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, n.applied, 1)
+	require.ErrorIs(t, n.errs[0], zoneErr)
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1")}, n.applied[0].Create)
+
+	// nothing to notify once the records are up to date
+	source.ExpectedCalls = nil
+	source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, n.applied, 1)
+}
+
+// recordingInventory records the records written to the inventory.
+type recordingInventory struct {
+	records [][]*endpoint.Endpoint
+}
+
+func (w *recordingInventory) Write(_ context.Context, records []*endpoint.Endpoint) {
+	w.records = append(w.records, records)
+}
+
+func TestRunOnceInventory(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("kept.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+	}, nil)
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("kept.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "3.3.3.3"),
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	inventory := &recordingInventory{}
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Inventory:          inventory,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, inventory.records, 1)
+	assert.ElementsMatch(t, []string{"kept.example.com", "new.example.com"}, dnsNames(inventory.records[0]))
+}
+
+// fakeApprovalGate approves changes once approved is set, and counts the applied ones.
+type fakeApprovalGate struct {
+	approved bool
+	applied  int
+}
+
+func (g *fakeApprovalGate) Approved(_ context.Context, _ *plan.Changes) (bool, error) {
+	return g.approved, nil
+}
+
+func (g *fakeApprovalGate) Applied(_ context.Context, _ *plan.Changes) {
+	g.applied++
+}
+
+func TestRunOnceApproval(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	gate := &fakeApprovalGate{}
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Approval:           gate,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Empty(t, p.ApplyChangesCalls, "changes waiting for approval aren't applied")
+	assert.Zero(t, gate.applied)
+
+	gate.approved = true
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, 1, gate.applied)
+}
+
+// fakeSyncStatus collects the reports of the synchronizations.
+type fakeSyncStatus struct {
+	reports []syncstatus.Report
+}
+
+func (f *fakeSyncStatus) Report(_ context.Context, report syncstatus.Report) {
+	f.reports = append(f.reports, report)
+}
+
+func TestRunOnceSyncStatus(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+	}, nil)
+	r, err := registry.NewNoopRegistry(&filteredMockProvider{})
+	require.NoError(t, err)
+	reporter := &fakeSyncStatus{}
+	gate := &fakeApprovalGate{}
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		DomainFilter:       endpoint.NewDomainFilter([]string{"example.com"}),
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Approval:           gate,
+		SyncStatus:         reporter,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, reporter.reports, 1)
+	report := reporter.reports[0]
+	require.NoError(t, report.Err)
+	assert.Equal(t, 2, report.Pending)
+	assert.Equal(t, syncstatus.ReasonAwaitingApproval, report.PendingReason)
+	assert.NotNil(t, report.Records)
+	assert.Empty(t, report.Records)
+	assert.True(t, report.LastSuccess.IsZero())
+	assert.Contains(t, report.Zones, "example.com")
+
+	gate.approved = true
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, reporter.reports, 2)
+	report = reporter.reports[1]
+	assert.Zero(t, report.Pending)
+	assert.Len(t, report.Records, 2)
+	assert.False(t, report.LastSuccess.IsZero())
+	assert.Equal(t, report.LastSuccess, report.Zones["example.com"])
+
+	ctrl.Registry, err = registry.NewNoopRegistry(&errorMockProvider{})
+	require.NoError(t, err)
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, reporter.reports, 3)
+	report = reporter.reports[2]
+	require.Error(t, report.Err)
+	assert.Nil(t, report.Records)
+	assert.Equal(t, reporter.reports[1].LastSuccess, report.LastSuccess)
+}
+
+// concurrencyTrackingProvider records the highest number of concurrent ApplyChanges calls.
+type concurrencyTrackingProvider struct {
+	filteredMockProvider
+	mu        sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (p *concurrencyTrackingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	p.mu.Lock()
+	p.active++
+	p.maxActive = max(p.maxActive, p.active)
+	p.ApplyChangesCalls = append(p.ApplyChangesCalls, changes)
+	p.mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+
+	p.mu.Lock()
+	p.active--
+	p.mu.Unlock()
+	return nil
+}
+
+func TestRunOnceZoneConcurrency(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.one.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("a.two.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("a.three.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &concurrencyTrackingProvider{
+		filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"one.com", "two.com", "three.com"})},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneBatching:       true,
+		ZoneConcurrency:    2,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 3)
+	assert.Equal(t, 2, p.maxActive)
+}
+
+func TestRunOnceMaxChangesPerZone(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.one.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.one.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("c.one.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("a.two.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"one.com", "two.com"})}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		MaxChangesPerZone:  2,
+		IncrementalSync:    true,
+		FullResyncInterval: time.Hour,
+	}
+	deferred := testutil.ToFloat64(deferredChangesTotal.CounterVec.WithLabelValues("one.com"))
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+	// the order of the changes of the plan isn't stable, so any two of the zone are applied
+	applied := dnsNames(p.ApplyChangesCalls[0].Create)
+	assert.Len(t, applied, 3)
+	assert.Contains(t, applied, "a.two.com")
+	assert.Len(t, slices.DeleteFunc(applied, func(name string) bool { return !strings.HasSuffix(name, ".one.com") }), 2)
+	assert.InDelta(t, deferred+1, testutil.ToFloat64(deferredChangesTotal.CounterVec.WithLabelValues("one.com")), 0)
+	assert.Nil(t, ctrl.lastSync, "the next synchronization is a full one, planning the deferred changes again")
+}
+
+func TestZoneNames(t *testing.T) {
+	filters := endpoint.MatchAllDomainFilters{
+		endpoint.NewDomainFilter([]string{"example.com", "eu-*.example.org"}),
+		endpoint.NewDomainFilter([]string{"*.staging.example.net", "example.net"}),
+	}
+	assert.Equal(t, []string{"example.com", "example.net"}, zoneNames(filters, (*endpoint.DomainFilter)(nil)))
+}
+
+// scopedRegistry returns the records of the provider with the desired DNS names when scoped.
+type scopedRegistry struct {
+	*registry.NoopRegistry
+	provider *filteredMockProvider
+	dnsNames []string
+}
+
+func (r *scopedRegistry) ScopedRecords(_ context.Context, dnsNames []string) ([]*endpoint.Endpoint, error) {
+	r.dnsNames = dnsNames
+	var records []*endpoint.Endpoint
+	for _, record := range r.provider.RecordsStore {
+		if slices.Contains(dnsNames, record.DNSName) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func TestRunOnceStreamRecords(t *testing.T) {
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+	}
+	noop, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	r := &scopedRegistry{NoopRegistry: noop, provider: p}
+
+	ctrl := &Controller{
+		Source: &staticSource{endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("A.example.com.", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+		}},
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+		StreamRecords:      true,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 0, p.RecordsCallCount)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, r.dnsNames)
+	require.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, []string{"b.example.com", "b.example.com"}, dnsNames(p.ApplyChangesCalls[0].Create))
+	assert.Equal(t, []string{"a.example.com"}, dnsNames(p.ApplyChangesCalls[0].UpdateNew))
+	assert.Empty(t, p.ApplyChangesCalls[0].Delete, "records not fetched are not deleted")
+
+	// the registry records are all fetched when not streaming
+	r.dnsNames = nil
+	ctrl.StreamRecords = false
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 1, p.RecordsCallCount)
+	assert.Nil(t, r.dnsNames)
+}
+
+func TestRunOnceCanonicalNames(t *testing.T) {
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			{DNSName: "www.example.com.", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"LB.example.com."}},
+			{DNSName: "API.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1"}},
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source: &staticSource{endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("WWW.Example.com", endpoint.RecordTypeCNAME, "lb.example.com"),
+			endpoint.NewEndpoint("api.example.com.", endpoint.RecordTypeA, "2.2.2.2"),
+		}},
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+	changes := p.ApplyChangesCalls[0]
+	assert.Empty(t, changes.Create)
+	assert.Empty(t, changes.Delete)
+	assert.Equal(t, []string{"api.example.com"}, dnsNames(changes.UpdateOld), "the provider is given canonical names")
+	assert.Equal(t, []string{"api.example.com"}, dnsNames(changes.UpdateNew))
+	assert.Equal(t, "API.example.com", p.RecordsStore[1].DNSName, "the provider records are left as they are")
+}
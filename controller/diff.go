@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// WriteDiff writes the given changes in a human-readable form, or as JSON if the format is "json".
+// In the text form, each created record is prefixed with "+", each deleted record with "-" and each
+// updated record with "~", followed by its new value.
+func WriteDiff(w io.Writer, changes *plan.Changes, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(changes)
+	}
+
+	if !changes.HasChanges() {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+	for _, ep := range changes.Create {
+		if _, err := fmt.Fprintf(w, "+ %s\n", ep); err != nil {
+			return err
+		}
+	}
+	for i, ep := range changes.UpdateOld {
+		if _, err := fmt.Fprintf(w, "~ %s\n", ep); err != nil {
+			return err
+		}
+		if i < len(changes.UpdateNew) {
+			if _, err := fmt.Fprintf(w, "  => %s\n", changes.UpdateNew[i]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ep := range changes.Delete {
+		if _, err := fmt.Fprintf(w, "- %s\n", ep); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%d to create, %d to update, %d to delete.\n", len(changes.Create), len(changes.UpdateNew), len(changes.Delete))
+	return err
+}
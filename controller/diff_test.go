@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestWriteDiff(t *testing.T) {
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeA, 300, "1.1.1.1")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "2.2.2.2")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "3.3.3.3")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeCNAME, "foo.example.com")},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, WriteDiff(&b, changes, "text"))
+	assert.Equal(t, `+ new.example.com 300 IN A  1.1.1.1 []
+~ changed.example.com 0 IN A  2.2.2.2 []
+  => changed.example.com 0 IN A  3.3.3.3 []
+- old.example.com 0 IN CNAME  foo.example.com []
+
+1 to create, 1 to update, 1 to delete.
+`, b.String())
+
+	b.Reset()
+	require.NoError(t, WriteDiff(&b, &plan.Changes{}, "text"))
+	assert.Equal(t, "No changes.\n", b.String())
+
+	b.Reset()
+	require.NoError(t, WriteDiff(&b, changes, "json"))
+	var decoded plan.Changes
+	require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+	assert.Equal(t, "new.example.com", decoded.Create[0].DNSName)
+	assert.Equal(t, "3.3.3.3", decoded.UpdateNew[0].Targets[0])
+}
+
+func TestControllerPlan(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	result, err := ctrl.Plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result.Changes.Create, 1)
+	assert.Equal(t, "a.example.com", result.Changes.Create[0].DNSName)
+	assert.Empty(t, p.ApplyChangesCalls, "planning must not apply any change")
+	assert.Nil(t, ctrl.lastState(), "planning must not update the state of the API")
+}
@@ -17,24 +17,72 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
+
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/plan"
 )
 
 // This function emits events for each change in the provided plan.Changes object using the given EventEmitter.
 // It handles create, update, and delete changes, assigning appropriate actions and reasons to each event.
+// Each change gets a single event, whose reason tells whether the record was created, updated or deleted.
 // If the emitter is nil, it does nothing.
-func emitChangeEvent(e events.EventEmitter, ch plan.Changes, reason events.Reason) {
+func emitChangeEvent(e events.EventEmitter, ch plan.Changes) {
 	if e == nil {
 		return
 	}
 	for _, change := range ch.Create {
-		e.Add(events.NewEvent(change.RefObject(), change.Describe(), events.ActionCreate, reason))
+		e.Add(events.NewEvent(change.RefObject(), change.Describe(), events.ActionCreate, events.CreatedDNSRecord))
 	}
 	for _, change := range ch.UpdateNew {
-		e.Add(events.NewEvent(change.RefObject(), change.Describe(), events.ActionUpdate, reason))
+		e.Add(events.NewEvent(change.RefObject(), change.Describe(), events.ActionUpdate, events.UpdatedDNSRecord))
 	}
 	for _, change := range ch.Delete {
-		e.Add(events.NewEvent(change.RefObject(), change.Describe(), events.ActionDelete, events.RecordDeleted))
+		e.Add(events.NewEvent(change.RefObject(), change.Describe(), events.ActionDelete, events.DeletedDNSRecord))
+	}
+}
+
+// emitFailedEvents emits a warning event on the source object of each change which failed to be applied.
+func emitFailedEvents(e events.EventEmitter, ch plan.Changes, err error) {
+	if e == nil {
+		return
+	}
+	for _, changes := range [][]*endpoint.Endpoint{ch.Create, ch.UpdateNew, ch.Delete} {
+		for _, change := range changes {
+			msg := fmt.Sprintf("%s: %v", change.Describe(), err)
+			e.Add(events.NewEvent(change.RefObject(), msg, events.ActionFailed, events.FailedApplyDNS))
+		}
+	}
+}
+
+// emitRejectedEvents emits a warning event on the source object of each desired endpoint which was skipped
+// by the plan because of the wildcard policy, a policy rule or the ownership of its DNS name.
+func emitRejectedEvents(e events.EventEmitter, skipped []plan.Skipped) {
+	if e == nil {
+		return
+	}
+	for _, s := range skipped {
+		var msg string
+		reason := events.RejectedDNSRecord
+		switch s.Reason {
+		case plan.SkipReasonWildcard:
+			msg = fmt.Sprintf("%s: wildcard records are denied by the wildcard policy", s.Endpoint.Describe())
+		case plan.SkipReasonPolicyRule:
+			if s.Endpoint.RefObject() == nil {
+				// deleted records have no resource
+				continue
+			}
+			msg = fmt.Sprintf("%s: %s denied by policy %s", s.Endpoint.Describe(), s.Action, s.Message)
+		case plan.SkipReasonOwner:
+			if s.Action == plan.ActionDelete || s.Endpoint.RefObject() == nil {
+				continue
+			}
+			msg = fmt.Sprintf("%s: DNS name %s", s.Endpoint.Describe(), s.Message)
+			reason = events.OwnershipConflict
+		default:
+			continue
+		}
+		e.Add(events.NewEvent(s.Endpoint.RefObject(), msg, events.ActionFailed, reason))
 	}
 }
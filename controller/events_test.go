@@ -17,10 +17,12 @@ limitations under the License.
 package controller
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/events"
@@ -28,7 +30,7 @@ import (
 	"sigs.k8s.io/external-dns/plan"
 )
 
-func TestEmit_RecordReady(t *testing.T) {
+func TestEmit_ChangeEvents(t *testing.T) {
 	refObj := &events.ObjectReference{}
 
 	tests := []struct {
@@ -53,10 +55,10 @@ func TestEmit_RecordReady(t *testing.T) {
 			},
 			asserts: func(em *fake.EventEmitter, ch plan.Changes) {
 				for _, ep := range ch.Create {
-					em.AssertCalled(t, "Add", events.NewEvent(ep.RefObject(), ep.Describe(), events.ActionCreate, events.RecordReady))
+					em.AssertCalled(t, "Add", events.NewEvent(ep.RefObject(), ep.Describe(), events.ActionCreate, events.CreatedDNSRecord))
 				}
 				for _, ep := range ch.Delete {
-					em.AssertCalled(t, "Add", events.NewEvent(ep.RefObject(), ep.Describe(), events.ActionDelete, events.RecordDeleted))
+					em.AssertCalled(t, "Add", events.NewEvent(ep.RefObject(), ep.Describe(), events.ActionDelete, events.DeletedDNSRecord))
 				}
 				em.AssertNotCalled(t, "Add", mock.MatchedBy(func(e events.Event) bool {
 					return e.EventType() == events.EventTypeWarning
@@ -75,12 +77,12 @@ func TestEmit_RecordReady(t *testing.T) {
 			},
 			asserts: func(em *fake.EventEmitter, ch plan.Changes) {
 				for _, ep := range ch.Delete {
-					em.AssertCalled(t, "Add", events.NewEvent(ep.RefObject(), ep.Describe(), events.ActionDelete, events.RecordDeleted))
+					em.AssertCalled(t, "Add", events.NewEvent(ep.RefObject(), ep.Describe(), events.ActionDelete, events.DeletedDNSRecord))
 				}
 				em.AssertCalled(t, "Add", mock.MatchedBy(func(e events.Event) bool {
 					return e.EventType() == events.EventTypeNormal &&
 						e.Action() == events.ActionDelete &&
-						e.Reason() == events.RecordDeleted
+						e.Reason() == events.DeletedDNSRecord
 				}))
 
 				em.AssertNumberOfCalls(t, "Add", 1)
@@ -92,7 +94,7 @@ func TestEmit_RecordReady(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			emitter := fake.NewFakeEventEmitter()
 
-			emitChangeEvent(emitter, tt.changes, events.RecordReady)
+			emitChangeEvent(emitter, tt.changes)
 
 			tt.asserts(emitter, tt.changes)
 			mock.AssertExpectationsForObjects(t, emitter)
@@ -102,6 +104,72 @@ func TestEmit_RecordReady(t *testing.T) {
 
 func TestEmit_NilEmitter(t *testing.T) {
 	assert.NotPanics(t, func() {
-		emitChangeEvent(nil, plan.Changes{}, events.RecordError)
+		emitChangeEvent(nil, plan.Changes{})
+	})
+}
+
+// recordingEmitter records all emitted events, as the fake emitter only records the first event of each call.
+type recordingEmitter struct {
+	events []events.Event
+}
+
+func (r *recordingEmitter) Add(e ...events.Event) {
+	r.events = append(r.events, e...)
+}
+
+func TestEmit_LifecycleReasons(t *testing.T) {
+	refObj := &events.ObjectReference{Kind: "Service", Namespace: "default", Name: "foo"}
+	changes := plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("one.example.com", endpoint.RecordTypeA, "10.10.10.0").WithRefObject(refObj)},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("two.example.com", endpoint.RecordTypeA, "10.10.10.1").WithRefObject(refObj)},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("three.example.com", endpoint.RecordTypeA, "10.10.10.2").WithRefObject(refObj)},
+	}
+
+	emitter := &recordingEmitter{}
+	emitChangeEvent(emitter, changes)
+	var reasons []events.Reason
+	for _, e := range emitter.events {
+		reasons = append(reasons, e.Reason())
+		assert.Equal(t, events.EventTypeNormal, e.EventType())
+	}
+	assert.Equal(t, []events.Reason{events.CreatedDNSRecord, events.UpdatedDNSRecord, events.DeletedDNSRecord}, reasons)
+
+	emitter = &recordingEmitter{}
+	emitFailedEvents(emitter, changes, errors.New("provider failure"))
+	require.Len(t, emitter.events, 3)
+	for _, e := range emitter.events {
+		assert.Equal(t, events.ActionFailed, e.Action())
+		assert.Equal(t, events.EventTypeWarning, e.EventType())
+		assert.Equal(t, events.FailedApplyDNS, e.Reason())
+	}
+
+	assert.NotPanics(t, func() {
+		emitFailedEvents(nil, changes, errors.New("provider failure"))
+	})
+}
+
+func TestEmitRejectedEvents(t *testing.T) {
+	refObj := &events.ObjectReference{Kind: "Ingress", Namespace: "default", Name: "foo"}
+	skipped := []plan.Skipped{
+		{Endpoint: endpoint.NewEndpoint("*.example.com", endpoint.RecordTypeA, "10.10.10.0").WithRefObject(refObj), Reason: plan.SkipReasonWildcard},
+		{Endpoint: endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.10.10.1").WithRefObject(refObj), Reason: plan.SkipReasonDomainFilter},
+		{Endpoint: endpoint.NewEndpoint("*.prod.example.com", endpoint.RecordTypeA, "10.10.10.2").WithRefObject(refObj), Reason: plan.SkipReasonPolicyRule, Action: plan.ActionCreate, Message: "no-prod-wildcards"},
+		{Endpoint: endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "10.10.10.3"), Reason: plan.SkipReasonPolicyRule, Action: plan.ActionDelete, Message: "frozen"},
+		{Endpoint: endpoint.NewEndpoint("taken.example.com", endpoint.RecordTypeA, "10.10.10.4").WithRefObject(refObj), Reason: plan.SkipReasonOwner, Message: `owned by "other" instead of "default"`},
+		{Endpoint: endpoint.NewEndpoint("gone.example.com", endpoint.RecordTypeA, "10.10.10.5"), Reason: plan.SkipReasonOwner, Action: plan.ActionDelete},
+	}
+
+	emitter := &recordingEmitter{}
+	emitRejectedEvents(emitter, skipped)
+	require.Len(t, emitter.events, 3)
+	assert.Equal(t, events.RejectedDNSRecord, emitter.events[0].Reason())
+	assert.Equal(t, events.EventTypeWarning, emitter.events[0].EventType())
+	assert.Equal(t, events.RejectedDNSRecord, emitter.events[1].Reason())
+	assert.Equal(t, events.ActionFailed, emitter.events[1].Action())
+	assert.Equal(t, events.OwnershipConflict, emitter.events[2].Reason())
+	assert.Equal(t, events.EventTypeWarning, emitter.events[2].EventType())
+
+	assert.NotPanics(t, func() {
+		emitRejectedEvents(nil, skipped)
 	})
 }
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/internal/logging"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/execcredential"
+)
+
+// execCredentialRetryInterval is the delay before running the credential plugin again after it failed.
+const execCredentialRetryInterval = 10 * time.Second
+
+// credentialPlugin obtains credentials.
+type credentialPlugin interface {
+	Run(ctx context.Context) (*execcredential.Credentials, error)
+}
+
+// readExecCredentials runs the credential plugin and sets the credentials it returned.
+func readExecCredentials(ctx context.Context, cfg *externaldns.Config, plugin credentialPlugin) (*execcredential.Credentials, error) {
+	creds, err := plugin.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(creds.Credentials)) {
+		if err := cfg.SetSecret(name, creds.Credentials[name]); err != nil {
+			return nil, fmt.Errorf("setting %s from the credential plugin: %w", name, err)
+		}
+	}
+	logging.AddSecrets(slices.Collect(maps.Values(creds.Credentials))...)
+	log.Infof("Obtained %d credentials from the credential plugin", len(creds.Credentials))
+	return creds, nil
+}
+
+// watchExecCredentials runs the credential plugin again before the given credentials obtained at the given time
+// expire, until the context is cancelled, and calls terminate once it returned other credentials. Unlike secret
+// files, credentials are only used at startup.
+func watchExecCredentials(ctx context.Context, plugin credentialPlugin, creds *execcredential.Credentials, obtained time.Time, terminate func()) {
+	for {
+		refreshAt, expires := creds.RefreshAt(obtained)
+		if !expires {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(refreshAt)):
+		}
+
+		next, err := plugin.Run(ctx)
+		if err != nil {
+			log.Warnf("Failed to refresh the credentials: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(execCredentialRetryInterval):
+			}
+			continue
+		}
+		if !maps.Equal(next.Credentials, creds.Credentials) {
+			log.Warn("The credential plugin returned new credentials. Terminating to be restarted with them")
+			terminate()
+			return
+		}
+		creds, obtained = next, time.Now()
+	}
+}
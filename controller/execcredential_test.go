@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/execcredential"
+)
+
+// fakeCredentialPlugin returns its credentials, expiring after its lifetime.
+type fakeCredentialPlugin struct {
+	mu          sync.Mutex
+	credentials map[string]string
+	lifetime    time.Duration
+	runs        int
+}
+
+func (p *fakeCredentialPlugin) Run(_ context.Context) (*execcredential.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.runs++
+	expiration := time.Now().Add(p.lifetime)
+	return &execcredential.Credentials{Credentials: p.credentials, ExpirationTimestamp: &expiration}, nil
+}
+
+func (p *fakeCredentialPlugin) set(credentials map[string]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.credentials = credentials
+}
+
+func (p *fakeCredentialPlugin) runCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.runs
+}
+
+func TestReadExecCredentials(t *testing.T) {
+	t.Setenv("CF_API_TOKEN", "")
+	cfg := externaldns.NewConfig()
+	plugin := &fakeCredentialPlugin{credentials: map[string]string{"CF_API_TOKEN": "token", "txt-encrypt-aes-key": "key"}, lifetime: time.Hour}
+
+	creds, err := readExecCredentials(context.Background(), cfg, plugin)
+	require.NoError(t, err)
+	assert.Equal(t, plugin.credentials, creds.Credentials)
+	assert.Equal(t, "token", os.Getenv("CF_API_TOKEN"))
+	assert.Equal(t, "key", cfg.TXTEncryptAESKey)
+
+	plugin.set(map[string]string{"provider": "aws"})
+	_, err = readExecCredentials(context.Background(), cfg, plugin)
+	assert.ErrorContains(t, err, "unknown credential")
+}
+
+func TestWatchExecCredentials(t *testing.T) {
+	plugin := &fakeCredentialPlugin{credentials: map[string]string{"CF_API_TOKEN": "old"}, lifetime: 50 * time.Millisecond}
+	creds, err := plugin.Run(context.Background())
+	require.NoError(t, err)
+
+	terminated := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchExecCredentials(ctx, plugin, creds, time.Now(), func() { close(terminated) })
+
+	require.Eventually(t, func() bool { return plugin.runCount() >= 3 }, 5*time.Second, 10*time.Millisecond, "credentials are refreshed before they expire")
+	select {
+	case <-terminated:
+		t.Fatal("terminated although the credentials didn't change")
+	default:
+	}
+
+	plugin.set(map[string]string{"CF_API_TOKEN": "new"})
+	select {
+	case <-terminated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not terminated after the credentials changed")
+	}
+}
@@ -18,10 +18,15 @@ package controller
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -34,10 +39,25 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/logging"
+	"sigs.k8s.io/external-dns/pkg/admission"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	"sigs.k8s.io/external-dns/pkg/approval"
+	"sigs.k8s.io/external-dns/pkg/audit"
 	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/execcredential"
+	"sigs.k8s.io/external-dns/pkg/inventory"
 	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/notify"
+	"sigs.k8s.io/external-dns/pkg/planpolicy"
+	"sigs.k8s.io/external-dns/pkg/publish"
+	"sigs.k8s.io/external-dns/pkg/status"
+	"sigs.k8s.io/external-dns/pkg/syncstatus"
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
+	"sigs.k8s.io/external-dns/pkg/tokenreview"
+	"sigs.k8s.io/external-dns/pkg/tracing"
+	"sigs.k8s.io/external-dns/pkg/vault"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/provider/akamai"
@@ -72,6 +92,10 @@ import (
 	"sigs.k8s.io/external-dns/source/wrappers"
 )
 
+// exitCodeChanges is the exit code of --once and the diff command when DNS records had to be changed
+// and --detailed-exit-code is set.
+const exitCodeChanges = 2
+
 func Execute() {
 	cfg := externaldns.NewConfig()
 	if err := cfg.ParseFlags(os.Args[1:]); err != nil {
@@ -79,8 +103,14 @@ func Execute() {
 	}
 	log.Infof("config: %s", cfg)
 	if err := validation.ValidateConfig(cfg); err != nil {
+		if cfg.Command == externaldns.CommandValidate {
+			writeChecks(os.Stdout, []preflightCheck{{name: "flags are valid", err: err}})
+			os.Exit(1)
+		}
 		log.Fatalf("config validation failed: %v", err)
 	}
+	// the configuration as parsed, which reloads are compared with
+	parsedCfg := *cfg
 
 	configureLogger(cfg)
 
@@ -97,13 +127,128 @@ func Execute() {
 
 	log.Info(externaldns.Banner())
 
+	if cfg.Command == externaldns.CommandRBAC {
+		if err := WriteManifests(os.Stdout, rbacManifests(cfg, cfg.RBACName, cfg.RBACServiceAccount)); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
-	go serveMetrics(cfg.MetricsAddress)
 	go handleSigterm(cancel)
 
-	endpointsSource, err := buildSource(ctx, cfg)
-	if err != nil {
+	stopTracing := func() {}
+	if cfg.TracingEndpoint != "" {
+		shutdown, err := tracing.Setup(ctx, tracing.Config{
+			Endpoint:    cfg.TracingEndpoint,
+			Insecure:    cfg.TracingInsecure,
+			SampleRatio: cfg.TracingSampleRatio,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		stopTracing = func() {
+			// the context is cancelled on SIGTERM, flush the pending spans regardless
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(flushCtx); err != nil {
+				log.Warnf("Failed to flush traces: %v", err)
+			}
+		}
+	}
+	defer stopTracing()
+
+	var vaultClient *vault.Client
+	var vaultValues map[string]string
+	if len(cfg.VaultSecrets) > 0 {
+		var err error
+		if vaultClient, vaultValues, err = readVaultSecrets(ctx, cfg); err != nil {
+			log.Fatal(err)
+		}
+		go vaultClient.KeepAlive(ctx)
+	}
+
+	var execPlugin *execcredential.Plugin
+	var execCreds *execcredential.Credentials
+	execObtained := time.Now()
+	if cfg.CredentialExecCommand != "" {
+		execPlugin = &execcredential.Plugin{Command: cfg.CredentialExecCommand, Args: cfg.CredentialExecArgs, Timeout: cfg.CredentialExecTimeout}
+		var err error
+		if execCreds, err = readExecCredentials(ctx, cfg, execPlugin); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if cfg.AdmissionWebhook {
+		go serveMetrics(cfg)
+		validator := admission.NewValidator(createDomainFilter(cfg))
+		if err := admission.ListenAndServeTLS(ctx, cfg.AdmissionWebhookAddress, cfg.AdmissionWebhookTLSCert, cfg.AdmissionWebhookTLSKey, validator); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.ValidateOnly || cfg.Command == externaldns.CommandValidate {
+		if !validateOnly(ctx, cfg, os.Stdout) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.Command == externaldns.CommandZones {
+		// the zones only depend on the provider, the sources aren't needed
+		prvdr, err := buildProvider(ctx, cfg, createDomainFilter(cfg))
+		if err != nil {
+			log.Fatal(err)
+		}
+		zones, err := provider.ListZones(ctx, prvdr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := WriteZones(os.Stdout, zones, cfg.ZonesOutput); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.Command == externaldns.CommandExport {
+		// like the zones, the records only depend on the provider and the registry
+		prvdr, err := buildProvider(ctx, cfg, createDomainFilter(cfg))
+		if err != nil {
+			log.Fatal(err)
+		}
+		reg, err := selectRegistry(cfg, prvdr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		records, err := ownedRecords(ctx, reg, cfg.TXTOwnerID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := WriteRecords(os.Stdout, records, cfg.ExportOutput); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.Command == externaldns.CommandVerifyAuditLog {
+		if err := VerifyAuditLog(ctx, os.Stdout, auditStore(cfg)); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	var savedPlan *SavedPlan
+	var endpointsSource source.Source
+	var err error
+	if cfg.Command == externaldns.CommandApply {
+		// the changes come from the saved plan, the sources aren't needed
+		if savedPlan, err = readPlanFile(cfg.PlanFile); err != nil {
+			log.Fatal(err)
+		}
+		endpointsSource = source.NewEmptySource()
+	} else if endpointsSource, err = buildSource(ctx, cfg); err != nil {
 		log.Fatal(err)
 	}
 
@@ -115,6 +260,7 @@ func Execute() {
 	}
 
 	if cfg.WebhookServer {
+		go serveMetrics(cfg)
 		webhookapi.StartHTTPApi(prvdr, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, "127.0.0.1:8888")
 		os.Exit(0)
 	}
@@ -123,16 +269,108 @@ func Execute() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if cfg.Paused {
+		ctrl.Pause()
+	}
+	if closer, ok := ctrl.Publisher.(io.Closer); ok {
+		defer closer.Close()
+	}
 
-	if cfg.Once {
-		err := ctrl.RunOnce(ctx)
+	if cfg.Command == externaldns.CommandDiff {
+		calculated, err := ctrl.Plan(ctx)
 		if err != nil {
 			log.Fatal(err)
 		}
+		if err := WriteDiff(os.Stdout, calculated.Changes, cfg.DiffOutput); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(exitCode(cfg, calculated.Changes))
+	}
+
+	if cfg.Command == externaldns.CommandPlan {
+		calculated, err := ctrl.Plan(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := writePlanFile(cfg.PlanOut, NewSavedPlan(cfg.TXTOwnerID, time.Now(), calculated.Changes)); err != nil {
+			log.Fatal(err)
+		}
+		if cfg.PlanOut != "-" {
+			if err := WriteDiff(os.Stdout, calculated.Changes, "text"); err != nil {
+				log.Fatal(err)
+			}
+		}
+		os.Exit(exitCode(cfg, calculated.Changes))
+	}
 
+	if cfg.Command == externaldns.CommandApply {
+		if err := ctrl.ApplyPlan(ctx, savedPlan, cfg.TXTOwnerID, cfg.PlanMaxAge); err != nil {
+			log.Fatal(err)
+		}
 		os.Exit(0)
 	}
 
+	if cfg.Once {
+		err := ctrl.runOnceWithTimeout(ctx)
+		stopTracing()
+		if cfg.PushgatewayURL != "" {
+			pushCtx, cancelPush := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := metrics.Push(pushCtx, cfg.PushgatewayURL, cfg.PushgatewayJob); err != nil {
+				log.Warnf("Failed to push metrics to %s: %v", cfg.PushgatewayURL, err)
+			}
+			cancelPush()
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var changes *plan.Changes
+		if state := ctrl.lastState(); state != nil {
+			changes = state.changes
+		}
+		os.Exit(exitCode(cfg, changes))
+	}
+
+	// the servers are only started for the controller loop, so that the one-shot commands can run next to a running
+	// instance, whose ports they would fail to bind
+	go serveMetrics(cfg)
+	log.Debugf("serving 'readyz' on '%s/readyz'", cfg.MetricsAddress)
+	http.Handle("/readyz", ctrl.ReadinessHandler(cfg.ReadyMaxSyncAge))
+
+	if cfg.APIToken != "" || cfg.APIWriteToken != "" || cfg.APITokenAudience != "" {
+		auth := APIAuth{Token: cfg.APIToken, WriteToken: cfg.APIWriteToken}
+		if cfg.APITokenAudience != "" {
+			client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
+			if err != nil {
+				log.Fatal(err)
+			}
+			auth.Tokens = tokenreview.NewAuthenticator(client, cfg.APITokenAudience, slices.Concat(cfg.APIServiceAccounts, cfg.APIWriteServiceAccounts))
+			for _, serviceAccount := range cfg.APIWriteServiceAccounts {
+				auth.Writers = append(auth.Writers, tokenreview.Username(serviceAccount))
+			}
+		}
+		log.Debugf("serving 'api' on '%s/api/v1/'", cfg.MetricsAddress)
+		http.Handle("/api/v1/", ctrl.APIHandler(auth))
+
+		if cfg.GRPCAddress != "" {
+			listener, err := net.Listen("tcp", cfg.GRPCAddress)
+			if err != nil {
+				log.Fatalf("listening on %s for the gRPC admin API: %v", cfg.GRPCAddress, err)
+			}
+			server := ctrl.GRPCServer(auth)
+			go func() {
+				<-ctx.Done()
+				server.GracefulStop()
+			}()
+			go func() {
+				log.Debugf("serving the gRPC admin API on '%s'", cfg.GRPCAddress)
+				if err := server.Serve(listener); err != nil {
+					log.Errorf("gRPC admin API stopped: %v", err)
+				}
+			}()
+		}
+	}
+
 	if cfg.UpdateEvents {
 		// Add RunOnce as the handler function that will be called when ingress/service sources have changed.
 		// Note that k8s Informers will perform an initial list operation, which results in the handler
@@ -140,6 +378,19 @@ func Execute() {
 		ctrl.Source.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
 	}
 
+	if cfg.ConfigFile != "" {
+		go watchConfig(ctx, &parsedCfg, os.Args[1:], ctrl)
+	}
+	if len(cfg.SecretFiles) > 0 {
+		go watchSecretFiles(ctx, cfg, configPollInterval, ctrl, cancel)
+	}
+	if vaultClient != nil {
+		go watchVaultSecrets(ctx, vaultClient, cfg.VaultSecrets, vaultValues, vaultPollInterval, cancel)
+	}
+	if execPlugin != nil {
+		go watchExecCredentials(ctx, execPlugin, execCreds, execObtained, cancel)
+	}
+
 	ctrl.ScheduleRunOnce(time.Now())
 	ctrl.Run(ctx)
 }
@@ -195,6 +446,7 @@ func buildProvider(
 				PreferCNAME:           cfg.AWSPreferCNAME,
 				DryRun:                cfg.DryRun,
 				ZoneCacheDuration:     cfg.AWSZoneCacheDuration,
+				ZoneListConcurrency:   cfg.ZoneListConcurrency,
 			},
 			clients,
 		)
@@ -206,9 +458,9 @@ func buildProvider(
 		}
 		p, err = awssd.NewAWSSDProvider(domainFilter, cfg.AWSZoneType, cfg.DryRun, cfg.AWSSDServiceCleanup, cfg.TXTOwnerID, cfg.AWSSDCreateTag, sd.NewFromConfig(aws.CreateDefaultV2Config(cfg)))
 	case "azure-dns", "azure":
-		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun)
+		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.ZoneListConcurrency, cfg.DryRun)
 	case "azure-private-dns":
-		p, err = azure.NewAzurePrivateDNSProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun)
+		p, err = azure.NewAzurePrivateDNSProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.ZoneListConcurrency, cfg.DryRun)
 	case "civo":
 		p, err = civo.NewCivoProvider(domainFilter, cfg.DryRun)
 	case "cloudflare":
@@ -227,11 +479,12 @@ func buildProvider(
 				CertificateAuthority: cfg.CloudflareCustomHostnamesCertificateAuthority,
 			},
 			cloudflare.DNSRecordsConfig{
-				PerPage: cfg.CloudflareDNSRecordsPerPage,
-				Comment: cfg.CloudflareDNSRecordsComment,
+				PerPage:         cfg.CloudflareDNSRecordsPerPage,
+				Comment:         cfg.CloudflareDNSRecordsComment,
+				ListConcurrency: cfg.ZoneListConcurrency,
 			})
 	case "google":
-		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun)
+		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.ZoneListConcurrency, cfg.ZoneChangeTokens, cfg.DryRun)
 	case "digitalocean":
 		p, err = digitalocean.NewDigitalOceanProvider(ctx, domainFilter, cfg.DryRun, cfg.DigitalOceanAPIPageSize)
 	case "ovh":
@@ -297,7 +550,7 @@ func buildProvider(
 			ClientCertFilePath:    cfg.TLSClientCert,
 			ClientCertKeyFilePath: cfg.TLSClientCertKey,
 		}
-		p, err = rfc2136.NewRfc2136Provider(cfg.RFC2136Host, cfg.RFC2136Port, cfg.RFC2136Zone, cfg.RFC2136Insecure, cfg.RFC2136TSIGKeyName, cfg.RFC2136TSIGSecret, cfg.RFC2136TSIGSecretAlg, cfg.RFC2136TAXFR, domainFilter, cfg.DryRun, cfg.RFC2136MinTTL, cfg.RFC2136CreatePTR, cfg.RFC2136GSSTSIG, cfg.RFC2136KerberosUsername, cfg.RFC2136KerberosPassword, cfg.RFC2136KerberosRealm, cfg.RFC2136BatchChangeSize, tlsConfig, cfg.RFC2136LoadBalancingStrategy, nil)
+		p, err = rfc2136.NewRfc2136Provider(cfg.RFC2136Host, cfg.RFC2136Port, cfg.RFC2136Zone, cfg.RFC2136Insecure, cfg.RFC2136TSIGKeyName, cfg.RFC2136TSIGSecret, cfg.RFC2136TSIGSecretAlg, cfg.RFC2136TAXFR, domainFilter, cfg.DryRun, cfg.RFC2136MinTTL, cfg.RFC2136CreatePTR, cfg.RFC2136GSSTSIG, cfg.RFC2136KerberosUsername, cfg.RFC2136KerberosPassword, cfg.RFC2136KerberosRealm, cfg.RFC2136BatchChangeSize, tlsConfig, cfg.RFC2136LoadBalancingStrategy, cfg.ZoneChangeTokens, nil)
 	case "ns1":
 		p, err = ns1.NewNS1Provider(
 			ns1.NS1Config{
@@ -331,10 +584,13 @@ func buildProvider(
 	case "plural":
 		p, err = plural.NewPluralProvider(cfg.PluralCluster, cfg.PluralProvider)
 	case "webhook":
-		p, err = webhook.NewWebhookProvider(cfg.WebhookProviderURL)
+		p, err = webhook.NewWebhookProvider(cfg.WebhookProviderURL, cfg.WebhookProviderAllowedHosts)
 	default:
 		err = fmt.Errorf("unknown dns provider: %s", cfg.Provider)
 	}
+	if p != nil {
+		p = provider.NewInstrumentedProvider(p, cfg.Provider)
+	}
 	if p != nil && cfg.ProviderCacheTime > 0 {
 		p = provider.NewCachedProvider(
 			p,
@@ -355,12 +611,17 @@ func buildController(
 	if !ok {
 		return nil, fmt.Errorf("unknown policy: %s", cfg.Policy)
 	}
+	resolver, ok := plan.ConflictResolvers[cfg.ConflictResolution]
+	if !ok {
+		return nil, fmt.Errorf("unknown conflict resolution: %s", cfg.ConflictResolution)
+	}
 	reg, err := selectRegistry(cfg, p)
 	if err != nil {
 		return nil, err
 	}
 	eventsCfg := events.NewConfig(
 		events.WithKubeConfig(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout),
+		events.WithRateLimit(cfg.KubeAPIQPS, cfg.KubeAPIBurst),
 		events.WithEmitEvents(cfg.EmitEvents),
 		events.WithDryRun(cfg.DryRun))
 	var eventEmitter events.EventEmitter
@@ -372,30 +633,193 @@ func buildController(
 		eventCtrl.Run(ctx)
 		eventEmitter = eventCtrl
 	}
+	// wrapped here rather than in buildSource, as resources with invalid annotations are reported by events
+	src = wrappers.NewAnnotationValidationSource(src, eventEmitter)
+	if cfg.NamespaceDomainsConfigMap != "" {
+		client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
+		if err != nil {
+			return nil, err
+		}
+		namespace, name, _ := strings.Cut(cfg.NamespaceDomainsConfigMap, "/")
+		src, err = wrappers.NewNamespaceDomainsSource(ctx, src, client, namespace, name, eventEmitter)
+		if err != nil {
+			return nil, fmt.Errorf("watching namespace domains: %w", err)
+		}
+		cfg.AddSourceWrapper("namespace-domains")
+	}
+	if cfg.TargetProbe != "" {
+		// wrapped here rather than in buildSource, as unreachable targets are reported by events
+		prober, err := wrappers.NewProber(cfg.TargetProbe, cfg.TargetProbePort, cfg.TargetProbeTimeout)
+		if err != nil {
+			return nil, err
+		}
+		src = wrappers.NewReachabilitySource(src, prober, eventEmitter)
+		cfg.AddSourceWrapper("reachability")
+	}
+	var statusWriter status.Writer
+	if cfg.StatusAnnotation {
+		client, err := source.NewDynamicKubernetesClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
+		if err != nil {
+			return nil, err
+		}
+		statusWriter = status.NewAnnotationWriter(client, cfg.DryRun)
+	}
+	var inventoryWriters inventory.Writers
+	if cfg.InventoryConfigMap != "" || cfg.NamespaceRecordsConfigMap != "" {
+		client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.InventoryConfigMap != "" {
+			namespace, name, _ := strings.Cut(cfg.InventoryConfigMap, "/")
+			inventoryWriters = append(inventoryWriters, inventory.NewConfigMapWriter(client, namespace, name, cfg.DryRun))
+		}
+		if cfg.NamespaceRecordsConfigMap != "" {
+			inventoryWriters = append(inventoryWriters, inventory.NewNamespacedConfigMapWriter(client, cfg.NamespaceRecordsConfigMap, cfg.DryRun))
+		}
+	}
+	var inventoryWriter inventory.Writer
+	if len(inventoryWriters) > 0 {
+		inventoryWriter = inventoryWriters
+	}
+	var auditLogger audit.Logger
+	switch {
+	case cfg.AuditLogChain:
+		logger, err := audit.NewChainLogger(ctx, auditStore(cfg), cfg.TXTOwnerID, cfg.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		auditLogger = logger
+	case cfg.AuditLog != "":
+		logger, err := audit.Open(cfg.AuditLog, cfg.TXTOwnerID, cfg.DryRun)
+		if err != nil {
+			return nil, fmt.Errorf("opening audit log: %w", err)
+		}
+		auditLogger = logger
+	}
+	var publisher publish.Publisher
+	switch {
+	case len(cfg.PublishKafkaBrokers) > 0:
+		publisher = publish.NewKafkaPublisher(cfg.PublishKafkaBrokers, cfg.PublishKafkaTopic, cfg.TXTOwnerID, cfg.DryRun)
+	case cfg.PublishNATSURL != "":
+		natsPublisher, err := publish.NewNATSPublisher(cfg.PublishNATSURL, cfg.PublishNATSSubject, cfg.TXTOwnerID, cfg.DryRun)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to NATS: %w", err)
+		}
+		publisher = natsPublisher
+	case cfg.PublishCloudEventsSink != "":
+		publisher = publish.NewCloudEventsPublisher(cfg.PublishCloudEventsSink, cfg.TXTOwnerID, cfg.DryRun)
+	}
+	var notifier notify.Notifier
+	if cfg.NotificationURL != "" {
+		var tmpl []byte
+		if cfg.NotificationTemplate != "" {
+			if tmpl, err = os.ReadFile(cfg.NotificationTemplate); err != nil {
+				return nil, fmt.Errorf("reading notification template: %w", err)
+			}
+		}
+		webhookNotifier, err := notify.NewWebhookNotifier(cfg.NotificationURL, cfg.NotificationFormat, string(tmpl), cfg.TXTOwnerID, cfg.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		notifier = webhookNotifier
+	}
+	var approvalGate approval.Gate
+	if cfg.ApprovalThreshold > 0 || len(cfg.ApprovalZones) > 0 {
+		client, err := source.NewDynamicKubernetesClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
+		if err != nil {
+			return nil, err
+		}
+		approvalGate = approval.NewCRDGate(client, cfg.ApprovalNamespace, cfg.TXTOwnerID, cfg.ApprovalThreshold, cfg.ApprovalZones, cfg.DryRun)
+	}
+	var syncStatus syncstatus.Reporter
+	if cfg.StatusObject != "" {
+		client, err := source.NewDynamicKubernetesClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
+		if err != nil {
+			return nil, err
+		}
+		syncStatus = syncstatus.NewCRDReporter(client, cfg.StatusObject, cfg.TXTOwnerID, cfg.DryRun)
+	}
+
+	var evaluators planpolicy.Evaluators
+	if cfg.PolicyRules != "" {
+		rules, err := planpolicy.LoadRules(cfg.PolicyRules)
+		if err != nil {
+			return nil, err
+		}
+		evaluators = append(evaluators, rules)
+	}
+	if cfg.PolicyOPAURL != "" {
+		evaluators = append(evaluators, planpolicy.NewOPAEvaluator(cfg.PolicyOPAURL))
+	}
+	var policyEvaluator planpolicy.Evaluator
+	if len(evaluators) > 0 {
+		policyEvaluator = evaluators
+	}
 
 	return &Controller{
 		Source:               src,
 		Registry:             reg,
 		Policy:               policy,
+		PolicyEvaluator:      policyEvaluator,
+		ConflictResolver:     resolver,
+		SourceGroup:          cfg.SourceGroup,
 		Interval:             cfg.Interval,
-		DomainFilter:         filter,
+		DomainFilter:         controllerDomainFilter(cfg, filter),
 		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
 		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
+		WildcardPolicy:       cfg.WildcardPolicy,
+		WildcardPlaceholder:  cfg.WildcardPlaceholder,
 		MinEventSyncInterval: cfg.MinEventSyncInterval,
+		EventDebounce:        cfg.EventDebounce,
+		EventJitter:          cfg.EventJitter,
+		EventMaxBackoff:      cfg.EventMaxBackoff,
+		EventCoalesceWindow:  cfg.EventCoalesceWindow,
+		ZoneBatching:         cfg.ZoneBatching,
+		ZoneConcurrency:      cfg.ZoneConcurrency,
+		MaxChangesPerZone:    cfg.MaxChangesPerZonePerSync,
 		EventEmitter:         eventEmitter,
+		StatusWriter:         statusWriter,
+		Inventory:            inventoryWriter,
+		Audit:                auditLogger,
+		Publisher:            publisher,
+		Notifier:             notifier,
+		Approval:             approvalGate,
+		SyncStatus:           syncStatus,
+		ChangeRetries:        cfg.ChangeRetries,
+		ChangeRetryBackoff:   cfg.ChangeRetryBackoff,
+		DrainTimeout:         cfg.DrainTimeout,
+		FinalSync:            cfg.FinalSync,
+		SyncTimeout:          cfg.SyncTimeout,
+		IncrementalSync:      cfg.IncrementalSync,
+		StreamRecords:        cfg.StreamRecords,
+		FullResyncInterval:   cfg.FullResyncInterval,
+		FastSyncInterval:     cfg.FastSyncInterval,
+		FastSyncResources:    cfg.FastSyncResources,
 	}, nil
 }
 
+// exitCode returns the exit code of a single synchronization which succeeded: with --detailed-exit-code,
+// exitCodeChanges if DNS records had to be changed.
+func exitCode(cfg *externaldns.Config, changes *plan.Changes) int {
+	if cfg.DetailedExitCode && changes != nil && changes.HasChanges() {
+		return exitCodeChanges
+	}
+	return 0
+}
+
 // This function configures the logger format and level based on the provided configuration.
 func configureLogger(cfg *externaldns.Config) {
 	if cfg.LogFormat == "json" {
 		log.SetFormatter(&log.JSONFormatter{})
 	}
-	ll, err := log.ParseLevel(cfg.LogLevel)
+	levels, err := logging.ParseLevels(cfg.LogLevel)
 	if err != nil {
 		log.Fatalf("failed to parse log level: %v", err)
 	}
-	log.SetLevel(ll)
+	levels.Configure(log.StandardLogger())
+	logging.AddSecrets(cfg.SecretValues()...)
+	logging.ConfigureRedaction(log.StandardLogger())
 }
 
 // selectRegistry selects the appropriate registry implementation based on the configuration in cfg.
@@ -435,6 +859,8 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 	sources, err := source.ByNames(ctx, &source.SingletonClientGenerator{
 		KubeConfig:   cfg.KubeConfig,
 		APIServerURL: cfg.APIServerURL,
+		QPS:          cfg.KubeAPIQPS,
+		Burst:        cfg.KubeAPIBurst,
 		RequestTimeout: func() time.Duration {
 			if cfg.UpdateEvents {
 				return 0
@@ -446,10 +872,49 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 		return nil, err
 	}
 	// Combine multiple sources into a single, deduplicated source.
-	combinedSource := wrappers.NewDedupSource(wrappers.NewMultiSource(sources, sourceCfg.DefaultTargets, sourceCfg.ForceDefaultTargets))
+	combinedSource := wrappers.NewMultiSource(sources, sourceCfg.DefaultTargets, sourceCfg.ForceDefaultTargets, cfg.SourceConcurrency, cfg.SourceTimeout)
+	// Normalize internationalized and mixed case names before they are merged or deduplicated.
+	combinedSource = wrappers.NewIDNASource(combinedSource)
+	cfg.AddSourceWrapper("idna")
+	if cfg.Federated {
+		// Merge the endpoints of the member clusters before deduplicating, which would otherwise keep them apart.
+		combinedSource = wrappers.NewFederatedSource(combinedSource)
+		cfg.AddSourceWrapper("federated")
+	}
+	combinedSource = wrappers.NewDedupSource(combinedSource)
 	cfg.AddSourceWrapper("dedup")
+	if len(cfg.RegistryResourceLabels) > 0 {
+		combinedSource = wrappers.NewResourceLabelsSource(combinedSource, cfg.RegistryResourceLabels)
+		cfg.AddSourceWrapper("resource-labels")
+	}
+	if cfg.ConflictResolution == "oldest-resource" || cfg.ConflictResolution == "priority" {
+		// only these strategies compare the creation time and priority of the resources
+		combinedSource = wrappers.NewConflictLabelsSource(combinedSource)
+		cfg.AddSourceWrapper("conflict-labels")
+	}
+	if cfg.AddressFamilyPolicy != "" && cfg.AddressFamilyPolicy != wrappers.AddressFamilyDualStack {
+		combinedSource = wrappers.NewAddressFamilySource(combinedSource, cfg.AddressFamilyPolicy)
+		cfg.AddSourceWrapper("address-family")
+	}
 	combinedSource = wrappers.NewNAT64Source(combinedSource, cfg.NAT64Networks)
 	cfg.AddSourceWrapper("nat64")
+	if cfg.GeoContinent != "" || cfg.GeoCountry != "" || cfg.GeoRegion != "" {
+		combinedSource = wrappers.NewGeoSource(combinedSource, endpoint.GeoLocation{
+			Continent: strings.ToUpper(cfg.GeoContinent),
+			Country:   strings.ToUpper(cfg.GeoCountry),
+			Region:    strings.ToUpper(cfg.GeoRegion),
+		})
+		cfg.AddSourceWrapper("geo")
+	}
+	flatteningTTL := endpoint.TTL(cfg.CNAMEFlatteningTTL.Seconds())
+	if cfg.FlattenCoexistingCNAMEs {
+		combinedSource = wrappers.NewCoexistingCNAMESource(combinedSource, flatteningTTL)
+		cfg.AddSourceWrapper("coexisting-cname")
+	}
+	if flatten := apexCNAMEFlattening(cfg.Provider); len(cfg.DomainFilter) > 0 && (flatten != nil || cfg.ResolveApexCNAME) {
+		combinedSource = wrappers.NewApexCNAMESource(combinedSource, cfg.DomainFilter, flatten, cfg.ResolveApexCNAME, flatteningTTL)
+		cfg.AddSourceWrapper("apex-cname")
+	}
 	// Filter targets
 	targetFilter := endpoint.NewTargetNetFilterWithExclusions(cfg.TargetNetFilter, cfg.ExcludeTargetNets)
 	if targetFilter.IsEnabled() {
@@ -459,6 +924,25 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 	return combinedSource, nil
 }
 
+// apexCNAMEFlattening returns how the provider publishes CNAME records at the apex of a zone itself, or nil if
+// it can't and they need to be resolved.
+func apexCNAMEFlattening(provider string) func(*endpoint.Endpoint) bool {
+	switch provider {
+	case "aws":
+		return aws.ApexAlias
+	case "cloudflare", "pdns":
+		// Cloudflare flattens CNAME records at the apex, PowerDNS publishes them as ALIAS records.
+		return func(*endpoint.Endpoint) bool { return true }
+	default:
+		return nil
+	}
+}
+
+// controllerDomainFilter returns the domain filter of the controller, which also keeps the DNS names of its shard.
+func controllerDomainFilter(cfg *externaldns.Config, filter *endpoint.DomainFilter) endpoint.DomainFilterInterface {
+	return endpoint.MatchAllDomainFilters{filter, endpoint.NewShardFilter(cfg.ShardIndex, cfg.ShardCount)}
+}
+
 // RegexDomainFilter overrides DomainFilter
 func createDomainFilter(cfg *externaldns.Config) *endpoint.DomainFilter {
 	if cfg.RegexDomainFilter != nil && cfg.RegexDomainFilter.String() != "" {
@@ -482,7 +966,10 @@ func handleSigterm(cancel func()) {
 // The /healthz endpoint returns a 200 OK status to indicate the service is healthy.
 // The /metrics endpoint serves Prometheus metrics.
 // The server listens on the specified address and logs debug information about the endpoints.
-func serveMetrics(address string) {
+// With --metrics-tls-cert it serves over TLS, and with --metrics-tls-client-ca it requires a client certificate
+// for all endpoints but the health checks, which kubelet probes without one.
+func serveMetrics(cfg *externaldns.Config) {
+	address := cfg.MetricsAddress
 	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
@@ -494,5 +981,34 @@ func serveMetrics(address string) {
 
 	http.Handle("/metrics", promhttp.Handler())
 
-	log.Fatal(http.ListenAndServe(address, nil))
+	server := &http.Server{
+		Addr:              address,
+		Handler:           http.DefaultServeMux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if cfg.MetricsTLSCert == "" {
+		log.Fatal(server.ListenAndServe())
+	}
+
+	tlsConfig, err := tlsutils.NewServerTLSConfig(cfg.MetricsTLSCert, cfg.MetricsTLSKey, cfg.MetricsTLSClientCA)
+	if err != nil {
+		log.Fatalf("failed to configure TLS for the metrics server: %v", err)
+	}
+	if tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert {
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		server.Handler = requireClientCert(server.Handler)
+	}
+	server.TLSConfig = tlsConfig
+	log.Fatal(server.ListenAndServeTLS("", ""))
+}
+
+// requireClientCert rejects the requests without a verified client certificate, except for the health checks.
+func requireClientCert(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" && r.URL.Path != "/readyz" && (r.TLS == nil || len(r.TLS.VerifiedChains) == 0) {
+			http.Error(w, "client certificate required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
 }
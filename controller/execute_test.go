@@ -19,7 +19,10 @@ package controller
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -35,6 +38,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	fakeprovider "sigs.k8s.io/external-dns/provider/fakes"
 )
@@ -308,6 +312,9 @@ func TestBuildProvider(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, p)
+				if instrumented, ok := p.(*provider.InstrumentedProvider); ok {
+					p = instrumented.Provider
+				}
 				assert.Contains(t, reflect.TypeOf(p).String(), tt.expectedType)
 			}
 		})
@@ -463,6 +470,53 @@ func TestBuildSourceWithWrappers(t *testing.T) {
 				assert.True(t, cfg.IsSourceWrapperInstrumented("dedup"))
 				assert.True(t, cfg.IsSourceWrapperInstrumented("nat64"))
 				assert.False(t, cfg.IsSourceWrapperInstrumented("target-filter"))
+				assert.False(t, cfg.IsSourceWrapperInstrumented("apex-cname"))
+			},
+		},
+		{
+			name: "configuration with address family wrapper",
+			cfg: &externaldns.Config{
+				APIServerURL:        svr.URL,
+				Sources:             []string{"fake"},
+				AddressFamilyPolicy: "ipv6-only",
+			},
+			asserts: func(t *testing.T, cfg *externaldns.Config) {
+				assert.True(t, cfg.IsSourceWrapperInstrumented("address-family"))
+			},
+		},
+		{
+			name: "configuration with apex CNAME wrapper",
+			cfg: &externaldns.Config{
+				APIServerURL: svr.URL,
+				Sources:      []string{"fake"},
+				Provider:     "aws",
+				DomainFilter: []string{"example.com"},
+			},
+			asserts: func(t *testing.T, cfg *externaldns.Config) {
+				assert.True(t, cfg.IsSourceWrapperInstrumented("apex-cname"))
+			},
+		},
+		{
+			name: "configuration without apex CNAME resolution",
+			cfg: &externaldns.Config{
+				APIServerURL: svr.URL,
+				Sources:      []string{"fake"},
+				Provider:     "google",
+				DomainFilter: []string{"example.com"},
+			},
+			asserts: func(t *testing.T, cfg *externaldns.Config) {
+				assert.False(t, cfg.IsSourceWrapperInstrumented("apex-cname"))
+			},
+		},
+		{
+			name: "configuration with coexisting CNAME wrapper",
+			cfg: &externaldns.Config{
+				APIServerURL:            svr.URL,
+				Sources:                 []string{"fake"},
+				FlattenCoexistingCNAMEs: true,
+			},
+			asserts: func(t *testing.T, cfg *externaldns.Config) {
+				assert.True(t, cfg.IsSourceWrapperInstrumented("coexisting-cname"))
 			},
 		},
 	}
@@ -531,6 +585,75 @@ func TestExecuteOnceDryRunExitsZero(t *testing.T) {
 	assert.Equal(t, 0, code)
 }
 
+func TestExecuteOnceDetailedExitCode(t *testing.T) {
+	code, _, err := runExecuteSubprocess(t, []string{
+		"--source", "fake",
+		"--provider", "inmemory",
+		"--once",
+		"--dry-run",
+		"--detailed-exit-code",
+		"--metrics-address", ":0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, exitCodeChanges, code)
+}
+
+func TestExitCode(t *testing.T) {
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1")}}
+
+	assert.Equal(t, 0, exitCode(&externaldns.Config{}, changes))
+	assert.Equal(t, 0, exitCode(&externaldns.Config{DetailedExitCode: true}, nil))
+	assert.Equal(t, 0, exitCode(&externaldns.Config{DetailedExitCode: true}, &plan.Changes{}))
+	assert.Equal(t, exitCodeChanges, exitCode(&externaldns.Config{DetailedExitCode: true}, changes))
+}
+
+func TestExecuteDiff(t *testing.T) {
+	code, output, err := runExecuteSubprocess(t, []string{
+		"diff",
+		"--source", "fake",
+		"--provider", "inmemory",
+		"--metrics-address", ":0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, output, "to create, 0 to update, 0 to delete.")
+
+	code, _, err = runExecuteSubprocess(t, []string{
+		"diff",
+		"--source", "fake",
+		"--provider", "inmemory",
+		"--detailed-exit-code",
+		"--metrics-address", ":0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, exitCodeChanges, code)
+}
+
+func TestExecuteSubcommandsWithMetricsPortTaken(t *testing.T) {
+	// a running instance serving its metrics in the same pod
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	for _, command := range []string{"validate", "zones", "export", "diff"} {
+		t.Run(command, func(t *testing.T) {
+			code, output, err := runExecuteSubprocess(t, []string{
+				command,
+				"--source", "fake",
+				"--provider", "inmemory",
+				"--metrics-address", listener.Addr().String(),
+				// a slow credential plugin gives a metrics server started before the command the time to fail
+				"--credential-exec-command=sh",
+				"--credential-exec-arg=-c",
+				`--credential-exec-arg=sleep 0.2; echo '{"credentials": {"pdns-api-key": "key"}}'`,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 0, code, output)
+			assert.NotContains(t, output, "address already in use")
+		})
+	}
+}
+
 func TestExecuteUnknownProviderExitsNonZero(t *testing.T) {
 	code, _, err := runExecuteSubprocess(t, []string{
 		"--source", "fake",
@@ -633,13 +756,14 @@ func TestExecuteBuildControllerErrorExitsNonZero(t *testing.T) {
 func TestControllerRunCancelContextStopsLoop(t *testing.T) {
 	// Minimal controller using fake source and inmemory provider.
 	cfg := &externaldns.Config{
-		Sources:    []string{"fake"},
-		Provider:   "inmemory",
-		LogLevel:   "error",
-		LogFormat:  "text",
-		Policy:     "sync",
-		Registry:   "txt",
-		TXTOwnerID: "test-owner",
+		Sources:            []string{"fake"},
+		Provider:           "inmemory",
+		LogLevel:           "error",
+		LogFormat:          "text",
+		Policy:             "sync",
+		ConflictResolution: "targets",
+		Registry:           "txt",
+		TXTOwnerID:         "test-owner",
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -663,3 +787,52 @@ func TestControllerRunCancelContextStopsLoop(t *testing.T) {
 		t.Fatal("controller did not stop after context cancellation")
 	}
 }
+
+func TestBuildControllerWithTargetProbe(t *testing.T) {
+	cfg := &externaldns.Config{
+		Sources:            []string{"fake"},
+		Provider:           "inmemory",
+		Policy:             "sync",
+		ConflictResolution: "targets",
+		Registry:           "txt",
+		TXTOwnerID:         "test-owner",
+		TargetProbe:        "tcp",
+		TargetProbePort:    443,
+		TargetProbeTimeout: time.Second,
+	}
+	ctx := context.Background()
+	src, err := buildSource(ctx, cfg)
+	require.NoError(t, err)
+	domainFilter := createDomainFilter(cfg)
+	p, err := buildProvider(ctx, cfg, domainFilter)
+	require.NoError(t, err)
+	_, err = buildController(ctx, cfg, src, p, domainFilter)
+	require.NoError(t, err)
+	assert.True(t, cfg.IsSourceWrapperInstrumented("reachability"))
+}
+
+func TestRequireClientCert(t *testing.T) {
+	handler := requireClientCert(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	verified := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+
+	for _, tc := range []struct {
+		path     string
+		state    *tls.ConnectionState
+		expected int
+	}{
+		{"/metrics", nil, http.StatusUnauthorized},
+		{"/metrics", &tls.ConnectionState{}, http.StatusUnauthorized},
+		{"/api/v1/plan", &tls.ConnectionState{}, http.StatusUnauthorized},
+		{"/metrics", verified, http.StatusOK},
+		{"/healthz", &tls.ConnectionState{}, http.StatusOK},
+		{"/readyz", &tls.ConnectionState{}, http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.TLS = tc.state
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, tc.expected, rec.Code, tc.path)
+	}
+}
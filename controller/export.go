@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/miekg/dns"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// exportDefaultTTL is the TTL written to zone files for records without one, the default of most providers.
+const exportDefaultTTL = 300
+
+// ownedRecords returns the records of the registry owned by the given owner, sorted by name, type and set identifier.
+func ownedRecords(ctx context.Context, r registry.Registry, ownerID string) ([]*endpoint.Endpoint, error) {
+	records, err := r.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owned := endpoint.FilterEndpointsByOwnerID(ownerID, records)
+	slices.SortFunc(owned, func(a, b *endpoint.Endpoint) int {
+		return cmp.Or(
+			cmp.Compare(a.DNSName, b.DNSName),
+			cmp.Compare(a.RecordType, b.RecordType),
+			cmp.Compare(a.SetIdentifier, b.SetIdentifier),
+		)
+	})
+	return owned, nil
+}
+
+// WriteRecords writes the given records as a table, as JSON if the format is "json", or as a zone file if the format
+// is "zone". The table shows the resource each record was created for.
+func WriteRecords(w io.Writer, records []*endpoint.Endpoint, format string) error {
+	switch format {
+	case "json":
+		if records == nil {
+			records = []*endpoint.Endpoint{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case "zone":
+		return writeZoneFile(w, records)
+	}
+
+	if len(records) == 0 {
+		_, err := fmt.Fprintln(w, "No records.")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tTYPE\tTTL\tTARGETS\tRESOURCE")
+	for _, ep := range records {
+		ttl, resource := "-", "-"
+		if ep.RecordTTL.IsConfigured() {
+			ttl = strconv.FormatInt(int64(ep.RecordTTL), 10)
+		}
+		if r := ep.Labels[endpoint.ResourceLabelKey]; r != "" {
+			resource = r
+		}
+		name := ep.DNSName
+		if ep.SetIdentifier != "" {
+			name += " (" + ep.SetIdentifier + ")"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, ep.RecordType, ttl, strings.Join(ep.Targets, ","), resource)
+	}
+	return tw.Flush()
+}
+
+// writeZoneFile writes one resource record per target in the presentation format of zone files. Records which can't
+// be represented, such as provider-specific aliases, are written as comments.
+func writeZoneFile(w io.Writer, records []*endpoint.Endpoint) error {
+	for _, ep := range records {
+		ttl := int64(exportDefaultTTL)
+		if ep.RecordTTL.IsConfigured() {
+			ttl = int64(ep.RecordTTL)
+		}
+		for _, target := range ep.Targets {
+			if ep.RecordType == endpoint.RecordTypeTXT {
+				target = endpoint.QuoteTXT(target)
+			}
+			rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(ep.DNSName), ttl, ep.RecordType, target))
+			if err != nil || rr == nil {
+				if _, err := fmt.Fprintf(w, "; %s %d IN %s %s\n", dns.Fqdn(ep.DNSName), ttl, ep.RecordType, target); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := fmt.Fprintln(w, rr.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestOwnedRecords(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org"}))
+	ours, err := registry.NewTXTRegistry(p, "", "", "cluster-1", time.Hour, "", nil, nil, false, nil)
+	require.NoError(t, err)
+	theirs, err := registry.NewTXTRegistry(p, "", "", "cluster-2", time.Hour, "", nil, nil, false, nil)
+	require.NoError(t, err)
+
+	www := endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeCNAME, 300, "lb.example.net")
+	www.Labels[endpoint.ResourceLabelKey] = "ingress/default/www"
+	require.NoError(t, ours.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{
+		www,
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2"),
+	}}))
+	require.NoError(t, theirs.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "192.0.2.3"),
+	}}))
+
+	records, err := ownedRecords(context.Background(), ours, "cluster-1")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "api.example.org", records[0].DNSName)
+	assert.Equal(t, "www.example.org", records[1].DNSName)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteRecords(&out, records, "table"))
+	assert.Equal(t, ""+
+		"NAME              TYPE    TTL   TARGETS               RESOURCE\n"+
+		"api.example.org   A       -     192.0.2.1,192.0.2.2   -\n"+
+		"www.example.org   CNAME   300   lb.example.net        ingress/default/www\n", out.String())
+}
+
+func TestWriteRecords(t *testing.T) {
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeCNAME, 60, "lb.example.net"),
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org"),
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, `v=spf1 include:"example.net" -all`),
+		endpoint.NewEndpoint("eu.example.org", endpoint.RecordTypeA, "192.0.2.3").WithSetIdentifier("eu"),
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteRecords(&out, records, "zone"))
+	assert.Equal(t, ""+
+		"api.example.org.\t300\tIN\tA\t192.0.2.1\n"+
+		"api.example.org.\t300\tIN\tA\t192.0.2.2\n"+
+		"www.example.org.\t60\tIN\tCNAME\tlb.example.net.\n"+
+		"example.org.\t300\tIN\tMX\t10 mail.example.org.\n"+
+		"example.org.\t300\tIN\tTXT\t\"v=spf1 include:\\\"example.net\\\" -all\"\n"+
+		"eu.example.org.\t300\tIN\tA\t192.0.2.3\n", out.String())
+
+	out.Reset()
+	require.NoError(t, WriteRecords(&out, records[4:], "table"))
+	assert.Contains(t, out.String(), "eu.example.org (eu)   A")
+
+	out.Reset()
+	require.NoError(t, WriteRecords(&out, records[:1], "json"))
+	assert.JSONEq(t, `[{"dnsName": "api.example.org", "targets": ["192.0.2.1", "192.0.2.2"], "recordType": "A"}]`, out.String())
+
+	out.Reset()
+	require.NoError(t, WriteRecords(&out, nil, "table"))
+	assert.Equal(t, "No records.\n", out.String())
+
+	out.Reset()
+	require.NoError(t, WriteRecords(&out, nil, "json"))
+	assert.JSONEq(t, `[]`, out.String())
+
+	out.Reset()
+	require.NoError(t, WriteRecords(&out, []*endpoint.Endpoint{endpoint.NewEndpoint("alias.example.org", "ALIAS", "lb.example.net")}, "zone"))
+	assert.Equal(t, "; alias.example.org. 300 IN ALIAS lb.example.net\n", out.String())
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// acmeChallengeLabel is the first label of the DNS name of the TXT records of ACME DNS-01 challenges.
+const acmeChallengeLabel = "_acme-challenge"
+
+// isFastSynced returns whether the endpoint is reconciled by the fast synchronization: TXT records of ACME
+// challenges, and TXT records of the resources matching one of the FastSyncResources patterns.
+func (c *Controller) isFastSynced(ep *endpoint.Endpoint) bool {
+	if ep.RecordType != endpoint.RecordTypeTXT {
+		return false
+	}
+	if label, _, _ := strings.Cut(normalizeName(ep.DNSName), "."); label == acmeChallengeLabel {
+		return true
+	}
+	resource := ep.Labels[endpoint.ResourceLabelKey]
+	return resource != "" && slices.ContainsFunc(c.FastSyncResources, func(pattern string) bool {
+		matched, _ := path.Match(pattern, resource)
+		return matched
+	})
+}
+
+// fastEndpoints returns the endpoints reconciled by the fast synchronization.
+func (c *Controller) fastEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var fast []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if c.isFastSynced(ep) {
+			fast = append(fast, ep)
+		}
+	}
+	return fast
+}
+
+// shouldFastSync returns whether a fast synchronization is due, scheduling the next one.
+func (c *Controller) shouldFastSync(now time.Time) bool {
+	if c.FastSyncInterval <= 0 || now.Before(c.nextFastSyncAt) {
+		return false
+	}
+	c.nextFastSyncAt = now.Add(c.FastSyncInterval)
+	return true
+}
+
+// FastSync reconciles the DNS names of the endpoints reconciled by the fast synchronization whose desired endpoints
+// changed since they were last synchronized, so that ACME challenges don't wait for the next synchronization.
+// Until they change, only the source is queried.
+func (c *Controller) FastSync(ctx context.Context) error {
+	_, endpoints, err := c.desiredEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+	fast := c.fastEndpoints(endpoints)
+	changed := changedNames(c.fastSynced, fast)
+	if len(changed) == 0 || c.Paused() {
+		return nil
+	}
+
+	names := slices.Sorted(maps.Keys(changed))
+	records := c.Registry.Records
+	if scoped, ok := c.Registry.(registry.ScopedRegistry); ok {
+		records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+			return scoped.ScopedRecords(ctx, names)
+		}
+	}
+	regRecords, err := c.registryRecords(ctx, records)
+	if err != nil {
+		return err
+	}
+	p, err := c.plan(ctx, filterNames(regRecords, changed), filterNames(endpoints, changed))
+	if err != nil {
+		return err
+	}
+	if p.Changes.HasChanges() {
+		if c.Approval != nil {
+			approved, err := c.Approval.Approved(ctx, p.Changes)
+			if err != nil || !approved {
+				return err
+			}
+		}
+		log.Infof("Fast synchronization of %s", strings.Join(names, ", "))
+		ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
+		if err := c.applyChanges(ctx, p.Changes, c.DomainFilter, c.Registry.GetDomainFilter()); err != nil {
+			return err
+		}
+		if c.Approval != nil {
+			c.Approval.Applied(ctx, p.Changes)
+		}
+		// the records left by the previous synchronization don't match the provider anymore
+		c.forgetSync()
+	}
+	c.fastSynced = fast
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestFastSync(t *testing.T) {
+	app := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	challenge := endpoint.NewEndpoint("_acme-challenge.app.example.com", endpoint.RecordTypeTXT, "token")
+	issuer := endpoint.NewEndpoint("verify.example.com", endpoint.RecordTypeTXT, "proof").
+		WithLabel(endpoint.ResourceLabelKey, "crd/cert-manager/verify")
+	other := endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "2.2.2.2")
+
+	source := &staticSource{endpoints: []*endpoint.Endpoint{app}}
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeTXT},
+		FastSyncInterval:   10 * time.Second,
+		FastSyncResources:  []string{"crd/cert-manager/*"},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+
+	// without changes of the challenges, the registry isn't queried
+	require.NoError(t, ctrl.FastSync(context.Background()))
+	assert.Equal(t, 1, p.RecordsCallCount)
+	assert.Len(t, p.ApplyChangesCalls, 1)
+
+	// only the changed challenges are reconciled
+	source.endpoints = []*endpoint.Endpoint{app, challenge, issuer, other}
+	require.NoError(t, ctrl.FastSync(context.Background()))
+	assert.Equal(t, 2, p.RecordsCallCount)
+	require.Len(t, p.ApplyChangesCalls, 2)
+	assert.ElementsMatch(t, []string{"_acme-challenge.app.example.com", "verify.example.com"}, dnsNames(p.ApplyChangesCalls[1].Create))
+
+	require.NoError(t, ctrl.FastSync(context.Background()))
+	assert.Equal(t, 2, p.RecordsCallCount)
+	assert.Len(t, p.ApplyChangesCalls, 2)
+
+	// paused synchronizations leave the challenges untouched
+	ctrl.Pause()
+	source.endpoints = []*endpoint.Endpoint{app, issuer, other}
+	require.NoError(t, ctrl.FastSync(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 2)
+}
+
+func TestShouldFastSync(t *testing.T) {
+	now := time.Now()
+	ctrl := &Controller{}
+	assert.False(t, ctrl.shouldFastSync(now), "disabled without interval")
+
+	ctrl.FastSyncInterval = 10 * time.Second
+	assert.True(t, ctrl.shouldFastSync(now))
+	assert.False(t, ctrl.shouldFastSync(now.Add(5*time.Second)))
+	assert.True(t, ctrl.shouldFastSync(now.Add(10*time.Second)))
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	adminv1 "sigs.k8s.io/external-dns/pkg/admin/v1"
+	"sigs.k8s.io/external-dns/pkg/tokenreview"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// adminServer implements the gRPC admin API over the controller.
+type adminServer struct {
+	adminv1.UnimplementedAdminServiceServer
+	c *Controller
+}
+
+// writeMethods are the methods of the gRPC admin API changing the state of the controller.
+var writeMethods = []string{
+	adminv1.AdminService_TriggerSync_FullMethodName,
+	adminv1.AdminService_Pause_FullMethodName,
+	adminv1.AdminService_Resume_FullMethodName,
+}
+
+// GRPCServer returns a server of the gRPC admin API, which serves the same state and controls as APIHandler.
+// Calls must carry a bearer token accepted by auth in their authorization metadata, one allowed to write for the
+// calls changing the state of the controller.
+func (c *Controller) GRPCServer(auth APIAuth) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var provided string
+		if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+			provided, _ = strings.CutPrefix(values[0], "Bearer ")
+		}
+		user, err := auth.authenticate(ctx, provided, slices.Contains(writeMethods, info.FullMethod))
+		switch {
+		case err == nil:
+			if user != "" {
+				log.Debugf("gRPC call %s by %s", info.FullMethod, user)
+			}
+			return handler(ctx, req)
+		case errors.Is(err, errForbidden):
+			return nil, status.Error(codes.PermissionDenied, "forbidden")
+		case !errors.Is(err, tokenreview.ErrUnauthorized):
+			log.Warnf("Failed to authenticate gRPC call: %v", err)
+			return nil, status.Error(codes.Unavailable, "failed to authenticate")
+		default:
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+	}))
+	adminv1.RegisterAdminServiceServer(server, &adminServer{c: c})
+	return server
+}
+
+func (s *adminServer) state() (*syncState, error) {
+	state := s.c.lastState()
+	if state == nil {
+		return nil, status.Error(codes.Unavailable, "no synchronization has completed yet")
+	}
+	return state, nil
+}
+
+func (s *adminServer) GetPlan(_ context.Context, _ *adminv1.GetPlanRequest) (*adminv1.GetPlanResponse, error) {
+	state, err := s.state()
+	if err != nil {
+		return nil, err
+	}
+	response := &adminv1.GetPlanResponse{
+		UpdatedAt: timestamppb.New(state.updatedAt),
+		Changes:   toProtoChanges(state.changes),
+		Paused:    s.c.Paused(),
+	}
+	for _, skipped := range state.skipped {
+		response.Skipped = append(response.Skipped, &adminv1.Skipped{
+			Endpoint: toProtoEndpoint(skipped.Endpoint),
+			Reason:   skipped.Reason,
+			Action:   skipped.Action,
+			Message:  skipped.Message,
+		})
+	}
+	for _, annotation := range state.annotations {
+		response.Annotations = append(response.Annotations, &adminv1.Annotation{
+			Endpoint: toProtoEndpoint(annotation.Endpoint),
+			Action:   annotation.Action,
+			Message:  annotation.Message,
+		})
+	}
+	return response, nil
+}
+
+func (s *adminServer) GetRecords(_ context.Context, req *adminv1.GetRecordsRequest) (*adminv1.GetRecordsResponse, error) {
+	state, err := s.state()
+	if err != nil {
+		return nil, err
+	}
+	endpoints := state.actual
+	switch req.GetRecordSet() {
+	case adminv1.RecordSet_RECORD_SET_UNSPECIFIED, adminv1.RecordSet_RECORD_SET_ACTUAL:
+	case adminv1.RecordSet_RECORD_SET_DESIRED:
+		endpoints = state.desired
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown record set %v", req.GetRecordSet())
+	}
+	return &adminv1.GetRecordsResponse{
+		UpdatedAt: timestamppb.New(state.updatedAt),
+		Endpoints: toProtoEndpoints(endpoints),
+	}, nil
+}
+
+func (s *adminServer) TriggerSync(_ context.Context, _ *adminv1.TriggerSyncRequest) (*adminv1.TriggerSyncResponse, error) {
+	now := time.Now()
+	s.c.ScheduleRunOnce(now)
+	s.c.runAtMutex.Lock()
+	// a synchronization due in the past starts at the next tick of Run
+	scheduledAt := latest(s.c.nextRunAt, now)
+	s.c.runAtMutex.Unlock()
+	return &adminv1.TriggerSyncResponse{ScheduledAt: timestamppb.New(scheduledAt)}, nil
+}
+
+func (s *adminServer) Pause(_ context.Context, _ *adminv1.PauseRequest) (*adminv1.PauseResponse, error) {
+	s.c.Pause()
+	return &adminv1.PauseResponse{Paused: true}, nil
+}
+
+func (s *adminServer) Resume(_ context.Context, _ *adminv1.ResumeRequest) (*adminv1.PauseResponse, error) {
+	s.c.Resume()
+	return &adminv1.PauseResponse{Paused: false}, nil
+}
+
+func (s *adminServer) GetPauseState(_ context.Context, _ *adminv1.GetPauseStateRequest) (*adminv1.PauseResponse, error) {
+	return &adminv1.PauseResponse{Paused: s.c.Paused()}, nil
+}
+
+func toProtoChanges(changes *plan.Changes) *adminv1.Changes {
+	if changes == nil {
+		return &adminv1.Changes{}
+	}
+	return &adminv1.Changes{
+		Create:    toProtoEndpoints(changes.Create),
+		UpdateOld: toProtoEndpoints(changes.UpdateOld),
+		UpdateNew: toProtoEndpoints(changes.UpdateNew),
+		Delete:    toProtoEndpoints(changes.Delete),
+	}
+}
+
+func toProtoEndpoints(endpoints []*endpoint.Endpoint) []*adminv1.Endpoint {
+	result := make([]*adminv1.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		result = append(result, toProtoEndpoint(ep))
+	}
+	return result
+}
+
+func toProtoEndpoint(ep *endpoint.Endpoint) *adminv1.Endpoint {
+	if ep == nil {
+		return nil
+	}
+	result := &adminv1.Endpoint{
+		DnsName:       ep.DNSName,
+		Targets:       ep.Targets,
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+		RecordTtl:     int64(ep.RecordTTL),
+		Labels:        ep.Labels,
+	}
+	for _, property := range ep.ProviderSpecific {
+		result.ProviderSpecific = append(result.ProviderSpecific, &adminv1.ProviderSpecificProperty{Name: property.Name, Value: property.Value})
+	}
+	return result
+}
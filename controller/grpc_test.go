@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	adminv1 "sigs.k8s.io/external-dns/pkg/admin/v1"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// newAdminClient serves the gRPC admin API of the controller in memory and returns a client of it.
+func newAdminClient(t *testing.T, ctrl *Controller, auth APIAuth) adminv1.AdminServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := ctrl.GRPCServer(auth)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return adminv1.NewAdminServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGRPCServer(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+	client := newAdminClient(t, ctrl, APIAuth{Token: "secret"})
+
+	_, err = client.GetPlan(context.Background(), &adminv1.GetPlanRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.GetPlan(withToken("wrong"), &adminv1.GetPlanRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.GetPlan(withToken("secret"), &adminv1.GetPlanRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	planned, err := client.GetPlan(withToken("secret"), &adminv1.GetPlanRequest{})
+	require.NoError(t, err)
+	require.Len(t, planned.GetChanges().GetCreate(), 1)
+	assert.Equal(t, "new.example.com", planned.GetChanges().GetCreate()[0].GetDnsName())
+	assert.Equal(t, []string{"1.2.3.4"}, planned.GetChanges().GetCreate()[0].GetTargets())
+	require.Len(t, planned.GetChanges().GetDelete(), 1)
+	assert.Equal(t, "old.example.com", planned.GetChanges().GetDelete()[0].GetDnsName())
+	assert.False(t, planned.GetPaused())
+
+	actual, err := client.GetRecords(withToken("secret"), &adminv1.GetRecordsRequest{})
+	require.NoError(t, err)
+	require.Len(t, actual.GetEndpoints(), 1)
+	assert.Equal(t, "old.example.com", actual.GetEndpoints()[0].GetDnsName())
+
+	desired, err := client.GetRecords(withToken("secret"), &adminv1.GetRecordsRequest{RecordSet: adminv1.RecordSet_RECORD_SET_DESIRED})
+	require.NoError(t, err)
+	require.Len(t, desired.GetEndpoints(), 1)
+	assert.Equal(t, "new.example.com", desired.GetEndpoints()[0].GetDnsName())
+}
+
+func TestGRPCServerControls(t *testing.T) {
+	ctrl := &Controller{Interval: time.Hour}
+	client := newAdminClient(t, ctrl, APIAuth{
+		Token:      "read-secret",
+		WriteToken: "secret",
+		Tokens:     fakeTokenAuthenticator{"automation": nil, "dashboard": nil, "broken": errors.New("unreachable")},
+		Writers:    []string{"system:serviceaccount:monitoring:automation"},
+	})
+
+	_, err := client.Pause(withToken("read-secret"), &adminv1.PauseRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.TriggerSync(withToken("dashboard"), &adminv1.TriggerSyncRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.False(t, ctrl.Paused())
+
+	paused, err := client.Pause(withToken("automation"), &adminv1.PauseRequest{})
+	require.NoError(t, err)
+	assert.True(t, paused.GetPaused())
+	assert.True(t, ctrl.Paused())
+
+	paused, err = client.GetPauseState(withToken("dashboard"), &adminv1.GetPauseStateRequest{})
+	require.NoError(t, err)
+	assert.True(t, paused.GetPaused())
+
+	paused, err = client.GetPauseState(withToken("read-secret"), &adminv1.GetPauseStateRequest{})
+	require.NoError(t, err)
+	assert.True(t, paused.GetPaused())
+
+	paused, err = client.Resume(withToken("secret"), &adminv1.ResumeRequest{})
+	require.NoError(t, err)
+	assert.False(t, paused.GetPaused())
+	assert.False(t, ctrl.Paused())
+
+	before := time.Now()
+	triggered, err := client.TriggerSync(withToken("secret"), &adminv1.TriggerSyncRequest{})
+	require.NoError(t, err)
+	assert.WithinDuration(t, before, triggered.GetScheduledAt().AsTime(), defaultEventDebounce+time.Second)
+	assert.True(t, ctrl.ShouldRunOnce(time.Now().Add(defaultEventDebounce)))
+
+	_, err = client.Pause(withToken("broken"), &adminv1.PauseRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// syncHealth tracks when the records of each zone were last synchronized successfully.
+type syncHealth struct {
+	mu          sync.Mutex
+	startedAt   time.Time
+	lastSuccess time.Time
+	zones       map[string]time.Time
+}
+
+// zoneHealth is the health of a zone returned by the readiness endpoint.
+type zoneHealth struct {
+	Zone        string     `json:"zone"`
+	LastSuccess *time.Time `json:"lastSuccess"`
+	Ready       bool       `json:"ready"`
+}
+
+// healthResponse is returned by the readiness endpoint.
+type healthResponse struct {
+	Ready       bool         `json:"ready"`
+	LastSuccess *time.Time   `json:"lastSuccess"`
+	Zones       []zoneHealth `json:"zones"`
+}
+
+// synced records a successful synchronization of the given zones, or of all records if all is set.
+func (h *syncHealth) synced(now time.Time, all bool, zones ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if all {
+		h.lastSuccess = now
+	}
+	if h.zones == nil {
+		h.zones = map[string]time.Time{}
+	}
+	for _, z := range zones {
+		h.zones[z] = now
+	}
+}
+
+// known makes the given zones reported before their first successful synchronization.
+func (h *syncHealth) known(zones ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.zones == nil {
+		h.zones = map[string]time.Time{}
+	}
+	for _, z := range zones {
+		if _, ok := h.zones[z]; !ok {
+			h.zones[z] = time.Time{}
+		}
+	}
+}
+
+// lastSuccesses returns the last successful synchronization of all records and of the given zones, zero for
+// those never synchronized.
+func (h *syncHealth) lastSuccesses(zones []string) (time.Time, map[string]time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	successes := make(map[string]time.Time, len(zones))
+	for _, z := range zones {
+		successes[z] = h.zones[z]
+	}
+	return h.lastSuccess, successes
+}
+
+// report returns the health as of now. Without maxAge everything is ready, otherwise a zone is ready if it
+// was synchronized successfully within maxAge, counting from startup for zones never synchronized.
+func (h *syncHealth) report(now time.Time, maxAge time.Duration) healthResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ready := func(t time.Time) bool {
+		return maxAge <= 0 || now.Sub(latest(h.startedAt, t)) <= maxAge
+	}
+	response := healthResponse{
+		Ready:       ready(h.lastSuccess),
+		LastSuccess: timeOrNil(h.lastSuccess),
+		Zones:       make([]zoneHealth, 0, len(h.zones)),
+	}
+	for zone, t := range h.zones {
+		zh := zoneHealth{Zone: zone, LastSuccess: timeOrNil(t), Ready: ready(t)}
+		response.Zones = append(response.Zones, zh)
+		response.Ready = response.Ready && zh.Ready
+	}
+	slices.SortFunc(response.Zones, func(a, b zoneHealth) int {
+		return strings.Compare(a.Zone, b.Zone)
+	})
+	return response
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// ReadinessHandler returns a handler reporting when the records of each zone known from the domain filters
+// were last synchronized successfully. It fails with 503 Service Unavailable once the last successful
+// synchronization of all records or of any zone is older than maxAge, so that silently failing instances can
+// be detected. Without maxAge, it only reports the details.
+func (c *Controller) ReadinessHandler(maxAge time.Duration) http.Handler {
+	c.health.mu.Lock()
+	c.health.startedAt = time.Now()
+	c.health.mu.Unlock()
+	c.health.known(c.zones()...)
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response := c.health.report(time.Now(), maxAge)
+		if !response.Ready {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		writeJSON(w, response)
+	})
+}
+
+// zones returns the normalized names of the zones known from the domain filters.
+func (c *Controller) zones() []string {
+	var zones []string
+	for _, z := range zoneNames(c.DomainFilter, c.Registry.GetDomainFilter()) {
+		z = strings.ToLower(strings.Trim(z, "."))
+		if z != "" && !slices.Contains(zones, z) {
+			zones = append(zones, z)
+		}
+	}
+	return zones
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.bad.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.good.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	p := &zoneFailingProvider{
+		filteredMockProvider: filteredMockProvider{domainFilter: endpoint.NewDomainFilter([]string{"bad.com", "good.com", "quiet.com."})},
+		failingZone:          "bad.com",
+		err:                  errors.New("zone failure"),
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneBatching:       true,
+	}
+
+	get := func(handler http.Handler) (int, healthResponse) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var response healthResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		return rec.Code, response
+	}
+
+	handler := ctrl.ReadinessHandler(0)
+	strictHandler := ctrl.ReadinessHandler(10 * time.Millisecond)
+	lenientHandler := ctrl.ReadinessHandler(time.Minute)
+
+	code, response := get(handler)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, response.Ready)
+	assert.Nil(t, response.LastSuccess)
+	require.Len(t, response.Zones, 3)
+	assert.Nil(t, response.Zones[0].LastSuccess)
+
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	time.Sleep(20 * time.Millisecond)
+
+	// the failing zone and the whole synchronization are older than the maximum age
+	code, response = get(strictHandler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, response.Ready)
+	assert.Nil(t, response.LastSuccess)
+	assert.Equal(t, "bad.com", response.Zones[0].Zone)
+	assert.Nil(t, response.Zones[0].LastSuccess)
+	assert.Equal(t, "good.com", response.Zones[1].Zone)
+	assert.NotNil(t, response.Zones[1].LastSuccess)
+	assert.Equal(t, "quiet.com", response.Zones[2].Zone)
+	assert.NotNil(t, response.Zones[2].LastSuccess)
+
+	code, response = get(lenientHandler)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, response.Ready)
+}
+
+func TestSyncHealthReport(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := &syncHealth{startedAt: start}
+	h.known("example.com", "example.org")
+	h.synced(start.Add(time.Minute), true, "example.com", "example.org")
+	h.synced(start.Add(10*time.Minute), false, "example.com")
+
+	response := h.report(start.Add(12*time.Minute), 5*time.Minute)
+	assert.False(t, response.Ready)
+	assert.Equal(t, start.Add(time.Minute), *response.LastSuccess)
+	assert.Equal(t, []zoneHealth{
+		{Zone: "example.com", LastSuccess: ptrTo(start.Add(10 * time.Minute)), Ready: true},
+		{Zone: "example.org", LastSuccess: ptrTo(start.Add(time.Minute)), Ready: false},
+	}, response.Zones)
+
+	assert.True(t, h.report(start.Add(12*time.Minute), 0).Ready)
+	assert.True(t, h.report(start.Add(5*time.Minute), 5*time.Minute).Ready)
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// syncedRecords is the outcome of a successful synchronization.
+type syncedRecords struct {
+	// fullSyncAt is the time of the last synchronization which fetched the records from the registry
+	fullSyncAt time.Time
+	// desired is the desired endpoints
+	desired []*endpoint.Endpoint
+	// current is the records of the registry once the changes were applied
+	current []*endpoint.Endpoint
+}
+
+// previousSync returns the previous synchronization to continue from, or nil if a full synchronization is due.
+func (c *Controller) previousSync(now time.Time) *syncedRecords {
+	if !c.IncrementalSync || c.lastSync == nil || now.Sub(c.lastSync.fullSyncAt) >= c.FullResyncInterval {
+		return nil
+	}
+	return c.lastSync
+}
+
+// rememberSync keeps the outcome of a successful synchronization for the next incremental one.
+func (c *Controller) rememberSync(p *plan.Plan, previous *syncedRecords) {
+	if !c.IncrementalSync {
+		return
+	}
+	fullSyncAt := time.Now()
+	if previous != nil {
+		fullSyncAt = previous.fullSyncAt
+	}
+	c.lastSync = &syncedRecords{
+		fullSyncAt: fullSyncAt,
+		desired:    p.Desired,
+		current:    applyToRecords(p.Current, p.Changes),
+	}
+}
+
+// forgetSync makes the next synchronization a full one, as the records may not match the previous one anymore.
+func (c *Controller) forgetSync() {
+	c.lastSync = nil
+}
+
+// applyToRecords returns the records resulting from applying the changes to the given records.
+func applyToRecords(records []*endpoint.Endpoint, changes *plan.Changes) []*endpoint.Endpoint {
+	removed := map[endpoint.EndpointKey]bool{}
+	for _, ep := range slices.Concat(changes.UpdateOld, changes.Delete) {
+		removed[ep.Key()] = true
+	}
+	result := make([]*endpoint.Endpoint, 0, len(records)+len(changes.Create))
+	for _, ep := range records {
+		if !removed[ep.Key()] {
+			result = append(result, ep)
+		}
+	}
+	return append(result, slices.Concat(changes.Create, changes.UpdateNew)...)
+}
+
+// changedNames returns the DNS names whose endpoints differ between the two lists. Both lists are complete, as
+// the sources are listed in full on each synchronization: only the planning is restricted to the changed names.
+func changedNames(previous, next []*endpoint.Endpoint) map[string]bool {
+	before, after := endpointsByName(previous), endpointsByName(next)
+	changed := map[string]bool{}
+	for name, eps := range before {
+		if !slices.Equal(eps, after[name]) {
+			changed[name] = true
+		}
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			changed[name] = true
+		}
+	}
+	return changed
+}
+
+// endpointsByName returns the sorted descriptions of the endpoints of each DNS name, labels included.
+func endpointsByName(endpoints []*endpoint.Endpoint) map[string][]string {
+	result := map[string][]string{}
+	for _, ep := range endpoints {
+		name := normalizeName(ep.DNSName)
+		result[name] = append(result[name], ep.String()+" "+ep.Labels.SerializePlain(false))
+	}
+	for _, eps := range result {
+		slices.Sort(eps)
+	}
+	return result
+}
+
+// filterNames returns the endpoints with one of the given DNS names.
+func filterNames(endpoints []*endpoint.Endpoint, names map[string]bool) []*endpoint.Endpoint {
+	result := make([]*endpoint.Endpoint, 0, len(names))
+	for _, ep := range endpoints {
+		if names[normalizeName(ep.DNSName)] {
+			result = append(result, ep)
+		}
+	}
+	return result
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
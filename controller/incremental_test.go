@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// staticSource returns its endpoints, which tests change between synchronizations.
+type staticSource struct {
+	endpoints []*endpoint.Endpoint
+}
+
+func (s *staticSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return s.endpoints, nil
+}
+
+func (s *staticSource) AddEventHandler(_ context.Context, _ func()) {}
+
+func TestRunOnceIncrementalSync(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	b := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	c := endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	bUpdated := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "2.2.2.2")
+
+	source := &staticSource{endpoints: []*endpoint.Endpoint{a, b}}
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		IncrementalSync:    true,
+		FullResyncInterval: time.Hour,
+	}
+
+	// the first synchronization is a full one
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 1, p.RecordsCallCount)
+	require.Len(t, p.ApplyChangesCalls, 1)
+	assert.Len(t, p.ApplyChangesCalls[0].Create, 2)
+
+	// only the changed names are planned, against the records left by the previous synchronization
+	source.endpoints = []*endpoint.Endpoint{a, bUpdated, c}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 1, p.RecordsCallCount)
+	require.Len(t, p.ApplyChangesCalls, 2)
+	assert.Equal(t, []*endpoint.Endpoint{c}, p.ApplyChangesCalls[1].Create)
+	assert.Equal(t, []*endpoint.Endpoint{bUpdated}, p.ApplyChangesCalls[1].UpdateNew)
+	assert.Len(t, ctrl.lastState().desired, 3)
+	assert.Len(t, ctrl.lastState().actual, 2)
+
+	// nothing changed
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 1, p.RecordsCallCount)
+	assert.Len(t, p.ApplyChangesCalls, 2)
+
+	source.endpoints = []*endpoint.Endpoint{bUpdated, c}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 3)
+	assert.Equal(t, []string{"a.example.com"}, dnsNames(p.ApplyChangesCalls[2].Delete))
+
+	// the records are fetched again once the full resync interval elapsed
+	ctrl.lastSync.fullSyncAt = time.Now().Add(-time.Hour)
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 2, p.RecordsCallCount)
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 2, p.RecordsCallCount)
+}
+
+func TestRunOnceIncrementalSyncAfterError(t *testing.T) {
+	source := &staticSource{endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}}
+	p := &rejectingProvider{rejected: "b.example.com"}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		IncrementalSync:    true,
+		FullResyncInterval: time.Hour,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 1, p.RecordsCallCount)
+
+	source.endpoints = append(source.endpoints, endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.1.1.1"))
+	require.ErrorIs(t, ctrl.RunOnce(context.Background()), provider.SoftError)
+	assert.Equal(t, 1, p.RecordsCallCount)
+
+	// the records may not match the previous synchronization anymore, so the next one is full
+	require.ErrorIs(t, ctrl.RunOnce(context.Background()), provider.SoftError)
+	assert.Equal(t, 2, p.RecordsCallCount)
+}
+
+func TestChangedNames(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	aTXT := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeTXT, "text")
+	b := endpoint.NewEndpoint("B.example.com.", endpoint.RecordTypeA, "1.1.1.1")
+	bLabeled := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/b")
+	c := endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.1.1.1")
+
+	assert.Empty(t, changedNames([]*endpoint.Endpoint{a, aTXT}, []*endpoint.Endpoint{aTXT, a}))
+	assert.Equal(t, map[string]bool{"b.example.com": true, "c.example.com": true},
+		changedNames([]*endpoint.Endpoint{a, b}, []*endpoint.Endpoint{a, bLabeled, c}))
+	assert.Equal(t, map[string]bool{"a.example.com": true}, changedNames([]*endpoint.Endpoint{a, aTXT}, []*endpoint.Endpoint{aTXT}))
+}
+
+func TestApplyToRecords(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	b := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	bUpdated := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "2.2.2.2")
+	c := endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	d := endpoint.NewEndpoint("d.example.com", endpoint.RecordTypeA, "1.1.1.1")
+
+	assert.Equal(t, []*endpoint.Endpoint{c, d, bUpdated}, applyToRecords([]*endpoint.Endpoint{a, b, c}, &plan.Changes{
+		Create:    []*endpoint.Endpoint{d},
+		UpdateOld: []*endpoint.Endpoint{b},
+		UpdateNew: []*endpoint.Endpoint{bUpdated},
+		Delete:    []*endpoint.Endpoint{a},
+	}))
+}
+
+func dnsNames(endpoints []*endpoint.Endpoint) []string {
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName)
+	}
+	return names
+}
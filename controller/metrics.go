@@ -16,7 +16,12 @@ limitations under the License.
 
 package controller
 
-import "sigs.k8s.io/external-dns/endpoint"
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
 
 type metricsRecorder struct {
 	counterPerEndpointType map[string]int
@@ -34,6 +39,9 @@ func newMetricsRecorder() *metricsRecorder {
 			endpoint.RecordTypePTR:   0,
 			endpoint.RecordTypeMX:    0,
 			endpoint.RecordTypeNAPTR: 0,
+			endpoint.RecordTypeSVCB:  0,
+			endpoint.RecordTypeHTTPS: 0,
+			endpoint.RecordTypeTLSA:  0,
 		},
 	}
 }
@@ -52,3 +60,28 @@ func (m *metricsRecorder) getEndpointTypeCount(endpointType string) int {
 func (m *metricsRecorder) loadFloat64(endpointType string) float64 {
 	return float64(m.getEndpointTypeCount(endpointType))
 }
+
+// changeKey identifies the changes of a zone with the same record type and action.
+type changeKey struct {
+	zone       string
+	recordType string
+	action     string
+}
+
+// countChanges counts the changes by zone, record type and action, an update counting once.
+// Changes outside of the given zones are counted under the empty zone.
+func countChanges(changes *plan.Changes, zones []string) map[changeKey]int {
+	counts := map[changeKey]int{}
+	for _, zc := range changes.SplitByZone(zones) {
+		for action, endpoints := range map[string][]*endpoint.Endpoint{
+			"create": zc.Changes.Create,
+			"update": zc.Changes.UpdateNew,
+			"delete": zc.Changes.Delete,
+		} {
+			for _, ep := range endpoints {
+				counts[changeKey{zone: zc.Zone, recordType: strings.ToLower(ep.RecordType), action: action}]++
+			}
+		}
+	}
+	return counts
+}
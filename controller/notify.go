@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// addApplied collects the changes applied by the synchronization in progress, for the notifier.
+func (c *Controller) addApplied(changes *plan.Changes) {
+	if c.Notifier == nil {
+		return
+	}
+	c.appliedMutex.Lock()
+	defer c.appliedMutex.Unlock()
+	c.applied.Create = slices.Concat(c.applied.Create, changes.Create)
+	c.applied.UpdateOld = slices.Concat(c.applied.UpdateOld, changes.UpdateOld)
+	c.applied.UpdateNew = slices.Concat(c.applied.UpdateNew, changes.UpdateNew)
+	c.applied.Delete = slices.Concat(c.applied.Delete, changes.Delete)
+}
+
+// notify notifies of the changes applied by the synchronization which ended with the given error, if it
+// applied changes or failed.
+func (c *Controller) notify(ctx context.Context, err error) {
+	if c.Notifier == nil {
+		return
+	}
+	c.appliedMutex.Lock()
+	applied := c.applied
+	c.applied = plan.Changes{}
+	c.appliedMutex.Unlock()
+
+	if err == nil && !applied.HasChanges() {
+		return
+	}
+	// the synchronization may have been cancelled or have timed out, which shouldn't prevent the notification
+	c.Notifier.Notify(context.WithoutCancel(ctx), &applied, err)
+}
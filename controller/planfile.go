@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// savedPlanVersion is the version of the format of saved plans, raised on incompatible changes.
+const savedPlanVersion = 1
+
+// SavedPlan holds the changes of a plan saved by the plan command, to be applied by the apply command once
+// reviewed.
+type SavedPlan struct {
+	Version int `json:"version"`
+	// OwnerID is the owner ID of the instance which calculated the plan
+	OwnerID string `json:"ownerID"`
+	// CreatedAt is the time the plan was calculated
+	CreatedAt time.Time     `json:"createdAt"`
+	Changes   *plan.Changes `json:"changes"`
+}
+
+// NewSavedPlan returns the saved plan of the given changes.
+func NewSavedPlan(ownerID string, createdAt time.Time, changes *plan.Changes) *SavedPlan {
+	return &SavedPlan{
+		Version:   savedPlanVersion,
+		OwnerID:   ownerID,
+		CreatedAt: createdAt.UTC(),
+		Changes:   changes,
+	}
+}
+
+// WritePlan writes the given plan as JSON.
+func WritePlan(w io.Writer, saved *SavedPlan) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(saved)
+}
+
+// ReadPlan reads a plan written by WritePlan.
+func ReadPlan(r io.Reader) (*SavedPlan, error) {
+	var saved SavedPlan
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("decoding the plan: %w", err)
+	}
+	if saved.Version != savedPlanVersion {
+		return nil, fmt.Errorf("unsupported plan version %d, expected %d", saved.Version, savedPlanVersion)
+	}
+	if saved.Changes == nil {
+		saved.Changes = &plan.Changes{}
+	}
+	if len(saved.Changes.UpdateOld) != len(saved.Changes.UpdateNew) {
+		return nil, fmt.Errorf("the plan has %d old and %d new records to update", len(saved.Changes.UpdateOld), len(saved.Changes.UpdateNew))
+	}
+	// registries add their labels to the records, which JSON leaves without any when they had none
+	for _, ep := range slices.Concat(saved.Changes.Create, saved.Changes.UpdateOld, saved.Changes.UpdateNew, saved.Changes.Delete) {
+		if ep.Labels == nil {
+			ep.Labels = endpoint.NewLabels()
+		}
+	}
+	return &saved, nil
+}
+
+// ApplyPlan applies the changes of a saved plan after checking that it was calculated for the given owner, that it
+// isn't older than maxAge, if set, and that the records it changes are still as they were when it was calculated.
+// Approval isn't requested, as reviewing the saved plan takes its place.
+func (c *Controller) ApplyPlan(ctx context.Context, saved *SavedPlan, ownerID string, maxAge time.Duration) error {
+	if saved.OwnerID != ownerID {
+		return fmt.Errorf("the plan was calculated for the owner ID %q, not %q", saved.OwnerID, ownerID)
+	}
+	if age := time.Since(saved.CreatedAt); maxAge > 0 && age > maxAge {
+		return fmt.Errorf("the plan was calculated %s ago, more than the maximum age of %s", age.Round(time.Second), maxAge)
+	}
+	if c.Paused() {
+		return errors.New("synchronization is paused")
+	}
+	if !saved.Changes.HasChanges() {
+		log.Info("The plan has no changes")
+		return nil
+	}
+
+	current, err := c.registryRecords(ctx, c.Registry.Records)
+	if err != nil {
+		return err
+	}
+	if stale := staleChanges(saved.Changes, current); len(stale) > 0 {
+		return fmt.Errorf("the records changed since the plan was calculated: %s", strings.Join(stale, "; "))
+	}
+
+	log.Infof("Applying the plan calculated at %s: %d creations, %d updates and %d deletions", saved.CreatedAt.Format(time.RFC3339),
+		len(saved.Changes.Create), len(saved.Changes.UpdateNew), len(saved.Changes.Delete))
+	ctx = context.WithValue(ctx, provider.RecordsContextKey, current)
+	return c.applyChanges(ctx, saved.Changes, c.DomainFilter, c.Registry.GetDomainFilter())
+}
+
+// staleChanges describes the changes which no longer match the current records: records to create which now exist,
+// and records to update or delete which are gone or whose targets, TTL or owner changed.
+func staleChanges(changes *plan.Changes, current []*endpoint.Endpoint) []string {
+	byKey := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(current))
+	for _, ep := range current {
+		byKey[ep.Key()] = ep
+	}
+
+	var stale []string
+	for _, ep := range changes.Create {
+		if _, ok := byKey[ep.Key()]; ok {
+			stale = append(stale, fmt.Sprintf("%s %s was created", ep.DNSName, ep.RecordType))
+		}
+	}
+	for _, ep := range slices.Concat(changes.UpdateOld, changes.Delete) {
+		existing, ok := byKey[ep.Key()]
+		switch {
+		case !ok:
+			stale = append(stale, fmt.Sprintf("%s %s was deleted", ep.DNSName, ep.RecordType))
+		case !existing.Targets.Same(ep.Targets) || existing.RecordTTL != ep.RecordTTL:
+			stale = append(stale, fmt.Sprintf("%s %s was updated", ep.DNSName, ep.RecordType))
+		case existing.Labels[endpoint.OwnerLabelKey] != ep.Labels[endpoint.OwnerLabelKey]:
+			stale = append(stale, fmt.Sprintf("%s %s changed owner", ep.DNSName, ep.RecordType))
+		}
+	}
+	return stale
+}
+
+// writePlanFile writes the given plan to the file at path, or to the standard output if path is "-".
+func writePlanFile(path string, saved *SavedPlan) error {
+	if path == "-" {
+		return WritePlan(os.Stdout, saved)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := WritePlan(f, saved); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing the plan to %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// readPlanFile reads the plan of the file at path, or of the standard input if path is "-".
+func readPlanFile(path string) (*SavedPlan, error) {
+	if path == "-" {
+		return ReadPlan(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	saved, err := ReadPlan(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading the plan of %s: %w", path, err)
+	}
+	return saved, nil
+}
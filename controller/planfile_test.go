@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestWritePlanReadPlan(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeA, 300, "192.0.2.1")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "192.0.2.2").WithLabel(endpoint.OwnerLabelKey, "default")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "192.0.2.3").WithLabel(endpoint.OwnerLabelKey, "default")},
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, writePlanFile(path, NewSavedPlan("default", createdAt, changes)))
+	saved, err := readPlanFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "default", saved.OwnerID)
+	assert.Equal(t, createdAt, saved.CreatedAt)
+	assert.Equal(t, changes, saved.Changes)
+
+	for _, tt := range []struct {
+		content   string
+		expectErr string
+	}{
+		{content: `{"version": 2}`, expectErr: "unsupported plan version 2"},
+		{content: `{"version": 1, "changes": {"updateOld": [{"dnsName": "a.example.com"}]}}`, expectErr: "1 old and 0 new records"},
+		{content: `not a plan`, expectErr: "decoding the plan"},
+	} {
+		_, err := ReadPlan(strings.NewReader(tt.content))
+		assert.ErrorContains(t, err, tt.expectErr)
+	}
+
+	saved, err = ReadPlan(strings.NewReader(`{"version": 1}`))
+	require.NoError(t, err)
+	assert.False(t, saved.Changes.HasChanges())
+}
+
+func TestControllerApplyPlan(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "192.0.2.2").WithLabel(endpoint.OwnerLabelKey, "default"),
+		endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.0.2.4").WithLabel(endpoint.OwnerLabelKey, "default"),
+	}
+	changes := func() *plan.Changes {
+		return &plan.Changes{
+			Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.1")},
+			UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "192.0.2.2").WithLabel(endpoint.OwnerLabelKey, "default")},
+			UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "192.0.2.3").WithLabel(endpoint.OwnerLabelKey, "default")},
+			Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.0.2.4").WithLabel(endpoint.OwnerLabelKey, "default")},
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		saved     *SavedPlan
+		current   []*endpoint.Endpoint
+		expectErr string
+	}{
+		{
+			name:    "up to date",
+			saved:   NewSavedPlan("default", time.Now(), changes()),
+			current: current,
+		},
+		{
+			name:      "other owner",
+			saved:     NewSavedPlan("other", time.Now(), changes()),
+			current:   current,
+			expectErr: `calculated for the owner ID "other"`,
+		},
+		{
+			name:      "too old",
+			saved:     NewSavedPlan("default", time.Now().Add(-2*time.Hour), changes()),
+			current:   current,
+			expectErr: "more than the maximum age of 1h0m0s",
+		},
+		{
+			name:  "records changed",
+			saved: NewSavedPlan("default", time.Now(), changes()),
+			current: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+				endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "192.0.2.5").WithLabel(endpoint.OwnerLabelKey, "default"),
+				endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.0.2.4").WithLabel(endpoint.OwnerLabelKey, "other"),
+			},
+			expectErr: "new.example.com A was created; changed.example.com A was updated; old.example.com A changed owner",
+		},
+		{
+			name:      "records deleted",
+			saved:     NewSavedPlan("default", time.Now(), changes()),
+			expectErr: "changed.example.com A was deleted; old.example.com A was deleted",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &filteredMockProvider{RecordsStore: tt.current}
+			r, err := registry.NewNoopRegistry(p)
+			require.NoError(t, err)
+			ctrl := &Controller{Registry: r, Policy: &plan.SyncPolicy{}}
+
+			err = ctrl.ApplyPlan(context.Background(), tt.saved, "default", time.Hour)
+			if tt.expectErr != "" {
+				require.ErrorContains(t, err, tt.expectErr)
+				assert.Empty(t, p.ApplyChangesCalls, "stale plans must not be applied")
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, p.ApplyChangesCalls, 1)
+			assert.Equal(t, tt.saved.Changes, p.ApplyChangesCalls[0])
+		})
+	}
+}
+
+func TestControllerApplyPlanPaused(t *testing.T) {
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{Registry: r, Policy: &plan.SyncPolicy{}}
+	ctrl.Pause()
+	defer ctrl.Resume()
+
+	var b bytes.Buffer
+	require.NoError(t, WritePlan(&b, NewSavedPlan("default", time.Now(), &plan.Changes{})))
+	saved, err := ReadPlan(&b)
+	require.NoError(t, err)
+	require.ErrorContains(t, ctrl.ApplyPlan(context.Background(), saved, "default", 0), "synchronization is paused")
+}
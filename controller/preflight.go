@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// preflightCheck is the outcome of a check run by the validate command or --validate-only.
+type preflightCheck struct {
+	name string
+	err  error
+	// warning makes the check pass despite its error
+	warning bool
+}
+
+// validateOnly checks the combination of the flags, that the sources can read their resources and that the provider
+// credentials are valid and its zones can be listed, writes the outcome of each check to w and returns whether all of
+// them passed.
+func validateOnly(ctx context.Context, cfg *externaldns.Config, w io.Writer) bool {
+	checks := checkFlags(cfg)
+
+	if rules := source.PolicyRules(source.NewSourceConfig(cfg), cfg.Sources...); len(rules) > 0 {
+		client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
+		if err != nil {
+			checks = append(checks, preflightCheck{name: "kubernetes client", err: err})
+		} else {
+			checks = append(checks, checkAccess(ctx, client, cfg.Namespace, rules)...)
+		}
+	}
+
+	if src, err := buildSource(ctx, cfg); err != nil {
+		checks = append(checks, preflightCheck{name: "sources", err: err})
+	} else {
+		endpoints, err := src.Endpoints(ctx)
+		checks = append(checks, preflightCheck{name: fmt.Sprintf("sources return %d endpoints", len(endpoints)), err: err})
+	}
+
+	if p, err := buildProvider(ctx, cfg, createDomainFilter(cfg)); err != nil {
+		checks = append(checks, preflightCheck{name: "provider", err: err})
+	} else {
+		checks = append(checks, checkZones(ctx, p))
+		records, err := p.Records(ctx)
+		checks = append(checks, preflightCheck{name: fmt.Sprintf("provider returns %d records", len(records)), err: err})
+	}
+
+	return writeChecks(w, checks)
+}
+
+// checkFlags checks that the sources, the provider and the registry are meant to be used together. Combinations
+// which work but are likely mistakes are reported as warnings.
+func checkFlags(cfg *externaldns.Config) []preflightCheck {
+	checks := []preflightCheck{{name: "flags are valid"}}
+	if cfg.Registry == "aws-sd" && cfg.Provider != "aws-sd" {
+		checks = append(checks, preflightCheck{name: "registry", err: errors.New("the aws-sd registry requires --provider=aws-sd")})
+	}
+	if (cfg.Registry == "txt" || cfg.Registry == "dynamodb") && cfg.TXTOwnerID == "default" {
+		checks = append(checks, preflightCheck{name: "registry", err: errors.New("--txt-owner-id isn't set, instances sharing a zone would take over each other's records"), warning: true})
+	}
+	if cfg.Registry == "noop" && cfg.Policy == "sync" {
+		checks = append(checks, preflightCheck{name: "registry", err: errors.New("the noop registry doesn't track ownership, --policy=sync deletes every record of the zones that isn't desired"), warning: true})
+	}
+	if slices.Contains(cfg.Sources, "fake") && cfg.Provider != "inmemory" {
+		checks = append(checks, preflightCheck{name: "sources", err: fmt.Errorf("the fake source creates records with random names in %s", cfg.Provider), warning: true})
+	}
+	if cfg.Provider == "inmemory" {
+		checks = append(checks, preflightCheck{name: "provider", err: errors.New("the inmemory provider doesn't persist the records"), warning: true})
+	}
+	return checks
+}
+
+// checkZones lists the zones of the provider, which validates the credentials and the zone filters.
+func checkZones(ctx context.Context, p provider.Provider) preflightCheck {
+	zones, err := provider.ListZones(ctx, p)
+	switch {
+	case errors.Is(err, provider.ErrZoneListingNotSupported):
+		return preflightCheck{name: "provider zones", err: err, warning: true}
+	case err != nil:
+		return preflightCheck{name: "provider zones", err: err}
+	case len(zones) == 0:
+		return preflightCheck{name: "provider zones", err: errors.New("no zone matches the zone filters"), warning: true}
+	}
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, zone.Name)
+	}
+	return preflightCheck{name: fmt.Sprintf("provider manages %d zones: %s", len(zones), strings.Join(names, ", "))}
+}
+
+// checkAccess checks through SelfSubjectAccessReviews that every verb of the given rules is allowed in the
+// namespace, or cluster-wide if empty.
+func checkAccess(ctx context.Context, client kubernetes.Interface, namespace string, rules []rbacv1.PolicyRule) []preflightCheck {
+	var checks []preflightCheck
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				resource, subresource, _ := strings.Cut(resource, "/")
+				for _, verb := range rule.Verbs {
+					name := verb + " " + resource
+					if group != "" {
+						name += "." + group
+					}
+					if subresource != "" {
+						name += "/" + subresource
+					}
+					review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+						Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+							ResourceAttributes: &authorizationv1.ResourceAttributes{
+								Namespace:   namespace,
+								Verb:        verb,
+								Group:       group,
+								Resource:    resource,
+								Subresource: subresource,
+							},
+						},
+					}, metav1.CreateOptions{})
+					if err == nil && !review.Status.Allowed {
+						err = errors.New("forbidden")
+						if review.Status.Reason != "" {
+							err = fmt.Errorf("forbidden: %s", review.Status.Reason)
+						}
+					}
+					checks = append(checks, preflightCheck{name: "can " + name, err: err})
+				}
+			}
+		}
+	}
+	return checks
+}
+
+// writeChecks writes one line per check and returns whether all of them passed.
+func writeChecks(w io.Writer, checks []preflightCheck) bool {
+	passed := true
+	for _, check := range checks {
+		if check.err != nil && check.warning {
+			_, _ = fmt.Fprintf(w, "WARN  %s: %v\n", check.name, check.err)
+		} else if check.err != nil {
+			passed = false
+			_, _ = fmt.Fprintf(w, "FAIL  %s: %v\n", check.name, check.err)
+		} else {
+			_, _ = fmt.Fprintf(w, "OK    %s\n", check.name)
+		}
+	}
+	return passed
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func TestCheckAccess(t *testing.T) {
+	client := fake.NewClientset()
+	var namespaces []string
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		namespaces = append(namespaces, attributes.Namespace)
+		review.Status.Allowed = attributes.Resource != "nodes" && attributes.Subresource == ""
+		if attributes.Resource == "nodes" {
+			review.Status.Reason = "no RBAC policy matched"
+		}
+		return true, review, nil
+	})
+
+	checks := checkAccess(t.Context(), client, "default", []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"services", "nodes"}, Verbs: []string{"list"}},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsendpoints/status"}, Verbs: []string{"update"}},
+	})
+
+	var out bytes.Buffer
+	assert.False(t, writeChecks(&out, checks))
+	assert.Equal(t, "OK    can list services\n"+
+		"FAIL  can list nodes: forbidden: no RBAC policy matched\n"+
+		"FAIL  can update dnsendpoints.externaldns.k8s.io/status: forbidden\n", out.String())
+	assert.Equal(t, []string{"default", "default", "default"}, namespaces)
+}
+
+func TestValidateOnly(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"fake"}
+	cfg.Provider = "inmemory"
+
+	var out bytes.Buffer
+	assert.True(t, validateOnly(t.Context(), cfg, &out))
+	assert.Contains(t, out.String(), "OK    sources return")
+	assert.Contains(t, out.String(), "OK    provider returns 0 records")
+}
+
+func TestValidateOnlyZones(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"fake"}
+	cfg.Provider = "inmemory"
+	cfg.InMemoryZones = []string{"example.org", "example.com"}
+
+	var out bytes.Buffer
+	assert.True(t, validateOnly(t.Context(), cfg, &out))
+	assert.Contains(t, out.String(), "OK    flags are valid\n")
+	assert.Contains(t, out.String(), "OK    provider manages 2 zones: example.com, example.org\n")
+}
+
+func TestCheckFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		modify   func(cfg *externaldns.Config)
+		expected string
+		passed   bool
+	}{
+		{
+			name: "valid",
+			modify: func(cfg *externaldns.Config) {
+				cfg.TXTOwnerID = "cluster-1"
+			},
+			expected: "OK    flags are valid\n",
+			passed:   true,
+		},
+		{
+			name: "default owner id",
+			modify: func(cfg *externaldns.Config) {
+				cfg.Registry = "dynamodb"
+			},
+			expected: "OK    flags are valid\n" +
+				"WARN  registry: --txt-owner-id isn't set, instances sharing a zone would take over each other's records\n",
+			passed: true,
+		},
+		{
+			name: "aws-sd registry",
+			modify: func(cfg *externaldns.Config) {
+				cfg.Registry = "aws-sd"
+				cfg.TXTOwnerID = "cluster-1"
+			},
+			expected: "OK    flags are valid\n" +
+				"FAIL  registry: the aws-sd registry requires --provider=aws-sd\n",
+		},
+		{
+			name: "noop registry",
+			modify: func(cfg *externaldns.Config) {
+				cfg.Registry = "noop"
+			},
+			expected: "OK    flags are valid\n" +
+				"WARN  registry: the noop registry doesn't track ownership, --policy=sync deletes every record of the zones that isn't desired\n",
+			passed: true,
+		},
+		{
+			name: "fake source",
+			modify: func(cfg *externaldns.Config) {
+				cfg.TXTOwnerID = "cluster-1"
+				cfg.Sources = []string{"service", "fake"}
+			},
+			expected: "OK    flags are valid\n" +
+				"WARN  sources: the fake source creates records with random names in aws\n",
+			passed: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := externaldns.NewConfig()
+			cfg.Sources = []string{"service"}
+			cfg.Provider = "aws"
+			cfg.Registry = "txt"
+			cfg.TXTOwnerID = "default"
+			cfg.Policy = "sync"
+			tt.modify(cfg)
+
+			var out bytes.Buffer
+			assert.Equal(t, tt.passed, writeChecks(&out, checkFlags(cfg)))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestCheckZones(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org", "example.com"}))
+	check := checkZones(t.Context(), provider.NewCachedProvider(provider.NewInstrumentedProvider(p, "inmemory"), time.Minute))
+	assert.Equal(t, preflightCheck{name: "provider manages 2 zones: example.com, example.org"}, check)
+
+	check = checkZones(t.Context(), inmemory.NewInMemoryProvider())
+	assert.True(t, check.warning)
+	assert.EqualError(t, check.err, "no zone matches the zone filters")
+
+	check = checkZones(t.Context(), &mockProvider{})
+	assert.True(t, check.warning)
+	assert.ErrorIs(t, check.err, provider.ErrZoneListingNotSupported)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// clusterScopedResources are the resources read by sources which aren't namespaced, granted by the ClusterRole
+// even when the sources are limited to a namespace.
+var clusterScopedResources = []string{"nodes", "namespaces"}
+
+// rbacManifests returns the ClusterRole, the Roles and their bindings granting the service account, in namespace/name
+// format, the permissions the configured sources and flags require. Rules are granted in the namespace of
+// --namespace when it is set, and cluster-wide otherwise.
+func rbacManifests(cfg *externaldns.Config, name, serviceAccount string) []any {
+	var clusterRules []rbacv1.PolicyRule
+	roleRules := map[string][]rbacv1.PolicyRule{}
+	grant := func(namespace string, rule rbacv1.PolicyRule) {
+		if namespace == "" {
+			clusterRules = mergeRule(clusterRules, rule)
+		} else {
+			roleRules[namespace] = mergeRule(roleRules[namespace], rule)
+		}
+	}
+
+	for _, rule := range source.PolicyRules(source.NewSourceConfig(cfg), cfg.Sources...) {
+		if slices.Equal(rule.APIGroups, []string{""}) && slices.Contains(clusterScopedResources, rule.Resources[0]) {
+			grant("", rule)
+		} else {
+			grant(cfg.Namespace, rule)
+		}
+	}
+	if len(cfg.EmitEvents) > 0 {
+		// events are created in the namespaces of the resources they are about
+		grant(cfg.Namespace, rbacv1.PolicyRule{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: []string{"create"}})
+	}
+	if namespace, configMap, ok := strings.Cut(cfg.InventoryConfigMap, "/"); ok {
+		// the create verb can't be limited to a resource name
+		grant(namespace, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{configMap}, Verbs: []string{"get", "update"}})
+		grant(namespace, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"create"}})
+	}
+	if cfg.NamespaceRecordsConfigMap != "" {
+		// written in the namespaces of the resources of the records
+		grant(cfg.Namespace, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{cfg.NamespaceRecordsConfigMap}, Verbs: []string{"get", "update"}})
+		grant(cfg.Namespace, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"create"}})
+	}
+	if namespace, configMap, ok := strings.Cut(cfg.NamespaceDomainsConfigMap, "/"); ok {
+		// watched with a field selector on its name
+		grant(namespace, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{configMap}, Verbs: []string{"get", "list", "watch"}})
+	}
+	if cfg.ApprovalThreshold > 0 || len(cfg.ApprovalZones) > 0 {
+		grant(cfg.ApprovalNamespace, rbacv1.PolicyRule{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"pendingchanges"}, Verbs: []string{"get", "list", "create"}})
+		grant(cfg.ApprovalNamespace, rbacv1.PolicyRule{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"pendingchanges/status"}, Verbs: []string{"update"}})
+	}
+	if cfg.StatusObject != "" {
+		// externaldnsstatuses are cluster-scoped, and the create verb can't be limited to a resource name
+		grant("", rbacv1.PolicyRule{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"externaldnsstatuses"}, ResourceNames: []string{cfg.StatusObject}, Verbs: []string{"get"}})
+		grant("", rbacv1.PolicyRule{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"externaldnsstatuses"}, Verbs: []string{"create"}})
+		grant("", rbacv1.PolicyRule{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"externaldnsstatuses/status"}, ResourceNames: []string{cfg.StatusObject}, Verbs: []string{"update"}})
+	}
+
+	if cfg.APITokenAudience != "" {
+		// tokenreviews are cluster-scoped
+		grant("", rbacv1.PolicyRule{APIGroups: []string{"authentication.k8s.io"}, Resources: []string{"tokenreviews"}, Verbs: []string{"create"}})
+	}
+
+	saNamespace, saName, _ := strings.Cut(serviceAccount, "/")
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Namespace: saNamespace, Name: saName}}
+
+	var manifests []any
+	if len(clusterRules) > 0 {
+		manifests = append(manifests,
+			&rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Rules:      clusterRules,
+			},
+			&rbacv1.ClusterRoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+				Subjects:   subjects,
+			})
+	}
+	for _, namespace := range slices.SortedFunc(maps.Keys(roleRules), cmp.Compare) {
+		manifests = append(manifests,
+			&rbacv1.Role{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Rules:      roleRules[namespace],
+			},
+			&rbacv1.RoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+				Subjects:   subjects,
+			})
+	}
+	return manifests
+}
+
+// mergeRule adds the resources of a rule to the rule granting the same verbs in the same API groups, or appends it.
+func mergeRule(rules []rbacv1.PolicyRule, rule rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	for i, r := range rules {
+		if slices.Equal(r.APIGroups, rule.APIGroups) && slices.Equal(r.Verbs, rule.Verbs) && slices.Equal(r.ResourceNames, rule.ResourceNames) {
+			for _, resource := range rule.Resources {
+				if !slices.Contains(r.Resources, resource) {
+					rules[i].Resources = append(rules[i].Resources, resource)
+				}
+			}
+			return rules
+		}
+	}
+	rule.Resources = slices.Clone(rule.Resources)
+	return append(rules, rule)
+}
+
+// WriteManifests writes the given Kubernetes objects as YAML documents, leaving out the fields set by the API server.
+func WriteManifests(w io.Writer, manifests []any) error {
+	for i, manifest := range manifests {
+		object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(manifest)
+		if err != nil {
+			return err
+		}
+		unstructured.RemoveNestedField(object, "metadata", "creationTimestamp")
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := fmt.Fprintln(w, "---"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
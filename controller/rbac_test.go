@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func TestRBACManifests(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"service", "traefik-proxy"}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteManifests(&out, rbacManifests(cfg, "external-dns", "dns/external-dns")))
+	assert.Equal(t, `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: external-dns
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - services
+  - pods
+  - nodes
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - discovery.k8s.io
+  resources:
+  - endpointslices
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - traefik.io
+  resources:
+  - ingressroutes
+  - ingressroutetcps
+  - ingressrouteudps
+  verbs:
+  - get
+  - list
+  - watch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: external-dns
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: external-dns
+subjects:
+- kind: ServiceAccount
+  name: external-dns
+  namespace: dns
+`, out.String())
+}
+
+func TestRBACManifestsNamespaced(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"service", "crd"}
+	cfg.CRDSourceAPIVersion = "externaldns.k8s.io/v1alpha1"
+	cfg.CRDSourceKind = "DNSEndpoint"
+	cfg.Namespace = "apps"
+	cfg.EmitEvents = []string{"RecordReady"}
+	cfg.InventoryConfigMap = "dns/records"
+	cfg.NamespaceDomainsConfigMap = "dns/namespace-domains"
+	cfg.ApprovalThreshold = 10
+	cfg.ApprovalNamespace = "dns"
+
+	manifests := rbacManifests(cfg, "external-dns", "dns/external-dns")
+	require.Len(t, manifests, 6)
+
+	clusterRole := manifests[0].(*rbacv1.ClusterRole)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get", "list", "watch"}},
+	}, clusterRole.Rules)
+
+	role := manifests[4].(*rbacv1.Role)
+	assert.Equal(t, "dns", role.Namespace)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"records"}, Verbs: []string{"get", "update"}},
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"create"}},
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"namespace-domains"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"pendingchanges"}, Verbs: []string{"get", "list", "create"}},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"pendingchanges/status"}, Verbs: []string{"update"}},
+	}, role.Rules)
+
+	role = manifests[2].(*rbacv1.Role)
+	assert.Equal(t, "apps", role.Namespace)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"services", "pods"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsendpoints"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsendpoints/status"}, Verbs: []string{"update"}},
+		{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: []string{"create"}},
+	}, role.Rules)
+
+	binding := manifests[3].(*rbacv1.RoleBinding)
+	assert.Equal(t, "apps", binding.Namespace)
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "external-dns"}, binding.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Namespace: "dns", Name: "external-dns"}}, binding.Subjects)
+}
+
+func TestRBACManifestsTokenReviews(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"service"}
+	cfg.Namespace = "apps"
+	cfg.APITokenAudience = "external-dns"
+	cfg.APIServiceAccounts = []string{"monitoring/api-reader"}
+
+	manifests := rbacManifests(cfg, "external-dns", "dns/external-dns")
+	require.Len(t, manifests, 4)
+	clusterRole := manifests[0].(*rbacv1.ClusterRole)
+	assert.Contains(t, clusterRole.Rules, rbacv1.PolicyRule{APIGroups: []string{"authentication.k8s.io"}, Resources: []string{"tokenreviews"}, Verbs: []string{"create"}})
+}
+
+func TestRBACManifestsStatusObject(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"service"}
+	cfg.Namespace = "apps"
+	cfg.StatusObject = "external-dns"
+
+	manifests := rbacManifests(cfg, "external-dns", "dns/external-dns")
+	require.Len(t, manifests, 4)
+	clusterRole := manifests[0].(*rbacv1.ClusterRole)
+	assert.Subset(t, clusterRole.Rules, []rbacv1.PolicyRule{
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"externaldnsstatuses"}, ResourceNames: []string{"external-dns"}, Verbs: []string{"get"}},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"externaldnsstatuses"}, Verbs: []string{"create"}},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"externaldnsstatuses/status"}, ResourceNames: []string{"external-dns"}, Verbs: []string{"update"}},
+	})
+}
+
+func TestRBACManifestsNamespaceRecordsConfigMap(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"service"}
+	cfg.Namespace = "apps"
+	cfg.NamespaceRecordsConfigMap = "dns-records"
+
+	manifests := rbacManifests(cfg, "external-dns", "dns/external-dns")
+	require.Len(t, manifests, 4)
+	role := manifests[2].(*rbacv1.Role)
+	assert.Equal(t, "apps", role.Namespace)
+	assert.Subset(t, role.Rules, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"dns-records"}, Verbs: []string{"get", "update"}},
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"create"}},
+	})
+}
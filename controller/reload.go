@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/logging"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// configPollInterval is the interval between two checks of the configuration file for changes.
+const configPollInterval = 10 * time.Second
+
+// reloadableConfig are the fields of the configuration applied by a reload, changes of all other fields require a
+// restart.
+var reloadableConfig = map[string]bool{
+	"LogLevel":             true,
+	"Interval":             true,
+	"MinEventSyncInterval": true,
+	"DomainFilter":         true,
+	"ExcludeDomains":       true,
+	"RegexDomainFilter":    true,
+	"RegexDomainExclusion": true,
+	"Policy":               true,
+	"WildcardPolicy":       true,
+	"WildcardPlaceholder":  true,
+}
+
+// Settings are the settings of the Controller which may change while it runs.
+type Settings struct {
+	Interval             time.Duration
+	MinEventSyncInterval time.Duration
+	DomainFilter         endpoint.DomainFilterInterface
+	Policy               plan.Policy
+	WildcardPolicy       string
+	WildcardPlaceholder  string
+}
+
+// Reload changes the settings of the controller. They are applied before its next synchronization, so that a
+// synchronization in progress completes with the settings it started with.
+func (c *Controller) Reload(s Settings) {
+	c.pendingSettings.Store(&s)
+}
+
+// ReloadRegistry replaces the registry of the controller, e.g. with one using new credentials. It is replaced before
+// the next synchronization, like the settings passed to Reload.
+func (c *Controller) ReloadRegistry(r registry.Registry) {
+	c.pendingRegistry.Store(&r)
+}
+
+// applySettings applies the settings passed to Reload and the registry passed to ReloadRegistry, if any.
+func (c *Controller) applySettings() {
+	if r := c.pendingRegistry.Swap(nil); r != nil {
+		c.Registry = *r
+	}
+	s := c.pendingSettings.Swap(nil)
+	if s == nil {
+		return
+	}
+	c.runAtMutex.Lock()
+	if s.Interval != c.Interval {
+		// a shorter interval takes effect right away instead of after the synchronization already scheduled
+		c.nextRunAt = earliest(c.nextRunAt, c.lastRunAt.Add(s.Interval))
+	}
+	c.Interval = s.Interval
+	c.MinEventSyncInterval = s.MinEventSyncInterval
+	c.runAtMutex.Unlock()
+	c.DomainFilter = s.DomainFilter
+	c.Policy = s.Policy
+	c.WildcardPolicy = s.WildcardPolicy
+	c.WildcardPlaceholder = s.WildcardPlaceholder
+}
+
+// watchConfig reloads the configuration from the given arguments when the configuration file changes or on
+// SIGHUP, until the context is cancelled, and applies the changes which don't require a restart.
+func watchConfig(ctx context.Context, cfg *externaldns.Config, args []string, ctrl *Controller) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	last, _ := os.ReadFile(cfg.ConfigFile)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.Info("Received SIGHUP. Reloading the configuration")
+		case <-ticker.C:
+			data, err := os.ReadFile(cfg.ConfigFile)
+			if err != nil || bytes.Equal(data, last) {
+				continue
+			}
+			last = data
+			log.Infof("Configuration file %s changed. Reloading the configuration", cfg.ConfigFile)
+		}
+		next, err := reloadConfig(cfg, args, ctrl)
+		if err != nil {
+			log.Errorf("Failed to reload the configuration, keeping the current one: %v", err)
+			continue
+		}
+		cfg = next
+	}
+}
+
+// reloadConfig parses the configuration again from the given arguments and applies it to the controller and the
+// logger. It returns the configuration applied, in which the fields requiring a restart keep their current values.
+func reloadConfig(cfg *externaldns.Config, args []string, ctrl *Controller) (*externaldns.Config, error) {
+	next := externaldns.NewConfig()
+	if err := next.ParseFlags(args); err != nil {
+		return nil, fmt.Errorf("flag parsing error: %w", err)
+	}
+	if err := validation.ValidateConfig(next); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+	levels, err := logging.ParseLevels(next.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log level: %w", err)
+	}
+	policy, ok := plan.Policies[next.Policy]
+	if !ok {
+		return nil, fmt.Errorf("unknown policy: %s", next.Policy)
+	}
+
+	// keep the fields requiring a restart, so that they are reported again only when they change again
+	applied := *cfg
+	var ignored []string
+	current, reloaded := reflect.ValueOf(&applied).Elem(), reflect.ValueOf(next).Elem()
+	for i := range current.NumField() {
+		field := current.Type().Field(i)
+		if !field.IsExported() || reflect.DeepEqual(current.Field(i).Interface(), reloaded.Field(i).Interface()) {
+			continue
+		}
+		if reloadableConfig[field.Name] {
+			current.Field(i).Set(reloaded.Field(i))
+		} else {
+			ignored = append(ignored, field.Name)
+		}
+	}
+	if len(ignored) > 0 {
+		log.Warnf("Ignoring changes of %s, which require a restart", strings.Join(ignored, ", "))
+	}
+
+	levels.Configure(log.StandardLogger())
+	ctrl.Reload(Settings{
+		Interval:             applied.Interval,
+		MinEventSyncInterval: applied.MinEventSyncInterval,
+		DomainFilter:         controllerDomainFilter(&applied, createDomainFilter(&applied)),
+		Policy:               policy,
+		WildcardPolicy:       applied.WildcardPolicy,
+		WildcardPlaceholder:  applied.WildcardPlaceholder,
+	})
+	log.Info("Reloaded the configuration")
+	return &applied, nil
+}
+
+// watchSecretFiles reads the secret files of the given configuration again when they change, checking them at the
+// given interval until the context is cancelled, and replaces the registry of the controller with one using a provider
+// set up with the new credentials. It calls terminate instead when one of the credentials changed is only read at
+// startup.
+func watchSecretFiles(ctx context.Context, cfg *externaldns.Config, interval time.Duration, ctrl *Controller, terminate func()) {
+	contents := map[string][]byte{}
+	for _, path := range cfg.SecretFiles {
+		contents[path], _ = os.ReadFile(path)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		var changed []string
+		for name, path := range cfg.SecretFiles {
+			data, err := os.ReadFile(path)
+			if err != nil || bytes.Equal(data, contents[path]) {
+				continue
+			}
+			contents[path] = data
+			if externaldns.SecretRequiresRestart(name) {
+				log.Warnf("Secret file %s of %s changed. Terminating to be restarted with the new secret", path, name)
+				terminate()
+				return
+			}
+			log.Infof("Secret file %s of %s changed. Setting up the provider again", path, name)
+			changed = append(changed, name)
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		next, err := reloadSecretFiles(ctx, cfg, changed, ctrl)
+		if err != nil {
+			log.Errorf("Failed to set up the provider with the new secrets, keeping the current one: %v", err)
+			continue
+		}
+		cfg = next
+	}
+}
+
+// reloadSecretFiles reads the given credentials again from their files, and replaces the registry of the controller
+// with one using a provider set up with them. It returns the configuration applied.
+func reloadSecretFiles(ctx context.Context, cfg *externaldns.Config, names []string, ctrl *Controller) (*externaldns.Config, error) {
+	next := *cfg
+	for _, name := range names {
+		value, err := next.ReloadSecretFile(name)
+		if err != nil {
+			return nil, err
+		}
+		logging.AddSecrets(value)
+	}
+	p, err := buildProvider(ctx, &next, createDomainFilter(&next))
+	if err != nil {
+		return nil, err
+	}
+	r, err := selectRegistry(&next, p)
+	if err != nil {
+		return nil, err
+	}
+	ctrl.ReloadRegistry(r)
+	log.Info("Set up the provider with the new secrets")
+	return &next, nil
+}
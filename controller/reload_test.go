@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestControllerReload(t *testing.T) {
+	now := time.Now()
+	ctrl := &Controller{
+		Interval:  time.Minute,
+		Policy:    &plan.SyncPolicy{},
+		lastRunAt: now,
+		nextRunAt: now.Add(time.Minute),
+	}
+	filter := endpoint.NewDomainFilter([]string{"example.org"})
+
+	ctrl.Reload(Settings{
+		Interval:             10 * time.Second,
+		MinEventSyncInterval: 2 * time.Second,
+		DomainFilter:         filter,
+		Policy:               &plan.UpsertOnlyPolicy{},
+		WildcardPolicy:       plan.WildcardPolicyDeny,
+		WildcardPlaceholder:  "any",
+	})
+	assert.Equal(t, time.Minute, ctrl.Interval, "settings are applied before the next synchronization")
+
+	ctrl.applySettings()
+	assert.Equal(t, 10*time.Second, ctrl.Interval)
+	assert.Equal(t, 2*time.Second, ctrl.MinEventSyncInterval)
+	assert.Equal(t, filter, ctrl.DomainFilter)
+	assert.Equal(t, &plan.UpsertOnlyPolicy{}, ctrl.Policy)
+	assert.Equal(t, plan.WildcardPolicyDeny, ctrl.WildcardPolicy)
+	assert.Equal(t, "any", ctrl.WildcardPlaceholder)
+	assert.Equal(t, now.Add(10*time.Second), ctrl.nextRunAt, "a shorter interval reschedules the next synchronization")
+
+	ctrl.Interval = time.Hour
+	ctrl.applySettings()
+	assert.Equal(t, time.Hour, ctrl.Interval, "settings are applied once")
+}
+
+func TestReloadConfig(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_CLI", "")
+	level := log.GetLevel()
+	t.Cleanup(func() { log.SetLevel(level) })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("provider: aws\nsource: service\ninterval: 1m\n"), 0o600))
+	args := []string{"--config=" + path, "--txt-owner-id=owner"}
+
+	cfg := externaldns.NewConfig()
+	require.NoError(t, cfg.ParseFlags(args))
+
+	require.NoError(t, os.WriteFile(path, []byte(`provider: google
+source: service
+interval: 30s
+domain-filter: example.org
+policy: upsert-only
+wildcard-policy: deny
+log-level: debug
+`), 0o600))
+
+	ctrl := &Controller{}
+	applied, err := reloadConfig(cfg, args, ctrl)
+	require.NoError(t, err)
+
+	assert.Equal(t, "aws", applied.Provider, "changes requiring a restart are ignored")
+	assert.Equal(t, "owner", applied.TXTOwnerID)
+	assert.Equal(t, 30*time.Second, applied.Interval)
+	assert.Equal(t, []string{"example.org"}, applied.DomainFilter)
+	assert.Equal(t, log.DebugLevel, log.GetLevel())
+
+	ctrl.applySettings()
+	assert.Equal(t, 30*time.Second, ctrl.Interval)
+	assert.True(t, ctrl.DomainFilter.Match("www.example.org"))
+	assert.False(t, ctrl.DomainFilter.Match("www.example.com"))
+	assert.Equal(t, &plan.UpsertOnlyPolicy{}, ctrl.Policy)
+	assert.Equal(t, plan.WildcardPolicyDeny, ctrl.WildcardPolicy)
+
+	require.NoError(t, os.WriteFile(path, []byte("provider: aws\nsource: service\ninterval: often\n"), 0o600))
+	_, err = reloadConfig(applied, args, ctrl)
+	require.Error(t, err)
+	assert.Nil(t, ctrl.pendingSettings.Load(), "an invalid configuration is not applied")
+}
+
+func TestWatchSecretFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+	cfg := externaldns.NewConfig()
+	cfg.Provider = "inmemory"
+	cfg.Registry = "noop"
+	cfg.PDNSAPIKey = "old"
+	cfg.SecretFiles = map[string]string{"pdns-api-key": path}
+	ctrl := &Controller{}
+
+	terminated := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchSecretFiles(ctx, cfg, 10*time.Millisecond, ctrl, func() { close(terminated) })
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Nil(t, ctrl.pendingRegistry.Load(), "registry replaced although the secret file didn't change")
+
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0o600))
+	require.Eventually(t, func() bool { return ctrl.pendingRegistry.Load() != nil }, 5*time.Second, 10*time.Millisecond)
+	ctrl.applySettings()
+	assert.NotNil(t, ctrl.Registry)
+	assert.Equal(t, "old", cfg.PDNSAPIKey, "the configuration passed is left unchanged")
+	select {
+	case <-terminated:
+		t.Fatal("terminated although the provider was set up with the new secret")
+	default:
+	}
+}
+
+func TestWatchSecretFilesRequiringRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+	cfg := externaldns.NewConfig()
+	cfg.Provider = "inmemory"
+	cfg.SecretFiles = map[string]string{"api-token": path}
+
+	terminated := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchSecretFiles(ctx, cfg, 10*time.Millisecond, &Controller{}, func() { close(terminated) })
+
+	select {
+	case <-terminated:
+		t.Fatal("terminated although the secret file didn't change")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0o600))
+	select {
+	case <-terminated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not terminated after the secret file changed")
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/external-dns/pkg/syncstatus"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// reportStatus completes the report of the synchronization which ended with the given error with the health of
+// the zones, and reports it.
+func (c *Controller) reportStatus(ctx context.Context, report *syncstatus.Report, err error) {
+	if c.SyncStatus == nil {
+		return
+	}
+	report.Time = time.Now()
+	report.Err = err
+	report.Paused = c.Paused()
+	report.LastSuccess, report.Zones = c.health.lastSuccesses(c.zones())
+	// the synchronization may have been cancelled or have timed out, which shouldn't prevent the report
+	c.SyncStatus.Report(context.WithoutCancel(ctx), *report)
+}
+
+// countPending returns the number of changes, counting updates once.
+func countPending(changes *plan.Changes) int {
+	return len(changes.Create) + len(changes.UpdateNew) + len(changes.Delete)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/internal/logging"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/vault"
+)
+
+// vaultPollInterval is the interval between two reads of the credentials from Vault, noticing their rotation.
+const vaultPollInterval = time.Minute
+
+// secretReader reads a secret referenced as <path>#<key>.
+type secretReader interface {
+	Read(ctx context.Context, ref string) (string, error)
+}
+
+// readVaultSecrets logs in to Vault and sets the credentials referenced by --vault-secret. It returns the client,
+// whose token has to be kept alive, and the credentials read.
+func readVaultSecrets(ctx context.Context, cfg *externaldns.Config) (*vault.Client, map[string]string, error) {
+	client, err := vault.NewClient(vault.Config{
+		Address:                 cfg.VaultAddress,
+		AuthMount:               cfg.VaultAuthMount,
+		Role:                    cfg.VaultRole,
+		ServiceAccountTokenPath: cfg.VaultServiceAccountTokenPath,
+		CACert:                  cfg.VaultCACert,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := client.Login(ctx); err != nil {
+		return nil, nil, err
+	}
+	values, err := readSecrets(ctx, client, cfg.VaultSecrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if err := cfg.SetSecret(name, values[name]); err != nil {
+			return nil, nil, fmt.Errorf("setting %s from vault: %w", name, err)
+		}
+	}
+	logging.AddSecrets(slices.Collect(maps.Values(values))...)
+	log.Infof("Read %d credentials from vault", len(values))
+	return client, values, nil
+}
+
+// readSecrets reads the secrets referenced by the given credentials.
+func readSecrets(ctx context.Context, r secretReader, refs map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(refs))
+	for name, ref := range refs {
+		value, err := r.Read(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// watchVaultSecrets calls terminate once one of the credentials read from Vault changed, reading them at the given
+// interval until the context is cancelled. Like secret files, credentials are only used at startup.
+func watchVaultSecrets(ctx context.Context, r secretReader, refs, values map[string]string, interval time.Duration, terminate func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		current, err := readSecrets(ctx, r, refs)
+		if err != nil {
+			log.Warnf("Failed to read the credentials from vault: %v", err)
+			continue
+		}
+		for name, value := range current {
+			if value != values[name] {
+				log.Warnf("Credential %s changed in vault. Terminating to be restarted with the new credential", name)
+				terminate()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSecretReader returns the values of its secrets, or an error for unknown ones.
+type fakeSecretReader struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+func (r *fakeSecretReader) Read(_ context.Context, ref string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.secrets[ref]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return value, nil
+}
+
+func (r *fakeSecretReader) set(ref, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets[ref] = value
+}
+
+func TestReadSecrets(t *testing.T) {
+	r := &fakeSecretReader{secrets: map[string]string{"secret/dns#token": "token", "secret/dns#key": "key"}}
+
+	values, err := readSecrets(context.Background(), r, map[string]string{"CF_API_TOKEN": "secret/dns#token", "pdns-api-key": "secret/dns#key"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"CF_API_TOKEN": "token", "pdns-api-key": "key"}, values)
+
+	_, err = readSecrets(context.Background(), r, map[string]string{"CF_API_TOKEN": "secret/dns#missing"})
+	assert.Error(t, err)
+}
+
+func TestWatchVaultSecrets(t *testing.T) {
+	r := &fakeSecretReader{secrets: map[string]string{"secret/dns#token": "old"}}
+	refs := map[string]string{"CF_API_TOKEN": "secret/dns#token"}
+
+	terminated := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchVaultSecrets(ctx, r, refs, map[string]string{"CF_API_TOKEN": "old"}, 10*time.Millisecond, func() { close(terminated) })
+
+	select {
+	case <-terminated:
+		t.Fatal("terminated although the credential didn't change")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	r.set("secret/dns#token", "new")
+	select {
+	case <-terminated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not terminated after the credential changed")
+	}
+}
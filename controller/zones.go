@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// WriteZones writes the given zones as a table, or as JSON if the format is "json". Properties the provider doesn't
+// tell are shown as "-".
+func WriteZones(w io.Writer, zones []provider.Zone, format string) error {
+	if format == "json" {
+		if zones == nil {
+			zones = []provider.Zone{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(zones)
+	}
+
+	if len(zones) == 0 {
+		_, err := fmt.Fprintln(w, "No zones.")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNAME\tVISIBILITY\tRECORDS")
+	for _, zone := range zones {
+		visibility, records := "-", "-"
+		if zone.Visibility != "" {
+			visibility = zone.Visibility
+		}
+		if zone.Records != nil {
+			records = strconv.FormatInt(*zone.Records, 10)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", zone.ID, zone.Name, visibility, records)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func TestWriteZones(t *testing.T) {
+	records := int64(12)
+	zones := []provider.Zone{
+		{ID: "Z1D633PJN98FT9", Name: "example.com", Visibility: "public", Records: &records},
+		{ID: "internal", Name: "internal.example.org"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteZones(&out, zones, "text"))
+	assert.Equal(t, ""+
+		"ID               NAME                   VISIBILITY   RECORDS\n"+
+		"Z1D633PJN98FT9   example.com            public       12\n"+
+		"internal         internal.example.org   -            -\n", out.String())
+
+	out.Reset()
+	require.NoError(t, WriteZones(&out, zones, "json"))
+	assert.JSONEq(t, `[
+		{"id": "Z1D633PJN98FT9", "name": "example.com", "visibility": "public", "records": 12},
+		{"id": "internal", "name": "internal.example.org"}
+	]`, out.String())
+
+	out.Reset()
+	require.NoError(t, WriteZones(&out, nil, "text"))
+	assert.Equal(t, "No zones.\n", out.String())
+
+	out.Reset()
+	require.NoError(t, WriteZones(&out, nil, "json"))
+	assert.JSONEq(t, `[]`, out.String())
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/internal/idna"
+)
+
+// CanonicalName returns a DNS name in canonical form: without surrounding spaces nor trailing dot, in lower case,
+// with the labels of internationalized names encoded in punycode. Names which only differ in these respects, as
+// given by the sources or returned by the providers, are the same name. A name which can't be fully converted is
+// returned as far as it could be.
+func CanonicalName(name string) string {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".")
+	canonical, err := idna.ToASCII(name)
+	if err != nil {
+		log.Warnf("Got error while converting DNS name %s to its canonical form: %v", name, err)
+	}
+	return canonical
+}
+
+// CanonicalTarget returns a target of a record of the given type in canonical form. The hostname targets of
+// CNAME, NS and PTR records are canonical names, other targets are returned as is.
+func CanonicalTarget(recordType, target string) string {
+	switch recordType {
+	case RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+		return CanonicalName(target)
+	}
+	return target
+}
+
+// Canonicalize returns the endpoints with their DNS names and hostname targets in canonical form, so that the
+// records of the providers and the endpoints of the sources are compared and applied alike, whatever the letter
+// case and trailing dots each uses. The endpoints which aren't in canonical form are replaced by canonical copies
+// rather than modified, the others, as most are, are kept as they are.
+func Canonicalize(endpoints []*Endpoint) []*Endpoint {
+	var canonicalized []*Endpoint
+	for i, ep := range endpoints {
+		canonical := ep.canonical()
+		if canonical == ep {
+			continue
+		}
+		if canonicalized == nil {
+			canonicalized = slices.Clone(endpoints)
+		}
+		canonicalized[i] = canonical
+	}
+	if canonicalized == nil {
+		return endpoints
+	}
+	return canonicalized
+}
+
+// canonical returns the endpoint itself when in canonical form, a canonical copy otherwise.
+func (e *Endpoint) canonical() *Endpoint {
+	if e == nil {
+		return nil
+	}
+	canonical := e
+	if name := CanonicalName(e.DNSName); name != e.DNSName {
+		canonical = e.DeepCopy()
+		canonical.DNSName = name
+	}
+	for i, target := range e.Targets {
+		if t := CanonicalTarget(e.RecordType, target); t != target {
+			if canonical == e {
+				canonical = e.DeepCopy()
+			}
+			canonical.Targets[i] = t
+		}
+	}
+	return canonical
+}
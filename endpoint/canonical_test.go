@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalName(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		expected string
+	}{
+		{"example.com", "example.com"},
+		{"example.com.", "example.com"},
+		{"  WWW.Example.COM.  ", "www.example.com"},
+		{"*.example.com.", "*.example.com"},
+		{"_sip._tcp.Example.com", "_sip._tcp.example.com"},
+		{"點看.org.", "xn--c1yn36f.org"},
+		{"", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CanonicalName(tt.name))
+		})
+	}
+}
+
+func TestCanonicalTarget(t *testing.T) {
+	assert.Equal(t, "lb.example.com", CanonicalTarget(RecordTypeCNAME, "LB.Example.com."))
+	assert.Equal(t, "ns1.example.com", CanonicalTarget(RecordTypeNS, "ns1.example.com."))
+	assert.Equal(t, "host.example.com", CanonicalTarget(RecordTypePTR, "Host.example.com."))
+	assert.Equal(t, "Some Text.", CanonicalTarget(RecordTypeTXT, "Some Text."))
+	assert.Equal(t, "10 Mail.example.com.", CanonicalTarget(RecordTypeMX, "10 Mail.example.com."))
+}
+
+func TestCanonicalize(t *testing.T) {
+	canonical := NewEndpoint("a.example.com", RecordTypeA, "1.2.3.4")
+	name := &Endpoint{DNSName: "B.example.com.", RecordType: RecordTypeA, Targets: Targets{"1.2.3.4"}}
+	target := &Endpoint{DNSName: "c.example.com", RecordType: RecordTypeCNAME, Targets: Targets{"LB.example.com."}}
+
+	endpoints := []*Endpoint{canonical, name, target}
+	canonicalized := Canonicalize(endpoints)
+
+	assert.Same(t, canonical, canonicalized[0], "canonical endpoints are kept as they are")
+	assert.Equal(t, "b.example.com", canonicalized[1].DNSName)
+	assert.Equal(t, Targets{"lb.example.com"}, canonicalized[2].Targets)
+
+	assert.Equal(t, "B.example.com.", name.DNSName, "the endpoints aren't modified")
+	assert.Equal(t, Targets{"LB.example.com."}, target.Targets)
+	assert.Same(t, name, endpoints[1])
+
+	canonicalEndpoints := []*Endpoint{canonical}
+	assert.Same(t, &canonicalEndpoints[0], &Canonicalize(canonicalEndpoints)[0], "canonical endpoints aren't copied")
+	assert.Nil(t, Canonicalize(nil))
+}
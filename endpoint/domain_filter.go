@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
 	"regexp"
 	"sort"
 	"strings"
@@ -122,7 +123,11 @@ func matchFilter(filters []string, domain string, emptyval bool) bool {
 			continue
 		}
 
-		if strings.HasPrefix(filter, ".") && strings.HasSuffix(strippedDomain, filter) {
+		if IsGlobDomain(filter) {
+			if matchGlob(filter, strippedDomain) {
+				return true
+			}
+		} else if strings.HasPrefix(filter, ".") && strings.HasSuffix(strippedDomain, filter) {
 			return true
 		} else if strings.Count(strippedDomain, ".") == strings.Count(filter, ".") {
 			if strippedDomain == filter {
@@ -240,13 +245,55 @@ func (df *DomainFilter) MatchParent(domain string) bool {
 			// We don't check parents if the filter is prefixed with "."
 			continue
 		}
-		if strings.HasSuffix(filter, "."+strippedDomain) {
+		if IsGlobDomain(filter) {
+			if matchGlobParent(filter, strippedDomain) {
+				return true
+			}
+		} else if strings.HasSuffix(filter, "."+strippedDomain) {
 			return true
 		}
 	}
 	return false
 }
 
+// IsGlobDomain returns whether a domain filter is a glob pattern, with "*" matching any characters and "?" a single
+// character within a label.
+func IsGlobDomain(filter string) bool {
+	return strings.ContainsAny(filter, "*?")
+}
+
+// matchGlob determines if a glob pattern matches `domain`. Like domain suffixes, the pattern matches the domains
+// whose last labels it matches, and with a leading "." only their subdomains.
+func matchGlob(pattern, domain string) bool {
+	subdomainsOnly := strings.HasPrefix(pattern, ".")
+	patternLabels := strings.Split(strings.TrimPrefix(pattern, "."), ".")
+	domainLabels := strings.Split(domain, ".")
+	if len(domainLabels) < len(patternLabels) || subdomainsOnly && len(domainLabels) == len(patternLabels) {
+		return false
+	}
+	return matchLabels(patternLabels, domainLabels[len(domainLabels)-len(patternLabels):])
+}
+
+// matchGlobParent determines if `domain` is a parent of the domains matched by a glob pattern.
+func matchGlobParent(pattern, domain string) bool {
+	patternLabels := strings.Split(pattern, ".")
+	domainLabels := strings.Split(domain, ".")
+	if len(domainLabels) >= len(patternLabels) {
+		return false
+	}
+	return matchLabels(patternLabels[len(patternLabels)-len(domainLabels):], domainLabels)
+}
+
+// matchLabels determines if each of the glob patterns matches the label at its position.
+func matchLabels(patterns, labels []string) bool {
+	for i, pattern := range patterns {
+		if ok, err := path.Match(pattern, labels[i]); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // normalizeDomain converts a domain to a canonical form, so that we can filter on it
 // it: trim "." suffix, get Unicode version of domain compliant with Section 5 of RFC 5891
 func normalizeDomain(domain string) string {
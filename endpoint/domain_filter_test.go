@@ -348,6 +348,98 @@ var domainFilterTests = []domainFilterTest{
 			"exclude": {"api.example.org"},
 		},
 	},
+	{
+		[]string{"*.staging.example.com"},
+		[]string{},
+		[]string{"api.staging.example.com", "www.api.staging.example.com", "*.staging.example.com", "API.Staging.Example.com"},
+		true,
+		map[string][]string{
+			"include": {"*.staging.example.com"},
+		},
+	},
+	{
+		[]string{"*.staging.example.com"},
+		[]string{},
+		[]string{"staging.example.com", "api.production.example.com", "api.staging.example.org"},
+		false,
+		map[string][]string{
+			"include": {"*.staging.example.com"},
+		},
+	},
+	{
+		[]string{"eu-*.example.com"},
+		[]string{},
+		[]string{"eu-west.example.com", "eu-.example.com", "api.eu-central.example.com"},
+		true,
+		map[string][]string{
+			"include": {"eu-*.example.com"},
+		},
+	},
+	{
+		[]string{"eu-*.example.com"},
+		[]string{},
+		[]string{"us-east.example.com", "example.com", "eu-west.foo.example.com", "eu-west.example.org"},
+		false,
+		map[string][]string{
+			"include": {"eu-*.example.com"},
+		},
+	},
+	{
+		[]string{"eu-?.example.com"},
+		[]string{},
+		[]string{"eu-1.example.com"},
+		true,
+		map[string][]string{
+			"include": {"eu-?.example.com"},
+		},
+	},
+	{
+		[]string{"eu-?.example.com"},
+		[]string{},
+		[]string{"eu-10.example.com"},
+		false,
+		map[string][]string{
+			"include": {"eu-?.example.com"},
+		},
+	},
+	{
+		[]string{".eu-*.example.com"},
+		[]string{},
+		[]string{"api.eu-west.example.com"},
+		true,
+		map[string][]string{
+			"include": {".eu-*.example.com"},
+		},
+	},
+	{
+		[]string{".eu-*.example.com"},
+		[]string{},
+		[]string{"eu-west.example.com"},
+		false,
+		map[string][]string{
+			"include": {".eu-*.example.com"},
+		},
+	},
+	{
+		[]string{"example.com"},
+		[]string{"eu-*.example.com"},
+		[]string{"example.com", "us-east.example.com"},
+		true,
+		map[string][]string{
+			"include": {"example.com"},
+			"exclude": {"eu-*.example.com"},
+		},
+	},
+	{
+		[]string{"example.com"},
+		[]string{"eu-*.example.com"},
+		[]string{"eu-west.example.com", "api.eu-west.example.com"},
+		false,
+		map[string][]string{
+			"include": {"example.com"},
+			"exclude": {"eu-*.example.com"},
+		},
+	},
 }
 
 var regexDomainFilterTests = []regexDomainFilterTest{
@@ -816,6 +908,42 @@ func TestDomainFilterMatchParent(t *testing.T) {
 			true,
 			map[string][]string{},
 		},
+		{
+			[]string{"*.staging.example.com"},
+			[]string{},
+			[]string{"staging.example.com", "example.com", "com"},
+			true,
+			map[string][]string{
+				"include": {"*.staging.example.com"},
+			},
+		},
+		{
+			[]string{"eu-*.example.com"},
+			[]string{},
+			[]string{"example.com"},
+			true,
+			map[string][]string{
+				"include": {"eu-*.example.com"},
+			},
+		},
+		{
+			[]string{"eu-*.example.com"},
+			[]string{},
+			[]string{"eu-west.example.com", "example.org", "staging.example.com"},
+			false,
+			map[string][]string{
+				"include": {"eu-*.example.com"},
+			},
+		},
+		{
+			[]string{".eu-*.example.com"},
+			[]string{},
+			[]string{"example.com"},
+			false,
+			map[string][]string{
+				"include": {".eu-*.example.com"},
+			},
+		},
 	}
 	for i, tt := range parentMatchTests {
 		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
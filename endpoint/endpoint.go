@@ -17,15 +17,19 @@ limitations under the License.
 package endpoint
 
 import (
+	"bytes"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/netip"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
-	"k8s.io/utils/set"
 
+	"sigs.k8s.io/external-dns/internal/idna"
 	"sigs.k8s.io/external-dns/pkg/events"
 )
 
@@ -48,6 +52,12 @@ const (
 	RecordTypeMX = "MX"
 	// RecordTypeNAPTR is a RecordType enum value
 	RecordTypeNAPTR = "NAPTR"
+	// RecordTypeSVCB is a RecordType enum value
+	RecordTypeSVCB = "SVCB"
+	// RecordTypeHTTPS is a RecordType enum value
+	RecordTypeHTTPS = "HTTPS"
+	// RecordTypeTLSA is a RecordType enum value
+	RecordTypeTLSA = "TLSA"
 )
 
 var (
@@ -60,6 +70,9 @@ var (
 		RecordTypePTR,
 		RecordTypeMX,
 		RecordTypeNAPTR,
+		RecordTypeSVCB,
+		RecordTypeHTTPS,
+		RecordTypeTLSA,
 	}
 )
 
@@ -80,10 +93,59 @@ type MXTarget struct {
 	host     string
 }
 
+// SRVTarget represents a single SRV (Service) record target, including its priority, weight, port and host.
+type SRVTarget struct {
+	priority uint16
+	weight   uint16
+	port     uint16
+	host     string
+}
+
+// SVCBTarget represents a single SVCB or HTTPS record target, including its priority, target name and service parameters.
+type SVCBTarget struct {
+	priority uint16
+	target   string
+	params   []string
+}
+
+// NAPTRTarget represents a single NAPTR (Naming Authority Pointer) record target, including its order, preference,
+// flags, service, regular expression and replacement.
+type NAPTRTarget struct {
+	order       uint16
+	preference  uint16
+	flags       string
+	service     string
+	regexp      string
+	replacement string
+}
+
+// TLSATarget represents a single TLSA record target, including its certificate usage, selector, matching type
+// and certificate association data.
+type TLSATarget struct {
+	usage        uint8
+	selector     uint8
+	matchingType uint8
+	data         string
+}
+
 // NewTargets is a convenience method to create a new Targets object from a vararg of strings.
 // Returns a new Targets slice with duplicates removed and elements sorted in order.
 func NewTargets(target ...string) Targets {
-	return set.New(target...).SortedList()
+	t := make(Targets, len(target))
+	copy(t, target)
+	slices.Sort(t)
+	return slices.Compact(t)
+}
+
+// Normalize returns the targets sorted in order with duplicates removed, like NewTargets. Targets which are
+// already normalized, as most are, are returned as they are rather than copied.
+func (t Targets) Normalize() Targets {
+	for i := 1; i < len(t); i++ {
+		if t[i-1] >= t[i] {
+			return NewTargets(t...)
+		}
+	}
+	return t
 }
 
 func (t Targets) String() string {
@@ -95,17 +157,24 @@ func (t Targets) Len() int {
 }
 
 func (t Targets) Less(i, j int) bool {
-	ipi, err := netip.ParseAddr(t[i])
+	return compareTargets(t[i], t[j]) < 0
+}
+
+// compareTargets orders IP addresses by their canonical form, and any other targets as strings. The canonical
+// forms are written to buffers on the stack, as sorting compares the same targets many times.
+func compareTargets(a, b string) int {
+	ipA, err := netip.ParseAddr(a)
 	if err != nil {
-		return t[i] < t[j]
+		return strings.Compare(a, b)
 	}
 
-	ipj, err := netip.ParseAddr(t[j])
+	ipB, err := netip.ParseAddr(b)
 	if err != nil {
-		return t[i] < t[j]
+		return strings.Compare(a, b)
 	}
 
-	return ipi.String() < ipj.String()
+	var bufA, bufB [64]byte
+	return bytes.Compare(ipA.AppendTo(bufA[:0]), ipB.AppendTo(bufB[:0]))
 }
 
 func (t Targets) Swap(i, j int) {
@@ -117,32 +186,33 @@ func (t Targets) Same(o Targets) bool {
 	if len(t) != len(o) {
 		return false
 	}
-	sort.Stable(t)
-	sort.Stable(o)
+	// targets are mostly compared with the same targets in the same order
+	if slices.EqualFunc(t, o, strings.EqualFold) {
+		return true
+	}
+	slices.SortStableFunc(t, compareTargets)
+	slices.SortStableFunc(o, compareTargets)
 
 	for i, e := range t {
-		if !strings.EqualFold(e, o[i]) {
-			// IPv6 can be shortened, so it should be parsed for equality checking
-			ipA, err := netip.ParseAddr(e)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"targets":           t,
-					"comparisonTargets": o,
-				}).Debugf("Couldn't parse %s as an IP address: %v", e, err)
-			}
+		if strings.EqualFold(e, o[i]) {
+			continue
+		}
 
-			ipB, err := netip.ParseAddr(o[i])
-			if err != nil {
+		// IPv6 can be shortened, so it should be parsed for equality checking
+		ipA, errA := netip.ParseAddr(e)
+		ipB, errB := netip.ParseAddr(o[i])
+		if err := errors.Join(errA, errB); err != nil {
+			if log.IsLevelEnabled(log.DebugLevel) {
 				log.WithFields(log.Fields{
 					"targets":           t,
 					"comparisonTargets": o,
-				}).Debugf("Couldn't parse %s as an IP address: %v", e, err)
+				}).Debugf("Couldn't parse %s or %s as an IP address: %v", e, o[i], err)
 			}
+			return false
+		}
 
-			// IPv6 Address Shortener == IPv6 Address Expander
-			if ipA.IsValid() && ipB.IsValid() {
-				return ipA.String() == ipB.String()
-			}
+		// IPv6 Address Shortener == IPv6 Address Expander
+		if ipA != ipB {
 			return false
 		}
 	}
@@ -242,11 +312,74 @@ type Endpoint struct {
 	// ProviderSpecific stores provider specific config
 	// +optional
 	ProviderSpecific ProviderSpecific `json:"providerSpecific,omitempty"`
+	// Weights stores the relative weight of targets for providers with weighted routing, targets without a weight have a weight of 1
+	// +optional
+	Weights map[string]int64 `json:"weights,omitempty"`
+	// Geo is the location whose clients the record answers, for providers with geo routing
+	// +optional
+	Geo *GeoLocation `json:"geo,omitempty"`
+	// Description is the human-readable description of the record, for providers with record comments
+	// +optional
+	Description string `json:"description,omitempty"`
 	// refObject stores reference object
 	// +optional
 	refObject *events.ObjectReference
 }
 
+// continents are the codes of the continents a geo location may refer to.
+var continents = []string{"AF", "AN", "AS", "EU", "NA", "OC", "SA"}
+
+// GeoLocation is the location of the clients a record answers, by continent, or by country and optionally region.
+type GeoLocation struct {
+	// Continent is the two-letter code of the continent, e.g. EU
+	Continent string `json:"continent,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the country, e.g. DE, or * for clients of any other location
+	Country string `json:"country,omitempty"`
+	// Region is the ISO 3166-2 code of the subdivision within the country, e.g. CA for California in the US
+	Region string `json:"region,omitempty"`
+}
+
+// Validate returns an error if the geo location doesn't refer to exactly one continent, country or region.
+func (g GeoLocation) Validate() error {
+	switch {
+	case g.Continent == "" && g.Country == "":
+		return errors.New("geo location needs a continent or a country")
+	case g.Continent != "" && (g.Country != "" || g.Region != ""):
+		return errors.New("geo location can't have both a continent and a country or region")
+	case g.Continent != "" && !slices.Contains(continents, g.Continent):
+		return fmt.Errorf("invalid continent %q, must be one of %s", g.Continent, strings.Join(continents, ", "))
+	case g.Country != "*" && g.Country != "" && !isGeoCode(g.Country, 2, 2):
+		return fmt.Errorf("invalid country %q, must be an ISO 3166-1 alpha-2 code or *", g.Country)
+	case g.Region != "" && (g.Country == "*" || !isGeoCode(g.Region, 1, 3)):
+		return fmt.Errorf("invalid region %q, must be an ISO 3166-2 subdivision code of the country", g.Region)
+	}
+	return nil
+}
+
+// isGeoCode returns true if the code consists of minLen to maxLen upper case letters or digits.
+func isGeoCode(code string, minLen, maxLen int) bool {
+	if len(code) < minLen || len(code) > maxLen {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the continent, the country, or the country and the region separated by a dash, e.g. US-CA.
+func (g GeoLocation) String() string {
+	if g.Continent != "" {
+		return g.Continent
+	}
+	if g.Region != "" {
+		return g.Country + "-" + g.Region
+	}
+	return g.Country
+}
+
 // NewEndpoint initialization method to be used to create an endpoint
 func NewEndpoint(dnsName, recordType string, targets ...string) *Endpoint {
 	return NewEndpointWithTTL(dnsName, recordType, TTL(0), targets...)
@@ -256,6 +389,11 @@ func NewEndpoint(dnsName, recordType string, targets ...string) *Endpoint {
 func NewEndpointWithTTL(dnsName, recordType string, ttl TTL, targets ...string) *Endpoint {
 	cleanTargets := make([]string, len(targets))
 	for idx, target := range targets {
+		// a last field of "." is the root name, e.g. in SRV, SVCB and NAPTR targets, and is kept
+		if target == "." || strings.HasSuffix(target, " .") {
+			cleanTargets[idx] = target
+			continue
+		}
 		cleanTargets[idx] = strings.TrimSuffix(target, ".")
 	}
 
@@ -326,6 +464,40 @@ func (e *Endpoint) DeleteProviderSpecificProperty(key string) {
 	}
 }
 
+// WithWeight applies the given weight to all targets of the endpoint.
+func (e *Endpoint) WithWeight(weight int64) *Endpoint {
+	e.Weights = make(map[string]int64, len(e.Targets))
+	for _, target := range e.Targets {
+		e.Weights[target] = weight
+	}
+	return e
+}
+
+// IsWeighted returns true if any target of the endpoint has a weight.
+func (e *Endpoint) IsWeighted() bool {
+	return len(e.Weights) > 0
+}
+
+// TargetWeight returns the weight of the given target, which is 1 if it has none.
+func (e *Endpoint) TargetWeight(target string) int64 {
+	if weight, ok := e.Weights[target]; ok {
+		return weight
+	}
+	return 1
+}
+
+// WithDescription applies the given description to the endpoint.
+func (e *Endpoint) WithDescription(description string) *Endpoint {
+	e.Description = description
+	return e
+}
+
+// WithGeo applies the given geo location to the endpoint.
+func (e *Endpoint) WithGeo(geo GeoLocation) *Endpoint {
+	e.Geo = &geo
+	return e
+}
+
 // WithLabel adds or updates a label for the Endpoint.
 //
 // Example usage:
@@ -365,12 +537,18 @@ func (e *Endpoint) IsOwnedBy(ownerID string) bool {
 	return ok && endpointOwner == ownerID
 }
 
+// IsProtected returns true if the endpoint is marked as protected from deletion and takeover, false otherwise
+func (e *Endpoint) IsProtected() bool {
+	return e.Labels[ProtectedLabelKey] == "true"
+}
+
 func (e *Endpoint) String() string {
 	return fmt.Sprintf("%s %d IN %s %s %s %s", e.DNSName, e.RecordTTL, e.RecordType, e.SetIdentifier, e.Targets, e.ProviderSpecific)
 }
 
+// Describe returns a human-readable description of the endpoint, with its DNS name in Unicode form.
 func (e *Endpoint) Describe() string {
-	return fmt.Sprintf("record:%s, owner:%s, type:%s, targets:%s", e.DNSName, e.SetIdentifier, e.RecordType, strings.Join(e.Targets, ", "))
+	return fmt.Sprintf("record:%s, owner:%s, type:%s, targets:%s", idna.ToUnicode(e.DNSName), e.SetIdentifier, e.RecordType, strings.Join(e.Targets, ", "))
 }
 
 // FilterEndpointsByOwnerID Apply filter to slice of endpoints and return new filtered slice that includes
@@ -392,8 +570,8 @@ func FilterEndpointsByOwnerID(ownerID string, eps []*Endpoint) []*Endpoint {
 // This function doesn't contemplate the Targets of an Endpoint
 // as part of the primary Key
 func RemoveDuplicates(endpoints []*Endpoint) []*Endpoint {
-	visited := make(map[EndpointKey]struct{})
-	result := []*Endpoint{}
+	visited := make(map[EndpointKey]struct{}, len(endpoints))
+	result := make([]*Endpoint, 0, len(endpoints))
 
 	for _, ep := range endpoints {
 		key := ep.Key()
@@ -411,11 +589,29 @@ func RemoveDuplicates(endpoints []*Endpoint) []*Endpoint {
 
 // CheckEndpoint Check if endpoint is properly formatted according to RFC standards
 func (e *Endpoint) CheckEndpoint() bool {
+	for target, weight := range e.Weights {
+		if weight < 0 || !slices.Contains(e.Targets, target) {
+			log.Debugf("Invalid weight %d of target %s of %s", weight, target, e.DNSName)
+			return false
+		}
+	}
+	if e.Geo != nil {
+		if err := e.Geo.Validate(); err != nil {
+			log.Debugf("Invalid geo location of %s: %v", e.DNSName, err)
+			return false
+		}
+	}
 	switch recordType := e.RecordType; recordType {
 	case RecordTypeMX:
 		return e.Targets.ValidateMXRecord()
 	case RecordTypeSRV:
 		return e.Targets.ValidateSRVRecord()
+	case RecordTypeSVCB, RecordTypeHTTPS:
+		return e.Targets.ValidateSVCBRecord()
+	case RecordTypeTLSA:
+		return e.Targets.ValidateTLSARecord()
+	case RecordTypeNAPTR:
+		return e.Targets.ValidateNAPTRRecord()
 	}
 	return true
 }
@@ -463,21 +659,365 @@ func (t Targets) ValidateMXRecord() bool {
 
 func (t Targets) ValidateSRVRecord() bool {
 	for _, target := range t {
-		// SRV records must have a priority, weight, and port value, e.g. "10 5 5060 example.com"
-		// as per https://www.rfc-editor.org/rfc/rfc2782.txt
-		targetParts := strings.Fields(strings.TrimSpace(target))
-		if len(targetParts) != 4 {
-			log.Debugf("Invalid SRV record target: %s. SRV records must have a priority, weight, and port value, e.g. '10 5 5060 example.com'", target)
+		_, err := NewSRVRecord(target)
+		if err != nil {
+			log.Debugf("Invalid SRV record target: %s. %v", target, err)
 			return false
 		}
+	}
+	return true
+}
 
-		for _, part := range targetParts[:3] {
-			_, err := strconv.ParseUint(part, 10, 16)
-			if err != nil {
-				log.Debugf("Invalid SRV record target: %s. Invalid integer value in target.", target)
-				return false
+// NewSRVRecord parses a string representation of an SRV record target (e.g., "10 5 5060 sip.example.com")
+// as per https://www.rfc-editor.org/rfc/rfc2782.txt and returns an SRVTarget struct.
+// Returns an error if the input is invalid.
+func NewSRVRecord(target string) (*SRVTarget, error) {
+	parts := strings.Fields(strings.TrimSpace(target))
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid SRV record target: %s. SRV records must have a priority, weight, and port value and a host, e.g. '10 5 5060 example.com'", target)
+	}
+
+	var values [3]uint16
+	for i, part := range parts[:3] {
+		value, err := strconv.ParseUint(part, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value in target: %s", target)
+		}
+		values[i] = uint16(value)
+	}
+
+	return &SRVTarget{
+		priority: values[0],
+		weight:   values[1],
+		port:     values[2],
+		host:     parts[3],
+	}, nil
+}
+
+// GetPriority returns the priority of the SRV record target.
+func (s *SRVTarget) GetPriority() *uint16 {
+	return &s.priority
+}
+
+// GetWeight returns the weight of the SRV record target.
+func (s *SRVTarget) GetWeight() *uint16 {
+	return &s.weight
+}
+
+// GetPort returns the port of the SRV record target.
+func (s *SRVTarget) GetPort() *uint16 {
+	return &s.port
+}
+
+// GetHost returns the host of the SRV record target.
+func (s *SRVTarget) GetHost() *string {
+	return &s.host
+}
+
+// String returns the SRV record target in its canonical form, "<priority> <weight> <port> <host>".
+func (s *SRVTarget) String() string {
+	return fmt.Sprintf("%d %d %d %s", s.priority, s.weight, s.port, s.host)
+}
+
+// svcParamKeys are the service parameter keys registered for SVCB and HTTPS records, besides "key<N>".
+var svcParamKeys = map[string]bool{
+	"mandatory":       true,
+	"alpn":            true,
+	"no-default-alpn": true,
+	"port":            true,
+	"ipv4hint":        true,
+	"ech":             true,
+	"ipv6hint":        true,
+	"dohpath":         true,
+	"ohttp":           true,
+}
+
+// NewSVCBRecord parses a string representation of an SVCB or HTTPS record target (e.g., "1 . alpn=h3,h2 ipv4hint=192.0.2.1")
+// as per https://www.rfc-editor.org/rfc/rfc9460.txt and returns an SVCBTarget struct. Quotes around parameter values
+// are removed. Returns an error if the input is invalid.
+func NewSVCBRecord(target string) (*SVCBTarget, error) {
+	parts := strings.Fields(strings.TrimSpace(target))
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid SVCB record target: %s. SVCB records must have a priority and a target, e.g. '1 . alpn=h3,h2'", target)
+	}
+
+	priority, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integer value in target: %s", target)
+	}
+	if priority == 0 && len(parts) > 2 {
+		return nil, fmt.Errorf("invalid SVCB record target: %s. Records with priority 0 are aliases and have no parameters", target)
+	}
+
+	params := make([]string, 0, len(parts)-2)
+	for _, param := range parts[2:] {
+		key, value, hasValue := strings.Cut(param, "=")
+		if !svcParamKeys[key] {
+			if n, ok := strings.CutPrefix(key, "key"); !ok || n == "" {
+				return nil, fmt.Errorf("invalid SVCB record target: %s. Unknown parameter %q", target, key)
+			} else if _, err := strconv.ParseUint(n, 10, 16); err != nil {
+				return nil, fmt.Errorf("invalid SVCB record target: %s. Unknown parameter %q", target, key)
+			}
+		}
+		value = strings.Trim(value, `"`)
+		if err := validateSVCParam(key, value); err != nil {
+			return nil, fmt.Errorf("invalid SVCB record target: %s. %w", target, err)
+		}
+		if hasValue {
+			param = key + "=" + value
+		}
+		params = append(params, param)
+	}
+
+	return &SVCBTarget{
+		priority: uint16(priority),
+		target:   parts[1],
+		params:   params,
+	}, nil
+}
+
+func validateSVCParam(key, value string) error {
+	switch key {
+	case "port":
+		if _, err := strconv.ParseUint(value, 10, 16); err != nil {
+			return fmt.Errorf("invalid port %q", value)
+		}
+	case "ipv4hint", "ipv6hint":
+		for _, hint := range strings.Split(value, ",") {
+			ip, err := netip.ParseAddr(hint)
+			if err != nil || ip.Is4() != (key == "ipv4hint") {
+				return fmt.Errorf("invalid %s %q", key, hint)
 			}
 		}
+	case "no-default-alpn":
+		if value != "" {
+			return fmt.Errorf("no-default-alpn has no value")
+		}
+	case "alpn", "mandatory":
+		if value == "" {
+			return fmt.Errorf("%s requires a value", key)
+		}
+	}
+	return nil
+}
+
+// GetPriority returns the priority of the SVCB record target.
+func (s *SVCBTarget) GetPriority() *uint16 {
+	return &s.priority
+}
+
+// GetTarget returns the target name of the SVCB record target.
+func (s *SVCBTarget) GetTarget() *string {
+	return &s.target
+}
+
+// GetParams returns the service parameters of the SVCB record target, in the form "key=value" or "key".
+func (s *SVCBTarget) GetParams() []string {
+	return s.params
+}
+
+// String returns the SVCB record target in the form "<priority> <target> <params>".
+func (s *SVCBTarget) String() string {
+	return strings.Join(append([]string{strconv.FormatUint(uint64(s.priority), 10), s.target}, s.params...), " ")
+}
+
+func (t Targets) ValidateSVCBRecord() bool {
+	for _, target := range t {
+		_, err := NewSVCBRecord(target)
+		if err != nil {
+			log.Debugf("Invalid SVCB record target: %s. %v", target, err)
+			return false
+		}
+	}
+	return true
+}
+
+// NewTLSARecord parses a string representation of a TLSA record target (e.g., "3 1 1 <sha256 hex>")
+// as per https://www.rfc-editor.org/rfc/rfc6698.txt and returns a TLSATarget struct.
+// Returns an error if the input is invalid.
+func NewTLSARecord(target string) (*TLSATarget, error) {
+	parts := strings.Fields(strings.TrimSpace(target))
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("invalid TLSA record target: %s. TLSA records must have a usage, selector and matching type value and certificate association data, e.g. '3 1 1 <sha256 hex>'", target)
+	}
+
+	var values [3]uint8
+	for i, part := range parts[:3] {
+		value, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value in target: %s", target)
+		}
+		values[i] = uint8(value)
+	}
+
+	// the certificate association data may be split in several blocks of hexadecimal digits
+	data := strings.ToLower(strings.Join(parts[3:], ""))
+	if _, err := hex.DecodeString(data); err != nil {
+		return nil, fmt.Errorf("invalid TLSA record target: %s. Certificate association data must be hexadecimal", target)
+	}
+	if length, ok := tlsaDigestLengths[values[2]]; ok && len(data) != length {
+		return nil, fmt.Errorf("invalid TLSA record target: %s. Certificate association data must have %d hexadecimal digits for matching type %d", target, length, values[2])
+	}
+
+	return &TLSATarget{
+		usage:        values[0],
+		selector:     values[1],
+		matchingType: values[2],
+		data:         data,
+	}, nil
+}
+
+// tlsaDigestLengths are the lengths in hexadecimal digits of the SHA-256 and SHA-512 matching types.
+var tlsaDigestLengths = map[uint8]int{
+	1: 64,
+	2: 128,
+}
+
+// GetUsage returns the certificate usage of the TLSA record target.
+func (t *TLSATarget) GetUsage() *uint8 {
+	return &t.usage
+}
+
+// GetSelector returns the selector of the TLSA record target.
+func (t *TLSATarget) GetSelector() *uint8 {
+	return &t.selector
+}
+
+// GetMatchingType returns the matching type of the TLSA record target.
+func (t *TLSATarget) GetMatchingType() *uint8 {
+	return &t.matchingType
+}
+
+// GetData returns the lowercase hexadecimal certificate association data of the TLSA record target.
+func (t *TLSATarget) GetData() *string {
+	return &t.data
+}
+
+// String returns the TLSA record target in its canonical form, "<usage> <selector> <matching type> <data>".
+func (t *TLSATarget) String() string {
+	return fmt.Sprintf("%d %d %d %s", t.usage, t.selector, t.matchingType, t.data)
+}
+
+func (t Targets) ValidateTLSARecord() bool {
+	for _, target := range t {
+		_, err := NewTLSARecord(target)
+		if err != nil {
+			log.Debugf("Invalid TLSA record target: %s. %v", target, err)
+			return false
+		}
+	}
+	return true
+}
+
+// NewNAPTRRecord parses a string representation of a NAPTR record target
+// (e.g., `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`) as per https://www.rfc-editor.org/rfc/rfc3403.txt
+// and returns a NAPTRTarget struct. The flags, service and regexp are character strings, which may be quoted.
+// Returns an error if the input is invalid.
+func NewNAPTRRecord(target string) (*NAPTRTarget, error) {
+	parts, err := splitCharacterStrings(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NAPTR record target: %s. %w", target, err)
+	}
+	if len(parts) != 6 {
+		return nil, fmt.Errorf("invalid NAPTR record target: %s. NAPTR records must have an order, preference, flags, service, regexp and replacement, e.g. '100 10 \"U\" \"E2U+sip\" \"!^.*$!sip:info@example.com!\" .'", target)
+	}
+
+	var values [2]uint16
+	for i, part := range parts[:2] {
+		value, err := strconv.ParseUint(part, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value in target: %s", target)
+		}
+		values[i] = uint16(value)
+	}
+
+	for _, r := range parts[2] {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return nil, fmt.Errorf("invalid NAPTR record target: %s. Flags must be alphanumeric", target)
+		}
+	}
+
+	return &NAPTRTarget{
+		order:       values[0],
+		preference:  values[1],
+		flags:       parts[2],
+		service:     parts[3],
+		regexp:      parts[4],
+		replacement: parts[5],
+	}, nil
+}
+
+// splitCharacterStrings splits the given presentation format on whitespace, keeping quoted character strings,
+// which may contain whitespace and escaped quotes, together and without their quotes.
+func splitCharacterStrings(s string) ([]string, error) {
+	var parts []string
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		if s[0] != '"' {
+			end := strings.IndexAny(s, " \t")
+			if end < 0 {
+				end = len(s)
+			}
+			parts = append(parts, s[:end])
+			s = s[end:]
+			continue
+		}
+		end := 1
+		for ; end < len(s) && s[end] != '"'; end++ {
+			if s[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(s) {
+			return nil, fmt.Errorf("unterminated quoted string")
+		}
+		parts = append(parts, s[1:end])
+		s = s[end+1:]
+	}
+	return parts, nil
+}
+
+// GetOrder returns the order of the NAPTR record target.
+func (n *NAPTRTarget) GetOrder() *uint16 {
+	return &n.order
+}
+
+// GetPreference returns the preference of the NAPTR record target.
+func (n *NAPTRTarget) GetPreference() *uint16 {
+	return &n.preference
+}
+
+// GetFlags returns the flags of the NAPTR record target.
+func (n *NAPTRTarget) GetFlags() *string {
+	return &n.flags
+}
+
+// GetService returns the service of the NAPTR record target.
+func (n *NAPTRTarget) GetService() *string {
+	return &n.service
+}
+
+// GetRegexp returns the regular expression of the NAPTR record target.
+func (n *NAPTRTarget) GetRegexp() *string {
+	return &n.regexp
+}
+
+// GetReplacement returns the replacement domain name of the NAPTR record target.
+func (n *NAPTRTarget) GetReplacement() *string {
+	return &n.replacement
+}
+
+// String returns the NAPTR record target in its canonical form, with quoted flags, service and regexp.
+func (n *NAPTRTarget) String() string {
+	return fmt.Sprintf(`%d %d "%s" "%s" "%s" %s`, n.order, n.preference, n.flags, n.service, n.regexp, n.replacement)
+}
+
+func (t Targets) ValidateNAPTRRecord() bool {
+	for _, target := range t {
+		_, err := NewNAPTRRecord(target)
+		if err != nil {
+			log.Debugf("Invalid NAPTR record target: %s. %v", target, err)
+			return false
+		}
 	}
 	return true
 }
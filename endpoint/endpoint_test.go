@@ -19,6 +19,7 @@ package endpoint
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,6 +39,9 @@ func TestNewEndpoint(t *testing.T) {
 	if w.DNSName != "example.org" || w.Targets[0] != "load-balancer.com" || w.RecordType != "" {
 		t.Error("endpoint is not initialized correctly")
 	}
+
+	n := NewEndpoint("example.org", RecordTypeNAPTR, `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.org!" .`, `102 10 "S" "SIP+D2T" "" _sip._tcp.example.org.`)
+	assert.Equal(t, Targets{`100 10 "U" "E2U+sip" "!^.*$!sip:info@example.org!" .`, `102 10 "S" "SIP+D2T" "" _sip._tcp.example.org`}, n.Targets)
 }
 
 func TestNewTargets(t *testing.T) {
@@ -72,6 +76,28 @@ func TestNewTargets(t *testing.T) {
 	}
 }
 
+func TestTargetsNormalize(t *testing.T) {
+	normalized := Targets{"1.2.3.4", "5.6.7.8", "example.com"}
+	got := normalized.Normalize()
+	assert.Equal(t, normalized, got)
+	assert.Same(t, &normalized[0], &got[0], "normalized targets should not be copied")
+
+	targets := Targets{"example.com", "5.6.7.8", "1.2.3.4", "5.6.7.8"}
+	assert.Equal(t, normalized, targets.Normalize())
+	assert.Equal(t, Targets{"example.com", "5.6.7.8", "1.2.3.4", "5.6.7.8"}, targets, "targets should be left as they were")
+
+	assert.Empty(t, Targets{}.Normalize())
+}
+
+func TestTargetsAllocations(t *testing.T) {
+	a := Targets{"10.0.0.1", "10.0.0.2", "2001:db8::1", "example.com"}
+	b := Targets{"10.0.0.1", "10.0.0.2", "2001:db8::1", "example.com"}
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { a.Same(b) }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { a.Less(2, 0) }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { a.Normalize() }))
+}
+
 func TestTargetsSame(t *testing.T) {
 	tests := []Targets{
 		{""},
@@ -141,6 +167,10 @@ func TestSameFailures(t *testing.T) {
 			[]string{"::1", "2600.com", "3.3.3.3"},
 			[]string{"2600.com", "3.3.3.3", "1.1.1.1"},
 		},
+		{
+			[]string{"::1", "b.example.org"},
+			[]string{"::0001", "c.example.org"},
+		},
 	}
 
 	for _, d := range tests {
@@ -588,6 +618,40 @@ func TestIsOwnedBy(t *testing.T) {
 	}
 }
 
+func TestIsProtected(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels Labels
+		want   bool
+	}{
+		{
+			name:   "empty labels",
+			labels: Labels{},
+			want:   false,
+		},
+		{
+			name:   "protected label false",
+			labels: Labels{ProtectedLabelKey: "false"},
+			want:   false,
+		},
+		{
+			name:   "protected label true",
+			labels: Labels{ProtectedLabelKey: "true"},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Endpoint{
+				Labels: tt.labels,
+			}
+			if got := e.IsProtected(); got != tt.want {
+				t.Errorf("Endpoint.IsProtected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDuplicatedEndpointsWithSimpleZone(t *testing.T) {
 	foo1 := &Endpoint{
 		DNSName:    "foo.com",
@@ -865,6 +929,226 @@ func TestNewMXTarget(t *testing.T) {
 	}
 }
 
+func TestNewSRVTarget(t *testing.T) {
+	tests := []struct {
+		description string
+		target      string
+		expected    *SRVTarget
+		expectError bool
+	}{
+		{
+			description: "Valid SRV record",
+			target:      " 10  5 5060 sip.example.com.",
+			expected:    &SRVTarget{priority: 10, weight: 5, port: 5060, host: "sip.example.com."},
+		},
+		{
+			description: "Invalid SRV record with missing weight",
+			target:      "10 5060 sip.example.com",
+			expectError: true,
+		},
+		{
+			description: "Invalid SRV record with out of range port",
+			target:      "10 5 65536 sip.example.com",
+			expectError: true,
+		},
+		{
+			description: "Invalid SRV record with non-integer priority",
+			target:      "high 5 5060 sip.example.com",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			actual, err := NewSRVRecord(tt.target)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+				assert.Equal(t, "10 5 5060 sip.example.com.", actual.String())
+			}
+		})
+	}
+}
+
+func TestNewSVCBTarget(t *testing.T) {
+	tests := []struct {
+		description string
+		target      string
+		expected    string
+		expectError bool
+	}{
+		{
+			description: "Service mode with parameters",
+			target:      `1  . alpn="h3,h2" ipv4hint=192.0.2.1,192.0.2.2 ipv6hint=2001:db8::1 port=8443 no-default-alpn key65000=foo`,
+			expected:    "1 . alpn=h3,h2 ipv4hint=192.0.2.1,192.0.2.2 ipv6hint=2001:db8::1 port=8443 no-default-alpn key65000=foo",
+		},
+		{
+			description: "Alias mode",
+			target:      "0 svc.example.com.",
+			expected:    "0 svc.example.com.",
+		},
+		{
+			description: "Alias mode with parameters",
+			target:      "0 svc.example.com. alpn=h2",
+			expectError: true,
+		},
+		{
+			description: "Missing target",
+			target:      "1",
+			expectError: true,
+		},
+		{
+			description: "Unknown parameter",
+			target:      "1 . foo=bar",
+			expectError: true,
+		},
+		{
+			description: "IPv6 address in ipv4hint",
+			target:      "1 . ipv4hint=2001:db8::1",
+			expectError: true,
+		},
+		{
+			description: "Invalid port",
+			target:      "1 . port=https",
+			expectError: true,
+		},
+		{
+			description: "Missing alpn value",
+			target:      "1 . alpn",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			actual, err := NewSVCBRecord(tt.target)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual.String())
+			}
+		})
+	}
+}
+
+func TestNewTLSATarget(t *testing.T) {
+	digest := "8cb0fc6c527506a053f4f14c8464bebbd6dede2738d11468dd953d7d6a3021f1"
+	tests := []struct {
+		description string
+		target      string
+		expected    string
+		expectError bool
+	}{
+		{
+			description: "SHA-256 of the public key",
+			target:      "3 1 1 " + strings.ToUpper(digest),
+			expected:    "3 1 1 " + digest,
+		},
+		{
+			description: "Data split in blocks",
+			target:      "3 1 1 " + digest[:32] + " " + digest[32:],
+			expected:    "3 1 1 " + digest,
+		},
+		{
+			description: "Full certificate",
+			target:      "3 0 0 3082",
+			expected:    "3 0 0 3082",
+		},
+		{
+			description: "Missing data",
+			target:      "3 1 1",
+			expectError: true,
+		},
+		{
+			description: "Non-hexadecimal data",
+			target:      "3 1 1 xyz",
+			expectError: true,
+		},
+		{
+			description: "Digest of the wrong length",
+			target:      "3 1 2 " + digest,
+			expectError: true,
+		},
+		{
+			description: "Usage out of range",
+			target:      "256 1 1 " + digest,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			actual, err := NewTLSARecord(tt.target)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual.String())
+			}
+		})
+	}
+}
+
+func TestNewNAPTRTarget(t *testing.T) {
+	tests := []struct {
+		description string
+		target      string
+		expected    string
+		expectError bool
+	}{
+		{
+			description: "Regexp",
+			target:      `100  10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`,
+			expected:    `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`,
+		},
+		{
+			description: "Replacement with unquoted and empty strings",
+			target:      `010 50 S SIP+D2U "" _sip._udp.example.com.`,
+			expected:    `10 50 "S" "SIP+D2U" "" _sip._udp.example.com.`,
+		},
+		{
+			description: "Regexp with spaces and escaped quotes",
+			target:      `100 10 "U" "E2U+sip" "!^.*$!sip:\"a b\"@example.com!" .`,
+			expected:    `100 10 "U" "E2U+sip" "!^.*$!sip:\"a b\"@example.com!" .`,
+		},
+		{
+			description: "Invalid flags",
+			target:      `100 10 "U+" "E2U+sip" "" sip.example.com.`,
+			expectError: true,
+		},
+		{
+			description: "Missing replacement",
+			target:      `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!"`,
+			expectError: true,
+		},
+		{
+			description: "Unterminated string",
+			target:      `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com! .`,
+			expectError: true,
+		},
+		{
+			description: "Order out of range",
+			target:      `65536 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			actual, err := NewNAPTRRecord(tt.target)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual.String())
+			}
+		})
+	}
+}
+
 func TestCheckEndpoint(t *testing.T) {
 	tests := []struct {
 		description string
@@ -916,6 +1200,36 @@ func TestCheckEndpoint(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			description: "Valid target weights",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeA,
+				Targets:    Targets{"192.168.1.1", "192.168.1.2"},
+				Weights:    map[string]int64{"192.168.1.1": 0, "192.168.1.2": 10},
+			},
+			expected: true,
+		},
+		{
+			description: "Negative target weight",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeA,
+				Targets:    Targets{"192.168.1.1"},
+				Weights:    map[string]int64{"192.168.1.1": -1},
+			},
+			expected: false,
+		},
+		{
+			description: "Weight of an unknown target",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeA,
+				Targets:    Targets{"192.168.1.1"},
+				Weights:    map[string]int64{"192.168.1.2": 1},
+			},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -926,6 +1240,50 @@ func TestCheckEndpoint(t *testing.T) {
 	}
 }
 
+func TestWithWeight(t *testing.T) {
+	ep := NewEndpoint("example.com", RecordTypeA, "192.168.1.1", "192.168.1.2")
+	assert.False(t, ep.IsWeighted())
+	assert.Equal(t, int64(1), ep.TargetWeight("192.168.1.1"))
+
+	ep.WithWeight(0)
+	assert.True(t, ep.IsWeighted())
+	assert.Equal(t, map[string]int64{"192.168.1.1": 0, "192.168.1.2": 0}, ep.Weights)
+
+	ep.Weights = map[string]int64{"192.168.1.1": 5}
+	assert.Equal(t, int64(5), ep.TargetWeight("192.168.1.1"))
+	assert.Equal(t, int64(1), ep.TargetWeight("192.168.1.2"))
+}
+
+func TestGeoLocation(t *testing.T) {
+	for _, tt := range []struct {
+		geo       GeoLocation
+		expected  string
+		expectErr bool
+	}{
+		{geo: GeoLocation{Continent: "EU"}, expected: "EU"},
+		{geo: GeoLocation{Country: "US"}, expected: "US"},
+		{geo: GeoLocation{Country: "US", Region: "CA"}, expected: "US-CA"},
+		{geo: GeoLocation{Country: "*"}, expected: "*"},
+		{geo: GeoLocation{}, expectErr: true},
+		{geo: GeoLocation{Continent: "XX"}, expectErr: true},
+		{geo: GeoLocation{Continent: "EU", Country: "DE"}, expectErr: true},
+		{geo: GeoLocation{Country: "USA"}, expectErr: true},
+		{geo: GeoLocation{Country: "us"}, expectErr: true},
+		{geo: GeoLocation{Country: "*", Region: "CA"}, expectErr: true},
+		{geo: GeoLocation{Country: "US", Region: "CALI"}, expectErr: true},
+	} {
+		t.Run(fmt.Sprintf("%+v", tt.geo), func(t *testing.T) {
+			err := tt.geo.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, tt.geo.String())
+		})
+	}
+}
+
 func TestEndpoint_WithRefObject(t *testing.T) {
 	ep := &Endpoint{}
 	ref := &events.ObjectReference{
@@ -939,6 +1297,11 @@ func TestEndpoint_WithRefObject(t *testing.T) {
 	assert.Equal(t, ep, result, "should return the same Endpoint pointer")
 }
 
+func TestEndpoint_Describe(t *testing.T) {
+	ep := NewEndpoint("xn--bcher-kva.example.com", RecordTypeA, "1.2.3.4", "1.2.3.5")
+	assert.Equal(t, "record:bücher.example.com, owner:, type:A, targets:1.2.3.4, 1.2.3.5", ep.Describe())
+}
+
 func TestTargets_UniqueOrdered(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -34,6 +34,24 @@ const (
 	OwnerLabelKey = "owner"
 	// ResourceLabelKey is the name of the label that identifies k8s resource which wants to acquire the DNS name
 	ResourceLabelKey = "resource"
+	// PriorityLabelKey is the name of the label that holds the priority of the k8s resource used to resolve conflicts
+	PriorityLabelKey = "priority"
+	// ResourceCreatedLabelKey is the name of the label that holds the creation time (unix seconds) of the k8s resource
+	ResourceCreatedLabelKey = "resource-created"
+	// ProtectedLabelKey is the name of the label that marks a record as never to be deleted or taken over by another resource
+	ProtectedLabelKey = "protected"
+	// SourceGroupLabelKey is the name of the label that identifies the group of sources which created the record,
+	// when several instances with distinct sources share the same owner
+	SourceGroupLabelKey = "source-group"
+	// ClustersLabelKey is the name of the label that holds the member clusters, sorted and joined by "+", whose
+	// resources contributed the targets of a record aggregated in federated mode
+	ClustersLabelKey = "clusters"
+	// TargetResourcesLabelKey is the name of the label that holds the resources which contributed each target of a
+	// record merged from several resources, as "<resource>@<target>" sorted and joined by "+"
+	TargetResourcesLabelKey = "target-resources"
+	// ResourceLabelPrefix prefixes the names of the labels holding the labels of the k8s resource copied with
+	// --registry-resource-labels, e.g. "label/team" for the "team" label
+	ResourceLabelPrefix = "label/"
 	// OwnedRecordLabelKey is the name of the label that identifies the record that is owned by the labeled TXT registry record
 	OwnedRecordLabelKey = "ownedRecord"
 
@@ -107,24 +125,35 @@ func NewLabelsFromString(labelText string, aesKey []byte) (Labels, error) {
 // SerializePlain transforms endpoints labels into a external-dns recognizable format string
 // withQuotes adds additional quotes
 func (l Labels) SerializePlain(withQuotes bool) string {
-	var tokens []string
-	tokens = append(tokens, fmt.Sprintf("heritage=%s", heritage))
-	var keys []string
+	keys := make([]string, 0, len(l))
 	for key := range l {
 		keys = append(keys, key)
 	}
 	sort.Strings(keys) // sort for consistency
 
+	var b strings.Builder
+	b.Grow(len(heritage) + 16 + len(l)*(len(heritage)+32))
+	if withQuotes {
+		b.WriteByte('"')
+	}
+	b.WriteString("heritage=")
+	b.WriteString(heritage)
 	for _, key := range keys {
-		if key == txtEncryptionNonce {
+		// the labels resolving conflicts between desired records are set by the sources, they aren't stored
+		if key == txtEncryptionNonce || key == ResourceCreatedLabelKey || key == PriorityLabelKey {
 			continue
 		}
-		tokens = append(tokens, fmt.Sprintf("%s/%s=%s", heritage, key, l[key]))
+		b.WriteByte(',')
+		b.WriteString(heritage)
+		b.WriteByte('/')
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(l[key])
 	}
 	if withQuotes {
-		return fmt.Sprintf("\"%s\"", strings.Join(tokens, ","))
+		b.WriteByte('"')
 	}
-	return strings.Join(tokens, ",")
+	return b.String()
 }
 
 // Serialize same to SerializePlain, but encrypt data, if encryption enabled
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+// ShardFilter matches the DNS names assigned to one of several instances sharing the same records.
+// Names are assigned by hashing the lowercased FQDN, so every instance configured with the same
+// count agrees on the assignment.
+type ShardFilter struct {
+	index uint32
+	count uint32
+}
+
+// NewShardFilter returns a ShardFilter matching the names of the shard with the given index out of count shards.
+func NewShardFilter(index, count int) ShardFilter {
+	return ShardFilter{index: uint32(index), count: uint32(count)}
+}
+
+// Match checks whether the domain belongs to the shard. All domains match if there is at most one shard.
+func (sf ShardFilter) Match(domain string) bool {
+	if sf.count <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.ToLower(strings.TrimSuffix(domain, "."))))
+	return h.Sum32()%sf.count == sf.index
+}
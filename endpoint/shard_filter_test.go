@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardFilter(t *testing.T) {
+	const count = 3
+	shards := make([]ShardFilter, count)
+	for i := range shards {
+		shards[i] = NewShardFilter(i, count)
+	}
+
+	matched := make([]int, count)
+	for n := 0; n < 300; n++ {
+		domain := fmt.Sprintf("host-%d.example.com", n)
+		owners := 0
+		for i, sf := range shards {
+			if sf.Match(domain) {
+				owners++
+				matched[i]++
+				assert.True(t, sf.Match("HOST-"+domain[len("host-"):]+"."), "should ignore case and trailing dot")
+			}
+		}
+		assert.Equal(t, 1, owners, "%s should belong to exactly one shard", domain)
+	}
+	for i, m := range matched {
+		assert.NotZero(t, m, "shard %d should get some names", i)
+	}
+
+	assert.True(t, NewShardFilter(0, 1).Match("foo.example.com"))
+	assert.True(t, NewShardFilter(0, 0).Match("foo.example.com"))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TXTStringMaxLength is the maximum length in bytes of a character-string, several of which make up the value of
+// a TXT record. Longer values, such as DKIM keys, must be split.
+const TXTStringMaxLength = 255
+
+// JoinTXT returns the value of a TXT target given as one or more quoted character-strings, e.g. `"v=DKIM1; " "p=..."`,
+// unescaped and concatenated. Targets which aren't made of quoted strings only are returned as they are.
+func JoinTXT(target string) string {
+	var value strings.Builder
+	rest := strings.TrimSpace(target)
+	if !strings.HasPrefix(rest, `"`) {
+		return target
+	}
+	for rest != "" {
+		if rest[0] != '"' {
+			return target
+		}
+		end := -1
+		for i := 1; i < len(rest); i++ {
+			if rest[i] == '\\' {
+				i++
+				continue
+			}
+			if rest[i] == '"' {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			return target
+		}
+		quoted := rest[1:end]
+		for i := 0; i < len(quoted); i++ {
+			if quoted[i] == '\\' && i+1 < len(quoted) {
+				i++
+			}
+			value.WriteByte(quoted[i])
+		}
+		rest = strings.TrimLeft(rest[end+1:], " \t")
+	}
+	return value.String()
+}
+
+// SplitTXT returns the value of a TXT target split into character-strings of at most TXTStringMaxLength bytes,
+// without splitting UTF-8 characters. The target may be given as a plain value or as quoted character-strings.
+func SplitTXT(target string) []string {
+	value := JoinTXT(target)
+	if value == "" {
+		return []string{""}
+	}
+	var chunks []string
+	for len(value) > TXTStringMaxLength {
+		cut := TXTStringMaxLength
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			// not UTF-8, split the bytes
+			cut = TXTStringMaxLength
+		}
+		chunks = append(chunks, value[:cut])
+		value = value[cut:]
+	}
+	return append(chunks, value)
+}
+
+// QuoteTXT returns the value of a TXT target as quoted character-strings of at most TXTStringMaxLength bytes
+// separated by spaces, the presentation format of TXT records, escaping quotes and backslashes.
+func QuoteTXT(target string) string {
+	chunks := SplitTXT(target)
+	quoted := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		quoted[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(chunk) + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+// SplitLongTXT returns a TXT target as quoted character-strings if its value is longer than TXTStringMaxLength,
+// and as it is otherwise, for providers taking TXT values in presentation format.
+func SplitLongTXT(target string) string {
+	if len(JoinTXT(target)) <= TXTStringMaxLength {
+		return target
+	}
+	return QuoteTXT(target)
+}
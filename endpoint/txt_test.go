@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinTXT(t *testing.T) {
+	for _, tt := range []struct {
+		target   string
+		expected string
+	}{
+		{target: "plain value", expected: "plain value"},
+		{target: `"heritage=external-dns,external-dns/owner=default"`, expected: "heritage=external-dns,external-dns/owner=default"},
+		{target: `"v=DKIM1; k=rsa; " "p=MIIB"`, expected: "v=DKIM1; k=rsa; p=MIIB"},
+		{target: `"escaped \"quote\" and \\"`, expected: `escaped "quote" and \`},
+		{target: `""`, expected: ""},
+		{target: `"unterminated`, expected: `"unterminated`},
+		{target: `"quoted" and not`, expected: `"quoted" and not`},
+	} {
+		assert.Equal(t, tt.expected, JoinTXT(tt.target), tt.target)
+	}
+}
+
+func TestSplitTXT(t *testing.T) {
+	assert.Equal(t, []string{"short"}, SplitTXT("short"))
+	assert.Equal(t, []string{""}, SplitTXT(`""`))
+
+	long := strings.Repeat("a", 300)
+	assert.Equal(t, []string{strings.Repeat("a", 255), strings.Repeat("a", 45)}, SplitTXT(long))
+	assert.Equal(t, SplitTXT(long), SplitTXT(QuoteTXT(long)))
+
+	// multi-byte characters are kept whole
+	utf := strings.Repeat("a", 254) + "é"
+	assert.Equal(t, []string{strings.Repeat("a", 254), "é"}, SplitTXT(utf))
+}
+
+func TestQuoteTXT(t *testing.T) {
+	assert.Equal(t, `"short"`, QuoteTXT("short"))
+	assert.Equal(t, `"say \"hi\""`, QuoteTXT(`say "hi"`))
+
+	long := strings.Repeat("a", 255) + `"b`
+	quoted := QuoteTXT(long)
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "\"b"`, quoted)
+	assert.Equal(t, long, JoinTXT(quoted))
+}
+
+func TestSplitLongTXT(t *testing.T) {
+	assert.Equal(t, `"heritage=external-dns"`, SplitLongTXT(`"heritage=external-dns"`))
+	assert.Equal(t, "plain", SplitLongTXT("plain"))
+
+	long := strings.Repeat("k", 400)
+	assert.Equal(t, `"`+strings.Repeat("k", 255)+`" "`+strings.Repeat("k", 145)+`"`, SplitLongTXT(long))
+	assert.Equal(t, SplitLongTXT(long), SplitLongTXT(`"`+long+`"`))
+}
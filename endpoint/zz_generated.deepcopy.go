@@ -24,6 +24,18 @@ func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 		*out = make(ProviderSpecific, len(*in))
 		copy(*out, *in)
 	}
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Geo != nil {
+		in, out := &in.Geo, &out.Geo
+		*out = new(GeoLocation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
@@ -35,3 +47,18 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeoLocation) DeepCopyInto(out *GeoLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoLocation.
+func (in *GeoLocation) DeepCopy() *GeoLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(GeoLocation)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package idna
 
 import (
+	"strings"
+
 	"golang.org/x/net/idna"
 )
 
@@ -27,3 +29,44 @@ var (
 		idna.StrictDomainName(false),
 	)
 )
+
+// ToASCII converts a DNS name to its canonical form, as published by the providers: lower case, with the labels of
+// internationalized names encoded in punycode. Names already in this form, as most are, are returned as is. On
+// error, the name is returned as far as it could be converted.
+func ToASCII(name string) (string, error) {
+	if isLowerASCIIName(name) {
+		return name, nil
+	}
+	return Profile.ToASCII(name)
+}
+
+// ToUnicode converts a DNS name to its lower case Unicode form for display, decoding its punycode labels. Names
+// which can't be converted are returned as is.
+func ToUnicode(name string) string {
+	if isLowerASCIIName(name) && !strings.Contains(name, "xn--") {
+		return name
+	}
+	converted, err := Profile.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+	return converted
+}
+
+// isLowerASCIIName returns whether the DNS name is made of lower case letters, digits, underscores, asterisks,
+// dots and single hyphens within labels, which the IDNA conversion leaves as is. Most names are, which spares
+// converting them.
+func isLowerASCIIName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '.', c == '_', c == '*':
+		case c == '-':
+			if i == 0 || i == len(name)-1 || name[i-1] == '.' || name[i+1] == '.' || name[i+1] == '-' {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -57,3 +57,59 @@ func TestProfileWithDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestIsLowerASCIIName(t *testing.T) {
+	for _, name := range []string{
+		"foo.com", "foo.com.", "my-example-1214.foo-1235.com", "*.example.com", "_acme-challenge.example.com",
+		"", ".", ".foo.com", "foo..com", "-foo.com", "foo-.com", "foo.-com", "ab--c.com", "xn--c1yn36f.org",
+		"Foo.com", "foo com", "點看.org", "nordic-ø.com",
+	} {
+		if isLowerASCIIName(name) {
+			converted, err := Profile.ToASCII(name)
+			assert.NoError(t, err, name)
+			assert.Equal(t, name, converted)
+		}
+	}
+	assert.True(t, isLowerASCIIName("my-example-1214.foo-1235.com"))
+	assert.False(t, isLowerASCIIName("ab--c.com"))
+	assert.False(t, isLowerASCIIName("Foo.com"))
+}
+
+func TestToASCII(t *testing.T) {
+	for _, tt := range []struct {
+		input    string
+		expected string
+	}{
+		{input: "foo.example.com", expected: "foo.example.com"},
+		{input: "Foo.Example.COM.", expected: "foo.example.com."},
+		{input: "bücher.example.com", expected: "xn--bcher-kva.example.com"},
+		{input: "Bücher.EXAMPLE.com", expected: "xn--bcher-kva.example.com"},
+		{input: "BÜCHER.xn--bcher-kva.Example.com", expected: "xn--bcher-kva.xn--bcher-kva.example.com"},
+		{input: "XN--BCHER-KVA.example.com", expected: "xn--bcher-kva.example.com"},
+		{input: "*.Bücher.example.com", expected: "*.xn--bcher-kva.example.com"},
+		{input: "_acme-challenge.Bücher.example.com", expected: "_acme-challenge.xn--bcher-kva.example.com"},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ToASCII(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestToUnicode(t *testing.T) {
+	for _, tt := range []struct {
+		input    string
+		expected string
+	}{
+		{input: "foo.example.com", expected: "foo.example.com"},
+		{input: "xn--bcher-kva.example.com.", expected: "bücher.example.com."},
+		{input: "XN--BCHER-KVA.Example.com", expected: "bücher.example.com"},
+		{input: "*.xn--bcher-kva.example.com", expected: "*.bücher.example.com"},
+		{input: "xn--not-a-valid-punycode.example.com", expected: "xn--not-a-valid-punycode.example.com"},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToUnicode(tt.input))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging sets the log levels of the packages of ExternalDNS independently.
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// modulePrefix is the import path prefix of the packages of ExternalDNS.
+const modulePrefix = "sigs.k8s.io/external-dns/"
+
+// Levels holds the default log level and the levels of specific modules. A module is the import path of a
+// package relative to the repository root with dots instead of slashes, such as source or provider.aws, and
+// includes its sub-packages unless they have their own level.
+type Levels struct {
+	Default log.Level
+	Modules map[string]log.Level
+}
+
+// ParseLevels parses a comma separated list of a default level and of module=level pairs, such as
+// info,source=debug,provider.aws=trace. The default level is info if omitted.
+func ParseLevels(spec string) (Levels, error) {
+	levels := Levels{Default: log.InfoLevel}
+	if strings.TrimSpace(spec) == "" {
+		return levels, nil
+	}
+	for i, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		module, value, isModule := strings.Cut(part, "=")
+		if !isModule {
+			if i > 0 {
+				return Levels{}, fmt.Errorf("default log level %q must come first", part)
+			}
+			value = part
+		}
+		level, err := log.ParseLevel(strings.TrimSpace(value))
+		if err != nil {
+			return Levels{}, err
+		}
+		if !isModule {
+			levels.Default = level
+			continue
+		}
+		module = strings.TrimSpace(module)
+		if module == "" {
+			return Levels{}, fmt.Errorf("missing module name in %q", part)
+		}
+		if levels.Modules == nil {
+			levels.Modules = map[string]log.Level{}
+		}
+		levels.Modules[module] = level
+	}
+	return levels, nil
+}
+
+// Configure sets the levels of the logger. With module levels, the logger reports the callers, whose entries
+// are dropped by its formatter when above the level of their module. It can be called again to replace the
+// levels set by a previous call.
+func (l Levels) Configure(logger *log.Logger) {
+	formatter := logger.Formatter
+	if f, ok := formatter.(*moduleFormatter); ok {
+		formatter = f.Formatter
+	}
+	if len(l.Modules) == 0 {
+		logger.SetLevel(l.Default)
+		logger.SetReportCaller(false)
+		logger.SetFormatter(formatter)
+		return
+	}
+	maxLevel := l.Default
+	for _, level := range l.Modules {
+		maxLevel = max(maxLevel, level)
+	}
+	logger.SetLevel(maxLevel)
+	logger.SetReportCaller(true)
+	logger.SetFormatter(&moduleFormatter{Formatter: formatter, levels: l})
+}
+
+// levelOf returns the level of the module of the given function, or of its closest parent module.
+func (l Levels) levelOf(function string) log.Level {
+	module := moduleOf(function)
+	for module != "" {
+		if level, ok := l.Modules[module]; ok {
+			return level
+		}
+		i := strings.LastIndex(module, ".")
+		if i < 0 {
+			break
+		}
+		module = module[:i]
+	}
+	return l.Default
+}
+
+// moduleOf returns the module of the fully qualified name of a function of ExternalDNS, such as provider.aws
+// for sigs.k8s.io/external-dns/provider/aws.(*AWSProvider).Records, or an empty string for other functions.
+func moduleOf(function string) string {
+	path, ok := strings.CutPrefix(function, modulePrefix)
+	if !ok {
+		return ""
+	}
+	pkg := path
+	if i := strings.Index(path[strings.LastIndex(path, "/")+1:], "."); i >= 0 {
+		pkg = path[:strings.LastIndex(path, "/")+1+i]
+	}
+	return strings.ReplaceAll(pkg, "/", ".")
+}
+
+// moduleFormatter drops the entries above the level of the module they were logged from, and formats the
+// others without the caller.
+type moduleFormatter struct {
+	log.Formatter
+	levels Levels
+}
+
+func (f *moduleFormatter) Format(entry *log.Entry) ([]byte, error) {
+	level := f.levels.Default
+	if entry.Caller != nil {
+		level = f.levels.levelOf(entry.Caller.Function)
+	}
+	if entry.Level > level {
+		return nil, nil
+	}
+	formatted := *entry
+	formatted.Caller = nil
+	return f.Formatter.Format(&formatted)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevels(t *testing.T) {
+	for _, tc := range []struct {
+		spec     string
+		expected Levels
+		err      bool
+	}{
+		{spec: "", expected: Levels{Default: log.InfoLevel}},
+		{spec: "debug", expected: Levels{Default: log.DebugLevel}},
+		{spec: "info,source=debug, provider.aws = trace", expected: Levels{
+			Default: log.InfoLevel,
+			Modules: map[string]log.Level{"source": log.DebugLevel, "provider.aws": log.TraceLevel},
+		}},
+		{spec: "source=debug", expected: Levels{Default: log.InfoLevel, Modules: map[string]log.Level{"source": log.DebugLevel}}},
+		{spec: "invalid", err: true},
+		{spec: "info,source=invalid", err: true},
+		{spec: "source=debug,info", err: true},
+		{spec: "info,=debug", err: true},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			levels, err := ParseLevels(tc.spec)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, levels)
+		})
+	}
+}
+
+func TestModuleOf(t *testing.T) {
+	assert.Equal(t, "provider.aws", moduleOf("sigs.k8s.io/external-dns/provider/aws.(*AWSProvider).Records"))
+	assert.Equal(t, "controller", moduleOf("sigs.k8s.io/external-dns/controller.(*Controller).RunOnce.func1"))
+	assert.Equal(t, "source.wrappers", moduleOf("sigs.k8s.io/external-dns/source/wrappers.NewDedupSource"))
+	assert.Empty(t, moduleOf("k8s.io/client-go/tools/cache.(*Reflector).Run"))
+}
+
+func TestLevelOf(t *testing.T) {
+	levels := Levels{
+		Default: log.InfoLevel,
+		Modules: map[string]log.Level{"provider": log.WarnLevel, "provider.aws": log.TraceLevel},
+	}
+	assert.Equal(t, log.TraceLevel, levels.levelOf("sigs.k8s.io/external-dns/provider/aws.(*AWSProvider).Records"))
+	assert.Equal(t, log.WarnLevel, levels.levelOf("sigs.k8s.io/external-dns/provider/google.(*GoogleProvider).Records"))
+	assert.Equal(t, log.WarnLevel, levels.levelOf("sigs.k8s.io/external-dns/provider.NewInstrumentedProvider"))
+	assert.Equal(t, log.InfoLevel, levels.levelOf("sigs.k8s.io/external-dns/source.NewServiceSource"))
+}
+
+func TestConfigure(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&log.TextFormatter{DisableTimestamp: true})
+
+	levels, err := ParseLevels("warn,internal.logging=debug")
+	require.NoError(t, err)
+	levels.Configure(logger)
+	assert.Equal(t, log.DebugLevel, logger.GetLevel())
+
+	logger.Debug("shown")
+	logger.Trace("hidden")
+	assert.Equal(t, "level=debug msg=shown\n", buf.String())
+
+	buf.Reset()
+	levels, err = ParseLevels("warn,source=debug")
+	require.NoError(t, err)
+	logger = log.New()
+	logger.SetOutput(&buf)
+	levels.Configure(logger)
+	logger.Info("hidden")
+	assert.Empty(t, buf.String())
+
+	logger = log.New()
+	Levels{Default: log.ErrorLevel}.Configure(logger)
+	assert.Equal(t, log.ErrorLevel, logger.GetLevel())
+	assert.False(t, logger.ReportCaller)
+	assert.IsType(t, &log.TextFormatter{}, logger.Formatter)
+}
+
+func TestConfigureTwice(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&log.TextFormatter{DisableTimestamp: true})
+
+	levels, err := ParseLevels("info,provider=debug")
+	require.NoError(t, err)
+	levels.Configure(logger)
+	levels, err = ParseLevels("info,source=debug")
+	require.NoError(t, err)
+	levels.Configure(logger)
+	formatter, ok := logger.Formatter.(*moduleFormatter)
+	require.True(t, ok)
+	assert.IsType(t, &log.TextFormatter{}, formatter.Formatter, "the module formatter should not be wrapped again")
+
+	levels, err = ParseLevels("debug")
+	require.NoError(t, err)
+	levels.Configure(logger)
+	assert.Equal(t, log.DebugLevel, logger.GetLevel())
+	assert.False(t, logger.ReportCaller)
+	assert.IsType(t, &log.TextFormatter{}, logger.Formatter)
+
+	logger.Debug("shown")
+	assert.Equal(t, "level=debug msg=shown\n", buf.String())
+}
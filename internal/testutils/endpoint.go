@@ -18,6 +18,7 @@ package testutils
 
 import (
 	"fmt"
+	"maps"
 	"math/rand"
 	"net/netip"
 	"reflect"
@@ -67,7 +68,7 @@ func SameEndpoint(a, b *endpoint.Endpoint) bool {
 		a.Labels[endpoint.OwnerLabelKey] == b.Labels[endpoint.OwnerLabelKey] && a.RecordTTL == b.RecordTTL &&
 		a.Labels[endpoint.ResourceLabelKey] == b.Labels[endpoint.ResourceLabelKey] &&
 		a.Labels[endpoint.OwnedRecordLabelKey] == b.Labels[endpoint.OwnedRecordLabelKey] &&
-		SameProviderSpecific(a.ProviderSpecific, b.ProviderSpecific)
+		SameProviderSpecific(a.ProviderSpecific, b.ProviderSpecific) && maps.Equal(a.Weights, b.Weights)
 }
 
 // SameEndpoints compares two slices of endpoints regardless of order
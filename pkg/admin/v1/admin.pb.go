@@ -0,0 +1,1050 @@
+// Copyright 2025 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: admin/v1/admin.proto
+
+// Package externaldns.admin.v1 is the administration API of ExternalDNS, serving the state of the last
+// synchronization and controlling the synchronizations.
+
+package adminv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RecordSet selects the records returned by GetRecords.
+type RecordSet int32
+
+const (
+	// RECORD_SET_UNSPECIFIED returns the actual records.
+	RecordSet_RECORD_SET_UNSPECIFIED RecordSet = 0
+	// RECORD_SET_ACTUAL returns the records of the registry.
+	RecordSet_RECORD_SET_ACTUAL RecordSet = 1
+	// RECORD_SET_DESIRED returns the endpoints computed from the sources.
+	RecordSet_RECORD_SET_DESIRED RecordSet = 2
+)
+
+// Enum value maps for RecordSet.
+var (
+	RecordSet_name = map[int32]string{
+		0: "RECORD_SET_UNSPECIFIED",
+		1: "RECORD_SET_ACTUAL",
+		2: "RECORD_SET_DESIRED",
+	}
+	RecordSet_value = map[string]int32{
+		"RECORD_SET_UNSPECIFIED": 0,
+		"RECORD_SET_ACTUAL":      1,
+		"RECORD_SET_DESIRED":     2,
+	}
+)
+
+func (x RecordSet) Enum() *RecordSet {
+	p := new(RecordSet)
+	*p = x
+	return p
+}
+
+func (x RecordSet) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecordSet) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_v1_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (RecordSet) Type() protoreflect.EnumType {
+	return &file_admin_v1_admin_proto_enumTypes[0]
+}
+
+func (x RecordSet) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecordSet.Descriptor instead.
+func (RecordSet) EnumDescriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// ProviderSpecificProperty is a setting of a record specific to the provider.
+type ProviderSpecificProperty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderSpecificProperty) Reset() {
+	*x = ProviderSpecificProperty{}
+	mi := &file_admin_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderSpecificProperty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderSpecificProperty) ProtoMessage() {}
+
+func (x *ProviderSpecificProperty) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderSpecificProperty.ProtoReflect.Descriptor instead.
+func (*ProviderSpecificProperty) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProviderSpecificProperty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProviderSpecificProperty) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Endpoint is a DNS record.
+type Endpoint struct {
+	state            protoimpl.MessageState      `protogen:"open.v1"`
+	DnsName          string                      `protobuf:"bytes,1,opt,name=dns_name,json=dnsName,proto3" json:"dns_name,omitempty"`
+	Targets          []string                    `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	RecordType       string                      `protobuf:"bytes,3,opt,name=record_type,json=recordType,proto3" json:"record_type,omitempty"`
+	SetIdentifier    string                      `protobuf:"bytes,4,opt,name=set_identifier,json=setIdentifier,proto3" json:"set_identifier,omitempty"`
+	RecordTtl        int64                       `protobuf:"varint,5,opt,name=record_ttl,json=recordTtl,proto3" json:"record_ttl,omitempty"`
+	Labels           map[string]string           `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ProviderSpecific []*ProviderSpecificProperty `protobuf:"bytes,7,rep,name=provider_specific,json=providerSpecific,proto3" json:"provider_specific,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Endpoint) Reset() {
+	*x = Endpoint{}
+	mi := &file_admin_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Endpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Endpoint) ProtoMessage() {}
+
+func (x *Endpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Endpoint) GetDnsName() string {
+	if x != nil {
+		return x.DnsName
+	}
+	return ""
+}
+
+func (x *Endpoint) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Endpoint) GetRecordType() string {
+	if x != nil {
+		return x.RecordType
+	}
+	return ""
+}
+
+func (x *Endpoint) GetSetIdentifier() string {
+	if x != nil {
+		return x.SetIdentifier
+	}
+	return ""
+}
+
+func (x *Endpoint) GetRecordTtl() int64 {
+	if x != nil {
+		return x.RecordTtl
+	}
+	return 0
+}
+
+func (x *Endpoint) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Endpoint) GetProviderSpecific() []*ProviderSpecificProperty {
+	if x != nil {
+		return x.ProviderSpecific
+	}
+	return nil
+}
+
+// Changes are the records to create, update and delete.
+type Changes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Create        []*Endpoint            `protobuf:"bytes,1,rep,name=create,proto3" json:"create,omitempty"`
+	UpdateOld     []*Endpoint            `protobuf:"bytes,2,rep,name=update_old,json=updateOld,proto3" json:"update_old,omitempty"`
+	UpdateNew     []*Endpoint            `protobuf:"bytes,3,rep,name=update_new,json=updateNew,proto3" json:"update_new,omitempty"`
+	Delete        []*Endpoint            `protobuf:"bytes,4,rep,name=delete,proto3" json:"delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Changes) Reset() {
+	*x = Changes{}
+	mi := &file_admin_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Changes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Changes) ProtoMessage() {}
+
+func (x *Changes) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Changes.ProtoReflect.Descriptor instead.
+func (*Changes) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Changes) GetCreate() []*Endpoint {
+	if x != nil {
+		return x.Create
+	}
+	return nil
+}
+
+func (x *Changes) GetUpdateOld() []*Endpoint {
+	if x != nil {
+		return x.UpdateOld
+	}
+	return nil
+}
+
+func (x *Changes) GetUpdateNew() []*Endpoint {
+	if x != nil {
+		return x.UpdateNew
+	}
+	return nil
+}
+
+func (x *Changes) GetDelete() []*Endpoint {
+	if x != nil {
+		return x.Delete
+	}
+	return nil
+}
+
+// Skipped is a desired endpoint or a change left out of the plan.
+type Skipped struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint *Endpoint              `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Reason   string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// action is the change which was dropped, empty for desired endpoints skipped before calculating the changes.
+	Action        string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Skipped) Reset() {
+	*x = Skipped{}
+	mi := &file_admin_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Skipped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skipped) ProtoMessage() {}
+
+func (x *Skipped) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skipped.ProtoReflect.Descriptor instead.
+func (*Skipped) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Skipped) GetEndpoint() *Endpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+func (x *Skipped) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Skipped) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Skipped) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Annotation is a message a policy rule attached to a change, which is applied nonetheless.
+type Annotation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      *Endpoint              `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Annotation) Reset() {
+	*x = Annotation{}
+	mi := &file_admin_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Annotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Annotation) ProtoMessage() {}
+
+func (x *Annotation) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Annotation.ProtoReflect.Descriptor instead.
+func (*Annotation) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Annotation) GetEndpoint() *Endpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+func (x *Annotation) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Annotation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetPlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlanRequest) Reset() {
+	*x = GetPlanRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanRequest) ProtoMessage() {}
+
+func (x *GetPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanRequest.ProtoReflect.Descriptor instead.
+func (*GetPlanRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+type GetPlanResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Changes     *Changes               `protobuf:"bytes,2,opt,name=changes,proto3" json:"changes,omitempty"`
+	Skipped     []*Skipped             `protobuf:"bytes,3,rep,name=skipped,proto3" json:"skipped,omitempty"`
+	Annotations []*Annotation          `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	// paused is set when the changes are not applied because synchronization is paused.
+	Paused        bool `protobuf:"varint,5,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlanResponse) Reset() {
+	*x = GetPlanResponse{}
+	mi := &file_admin_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanResponse) ProtoMessage() {}
+
+func (x *GetPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanResponse.ProtoReflect.Descriptor instead.
+func (*GetPlanResponse) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPlanResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *GetPlanResponse) GetChanges() *Changes {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetPlanResponse) GetSkipped() []*Skipped {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+func (x *GetPlanResponse) GetAnnotations() []*Annotation {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *GetPlanResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type GetRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordSet     RecordSet              `protobuf:"varint,1,opt,name=record_set,json=recordSet,proto3,enum=externaldns.admin.v1.RecordSet" json:"record_set,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecordsRequest) Reset() {
+	*x = GetRecordsRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordsRequest) ProtoMessage() {}
+
+func (x *GetRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRecordsRequest) GetRecordSet() RecordSet {
+	if x != nil {
+		return x.RecordSet
+	}
+	return RecordSet_RECORD_SET_UNSPECIFIED
+}
+
+type GetRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Endpoints     []*Endpoint            `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecordsResponse) Reset() {
+	*x = GetRecordsResponse{}
+	mi := &file_admin_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordsResponse) ProtoMessage() {}
+
+func (x *GetRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordsResponse.ProtoReflect.Descriptor instead.
+func (*GetRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRecordsResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *GetRecordsResponse) GetEndpoints() []*Endpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+type TriggerSyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerSyncRequest) Reset() {
+	*x = TriggerSyncRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSyncRequest) ProtoMessage() {}
+
+func (x *TriggerSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSyncRequest.ProtoReflect.Descriptor instead.
+func (*TriggerSyncRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+type TriggerSyncResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// scheduled_at is the time the synchronization is scheduled at.
+	ScheduledAt   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerSyncResponse) Reset() {
+	*x = TriggerSyncResponse{}
+	mi := &file_admin_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSyncResponse) ProtoMessage() {}
+
+func (x *TriggerSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSyncResponse.ProtoReflect.Descriptor instead.
+func (*TriggerSyncResponse) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TriggerSyncResponse) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+type PauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseRequest) Reset() {
+	*x = PauseRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRequest) ProtoMessage() {}
+
+func (x *PauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRequest.ProtoReflect.Descriptor instead.
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+type ResumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+type GetPauseStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPauseStateRequest) Reset() {
+	*x = GetPauseStateRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPauseStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPauseStateRequest) ProtoMessage() {}
+
+func (x *GetPauseStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPauseStateRequest.ProtoReflect.Descriptor instead.
+func (*GetPauseStateRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{13}
+}
+
+type PauseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paused        bool                   `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseResponse) Reset() {
+	*x = PauseResponse{}
+	mi := &file_admin_v1_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseResponse) ProtoMessage() {}
+
+func (x *PauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseResponse.ProtoReflect.Descriptor instead.
+func (*PauseResponse) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PauseResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+var File_admin_v1_admin_proto protoreflect.FileDescriptor
+
+const file_admin_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x14admin/v1/admin.proto\x12\x14externaldns.admin.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"D\n" +
+	"\x18ProviderSpecificProperty\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x82\x03\n" +
+	"\bEndpoint\x12\x19\n" +
+	"\bdns_name\x18\x01 \x01(\tR\adnsName\x12\x18\n" +
+	"\atargets\x18\x02 \x03(\tR\atargets\x12\x1f\n" +
+	"\vrecord_type\x18\x03 \x01(\tR\n" +
+	"recordType\x12%\n" +
+	"\x0eset_identifier\x18\x04 \x01(\tR\rsetIdentifier\x12\x1d\n" +
+	"\n" +
+	"record_ttl\x18\x05 \x01(\x03R\trecordTtl\x12B\n" +
+	"\x06labels\x18\x06 \x03(\v2*.externaldns.admin.v1.Endpoint.LabelsEntryR\x06labels\x12[\n" +
+	"\x11provider_specific\x18\a \x03(\v2..externaldns.admin.v1.ProviderSpecificPropertyR\x10providerSpecific\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf7\x01\n" +
+	"\aChanges\x126\n" +
+	"\x06create\x18\x01 \x03(\v2\x1e.externaldns.admin.v1.EndpointR\x06create\x12=\n" +
+	"\n" +
+	"update_old\x18\x02 \x03(\v2\x1e.externaldns.admin.v1.EndpointR\tupdateOld\x12=\n" +
+	"\n" +
+	"update_new\x18\x03 \x03(\v2\x1e.externaldns.admin.v1.EndpointR\tupdateNew\x126\n" +
+	"\x06delete\x18\x04 \x03(\v2\x1e.externaldns.admin.v1.EndpointR\x06delete\"\x8f\x01\n" +
+	"\aSkipped\x12:\n" +
+	"\bendpoint\x18\x01 \x01(\v2\x1e.externaldns.admin.v1.EndpointR\bendpoint\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"z\n" +
+	"\n" +
+	"Annotation\x12:\n" +
+	"\bendpoint\x18\x01 \x01(\v2\x1e.externaldns.admin.v1.EndpointR\bendpoint\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x10\n" +
+	"\x0eGetPlanRequest\"\x9a\x02\n" +
+	"\x0fGetPlanResponse\x129\n" +
+	"\n" +
+	"updated_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
+	"\achanges\x18\x02 \x01(\v2\x1d.externaldns.admin.v1.ChangesR\achanges\x127\n" +
+	"\askipped\x18\x03 \x03(\v2\x1d.externaldns.admin.v1.SkippedR\askipped\x12B\n" +
+	"\vannotations\x18\x04 \x03(\v2 .externaldns.admin.v1.AnnotationR\vannotations\x12\x16\n" +
+	"\x06paused\x18\x05 \x01(\bR\x06paused\"S\n" +
+	"\x11GetRecordsRequest\x12>\n" +
+	"\n" +
+	"record_set\x18\x01 \x01(\x0e2\x1f.externaldns.admin.v1.RecordSetR\trecordSet\"\x8d\x01\n" +
+	"\x12GetRecordsResponse\x129\n" +
+	"\n" +
+	"updated_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12<\n" +
+	"\tendpoints\x18\x02 \x03(\v2\x1e.externaldns.admin.v1.EndpointR\tendpoints\"\x14\n" +
+	"\x12TriggerSyncRequest\"T\n" +
+	"\x13TriggerSyncResponse\x12=\n" +
+	"\fscheduled_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\"\x0e\n" +
+	"\fPauseRequest\"\x0f\n" +
+	"\rResumeRequest\"\x16\n" +
+	"\x14GetPauseStateRequest\"'\n" +
+	"\rPauseResponse\x12\x16\n" +
+	"\x06paused\x18\x01 \x01(\bR\x06paused*V\n" +
+	"\tRecordSet\x12\x1a\n" +
+	"\x16RECORD_SET_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11RECORD_SET_ACTUAL\x10\x01\x12\x16\n" +
+	"\x12RECORD_SET_DESIRED\x10\x022\xb3\x04\n" +
+	"\fAdminService\x12V\n" +
+	"\aGetPlan\x12$.externaldns.admin.v1.GetPlanRequest\x1a%.externaldns.admin.v1.GetPlanResponse\x12_\n" +
+	"\n" +
+	"GetRecords\x12'.externaldns.admin.v1.GetRecordsRequest\x1a(.externaldns.admin.v1.GetRecordsResponse\x12b\n" +
+	"\vTriggerSync\x12(.externaldns.admin.v1.TriggerSyncRequest\x1a).externaldns.admin.v1.TriggerSyncResponse\x12P\n" +
+	"\x05Pause\x12\".externaldns.admin.v1.PauseRequest\x1a#.externaldns.admin.v1.PauseResponse\x12R\n" +
+	"\x06Resume\x12#.externaldns.admin.v1.ResumeRequest\x1a#.externaldns.admin.v1.PauseResponse\x12`\n" +
+	"\rGetPauseState\x12*.externaldns.admin.v1.GetPauseStateRequest\x1a#.externaldns.admin.v1.PauseResponseB/Z-sigs.k8s.io/external-dns/pkg/admin/v1;adminv1b\x06proto3"
+
+var (
+	file_admin_v1_admin_proto_rawDescOnce sync.Once
+	file_admin_v1_admin_proto_rawDescData []byte
+)
+
+func file_admin_v1_admin_proto_rawDescGZIP() []byte {
+	file_admin_v1_admin_proto_rawDescOnce.Do(func() {
+		file_admin_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_v1_admin_proto_rawDesc), len(file_admin_v1_admin_proto_rawDesc)))
+	})
+	return file_admin_v1_admin_proto_rawDescData
+}
+
+var file_admin_v1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_admin_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_admin_v1_admin_proto_goTypes = []any{
+	(RecordSet)(0),                   // 0: externaldns.admin.v1.RecordSet
+	(*ProviderSpecificProperty)(nil), // 1: externaldns.admin.v1.ProviderSpecificProperty
+	(*Endpoint)(nil),                 // 2: externaldns.admin.v1.Endpoint
+	(*Changes)(nil),                  // 3: externaldns.admin.v1.Changes
+	(*Skipped)(nil),                  // 4: externaldns.admin.v1.Skipped
+	(*Annotation)(nil),               // 5: externaldns.admin.v1.Annotation
+	(*GetPlanRequest)(nil),           // 6: externaldns.admin.v1.GetPlanRequest
+	(*GetPlanResponse)(nil),          // 7: externaldns.admin.v1.GetPlanResponse
+	(*GetRecordsRequest)(nil),        // 8: externaldns.admin.v1.GetRecordsRequest
+	(*GetRecordsResponse)(nil),       // 9: externaldns.admin.v1.GetRecordsResponse
+	(*TriggerSyncRequest)(nil),       // 10: externaldns.admin.v1.TriggerSyncRequest
+	(*TriggerSyncResponse)(nil),      // 11: externaldns.admin.v1.TriggerSyncResponse
+	(*PauseRequest)(nil),             // 12: externaldns.admin.v1.PauseRequest
+	(*ResumeRequest)(nil),            // 13: externaldns.admin.v1.ResumeRequest
+	(*GetPauseStateRequest)(nil),     // 14: externaldns.admin.v1.GetPauseStateRequest
+	(*PauseResponse)(nil),            // 15: externaldns.admin.v1.PauseResponse
+	nil,                              // 16: externaldns.admin.v1.Endpoint.LabelsEntry
+	(*timestamppb.Timestamp)(nil),    // 17: google.protobuf.Timestamp
+}
+var file_admin_v1_admin_proto_depIdxs = []int32{
+	16, // 0: externaldns.admin.v1.Endpoint.labels:type_name -> externaldns.admin.v1.Endpoint.LabelsEntry
+	1,  // 1: externaldns.admin.v1.Endpoint.provider_specific:type_name -> externaldns.admin.v1.ProviderSpecificProperty
+	2,  // 2: externaldns.admin.v1.Changes.create:type_name -> externaldns.admin.v1.Endpoint
+	2,  // 3: externaldns.admin.v1.Changes.update_old:type_name -> externaldns.admin.v1.Endpoint
+	2,  // 4: externaldns.admin.v1.Changes.update_new:type_name -> externaldns.admin.v1.Endpoint
+	2,  // 5: externaldns.admin.v1.Changes.delete:type_name -> externaldns.admin.v1.Endpoint
+	2,  // 6: externaldns.admin.v1.Skipped.endpoint:type_name -> externaldns.admin.v1.Endpoint
+	2,  // 7: externaldns.admin.v1.Annotation.endpoint:type_name -> externaldns.admin.v1.Endpoint
+	17, // 8: externaldns.admin.v1.GetPlanResponse.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 9: externaldns.admin.v1.GetPlanResponse.changes:type_name -> externaldns.admin.v1.Changes
+	4,  // 10: externaldns.admin.v1.GetPlanResponse.skipped:type_name -> externaldns.admin.v1.Skipped
+	5,  // 11: externaldns.admin.v1.GetPlanResponse.annotations:type_name -> externaldns.admin.v1.Annotation
+	0,  // 12: externaldns.admin.v1.GetRecordsRequest.record_set:type_name -> externaldns.admin.v1.RecordSet
+	17, // 13: externaldns.admin.v1.GetRecordsResponse.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 14: externaldns.admin.v1.GetRecordsResponse.endpoints:type_name -> externaldns.admin.v1.Endpoint
+	17, // 15: externaldns.admin.v1.TriggerSyncResponse.scheduled_at:type_name -> google.protobuf.Timestamp
+	6,  // 16: externaldns.admin.v1.AdminService.GetPlan:input_type -> externaldns.admin.v1.GetPlanRequest
+	8,  // 17: externaldns.admin.v1.AdminService.GetRecords:input_type -> externaldns.admin.v1.GetRecordsRequest
+	10, // 18: externaldns.admin.v1.AdminService.TriggerSync:input_type -> externaldns.admin.v1.TriggerSyncRequest
+	12, // 19: externaldns.admin.v1.AdminService.Pause:input_type -> externaldns.admin.v1.PauseRequest
+	13, // 20: externaldns.admin.v1.AdminService.Resume:input_type -> externaldns.admin.v1.ResumeRequest
+	14, // 21: externaldns.admin.v1.AdminService.GetPauseState:input_type -> externaldns.admin.v1.GetPauseStateRequest
+	7,  // 22: externaldns.admin.v1.AdminService.GetPlan:output_type -> externaldns.admin.v1.GetPlanResponse
+	9,  // 23: externaldns.admin.v1.AdminService.GetRecords:output_type -> externaldns.admin.v1.GetRecordsResponse
+	11, // 24: externaldns.admin.v1.AdminService.TriggerSync:output_type -> externaldns.admin.v1.TriggerSyncResponse
+	15, // 25: externaldns.admin.v1.AdminService.Pause:output_type -> externaldns.admin.v1.PauseResponse
+	15, // 26: externaldns.admin.v1.AdminService.Resume:output_type -> externaldns.admin.v1.PauseResponse
+	15, // 27: externaldns.admin.v1.AdminService.GetPauseState:output_type -> externaldns.admin.v1.PauseResponse
+	22, // [22:28] is the sub-list for method output_type
+	16, // [16:22] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_admin_v1_admin_proto_init() }
+func file_admin_v1_admin_proto_init() {
+	if File_admin_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_v1_admin_proto_rawDesc), len(file_admin_v1_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_v1_admin_proto_goTypes,
+		DependencyIndexes: file_admin_v1_admin_proto_depIdxs,
+		EnumInfos:         file_admin_v1_admin_proto_enumTypes,
+		MessageInfos:      file_admin_v1_admin_proto_msgTypes,
+	}.Build()
+	File_admin_v1_admin_proto = out.File
+	file_admin_v1_admin_proto_goTypes = nil
+	file_admin_v1_admin_proto_depIdxs = nil
+}
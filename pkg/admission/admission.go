@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// maxRequestSize is the maximum size of the AdmissionReview requests read by the webhook.
+const maxRequestSize = 3 << 20
+
+// Validator validates the external-dns annotations of any resource, and the spec of DNSEndpoint
+// resources, so that mistakes are reported when the resource is applied instead of only being logged
+// by the controller.
+type Validator struct {
+	domainFilter endpoint.DomainFilterInterface
+}
+
+// NewValidator returns a Validator which only validates the resources with hostnames matching the domain filter,
+// and warns about hostnames not matching it, as they may be managed by another instance of external-dns.
+func NewValidator(domainFilter endpoint.DomainFilterInterface) *Validator {
+	return &Validator{domainFilter: domainFilter}
+}
+
+// object holds the fields of a resource the Validator looks at.
+type object struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              json.RawMessage `json:"spec,omitempty"`
+}
+
+// Validate validates the given resource, encoded as JSON. It returns warnings about the hostnames not matching
+// the domain filter, which aren't validated. The annotations are only validated when the resource has no hostname
+// annotation or at least one of them matches the domain filter.
+func (v *Validator) Validate(raw []byte) ([]string, error) {
+	var obj object
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, fmt.Errorf("failed to decode object: %w", err)
+	}
+
+	var warnings []string
+	hostnames, managed := 0, 0
+	for _, key := range []string{annotations.HostnameKey, annotations.InternalHostnameKey} {
+		if _, ok := obj.Annotations[key]; !ok {
+			continue
+		}
+		for _, hostname := range annotations.SplitHostnameAnnotation(obj.Annotations[key]) {
+			hostnames++
+			if v.matches(hostname) {
+				managed++
+			} else {
+				warnings = append(warnings, unmanagedWarning(key, hostname))
+			}
+		}
+	}
+	var errs []error
+	if hostnames == 0 || managed > 0 {
+		errs = append(errs, annotations.Validate(obj.Annotations))
+	}
+	if obj.GroupVersionKind() == apiv1alpha1.GroupVersion.WithKind("DNSEndpoint") && len(obj.Spec) > 0 {
+		var spec apiv1alpha1.DNSEndpointSpec
+		if err := json.Unmarshal(obj.Spec, &spec); err != nil {
+			return nil, fmt.Errorf("failed to decode spec: %w", err)
+		}
+		for i, ep := range spec.Endpoints {
+			field := fmt.Sprintf("spec.endpoints[%d]", i)
+			if ep != nil && !v.matches(ep.DNSName) {
+				warnings = append(warnings, unmanagedWarning(field+".dnsName", ep.DNSName))
+				continue
+			}
+			errs = append(errs, v.validateEndpoint(field, ep))
+		}
+	}
+	return warnings, errors.Join(errs...)
+}
+
+// matches returns whether the hostname matches the domain filter, so that this instance would manage it.
+func (v *Validator) matches(hostname string) bool {
+	name := strings.TrimPrefix(strings.TrimSuffix(hostname, "."), "*.")
+	return v.domainFilter == nil || v.domainFilter.Match(name)
+}
+
+func unmanagedWarning(field, hostname string) string {
+	return fmt.Sprintf("%s: hostname %q is not in a domain managed by this instance of external-dns, so it isn't validated", field, hostname)
+}
+
+func (v *Validator) validateEndpoint(field string, ep *endpoint.Endpoint) error {
+	if ep == nil {
+		return fmt.Errorf("%s: must not be null", field)
+	}
+	if err := annotations.ValidateHostname(ep.DNSName); err != nil {
+		return fmt.Errorf("%s.dnsName: %w", field, err)
+	}
+	if ep.RecordTTL < 0 {
+		return fmt.Errorf("%s.recordTTL: must not be negative", field)
+	}
+	if len(ep.Targets) == 0 {
+		return fmt.Errorf("%s.targets: must not be empty", field)
+	}
+	if !ep.CheckEndpoint() {
+		return fmt.Errorf("%s.targets: invalid %s targets %v", field, ep.RecordType, ep.Targets)
+	}
+	for _, target := range ep.Targets {
+		var err error
+		switch ep.RecordType {
+		case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+			addr, parseErr := netip.ParseAddr(target)
+			switch {
+			case parseErr != nil:
+				err = parseErr
+			case ep.RecordType == endpoint.RecordTypeA && !addr.Is4():
+				err = fmt.Errorf("%q is not an IPv4 address", target)
+			case ep.RecordType == endpoint.RecordTypeAAAA && (!addr.Is6() || addr.Is4In6()):
+				err = fmt.Errorf("%q is not an IPv6 address", target)
+			}
+		case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS:
+			err = annotations.ValidateHostname(target)
+		}
+		if err != nil {
+			return fmt.Errorf("%s.targets: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// ServeHTTP handles AdmissionReview requests of a ValidatingWebhookConfiguration.
+func (v *Validator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review has no request", http.StatusBadRequest)
+		return
+	}
+
+	response := &admissionv1.AdmissionResponse{UID: review.Request.UID, Allowed: true}
+	if review.Request.Operation != admissionv1.Delete {
+		warnings, err := v.Validate(review.Request.Object.Raw)
+		response.Warnings = warnings
+		if err != nil {
+			log.Infof("Rejected %s %s/%s: %v", review.Request.Kind.Kind, review.Request.Namespace, review.Request.Name, err)
+			response.Allowed = false
+			response.Result = &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Reason:  metav1.StatusReasonInvalid,
+				Code:    http.StatusUnprocessableEntity,
+				Message: err.Error(),
+			}
+		}
+	}
+
+	review.Request = nil
+	review.Response = response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Errorf("Failed to encode admission review: %v", err)
+	}
+}
+
+// ListenAndServeTLS serves the Validator on the given address until the context is cancelled.
+func ListenAndServeTLS(ctx context.Context, address, certFile, keyFile string, v *Validator) error {
+	mux := http.NewServeMux()
+	mux.Handle("/validate", v)
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Errorf("Failed to shut down admission webhook: %v", err)
+		}
+	}()
+	log.Infof("Serving admission webhook on %s", address)
+	if err := server.ListenAndServeTLS(certFile, keyFile); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestValidatorValidate(t *testing.T) {
+	v := NewValidator(endpoint.NewDomainFilter([]string{"example.com"}))
+
+	tests := []struct {
+		name          string
+		object        string
+		expectErr     string
+		expectWarning string
+	}{
+		{
+			name:   "service without annotations",
+			object: `{"apiVersion":"v1","kind":"Service","metadata":{"name":"foo"}}`,
+		},
+		{
+			name:   "valid annotations",
+			object: `{"apiVersion":"v1","kind":"Service","metadata":{"name":"foo","annotations":{"external-dns.alpha.kubernetes.io/hostname":"*.foo.example.com","external-dns.alpha.kubernetes.io/ttl":"60"}}}`,
+		},
+		{
+			name:      "bad ttl",
+			object:    `{"apiVersion":"v1","kind":"Service","metadata":{"name":"foo","annotations":{"external-dns.alpha.kubernetes.io/ttl":"-1"}}}`,
+			expectErr: "must be between",
+		},
+		{
+			name:          "hostname outside the domain filter",
+			object:        `{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"foo","annotations":{"external-dns.alpha.kubernetes.io/hostname":"foo.example.org","external-dns.alpha.kubernetes.io/ttl":"-1"}}}`,
+			expectWarning: `hostname "foo.example.org" is not in a domain managed by this instance of external-dns`,
+		},
+		{
+			name:          "hostnames partly outside the domain filter",
+			object:        `{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"foo","annotations":{"external-dns.alpha.kubernetes.io/hostname":"foo.example.org,foo.example.com","external-dns.alpha.kubernetes.io/ttl":"-1"}}}`,
+			expectErr:     "must be between",
+			expectWarning: `hostname "foo.example.org" is not in a domain managed by this instance of external-dns`,
+		},
+		{
+			name:   "valid dns endpoint",
+			object: `{"apiVersion":"externaldns.k8s.io/v1alpha1","kind":"DNSEndpoint","metadata":{"name":"foo"},"spec":{"endpoints":[{"dnsName":"foo.example.com","recordType":"A","targets":["192.0.2.1"]},{"dnsName":"bar.example.com","recordType":"TXT","targets":["some text"]}]}}`,
+		},
+		{
+			name:      "dns endpoint with invalid target",
+			object:    `{"apiVersion":"externaldns.k8s.io/v1alpha1","kind":"DNSEndpoint","metadata":{"name":"foo"},"spec":{"endpoints":[{"dnsName":"foo.example.com","recordType":"AAAA","targets":["192.0.2.1"]}]}}`,
+			expectErr: `spec.endpoints[0].targets: "192.0.2.1" is not an IPv6 address`,
+		},
+		{
+			name:          "dns endpoint outside the domain filter",
+			object:        `{"apiVersion":"externaldns.k8s.io/v1alpha1","kind":"DNSEndpoint","metadata":{"name":"foo"},"spec":{"endpoints":[{"dnsName":"foo.example.org","recordType":"AAAA","targets":["192.0.2.1"]},{"dnsName":"foo.example.com","recordType":"CNAME","targets":["foo.example.org"]}]}}`,
+			expectWarning: `spec.endpoints[0].dnsName: hostname "foo.example.org" is not in a domain managed by this instance of external-dns`,
+		},
+		{
+			name:      "dns endpoint with negative ttl",
+			object:    `{"apiVersion":"externaldns.k8s.io/v1alpha1","kind":"DNSEndpoint","metadata":{"name":"foo"},"spec":{"endpoints":[{"dnsName":"foo.example.com","recordType":"A","recordTTL":-5,"targets":["192.0.2.1"]}]}}`,
+			expectErr: "spec.endpoints[0].recordTTL: must not be negative",
+		},
+		{
+			name:      "dns endpoint without targets",
+			object:    `{"apiVersion":"externaldns.k8s.io/v1alpha1","kind":"DNSEndpoint","metadata":{"name":"foo"},"spec":{"endpoints":[{"dnsName":"foo.example.com","recordType":"A"}]}}`,
+			expectErr: "spec.endpoints[0].targets: must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := v.Validate([]byte(tt.object))
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectErr)
+			}
+			if tt.expectWarning == "" {
+				assert.Empty(t, warnings)
+			} else {
+				require.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], tt.expectWarning)
+			}
+		})
+	}
+}
+
+func TestValidatorServeHTTP(t *testing.T) {
+	v := NewValidator(endpoint.NewDomainFilter([]string{"example.com"}))
+	invalid := []byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"foo","annotations":{"external-dns.alpha.kubernetes.io/ttl":"soon"}}}`)
+	unmanaged := []byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"foo","annotations":{"external-dns.alpha.kubernetes.io/hostname":"foo.example.org","external-dns.alpha.kubernetes.io/ttl":"soon"}}}`)
+
+	review := func(t *testing.T, operation admissionv1.Operation, object []byte) *admissionv1.AdmissionResponse {
+		body, err := json.Marshal(admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: admissionv1.SchemeGroupVersion.String(), Kind: "AdmissionReview"},
+			Request: &admissionv1.AdmissionRequest{
+				UID:       types.UID("uid"),
+				Operation: operation,
+				Object:    runtime.RawExtension{Raw: object},
+			},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		v.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp admissionv1.AdmissionReview
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Response)
+		assert.Equal(t, types.UID("uid"), resp.Response.UID)
+		return resp.Response
+	}
+
+	resp := review(t, admissionv1.Create, invalid)
+	assert.False(t, resp.Allowed)
+	assert.Contains(t, resp.Result.Message, `"soon" is not a valid TTL value`)
+
+	resp = review(t, admissionv1.Create, unmanaged)
+	assert.True(t, resp.Allowed)
+	require.Len(t, resp.Warnings, 1)
+	assert.Contains(t, resp.Warnings[0], `hostname "foo.example.org" is not in a domain managed by this instance of external-dns`)
+
+	assert.True(t, review(t, admissionv1.Delete, invalid).Allowed)
+
+	rec := httptest.NewRecorder()
+	v.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader([]byte("{"))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/goccy/go-yaml"
+)
+
+const configFlag = "config"
+
+// configFileArgs returns the flags set by the configuration file given with --config, or the environment variable
+// of that flag, as arguments. The file maps flag names to their values, lists give repeatable flags several values and
+// mappings give key=value flags their pairs. Flags given as arguments or environment variables are left out, so
+// that they override the file.
+func configFileArgs(app *kingpin.Application, args []string) ([]string, error) {
+	ctx, err := app.ParseContext(args)
+	if err != nil {
+		// The error is reported again when parsing the arguments.
+		return nil, nil
+	}
+
+	path := ""
+	given := map[string]bool{}
+	for _, element := range ctx.Elements {
+		flag, ok := element.Clause.(*kingpin.FlagClause)
+		if !ok {
+			continue
+		}
+		name := flag.Model().Name
+		given[name] = true
+		if name == configFlag && element.Value != nil {
+			path = *element.Value
+		}
+	}
+	if path == "" {
+		path = app.GetFlag(configFlag).GetEnvarValue()
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+	values := map[string]any{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+
+	var fileArgs []string
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		flag := app.GetFlag(name)
+		if flag == nil || name == configFlag || name == "help" || name == "version" {
+			return nil, fmt.Errorf("unknown flag %q in configuration file %s", name, path)
+		}
+		if given[name] || flag.HasEnvarValue() {
+			continue
+		}
+		flagArgs, err := configFileFlagArgs(name, values[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of flag %q in configuration file %s: %w", name, path, err)
+		}
+		fileArgs = append(fileArgs, flagArgs...)
+	}
+	return fileArgs, nil
+}
+
+// configFileFlagArgs returns the arguments setting the flag with the given name to a value of the configuration file.
+func configFileFlagArgs(name string, value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if v {
+			return []string{"--" + name}, nil
+		}
+		return []string{"--no-" + name}, nil
+	case []any:
+		args := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := configFileScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, "--"+name+"="+s)
+		}
+		return args, nil
+	case map[string]any:
+		args := make([]string, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			s, err := configFileScalar(v[key])
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, "--"+name+"="+key+"="+s)
+		}
+		return args, nil
+	default:
+		s, err := configFileScalar(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{"--" + name + "=" + s}, nil
+	}
+}
+
+func configFileScalar(value any) (string, error) {
+	switch value.(type) {
+	case []any, map[string]any:
+		return "", fmt.Errorf("expected a single value, got %v", value)
+	}
+	return fmt.Sprint(value), nil
+}
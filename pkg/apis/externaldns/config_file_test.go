@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigFile = `
+provider: aws
+source:
+  - service
+  - ingress
+domain-filter:
+  - example.org
+  - example.com
+interval: 5m
+once: true
+exclude-unschedulable: false
+aws-sd-create-tag:
+  team: dns
+txt-owner-id: 42
+`
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestParseFlagsConfigFile(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_CLI", "")
+	path := writeConfigFile(t, testConfigFile)
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--config=" + path}))
+
+	assert.Equal(t, path, cfg.ConfigFile)
+	assert.Equal(t, "aws", cfg.Provider)
+	assert.Equal(t, []string{"service", "ingress"}, cfg.Sources)
+	assert.Equal(t, []string{"example.org", "example.com"}, cfg.DomainFilter)
+	assert.Equal(t, 5*time.Minute, cfg.Interval)
+	assert.True(t, cfg.Once)
+	assert.False(t, cfg.ExcludeUnschedulable)
+	assert.Equal(t, map[string]string{"team": "dns"}, cfg.AWSSDCreateTag)
+	assert.Equal(t, "42", cfg.TXTOwnerID)
+	assert.Equal(t, defaultConfig.Registry, cfg.Registry)
+}
+
+func TestParseFlagsConfigFileOverridden(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_CLI", "")
+	t.Setenv("EXTERNAL_DNS_INTERVAL", "10m")
+	path := writeConfigFile(t, testConfigFile)
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--provider=google", "--domain-filter=example.net", "--config", path, "--exclude-unschedulable"}))
+
+	assert.Equal(t, "google", cfg.Provider)
+	assert.Equal(t, []string{"example.net"}, cfg.DomainFilter)
+	assert.Equal(t, 10*time.Minute, cfg.Interval)
+	assert.True(t, cfg.ExcludeUnschedulable)
+	assert.Equal(t, []string{"service", "ingress"}, cfg.Sources)
+}
+
+func TestParseFlagsConfigFileFromEnv(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_CLI", "")
+	t.Setenv("EXTERNAL_DNS_CONFIG", writeConfigFile(t, testConfigFile))
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{}))
+
+	assert.Equal(t, "aws", cfg.Provider)
+	assert.Equal(t, []string{"service", "ingress"}, cfg.Sources)
+}
+
+func TestParseFlagsConfigFileErrors(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_CLI", "")
+
+	for _, tt := range []struct {
+		title   string
+		content string
+		err     string
+	}{
+		{
+			title:   "unknown flag",
+			content: "provider: aws\nsource: service\nno-such-flag: true\n",
+			err:     `unknown flag "no-such-flag"`,
+		},
+		{
+			title:   "config flag",
+			content: "config: other.yaml\n",
+			err:     `unknown flag "config"`,
+		},
+		{
+			title:   "nested list",
+			content: "provider: aws\nsource:\n  - [service]\n",
+			err:     `invalid value of flag "source"`,
+		},
+		{
+			title:   "invalid value",
+			content: "provider: aws\nsource: service\ninterval: often\n",
+			err:     "invalid duration",
+		},
+		{
+			title:   "invalid yaml",
+			content: "provider: [aws\n",
+			err:     "failed to parse configuration file",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := NewConfig()
+			err := cfg.ParseFlags([]string{"--config=" + writeConfigFile(t, tt.content)})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	cfg := NewConfig()
+	err := cfg.ParseFlags([]string{"--config=" + filepath.Join(t.TempDir(), "missing.yaml")})
+	require.ErrorContains(t, err, "failed to read configuration file")
+}
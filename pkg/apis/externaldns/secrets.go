@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+// secretFileSuffix is the suffix of the flags and environment variables naming the file of a credential.
+const secretFileSuffix = "-file"
+
+// credentialEnvVars are the environment variables of the credentials read by providers, which may also be read
+// from the file named by the same variable with the _FILE suffix.
+var credentialEnvVars = []string{
+	"CF_API_KEY",
+	"CF_API_TOKEN",
+	"CIVO_TOKEN",
+	"DNSIMPLE_OAUTH",
+	"DO_TOKEN",
+	"ETCD_PASSWORD",
+	"GANDI_KEY",
+	"GANDI_PAT",
+	"LINODE_TOKEN",
+	"NS1_APIKEY",
+	"PLURAL_ACCESS_TOKEN",
+}
+
+// restartSecretFlags are the flags of credentials which aren't used by the provider or the registry, so that
+// ExternalDNS has to be restarted to use new ones.
+var restartSecretFlags = []string{"api-token", "api-write-token", "cf-password", "notification-url"}
+
+// secretFlags returns the flags of credentials and the fields they set. Each of them may also be read from a file
+// with the flag of the same name with the -file suffix.
+func (cfg *Config) secretFlags() map[string]*string {
+	return map[string]*string{
+		"akamai-access-token":         &cfg.AkamaiAccessToken,
+		"akamai-client-secret":        &cfg.AkamaiClientSecret,
+		"akamai-client-token":         &cfg.AkamaiClientToken,
+		"api-token":                   &cfg.APIToken,
+		"api-write-token":             &cfg.APIWriteToken,
+		"aws-assume-role-external-id": &cfg.AWSAssumeRoleExternalID,
+		"cf-password":                 &cfg.CFPassword,
+		"exoscale-apikey":             &cfg.ExoscaleAPIKey,
+		"exoscale-apisecret":          &cfg.ExoscaleAPISecret,
+		"godaddy-api-key":             &cfg.GoDaddyAPIKey,
+		"godaddy-api-secret":          &cfg.GoDaddySecretKey,
+		"notification-url":            &cfg.NotificationURL,
+		"pdns-api-key":                &cfg.PDNSAPIKey,
+		"pihole-password":             &cfg.PiholePassword,
+		"rfc2136-kerberos-password":   &cfg.RFC2136KerberosPassword,
+		"rfc2136-tsig-secret":         &cfg.RFC2136TSIGSecret,
+		"txt-encrypt-aes-key":         &cfg.TXTEncryptAESKey,
+	}
+}
+
+// addSecretFileFlags adds the flags naming the files of the credentials.
+func addSecretFileFlags(app *kingpin.Application, cfg *Config) {
+	for _, name := range slices.Sorted(maps.Keys(cfg.secretFlags())) {
+		onChange := "the provider is set up again with the new value when the file changes"
+		if SecretRequiresRestart(name) {
+			onChange = "ExternalDNS terminates to be restarted when the file changes"
+		}
+		app.Flag(name+secretFileSuffix, fmt.Sprintf("Read the value of --%s from a file, e.g. mounted from a Secret; %s (optional)", name, onChange)).String()
+	}
+}
+
+// readSecretFiles reads the credentials whose files are given by the flags added by addSecretFileFlags, or by
+// environment variables with the _FILE suffix, and records these files in SecretFiles.
+func (cfg *Config) readSecretFiles(app *kingpin.Application) error {
+	for name, target := range cfg.secretFlags() {
+		path := app.GetFlag(name + secretFileSuffix).Model().Value.String()
+		if path == "" {
+			continue
+		}
+		if *target != "" {
+			return fmt.Errorf("--%s and --%s%s are mutually exclusive", name, name, secretFileSuffix)
+		}
+		value, err := readSecretFile(path)
+		if err != nil {
+			return err
+		}
+		*target = value
+		cfg.addSecretFile(name, path)
+	}
+
+	for _, name := range credentialEnvVars {
+		path := os.Getenv(name + "_FILE")
+		if path == "" {
+			continue
+		}
+		if os.Getenv(name) != "" {
+			return fmt.Errorf("%s and %s_FILE are mutually exclusive", name, name)
+		}
+		value, err := readSecretFile(path)
+		if err != nil {
+			return err
+		}
+		// providers read their credentials from the environment
+		if err := os.Setenv(name, value); err != nil {
+			return err
+		}
+		cfg.addSecretFile(name, path)
+	}
+	return nil
+}
+
+// IsSecret returns whether the given name is the flag or the environment variable of a credential.
+func (cfg *Config) IsSecret(name string) bool {
+	_, ok := cfg.secretFlags()[name]
+	return ok || slices.Contains(credentialEnvVars, name)
+}
+
+// SecretValues returns the values of the credentials which are set, from their flags or environment variables.
+func (cfg *Config) SecretValues() []string {
+	var values []string
+	for _, target := range cfg.secretFlags() {
+		if *target != "" {
+			values = append(values, *target)
+		}
+	}
+	for _, name := range credentialEnvVars {
+		if value := os.Getenv(name); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// SetSecret sets the credential of the given flag or environment variable, which must not have been set yet.
+func (cfg *Config) SetSecret(name, value string) error {
+	if target, ok := cfg.secretFlags()[name]; ok {
+		if *target != "" {
+			return fmt.Errorf("--%s is already set", name)
+		}
+		*target = value
+		return nil
+	}
+	if !slices.Contains(credentialEnvVars, name) {
+		return fmt.Errorf("unknown credential %s", name)
+	}
+	if os.Getenv(name) != "" {
+		return fmt.Errorf("%s is already set", name)
+	}
+	// providers read their credentials from the environment
+	return os.Setenv(name, value)
+}
+
+// SecretRequiresRestart returns whether ExternalDNS has to be restarted to use a new value of the given credential,
+// which isn't used by the provider or the registry.
+func SecretRequiresRestart(name string) bool {
+	return slices.Contains(restartSecretFlags, name)
+}
+
+// ReloadSecretFile reads the credential of the given flag or environment variable again from its file in
+// SecretFiles, and returns its new value.
+func (cfg *Config) ReloadSecretFile(name string) (string, error) {
+	path, ok := cfg.SecretFiles[name]
+	if !ok {
+		return "", fmt.Errorf("%s isn't read from a file", name)
+	}
+	value, err := readSecretFile(path)
+	if err != nil {
+		return "", err
+	}
+	if target, ok := cfg.secretFlags()[name]; ok {
+		*target = value
+		return value, nil
+	}
+	// providers read their credentials from the environment
+	return value, os.Setenv(name, value)
+}
+
+func (cfg *Config) addSecretFile(name, path string) {
+	if cfg.SecretFiles == nil {
+		cfg.SecretFiles = map[string]string{}
+	}
+	cfg.SecretFiles[name] = path
+}
+
+// readSecretFile returns the content of the given file, without surrounding whitespace such as a final newline.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSecretFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestSecretFlagsCoverSecureFields(t *testing.T) {
+	cfg := &Config{}
+	targets := map[any]bool{}
+	for _, target := range cfg.secretFlags() {
+		targets[target] = true
+	}
+
+	v := reflect.ValueOf(cfg).Elem()
+	for i := range v.NumField() {
+		if v.Type().Field(i).Tag.Get("secure") == "yes" {
+			assert.True(t, targets[v.Field(i).Addr().Interface()], "%s has no secret file flag", v.Type().Field(i).Name)
+		}
+	}
+	assert.Len(t, targets, len(cfg.secretFlags()))
+}
+
+func TestParseFlagsSecretFiles(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_CLI", "")
+	t.Setenv("CF_API_TOKEN", "")
+	t.Setenv("CF_API_TOKEN_FILE", writeSecretFile(t, "cloudflare-token\n"))
+	t.Setenv("EXTERNAL_DNS_RFC2136_TSIG_SECRET_FILE", writeSecretFile(t, "tsig-secret"))
+	pdnsFile := writeSecretFile(t, "  pdns-key\n")
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--provider=pdns", "--source=service", "--pdns-api-key-file=" + pdnsFile}))
+
+	assert.Equal(t, "pdns-key", cfg.PDNSAPIKey)
+	assert.Equal(t, "tsig-secret", cfg.RFC2136TSIGSecret)
+	assert.Equal(t, "cloudflare-token", os.Getenv("CF_API_TOKEN"))
+	assert.Equal(t, map[string]string{
+		"pdns-api-key":        pdnsFile,
+		"rfc2136-tsig-secret": os.Getenv("EXTERNAL_DNS_RFC2136_TSIG_SECRET_FILE"),
+		"CF_API_TOKEN":        os.Getenv("CF_API_TOKEN_FILE"),
+	}, cfg.SecretFiles)
+	assert.NotContains(t, cfg.String(), "pdns-key")
+}
+
+func TestParseFlagsSecretFilesErrors(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_CLI", "")
+	path := writeSecretFile(t, "secret")
+
+	for _, tt := range []struct {
+		title string
+		args  []string
+		env   map[string]string
+		err   string
+	}{
+		{
+			title: "flag and file",
+			args:  []string{"--pdns-api-key=key", "--pdns-api-key-file=" + path},
+			err:   "--pdns-api-key and --pdns-api-key-file are mutually exclusive",
+		},
+		{
+			title: "missing file",
+			args:  []string{"--pdns-api-key-file=" + filepath.Join(t.TempDir(), "missing")},
+			err:   "failed to read secret file",
+		},
+		{
+			title: "environment variable and file",
+			env:   map[string]string{"DO_TOKEN": "token", "DO_TOKEN_FILE": path},
+			err:   "DO_TOKEN and DO_TOKEN_FILE are mutually exclusive",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			cfg := NewConfig()
+			err := cfg.ParseFlags(append([]string{"--provider=pdns", "--source=service"}, tt.args...))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestSetSecret(t *testing.T) {
+	t.Setenv("CF_API_TOKEN", "")
+	t.Setenv("DO_TOKEN", "set")
+	cfg := NewConfig()
+	cfg.PDNSAPIKey = "set"
+
+	assert.True(t, cfg.IsSecret("rfc2136-tsig-secret"))
+	assert.True(t, cfg.IsSecret("CF_API_TOKEN"))
+	assert.False(t, cfg.IsSecret("provider"))
+
+	require.NoError(t, cfg.SetSecret("rfc2136-tsig-secret", "tsig"))
+	assert.Equal(t, "tsig", cfg.RFC2136TSIGSecret)
+	require.NoError(t, cfg.SetSecret("CF_API_TOKEN", "token"))
+	assert.Equal(t, "token", os.Getenv("CF_API_TOKEN"))
+
+	assert.ErrorContains(t, cfg.SetSecret("pdns-api-key", "other"), "already set")
+	assert.ErrorContains(t, cfg.SetSecret("DO_TOKEN", "other"), "already set")
+	assert.ErrorContains(t, cfg.SetSecret("provider", "other"), "unknown credential")
+}
+
+func TestSecretValues(t *testing.T) {
+	for _, name := range credentialEnvVars {
+		t.Setenv(name, "")
+	}
+	t.Setenv("DO_TOKEN", "do-token")
+	cfg := NewConfig()
+	cfg.PDNSAPIKey = "pdns-key"
+	cfg.RFC2136TSIGSecret = "tsig-secret"
+
+	assert.ElementsMatch(t, []string{"pdns-key", "tsig-secret", "do-token"}, cfg.SecretValues())
+}
+
+func TestReloadSecretFile(t *testing.T) {
+	t.Setenv("CF_API_TOKEN", "")
+	keyPath := writeSecretFile(t, "new-key\n")
+	tokenPath := writeSecretFile(t, "new-token")
+	cfg := NewConfig()
+	cfg.PDNSAPIKey = "old-key"
+	cfg.SecretFiles = map[string]string{"pdns-api-key": keyPath, "CF_API_TOKEN": tokenPath}
+
+	value, err := cfg.ReloadSecretFile("pdns-api-key")
+	require.NoError(t, err)
+	assert.Equal(t, "new-key", value)
+	assert.Equal(t, "new-key", cfg.PDNSAPIKey)
+
+	value, err = cfg.ReloadSecretFile("CF_API_TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", value)
+	assert.Equal(t, "new-token", os.Getenv("CF_API_TOKEN"))
+
+	_, err = cfg.ReloadSecretFile("rfc2136-tsig-secret")
+	require.ErrorContains(t, err, "isn't read from a file")
+
+	assert.True(t, SecretRequiresRestart("api-token"))
+	assert.False(t, SecretRequiresRestart("pdns-api-key"))
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/vault"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/sirupsen/logrus"
@@ -38,15 +39,43 @@ const (
 	passwordMask = "******"
 )
 
+const (
+	// CommandRun synchronizes the DNS records, which is the default
+	CommandRun = "run"
+	// CommandDiff prints the changes a synchronization would make and exits
+	CommandDiff = "diff"
+	// CommandValidate checks the flags, the access to the cluster and to the provider and exits
+	CommandValidate = "validate"
+	// CommandZones prints the zones the provider manages and exits
+	CommandZones = "zones"
+	// CommandExport prints the records owned by this instance and exits
+	CommandExport = "export"
+	// CommandRBAC prints the RBAC manifests the configuration requires and exits
+	CommandRBAC = "rbac"
+	// CommandVerifyAuditLog verifies the hash chain of the audit log and exits
+	CommandVerifyAuditLog = "verify-audit-log"
+	// CommandPlan saves the changes a synchronization would make to a file and exits
+	CommandPlan = "plan"
+	// CommandApply applies the changes of a saved plan and exits
+	CommandApply = "apply"
+)
+
 // Config is a project-wide configuration
 type Config struct {
+	ConfigFile                                    string
+	SecretFiles                                   map[string]string
 	APIServerURL                                  string
 	KubeConfig                                    string
 	RequestTimeout                                time.Duration
+	KubeAPIQPS                                    float32
+	KubeAPIBurst                                  int
+	InformerResyncPeriod                          time.Duration
 	DefaultTargets                                []string
 	GlooNamespaces                                []string
 	SkipperRouteGroupVersion                      string
 	Sources                                       []string
+	SourceConcurrency                             int
+	SourceTimeout                                 time.Duration
 	Namespace                                     string
 	AnnotationFilter                              string
 	LabelFilter                                   string
@@ -59,11 +88,14 @@ type Config struct {
 	IgnoreIngressRulesSpec                        bool
 	ListenEndpointEvents                          bool
 	ExposeInternalIPV6                            bool
+	NodeZoneDomain                                string
 	GatewayName                                   string
 	GatewayNamespace                              string
 	GatewayLabelFilter                            string
 	Compatibility                                 string
 	PodSourceDomain                               string
+	IstioEastWestDomain                           string
+	PreferTargetType                              string
 	PublishInternal                               bool
 	PublishHostIP                                 bool
 	AlwaysPublishNotReadyAddresses                bool
@@ -119,9 +151,9 @@ type Config struct {
 	CloudflareRegionKey                           string
 	CoreDNSPrefix                                 string
 	AkamaiServiceConsumerDomain                   string
-	AkamaiClientToken                             string
-	AkamaiClientSecret                            string
-	AkamaiAccessToken                             string
+	AkamaiClientToken                             string `secure:"yes"`
+	AkamaiClientSecret                            string `secure:"yes"`
+	AkamaiAccessToken                             string `secure:"yes"`
 	AkamaiEdgercPath                              string
 	AkamaiEdgercSection                           string
 	OCIConfigFile                                 string
@@ -141,19 +173,96 @@ type Config struct {
 	TLSClientCert                                 string
 	TLSClientCertKey                              string
 	Policy                                        string
+	PolicyRules                                   string
+	PolicyOPAURL                                  string
+	ApprovalThreshold                             int
+	ApprovalZones                                 []string
+	ApprovalNamespace                             string
+	ConflictResolution                            string
 	Registry                                      string
 	TXTOwnerID                                    string
+	SourceGroup                                   string
+	RegistryResourceLabels                        []string
 	TXTPrefix                                     string
 	TXTSuffix                                     string
 	TXTEncryptEnabled                             bool
 	TXTEncryptAESKey                              string `secure:"yes"`
 	Interval                                      time.Duration
 	MinEventSyncInterval                          time.Duration
+	EventDebounce                                 time.Duration
+	EventJitter                                   time.Duration
+	EventMaxBackoff                               time.Duration
+	EventCoalesceWindow                           time.Duration
+	ZoneBatching                                  bool
+	APIToken                                      string `secure:"yes"`
+	APIWriteToken                                 string `secure:"yes"`
+	APITokenAudience                              string
+	APIServiceAccounts                            []string
+	APIWriteServiceAccounts                       []string
+	GRPCAddress                                   string
+	ZoneConcurrency                               int
+	MaxChangesPerZonePerSync                      int
+	ZoneListConcurrency                           int
+	ZoneChangeTokens                              bool
+	StatusAnnotation                              bool
+	InventoryConfigMap                            string
+	NamespaceRecordsConfigMap                     string
+	StatusObject                                  string
+	AuditLog                                      string
+	AuditLogChain                                 bool
+	AuditLogS3Bucket                              string
+	AuditLogS3Prefix                              string
+	PublishKafkaBrokers                           []string
+	PublishKafkaTopic                             string
+	PublishNATSURL                                string
+	PublishNATSSubject                            string
+	PublishCloudEventsSink                        string
+	NotificationURL                               string `secure:"yes"`
+	NotificationFormat                            string
+	NotificationTemplate                          string
+	AdmissionWebhook                              bool
+	AdmissionWebhookAddress                       string
+	AdmissionWebhookTLSCert                       string
+	AdmissionWebhookTLSKey                        string
+	Command                                       string
+	DiffOutput                                    string
+	ZonesOutput                                   string
+	ExportOutput                                  string
+	RBACName                                      string
+	RBACServiceAccount                            string
+	PlanOut                                       string
+	PlanFile                                      string
+	PlanMaxAge                                    time.Duration
+	DrainTimeout                                  time.Duration
+	SyncTimeout                                   time.Duration
+	ReadyMaxSyncAge                               time.Duration
+	ChangeRetries                                 int
+	ChangeRetryBackoff                            time.Duration
+	FinalSync                                     bool
+	IncrementalSync                               bool
+	FullResyncInterval                            time.Duration
+	FastSyncInterval                              time.Duration
+	FastSyncResources                             []string
+	StreamRecords                                 bool
+	Paused                                        bool
+	ShardIndex                                    int
+	ShardCount                                    int
 	Once                                          bool
+	ValidateOnly                                  bool
+	DetailedExitCode                              bool
 	DryRun                                        bool
 	UpdateEvents                                  bool
 	LogFormat                                     string
+	FIPS                                          bool
 	MetricsAddress                                string
+	MetricsTLSCert                                string
+	MetricsTLSKey                                 string
+	MetricsTLSClientCA                            string
+	PushgatewayURL                                string
+	PushgatewayJob                                string
+	TracingEndpoint                               string
+	TracingInsecure                               bool
+	TracingSampleRatio                            float64
 	LogLevel                                      string
 	TXTCacheInterval                              time.Duration
 	TXTWildcardReplacement                        string
@@ -167,7 +276,7 @@ type Config struct {
 	ServiceTypeFilter                             []string
 	CFAPIEndpoint                                 string
 	CFUsername                                    string
-	CFPassword                                    string
+	CFPassword                                    string `secure:"yes"`
 	ResolveServiceLoadBalancerHostname            bool
 	RFC2136Host                                   []string
 	RFC2136Port                                   int
@@ -207,15 +316,40 @@ type Config struct {
 	PluralCluster                                 string
 	PluralProvider                                string
 	WebhookProviderURL                            string
+	WebhookProviderAllowedHosts                   []string
 	WebhookProviderReadTimeout                    time.Duration
 	WebhookProviderWriteTimeout                   time.Duration
 	WebhookServer                                 bool
 	TraefikEnableLegacy                           bool
 	TraefikDisableNew                             bool
 	NAT64Networks                                 []string
+	AddressFamilyPolicy                           string
+	ResolveApexCNAME                              bool
+	FlattenCoexistingCNAMEs                       bool
+	CNAMEFlatteningTTL                            time.Duration
+	GeoContinent                                  string
+	GeoCountry                                    string
+	GeoRegion                                     string
+	Federated                                     bool
+	WildcardPolicy                                string
+	TargetProbe                                   string
+	TargetProbePort                               int
+	TargetProbeTimeout                            time.Duration
+	NamespaceDomainsConfigMap                     string
+	WildcardPlaceholder                           string
 	ExcludeUnschedulable                          bool
+	InformerFullObjects                           []string
 	EmitEvents                                    []string
 	ForceDefaultTargets                           bool
+	VaultAddress                                  string
+	VaultAuthMount                                string
+	VaultRole                                     string
+	VaultServiceAccountTokenPath                  string
+	VaultCACert                                   string
+	VaultSecrets                                  map[string]string
+	CredentialExecCommand                         string
+	CredentialExecArgs                            []string
+	CredentialExecTimeout                         time.Duration
 	sourceWrappers                                map[string]bool // map of source wrappers, e.g. "targetfilter", "nat64"
 }
 
@@ -277,6 +411,13 @@ var defaultConfig = &Config{
 	ExcludeTargetNets:            []string{},
 	EmitEvents:                   []string{},
 	ExcludeUnschedulable:         true,
+	ResolveApexCNAME:             true,
+	CNAMEFlatteningTTL:           time.Minute,
+	AddressFamilyPolicy:          "dual-stack",
+	WildcardPolicy:               "allow",
+	TargetProbePort:              443,
+	TargetProbeTimeout:           time.Second,
+	WildcardPlaceholder:          "wildcard",
 	ExoscaleAPIEnvironment:       "api",
 	ExoscaleAPIKey:               "",
 	ExoscaleAPISecret:            "",
@@ -307,7 +448,26 @@ var defaultConfig = &Config{
 	LogLevel:                     logrus.InfoLevel.String(),
 	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
 	MetricsAddress:               ":7979",
+	PushgatewayJob:               "external-dns",
+	TracingSampleRatio:           1,
+	AuditLogS3Prefix:             "external-dns/audit/",
+	ApprovalNamespace:            "default",
+	PublishKafkaTopic:            "external-dns-changes",
+	PublishNATSSubject:           "external-dns.changes",
+	NotificationFormat:           "json",
 	MinEventSyncInterval:         5 * time.Second,
+	EventDebounce:                5 * time.Second,
+	EventJitter:                  0,
+	EventMaxBackoff:              0,
+	EventCoalesceWindow:          0,
+	ZoneConcurrency:              1,
+	ZoneListConcurrency:          1,
+	AdmissionWebhookAddress:      ":9443",
+	Command:                      CommandRun,
+	ChangeRetryBackoff:           time.Second,
+	FullResyncInterval:           time.Hour,
+	ShardIndex:                   0,
+	ShardCount:                   1,
 	Namespace:                    "",
 	NAT64Networks:                []string{},
 	NS1Endpoint:                  "",
@@ -331,6 +491,7 @@ var defaultConfig = &Config{
 	PluralProvider:               "",
 	PodSourceDomain:              "",
 	Policy:                       "sync",
+	ConflictResolution:           "targets",
 	Provider:                     "",
 	ProviderCacheTime:            0,
 	PublishHostIP:                false,
@@ -359,6 +520,8 @@ var defaultConfig = &Config{
 	ServiceTypeFilter:            []string{},
 	SkipperRouteGroupVersion:     "zalando.org/v1",
 	Sources:                      nil,
+	SourceConcurrency:            1,
+	SourceTimeout:                0,
 	TargetNetFilter:              []string{},
 	TLSCA:                        "",
 	TLSClientCert:                "",
@@ -377,10 +540,15 @@ var defaultConfig = &Config{
 	UpdateEvents:                 false,
 	WebhookProviderReadTimeout:   5 * time.Second,
 	WebhookProviderURL:           "http://localhost:8888",
+	WebhookProviderAllowedHosts:  []string{"localhost", "127.0.0.0/8", "::1/128"},
 	WebhookProviderWriteTimeout:  10 * time.Second,
 	WebhookServer:                false,
 	ZoneIDFilter:                 []string{},
 	ForceDefaultTargets:          false,
+	VaultAuthMount:               vault.DefaultAuthMount,
+	VaultServiceAccountTokenPath: vault.DefaultServiceAccountTokenPath,
+	VaultSecrets:                 map[string]string{},
+	CredentialExecTimeout:        30 * time.Second,
 	sourceWrappers:               map[string]bool{},
 }
 
@@ -388,6 +556,7 @@ var defaultConfig = &Config{
 func NewConfig() *Config {
 	return &Config{
 		AWSSDCreateTag: map[string]string{},
+		VaultSecrets:   map[string]string{},
 	}
 }
 
@@ -412,15 +581,6 @@ func (cfg *Config) String() string {
 	return fmt.Sprintf("%+v", temp)
 }
 
-// allLogLevelsAsStrings returns all logrus levels as a list of strings
-func allLogLevelsAsStrings() []string {
-	var levels []string
-	for _, level := range logrus.AllLevels {
-		levels = append(levels, level.String())
-	}
-	return levels
-}
-
 // ParseFlags adds and parses flags from command line
 func (cfg *Config) ParseFlags(args []string) error {
 	backend := ""
@@ -462,10 +622,18 @@ func (cfg *Config) ParseFlags(args []string) error {
 	}
 
 	app := App(cfg)
-	_, err := app.Parse(pruned)
+	fileArgs, err := configFileArgs(app, pruned)
+	if err != nil {
+		return err
+	}
+	command, err := app.Parse(append(fileArgs, pruned...))
 	if err != nil {
 		return err
 	}
+	if err := cfg.readSecretFiles(app); err != nil {
+		return err
+	}
+	cfg.Command = command
 
 	return nil
 }
@@ -518,10 +686,15 @@ func App(cfg *Config) *kingpin.Application {
 	app.Version(Version)
 	app.DefaultEnvars()
 
+	app.Flag("config", "Read flag values from a YAML file mapping flag names to their values; flags and environment variables take precedence over the file, which is reloaded when it changes or on SIGHUP (optional)").StringVar(&cfg.ConfigFile)
+
 	// Flags related to Kubernetes
 	app.Flag("server", "The Kubernetes API server to connect to (default: auto-detect)").Default(defaultConfig.APIServerURL).StringVar(&cfg.APIServerURL)
 	app.Flag("kubeconfig", "Retrieve target cluster configuration from a Kubernetes configuration file (default: auto-detect)").Default(defaultConfig.KubeConfig).StringVar(&cfg.KubeConfig)
 	app.Flag("request-timeout", "Request timeout when calling Kubernetes APIs. 0s means no timeout").Default(defaultConfig.RequestTimeout.String()).DurationVar(&cfg.RequestTimeout)
+	app.Flag("kube-api-qps", "The maximum number of queries per second of each Kubernetes client to the API server (default: 0, the client-go default of 5)").Default("0").Float32Var(&cfg.KubeAPIQPS)
+	app.Flag("kube-api-burst", "The maximum burst of queries of each Kubernetes client to the API server, above --kube-api-qps (default: 0, the client-go default of 10)").Default("0").IntVar(&cfg.KubeAPIBurst)
+	app.Flag("informer-resync-period", "The period after which the informers of the service, ingress, node, pod and gateway sources pass all their cached objects to their handlers again, in duration format (default: 0s, disabled)").Default(defaultConfig.InformerResyncPeriod.String()).DurationVar(&cfg.InformerResyncPeriod)
 	app.Flag("resolve-service-load-balancer-hostname", "Resolve the hostname of LoadBalancer-type Service object to IP addresses in order to create DNS A/AAAA records instead of CNAMEs").BoolVar(&cfg.ResolveServiceLoadBalancerHostname)
 	app.Flag("listen-endpoint-events", "Trigger a reconcile on changes to EndpointSlices, for Service source (default: false)").BoolVar(&cfg.ListenEndpointEvents)
 
@@ -537,8 +710,10 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("skipper-routegroup-groupversion", "The resource version for skipper routegroup").Default(defaultConfig.SkipperRouteGroupVersion).StringVar(&cfg.SkipperRouteGroupVersion)
 
 	// Flags related to processing source
+	app.Flag("address-family-policy", "Which address records to publish when sources have addresses of both families: both A and AAAA records, A records only for DNS names without AAAA records, or no A records at all (default: dual-stack, options: dual-stack, ipv6-first, ipv6-only)").Default(defaultConfig.AddressFamilyPolicy).EnumVar(&cfg.AddressFamilyPolicy, "dual-stack", "ipv6-first", "ipv6-only")
 	app.Flag("always-publish-not-ready-addresses", "Always publish also not ready addresses for headless services (optional)").BoolVar(&cfg.AlwaysPublishNotReadyAddresses)
 	app.Flag("annotation-filter", "Filter resources queried for endpoints by annotation, using label selector semantics").Default(defaultConfig.AnnotationFilter).StringVar(&cfg.AnnotationFilter)
+	app.Flag("cname-flattening-ttl", "The maximum TTL of the A/AAAA records resolved from CNAME records, which are resolved again by every synchronization; 0 keeps the TTL of the CNAME records (default: 1m)").Default(defaultConfig.CNAMEFlatteningTTL.String()).DurationVar(&cfg.CNAMEFlatteningTTL)
 	app.Flag("combine-fqdn-annotation", "Combine FQDN template and Annotations instead of overwriting (default: false)").BoolVar(&cfg.CombineFQDNAndAnnotation)
 	app.Flag("compatibility", "Process annotation semantics from legacy implementations (optional, options: mate, molecule, kops-dns-controller)").Default(defaultConfig.Compatibility).EnumVar(&cfg.Compatibility, "", "mate", "molecule", "kops-dns-controller")
 	app.Flag("connector-source-server", "The server to connect for connector source, valid only when using connector source").Default(defaultConfig.ConnectorSourceServer).StringVar(&cfg.ConnectorSourceServer)
@@ -546,42 +721,60 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("crd-source-kind", "Kind of the CRD for the crd source in API group and version specified by crd-source-apiversion").Default(defaultConfig.CRDSourceKind).StringVar(&cfg.CRDSourceKind)
 	app.Flag("default-targets", "Set globally default host/IP that will apply as a target instead of source addresses. Specify multiple times for multiple targets (optional)").StringsVar(&cfg.DefaultTargets)
 	app.Flag("force-default-targets", "Force the application of --default-targets, overriding any targets provided by the source (DEPRECATED: This reverts to (improved) legacy behavior which allows empty CRD targets for migration to new state)").Default(strconv.FormatBool(defaultConfig.ForceDefaultTargets)).BoolVar(&cfg.ForceDefaultTargets)
-	app.Flag("exclude-record-types", "Record types to exclude from management; specify multiple times to exclude many; (optional)").Default().StringsVar(&cfg.ExcludeDNSRecordTypes)
+	app.Flag("exclude-record-types", "Record types to exclude from management, even when listed in --managed-record-types; records of these types are never created, updated or deleted; specify multiple times to exclude many; (optional)").Default().StringsVar(&cfg.ExcludeDNSRecordTypes)
 	app.Flag("exclude-target-net", "Exclude target nets (optional)").StringsVar(&cfg.ExcludeTargetNets)
 	app.Flag("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)").Default(strconv.FormatBool(defaultConfig.ExcludeUnschedulable)).BoolVar(&cfg.ExcludeUnschedulable)
 	app.Flag("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional, default: false)").BoolVar(&cfg.ExposeInternalIPV6)
+	app.Flag("flatten-coexisting-cnames", "Resolve CNAME records sharing their DNS name with records of other types to A/AAAA records, instead of discarding them (default: false)").BoolVar(&cfg.FlattenCoexistingCNAMEs)
 	app.Flag("fqdn-template", "A templated string that's used to generate DNS names from sources that don't define a hostname themselves, or to add a hostname suffix when paired with the fake source (optional). Accepts comma separated list for multiple global FQDN.").Default(defaultConfig.FQDNTemplate).StringVar(&cfg.FQDNTemplate)
 	app.Flag("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)").StringVar(&cfg.GatewayLabelFilter)
 	app.Flag("gateway-name", "Limit Gateways of Route endpoints to a specific name (default: all names)").StringVar(&cfg.GatewayName)
 	app.Flag("gateway-namespace", "Limit Gateways of Route endpoints to a specific namespace (default: all namespaces)").StringVar(&cfg.GatewayNamespace)
+	app.Flag("geo-continent", "Continent code of the cluster location, applied to endpoints without geo annotations for providers with geolocation routing (optional, options: AF, AN, AS, EU, NA, OC, SA)").StringVar(&cfg.GeoContinent)
+	app.Flag("geo-country", "ISO 3166-1 country code of the cluster location, applied to endpoints without geo annotations for providers with geolocation routing (optional)").StringVar(&cfg.GeoCountry)
+	app.Flag("geo-region", "Subdivision code of the cluster location within --geo-country, applied to endpoints without geo annotations (optional)").StringVar(&cfg.GeoRegion)
+	app.Flag("federated", "Merge the endpoints of the same record propagated from distinct member clusters, as identified by the cluster annotation, into a single record holding the targets of all of them (default: disabled)").BoolVar(&cfg.Federated)
 	app.Flag("ignore-hostname-annotation", "Ignore hostname annotation when generating DNS names, valid only when --fqdn-template is set (default: false)").BoolVar(&cfg.IgnoreHostnameAnnotation)
 	app.Flag("ignore-ingress-rules-spec", "Ignore the spec.rules section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressRulesSpec)
 	app.Flag("ignore-ingress-tls-spec", "Ignore the spec.tls section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressTLSSpec)
 	app.Flag("ignore-non-host-network-pods", "Ignore pods not running on host network when using pod source (default: false)").BoolVar(&cfg.IgnoreNonHostNetworkPods)
+	app.Flag("informer-full-objects", "Keep complete objects in the informer caches of this source instead of stripping managed fields, the kubectl last-applied-configuration annotation and unused node status; specify multiple times for multiple sources (optional, options: service, ingress, node, pod, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute)").EnumsVar(&cfg.InformerFullObjects, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute")
 	app.Flag("ingress-class", "Require an Ingress to have this class name; specify multiple times to allow more than one class (optional; defaults to any class)").StringsVar(&cfg.IngressClassNames)
+	app.Flag("istio-east-west-domain", "Domain under which the istio-gateway source publishes the addresses of the east-west gateways of each mesh network, as <network>.<domain> (optional)").StringVar(&cfg.IstioEastWestDomain)
 	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types crd, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, ingress, node, openshift-route, service and ambassador-host").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
 	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
 	app.Flag("managed-record-types", managedRecordTypesHelp).Default(defaultConfig.ManagedDNSRecordTypes...).StringsVar(&cfg.ManagedDNSRecordTypes)
 	app.Flag("namespace", "Limit resources queried for endpoints to a specific namespace (default: all namespaces)").Default(defaultConfig.Namespace).StringVar(&cfg.Namespace)
 	app.Flag("nat64-networks", "Adding an A record for each AAAA record in NAT64-enabled networks; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.NAT64Networks)
+	app.Flag("node-zone-domain", "When using the node source, also publish for each topology zone a record named after the zone within this domain, e.g. eu-west-1a.nodes.example.com, with the addresses of all the nodes of the zone (optional)").Default(defaultConfig.NodeZoneDomain).StringVar(&cfg.NodeZoneDomain)
 	app.Flag("openshift-router-name", "if source is openshift-route then you can pass the ingress controller name. Based on this name external-dns will select the respective router from the route status and map that routerCanonicalHostname to the route host while creating a CNAME record.").StringVar(&cfg.OCPRouterName)
 	app.Flag("pod-source-domain", "Domain to use for pods records (optional)").Default(defaultConfig.PodSourceDomain).StringVar(&cfg.PodSourceDomain)
+	app.Flag("prefer-target-type", "Which target the ingress and service sources publish for load balancers with both an IP address and a hostname: the IP address as A/AAAA records, or the hostname as a CNAME record; overridden by the prefer-target-type annotation (default: both, options: ip, hostname)").Default(defaultConfig.PreferTargetType).EnumVar(&cfg.PreferTargetType, "", "ip", "hostname")
 	app.Flag("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)").BoolVar(&cfg.PublishHostIP)
 	app.Flag("publish-internal-services", "Allow external-dns to publish DNS records for ClusterIP services (optional)").BoolVar(&cfg.PublishInternal)
+	app.Flag("resolve-apex-cname", "Resolve CNAME records at the apex of the zones in --domain-filter to A/AAAA records if the provider has no alias, ANAME or flattening for them (default: true)").Default(strconv.FormatBool(defaultConfig.ResolveApexCNAME)).BoolVar(&cfg.ResolveApexCNAME)
 	app.Flag("service-type-filter", "The service types to filter by. Specify multiple times for multiple filters to be applied. (optional, default: all, expected: ClusterIP, NodePort, LoadBalancer or ExternalName)").Default(defaultConfig.ServiceTypeFilter...).StringsVar(&cfg.ServiceTypeFilter)
-	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
+	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy, cert-manager-tlsa)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy", "cert-manager-tlsa")
+	app.Flag("source-concurrency", "The number of sources whose endpoints are collected concurrently (default: 1)").Default(strconv.Itoa(defaultConfig.SourceConcurrency)).IntVar(&cfg.SourceConcurrency)
+	app.Flag("source-timeout", "The maximum time a source may take to return its endpoints, after which the synchronization fails; 0s means no timeout (default: 0s)").Default(defaultConfig.SourceTimeout.String()).DurationVar(&cfg.SourceTimeout)
 	app.Flag("target-net-filter", "Limit possible targets by a net filter; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
+	app.Flag("target-probe", "Probe the targets of A and AAAA records with several targets before publishing them, and drop the unreachable ones (optional, options: tcp, icmp)").Default(defaultConfig.TargetProbe).EnumVar(&cfg.TargetProbe, "", "tcp", "icmp")
+	app.Flag("target-probe-port", "The port connected to by --target-probe=tcp").Default(strconv.Itoa(defaultConfig.TargetProbePort)).IntVar(&cfg.TargetProbePort)
+	app.Flag("target-probe-timeout", "The time within which a target must answer --target-probe to be reachable").Default(defaultConfig.TargetProbeTimeout.String()).DurationVar(&cfg.TargetProbeTimeout)
+	app.Flag("namespace-domains-configmap", "When set, only publish the DNS names each namespace may request, as given by the ConfigMap in namespace/name format whose keys are namespaces, or * for the others, and values DNS suffixes; the others are dropped with an event (optional)").Default(defaultConfig.NamespaceDomainsConfigMap).StringVar(&cfg.NamespaceDomainsConfigMap)
 	app.Flag("traefik-enable-legacy", "Enable legacy listeners on Resources under the traefik.containo.us API Group").Default(strconv.FormatBool(defaultConfig.TraefikEnableLegacy)).BoolVar(&cfg.TraefikEnableLegacy)
 	app.Flag("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableNew)).BoolVar(&cfg.TraefikDisableNew)
+	app.Flag("wildcard-placeholder", "The label replacing the \"*\" label of wildcard DNS names with --wildcard-policy=replace (default: wildcard)").Default(defaultConfig.WildcardPlaceholder).StringVar(&cfg.WildcardPlaceholder)
+	app.Flag("wildcard-policy", "Whether wildcard DNS names from sources are published, rejected with an event, or published with --wildcard-placeholder instead of their \"*\" label (default: allow, options: allow, deny, replace)").Default(defaultConfig.WildcardPolicy).EnumVar(&cfg.WildcardPolicy, "allow", "deny", "replace")
 
-	app.Flag("events-emit", "Events that should be emitted. Specify multiple times for multiple events support (optional, default: none, expected: RecordReady, RecordDeleted, RecordError)").Default(defaultConfig.EmitEvents...).StringsVar(&cfg.EmitEvents)
+	app.Flag("events-emit", "Events that should be emitted. Specify multiple times for multiple events support (optional, default: none, expected: CreatedDNSRecord, UpdatedDNSRecord, DeletedDNSRecord, FailedApplyDNS, RejectedDNSRecord, UnreachableTargets, OwnershipConflict, InvalidAnnotations; RecordReady, RecordDeleted and RecordError select the reasons replacing them)").Default(defaultConfig.EmitEvents...).StringsVar(&cfg.EmitEvents)
 
 	// Flags related to providers
 	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "transip", "webhook"}
 	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(providers, ", ")+")").Required().PlaceHolder("provider").EnumVar(&cfg.Provider, providers...)
 	app.Flag("provider-cache-time", "The time to cache the DNS provider record list requests.").Default(defaultConfig.ProviderCacheTime.String()).DurationVar(&cfg.ProviderCacheTime)
-	app.Flag("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)").Default("").StringsVar(&cfg.DomainFilter)
-	app.Flag("exclude-domains", "Exclude subdomains (optional)").Default("").StringsVar(&cfg.ExcludeDomains)
+	app.Flag("domain-filter", "Limit possible target zones by a domain suffix or a glob pattern such as *.example.com; specify multiple times for multiple domains (optional)").Default("").StringsVar(&cfg.DomainFilter)
+	app.Flag("exclude-domains", "Exclude subdomains, given as domain suffixes or glob patterns (optional)").Default("").StringsVar(&cfg.ExcludeDomains)
 	app.Flag("regex-domain-filter", "Limit possible domains and target zones by a Regex filter; Overrides domain-filter (optional)").Default(defaultConfig.RegexDomainFilter.String()).RegexpVar(&cfg.RegexDomainFilter)
 	app.Flag("regex-domain-exclusion", "Regex filter that excludes domains and target zones matched by regex-domain-filter (optional); Require 'regex-domain-filter' ").Default(defaultConfig.RegexDomainExclusion.String()).RegexpVar(&cfg.RegexDomainExclusion)
 	app.Flag("zone-name-filter", "Filter target zones by zone domain (For now, only AzureDNS provider is using this flag); specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.ZoneNameFilter)
@@ -701,10 +894,18 @@ func App(cfg *Config) *kingpin.Application {
 
 	// Flags related to policies
 	app.Flag("policy", "Modify how DNS records are synchronized between sources and providers (default: sync, options: sync, upsert-only, create-only)").Default(defaultConfig.Policy).EnumVar(&cfg.Policy, "sync", "upsert-only", "create-only")
+	app.Flag("policy-rules", "When set, evaluate the CEL rules of the given YAML file over each planned change, leaving out the changes they deny (optional)").Default(defaultConfig.PolicyRules).StringVar(&cfg.PolicyRules)
+	app.Flag("policy-opa-url", "When set, query the OPA document at the given URL, e.g. http://opa:8181/v1/data/externaldns/verdicts, with the planned changes, leaving out the changes it denies (optional)").Default(defaultConfig.PolicyOPAURL).StringVar(&cfg.PolicyOPAURL)
+	app.Flag("approval-threshold", "When set, submit the plans with at least the given number of changes as PendingChange objects, and only apply them once approved by setting their external-dns.alpha.kubernetes.io/approved annotation to \"true\" (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.ApprovalThreshold)).IntVar(&cfg.ApprovalThreshold)
+	app.Flag("approval-zones", "Submit the plans with changes within the given DNS zone for approval, like those reaching --approval-threshold; specify multiple times for multiple zones (optional)").StringsVar(&cfg.ApprovalZones)
+	app.Flag("approval-namespace", "The namespace of the PendingChange objects of the plans needing approval (default: default)").Default(defaultConfig.ApprovalNamespace).StringVar(&cfg.ApprovalNamespace)
+	app.Flag("conflict-resolution", "Modify how a DNS name requested by several resources is assigned (default: targets, options: targets, oldest-resource, priority, merge-targets)").Default(defaultConfig.ConflictResolution).EnumVar(&cfg.ConflictResolution, "targets", "oldest-resource", "priority", "merge-targets")
 
 	// Flags related to the registry
 	app.Flag("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: txt, noop, dynamodb, aws-sd)").Default(defaultConfig.Registry).EnumVar(&cfg.Registry, "txt", "noop", "dynamodb", "aws-sd")
 	app.Flag("txt-owner-id", "When using the TXT or DynamoDB registry, a name that identifies this instance of ExternalDNS (default: default)").Default(defaultConfig.TXTOwnerID).StringVar(&cfg.TXTOwnerID)
+	app.Flag("source-group", "When several instances with distinct sources share the same --txt-owner-id, a name that identifies the sources of this instance; records are only deleted or updated by the group which created them (optional)").Default(defaultConfig.SourceGroup).StringVar(&cfg.SourceGroup)
+	app.Flag("registry-resource-labels", "The keys of the resource labels, such as team or environment, to persist along with the ownership of the records they create, which can be queried with the API; specify multiple times for multiple labels (optional)").StringsVar(&cfg.RegistryResourceLabels)
 	app.Flag("txt-prefix", "When using the TXT registry, a custom string that's prefixed to each ownership DNS record (optional). Could contain record type template like '%{record_type}-prefix-'. Mutual exclusive with txt-suffix!").Default(defaultConfig.TXTPrefix).StringVar(&cfg.TXTPrefix)
 	app.Flag("txt-suffix", "When using the TXT registry, a custom string that's suffixed to the host portion of each ownership DNS record (optional). Could contain record type template like '-%{record_type}-suffix'. Mutual exclusive with txt-prefix!").Default(defaultConfig.TXTSuffix).StringVar(&cfg.TXTSuffix)
 	app.Flag("txt-wildcard-replacement", "When using the TXT registry, a custom string that's used instead of an asterisk for TXT records corresponding to wildcard DNS records (optional)").Default(defaultConfig.TXTWildcardReplacement).StringVar(&cfg.TXTWildcardReplacement)
@@ -717,21 +918,116 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("txt-cache-interval", "The interval between cache synchronizations in duration format (default: disabled)").Default(defaultConfig.TXTCacheInterval.String()).DurationVar(&cfg.TXTCacheInterval)
 	app.Flag("interval", "The interval between two consecutive synchronizations in duration format (default: 1m)").Default(defaultConfig.Interval.String()).DurationVar(&cfg.Interval)
 	app.Flag("min-event-sync-interval", "The minimum interval between two consecutive synchronizations triggered from kubernetes events in duration format (default: 5s)").Default(defaultConfig.MinEventSyncInterval.String()).DurationVar(&cfg.MinEventSyncInterval)
+	app.Flag("events-debounce", "When using --events, the delay between a change and the synchronization it triggers, during which further changes are coalesced, in duration format (default: 5s)").Default(defaultConfig.EventDebounce.String()).DurationVar(&cfg.EventDebounce)
+	app.Flag("events-jitter", "When using --events, add a random delay of up to this duration to each triggered synchronization, in duration format (default: disabled)").Default(defaultConfig.EventJitter.String()).DurationVar(&cfg.EventJitter)
+	app.Flag("events-max-backoff", "When using --events, double the minimum interval between consecutive triggered synchronizations under continuous changes, up to this duration (default: disabled)").Default(defaultConfig.EventMaxBackoff.String()).DurationVar(&cfg.EventMaxBackoff)
+	app.Flag("events-coalesce-window", "When using --events, postpone each triggered synchronization until no change happened for this duration, so that a burst of changes results in a single synchronization, but no later than the next periodic one, in duration format (default: disabled)").Default(defaultConfig.EventCoalesceWindow.String()).DurationVar(&cfg.EventCoalesceWindow)
+	app.Flag("zone-batching", "When enabled, the changes of each DNS zone are applied independently, so that a failure in one zone doesn't prevent the other zones from being updated; zones are taken from the domain filters (default: disabled)").BoolVar(&cfg.ZoneBatching)
+	app.Flag("zone-concurrency", "When using --zone-batching, the number of zones whose changes are applied concurrently; only use values above 1 with providers which support concurrent changes (default: 1)").Default(strconv.Itoa(defaultConfig.ZoneConcurrency)).IntVar(&cfg.ZoneConcurrency)
+	app.Flag("max-changes-per-zone-per-sync", "When set, the number of changes applied to any single DNS zone by one synchronization, an update counting once; the others are deferred to later synchronizations, which caps the changes of a faulty source or template; zones are taken from the domain filters (default: 0, unlimited)").Default(strconv.Itoa(defaultConfig.MaxChangesPerZonePerSync)).IntVar(&cfg.MaxChangesPerZonePerSync)
+	app.Flag("zone-list-concurrency", "The number of zones whose records are listed concurrently, bounded to keep within the API rate limits of the provider; supported by the aws, azure, azure-private-dns, cloudflare and google providers (default: 1)").Default(strconv.Itoa(defaultConfig.ZoneListConcurrency)).IntVar(&cfg.ZoneListConcurrency)
+	app.Flag("zone-change-tokens", "When enabled, skip listing the records of zones whose change token, the serial of their SOA record, is unchanged since they were last listed; supported by the google and rfc2136 providers (default: disabled)").BoolVar(&cfg.ZoneChangeTokens)
+	app.Flag("shard-index", "When running several instances with --shard-count, the index of the shard of DNS names managed by this instance, starting at 0 (default: 0)").Default(strconv.Itoa(defaultConfig.ShardIndex)).IntVar(&cfg.ShardIndex)
+	app.Flag("shard-count", "Split the DNS names between this many instances by a hash of the FQDN; each instance needs a distinct --shard-index (default: 1, no sharding)").Default(strconv.Itoa(defaultConfig.ShardCount)).IntVar(&cfg.ShardCount)
+	app.Flag("change-retries", "The number of times changes which failed to apply are retried within the interval; each retry splits the failed changes in halves to isolate the rejected ones (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.ChangeRetries)).IntVar(&cfg.ChangeRetries)
+	app.Flag("change-retry-backoff", "When using --change-retries, the delay before the first retry in duration format, doubled for each further retry (default: 1s)").Default(defaultConfig.ChangeRetryBackoff.String()).DurationVar(&cfg.ChangeRetryBackoff)
+	app.Flag("sync-timeout", "Cancel a synchronization which takes longer than this duration, in duration format; the next one starts afresh at the following interval (default: disabled)").Default(defaultConfig.SyncTimeout.String()).DurationVar(&cfg.SyncTimeout)
+	app.Flag("ready-max-sync-age", "Report the instance as not ready on /readyz once the last successful synchronization of all records or of any zone is older than this duration, in duration format (default: disabled, /readyz only reports the details)").Default(defaultConfig.ReadyMaxSyncAge.String()).DurationVar(&cfg.ReadyMaxSyncAge)
+	app.Flag("drain-timeout", "On SIGTERM, let the synchronization in progress complete for up to this duration before terminating, in duration format (default: disabled, the synchronization is interrupted)").Default(defaultConfig.DrainTimeout.String()).DurationVar(&cfg.DrainTimeout)
+	app.Flag("final-sync", "When enabled, run one last synchronization on SIGTERM before terminating; requires --drain-timeout, which bounds it (default: disabled)").BoolVar(&cfg.FinalSync)
+	app.Flag("incremental-sync", "When enabled, reuse the records left by the previous synchronization instead of fetching them from the provider, and only plan the DNS names whose desired endpoints changed since; the sources are still listed in full (default: disabled)").BoolVar(&cfg.IncrementalSync)
+	app.Flag("full-resync-interval", "When using --incremental-sync, the interval between full synchronizations fetching the records from the provider, in duration format (default: 1h)").Default(defaultConfig.FullResyncInterval.String()).DurationVar(&cfg.FullResyncInterval)
+	app.Flag("fast-sync-interval", "When set, check the desired TXT records of ACME DNS-01 challenges, named _acme-challenge.<domain>, at this interval in duration format, and reconcile those which changed right away rather than at the next synchronization (default: 0, disabled)").Default(defaultConfig.FastSyncInterval.String()).DurationVar(&cfg.FastSyncInterval)
+	app.Flag("fast-sync-resource", "When using --fast-sync-interval, also reconcile the TXT records of the resources matching this pattern in kind/namespace/name format, such as crd/cert-manager/*; specify multiple times for multiple patterns (optional)").StringsVar(&cfg.FastSyncResources)
+	app.Flag("stream-records", "When enabled, stream the records from the provider and only keep those of the desired DNS names and those owned by this instance, to plan very large zones in bounded memory; requires the txt registry (default: disabled)").BoolVar(&cfg.StreamRecords)
 	app.Flag("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)").BoolVar(&cfg.Once)
+	app.Flag("validate-only", "When enabled, check that the sources are allowed to read their resources and that the provider credentials are valid, print the outcome and exit, with a non-zero code if any check failed (default: disabled)").BoolVar(&cfg.ValidateOnly)
+	app.Flag("detailed-exit-code", "When enabled with --once or the diff command, exit with code 2 if DNS records had to be changed, 0 if they were already up to date and 1 on errors (default: disabled)").BoolVar(&cfg.DetailedExitCode)
 	app.Flag("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)").BoolVar(&cfg.DryRun)
 	app.Flag("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)").BoolVar(&cfg.UpdateEvents)
 
 	// Miscellaneous flags
 	app.Flag("log-format", "The format in which log messages are printed (default: text, options: text, json)").Default(defaultConfig.LogFormat).EnumVar(&cfg.LogFormat, "text", "json")
+	app.Flag("fips", "When enabled, only use FIPS 140-3 approved cryptography: fail on startup unless Go runs in FIPS 140-3 mode, as with GODEBUG=fips140=on, or if an option needs other algorithms (default: disabled)").BoolVar(&cfg.FIPS)
+	app.Flag("status-annotation", "When enabled, set the external-dns.alpha.kubernetes.io/status annotation to synced@<timestamp> on the resources whose records were created or updated; requires the patch permission on these resources (default: disabled)").BoolVar(&cfg.StatusAnnotation)
+	app.Flag("inventory-configmap", "When set, publish the records managed by this instance as JSON in the given ConfigMap, in namespace/name format, after each synchronization; requires the get, create and update permissions on it (optional)").Default(defaultConfig.InventoryConfigMap).StringVar(&cfg.InventoryConfigMap)
+	app.Flag("namespace-records-configmap", "When set, publish in each namespace a ConfigMap of the given name mapping the hostnames of the records of its resources to their targets as JSON, after each synchronization; requires the get, create and update permissions on it (optional)").Default(defaultConfig.NamespaceRecordsConfigMap).StringVar(&cfg.NamespaceRecordsConfigMap)
+	app.Flag("status-object", "When set, report the outcome of each synchronization in the status of the cluster-scoped ExternalDNSStatus object of the given name, which is created if needed; requires the ExternalDNSStatus CRD and the get, create and update permissions on it (optional)").Default(defaultConfig.StatusObject).StringVar(&cfg.StatusObject)
+	app.Flag("audit-log", "When set, append a JSON line for every created, updated and deleted record, with the old and new values, the resource and the owner ID, to this file, or to the standard output if set to - (optional)").Default(defaultConfig.AuditLog).StringVar(&cfg.AuditLog)
+	app.Flag("audit-log-chain", "When enabled, chain the entries of the audit log by hash, each holding the hash of the previous one, so that the verify-audit-log command detects altered or removed entries; requires --audit-log to be a file, or --audit-log-s3-bucket (default: disabled)").BoolVar(&cfg.AuditLogChain)
+	app.Flag("audit-log-s3-bucket", "When set with --audit-log-chain, store the hash-chained audit log as objects in this S3 bucket instead of --audit-log, with the AWS credentials of the environment (optional)").Default(defaultConfig.AuditLogS3Bucket).StringVar(&cfg.AuditLogS3Bucket)
+	app.Flag("audit-log-s3-prefix", "The key prefix of the objects of the audit log in --audit-log-s3-bucket (default: external-dns/audit/)").Default(defaultConfig.AuditLogS3Prefix).StringVar(&cfg.AuditLogS3Prefix)
+	app.Flag("publish-kafka-brokers", "When set, publish a JSON message for every applied and failed change to Kafka through these brokers, in host:port format; specify multiple times for multiple brokers (optional)").StringsVar(&cfg.PublishKafkaBrokers)
+	app.Flag("publish-kafka-topic", "When using --publish-kafka-brokers, the Kafka topic the changes are published to (default: external-dns-changes)").Default(defaultConfig.PublishKafkaTopic).StringVar(&cfg.PublishKafkaTopic)
+	app.Flag("publish-nats-url", "When set, publish a JSON message for every applied and failed change to the NATS server at this URL (optional)").Default(defaultConfig.PublishNATSURL).StringVar(&cfg.PublishNATSURL)
+	app.Flag("publish-nats-subject", "When using --publish-nats-url, the NATS subject the changes are published to (default: external-dns.changes)").Default(defaultConfig.PublishNATSSubject).StringVar(&cfg.PublishNATSSubject)
+	app.Flag("publish-cloudevents-sink", "When set, post a CloudEvent for every applied and failed change to this HTTP sink, e.g. a Knative broker (optional)").StringVar(&cfg.PublishCloudEventsSink)
+	app.Flag("notification-url", "When set, POST a summary of each synchronization which applied changes or failed to this URL (optional)").Default(defaultConfig.NotificationURL).StringVar(&cfg.NotificationURL)
+	app.Flag("notification-format", "When using --notification-url, the format of the summary (default: json, options: json, slack)").Default(defaultConfig.NotificationFormat).EnumVar(&cfg.NotificationFormat, "json", "slack")
+	app.Flag("notification-template", "When using --notification-url, the path to a Go template rendering the body of the summary, overriding --notification-format (optional)").Default(defaultConfig.NotificationTemplate).StringVar(&cfg.NotificationTemplate)
+	app.Flag("paused", "When enabled, start with applying changes paused: the plan is still calculated and served by the API, which resumes synchronization with POST /api/v1/resume; requires --api-write-token or --api-write-service-account (default: disabled)").BoolVar(&cfg.Paused)
+	app.Flag("api-token", "When set, serve the desired endpoints, the actual records and the pending changes of the last synchronization as JSON under /api/v1/ on the metrics address, requiring this bearer token, which can't pause synchronization, see --api-write-token (optional)").Default(defaultConfig.APIToken).StringVar(&cfg.APIToken)
+	app.Flag("api-write-token", "When set, serve the API as --api-token does to this bearer token, which is also allowed to pause and resume synchronization and to trigger synchronizations (optional)").Default(defaultConfig.APIWriteToken).StringVar(&cfg.APIWriteToken)
+	app.Flag("api-token-audience", "When set, also serve the API to the service accounts given by --api-service-account with tokens bound to this audience, such as projected tokens, verified with TokenReview; requires the create permission on tokenreviews (optional)").Default(defaultConfig.APITokenAudience).StringVar(&cfg.APITokenAudience)
+	app.Flag("api-service-account", "When using --api-token-audience, a service account allowed to call the API, in namespace/name format; specify multiple times for multiple service accounts").StringsVar(&cfg.APIServiceAccounts)
+	app.Flag("api-write-service-account", "When using --api-token-audience, a service account allowed to call the API, including pausing and resuming synchronization and triggering synchronizations, in namespace/name format; specify multiple times for multiple service accounts").StringsVar(&cfg.APIWriteServiceAccounts)
+	app.Flag("grpc-address", "When set, serve the gRPC admin API, which queries the state of the last synchronization, triggers synchronizations and pauses them, on the given address, e.g. :7980; requires the bearer tokens of --api-token, --api-write-token or --api-token-audience (optional)").Default(defaultConfig.GRPCAddress).StringVar(&cfg.GRPCAddress)
 	app.Flag("metrics-address", "Specify where to serve the metrics and health check endpoint (default: :7979)").Default(defaultConfig.MetricsAddress).StringVar(&cfg.MetricsAddress)
-	app.Flag("log-level", "Set the level of logging. (default: info, options: panic, debug, info, warning, error, fatal)").Default(defaultConfig.LogLevel).EnumVar(&cfg.LogLevel, allLogLevelsAsStrings()...)
+	app.Flag("metrics-tls-cert", "When set with --metrics-tls-key, serve the metrics, health check and API endpoints over TLS with this certificate file, read again when it changes (optional)").Default(defaultConfig.MetricsTLSCert).StringVar(&cfg.MetricsTLSCert)
+	app.Flag("metrics-tls-key", "The private key file of --metrics-tls-cert (optional)").Default(defaultConfig.MetricsTLSKey).StringVar(&cfg.MetricsTLSKey)
+	app.Flag("metrics-tls-client-ca", "When using --metrics-tls-cert, require a client certificate signed by this CA file for all endpoints but the /healthz and /readyz probes (optional)").Default(defaultConfig.MetricsTLSClientCA).StringVar(&cfg.MetricsTLSClientCA)
+	app.Flag("pushgateway-url", "When using --once, push the metrics to the Prometheus Pushgateway at this URL at the end of the run (optional)").Default(defaultConfig.PushgatewayURL).StringVar(&cfg.PushgatewayURL)
+	app.Flag("pushgateway-job", "When using --pushgateway-url, the job name the metrics are pushed under, replacing those of the previous run (default: external-dns)").Default(defaultConfig.PushgatewayJob).StringVar(&cfg.PushgatewayJob)
+	app.Flag("tracing-endpoint", "When set, export OpenTelemetry traces of the synchronizations to this OTLP gRPC collector, in host:port format (optional)").Default(defaultConfig.TracingEndpoint).StringVar(&cfg.TracingEndpoint)
+	app.Flag("tracing-insecure", "When using --tracing-endpoint, connect to the collector without TLS (default: disabled)").BoolVar(&cfg.TracingInsecure)
+	app.Flag("tracing-sample-ratio", "When using --tracing-endpoint, the ratio of synchronizations traced, between 0 and 1 (default: 1)").Default(strconv.FormatFloat(defaultConfig.TracingSampleRatio, 'f', -1, 64)).Float64Var(&cfg.TracingSampleRatio)
+	app.Flag("log-level", "Set the level of logging, optionally followed by the levels of specific modules, such as info,source=debug,provider.aws=trace (default: info, options: panic, fatal, error, warning, info, debug, trace)").Default(defaultConfig.LogLevel).StringVar(&cfg.LogLevel)
 
 	// Webhook provider
 	app.Flag("webhook-provider-url", "The URL of the remote endpoint to call for the webhook provider (default: http://localhost:8888)").Default(defaultConfig.WebhookProviderURL).StringVar(&cfg.WebhookProviderURL)
+	app.Flag("webhook-provider-allowed-hosts", "The hosts, IP addresses and CIDRs the webhook provider may call, including on redirects; names starting with *. match their sub-domains and * any host; unix:// socket URLs are always allowed; specify multiple times for multiple hosts (default: localhost, 127.0.0.0/8, ::1/128)").Default(defaultConfig.WebhookProviderAllowedHosts...).StringsVar(&cfg.WebhookProviderAllowedHosts)
 	app.Flag("webhook-provider-read-timeout", "The read timeout for the webhook provider in duration format (default: 5s)").Default(defaultConfig.WebhookProviderReadTimeout.String()).DurationVar(&cfg.WebhookProviderReadTimeout)
 	app.Flag("webhook-provider-write-timeout", "The write timeout for the webhook provider in duration format (default: 10s)").Default(defaultConfig.WebhookProviderWriteTimeout.String()).DurationVar(&cfg.WebhookProviderWriteTimeout)
 
 	app.Flag("webhook-server", "When enabled, runs as a webhook server instead of a controller. (default: false).").BoolVar(&cfg.WebhookServer)
+	app.Flag("admission-webhook", "When enabled, runs as a validating admission webhook for external-dns annotations and DNSEndpoint resources instead of a controller (default: false)").BoolVar(&cfg.AdmissionWebhook)
+	app.Flag("admission-webhook-address", "The address the admission webhook listens on (default: :9443)").Default(defaultConfig.AdmissionWebhookAddress).StringVar(&cfg.AdmissionWebhookAddress)
+	app.Flag("admission-webhook-tls-cert", "The TLS certificate file of the admission webhook; required with --admission-webhook").Default(defaultConfig.AdmissionWebhookTLSCert).StringVar(&cfg.AdmissionWebhookTLSCert)
+	app.Flag("admission-webhook-tls-key", "The TLS private key file of the admission webhook; required with --admission-webhook").Default(defaultConfig.AdmissionWebhookTLSKey).StringVar(&cfg.AdmissionWebhookTLSKey)
+
+	// Flags related to HashiCorp Vault
+	app.Flag("vault-address", "The address of the HashiCorp Vault to read credentials from with --vault-secret, e.g. https://vault.example.org:8200 (optional)").Default(defaultConfig.VaultAddress).StringVar(&cfg.VaultAddress)
+	app.Flag("vault-auth-mount", "The mount path of the Kubernetes auth method of Vault (default: kubernetes)").Default(defaultConfig.VaultAuthMount).StringVar(&cfg.VaultAuthMount)
+	app.Flag("vault-role", "The role of the Kubernetes auth method of Vault to log in with; required with --vault-secret").Default(defaultConfig.VaultRole).StringVar(&cfg.VaultRole)
+	app.Flag("vault-service-account-token-path", "The path of the service account token to log in to Vault with (default: /var/run/secrets/kubernetes.io/serviceaccount/token)").Default(defaultConfig.VaultServiceAccountTokenPath).StringVar(&cfg.VaultServiceAccountTokenPath)
+	app.Flag("vault-ca-cert", "The CA certificate of Vault, the system roots are used if not set (optional)").Default(defaultConfig.VaultCACert).StringVar(&cfg.VaultCACert)
+	app.Flag("vault-secret", "Read a credential from Vault, as the name of its flag or environment variable and the secret key, e.g. pdns-api-key=secret/data/external-dns#pdns-api-key; specify multiple times for multiple credentials (optional)").StringMapVar(&cfg.VaultSecrets)
+
+	// Flags related to the credential plugin
+	app.Flag("credential-exec-command", "A command printing credentials as JSON, run at startup and again before the credentials expire (optional)").Default(defaultConfig.CredentialExecCommand).StringVar(&cfg.CredentialExecCommand)
+	app.Flag("credential-exec-arg", "An argument of --credential-exec-command; specify multiple times for multiple arguments (optional)").StringsVar(&cfg.CredentialExecArgs)
+	app.Flag("credential-exec-timeout", "The time --credential-exec-command may run before it is killed (default: 30s)").Default(defaultConfig.CredentialExecTimeout.String()).DurationVar(&cfg.CredentialExecTimeout)
+
+	addSecretFileFlags(app, cfg)
+
+	// Commands
+	app.Command(CommandRun, "Synchronize the DNS records with the sources (default)").Default()
+	diff := app.Command(CommandDiff, "Print the changes a synchronization would make to the DNS records and exit without applying them")
+	diff.Flag("output", "The format of the diff; text or json (default: text)").Short('o').Default("text").EnumVar(&cfg.DiffOutput, "text", "json")
+	app.Command(CommandValidate, "Check the combination of the flags, the access of the sources to their resources and the zones of the provider, print a report and exit with a non-zero code if any check failed")
+	zones := app.Command(CommandZones, "Print the zones the provider manages with the zone and domain filters and exit")
+	zones.Flag("output", "The format of the zones; text or json (default: text)").Short('o').Default("text").EnumVar(&cfg.ZonesOutput, "text", "json")
+	export := app.Command(CommandExport, "Print the records owned by this instance according to the registry and exit")
+	export.Flag("output", "The format of the records; table, json or zone for a zone file (default: table)").Short('o').Default("table").EnumVar(&cfg.ExportOutput, "table", "json", "zone")
+	rbac := app.Command(CommandRBAC, "Print the ClusterRole, Roles and bindings granting the permissions the sources and flags require and exit")
+	rbac.Flag("name", "The name of the roles and bindings (default: external-dns)").Default("external-dns").StringVar(&cfg.RBACName)
+	rbac.Flag("service-account", "The service account the roles are bound to, in namespace/name format (default: default/external-dns)").Default("default/external-dns").StringVar(&cfg.RBACServiceAccount)
+	app.Command(CommandVerifyAuditLog, "Verify the hash chain of the audit log written with --audit-log-chain, print the number of entries and the head, and exit with a non-zero code if entries were altered or removed")
+	savePlan := app.Command(CommandPlan, "Save the changes a synchronization would make to the DNS records to a file for review and exit without applying them")
+	savePlan.Flag("out", "The file the plan is saved to, or - for the standard output (default: -)").Default("-").StringVar(&cfg.PlanOut)
+	apply := app.Command(CommandApply, "Apply the changes of a plan saved by the plan command, if the records it changes are still as they were planned, and exit")
+	apply.Arg("plan-file", "The file of the plan").Required().StringVar(&cfg.PlanFile)
+	apply.Flag("max-age", "The age beyond which a plan is refused as stale; 0 to apply plans of any age (default: 1h)").Default("1h").DurationVar(&cfg.PlanMaxAge)
 
 	return app
 }
@@ -19,6 +19,7 @@ package externaldns
 import (
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -37,6 +38,7 @@ var (
 		GlooNamespaces:                         []string{"gloo-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v1",
 		Sources:                                []string{"service"},
+		SourceConcurrency:                      1,
 		Namespace:                              "",
 		FQDNTemplate:                           "",
 		Compatibility:                          "",
@@ -97,17 +99,33 @@ var (
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "",
 		Policy:                                        "sync",
+		ConflictResolution:                            "targets",
 		Registry:                                      "txt",
 		TXTOwnerID:                                    "default",
 		TXTPrefix:                                     "",
 		TXTCacheInterval:                              0,
 		Interval:                                      time.Minute,
 		MinEventSyncInterval:                          5 * time.Second,
+		EventDebounce:                                 5 * time.Second,
+		ZoneConcurrency:                               1,
+		ZoneListConcurrency:                           1,
+		AdmissionWebhookAddress:                       ":9443",
+		Command:                                       CommandRun,
+		ChangeRetryBackoff:                            time.Second,
+		FullResyncInterval:                            time.Hour,
+		ShardCount:                                    1,
 		Once:                                          false,
 		DryRun:                                        false,
 		UpdateEvents:                                  false,
 		LogFormat:                                     "text",
 		MetricsAddress:                                ":7979",
+		PushgatewayJob:                                "external-dns",
+		TracingSampleRatio:                            1,
+		AuditLogS3Prefix:                              "external-dns/audit/",
+		ApprovalNamespace:                             "default",
+		PublishKafkaTopic:                             "external-dns-changes",
+		PublishNATSSubject:                            "external-dns.changes",
+		NotificationFormat:                            "json",
 		LogLevel:                                      logrus.InfoLevel.String(),
 		ConnectorSourceServer:                         "localhost:8080",
 		ExoscaleAPIEnvironment:                        "api",
@@ -126,18 +144,35 @@ var (
 		OCPRouterName:                                 "default",
 		PiholeApiVersion:                              "5",
 		WebhookProviderURL:                            "http://localhost:8888",
+		WebhookProviderAllowedHosts:                   []string{"localhost", "127.0.0.0/8", "::1/128"},
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
 		ExcludeUnschedulable:                          true,
+		ResolveApexCNAME:                              true,
+		CNAMEFlatteningTTL:                            time.Minute,
+		AddressFamilyPolicy:                           "dual-stack",
+		WildcardPolicy:                                "allow",
+		TargetProbePort:                               443,
+		TargetProbeTimeout:                            time.Second,
+		WildcardPlaceholder:                           "wildcard",
+		VaultAuthMount:                                "kubernetes",
+		VaultServiceAccountTokenPath:                  "/var/run/secrets/kubernetes.io/serviceaccount/token",
+		VaultSecrets:                                  map[string]string{},
+		CredentialExecTimeout:                         30 * time.Second,
 	}
 
 	overriddenConfig = &Config{
 		APIServerURL:                           "http://127.0.0.1:8080",
 		KubeConfig:                             "/some/path",
 		RequestTimeout:                         time.Second * 77,
+		KubeAPIQPS:                             50,
+		KubeAPIBurst:                           100,
+		InformerResyncPeriod:                   10 * time.Minute,
 		GlooNamespaces:                         []string{"gloo-not-system", "gloo-second-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v2",
 		Sources:                                []string{"service", "ingress", "connector"},
+		SourceConcurrency:                      3,
+		SourceTimeout:                          20 * time.Second,
 		Namespace:                              "namespace",
 		IgnoreHostnameAnnotation:               true,
 		IgnoreNonHostNetworkPods:               true,
@@ -208,18 +243,93 @@ var (
 		TLSClientCert:                                 "/path/to/cert.pem",
 		TLSClientCertKey:                              "/path/to/key.pem",
 		PodSourceDomain:                               "example.org",
+		NodeZoneDomain:                                "nodes.example.org",
+		IstioEastWestDomain:                           "mesh.example.org",
+		PreferTargetType:                              "hostname",
 		Policy:                                        "upsert-only",
+		PolicyRules:                                   "/etc/external-dns/policy.yaml",
+		PolicyOPAURL:                                  "http://opa:8181/v1/data/externaldns/verdicts",
+		ApprovalThreshold:                             20,
+		ApprovalZones:                                 []string{"prod.example.com", "example.org"},
+		ApprovalNamespace:                             "external-dns",
+		ConflictResolution:                            "priority",
 		Registry:                                      "noop",
 		TXTOwnerID:                                    "owner-1",
+		SourceGroup:                                   "ingresses",
+		RegistryResourceLabels:                        []string{"team", "environment"},
 		TXTPrefix:                                     "associated-txt-record",
 		TXTCacheInterval:                              12 * time.Hour,
 		Interval:                                      10 * time.Minute,
 		MinEventSyncInterval:                          50 * time.Second,
+		EventDebounce:                                 10 * time.Second,
+		EventJitter:                                   2 * time.Second,
+		EventMaxBackoff:                               5 * time.Minute,
+		EventCoalesceWindow:                           20 * time.Second,
+		DrainTimeout:                                  20 * time.Second,
+		SyncTimeout:                                   5 * time.Minute,
+		ReadyMaxSyncAge:                               time.Hour,
+		ChangeRetries:                                 3,
+		ChangeRetryBackoff:                            2 * time.Second,
+		IncrementalSync:                               true,
+		FullResyncInterval:                            30 * time.Minute,
+		FastSyncInterval:                              10 * time.Second,
+		FastSyncResources:                             []string{"crd/cert-manager/*", "ingress/*/*"},
+		StreamRecords:                                 true,
+		FinalSync:                                     true,
+		Paused:                                        true,
 		Once:                                          true,
+		ValidateOnly:                                  true,
+		DetailedExitCode:                              true,
 		DryRun:                                        true,
 		UpdateEvents:                                  true,
+		ZoneBatching:                                  true,
+		ZoneConcurrency:                               4,
+		MaxChangesPerZonePerSync:                      50,
+		ZoneListConcurrency:                           8,
+		ZoneChangeTokens:                              true,
+		StatusAnnotation:                              true,
+		InventoryConfigMap:                            "external-dns/records",
+		NamespaceRecordsConfigMap:                     "dns-records",
+		StatusObject:                                  "external-dns",
+		AuditLog:                                      "/var/log/external-dns/audit.log",
+		AuditLogChain:                                 true,
+		AuditLogS3Bucket:                              "dns-audit",
+		AuditLogS3Prefix:                              "cluster-1/",
+		PublishKafkaBrokers:                           []string{"kafka-0:9092", "kafka-1:9092"},
+		PublishKafkaTopic:                             "dns-changes",
+		PublishNATSURL:                                "nats://nats:4222",
+		PublishNATSSubject:                            "dns.changes",
+		PublishCloudEventsSink:                        "http://broker-ingress.knative-eventing/default/default",
+		NotificationURL:                               "https://hooks.slack.com/services/T000/B000/XXXX",
+		NotificationFormat:                            "slack",
+		NotificationTemplate:                          "/etc/external-dns/notification.tmpl",
+		AdmissionWebhook:                              true,
+		AdmissionWebhookAddress:                       ":8443",
+		AdmissionWebhookTLSCert:                       "/tls/tls.crt",
+		AdmissionWebhookTLSKey:                        "/tls/tls.key",
+		Command:                                       CommandRun,
+		ShardIndex:                                    1,
+		GeoCountry:                                    "US",
+		GeoRegion:                                     "CA",
+		Federated:                                     true,
+		ShardCount:                                    3,
 		LogFormat:                                     "json",
+		FIPS:                                          true,
 		MetricsAddress:                                "127.0.0.1:9099",
+		MetricsTLSCert:                                "/tls/metrics.crt",
+		MetricsTLSKey:                                 "/tls/metrics.key",
+		MetricsTLSClientCA:                            "/tls/ca.crt",
+		PushgatewayURL:                                "http://pushgateway:9091",
+		PushgatewayJob:                                "external-dns-cron",
+		TracingEndpoint:                               "otel-collector:4317",
+		TracingInsecure:                               true,
+		TracingSampleRatio:                            0.25,
+		APIToken:                                      "api-token",
+		APIWriteToken:                                 "api-write-token",
+		APITokenAudience:                              "external-dns",
+		APIServiceAccounts:                            []string{"monitoring/api-reader", "ci/deployer"},
+		APIWriteServiceAccounts:                       []string{"ops/on-call"},
+		GRPCAddress:                                   ":7980",
 		LogLevel:                                      logrus.DebugLevel.String(),
 		ConnectorSourceServer:                         "localhost:8081",
 		ExoscaleAPIEnvironment:                        "api1",
@@ -239,9 +349,30 @@ var (
 		RFC2136LoadBalancingStrategy:                  "round-robin",
 		PiholeApiVersion:                              "6",
 		WebhookProviderURL:                            "http://localhost:8888",
+		WebhookProviderAllowedHosts:                   []string{"localhost", "*.webhooks.svc.cluster.local"},
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
+		InformerFullObjects:                           []string{"node", "pod"},
 		ExcludeUnschedulable:                          false,
+		ResolveApexCNAME:                              false,
+		FlattenCoexistingCNAMEs:                       true,
+		CNAMEFlatteningTTL:                            30 * time.Second,
+		AddressFamilyPolicy:                           "ipv6-first",
+		WildcardPolicy:                                "replace",
+		TargetProbe:                                   "tcp",
+		TargetProbePort:                               80,
+		TargetProbeTimeout:                            500 * time.Millisecond,
+		NamespaceDomainsConfigMap:                     "external-dns/namespace-domains",
+		WildcardPlaceholder:                           "any",
+		VaultAddress:                                  "https://vault.example.org:8200",
+		VaultAuthMount:                                "k8s",
+		VaultRole:                                     "external-dns",
+		VaultServiceAccountTokenPath:                  "/token",
+		VaultCACert:                                   "/vault/ca.crt",
+		VaultSecrets:                                  map[string]string{"CF_API_TOKEN": "secret/data/dns#token", "txt-encrypt-aes-key": "secret/data/dns#aes"},
+		CredentialExecCommand:                         "/bin/get-credentials",
+		CredentialExecArgs:                            []string{"--audience", "dns"},
+		CredentialExecTimeout:                         10 * time.Second,
 	}
 )
 
@@ -268,12 +399,17 @@ func TestParseFlags(t *testing.T) {
 				"--server=http://127.0.0.1:8080",
 				"--kubeconfig=/some/path",
 				"--request-timeout=77s",
+				"--kube-api-qps=50",
+				"--kube-api-burst=100",
+				"--informer-resync-period=10m",
 				"--gloo-namespace=gloo-not-system",
 				"--gloo-namespace=gloo-second-system",
 				"--skipper-routegroup-groupversion=zalando.org/v2",
 				"--source=service",
 				"--source=ingress",
 				"--source=connector",
+				"--source-concurrency=3",
+				"--source-timeout=20s",
 				"--namespace=namespace",
 				"--fqdn-template={{.Name}}.service.example.com",
 				"--ignore-non-host-network-pods",
@@ -319,6 +455,9 @@ func TestParseFlags(t *testing.T) {
 				"--tls-client-cert=/path/to/cert.pem",
 				"--tls-client-cert-key=/path/to/key.pem",
 				"--pod-source-domain=example.org",
+				"--node-zone-domain=nodes.example.org",
+				"--istio-east-west-domain=mesh.example.org",
+				"--prefer-target-type=hostname",
 				"--domain-filter=example.org",
 				"--domain-filter=company.com",
 				"--exclude-domains=xapi.example.org",
@@ -352,19 +491,97 @@ func TestParseFlags(t *testing.T) {
 				"--aws-sd-create-tag=key2=value2",
 				"--no-aws-evaluate-target-health",
 				"--pihole-api-version=6",
+				"--webhook-provider-allowed-hosts=localhost",
+				"--webhook-provider-allowed-hosts=*.webhooks.svc.cluster.local",
 				"--policy=upsert-only",
+				"--policy-rules=/etc/external-dns/policy.yaml",
+				"--policy-opa-url=http://opa:8181/v1/data/externaldns/verdicts",
+				"--approval-threshold=20",
+				"--approval-zones=prod.example.com",
+				"--approval-zones=example.org",
+				"--approval-namespace=external-dns",
+				"--conflict-resolution=priority",
 				"--registry=noop",
 				"--txt-owner-id=owner-1",
+				"--source-group=ingresses",
+				"--registry-resource-labels=team",
+				"--registry-resource-labels=environment",
 				"--txt-prefix=associated-txt-record",
 				"--txt-cache-interval=12h",
 				"--dynamodb-table=custom-table",
 				"--interval=10m",
 				"--min-event-sync-interval=50s",
+				"--events-debounce=10s",
+				"--events-jitter=2s",
+				"--events-max-backoff=5m",
+				"--events-coalesce-window=20s",
+				"--drain-timeout=20s",
+				"--sync-timeout=5m",
+				"--ready-max-sync-age=1h",
+				"--change-retries=3",
+				"--change-retry-backoff=2s",
+				"--incremental-sync",
+				"--full-resync-interval=30m",
+				"--fast-sync-interval=10s",
+				"--fast-sync-resource=crd/cert-manager/*",
+				"--fast-sync-resource=ingress/*/*",
+				"--stream-records",
+				"--final-sync",
+				"--paused",
+				"--shard-index=1",
+				"--geo-country=US",
+				"--geo-region=CA",
+				"--federated",
+				"--shard-count=3",
 				"--once",
+				"--validate-only",
+				"--detailed-exit-code",
 				"--dry-run",
 				"--events",
+				"--zone-batching",
+				"--status-annotation",
+				"--inventory-configmap=external-dns/records",
+				"--namespace-records-configmap=dns-records",
+				"--status-object=external-dns",
+				"--audit-log=/var/log/external-dns/audit.log",
+				"--audit-log-chain",
+				"--audit-log-s3-bucket=dns-audit",
+				"--audit-log-s3-prefix=cluster-1/",
+				"--publish-kafka-brokers=kafka-0:9092",
+				"--publish-kafka-brokers=kafka-1:9092",
+				"--publish-kafka-topic=dns-changes",
+				"--publish-nats-url=nats://nats:4222",
+				"--publish-nats-subject=dns.changes",
+				"--publish-cloudevents-sink=http://broker-ingress.knative-eventing/default/default",
+				"--notification-url=https://hooks.slack.com/services/T000/B000/XXXX",
+				"--notification-format=slack",
+				"--notification-template=/etc/external-dns/notification.tmpl",
+				"--admission-webhook",
+				"--admission-webhook-address=:8443",
+				"--admission-webhook-tls-cert=/tls/tls.crt",
+				"--admission-webhook-tls-key=/tls/tls.key",
+				"--zone-concurrency=4",
+				"--max-changes-per-zone-per-sync=50",
+				"--zone-list-concurrency=8",
+				"--zone-change-tokens",
 				"--log-format=json",
+				"--fips",
 				"--metrics-address=127.0.0.1:9099",
+				"--metrics-tls-cert=/tls/metrics.crt",
+				"--metrics-tls-key=/tls/metrics.key",
+				"--metrics-tls-client-ca=/tls/ca.crt",
+				"--pushgateway-url=http://pushgateway:9091",
+				"--pushgateway-job=external-dns-cron",
+				"--tracing-endpoint=otel-collector:4317",
+				"--tracing-insecure",
+				"--tracing-sample-ratio=0.25",
+				"--api-token=api-token",
+				"--api-write-token=api-write-token",
+				"--api-token-audience=external-dns",
+				"--api-service-account=monitoring/api-reader",
+				"--api-service-account=ci/deployer",
+				"--api-write-service-account=ops/on-call",
+				"--grpc-address=:7980",
 				"--log-level=debug",
 				"--connector-source-server=localhost:8081",
 				"--exoscale-apienv=api1",
@@ -383,6 +600,29 @@ func TestParseFlags(t *testing.T) {
 				"--managed-record-types=CNAME",
 				"--managed-record-types=NS",
 				"--no-exclude-unschedulable",
+				"--informer-full-objects=node",
+				"--informer-full-objects=pod",
+				"--no-resolve-apex-cname",
+				"--flatten-coexisting-cnames",
+				"--cname-flattening-ttl=30s",
+				"--address-family-policy=ipv6-first",
+				"--wildcard-policy=replace",
+				"--wildcard-placeholder=any",
+				"--vault-address=https://vault.example.org:8200",
+				"--vault-auth-mount=k8s",
+				"--vault-role=external-dns",
+				"--vault-service-account-token-path=/token",
+				"--vault-ca-cert=/vault/ca.crt",
+				"--vault-secret=CF_API_TOKEN=secret/data/dns#token",
+				"--vault-secret=txt-encrypt-aes-key=secret/data/dns#aes",
+				"--credential-exec-command=/bin/get-credentials",
+				"--credential-exec-arg=--audience",
+				"--credential-exec-arg=dns",
+				"--credential-exec-timeout=10s",
+				"--target-probe=tcp",
+				"--target-probe-port=80",
+				"--target-probe-timeout=500ms",
+				"--namespace-domains-configmap=external-dns/namespace-domains",
 				"--rfc2136-batch-change-size=100",
 				"--rfc2136-load-balancing-strategy=round-robin",
 				"--rfc2136-host=rfc2136-host1",
@@ -398,10 +638,15 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_SERVER":                                            "http://127.0.0.1:8080",
 				"EXTERNAL_DNS_KUBECONFIG":                                        "/some/path",
 				"EXTERNAL_DNS_REQUEST_TIMEOUT":                                   "77s",
+				"EXTERNAL_DNS_KUBE_API_QPS":                                      "50",
+				"EXTERNAL_DNS_KUBE_API_BURST":                                    "100",
+				"EXTERNAL_DNS_INFORMER_RESYNC_PERIOD":                            "10m",
 				"EXTERNAL_DNS_CONTOUR_LOAD_BALANCER":                             "heptio-contour-other/contour-other",
 				"EXTERNAL_DNS_GLOO_NAMESPACE":                                    "gloo-not-system\ngloo-second-system",
 				"EXTERNAL_DNS_SKIPPER_ROUTEGROUP_GROUPVERSION":                   "zalando.org/v2",
 				"EXTERNAL_DNS_SOURCE":                                            "service\ningress\nconnector",
+				"EXTERNAL_DNS_SOURCE_CONCURRENCY":                                "3",
+				"EXTERNAL_DNS_SOURCE_TIMEOUT":                                    "20s",
 				"EXTERNAL_DNS_NAMESPACE":                                         "namespace",
 				"EXTERNAL_DNS_FQDN_TEMPLATE":                                     "{{.Name}}.service.example.com",
 				"EXTERNAL_DNS_IGNORE_NON_HOST_NETWORK_PODS":                      "1",
@@ -439,6 +684,9 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_OVH_ENDPOINT":                                      "ovh-ca",
 				"EXTERNAL_DNS_OVH_API_RATE_LIMIT":                                "42",
 				"EXTERNAL_DNS_POD_SOURCE_DOMAIN":                                 "example.org",
+				"EXTERNAL_DNS_NODE_ZONE_DOMAIN":                                  "nodes.example.org",
+				"EXTERNAL_DNS_ISTIO_EAST_WEST_DOMAIN":                            "mesh.example.org",
+				"EXTERNAL_DNS_PREFER_TARGET_TYPE":                                "hostname",
 				"EXTERNAL_DNS_DOMAIN_FILTER":                                     "example.org\ncompany.com",
 				"EXTERNAL_DNS_EXCLUDE_DOMAINS":                                   "xapi.example.org\nxapi.company.com",
 				"EXTERNAL_DNS_REGEX_DOMAIN_FILTER":                               "(example\\.org|company\\.com)$",
@@ -473,19 +721,91 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_AWS_SD_CREATE_TAG":                                 "key1=value1\nkey2=value2",
 				"EXTERNAL_DNS_DYNAMODB_TABLE":                                    "custom-table",
 				"EXTERNAL_DNS_PIHOLE_API_VERSION":                                "6",
+				"EXTERNAL_DNS_WEBHOOK_PROVIDER_ALLOWED_HOSTS":                    "localhost\n*.webhooks.svc.cluster.local",
 				"EXTERNAL_DNS_POLICY":                                            "upsert-only",
+				"EXTERNAL_DNS_POLICY_RULES":                                      "/etc/external-dns/policy.yaml",
+				"EXTERNAL_DNS_POLICY_OPA_URL":                                    "http://opa:8181/v1/data/externaldns/verdicts",
+				"EXTERNAL_DNS_APPROVAL_THRESHOLD":                                "20",
+				"EXTERNAL_DNS_APPROVAL_ZONES":                                    "prod.example.com\nexample.org",
+				"EXTERNAL_DNS_APPROVAL_NAMESPACE":                                "external-dns",
+				"EXTERNAL_DNS_CONFLICT_RESOLUTION":                               "priority",
 				"EXTERNAL_DNS_REGISTRY":                                          "noop",
 				"EXTERNAL_DNS_TXT_OWNER_ID":                                      "owner-1",
+				"EXTERNAL_DNS_SOURCE_GROUP":                                      "ingresses",
+				"EXTERNAL_DNS_REGISTRY_RESOURCE_LABELS":                          "team\nenvironment",
 				"EXTERNAL_DNS_TXT_PREFIX":                                        "associated-txt-record",
 				"EXTERNAL_DNS_TXT_CACHE_INTERVAL":                                "12h",
 				"EXTERNAL_DNS_TXT_NEW_FORMAT_ONLY":                               "1",
 				"EXTERNAL_DNS_INTERVAL":                                          "10m",
 				"EXTERNAL_DNS_MIN_EVENT_SYNC_INTERVAL":                           "50s",
+				"EXTERNAL_DNS_EVENTS_DEBOUNCE":                                   "10s",
+				"EXTERNAL_DNS_EVENTS_JITTER":                                     "2s",
+				"EXTERNAL_DNS_EVENTS_MAX_BACKOFF":                                "5m",
+				"EXTERNAL_DNS_EVENTS_COALESCE_WINDOW":                            "20s",
+				"EXTERNAL_DNS_DRAIN_TIMEOUT":                                     "20s",
+				"EXTERNAL_DNS_SYNC_TIMEOUT":                                      "5m",
+				"EXTERNAL_DNS_READY_MAX_SYNC_AGE":                                "1h",
+				"EXTERNAL_DNS_CHANGE_RETRIES":                                    "3",
+				"EXTERNAL_DNS_CHANGE_RETRY_BACKOFF":                              "2s",
+				"EXTERNAL_DNS_INCREMENTAL_SYNC":                                  "1",
+				"EXTERNAL_DNS_FULL_RESYNC_INTERVAL":                              "30m",
+				"EXTERNAL_DNS_FAST_SYNC_INTERVAL":                                "10s",
+				"EXTERNAL_DNS_FAST_SYNC_RESOURCE":                                "crd/cert-manager/*\ningress/*/*",
+				"EXTERNAL_DNS_STREAM_RECORDS":                                    "1",
+				"EXTERNAL_DNS_FINAL_SYNC":                                        "1",
+				"EXTERNAL_DNS_PAUSED":                                            "1",
+				"EXTERNAL_DNS_SHARD_INDEX":                                       "1",
+				"EXTERNAL_DNS_GEO_COUNTRY":                                       "US",
+				"EXTERNAL_DNS_GEO_REGION":                                        "CA",
+				"EXTERNAL_DNS_FEDERATED":                                         "1",
+				"EXTERNAL_DNS_SHARD_COUNT":                                       "3",
 				"EXTERNAL_DNS_ONCE":                                              "1",
+				"EXTERNAL_DNS_VALIDATE_ONLY":                                     "1",
+				"EXTERNAL_DNS_DETAILED_EXIT_CODE":                                "1",
 				"EXTERNAL_DNS_DRY_RUN":                                           "1",
 				"EXTERNAL_DNS_EVENTS":                                            "1",
+				"EXTERNAL_DNS_ZONE_BATCHING":                                     "1",
+				"EXTERNAL_DNS_STATUS_ANNOTATION":                                 "1",
+				"EXTERNAL_DNS_INVENTORY_CONFIGMAP":                               "external-dns/records",
+				"EXTERNAL_DNS_NAMESPACE_RECORDS_CONFIGMAP":                       "dns-records",
+				"EXTERNAL_DNS_STATUS_OBJECT":                                     "external-dns",
+				"EXTERNAL_DNS_AUDIT_LOG":                                         "/var/log/external-dns/audit.log",
+				"EXTERNAL_DNS_AUDIT_LOG_CHAIN":                                   "1",
+				"EXTERNAL_DNS_AUDIT_LOG_S3_BUCKET":                               "dns-audit",
+				"EXTERNAL_DNS_AUDIT_LOG_S3_PREFIX":                               "cluster-1/",
+				"EXTERNAL_DNS_PUBLISH_KAFKA_BROKERS":                             "kafka-0:9092\nkafka-1:9092",
+				"EXTERNAL_DNS_PUBLISH_KAFKA_TOPIC":                               "dns-changes",
+				"EXTERNAL_DNS_PUBLISH_NATS_URL":                                  "nats://nats:4222",
+				"EXTERNAL_DNS_PUBLISH_NATS_SUBJECT":                              "dns.changes",
+				"EXTERNAL_DNS_PUBLISH_CLOUDEVENTS_SINK":                          "http://broker-ingress.knative-eventing/default/default",
+				"EXTERNAL_DNS_NOTIFICATION_URL":                                  "https://hooks.slack.com/services/T000/B000/XXXX",
+				"EXTERNAL_DNS_NOTIFICATION_FORMAT":                               "slack",
+				"EXTERNAL_DNS_NOTIFICATION_TEMPLATE":                             "/etc/external-dns/notification.tmpl",
+				"EXTERNAL_DNS_ADMISSION_WEBHOOK":                                 "1",
+				"EXTERNAL_DNS_ADMISSION_WEBHOOK_ADDRESS":                         ":8443",
+				"EXTERNAL_DNS_ADMISSION_WEBHOOK_TLS_CERT":                        "/tls/tls.crt",
+				"EXTERNAL_DNS_ADMISSION_WEBHOOK_TLS_KEY":                         "/tls/tls.key",
+				"EXTERNAL_DNS_ZONE_CONCURRENCY":                                  "4",
+				"EXTERNAL_DNS_MAX_CHANGES_PER_ZONE_PER_SYNC":                     "50",
+				"EXTERNAL_DNS_ZONE_LIST_CONCURRENCY":                             "8",
+				"EXTERNAL_DNS_ZONE_CHANGE_TOKENS":                                "1",
 				"EXTERNAL_DNS_LOG_FORMAT":                                        "json",
+				"EXTERNAL_DNS_FIPS":                                              "1",
 				"EXTERNAL_DNS_METRICS_ADDRESS":                                   "127.0.0.1:9099",
+				"EXTERNAL_DNS_METRICS_TLS_CERT":                                  "/tls/metrics.crt",
+				"EXTERNAL_DNS_METRICS_TLS_KEY":                                   "/tls/metrics.key",
+				"EXTERNAL_DNS_METRICS_TLS_CLIENT_CA":                             "/tls/ca.crt",
+				"EXTERNAL_DNS_PUSHGATEWAY_URL":                                   "http://pushgateway:9091",
+				"EXTERNAL_DNS_PUSHGATEWAY_JOB":                                   "external-dns-cron",
+				"EXTERNAL_DNS_TRACING_ENDPOINT":                                  "otel-collector:4317",
+				"EXTERNAL_DNS_TRACING_INSECURE":                                  "1",
+				"EXTERNAL_DNS_TRACING_SAMPLE_RATIO":                              "0.25",
+				"EXTERNAL_DNS_API_TOKEN":                                         "api-token",
+				"EXTERNAL_DNS_API_WRITE_TOKEN":                                   "api-write-token",
+				"EXTERNAL_DNS_API_TOKEN_AUDIENCE":                                "external-dns",
+				"EXTERNAL_DNS_API_SERVICE_ACCOUNT":                               "monitoring/api-reader\nci/deployer",
+				"EXTERNAL_DNS_API_WRITE_SERVICE_ACCOUNT":                         "ops/on-call",
+				"EXTERNAL_DNS_GRPC_ADDRESS":                                      ":7980",
 				"EXTERNAL_DNS_LOG_LEVEL":                                         "debug",
 				"EXTERNAL_DNS_CONNECTOR_SOURCE_SERVER":                           "localhost:8081",
 				"EXTERNAL_DNS_EXOSCALE_APIENV":                                   "api1",
@@ -501,6 +821,26 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_DIGITALOCEAN_API_PAGE_SIZE":                        "100",
 				"EXTERNAL_DNS_MANAGED_RECORD_TYPES":                              "A\nAAAA\nCNAME\nNS",
 				"EXTERNAL_DNS_EXCLUDE_UNSCHEDULABLE":                             "false",
+				"EXTERNAL_DNS_INFORMER_FULL_OBJECTS":                             "node\npod",
+				"EXTERNAL_DNS_RESOLVE_APEX_CNAME":                                "false",
+				"EXTERNAL_DNS_FLATTEN_COEXISTING_CNAMES":                         "1",
+				"EXTERNAL_DNS_CNAME_FLATTENING_TTL":                              "30s",
+				"EXTERNAL_DNS_ADDRESS_FAMILY_POLICY":                             "ipv6-first",
+				"EXTERNAL_DNS_WILDCARD_POLICY":                                   "replace",
+				"EXTERNAL_DNS_WILDCARD_PLACEHOLDER":                              "any",
+				"EXTERNAL_DNS_VAULT_ADDRESS":                                     "https://vault.example.org:8200",
+				"EXTERNAL_DNS_VAULT_AUTH_MOUNT":                                  "k8s",
+				"EXTERNAL_DNS_VAULT_ROLE":                                        "external-dns",
+				"EXTERNAL_DNS_VAULT_SERVICE_ACCOUNT_TOKEN_PATH":                  "/token",
+				"EXTERNAL_DNS_VAULT_CA_CERT":                                     "/vault/ca.crt",
+				"EXTERNAL_DNS_VAULT_SECRET":                                      "CF_API_TOKEN=secret/data/dns#token\ntxt-encrypt-aes-key=secret/data/dns#aes",
+				"EXTERNAL_DNS_CREDENTIAL_EXEC_COMMAND":                           "/bin/get-credentials",
+				"EXTERNAL_DNS_CREDENTIAL_EXEC_ARG":                               "--audience\ndns",
+				"EXTERNAL_DNS_CREDENTIAL_EXEC_TIMEOUT":                           "10s",
+				"EXTERNAL_DNS_TARGET_PROBE":                                      "tcp",
+				"EXTERNAL_DNS_TARGET_PROBE_PORT":                                 "80",
+				"EXTERNAL_DNS_TARGET_PROBE_TIMEOUT":                              "500ms",
+				"EXTERNAL_DNS_NAMESPACE_DOMAINS_CONFIGMAP":                       "external-dns/namespace-domains",
 				"EXTERNAL_DNS_RFC2136_BATCH_CHANGE_SIZE":                         "100",
 				"EXTERNAL_DNS_RFC2136_LOAD_BALANCING_STRATEGY":                   "round-robin",
 				"EXTERNAL_DNS_RFC2136_HOST":                                      "rfc2136-host1\nrfc2136-host2",
@@ -563,6 +903,58 @@ func TestParseFlagsDefaultKingpin(t *testing.T) {
 	assert.Equal(t, "default", cfg.OCPRouterName)
 }
 
+func TestParseFlagsCommands(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_CLI", "")
+
+	for _, tt := range []struct {
+		args               []string
+		expectCommand      string
+		expectOutput       string
+		expectZonesOutput  string
+		expectExportOutput string
+		expectRBAC         [2]string
+		expectPlan         [2]string
+		expectPlanMaxAge   time.Duration
+		expectParseErr     bool
+	}{
+		{args: []string{"--provider=aws", "--source=service"}, expectCommand: CommandRun},
+		{args: []string{"run", "--provider=aws", "--source=service"}, expectCommand: CommandRun},
+		{args: []string{"diff", "--provider=aws", "--source=service"}, expectCommand: CommandDiff, expectOutput: "text"},
+		{args: []string{"--provider=aws", "diff", "-o", "json", "--source=service"}, expectCommand: CommandDiff, expectOutput: "json"},
+		{args: []string{"diff", "--output=yaml", "--provider=aws", "--source=service"}, expectParseErr: true},
+		{args: []string{"validate", "--provider=aws", "--source=service"}, expectCommand: CommandValidate},
+		{args: []string{"zones", "--provider=aws", "--source=service"}, expectCommand: CommandZones, expectZonesOutput: "text"},
+		{args: []string{"zones", "-o", "json", "--provider=aws", "--source=service"}, expectCommand: CommandZones, expectZonesOutput: "json"},
+		{args: []string{"export", "--provider=aws", "--source=service"}, expectCommand: CommandExport, expectExportOutput: "table"},
+		{args: []string{"export", "--output=zone", "--provider=aws", "--source=service"}, expectCommand: CommandExport, expectExportOutput: "zone"},
+		{args: []string{"export", "--output=text", "--provider=aws", "--source=service"}, expectParseErr: true},
+		{args: []string{"rbac", "--provider=aws", "--source=service"}, expectCommand: CommandRBAC, expectRBAC: [2]string{"external-dns", "default/external-dns"}},
+		{args: []string{"rbac", "--name=dns", "--service-account=dns/controller", "--provider=aws", "--source=service"}, expectCommand: CommandRBAC, expectRBAC: [2]string{"dns", "dns/controller"}},
+		{args: []string{"plan", "--provider=aws", "--source=service"}, expectCommand: CommandPlan, expectPlan: [2]string{"-", ""}},
+		{args: []string{"plan", "--out=plan.json", "--provider=aws", "--source=service"}, expectCommand: CommandPlan, expectPlan: [2]string{"plan.json", ""}},
+		{args: []string{"apply", "plan.json", "--provider=aws", "--source=service"}, expectCommand: CommandApply, expectPlan: [2]string{"", "plan.json"}, expectPlanMaxAge: time.Hour},
+		{args: []string{"apply", "--max-age=0", "plan.json", "--provider=aws", "--source=service"}, expectCommand: CommandApply, expectPlan: [2]string{"", "plan.json"}},
+		{args: []string{"apply", "--provider=aws", "--source=service"}, expectParseErr: true},
+	} {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			cfg := NewConfig()
+			err := cfg.ParseFlags(tt.args)
+			if tt.expectParseErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectCommand, cfg.Command)
+			assert.Equal(t, tt.expectOutput, cfg.DiffOutput)
+			assert.Equal(t, tt.expectZonesOutput, cfg.ZonesOutput)
+			assert.Equal(t, tt.expectExportOutput, cfg.ExportOutput)
+			assert.Equal(t, tt.expectRBAC, [2]string{cfg.RBACName, cfg.RBACServiceAccount})
+			assert.Equal(t, tt.expectPlan, [2]string{cfg.PlanOut, cfg.PlanFile})
+			assert.Equal(t, tt.expectPlanMaxAge, cfg.PlanMaxAge)
+		})
+	}
+}
+
 // When EXTERNAL_DNS_CLI=cobra is set, cobra path should parse the subset of
 // flags it currently binds, yielding parity with kingpin for those fields.
 func TestParseFlagsCobraSwitchParitySubset(t *testing.T) {
@@ -717,10 +1109,14 @@ func TestPasswordsNotLogged(t *testing.T) {
 	cfg := Config{
 		PDNSAPIKey:        "pdns-api-key",
 		RFC2136TSIGSecret: "tsig-secret",
+		APIToken:          "api-token",
+		APIWriteToken:     "api-write-token",
 	}
 
 	s := cfg.String()
 
 	assert.NotContains(t, s, "pdns-api-key")
 	assert.NotContains(t, s, "tsig-secret")
+	assert.NotContains(t, s, "api-token")
+	assert.NotContains(t, s, "api-write-token")
 }
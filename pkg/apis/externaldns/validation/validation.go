@@ -17,11 +17,18 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/fips140"
 	"errors"
 	"fmt"
+	"net/url"
+	"path"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/labels"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/logging"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 )
 
@@ -45,6 +52,227 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
+	if cfg.SourceGroup != "" && cfg.Registry == "noop" {
+		return errors.New("--source-group requires a registry keeping track of ownership")
+	}
+
+	if len(cfg.RegistryResourceLabels) > 0 && cfg.Registry != "txt" && cfg.Registry != "dynamodb" {
+		return errors.New("--registry-resource-labels requires the txt or dynamodb registry")
+	}
+	for _, key := range cfg.RegistryResourceLabels {
+		if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid --registry-resource-labels %q: %s", key, strings.Join(errs, ", "))
+		}
+	}
+
+	if cfg.Command == externaldns.CommandExport && cfg.Registry == "noop" {
+		return errors.New("the export command requires a registry keeping track of ownership")
+	}
+
+	if cfg.Command == externaldns.CommandRBAC {
+		if namespace, name, ok := strings.Cut(cfg.RBACServiceAccount, "/"); !ok || namespace == "" || name == "" {
+			return errors.New("--service-account must be in namespace/name format")
+		}
+	}
+
+	if cfg.InventoryConfigMap != "" {
+		if namespace, name, ok := strings.Cut(cfg.InventoryConfigMap, "/"); !ok || namespace == "" || name == "" {
+			return errors.New("--inventory-configmap must be in namespace/name format")
+		}
+	}
+
+	if cfg.NamespaceRecordsConfigMap != "" {
+		if errs := k8svalidation.IsDNS1123Subdomain(cfg.NamespaceRecordsConfigMap); len(errs) > 0 {
+			return fmt.Errorf("invalid --namespace-records-configmap %q: %s", cfg.NamespaceRecordsConfigMap, strings.Join(errs, ", "))
+		}
+	}
+
+	if cfg.StatusObject != "" {
+		if errs := k8svalidation.IsDNS1123Subdomain(cfg.StatusObject); len(errs) > 0 {
+			return fmt.Errorf("invalid --status-object %q: %s", cfg.StatusObject, strings.Join(errs, ", "))
+		}
+	}
+
+	if cfg.IstioEastWestDomain != "" {
+		if errs := k8svalidation.IsDNS1123Subdomain(strings.Trim(cfg.IstioEastWestDomain, ".")); len(errs) > 0 {
+			return fmt.Errorf("invalid --istio-east-west-domain %q: %s", cfg.IstioEastWestDomain, strings.Join(errs, ", "))
+		}
+	}
+
+	if cfg.AuditLogS3Bucket != "" {
+		if !cfg.AuditLogChain {
+			return errors.New("--audit-log-s3-bucket requires --audit-log-chain")
+		}
+		if cfg.AuditLog != "" {
+			return errors.New("--audit-log and --audit-log-s3-bucket are mutually exclusive")
+		}
+	} else if (cfg.AuditLogChain || cfg.Command == externaldns.CommandVerifyAuditLog) && (cfg.AuditLog == "" || cfg.AuditLog == "-") {
+		return errors.New("--audit-log-chain and verify-audit-log require --audit-log to be a file, or --audit-log-s3-bucket")
+	}
+
+	if cfg.NamespaceDomainsConfigMap != "" {
+		if namespace, name, ok := strings.Cut(cfg.NamespaceDomainsConfigMap, "/"); !ok || namespace == "" || name == "" {
+			return errors.New("--namespace-domains-configmap must be in namespace/name format")
+		}
+	}
+
+	if cfg.MaxChangesPerZonePerSync < 0 {
+		return errors.New("--max-changes-per-zone-per-sync must not be negative")
+	}
+
+	if cfg.ApprovalThreshold < 0 {
+		return errors.New("--approval-threshold must not be negative")
+	}
+	if (cfg.ApprovalThreshold > 0 || len(cfg.ApprovalZones) > 0) && cfg.ApprovalNamespace == "" {
+		return errors.New("--approval-threshold and --approval-zones require --approval-namespace")
+	}
+
+	if _, err := logging.ParseLevels(cfg.LogLevel); err != nil {
+		return fmt.Errorf("invalid --log-level: %w", err)
+	}
+
+	if cfg.PushgatewayURL != "" && !cfg.Once {
+		return errors.New("--pushgateway-url requires --once")
+	}
+
+	publishers := 0
+	for _, set := range []bool{len(cfg.PublishKafkaBrokers) > 0, cfg.PublishNATSURL != "", cfg.PublishCloudEventsSink != ""} {
+		if set {
+			publishers++
+		}
+	}
+	if publishers > 1 {
+		return errors.New("--publish-kafka-brokers, --publish-nats-url and --publish-cloudevents-sink are mutually exclusive")
+	}
+
+	if cfg.PublishCloudEventsSink != "" {
+		if u, err := url.Parse(cfg.PublishCloudEventsSink); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("--publish-cloudevents-sink must be an http or https URL")
+		}
+	}
+
+	if cfg.PolicyOPAURL != "" {
+		if u, err := url.Parse(cfg.PolicyOPAURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("--policy-opa-url must be an http or https URL")
+		}
+	}
+
+	if cfg.TracingSampleRatio < 0 || cfg.TracingSampleRatio > 1 {
+		return errors.New("--tracing-sample-ratio must be between 0 and 1")
+	}
+
+	if cfg.ShardCount < 0 {
+		return errors.New("--shard-count must not be negative")
+	}
+	if cfg.ShardIndex < 0 || (cfg.ShardIndex > 0 && cfg.ShardIndex >= cfg.ShardCount) {
+		return fmt.Errorf("--shard-index must be lower than --shard-count (%d)", cfg.ShardCount)
+	}
+
+	if cfg.KubeAPIQPS < 0 || cfg.KubeAPIBurst < 0 {
+		return errors.New("--kube-api-qps and --kube-api-burst must not be negative")
+	}
+
+	if cfg.FinalSync && cfg.DrainTimeout <= 0 {
+		return errors.New("--final-sync requires --drain-timeout")
+	}
+
+	if cfg.FastSyncInterval < 0 {
+		return errors.New("--fast-sync-interval must not be negative")
+	}
+	if len(cfg.FastSyncResources) > 0 && cfg.FastSyncInterval == 0 {
+		return errors.New("--fast-sync-resource requires --fast-sync-interval")
+	}
+	for _, pattern := range cfg.FastSyncResources {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --fast-sync-resource %q: %w", pattern, err)
+		}
+	}
+
+	if cfg.StreamRecords && cfg.Registry != "txt" {
+		return errors.New("--stream-records requires the txt registry")
+	}
+	if cfg.StreamRecords && cfg.IncrementalSync {
+		return errors.New("--stream-records and --incremental-sync are mutually exclusive")
+	}
+
+	if cfg.Paused && cfg.APIWriteToken == "" && len(cfg.APIWriteServiceAccounts) == 0 {
+		return errors.New("--paused requires --api-write-token or --api-write-service-account to resume synchronization")
+	}
+	if cfg.GRPCAddress != "" && cfg.APIToken == "" && cfg.APIWriteToken == "" && cfg.APITokenAudience == "" {
+		return errors.New("--grpc-address requires --api-token, --api-write-token or --api-token-audience")
+	}
+	if (cfg.APITokenAudience == "") != (len(cfg.APIServiceAccounts)+len(cfg.APIWriteServiceAccounts) == 0) {
+		return errors.New("--api-token-audience and --api-service-account or --api-write-service-account must be set together")
+	}
+	for flag, serviceAccounts := range map[string][]string{"api-service-account": cfg.APIServiceAccounts, "api-write-service-account": cfg.APIWriteServiceAccounts} {
+		for _, serviceAccount := range serviceAccounts {
+			if namespace, name, ok := strings.Cut(serviceAccount, "/"); !ok || namespace == "" || name == "" {
+				return fmt.Errorf("--%s %q must be in namespace/name format", flag, serviceAccount)
+			}
+		}
+	}
+
+	if cfg.GeoContinent != "" || cfg.GeoCountry != "" || cfg.GeoRegion != "" {
+		geo := endpoint.GeoLocation{
+			Continent: strings.ToUpper(cfg.GeoContinent),
+			Country:   strings.ToUpper(cfg.GeoCountry),
+			Region:    strings.ToUpper(cfg.GeoRegion),
+		}
+		if err := geo.Validate(); err != nil {
+			return fmt.Errorf("invalid --geo-continent, --geo-country or --geo-region: %w", err)
+		}
+	}
+
+	if cfg.WildcardPolicy == "replace" {
+		if errs := k8svalidation.IsDNS1123Label(cfg.WildcardPlaceholder); len(errs) > 0 {
+			return fmt.Errorf("invalid --wildcard-placeholder %q: %s", cfg.WildcardPlaceholder, strings.Join(errs, ", "))
+		}
+	}
+
+	if cfg.CNAMEFlatteningTTL < 0 {
+		return errors.New("--cname-flattening-ttl must not be negative")
+	}
+
+	if cfg.TargetProbe != "" && cfg.TargetProbeTimeout <= 0 {
+		return errors.New("--target-probe-timeout must be positive")
+	}
+	if cfg.TargetProbe == "tcp" && (cfg.TargetProbePort < 1 || cfg.TargetProbePort > 65535) {
+		return fmt.Errorf("invalid --target-probe-port %d", cfg.TargetProbePort)
+	}
+
+	if len(cfg.VaultSecrets) > 0 && (cfg.VaultAddress == "" || cfg.VaultRole == "") {
+		return errors.New("--vault-secret requires --vault-address and --vault-role")
+	}
+	for name, ref := range cfg.VaultSecrets {
+		if !cfg.IsSecret(name) {
+			return fmt.Errorf("invalid --vault-secret %s: not a credential", name)
+		}
+		if path, key, ok := strings.Cut(ref, "#"); !ok || path == "" || key == "" {
+			return fmt.Errorf("invalid --vault-secret %s=%s: expected <path>#<key>", name, ref)
+		}
+	}
+
+	if cfg.CredentialExecCommand != "" && cfg.CredentialExecTimeout <= 0 {
+		return errors.New("--credential-exec-timeout must be positive")
+	}
+
+	if cfg.AdmissionWebhook && (cfg.AdmissionWebhookTLSCert == "" || cfg.AdmissionWebhookTLSKey == "") {
+		return errors.New("--admission-webhook requires --admission-webhook-tls-cert and --admission-webhook-tls-key")
+	}
+
+	if (cfg.MetricsTLSCert == "") != (cfg.MetricsTLSKey == "") {
+		return errors.New("--metrics-tls-cert and --metrics-tls-key must be set together")
+	}
+	if cfg.MetricsTLSClientCA != "" && cfg.MetricsTLSCert == "" {
+		return errors.New("--metrics-tls-client-ca requires --metrics-tls-cert and --metrics-tls-key")
+	}
+
+	if cfg.FIPS {
+		if err := validateConfigForFIPS(cfg); err != nil {
+			return err
+		}
+	}
+
 	_, err := labels.Parse(cfg.LabelFilter)
 	if err != nil {
 		return errors.New("--label-filter does not specify a valid label selector")
@@ -52,6 +280,33 @@ func ValidateConfig(cfg *externaldns.Config) error {
 	return nil
 }
 
+// fipsEnabled reports whether Go runs in FIPS 140-3 mode, overridden by tests.
+var fipsEnabled = fips140.Enabled
+
+// fipsTSIGAlgs are the TSIG algorithms of the rfc2136 provider built on FIPS 140-3 approved hashes still allowed
+// for new uses, which excludes SHA-1.
+var fipsTSIGAlgs = map[string]bool{
+	"hmac-sha224": true,
+	"hmac-sha256": true,
+	"hmac-sha384": true,
+	"hmac-sha512": true,
+}
+
+func validateConfigForFIPS(cfg *externaldns.Config) error {
+	if !fipsEnabled() {
+		return errors.New("--fips requires Go to run in FIPS 140-3 mode, set GODEBUG=fips140=on or build with GOFIPS140")
+	}
+	if cfg.Provider == "rfc2136" {
+		if cfg.RFC2136GSSTSIG {
+			return errors.New("--rfc2136-gss-tsig is not supported with --fips, Kerberos isn't restricted to approved algorithms")
+		}
+		if !cfg.RFC2136Insecure && !fipsTSIGAlgs[cfg.RFC2136TSIGSecretAlg] {
+			return fmt.Errorf("--rfc2136-tsig-secret-alg %q is not supported with --fips, use hmac-sha256 or stronger", cfg.RFC2136TSIGSecretAlg)
+		}
+	}
+	return nil
+}
+
 func preValidateConfig(cfg *externaldns.Config) error {
 	if cfg.LogFormat != "text" && cfg.LogFormat != "json" {
 		return fmt.Errorf("unsupported log format: %s", cfg.LogFormat)
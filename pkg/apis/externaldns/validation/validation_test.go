@@ -17,7 +17,9 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/fips140"
 	"testing"
+	"time"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 
@@ -51,6 +53,289 @@ func TestValidateFlags(t *testing.T) {
 	cfg.Provider = ""
 	require.Error(t, ValidateConfig(cfg))
 
+	cfg = newValidConfig(t)
+	cfg.ShardCount = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ShardIndex = 3
+	cfg.ShardCount = 3
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ShardIndex = 2
+	cfg.ShardCount = 3
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.GeoCountry = "US"
+	cfg.GeoRegion = "CA"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.GeoContinent = "NA"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.GeoContinent = "XX"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.WildcardPolicy = "replace"
+	cfg.WildcardPlaceholder = "any"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.WildcardPlaceholder = "*"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.CNAMEFlatteningTTL = -time.Second
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.TargetProbe = "tcp"
+	cfg.TargetProbePort = 443
+	cfg.TargetProbeTimeout = time.Second
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.TargetProbePort = 0
+	require.Error(t, ValidateConfig(cfg))
+	cfg.TargetProbe = "icmp"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.TargetProbeTimeout = 0
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.VaultSecrets = map[string]string{"pdns-api-key": "secret/data/dns#pdns"}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.VaultAddress = "https://vault.example.org:8200"
+	cfg.VaultRole = "external-dns"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.VaultSecrets = map[string]string{"CF_API_TOKEN": "secret/data/dns#token"}
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.VaultSecrets = map[string]string{"provider": "secret/data/dns#provider"}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.VaultSecrets = map[string]string{"pdns-api-key": "secret/data/dns"}
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.CredentialExecCommand = "/bin/get-credentials"
+	cfg.CredentialExecTimeout = 10 * time.Second
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.CredentialExecTimeout = 0
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.KubeAPIQPS = 50
+	cfg.KubeAPIBurst = 100
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.KubeAPIBurst = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.FinalSync = true
+	require.Error(t, ValidateConfig(cfg))
+	cfg.DrainTimeout = 10 * time.Second
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.FastSyncResources = []string{"crd/cert-manager/*"}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.FastSyncInterval = 10 * time.Second
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.FastSyncResources = []string{"crd/[cert-manager/*"}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.FastSyncResources = nil
+	cfg.FastSyncInterval = -time.Second
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.Registry = "txt"
+	cfg.StreamRecords = true
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.IncrementalSync = true
+	require.Error(t, ValidateConfig(cfg))
+	cfg.IncrementalSync = false
+	cfg.Registry = "dynamodb"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.SourceGroup = "ingresses"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.Registry = "noop"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.Registry = "txt"
+	cfg.RegistryResourceLabels = []string{"team", "app.kubernetes.io/part-of"}
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.Registry = "dynamodb"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.Registry = "aws-sd"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.Registry = "txt"
+	cfg.RegistryResourceLabels = []string{"team=payments"}
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.Command = externaldns.CommandExport
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.Registry = "noop"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.Command = externaldns.CommandRBAC
+	cfg.RBACServiceAccount = "kube-system/external-dns"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.RBACServiceAccount = "external-dns"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.InventoryConfigMap = "external-dns/records"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.InventoryConfigMap = "records"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.NamespaceRecordsConfigMap = "dns-records"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.NamespaceRecordsConfigMap = "apps/dns-records"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.StatusObject = "external-dns"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.StatusObject = "External DNS"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.IstioEastWestDomain = "mesh.example.org."
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.IstioEastWestDomain = "mesh_example.org"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.AuditLogChain = true
+	require.Error(t, ValidateConfig(cfg))
+	cfg.AuditLog = "-"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.AuditLog = "/var/log/external-dns/audit.log"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.AuditLogS3Bucket = "dns-audit"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.AuditLog = ""
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.AuditLogChain = false
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.Command = externaldns.CommandVerifyAuditLog
+	require.Error(t, ValidateConfig(cfg))
+	cfg.AuditLog = "/var/log/external-dns/audit.log"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.NamespaceDomainsConfigMap = "external-dns/namespace-domains"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.NamespaceDomainsConfigMap = "/namespace-domains"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.MaxChangesPerZonePerSync = 50
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.MaxChangesPerZonePerSync = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ApprovalThreshold = 10
+	cfg.ApprovalNamespace = "external-dns"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.ApprovalThreshold = -1
+	require.Error(t, ValidateConfig(cfg))
+	cfg.ApprovalThreshold = 0
+	cfg.ApprovalZones = []string{"prod.example.com"}
+	cfg.ApprovalNamespace = ""
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.LogLevel = "info,source=debug,provider.aws=trace"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.LogLevel = "info,source=verbose"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.PushgatewayURL = "http://pushgateway:9091"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.Once = true
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.PublishKafkaBrokers = []string{"kafka:9092"}
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.PublishNATSURL = "nats://nats:4222"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.PublishCloudEventsSink = "http://broker-ingress.knative-eventing/default/default"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.PublishNATSURL = "nats://nats:4222"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.PublishNATSURL = ""
+	cfg.PublishCloudEventsSink = "broker-ingress.knative-eventing"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.PolicyOPAURL = "http://opa:8181/v1/data/externaldns/verdicts"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.PolicyOPAURL = "opa:8181"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.TracingSampleRatio = 0
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.TracingSampleRatio = 1.5
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.Paused = true
+	require.Error(t, ValidateConfig(cfg))
+	cfg.APIToken = "token"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.APIWriteToken = "write-token"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.GRPCAddress = ":7980"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.APIToken = "token"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.Paused = true
+	cfg.APITokenAudience = "external-dns"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.APIServiceAccounts = []string{"monitoring"}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.APIServiceAccounts = []string{"monitoring/api-reader"}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.APIWriteServiceAccounts = []string{"ci"}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.APIWriteServiceAccounts = []string{"ci/deployer"}
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.APITokenAudience = ""
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.AdmissionWebhook = true
+	cfg.AdmissionWebhookTLSCert = "/tls/tls.crt"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.AdmissionWebhookTLSKey = "/tls/tls.key"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.MetricsTLSClientCA = "/tls/ca.crt"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.MetricsTLSCert = "/tls/metrics.crt"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.MetricsTLSKey = "/tls/metrics.key"
+	require.NoError(t, ValidateConfig(cfg))
+
 	cfg = newValidConfig(t)
 	cfg.IgnoreHostnameAnnotation = true
 	cfg.FQDNTemplate = ""
@@ -351,3 +636,33 @@ func TestValidateGoodAzureConfig(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestValidateFIPSConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.FIPS = true
+	t.Cleanup(func() { fipsEnabled = fips140.Enabled })
+	fipsEnabled = func() bool { return false }
+	require.ErrorContains(t, ValidateConfig(cfg), "FIPS 140-3 mode")
+
+	fipsEnabled = func() bool { return true }
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.Provider = "rfc2136"
+	cfg.RFC2136MinTTL = 3600
+	cfg.RFC2136BatchChangeSize = 50
+	cfg.RFC2136TSIGSecretAlg = "hmac-sha1"
+	require.ErrorContains(t, ValidateConfig(cfg), "hmac-sha1")
+	cfg.RFC2136TSIGSecretAlg = "hmac-md5"
+	require.ErrorContains(t, ValidateConfig(cfg), "hmac-md5")
+	cfg.RFC2136TSIGSecretAlg = "hmac-sha256"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.RFC2136GSSTSIG = true
+	cfg.RFC2136KerberosRealm = "test-realm"
+	cfg.RFC2136KerberosUsername = "test-user"
+	cfg.RFC2136KerberosPassword = "test-pass"
+	require.ErrorContains(t, ValidateConfig(cfg), "--rfc2136-gss-tsig")
+
+	cfg.FIPS = false
+	require.NoError(t, ValidateConfig(cfg))
+}
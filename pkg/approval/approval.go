@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// ApprovedKey is the annotation approving the changes of a PendingChange when set to "true".
+const ApprovedKey = annotations.AnnotationKeyPrefix + "approved"
+
+var pendingChangesResource = v1alpha1.GroupVersion.WithResource("pendingchanges")
+
+// Gate holds back the changes of plans which need approval until they're approved.
+type Gate interface {
+	// Approved returns whether the changes may be applied, submitting them for approval if they need it and
+	// weren't submitted yet.
+	Approved(ctx context.Context, changes *plan.Changes) (bool, error)
+	// Applied records that the approved changes were applied.
+	Applied(ctx context.Context, changes *plan.Changes)
+}
+
+// CRDGate submits the changes needing approval as PendingChange objects, which are approved by setting their
+// ApprovedKey annotation. The changes need approval when their number reaches the threshold, or when any of them
+// is within one of the sensitive zones.
+type CRDGate struct {
+	client    dynamic.Interface
+	namespace string
+	ownerID   string
+	threshold int
+	zones     []string
+	dryRun    bool
+	now       func() time.Time
+}
+
+// NewCRDGate returns a CRDGate keeping the PendingChange objects in the given namespace. A threshold of 0 only
+// requires approval for changes in the given zones.
+func NewCRDGate(client dynamic.Interface, namespace, ownerID string, threshold int, zones []string, dryRun bool) *CRDGate {
+	normalized := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		if zone = strings.Trim(strings.ToLower(strings.TrimSpace(zone)), "."); zone != "" {
+			normalized = append(normalized, zone)
+		}
+	}
+	return &CRDGate{
+		client:    client,
+		namespace: namespace,
+		ownerID:   ownerID,
+		threshold: threshold,
+		zones:     normalized,
+		dryRun:    dryRun,
+		now:       time.Now,
+	}
+}
+
+// Approved creates the PendingChange of changes needing approval on their first synchronization, superseding
+// the other pending changes of this instance, and returns true once it is approved. In a dry run, the
+// PendingChange is only validated, and the changes are not approved.
+func (g *CRDGate) Approved(ctx context.Context, changes *plan.Changes) (bool, error) {
+	reasons := g.reasons(changes)
+	if len(reasons) == 0 {
+		return true, nil
+	}
+
+	name := g.name(changes)
+	resource := g.client.Resource(pendingChangesResource).Namespace(g.namespace)
+	obj, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		if obj.GetAnnotations()[ApprovedKey] == "true" {
+			return true, nil
+		}
+		// the plan came back after being replaced by another one
+		if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase == v1alpha1.PendingChangePhaseSuperseded && !g.dryRun {
+			if err := g.setPhase(ctx, obj, v1alpha1.PendingChangePhasePending); err != nil {
+				return false, err
+			}
+			g.supersede(ctx, name)
+		}
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("getting pending change %s/%s: %w", g.namespace, name, err)
+	}
+
+	pending := &v1alpha1.PendingChange{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "PendingChange"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: g.namespace},
+		Spec: v1alpha1.PendingChangeSpec{
+			OwnerID:   g.ownerID,
+			Risk:      risk(changes),
+			Reasons:   reasons,
+			Create:    changes.Create,
+			UpdateOld: changes.UpdateOld,
+			UpdateNew: changes.UpdateNew,
+			Delete:    changes.Delete,
+		},
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pending)
+	if err != nil {
+		return false, fmt.Errorf("converting pending change %s/%s: %w", g.namespace, name, err)
+	}
+	created, err := resource.Create(ctx, &unstructured.Unstructured{Object: content}, metav1.CreateOptions{DryRun: g.dryRunOption()})
+	if err != nil {
+		return false, fmt.Errorf("creating pending change %s/%s: %w", g.namespace, name, err)
+	}
+	// a dry run doesn't store the pending change, there is neither its phase to set nor others to supersede
+	if g.dryRun {
+		log.Infof("Changes need approval (%s), would submit them as pending change %s/%s", strings.Join(reasons, ", "), g.namespace, name)
+		return false, nil
+	}
+	if err := g.setPhase(ctx, created, v1alpha1.PendingChangePhasePending); err != nil {
+		return false, err
+	}
+	log.Infof("Changes need approval (%s), submitted them as pending change %s/%s", strings.Join(reasons, ", "), g.namespace, name)
+
+	g.supersede(ctx, name)
+	return false, nil
+}
+
+// Applied sets the phase of the PendingChange of the changes, if they needed approval, to Applied. Failures are
+// only logged, as the changes themselves were applied.
+func (g *CRDGate) Applied(ctx context.Context, changes *plan.Changes) {
+	if len(g.reasons(changes)) == 0 {
+		return
+	}
+	name := g.name(changes)
+	obj, err := g.client.Resource(pendingChangesResource).Namespace(g.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Warnf("Failed to get pending change %s/%s: %v", g.namespace, name, err)
+		return
+	}
+	if err := g.setPhase(ctx, obj, v1alpha1.PendingChangePhaseApplied); err != nil {
+		log.Warn(err)
+	}
+}
+
+// supersede sets the phase of the other pending changes of this instance to Superseded, as they were replaced by
+// the changes of the latest plan. Failures are only logged.
+func (g *CRDGate) supersede(ctx context.Context, current string) {
+	list, err := g.client.Resource(pendingChangesResource).Namespace(g.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Warnf("Failed to list pending changes in %s: %v", g.namespace, err)
+		return
+	}
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.GetName() == current {
+			continue
+		}
+		owner, _, _ := unstructured.NestedString(obj.Object, "spec", "ownerID")
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if owner != g.ownerID || phase != v1alpha1.PendingChangePhasePending {
+			continue
+		}
+		if err := g.setPhase(ctx, obj, v1alpha1.PendingChangePhaseSuperseded); err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
+// setPhase updates the status of the PendingChange to the given phase.
+func (g *CRDGate) setPhase(ctx context.Context, obj *unstructured.Unstructured, phase string) error {
+	obj = obj.DeepCopy()
+	status := map[string]any{"phase": phase}
+	if phase == v1alpha1.PendingChangePhaseApplied {
+		status["appliedAt"] = g.now().UTC().Format(time.RFC3339)
+	}
+	if err := unstructured.SetNestedMap(obj.Object, status, "status"); err != nil {
+		return fmt.Errorf("setting phase of pending change %s/%s: %w", g.namespace, obj.GetName(), err)
+	}
+	if _, err := g.client.Resource(pendingChangesResource).Namespace(g.namespace).UpdateStatus(ctx, obj, metav1.UpdateOptions{DryRun: g.dryRunOption()}); err != nil {
+		return fmt.Errorf("setting phase of pending change %s/%s to %s: %w", g.namespace, obj.GetName(), phase, err)
+	}
+	return nil
+}
+
+func (g *CRDGate) dryRunOption() []string {
+	if g.dryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// reasons returns why the changes need approval, none if they don't.
+func (g *CRDGate) reasons(changes *plan.Changes) []string {
+	var reasons []string
+	if n := risk(changes); g.threshold > 0 && n >= g.threshold {
+		reasons = append(reasons, fmt.Sprintf("%d changes reach the threshold of %d", n, g.threshold))
+	}
+	for _, zone := range g.zones {
+		if slices.ContainsFunc(allEndpoints(changes), func(ep *endpoint.Endpoint) bool { return inZone(ep.DNSName, zone) }) {
+			reasons = append(reasons, fmt.Sprintf("changes in sensitive zone %s", zone))
+		}
+	}
+	return reasons
+}
+
+// name returns the name of the PendingChange of the changes, derived from the owner and the changes so that the
+// same plan maps to the same object across synchronizations, whatever the order of its changes.
+func (g *CRDGate) name(changes *plan.Changes) string {
+	var lines []string
+	for action, endpoints := range map[string][]*endpoint.Endpoint{
+		"create":     changes.Create,
+		"update-old": changes.UpdateOld,
+		"update-new": changes.UpdateNew,
+		"delete":     changes.Delete,
+	} {
+		for _, ep := range endpoints {
+			lines = append(lines, action+" "+ep.String())
+		}
+	}
+	slices.Sort(lines)
+	sum := sha256.Sum256([]byte(g.ownerID + "\n" + strings.Join(lines, "\n")))
+	return "external-dns-" + hex.EncodeToString(sum[:])[:16]
+}
+
+// risk is the number of changes, counting updates once.
+func risk(changes *plan.Changes) int {
+	return len(changes.Create) + len(changes.UpdateNew) + len(changes.Delete)
+}
+
+func allEndpoints(changes *plan.Changes) []*endpoint.Endpoint {
+	return slices.Concat(changes.Create, changes.UpdateOld, changes.UpdateNew, changes.Delete)
+}
+
+// inZone returns whether the DNS name is the zone or one of its sub-domains.
+func inZone(name, zone string) bool {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func newFakeClient() *fakedynamic.FakeDynamicClient {
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{pendingChangesResource: "PendingChangeList"})
+}
+
+func getPendingChange(t *testing.T, client *fakedynamic.FakeDynamicClient, name string) *unstructured.Unstructured {
+	t.Helper()
+	obj, err := client.Resource(pendingChangesResource).Namespace("dns").Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return obj
+}
+
+func approve(t *testing.T, client *fakedynamic.FakeDynamicClient, name string) {
+	t.Helper()
+	obj := getPendingChange(t, client, name)
+	obj.SetAnnotations(map[string]string{ApprovedKey: "true"})
+	_, err := client.Resource(pendingChangesResource).Namespace("dns").Update(context.Background(), obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+}
+
+func phase(t *testing.T, client *fakedynamic.FakeDynamicClient, name string) string {
+	t.Helper()
+	phase, _, _ := unstructured.NestedString(getPendingChange(t, client, name).Object, "status", "phase")
+	return phase
+}
+
+func TestCRDGateThreshold(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	gate := NewCRDGate(client, "dns", "default", 2, nil, false)
+	gate.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	small := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")}}
+	approved, err := gate.Approved(ctx, small)
+	require.NoError(t, err)
+	assert.True(t, approved, "changes below the threshold don't need approval")
+	assert.Empty(t, client.Actions())
+
+	large := &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "5.6.7.8")},
+	}
+	approved, err = gate.Approved(ctx, large)
+	require.NoError(t, err)
+	assert.False(t, approved)
+
+	name := gate.name(large)
+	obj := getPendingChange(t, client, name)
+	// Endpoint has unexported fields, which the unstructured converter can't fill
+	data, err := obj.MarshalJSON()
+	require.NoError(t, err)
+	pending := &v1alpha1.PendingChange{}
+	require.NoError(t, json.Unmarshal(data, pending))
+	assert.Equal(t, "default", pending.Spec.OwnerID)
+	assert.Equal(t, 2, pending.Spec.Risk)
+	assert.Equal(t, []string{"2 changes reach the threshold of 2"}, pending.Spec.Reasons)
+	assert.Equal(t, "a.example.com", pending.Spec.Create[0].DNSName)
+	assert.Equal(t, "b.example.com", pending.Spec.Delete[0].DNSName)
+	assert.Equal(t, v1alpha1.PendingChangePhasePending, pending.Status.Phase)
+
+	// the same changes, in another order, map to the same pending change
+	reordered := &plan.Changes{Delete: large.Delete, Create: large.Create}
+	approved, err = gate.Approved(ctx, reordered)
+	require.NoError(t, err)
+	assert.False(t, approved)
+
+	approve(t, client, name)
+	approved, err = gate.Approved(ctx, reordered)
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	gate.Applied(ctx, large)
+	obj = getPendingChange(t, client, name)
+	assert.Equal(t, v1alpha1.PendingChangePhaseApplied, obj.Object["status"].(map[string]any)["phase"])
+	assert.Equal(t, "2025-01-02T03:04:05Z", obj.Object["status"].(map[string]any)["appliedAt"])
+}
+
+func TestCRDGateZones(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	gate := NewCRDGate(client, "dns", "default", 0, []string{"Prod.Example.com."}, false)
+
+	other := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.dev.example.com", endpoint.RecordTypeA, "1.2.3.4")}}
+	approved, err := gate.Approved(ctx, other)
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	sensitive := &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("api.prod.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("api.prod.example.com", endpoint.RecordTypeA, "5.6.7.8")},
+	}
+	approved, err = gate.Approved(ctx, sensitive)
+	require.NoError(t, err)
+	assert.False(t, approved)
+	reasons, _, _ := unstructured.NestedStringSlice(getPendingChange(t, client, gate.name(sensitive)).Object, "spec", "reasons")
+	assert.Equal(t, []string{"changes in sensitive zone prod.example.com"}, reasons)
+}
+
+func TestCRDGateSupersede(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	gate := NewCRDGate(client, "dns", "default", 1, nil, false)
+	otherGate := NewCRDGate(client, "dns", "other", 1, nil, false)
+
+	first := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")}}
+	second := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "5.6.7.8")}}
+
+	_, err := otherGate.Approved(ctx, first)
+	require.NoError(t, err)
+	_, err = gate.Approved(ctx, first)
+	require.NoError(t, err)
+	_, err = gate.Approved(ctx, second)
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.PendingChangePhaseSuperseded, phase(t, client, gate.name(first)))
+	assert.Equal(t, v1alpha1.PendingChangePhasePending, phase(t, client, gate.name(second)))
+	assert.Equal(t, v1alpha1.PendingChangePhasePending, phase(t, client, otherGate.name(first)), "the changes of other owners are left alone")
+
+	// the first plan coming back is pending again
+	approved, err := gate.Approved(ctx, first)
+	require.NoError(t, err)
+	assert.False(t, approved)
+	assert.Equal(t, v1alpha1.PendingChangePhasePending, phase(t, client, gate.name(first)))
+	assert.Equal(t, v1alpha1.PendingChangePhaseSuperseded, phase(t, client, gate.name(second)))
+}
+
+func TestCRDGateDryRun(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	// like the API server, don't store the objects created in a dry run; the fake client drops the create
+	// options, and all the creates of the gate are dry runs
+	client.PrependReactor("create", "pendingchanges", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, action.(k8stesting.CreateAction).GetObject(), nil
+	})
+	gate := NewCRDGate(client, "dns", "default", 1, nil, true)
+
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")}}
+	approved, err := gate.Approved(ctx, changes)
+	require.NoError(t, err)
+	assert.False(t, approved, "changes needing approval aren't approved in a dry run")
+
+	_, err = client.Resource(pendingChangesResource).Namespace("dns").Get(ctx, gate.name(changes), metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "the pending change isn't stored in a dry run")
+	for _, action := range client.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb(), "no phase is set in a dry run")
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records every change applied to DNS records, as evidence of who changed which record and why.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Entry is the audit record of a change applied to a DNS record.
+type Entry struct {
+	Time          time.Time `json:"time"`
+	Action        string    `json:"action"`
+	DNSName       string    `json:"dnsName"`
+	RecordType    string    `json:"recordType"`
+	SetIdentifier string    `json:"setIdentifier,omitempty"`
+	// Old is the record before the change, unset for creations
+	Old *Record `json:"old,omitempty"`
+	// New is the record after the change, unset for deletions
+	New *Record `json:"new,omitempty"`
+	// Resource is the Kubernetes resource the record is managed for, if known
+	Resource string `json:"resource,omitempty"`
+	OwnerID  string `json:"ownerID,omitempty"`
+	DryRun   bool   `json:"dryRun,omitempty"`
+	// Sequence numbers the entries of a hash-chained log from 1
+	Sequence uint64 `json:"sequence,omitempty"`
+	// PrevHash is the hash of the previous entry of a hash-chained log, empty for the first one
+	PrevHash string `json:"prevHash,omitempty"`
+	// Hash is the SHA-256 of the entry without its hash, in hexadecimal, set in hash-chained logs
+	Hash string `json:"hash,omitempty"`
+}
+
+// Record is the state of a DNS record before or after a change.
+type Record struct {
+	Targets          []string                  `json:"targets"`
+	TTL              int64                     `json:"ttl,omitempty"`
+	ProviderSpecific endpoint.ProviderSpecific `json:"providerSpecific,omitempty"`
+}
+
+// Logger records the changes applied to DNS records.
+type Logger interface {
+	// Log records the given changes, which were applied.
+	Log(changes *plan.Changes)
+}
+
+// JSONLogger writes an Entry per applied change as a line of JSON.
+type JSONLogger struct {
+	ownerID string
+	dryRun  bool
+	now     func() time.Time
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLogger returns a JSONLogger writing to w, recording the given owner ID in the entries.
+func NewJSONLogger(w io.Writer, ownerID string, dryRun bool) *JSONLogger {
+	return &JSONLogger{w: w, ownerID: ownerID, dryRun: dryRun, now: time.Now}
+}
+
+// Open returns a JSONLogger appending to the file at path, created if needed, or writing to the standard
+// output if path is "-".
+func Open(path, ownerID string, dryRun bool) (*JSONLogger, error) {
+	if path == "-" {
+		return NewJSONLogger(os.Stdout, ownerID, dryRun), nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONLogger(f, ownerID, dryRun), nil
+}
+
+// Log writes an entry per created, updated and deleted record. Failures are only logged, as the changes
+// themselves were applied.
+func (l *JSONLogger) Log(changes *plan.Changes) {
+	entries := Entries(changes, l.now(), l.ownerID, l.dryRun)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	enc := json.NewEncoder(l.w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			log.Errorf("Failed to write audit log entry for %s %s: %v", entry.DNSName, entry.RecordType, err)
+		}
+	}
+}
+
+// Entries returns an entry per created, updated and deleted record of the changes, made at the given time
+// by the instance with the given owner ID.
+func Entries(changes *plan.Changes, at time.Time, ownerID string, dryRun bool) []Entry {
+	entry := func(action string, ep *endpoint.Endpoint) Entry {
+		return Entry{
+			Time:          at.UTC(),
+			Action:        action,
+			DNSName:       ep.DNSName,
+			RecordType:    ep.RecordType,
+			SetIdentifier: ep.SetIdentifier,
+			Resource:      ep.Labels[endpoint.ResourceLabelKey],
+			OwnerID:       ownerID,
+			DryRun:        dryRun,
+		}
+	}
+
+	old := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(changes.UpdateOld))
+	for _, ep := range changes.UpdateOld {
+		old[ep.Key()] = ep
+	}
+
+	entries := make([]Entry, 0, len(changes.Create)+len(changes.UpdateNew)+len(changes.Delete))
+	for _, ep := range changes.Create {
+		e := entry(ActionCreate, ep)
+		e.New = toRecord(ep)
+		entries = append(entries, e)
+	}
+	for _, ep := range changes.UpdateNew {
+		e := entry(ActionUpdate, ep)
+		if prev, ok := old[ep.Key()]; ok {
+			e.Old = toRecord(prev)
+			if e.Resource == "" {
+				e.Resource = prev.Labels[endpoint.ResourceLabelKey]
+			}
+		}
+		e.New = toRecord(ep)
+		entries = append(entries, e)
+	}
+	for _, ep := range changes.Delete {
+		e := entry(ActionDelete, ep)
+		e.Old = toRecord(ep)
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func toRecord(ep *endpoint.Endpoint) *Record {
+	return &Record{
+		Targets:          ep.Targets,
+		TTL:              int64(ep.RecordTTL),
+		ProviderSpecific: ep.ProviderSpecific,
+	}
+}
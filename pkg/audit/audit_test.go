@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func decode(t *testing.T, data string) []Entry {
+	t.Helper()
+	var entries []Entry
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		var entry Entry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestJSONLoggerLog(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, "owner", false)
+	logger.now = func() time.Time { return now }
+
+	created := endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeA, 300, "1.1.1.1")
+	created.Labels[endpoint.ResourceLabelKey] = "service/default/new"
+	oldRecord := endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "2.2.2.2")
+	oldRecord.Labels[endpoint.ResourceLabelKey] = "ingress/default/updated"
+	newRecord := endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "3.3.3.3")
+	deleted := endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeCNAME, "target.example.com")
+
+	logger.Log(&plan.Changes{
+		Create:    []*endpoint.Endpoint{created},
+		UpdateOld: []*endpoint.Endpoint{oldRecord},
+		UpdateNew: []*endpoint.Endpoint{newRecord},
+		Delete:    []*endpoint.Endpoint{deleted},
+	})
+
+	assert.Equal(t, []Entry{
+		{
+			Time: now, Action: ActionCreate, DNSName: "new.example.com", RecordType: endpoint.RecordTypeA,
+			New: &Record{Targets: []string{"1.1.1.1"}, TTL: 300}, Resource: "service/default/new", OwnerID: "owner",
+		},
+		{
+			Time: now, Action: ActionUpdate, DNSName: "updated.example.com", RecordType: endpoint.RecordTypeA,
+			Old: &Record{Targets: []string{"2.2.2.2"}}, New: &Record{Targets: []string{"3.3.3.3"}},
+			Resource: "ingress/default/updated", OwnerID: "owner",
+		},
+		{
+			Time: now, Action: ActionDelete, DNSName: "old.example.com", RecordType: endpoint.RecordTypeCNAME,
+			Old: &Record{Targets: []string{"target.example.com"}}, OwnerID: "owner",
+		},
+	}, decode(t, buf.String()))
+}
+
+func TestOpenAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1")}}
+
+	for range 2 {
+		logger, err := Open(path, "owner", true)
+		require.NoError(t, err)
+		logger.Log(changes)
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	entries := decode(t, string(data))
+	require.Len(t, entries, 2)
+	assert.True(t, entries[1].DryRun)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing", "audit.log"), "owner", false)
+	require.Error(t, err)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// appendTimeout bounds the time taken to store the entries of a synchronization.
+const appendTimeout = 30 * time.Second
+
+// Store persists the entries of a hash-chained log in append-only storage.
+type Store interface {
+	// Last returns the last entry stored, or nil if there is none.
+	Last(ctx context.Context) (*Entry, error)
+	// Append stores the entries after the last one.
+	Append(ctx context.Context, entries []Entry) error
+	// Read calls fn with each entry stored, in order, until it fails.
+	Read(ctx context.Context, fn func(Entry) error) error
+}
+
+// ChainLogger records the changes in a hash-chained log: each entry holds the hash of the previous one, so
+// that altering, removing or reordering entries breaks the chain, which Verify detects.
+type ChainLogger struct {
+	store   Store
+	ownerID string
+	dryRun  bool
+	now     func() time.Time
+
+	mu sync.Mutex
+	// head is the last chained entry
+	head *Entry
+	// pending are the chained entries which failed to be stored, retried with the next changes
+	pending []Entry
+}
+
+// NewChainLogger returns a ChainLogger appending to the store, which continues the chain of its last entry.
+func NewChainLogger(ctx context.Context, store Store, ownerID string, dryRun bool) (*ChainLogger, error) {
+	head, err := store.Last(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading the head of the audit log: %w", err)
+	}
+	if head != nil && head.Hash == "" {
+		// entries written before the log was chained
+		head = nil
+	}
+	return &ChainLogger{store: store, ownerID: ownerID, dryRun: dryRun, now: time.Now, head: head}, nil
+}
+
+// Log chains an entry per created, updated and deleted record and stores them. Entries which fail to be
+// stored are logged and retried with the next changes, so that the chain has no gaps while ExternalDNS runs.
+func (l *ChainLogger) Log(changes *plan.Changes) {
+	entries := Entries(changes, l.now(), l.ownerID, l.dryRun)
+	if len(entries) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, entry := range entries {
+		entry.Sequence, entry.PrevHash = 1, ""
+		if l.head != nil {
+			entry.Sequence, entry.PrevHash = l.head.Sequence+1, l.head.Hash
+		}
+		entry.Hash = Hash(entry)
+		l.pending = append(l.pending, entry)
+		l.head = &entry
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), appendTimeout)
+	defer cancel()
+	if err := l.store.Append(ctx, l.pending); err != nil {
+		log.Errorf("Failed to append %d entries to the audit log, retrying with the next changes: %v", len(l.pending), err)
+		return
+	}
+	l.pending = nil
+	log.Infof("Audit log head is entry %d with hash %s", l.head.Sequence, l.head.Hash)
+}
+
+// Hash returns the SHA-256 of the JSON encoding of the entry without its hash, in hexadecimal.
+func Hash(entry Entry) string {
+	entry.Hash = ""
+	data, err := json.Marshal(entry)
+	if err != nil {
+		// entries only hold strings, numbers and times
+		panic(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ErrBrokenChain is returned by Verify for altered, removed or reordered entries.
+var ErrBrokenChain = errors.New("broken audit log chain")
+
+// Verify checks the hash chain of the entries of the store, and returns the number of chained entries and the
+// last one, nil if there is none. Entries written before the log was chained are skipped. As removing the last
+// entries keeps the chain valid, compare the head with the one logged by ExternalDNS or stored elsewhere.
+func Verify(ctx context.Context, store Store) (int, *Entry, error) {
+	var (
+		count int
+		head  *Entry
+	)
+	err := store.Read(ctx, func(entry Entry) error {
+		if entry.Hash == "" {
+			if head != nil {
+				return fmt.Errorf("%w: unchained entry after entry %d", ErrBrokenChain, head.Sequence)
+			}
+			return nil
+		}
+		if hash := Hash(entry); entry.Hash != hash {
+			return fmt.Errorf("%w: entry %d was altered, its hash is %s", ErrBrokenChain, entry.Sequence, hash)
+		}
+		switch {
+		case head == nil && (entry.Sequence != 1 || entry.PrevHash != ""):
+			return fmt.Errorf("%w: the first entry is %d", ErrBrokenChain, entry.Sequence)
+		case head != nil && entry.Sequence != head.Sequence+1:
+			return fmt.Errorf("%w: entry %d follows entry %d", ErrBrokenChain, entry.Sequence, head.Sequence)
+		case head != nil && entry.PrevHash != head.Hash:
+			return fmt.Errorf("%w: entry %d doesn't follow entry %d", ErrBrokenChain, entry.Sequence, head.Sequence)
+		}
+		count++
+		head = &entry
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return count, head, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func testChanges(names ...string) *plan.Changes {
+	changes := &plan.Changes{}
+	for _, name := range names {
+		changes.Create = append(changes.Create, endpoint.NewEndpoint(name, endpoint.RecordTypeA, "192.0.2.1"))
+	}
+	return changes
+}
+
+func newTestChainLogger(t *testing.T, store Store) *ChainLogger {
+	t.Helper()
+	logger, err := NewChainLogger(context.Background(), store, "owner", false)
+	require.NoError(t, err)
+	logger.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	return logger
+}
+
+func TestChainLogger(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "audit.log"))
+	logger := newTestChainLogger(t, store)
+	logger.Log(testChanges("a.example.com", "b.example.com"))
+	logger.Log(&plan.Changes{})
+
+	// a restart continues the chain
+	logger = newTestChainLogger(t, store)
+	logger.Log(testChanges("c.example.com"))
+
+	var entries []Entry
+	require.NoError(t, store.Read(context.Background(), func(entry Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	require.Len(t, entries, 3)
+	assert.Equal(t, uint64(1), entries[0].Sequence)
+	assert.Empty(t, entries[0].PrevHash)
+	for i, entry := range entries[1:] {
+		assert.Equal(t, uint64(i+2), entry.Sequence)
+		assert.Equal(t, entries[i].Hash, entry.PrevHash)
+	}
+
+	count, head, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, entries[2], *head)
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	for name, tamper := range map[string]func([]string) []string{
+		"altered": func(lines []string) []string {
+			lines[1] = strings.Replace(lines[1], "192.0.2.1", "198.51.100.1", 1)
+			return lines
+		},
+		"removed": func(lines []string) []string {
+			return append(lines[:1], lines[2:]...)
+		},
+		"reordered": func(lines []string) []string {
+			lines[1], lines[2] = lines[2], lines[1]
+			return lines
+		},
+		"removed first": func(lines []string) []string {
+			return lines[1:]
+		},
+		"unchained": func(lines []string) []string {
+			return append(lines, `{"action":"create","dnsName":"d.example.com"}`)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+			newTestChainLogger(t, NewFileStore(path)).Log(testChanges("a.example.com", "b.example.com", "c.example.com"))
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			lines := tamper(strings.Split(strings.TrimSpace(string(data)), "\n"))
+			require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+
+			_, _, err = Verify(context.Background(), NewFileStore(path))
+			require.ErrorIs(t, err, ErrBrokenChain)
+		})
+	}
+}
+
+func TestChainLoggerAfterUnchainedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	unchained, err := Open(path, "owner", false)
+	require.NoError(t, err)
+	unchained.Log(testChanges("a.example.com"))
+
+	store := NewFileStore(path)
+	newTestChainLogger(t, store).Log(testChanges("b.example.com"))
+
+	count, head, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, uint64(1), head.Sequence)
+	assert.Equal(t, "b.example.com", head.DNSName)
+}
+
+// failingStore fails to append while failing is set.
+type failingStore struct {
+	FileStore
+	failing bool
+}
+
+func (s *failingStore) Append(ctx context.Context, entries []Entry) error {
+	if s.failing {
+		return errors.New("volume unavailable")
+	}
+	return s.FileStore.Append(ctx, entries)
+}
+
+func TestChainLoggerRetriesFailedAppends(t *testing.T) {
+	store := &failingStore{FileStore: *NewFileStore(filepath.Join(t.TempDir(), "audit.log")), failing: true}
+	logger := newTestChainLogger(t, store)
+	logger.Log(testChanges("a.example.com"))
+
+	store.failing = false
+	logger.Log(testChanges("b.example.com"))
+
+	count, head, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "b.example.com", head.DNSName)
+}
+
+func TestVerifyEmpty(t *testing.T) {
+	count, head, err := Verify(context.Background(), NewFileStore(filepath.Join(t.TempDir(), "audit.log")))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Nil(t, head)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3API is the part of the S3 client the S3Store uses.
+type S3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+}
+
+// S3Store stores the entries of a hash-chained log in an S3 compatible object storage, as an object of lines of
+// JSON per synchronization named by the sequence of its first entry. Objects are never overwritten, and the
+// bucket may enforce it with S3 Object Lock.
+type S3Store struct {
+	client S3API
+	bucket string
+	prefix string
+}
+
+// NewS3Store returns an S3Store writing the objects with the given key prefix to the bucket.
+func NewS3Store(client S3API, bucket, prefix string) *S3Store {
+	return &S3Store{client: client, bucket: bucket, prefix: prefix}
+}
+
+// key returns the key of the object whose first entry has the given sequence, which sort in sequence order.
+func (s *S3Store) key(sequence uint64) string {
+	return fmt.Sprintf("%s%020d.jsonl", s.prefix, sequence)
+}
+
+func (s *S3Store) Last(ctx context.Context) (*Entry, error) {
+	var lastKey string
+	err := s.keys(ctx, func(key string) error {
+		lastKey = key
+		return nil
+	})
+	if err != nil || lastKey == "" {
+		return nil, err
+	}
+	var last *Entry
+	err = s.readObject(ctx, lastKey, func(entry Entry) error {
+		last = &entry
+		return nil
+	})
+	return last, err
+}
+
+// Append stores the entries as a new object, failing rather than overwriting an existing one.
+func (s *S3Store) Append(ctx context.Context, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	data, err := encodeEntries(entries)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.key(entries[0].Sequence)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/x-ndjson"),
+		IfNoneMatch: aws.String("*"),
+	})
+	return err
+}
+
+func (s *S3Store) Read(ctx context.Context, fn func(Entry) error) error {
+	return s.keys(ctx, func(key string) error {
+		return s.readObject(ctx, key, fn)
+	})
+}
+
+// keys calls fn with the keys of the objects of the log, in order.
+func (s *S3Store) keys(ctx context.Context, fn func(string) error) error {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, object := range page.Contents {
+			key := aws.ToString(object.Key)
+			if !strings.HasSuffix(key, ".jsonl") || strings.Contains(key[len(s.prefix):], "/") {
+				continue
+			}
+			if err := fn(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *S3Store) readObject(ctx context.Context, key string, fn func(Entry) error) error {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+	return decodeEntries(out.Body, "s3://"+s.bucket+"/"+key, fn)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is an in-memory bucket listing a page of up to two objects at a time.
+type fakeS3 struct {
+	objects map[string][]byte
+}
+
+func (f *fakeS3) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	key := aws.ToString(params.Key)
+	if _, ok := f.objects[key]; ok && aws.ToString(params.IfNoneMatch) == "*" {
+		return nil, errors.New("PreconditionFailed")
+	}
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.objects[key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	data, ok := f.objects[aws.ToString(params.Key)]
+	if !ok {
+		return nil, errors.New("NoSuchKey")
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (f *fakeS3) ListObjectsV2(_ context.Context, params *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, aws.ToString(params.Prefix)) && key > aws.ToString(params.ContinuationToken) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	out := &s3.ListObjectsV2Output{}
+	if len(keys) > 2 {
+		keys = keys[:2]
+		out.IsTruncated = aws.Bool(true)
+		out.NextContinuationToken = aws.String(keys[1])
+	}
+	for _, key := range keys {
+		out.Contents = append(out.Contents, s3types.Object{Key: aws.String(key)})
+	}
+	return out, nil
+}
+
+func TestS3Store(t *testing.T) {
+	client := &fakeS3{objects: map[string][]byte{"audit/other/00000000000000000001.jsonl": []byte("{}\n")}}
+	store := NewS3Store(client, "bucket", "audit/")
+
+	last, err := store.Last(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, last)
+
+	logger := newTestChainLogger(t, store)
+	logger.Log(testChanges("a.example.com", "b.example.com"))
+	logger.Log(testChanges("c.example.com"))
+	logger.Log(testChanges("d.example.com"))
+	logger = newTestChainLogger(t, store)
+	logger.Log(testChanges("e.example.com"))
+
+	assert.Contains(t, client.objects, "audit/00000000000000000001.jsonl")
+	assert.Contains(t, client.objects, "audit/00000000000000000003.jsonl")
+	assert.Contains(t, client.objects, "audit/00000000000000000005.jsonl")
+
+	count, head, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+	assert.Equal(t, "e.example.com", head.DNSName)
+
+	// objects are never overwritten
+	require.Error(t, store.Append(context.Background(), []Entry{{Sequence: 5}}))
+}
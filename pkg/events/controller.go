@@ -65,6 +65,12 @@ func NewEventController(cfg *Config) (*Controller, error) {
 		return nil, err
 	}
 	rConfig.Timeout = cfg.timeout
+	if cfg.qps > 0 {
+		rConfig.QPS = cfg.qps
+	}
+	if cfg.burst > 0 {
+		rConfig.Burst = cfg.burst
+	}
 
 	client, err := v1.NewForConfig(rConfig)
 	if err != nil {
@@ -40,6 +40,22 @@ const (
 	RecordReady   Reason = "RecordReady"
 	RecordDeleted Reason = "RecordDeleted"
 	RecordError   Reason = "RecordError"
+	// Reasons of the events emitted on the source object of a record, describing the change applied to it. Each
+	// applied or failed change gets a single event with one of them
+	CreatedDNSRecord Reason = "CreatedDNSRecord"
+	UpdatedDNSRecord Reason = "UpdatedDNSRecord"
+	DeletedDNSRecord Reason = "DeletedDNSRecord"
+	FailedApplyDNS   Reason = "FailedApplyDNS"
+	// RejectedDNSRecord is the reason of the events emitted on the source object of a record which is not published
+	RejectedDNSRecord Reason = "RejectedDNSRecord"
+	// UnreachableTargets is the reason of the events emitted on the source object of a record whose unreachable
+	// targets are not published
+	UnreachableTargets Reason = "UnreachableTargets"
+	// OwnershipConflict is the reason of the events emitted on the source object of a record which is not published
+	// because its DNS name is owned by another ExternalDNS instance
+	OwnershipConflict Reason = "OwnershipConflict"
+	// InvalidAnnotations is the reason of the events emitted on a resource whose external-dns annotations are invalid
+	InvalidAnnotations Reason = "InvalidAnnotations"
 
 	EventTypeNormal  EventType = EventType(apiv1.EventTypeNormal)
 	EventTypeWarning EventType = EventType(apiv1.EventTypeWarning)
@@ -82,6 +98,8 @@ type (
 		kubeConfig   string
 		apiServerURL string
 		timeout      time.Duration
+		qps          float32
+		burst        int
 		emitEvents   sets.Set[Reason]
 		dryRun       bool
 	}
@@ -102,10 +120,14 @@ func NewEvent(obj *ObjectReference, msg string, a Action, r Reason) Event {
 	if obj == nil {
 		return Event{}
 	}
+	eType := EventTypeNormal
+	if r == RecordError || r == FailedApplyDNS || r == RejectedDNSRecord || r == UnreachableTargets || r == OwnershipConflict || r == InvalidAnnotations {
+		eType = EventTypeWarning
+	}
 	return Event{
 		ref:     *obj,
 		message: msg,
-		eType:   EventTypeNormal,
+		eType:   eType,
 		action:  a,
 		reason:  r,
 		source:  obj.Source,
@@ -193,18 +215,37 @@ func WithKubeConfig(kubeConfig string, url string, timeout time.Duration) Config
 	}
 }
 
+// WithRateLimit sets the maximum number of queries per second and the burst of the client to the API server,
+// zero values leaving the client-go defaults.
+func WithRateLimit(qps float32, burst int) ConfigOption {
+	return func(c *Config) {
+		c.qps = qps
+		c.burst = burst
+	}
+}
+
 func WithDryRun(dryRun bool) ConfigOption {
 	return func(c *Config) {
 		c.dryRun = dryRun
 	}
 }
 
+// legacyReasons maps the reasons of the change events emitted before each change got a single event with a reason
+// telling its outcome, to the reasons replacing them.
+var legacyReasons = map[Reason][]Reason{
+	RecordReady:   {CreatedDNSRecord, UpdatedDNSRecord},
+	RecordDeleted: {DeletedDNSRecord},
+	RecordError:   {FailedApplyDNS},
+}
+
 func WithEmitEvents(events []string) ConfigOption {
 	return func(c *Config) {
 		if len(events) > 0 {
 			c.emitEvents = sets.New[Reason]()
 			for _, event := range events {
-				if slices.Contains([]string{string(RecordReady), string(RecordError)}, event) {
+				if reasons, ok := legacyReasons[Reason(event)]; ok {
+					c.emitEvents.Insert(reasons...)
+				} else if slices.Contains([]Reason{CreatedDNSRecord, UpdatedDNSRecord, DeletedDNSRecord, FailedApplyDNS, RejectedDNSRecord, UnreachableTargets, OwnershipConflict, InvalidAnnotations}, Reason(event)) {
 					c.emitEvents.Insert(Reason(event))
 				}
 			}
@@ -138,6 +138,24 @@ func TestEvent_NewEvents(t *testing.T) {
 	}
 }
 
+func TestEvent_EventType(t *testing.T) {
+	ref := &ObjectReference{Kind: "Service", Name: "foo"}
+	for reason, expected := range map[Reason]EventType{
+		RecordReady:        EventTypeNormal,
+		RecordDeleted:      EventTypeNormal,
+		CreatedDNSRecord:   EventTypeNormal,
+		UpdatedDNSRecord:   EventTypeNormal,
+		DeletedDNSRecord:   EventTypeNormal,
+		RecordError:        EventTypeWarning,
+		FailedApplyDNS:     EventTypeWarning,
+		OwnershipConflict:  EventTypeWarning,
+		InvalidAnnotations: EventTypeWarning,
+	} {
+		e := NewEvent(ref, "", ActionCreate, reason)
+		require.Equal(t, expected, e.EventType(), "reason %s", reason)
+	}
+}
+
 func TestEvent_Transpose(t *testing.T) {
 	ev := NewEvent(&ObjectReference{
 		Kind:      "Pod",
@@ -174,10 +192,19 @@ func TestWithEmitEvents(t *testing.T) {
 	}{
 		{
 			name:     "valid events",
-			input:    []string{string(RecordReady), string(RecordError)},
-			expected: sets.New[Reason](RecordReady, RecordError),
+			input:    []string{string(CreatedDNSRecord), string(FailedApplyDNS), string(RejectedDNSRecord)},
+			expected: sets.New[Reason](CreatedDNSRecord, FailedApplyDNS, RejectedDNSRecord),
+			assert: func(c *Config) {
+				require.Equal(t, sets.New[Reason](CreatedDNSRecord, FailedApplyDNS, RejectedDNSRecord), c.emitEvents)
+				require.True(t, c.IsEnabled())
+			},
+		},
+		{
+			name:     "legacy events",
+			input:    []string{string(RecordReady), string(RecordDeleted), string(RecordError)},
+			expected: sets.New[Reason](CreatedDNSRecord, UpdatedDNSRecord, DeletedDNSRecord, FailedApplyDNS),
 			assert: func(c *Config) {
-				require.Equal(t, sets.New[Reason](RecordReady, RecordError), c.emitEvents)
+				require.Equal(t, sets.New[Reason](CreatedDNSRecord, UpdatedDNSRecord, DeletedDNSRecord, FailedApplyDNS), c.emitEvents)
 				require.True(t, c.IsEnabled())
 			},
 		},
@@ -193,9 +220,9 @@ func TestWithEmitEvents(t *testing.T) {
 		{
 			name:     "mixed valid and invalid",
 			input:    []string{string(RecordReady), "InvalidEvent"},
-			expected: sets.New[Reason](RecordReady),
+			expected: sets.New[Reason](CreatedDNSRecord, UpdatedDNSRecord),
 			assert: func(c *Config) {
-				require.Equal(t, sets.New[Reason](RecordReady), c.emitEvents)
+				require.Equal(t, sets.New[Reason](CreatedDNSRecord, UpdatedDNSRecord), c.emitEvents)
 				require.True(t, c.IsEnabled())
 			},
 		},
@@ -233,3 +260,11 @@ func TestWithKubeConfig(t *testing.T) {
 	require.Equal(t, apiServerURL, cfg.apiServerURL)
 	require.Equal(t, timeout, cfg.timeout)
 }
+
+func TestWithRateLimit(t *testing.T) {
+	cfg := &Config{}
+	WithRateLimit(50, 100)(cfg)
+
+	require.InDelta(t, 50, cfg.qps, 0)
+	require.Equal(t, 100, cfg.burst)
+}
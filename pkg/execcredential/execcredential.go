@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package execcredential obtains credentials from an external command, like the exec credential plugins of
+// kubeconfig files.
+package execcredential
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// refreshRatio is the share of their lifetime after which credentials are obtained again.
+const refreshRatio = 0.8
+
+// Credentials are the credentials printed as JSON by a plugin on its standard output.
+type Credentials struct {
+	// Credentials maps the names of the credentials, the names of their flags or environment variables, to their
+	// values
+	Credentials map[string]string `json:"credentials"`
+	// ExpirationTimestamp is when the credentials expire, if they do
+	ExpirationTimestamp *time.Time `json:"expirationTimestamp,omitempty"`
+}
+
+// RefreshAt returns when the credentials should be obtained again, once most of their lifetime obtained at the given
+// time has elapsed, and false if they don't expire.
+func (c *Credentials) RefreshAt(obtained time.Time) (time.Time, bool) {
+	if c.ExpirationTimestamp == nil {
+		return time.Time{}, false
+	}
+	lifetime := c.ExpirationTimestamp.Sub(obtained)
+	return obtained.Add(time.Duration(float64(lifetime) * refreshRatio)), true
+}
+
+// Plugin is a command printing credentials.
+type Plugin struct {
+	Command string
+	Args    []string
+	// Timeout bounds each run of the command
+	Timeout time.Duration
+}
+
+// Run runs the command and returns the credentials it printed.
+func (p *Plugin) Run(ctx context.Context) (*Credentials, error) {
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("running credential plugin %s: %w: %s", p.Command, err, msg)
+		}
+		return nil, fmt.Errorf("running credential plugin %s: %w", p.Command, err)
+	}
+
+	var creds Credentials
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, fmt.Errorf("decoding the output of credential plugin %s: %w", p.Command, err)
+	}
+	if len(creds.Credentials) == 0 {
+		return nil, fmt.Errorf("credential plugin %s returned no credentials", p.Command)
+	}
+	for name, value := range creds.Credentials {
+		if value == "" {
+			return nil, fmt.Errorf("credential plugin %s returned an empty value of %s", p.Command, name)
+		}
+	}
+	return &creds, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package execcredential
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func shellPlugin(script string) *Plugin {
+	return &Plugin{Command: "/bin/sh", Args: []string{"-c", script}, Timeout: 5 * time.Second}
+}
+
+func TestPluginRun(t *testing.T) {
+	creds, err := shellPlugin(`echo '{"credentials": {"CF_API_TOKEN": "token"}, "expirationTimestamp": "2026-10-17T12:00:00Z"}'`).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"CF_API_TOKEN": "token"}, creds.Credentials)
+	require.NotNil(t, creds.ExpirationTimestamp)
+	assert.Equal(t, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), creds.ExpirationTimestamp.UTC())
+
+	creds, err = shellPlugin(`echo '{"credentials": {"pdns-api-key": "key"}}'`).Run(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, creds.ExpirationTimestamp)
+}
+
+func TestPluginRunErrors(t *testing.T) {
+	for script, msg := range map[string]string{
+		`echo "token expired" >&2; exit 1`:             "exit status 1: token expired",
+		`echo not json`:                                "decoding the output",
+		`echo '{"credentials": {}}'`:                   "returned no credentials",
+		`echo '{"credentials": {"CF_API_TOKEN": ""}}'`: "returned an empty value of CF_API_TOKEN",
+	} {
+		_, err := shellPlugin(script).Run(context.Background())
+		assert.ErrorContains(t, err, msg, script)
+	}
+
+	plugin := shellPlugin("sleep 5")
+	plugin.Timeout = 50 * time.Millisecond
+	_, err := plugin.Run(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCredentialsRefreshAt(t *testing.T) {
+	obtained := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	_, expires := (&Credentials{}).RefreshAt(obtained)
+	assert.False(t, expires)
+
+	expiration := obtained.Add(time.Hour)
+	refreshAt, expires := (&Credentials{ExpirationTimestamp: &expiration}).RefreshAt(obtained)
+	assert.True(t, expires)
+	assert.Equal(t, obtained.Add(48*time.Minute), refreshAt)
+}
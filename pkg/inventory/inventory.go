@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// DataKey is the key of the ConfigMap data holding the records as JSON.
+const DataKey = "records.json"
+
+// Record is a DNS record managed by ExternalDNS, as published in the inventory.
+type Record struct {
+	DNSName       string   `json:"dnsName"`
+	RecordType    string   `json:"recordType"`
+	SetIdentifier string   `json:"setIdentifier,omitempty"`
+	Targets       []string `json:"targets"`
+	TTL           int64    `json:"ttl,omitempty"`
+	// Resource is the Kubernetes resource the record was created for, if known
+	Resource string `json:"resource,omitempty"`
+}
+
+// Writer publishes the records managed by ExternalDNS for other components to consume.
+type Writer interface {
+	// Write publishes the given records, replacing the previously published ones.
+	Write(ctx context.Context, records []*endpoint.Endpoint)
+}
+
+// Writers publishes the records with each of the writers.
+type Writers []Writer
+
+// Write publishes the records with each of the writers.
+func (ws Writers) Write(ctx context.Context, records []*endpoint.Endpoint) {
+	for _, w := range ws {
+		w.Write(ctx, records)
+	}
+}
+
+// ConfigMapWriter publishes the records as JSON in a ConfigMap, which it creates if needed.
+type ConfigMapWriter struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+	dryRun    bool
+
+	mu sync.Mutex
+	// last is the data last written, which isn't written again until it changes
+	last string
+}
+
+// NewConfigMapWriter returns a ConfigMapWriter publishing the records in the given ConfigMap.
+func NewConfigMapWriter(client kubernetes.Interface, namespace, name string, dryRun bool) *ConfigMapWriter {
+	return &ConfigMapWriter{client: client, namespace: namespace, name: name, dryRun: dryRun}
+}
+
+// Write publishes the records in the ConfigMap, sorted by DNS name, record type and set identifier.
+// The ConfigMap is only updated when the records changed. Failures are only logged, as the records
+// themselves were applied.
+func (w *ConfigMapWriter) Write(ctx context.Context, records []*endpoint.Endpoint) {
+	data, err := json.Marshal(toRecords(records))
+	if err != nil {
+		log.Errorf("Failed to encode records inventory: %v", err)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if string(data) == w.last {
+		return
+	}
+	if err := writeConfigMap(ctx, w.client, w.namespace, w.name, DataKey, string(data), w.dryRun); err != nil {
+		log.Warnf("Failed to write records inventory to ConfigMap %s/%s: %v", w.namespace, w.name, err)
+		return
+	}
+	w.last = string(data)
+	log.Debugf("Wrote %d records to inventory ConfigMap %s/%s", len(records), w.namespace, w.name)
+}
+
+// writeConfigMap sets the key of the ConfigMap to the data, creating the ConfigMap if needed.
+func writeConfigMap(ctx context.Context, client kubernetes.Interface, namespace, name, key, data string, dryRun bool) error {
+	var dryRunOption []string
+	if dryRun {
+		dryRunOption = []string{metav1.DryRunAll}
+	}
+	configMaps := client.CoreV1().ConfigMaps(namespace)
+	cm, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "external-dns"},
+			},
+			Data: map[string]string{key: data},
+		}, metav1.CreateOptions{DryRun: dryRunOption})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[key] = data
+	_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{DryRun: dryRunOption})
+	return err
+}
+
+func toRecords(endpoints []*endpoint.Endpoint) []Record {
+	records := make([]Record, 0, len(endpoints))
+	for _, ep := range endpoints {
+		records = append(records, Record{
+			DNSName:       ep.DNSName,
+			RecordType:    ep.RecordType,
+			SetIdentifier: ep.SetIdentifier,
+			Targets:       ep.Targets,
+			TTL:           int64(ep.RecordTTL),
+			Resource:      ep.Labels[endpoint.ResourceLabelKey],
+		})
+	}
+	slices.SortFunc(records, func(a, b Record) int {
+		return cmp.Or(
+			cmp.Compare(a.DNSName, b.DNSName),
+			cmp.Compare(a.RecordType, b.RecordType),
+			cmp.Compare(a.SetIdentifier, b.SetIdentifier),
+		)
+	})
+	return records
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestConfigMapWriter(t *testing.T) {
+	client := fake.NewClientset()
+	w := NewConfigMapWriter(client, "external-dns", "records", false)
+	ctx := context.Background()
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeA, 300, "1.1.1.1").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/default/b"),
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeCNAME, "lb.example.com"),
+	}
+	w.Write(ctx, records)
+
+	cm, err := client.CoreV1().ConfigMaps("external-dns").Get(ctx, "records", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "external-dns", cm.Labels["app.kubernetes.io/managed-by"])
+	var published []Record
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[DataKey]), &published))
+	assert.Equal(t, []Record{
+		{DNSName: "a.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: []string{"lb.example.com"}},
+		{DNSName: "b.example.com", RecordType: endpoint.RecordTypeA, Targets: []string{"1.1.1.1"}, TTL: 300, Resource: "ingress/default/b"},
+	}, published)
+
+	// unchanged records are not written again
+	cm.Data["other"] = "kept"
+	_, err = client.CoreV1().ConfigMaps("external-dns").Update(ctx, cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	actions := len(client.Actions())
+	w.Write(ctx, records)
+	assert.Len(t, client.Actions(), actions)
+
+	w.Write(ctx, records[:1])
+	cm, err = client.CoreV1().ConfigMaps("external-dns").Get(ctx, "records", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[DataKey]), &published))
+	assert.Len(t, published, 1)
+	assert.Equal(t, "kept", cm.Data["other"])
+}
+
+func TestNamespacedConfigMapWriter(t *testing.T) {
+	client := fake.NewClientset()
+	w := NewNamespacedConfigMapWriter(client, "dns-records", false)
+	ctx := context.Background()
+
+	hosts := func(namespace string) map[string][]string {
+		t.Helper()
+		cm, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, "dns-records", metav1.GetOptions{})
+		require.NoError(t, err)
+		var published map[string][]string
+		require.NoError(t, json.Unmarshal([]byte(cm.Data[HostsKey]), &published))
+		return published
+	}
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "2.2.2.2", "1.1.1.1").
+			WithLabel(endpoint.ResourceLabelKey, "service/apps/app"),
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "::1").
+			WithLabel(endpoint.ResourceLabelKey, "service/apps/app"),
+		endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeCNAME, "lb.example.com").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/web/web"),
+		endpoint.NewEndpoint("node.example.com", endpoint.RecordTypeA, "3.3.3.3").
+			WithLabel(endpoint.ResourceLabelKey, "node/node-1"),
+		endpoint.NewEndpoint("manual.example.com", endpoint.RecordTypeA, "4.4.4.4"),
+	}
+	w.Write(ctx, records)
+
+	assert.Equal(t, map[string][]string{"app.example.com": {"1.1.1.1", "2.2.2.2", "::1"}}, hosts("apps"))
+	assert.Equal(t, map[string][]string{"web.example.com": {"lb.example.com"}}, hosts("web"))
+	list, err := client.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 2, "records of cluster-scoped resources or without a resource aren't published")
+
+	// unchanged namespaces are not written again
+	actions := len(client.Actions())
+	w.Write(ctx, records[:3])
+	assert.Len(t, client.Actions(), actions)
+
+	// namespaces without records left are emptied
+	w.Write(ctx, records[2:3])
+	assert.Empty(t, hosts("apps"))
+	assert.Equal(t, map[string][]string{"web.example.com": {"lb.example.com"}}, hosts("web"))
+	actions = len(client.Actions())
+	w.Write(ctx, records[2:3])
+	assert.Len(t, client.Actions(), actions)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// HostsKey is the key of the ConfigMap data holding the hostnames of a namespace and their targets as JSON.
+const HostsKey = "hosts.json"
+
+// NamespacedConfigMapWriter publishes in each namespace a ConfigMap mapping the hostnames of the records of the
+// resources of the namespace to their targets, so that components of the namespace can resolve them without DNS
+// lookups. Records of cluster-scoped resources, or without a resource, aren't published.
+type NamespacedConfigMapWriter struct {
+	client kubernetes.Interface
+	name   string
+	dryRun bool
+
+	mu sync.Mutex
+	// last is the data last written to each namespace, which isn't written again until it changes
+	last map[string]string
+}
+
+// NewNamespacedConfigMapWriter returns a NamespacedConfigMapWriter publishing the hostnames in the ConfigMap of the
+// given name of each namespace.
+func NewNamespacedConfigMapWriter(client kubernetes.Interface, name string, dryRun bool) *NamespacedConfigMapWriter {
+	return &NamespacedConfigMapWriter{client: client, name: name, dryRun: dryRun, last: map[string]string{}}
+}
+
+// Write publishes the hostnames of each namespace, with the targets of all their records sorted. The ConfigMap of
+// a namespace is only updated when its hostnames changed, and emptied once the namespace has no records left.
+// Failures are only logged, as the records themselves were applied.
+func (w *NamespacedConfigMapWriter) Write(ctx context.Context, records []*endpoint.Endpoint) {
+	hosts := namespaceHosts(records)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for namespace := range w.last {
+		if _, ok := hosts[namespace]; !ok {
+			hosts[namespace] = map[string][]string{}
+		}
+	}
+	for namespace, targets := range hosts {
+		data, err := json.Marshal(targets)
+		if err != nil {
+			log.Errorf("Failed to encode hostnames of namespace %s: %v", namespace, err)
+			continue
+		}
+		if last, ok := w.last[namespace]; ok && last == string(data) {
+			continue
+		}
+		if err := writeConfigMap(ctx, w.client, namespace, w.name, HostsKey, string(data), w.dryRun); err != nil {
+			log.Warnf("Failed to write hostnames to ConfigMap %s/%s: %v", namespace, w.name, err)
+			continue
+		}
+		if len(targets) == 0 {
+			delete(w.last, namespace)
+		} else {
+			w.last[namespace] = string(data)
+		}
+		log.Debugf("Wrote %d hostnames to ConfigMap %s/%s", len(targets), namespace, w.name)
+	}
+}
+
+// namespaceHosts maps the namespaces of the resources of the records to their hostnames and targets.
+func namespaceHosts(records []*endpoint.Endpoint) map[string]map[string][]string {
+	hosts := map[string]map[string][]string{}
+	for _, ep := range records {
+		parts := strings.Split(ep.Labels[endpoint.ResourceLabelKey], "/")
+		if len(parts) != 3 || parts[1] == "" {
+			continue
+		}
+		namespace := parts[1]
+		if hosts[namespace] == nil {
+			hosts[namespace] = map[string][]string{}
+		}
+		targets := slices.Concat(hosts[namespace][ep.DNSName], ep.Targets)
+		slices.Sort(targets)
+		hosts[namespace][ep.DNSName] = slices.Compact(targets)
+	}
+	return hosts
+}
@@ -62,8 +62,9 @@ func TestMustRegister(t *testing.T) {
 				NewCounterVecWithOpts(prometheus.CounterOpts{Name: "test_counter_vec_3"}, []string{"label"}),
 				NewGaugedVectorOpts(prometheus.GaugeOpts{Name: "test_gauge_v_3"}, []string{"label"}),
 				NewSummaryVecWithOpts(prometheus.SummaryOpts{Name: "test_summary_v_3"}, []string{"label"}),
+				NewHistogramVecWithOpts(prometheus.HistogramOpts{Name: "test_histogram_v_3"}, []string{"label"}),
 			},
-			expected: 5,
+			expected: 6,
 		},
 		{
 			name: "unsupported metric",
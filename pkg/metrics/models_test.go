@@ -219,6 +219,26 @@ func TestSummaryV_SetWithLabels(t *testing.T) {
 	assert.Len(t, metricsFamilies[0].Metric[0].Label, 2)
 }
 
+func TestNewHistogramVecWithOpts(t *testing.T) {
+	hv := NewHistogramVecWithOpts(prometheus.HistogramOpts{
+		Name:      "test_histogram_vec",
+		Subsystem: "test_subsystem",
+		Help:      "This is a test histogram vector",
+	}, []string{"label"})
+
+	assert.Equal(t, "histogramVec", hv.Type)
+	assert.Equal(t, Namespace, hv.Namespace)
+	assert.Equal(t, "test_subsystem_test_histogram_vec", hv.FQDN)
+
+	hv.HistogramVec.WithLabelValues("alpha").Observe(0.2)
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(hv.HistogramVec)
+	metricsFamilies, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, metricsFamilies, 1)
+	assert.Equal(t, uint64(1), metricsFamilies[0].Metric[0].Histogram.GetSampleCount())
+}
+
 func TestPathProcessor(t *testing.T) {
 	tests := []struct {
 		input    string
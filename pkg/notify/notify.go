@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notify posts a summary of the synchronizations which changed DNS records or failed to a webhook.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	FormatJSON  = "json"
+	FormatSlack = "slack"
+
+	// maxListed is the number of changes listed in the text of a notification
+	maxListed = 20
+)
+
+// slackTemplate renders a notification as a Slack incoming webhook message.
+const slackTemplate = `{"text": {{ json .Text }}}`
+
+// Notification summarizes a synchronization which changed DNS records or failed.
+type Notification struct {
+	Time    time.Time `json:"time"`
+	OwnerID string    `json:"ownerID,omitempty"`
+	DryRun  bool      `json:"dryRun,omitempty"`
+	Created []Record  `json:"created,omitempty"`
+	Updated []Record  `json:"updated,omitempty"`
+	Deleted []Record  `json:"deleted,omitempty"`
+	// Error is the error of the synchronization, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// Record is a DNS record which was created, updated or deleted, with its targets after the change.
+type Record struct {
+	DNSName       string   `json:"dnsName"`
+	RecordType    string   `json:"recordType"`
+	SetIdentifier string   `json:"setIdentifier,omitempty"`
+	Targets       []string `json:"targets"`
+}
+
+// Text returns a human readable summary of the notification, listing up to 20 changes.
+func (n Notification) Text() string {
+	var b strings.Builder
+	if n.Error != "" {
+		b.WriteString("ExternalDNS synchronization failed")
+	} else {
+		b.WriteString("ExternalDNS synchronization applied changes")
+	}
+	if n.OwnerID != "" {
+		fmt.Fprintf(&b, " (%s)", n.OwnerID)
+	}
+	if n.DryRun {
+		b.WriteString(" [dry run]")
+	}
+	fmt.Fprintf(&b, ": %d created, %d updated, %d deleted", len(n.Created), len(n.Updated), len(n.Deleted))
+
+	listed := 0
+	for _, group := range []struct {
+		sign    string
+		records []Record
+	}{{"+", n.Created}, {"~", n.Updated}, {"-", n.Deleted}} {
+		for _, r := range group.records {
+			if listed == maxListed {
+				break
+			}
+			fmt.Fprintf(&b, "\n%s %s %s %s", group.sign, r.DNSName, r.RecordType, strings.Join(r.Targets, ","))
+			listed++
+		}
+	}
+	if total := len(n.Created) + len(n.Updated) + len(n.Deleted); total > listed {
+		fmt.Fprintf(&b, "\n... and %d more", total-listed)
+	}
+	if n.Error != "" {
+		fmt.Fprintf(&b, "\nError: %s", n.Error)
+	}
+	return b.String()
+}
+
+// Notifier notifies of the synchronizations which changed DNS records or failed.
+type Notifier interface {
+	// Notify notifies of the given applied changes and of the error of the synchronization, if any.
+	Notify(ctx context.Context, applied *plan.Changes, err error)
+}
+
+// WebhookNotifier posts the notifications to a webhook, as JSON or rendered by a template.
+type WebhookNotifier struct {
+	url      string
+	template *template.Template
+	client   *http.Client
+	ownerID  string
+	dryRun   bool
+	now      func() time.Time
+}
+
+// NewWebhookNotifier returns a WebhookNotifier posting to the given URL. The body is rendered by the given Go
+// template if any, or else by the built-in template of the given format.
+func NewWebhookNotifier(url, format, tmpl, ownerID string, dryRun bool) (*WebhookNotifier, error) {
+	if tmpl == "" && format == FormatSlack {
+		tmpl = slackTemplate
+	}
+	n := &WebhookNotifier{
+		url:     url,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		ownerID: ownerID,
+		dryRun:  dryRun,
+		now:     time.Now,
+	}
+	if tmpl != "" {
+		t, err := template.New("notification").Funcs(template.FuncMap{"json": toJSON}).Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("parsing notification template: %w", err)
+		}
+		n.template = t
+	}
+	return n, nil
+}
+
+// Notify posts a notification of the changes and the error. Failures are only logged, as they don't affect
+// the DNS records.
+func (n *WebhookNotifier) Notify(ctx context.Context, applied *plan.Changes, err error) {
+	notification := Notification{
+		Time:    n.now().UTC(),
+		OwnerID: n.ownerID,
+		DryRun:  n.dryRun,
+		Created: toRecords(applied.Create),
+		Updated: toRecords(applied.UpdateNew),
+		Deleted: toRecords(applied.Delete),
+	}
+	if err != nil {
+		notification.Error = err.Error()
+	}
+	if err := n.post(ctx, notification); err != nil {
+		log.Warnf("Failed to post notification: %v", err)
+	}
+}
+
+func (n *WebhookNotifier) post(ctx context.Context, notification Notification) error {
+	var body bytes.Buffer
+	if n.template != nil {
+		if err := n.template.Execute(&body, notification); err != nil {
+			return fmt.Errorf("rendering notification: %w", err)
+		}
+	} else if err := json.NewEncoder(&body).Encode(notification); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func toRecords(endpoints []*endpoint.Endpoint) []Record {
+	records := make([]Record, 0, len(endpoints))
+	for _, ep := range endpoints {
+		records = append(records, Record{
+			DNSName:       ep.DNSName,
+			RecordType:    ep.RecordType,
+			SetIdentifier: ep.SetIdentifier,
+			Targets:       ep.Targets,
+		})
+	}
+	return records
+}
+
+// toJSON encodes a value as JSON within templates, for example to quote a string.
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// webhook returns a server recording the bodies posted to it.
+func webhook(t *testing.T, status int) (*httptest.Server, *[]string) {
+	t.Helper()
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func testChanges() *plan.Changes {
+	return &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.1.1.1")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "2.2.2.2")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "3.3.3.3")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeCNAME, "app.example.com")},
+	}
+}
+
+func TestWebhookNotifierJSON(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	server, bodies := webhook(t, http.StatusOK)
+	n, err := NewWebhookNotifier(server.URL, FormatJSON, "", "owner", false)
+	require.NoError(t, err)
+	n.now = func() time.Time { return now }
+
+	n.Notify(context.Background(), testChanges(), errors.New("zone failure"))
+
+	require.Len(t, *bodies, 1)
+	var notification Notification
+	require.NoError(t, json.Unmarshal([]byte((*bodies)[0]), &notification))
+	assert.Equal(t, Notification{
+		Time:    now,
+		OwnerID: "owner",
+		Created: []Record{{DNSName: "new.example.com", RecordType: endpoint.RecordTypeA, Targets: []string{"1.1.1.1"}}},
+		Updated: []Record{{DNSName: "app.example.com", RecordType: endpoint.RecordTypeA, Targets: []string{"3.3.3.3"}}},
+		Deleted: []Record{{DNSName: "old.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: []string{"app.example.com"}}},
+		Error:   "zone failure",
+	}, notification)
+}
+
+func TestWebhookNotifierSlack(t *testing.T) {
+	server, bodies := webhook(t, http.StatusOK)
+	n, err := NewWebhookNotifier(server.URL, FormatSlack, "", "owner", true)
+	require.NoError(t, err)
+
+	n.Notify(context.Background(), testChanges(), nil)
+
+	require.Len(t, *bodies, 1)
+	var message struct {
+		Text string `json:"text"`
+	}
+	require.NoError(t, json.Unmarshal([]byte((*bodies)[0]), &message))
+	assert.Equal(t, `ExternalDNS synchronization applied changes (owner) [dry run]: 1 created, 1 updated, 1 deleted
++ new.example.com A 1.1.1.1
+~ app.example.com A 3.3.3.3
+- old.example.com CNAME app.example.com`, message.Text)
+}
+
+func TestWebhookNotifierTemplate(t *testing.T) {
+	server, bodies := webhook(t, http.StatusInternalServerError)
+	n, err := NewWebhookNotifier(server.URL, FormatSlack, `{"created": {{ len .Created }}, "error": {{ json .Error }}}`, "", false)
+	require.NoError(t, err)
+
+	// failures are only logged
+	n.Notify(context.Background(), testChanges(), errors.New(`"quoted"`))
+	assert.Equal(t, []string{`{"created": 1, "error": "\"quoted\""}`}, *bodies)
+
+	_, err = NewWebhookNotifier(server.URL, FormatJSON, "{{ .Missing", "", false)
+	require.Error(t, err)
+}
+
+func TestNotificationTextTruncated(t *testing.T) {
+	n := Notification{}
+	for i := range 25 {
+		n.Created = append(n.Created, Record{DNSName: fmt.Sprintf("%d.example.com", i), RecordType: endpoint.RecordTypeA})
+	}
+	lines := strings.Split(n.Text(), "\n")
+	require.Len(t, lines, 22)
+	assert.Equal(t, "... and 5 more", lines[21])
+}
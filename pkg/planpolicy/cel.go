@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planpolicy
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/cel-go/cel"
+	"sigs.k8s.io/yaml"
+)
+
+// Rule gives its verdict to the changes for which its CEL expression is true.
+type Rule struct {
+	// Name identifies the rule in the logs, metrics and messages
+	Name string `json:"name"`
+	// Expression is a CEL expression over the action, zone, record and previous variables
+	Expression string `json:"expression"`
+	// Verdict is deny or annotate
+	Verdict string `json:"verdict"`
+	// Message explains the verdict
+	Message string `json:"message,omitempty"`
+}
+
+// Rules is the content of a policy rules file.
+type Rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+// celRule is a rule with its compiled expression.
+type celRule struct {
+	Rule
+	program cel.Program
+}
+
+// CELEvaluator evaluates CEL rules over the changes.
+type CELEvaluator struct {
+	rules []celRule
+}
+
+// LoadRules reads policy rules from a YAML file and compiles them.
+func LoadRules(path string) (*CELEvaluator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy rules: %w", err)
+	}
+	var rules Rules
+	if err := yaml.UnmarshalStrict(data, &rules); err != nil {
+		return nil, fmt.Errorf("parsing policy rules %s: %w", path, err)
+	}
+	return NewCELEvaluator(rules.Rules)
+}
+
+// NewCELEvaluator compiles the rules, whose expressions must be boolean. Their variables are:
+//
+//   - action: create, update or delete
+//   - zone: the longest known zone of the DNS name, empty if none matches
+//   - record: the record after the change, or the deleted record, with the dnsName, recordType, setIdentifier,
+//     targets, ttl and labels keys
+//   - previous: the record before an update, empty for the other actions
+func NewCELEvaluator(rules []Rule) (*CELEvaluator, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("action", cel.StringType),
+		cel.Variable("zone", cel.StringType),
+		cel.Variable("record", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("previous", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	e := &CELEvaluator{}
+	names := map[string]bool{}
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("policy rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate policy rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.Verdict != VerdictDeny && rule.Verdict != VerdictAnnotate {
+			return nil, fmt.Errorf("policy rule %q: invalid verdict %q, expected deny or annotate", rule.Name, rule.Verdict)
+		}
+		ast, issues := env.Compile(rule.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("policy rule %q: %w", rule.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("policy rule %q: expression must be boolean, not %s", rule.Name, ast.OutputType())
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("policy rule %q: %w", rule.Name, err)
+		}
+		e.rules = append(e.rules, celRule{Rule: rule, program: program})
+	}
+	return e, nil
+}
+
+// Evaluate gives the verdict of every rule whose expression is true for a change. An expression failing to
+// evaluate, e.g. on a missing label, fails the evaluation.
+func (e *CELEvaluator) Evaluate(ctx context.Context, changes []Change) ([]Verdict, error) {
+	var verdicts []Verdict
+	for i, change := range changes {
+		previous := map[string]any{}
+		if change.Previous != nil {
+			previous = recordValue(*change.Previous)
+		}
+		vars := map[string]any{
+			"action":   change.Action,
+			"zone":     change.Zone,
+			"record":   recordValue(change.Record),
+			"previous": previous,
+		}
+		for _, rule := range e.rules {
+			out, _, err := rule.program.ContextEval(ctx, vars)
+			if err != nil {
+				return nil, fmt.Errorf("policy rule %q on %s: %w", rule.Name, change.Record.DNSName, err)
+			}
+			if matched, _ := out.Value().(bool); matched {
+				verdicts = append(verdicts, Verdict{Index: i, Verdict: rule.Verdict, Rule: rule.Name, Message: rule.Message})
+			}
+		}
+	}
+	return verdicts, nil
+}
+
+// recordValue returns the record as a CEL map.
+func recordValue(r Record) map[string]any {
+	return map[string]any{
+		"dnsName":       r.DNSName,
+		"recordType":    r.RecordType,
+		"setIdentifier": r.SetIdentifier,
+		"targets":       r.Targets,
+		"ttl":           r.TTL,
+		"labels":        r.Labels,
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planpolicy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCELEvaluator(t *testing.T) {
+	evaluator, err := NewCELEvaluator([]Rule{
+		{Name: "no-prod-wildcards", Expression: `record.dnsName.startsWith("*.") && zone.endsWith("prod.example.com")`, Verdict: VerdictDeny, Message: "no wildcards in production"},
+		{Name: "low-ttl", Expression: `action != "delete" && record.ttl > 0 && record.ttl < 60`, Verdict: VerdictAnnotate},
+		{Name: "team", Expression: `"team" in record.labels && record.labels["team"] == "legacy"`, Verdict: VerdictDeny},
+		{Name: "retarget", Expression: `action == "update" && previous.targets != record.targets && size(record.targets) < size(previous.targets)`, Verdict: VerdictAnnotate},
+	})
+	require.NoError(t, err)
+
+	verdicts, err := evaluator.Evaluate(context.Background(), []Change{
+		{Action: "create", Zone: "prod.example.com", Record: Record{DNSName: "*.prod.example.com", Targets: []string{"192.0.2.1"}, TTL: 30}},
+		{Action: "create", Zone: "dev.example.com", Record: Record{DNSName: "*.dev.example.com", Targets: []string{"192.0.2.1"}}},
+		{Action: "delete", Record: Record{DNSName: "old.example.com", TTL: 30, Labels: map[string]string{"team": "legacy"}}},
+		{
+			Action:   "update",
+			Record:   Record{DNSName: "lb.example.com", Targets: []string{"192.0.2.1"}},
+			Previous: &Record{DNSName: "lb.example.com", Targets: []string{"192.0.2.1", "192.0.2.2"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Verdict{
+		{Index: 0, Verdict: VerdictDeny, Rule: "no-prod-wildcards", Message: "no wildcards in production"},
+		{Index: 0, Verdict: VerdictAnnotate, Rule: "low-ttl"},
+		{Index: 2, Verdict: VerdictDeny, Rule: "team"},
+		{Index: 3, Verdict: VerdictAnnotate, Rule: "retarget"},
+	}, verdicts)
+}
+
+func TestCELEvaluatorEvaluationError(t *testing.T) {
+	evaluator, err := NewCELEvaluator([]Rule{{Name: "team", Expression: `record.labels["team"] == "legacy"`, Verdict: VerdictDeny}})
+	require.NoError(t, err)
+
+	_, err = evaluator.Evaluate(context.Background(), []Change{{Action: "create", Record: Record{DNSName: "www.example.com"}}})
+	require.Error(t, err)
+}
+
+func TestNewCELEvaluatorInvalidRules(t *testing.T) {
+	for name, rule := range map[string]Rule{
+		"no name":        {Expression: "true", Verdict: VerdictDeny},
+		"invalid syntax": {Name: "rule", Expression: "record.dnsName ==", Verdict: VerdictDeny},
+		"unknown field":  {Name: "rule", Expression: "unknown == 1", Verdict: VerdictDeny},
+		"not boolean":    {Name: "rule", Expression: "record.dnsName", Verdict: VerdictDeny},
+		"invalid":        {Name: "rule", Expression: "true", Verdict: "allow"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewCELEvaluator([]Rule{rule})
+			require.Error(t, err)
+		})
+	}
+
+	_, err := NewCELEvaluator([]Rule{{Name: "rule", Expression: "true", Verdict: VerdictDeny}, {Name: "rule", Expression: "false", Verdict: VerdictDeny}})
+	require.Error(t, err)
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`rules:
+  - name: no-prod-wildcards
+    expression: record.dnsName.startsWith("*.") && zone == "prod.example.com"
+    verdict: deny
+    message: no wildcards in production
+`), 0o600))
+
+	evaluator, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, evaluator.rules, 1)
+	assert.Equal(t, "no wildcards in production", evaluator.rules[0].Message)
+
+	require.NoError(t, os.WriteFile(path, []byte("rules:\n  - name: rule\n    expresion: true\n"), 0o600))
+	_, err = LoadRules(path)
+	require.Error(t, err)
+
+	_, err = LoadRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxResponseSize bounds the responses read from the OPA endpoint.
+const maxResponseSize = 10 << 20
+
+// opaInput is the input document of the OPA query.
+type opaInput struct {
+	Input struct {
+		Changes []Change `json:"changes"`
+	} `json:"input"`
+}
+
+// opaResult is the response of the OPA data API. An undefined document has no result.
+type opaResult struct {
+	Result []Verdict `json:"result"`
+}
+
+// OPAEvaluator queries an OPA data API endpoint with the changes, e.g.
+// http://opa:8181/v1/data/externaldns/verdicts, whose document must be the list of verdicts.
+type OPAEvaluator struct {
+	url    string
+	client *http.Client
+}
+
+// NewOPAEvaluator returns an evaluator querying the OPA document at the given URL.
+func NewOPAEvaluator(url string) *OPAEvaluator {
+	return &OPAEvaluator{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Evaluate posts the changes as the input.changes of the query, and returns the verdicts of the document.
+func (e *OPAEvaluator) Evaluate(ctx context.Context, changes []Change) ([]Verdict, error) {
+	var input opaInput
+	input.Input.Changes = changes
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying OPA: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("querying OPA: unexpected status %s", resp.Status)
+	}
+	var result opaResult
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding OPA response: %w", err)
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOPAEvaluator(t *testing.T) {
+	var input opaInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/data/externaldns/verdicts", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		_, _ = w.Write([]byte(`{"result": [{"index": 0, "verdict": "deny", "rule": "no-prod-wildcards", "message": "no wildcards in production"}]}`))
+	}))
+	defer server.Close()
+
+	evaluator := NewOPAEvaluator(server.URL + "/v1/data/externaldns/verdicts")
+	verdicts, err := evaluator.Evaluate(context.Background(), []Change{
+		{Action: "create", Zone: "prod.example.com", Record: Record{DNSName: "*.prod.example.com", RecordType: "A", Targets: []string{"192.0.2.1"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Verdict{{Index: 0, Verdict: VerdictDeny, Rule: "no-prod-wildcards", Message: "no wildcards in production"}}, verdicts)
+
+	require.Len(t, input.Input.Changes, 1)
+	assert.Equal(t, "*.prod.example.com", input.Input.Changes[0].Record.DNSName)
+	assert.Equal(t, "prod.example.com", input.Input.Changes[0].Zone)
+}
+
+func TestOPAEvaluatorUndefinedDocument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	verdicts, err := NewOPAEvaluator(server.URL).Evaluate(context.Background(), []Change{{Action: "create"}})
+	require.NoError(t, err)
+	assert.Empty(t, verdicts)
+}
+
+func TestOPAEvaluatorErrors(t *testing.T) {
+	for name, handler := range map[string]http.HandlerFunc{
+		"status": func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "policy error", http.StatusInternalServerError)
+		},
+		"invalid response": func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"result": true}`))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			_, err := NewOPAEvaluator(server.URL).Evaluate(context.Background(), []Change{{Action: "create"}})
+			require.Error(t, err)
+		})
+	}
+}
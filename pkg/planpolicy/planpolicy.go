@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package planpolicy evaluates organization policies over the changes of a plan, with CEL expressions or an
+// OPA endpoint, so that rules such as "no wildcard records in production zones" are enforced centrally.
+package planpolicy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// Verdicts a policy may give to a change.
+const (
+	// VerdictDeny leaves the change out of the plan
+	VerdictDeny = "deny"
+	// VerdictAnnotate keeps the change, and attaches the message of the verdict to the plan
+	VerdictAnnotate = "annotate"
+)
+
+var verdictsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Subsystem: "policy",
+		Name:      "verdicts_total",
+		Help:      "Number of verdicts given by the policy rules to the planned changes, by verdict and rule (vector).",
+	},
+	[]string{"verdict", "rule"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(verdictsTotal)
+}
+
+// Change is a planned change submitted to the policies.
+type Change struct {
+	// Action is create, update or delete
+	Action string `json:"action"`
+	// Zone is the longest known zone of the DNS name, empty if none matches
+	Zone string `json:"zone"`
+	// Record is the record after the change, or the deleted record
+	Record Record `json:"record"`
+	// Previous is the record before an update
+	Previous *Record `json:"previous,omitempty"`
+}
+
+// Record is the DNS record of a change.
+type Record struct {
+	DNSName       string            `json:"dnsName"`
+	RecordType    string            `json:"recordType"`
+	SetIdentifier string            `json:"setIdentifier"`
+	Targets       []string          `json:"targets"`
+	TTL           int64             `json:"ttl"`
+	Labels        map[string]string `json:"labels"`
+}
+
+// Verdict is given by a policy rule to the change at Index of the evaluated changes.
+type Verdict struct {
+	Index   int    `json:"index"`
+	Verdict string `json:"verdict"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// describe returns the message of the verdict, prefixed by its rule.
+func (v Verdict) describe() string {
+	switch {
+	case v.Rule == "":
+		return v.Message
+	case v.Message == "":
+		return "rule " + v.Rule
+	}
+	return v.Rule + ": " + v.Message
+}
+
+// Evaluator gives the verdicts of the policies to the changes of a plan. Changes without a verdict are allowed.
+type Evaluator interface {
+	Evaluate(ctx context.Context, changes []Change) ([]Verdict, error)
+}
+
+// Evaluators evaluates the changes with each of its evaluators, and returns all their verdicts.
+type Evaluators []Evaluator
+
+func (e Evaluators) Evaluate(ctx context.Context, changes []Change) ([]Verdict, error) {
+	var verdicts []Verdict
+	for _, evaluator := range e {
+		v, err := evaluator.Evaluate(ctx, changes)
+		if err != nil {
+			return nil, err
+		}
+		verdicts = append(verdicts, v...)
+	}
+	return verdicts, nil
+}
+
+// Apply evaluates the changes of the calculated plan, whose DNS names belong to the given zones. Denied changes
+// are removed from the plan and recorded as skipped with plan.SkipReasonPolicyRule, and annotated ones recorded
+// in its annotations. Failing to evaluate the changes fails, rather than applying changes the policies may deny.
+func Apply(ctx context.Context, evaluator Evaluator, p *plan.Plan, zones []string) error {
+	changes, endpoints := planChanges(p.Changes, zones)
+	if len(changes) == 0 {
+		return nil
+	}
+	verdicts, err := evaluator.Evaluate(ctx, changes)
+	if err != nil {
+		return fmt.Errorf("evaluating policies: %w", err)
+	}
+
+	denied := map[int]Verdict{}
+	for _, v := range verdicts {
+		if v.Index < 0 || v.Index >= len(changes) {
+			return fmt.Errorf("policy verdict for unknown change %d", v.Index)
+		}
+		switch v.Verdict {
+		case VerdictDeny:
+			if _, ok := denied[v.Index]; !ok {
+				denied[v.Index] = v
+			}
+		case VerdictAnnotate:
+			log.WithField("endpoint", endpoints[v.Index]).Warnf("Policy annotated the %s of %s: %s", changes[v.Index].Action, changes[v.Index].Record.DNSName, v.describe())
+			p.Annotations = append(p.Annotations, plan.Annotation{Endpoint: endpoints[v.Index], Action: changes[v.Index].Action, Message: v.describe()})
+		default:
+			return fmt.Errorf("invalid policy verdict %q for change %d, expected deny or annotate", v.Verdict, v.Index)
+		}
+		verdictsTotal.CounterVec.WithLabelValues(v.Verdict, v.Rule).Inc()
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+
+	allowed := &plan.Changes{}
+	for i, change := range changes {
+		ep := endpoints[i]
+		if v, ok := denied[i]; ok {
+			log.WithField("endpoint", ep).Warnf("Policy denied the %s of %s: %s", change.Action, change.Record.DNSName, v.describe())
+			p.Skipped = append(p.Skipped, plan.Skipped{Endpoint: ep, Reason: plan.SkipReasonPolicyRule, Action: change.Action, Message: v.describe()})
+			continue
+		}
+		switch change.Action {
+		case plan.ActionCreate:
+			allowed.Create = append(allowed.Create, ep)
+		case plan.ActionUpdate:
+			allowed.UpdateOld = append(allowed.UpdateOld, p.Changes.UpdateOld[i-len(p.Changes.Create)])
+			allowed.UpdateNew = append(allowed.UpdateNew, ep)
+		case plan.ActionDelete:
+			allowed.Delete = append(allowed.Delete, ep)
+		}
+	}
+	p.Changes = allowed
+	return nil
+}
+
+// planChanges returns the changes to evaluate, the creations first, then the updates and the deletions, along
+// with the endpoint of each: the record after an update.
+func planChanges(changes *plan.Changes, zones []string) ([]Change, []*endpoint.Endpoint) {
+	if changes == nil {
+		return nil, nil
+	}
+	var (
+		result    []Change
+		endpoints []*endpoint.Endpoint
+	)
+	add := func(action string, ep, previous *endpoint.Endpoint) {
+		change := Change{Action: action, Zone: findZone(zones, ep.DNSName), Record: newRecord(ep)}
+		if previous != nil {
+			r := newRecord(previous)
+			change.Previous = &r
+		}
+		result = append(result, change)
+		endpoints = append(endpoints, ep)
+	}
+	for _, ep := range changes.Create {
+		add(plan.ActionCreate, ep, nil)
+	}
+	for i, ep := range changes.UpdateNew {
+		add(plan.ActionUpdate, ep, changes.UpdateOld[i])
+	}
+	for _, ep := range changes.Delete {
+		add(plan.ActionDelete, ep, nil)
+	}
+	return result, endpoints
+}
+
+func newRecord(ep *endpoint.Endpoint) Record {
+	labels := make(map[string]string, len(ep.Labels))
+	for key, value := range ep.Labels {
+		labels[key] = value
+	}
+	targets := make([]string, len(ep.Targets))
+	copy(targets, ep.Targets)
+	return Record{
+		DNSName:       ep.DNSName,
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+		Targets:       targets,
+		TTL:           int64(ep.RecordTTL),
+		Labels:        labels,
+	}
+}
+
+// findZone returns the longest of the zones the given DNS name belongs to.
+func findZone(zones []string, dnsName string) string {
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	var found string
+	for _, z := range zones {
+		z = strings.ToLower(strings.Trim(z, "."))
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(found) {
+			found = z
+		}
+	}
+	return found
+}
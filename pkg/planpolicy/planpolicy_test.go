@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planpolicy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeEvaluator gives fixed verdicts, and records the changes it evaluated.
+type fakeEvaluator struct {
+	verdicts []Verdict
+	err      error
+	changes  []Change
+}
+
+func (e *fakeEvaluator) Evaluate(_ context.Context, changes []Change) ([]Verdict, error) {
+	e.changes = changes
+	return e.verdicts, e.err
+}
+
+func testPlan() *plan.Plan {
+	return &plan.Plan{Changes: &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("*.prod.example.com", endpoint.RecordTypeA, "192.0.2.1"), endpoint.NewEndpoint("www.dev.example.com", endpoint.RecordTypeA, "192.0.2.2")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("api.prod.example.com", endpoint.RecordTypeCNAME, "old.example.net")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("api.prod.example.com", endpoint.RecordTypeCNAME, "new.example.net")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.org", endpoint.RecordTypeA, "192.0.2.3")},
+	}}
+}
+
+func TestApply(t *testing.T) {
+	p := testPlan()
+	evaluator := &fakeEvaluator{verdicts: []Verdict{
+		{Index: 0, Verdict: VerdictDeny, Rule: "no-prod-wildcards", Message: "wildcards are not allowed in production"},
+		{Index: 2, Verdict: VerdictDeny, Rule: "frozen"},
+		{Index: 3, Verdict: VerdictAnnotate, Message: "deleting a record outside the zones"},
+	}}
+
+	require.NoError(t, Apply(context.Background(), evaluator, p, []string{"example.com", "prod.example.com."}))
+
+	require.Len(t, evaluator.changes, 4)
+	assert.Equal(t, "prod.example.com", evaluator.changes[0].Zone)
+	assert.Equal(t, "example.com", evaluator.changes[1].Zone)
+	assert.Equal(t, plan.ActionUpdate, evaluator.changes[2].Action)
+	assert.Equal(t, []string{"old.example.net"}, evaluator.changes[2].Previous.Targets)
+	assert.Equal(t, []string{"new.example.net"}, evaluator.changes[2].Record.Targets)
+	assert.Empty(t, evaluator.changes[3].Zone)
+
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("www.dev.example.com", endpoint.RecordTypeA, "192.0.2.2")}, p.Changes.Create)
+	assert.Empty(t, p.Changes.UpdateOld)
+	assert.Empty(t, p.Changes.UpdateNew)
+	assert.Len(t, p.Changes.Delete, 1)
+
+	require.Len(t, p.Skipped, 2)
+	assert.Equal(t, plan.SkipReasonPolicyRule, p.Skipped[0].Reason)
+	assert.Equal(t, plan.ActionCreate, p.Skipped[0].Action)
+	assert.Equal(t, "no-prod-wildcards: wildcards are not allowed in production", p.Skipped[0].Message)
+	assert.Equal(t, plan.ActionUpdate, p.Skipped[1].Action)
+	assert.Equal(t, "rule frozen", p.Skipped[1].Message)
+	assert.Equal(t, "api.prod.example.com", p.Skipped[1].Endpoint.DNSName)
+
+	require.Len(t, p.Annotations, 1)
+	assert.Equal(t, plan.ActionDelete, p.Annotations[0].Action)
+	assert.Equal(t, "deleting a record outside the zones", p.Annotations[0].Message)
+}
+
+func TestApplyKeepsUpdatePairs(t *testing.T) {
+	p := &plan.Plan{Changes: &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.1")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.2"), endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "192.0.2.3")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.4"), endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "192.0.2.5")},
+	}}
+
+	require.NoError(t, Apply(context.Background(), &fakeEvaluator{verdicts: []Verdict{{Index: 1, Verdict: VerdictDeny}}}, p, nil))
+
+	assert.Len(t, p.Changes.Create, 1)
+	require.Len(t, p.Changes.UpdateOld, 1)
+	assert.Equal(t, "b.example.com", p.Changes.UpdateOld[0].DNSName)
+	assert.Equal(t, "b.example.com", p.Changes.UpdateNew[0].DNSName)
+}
+
+func TestApplyErrors(t *testing.T) {
+	for name, evaluator := range map[string]*fakeEvaluator{
+		"evaluation failed": {err: errors.New("unavailable")},
+		"unknown change":    {verdicts: []Verdict{{Index: 4, Verdict: VerdictDeny}}},
+		"invalid verdict":   {verdicts: []Verdict{{Index: 0, Verdict: "allow"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := testPlan()
+			require.Error(t, Apply(context.Background(), evaluator, p, nil))
+		})
+	}
+}
+
+func TestApplyWithoutChanges(t *testing.T) {
+	evaluator := &fakeEvaluator{err: errors.New("unavailable")}
+	require.NoError(t, Apply(context.Background(), evaluator, &plan.Plan{Changes: &plan.Changes{}}, nil))
+	assert.Nil(t, evaluator.changes)
+}
+
+func TestEvaluators(t *testing.T) {
+	evaluators := Evaluators{
+		&fakeEvaluator{verdicts: []Verdict{{Index: 0, Verdict: VerdictDeny}}},
+		&fakeEvaluator{verdicts: []Verdict{{Index: 1, Verdict: VerdictAnnotate}}},
+	}
+	verdicts, err := evaluators.Evaluate(context.Background(), []Change{{}, {}})
+	require.NoError(t, err)
+	assert.Len(t, verdicts, 2)
+
+	evaluators = append(evaluators, &fakeEvaluator{err: errors.New("unavailable")})
+	_, err = evaluators.Evaluate(context.Background(), []Change{{}, {}})
+	require.Error(t, err)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publish
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sigs.k8s.io/external-dns/pkg/audit"
+)
+
+// cloudEventTypePrefix prefixes the types of the CloudEvents, followed by the outcome of the change.
+const cloudEventTypePrefix = "io.k8s.sigs.external-dns.record."
+
+// CloudEvents types of the changes, by action for applied changes.
+var cloudEventTypes = map[string]string{
+	audit.ActionCreate: cloudEventTypePrefix + "created",
+	audit.ActionUpdate: cloudEventTypePrefix + "updated",
+	audit.ActionDelete: cloudEventTypePrefix + "deleted",
+}
+
+// cloudEventTypeFailed is the CloudEvents type of the changes which failed to apply.
+const cloudEventTypeFailed = cloudEventTypePrefix + "failed"
+
+type cloudEventsTransport struct {
+	sink   string
+	source string
+	client *http.Client
+	newID  func() string
+}
+
+// NewCloudEventsPublisher returns a BrokerPublisher posting the messages as CloudEvents to the given sink, such
+// as a Knative broker, in the binary content mode of the HTTP protocol binding. The events have the DNS name as
+// subject, the instance as source and a type telling whether the record was created, updated or deleted, or
+// whether the change failed.
+func NewCloudEventsPublisher(sink, ownerID string, dryRun bool) *BrokerPublisher {
+	return newBrokerPublisher(&cloudEventsTransport{
+		sink:   sink,
+		source: "external-dns/" + ownerID,
+		client: &http.Client{Timeout: 10 * time.Second},
+		newID:  uuid.NewString,
+	}, ownerID, dryRun)
+}
+
+// send posts an event per record, carrying on past failures so that one rejected event doesn't drop the others.
+func (t *cloudEventsTransport) send(ctx context.Context, records []record) error {
+	var errs []error
+	for _, r := range records {
+		if err := t.post(ctx, r); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *cloudEventsTransport) post(ctx context.Context, r record) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.sink, bytes.NewReader(r.value))
+	if err != nil {
+		return err
+	}
+	eventType := cloudEventTypes[r.message.Action]
+	if r.message.Status == StatusFailed {
+		eventType = cloudEventTypeFailed
+	}
+	req.Header.Set("Ce-Specversion", "1.0")
+	req.Header.Set("Ce-Id", t.newID())
+	req.Header.Set("Ce-Source", t.source)
+	req.Header.Set("Ce-Type", eventType)
+	req.Header.Set("Ce-Subject", r.key)
+	req.Header.Set("Ce-Time", r.message.Time.UTC().Format(time.RFC3339Nano))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("sink returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (t *cloudEventsTransport) close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
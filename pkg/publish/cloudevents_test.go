@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestCloudEventsPublisherPublish(t *testing.T) {
+	var mu sync.Mutex
+	var headers []http.Header
+	var bodies []Message
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var msg Message
+		require.NoError(t, json.Unmarshal(body, &msg))
+
+		mu.Lock()
+		defer mu.Unlock()
+		headers = append(headers, r.Header)
+		bodies = append(bodies, msg)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer sink.Close()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	p := NewCloudEventsPublisher(sink.URL, "my-cluster", false)
+	p.now = func() time.Time { return now }
+	p.transport.(*cloudEventsTransport).newID = func() string { return "id" }
+
+	p.Publish(context.Background(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.1")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "192.0.2.2")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "192.0.2.3")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.0.2.4")},
+	}, nil)
+	p.Publish(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("failed.example.com", endpoint.RecordTypeA, "192.0.2.5")},
+	}, errors.New("throttled"))
+
+	require.Len(t, headers, 4)
+	var types []string
+	for i, h := range headers {
+		assert.Equal(t, "1.0", h.Get("Ce-Specversion"))
+		assert.Equal(t, "id", h.Get("Ce-Id"))
+		assert.Equal(t, "external-dns/my-cluster", h.Get("Ce-Source"))
+		assert.Equal(t, bodies[i].DNSName, h.Get("Ce-Subject"))
+		assert.Equal(t, "2025-06-01T12:00:00Z", h.Get("Ce-Time"))
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		types = append(types, h.Get("Ce-Type"))
+	}
+	assert.Equal(t, []string{
+		"io.k8s.sigs.external-dns.record.created",
+		"io.k8s.sigs.external-dns.record.updated",
+		"io.k8s.sigs.external-dns.record.deleted",
+		"io.k8s.sigs.external-dns.record.failed",
+	}, types)
+	assert.Equal(t, "throttled", bodies[3].Error)
+	require.NoError(t, p.Close())
+}
+
+func TestCloudEventsTransportSendFailure(t *testing.T) {
+	var received int
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		if received == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer sink.Close()
+
+	transport := NewCloudEventsPublisher(sink.URL, "my-cluster", false).transport
+	err := transport.send(context.Background(), []record{
+		{key: "a.example.com", value: []byte(`{}`)},
+		{key: "b.example.com", value: []byte(`{}`)},
+	})
+	require.ErrorContains(t, err, "a.example.com: sink returned 503 Service Unavailable")
+	assert.Equal(t, 2, received, "should carry on past failures")
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publish
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type kafkaTransport struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher returns a BrokerPublisher producing the messages to the given Kafka topic. Messages are
+// keyed by DNS name, so that the changes to a record are consumed in order.
+func NewKafkaPublisher(brokers []string, topic, ownerID string, dryRun bool) *BrokerPublisher {
+	return newBrokerPublisher(&kafkaTransport{writer: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}}, ownerID, dryRun)
+}
+
+func (t *kafkaTransport) send(ctx context.Context, records []record) error {
+	messages := make([]kafka.Message, 0, len(records))
+	for _, r := range records {
+		messages = append(messages, kafka.Message{Key: []byte(r.key), Value: r.value})
+	}
+	return t.writer.WriteMessages(ctx, messages...)
+}
+
+func (t *kafkaTransport) close() error {
+	return t.writer.Close()
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publish
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+type natsTransport struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNATSPublisher connects to the NATS server at the given URL and returns a BrokerPublisher publishing the
+// messages to the given subject, with the DNS name in the DNS-Name header.
+func NewNATSPublisher(url, subject, ownerID string, dryRun bool) (*BrokerPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("external-dns"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	return newBrokerPublisher(&natsTransport{conn: conn, subject: subject}, ownerID, dryRun), nil
+}
+
+func (t *natsTransport) send(ctx context.Context, records []record) error {
+	for _, r := range records {
+		msg := nats.NewMsg(t.subject)
+		msg.Header.Set("DNS-Name", r.key)
+		msg.Data = r.value
+		if err := t.conn.PublishMsg(msg); err != nil {
+			return err
+		}
+	}
+	return t.conn.FlushWithContext(ctx)
+}
+
+func (t *natsTransport) close() error {
+	return t.conn.Drain()
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package publish publishes the applied and failed DNS changes to a message broker or a CloudEvents sink, for
+// external audit and CMDB systems to subscribe to.
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/audit"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	StatusApplied = "applied"
+	StatusFailed  = "failed"
+)
+
+// Message is published for every change to a DNS record. It holds the fields of the audit log entry of
+// the change, its status and, for failed changes, the error returned by the provider.
+type Message struct {
+	audit.Entry
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Publisher publishes the changes to DNS records.
+type Publisher interface {
+	// Publish publishes the given changes, which were applied, or which failed to apply with the given error.
+	Publish(ctx context.Context, changes *plan.Changes, err error)
+}
+
+// record is a message as sent to the broker.
+type record struct {
+	key   string
+	value []byte
+	// message is the message encoded in value, for transports describing it in their metadata
+	message Message
+}
+
+// transport sends records to a message broker.
+type transport interface {
+	send(ctx context.Context, records []record) error
+	close() error
+}
+
+// BrokerPublisher publishes a JSON encoded Message per change to a Kafka topic, a NATS subject or a CloudEvents
+// sink, keyed by the DNS name of the record.
+type BrokerPublisher struct {
+	transport transport
+	ownerID   string
+	dryRun    bool
+	now       func() time.Time
+}
+
+func newBrokerPublisher(t transport, ownerID string, dryRun bool) *BrokerPublisher {
+	return &BrokerPublisher{transport: t, ownerID: ownerID, dryRun: dryRun, now: time.Now}
+}
+
+// Publish sends a message per created, updated and deleted record. Failures are only logged, as they don't
+// affect the DNS records.
+func (p *BrokerPublisher) Publish(ctx context.Context, changes *plan.Changes, err error) {
+	status, errMsg := StatusApplied, ""
+	if err != nil {
+		status, errMsg = StatusFailed, err.Error()
+	}
+	entries := audit.Entries(changes, p.now(), p.ownerID, p.dryRun)
+	records := make([]record, 0, len(entries))
+	for _, entry := range entries {
+		msg := Message{Entry: entry, Status: status, Error: errMsg}
+		value, err := json.Marshal(msg)
+		if err != nil {
+			log.Errorf("Failed to encode change message for %s %s: %v", entry.DNSName, entry.RecordType, err)
+			continue
+		}
+		records = append(records, record{key: entry.DNSName, value: value, message: msg})
+	}
+	if len(records) == 0 {
+		return
+	}
+	if err := p.transport.send(ctx, records); err != nil {
+		log.Warnf("Failed to publish %d change messages: %v", len(records), err)
+	}
+}
+
+// Close flushes the pending messages and closes the connection to the broker.
+func (p *BrokerPublisher) Close() error {
+	return p.transport.close()
+}
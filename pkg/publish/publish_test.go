@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/audit"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// recordingTransport records the records sent.
+type recordingTransport struct {
+	records []record
+	err     error
+	closed  bool
+}
+
+func (t *recordingTransport) send(_ context.Context, records []record) error {
+	t.records = append(t.records, records...)
+	return t.err
+}
+
+func (t *recordingTransport) close() error {
+	t.closed = true
+	return nil
+}
+
+func TestBrokerPublisherPublish(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	transport := &recordingTransport{}
+	p := newBrokerPublisher(transport, "owner", false)
+	p.now = func() time.Time { return now }
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.1.1.1")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "2.2.2.2")},
+	}
+	p.Publish(context.Background(), changes, nil)
+	p.Publish(context.Background(), changes, errors.New("throttled"))
+
+	var messages []Message
+	var keys []string
+	for _, r := range transport.records {
+		var msg Message
+		require.NoError(t, json.Unmarshal(r.value, &msg))
+		messages = append(messages, msg)
+		keys = append(keys, r.key)
+	}
+	assert.Equal(t, []string{"new.example.com", "old.example.com", "new.example.com", "old.example.com"}, keys)
+	assert.Equal(t, Message{
+		Entry: audit.Entry{
+			Time: now, Action: audit.ActionCreate, DNSName: "new.example.com", RecordType: endpoint.RecordTypeA,
+			New: &audit.Record{Targets: []string{"1.1.1.1"}}, OwnerID: "owner",
+		},
+		Status: StatusApplied,
+	}, messages[0])
+	assert.Equal(t, StatusFailed, messages[3].Status)
+	assert.Equal(t, "throttled", messages[3].Error)
+	assert.Equal(t, audit.ActionDelete, messages[3].Action)
+
+	require.NoError(t, p.Close())
+	assert.True(t, transport.closed)
+}
+
+func TestBrokerPublisherPublishFailure(t *testing.T) {
+	transport := &recordingTransport{err: errors.New("unreachable")}
+	p := newBrokerPublisher(transport, "owner", false)
+
+	p.Publish(context.Background(), &plan.Changes{}, nil)
+	assert.Empty(t, transport.records)
+
+	// failures are only logged
+	p.Publish(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.1.1.1")},
+	}, nil)
+	assert.Len(t, transport.records, 1)
+}
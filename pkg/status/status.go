@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// SyncedPrefix is the prefix of the status annotation value, followed by the time the records were applied.
+const SyncedPrefix = "synced@"
+
+// Writer reports the DNS provisioning status back onto the resources records were derived from.
+type Writer interface {
+	// Synced marks the referenced resources as synchronized.
+	Synced(ctx context.Context, refs ...*events.ObjectReference)
+}
+
+// AnnotationWriter sets the status annotation on resources through the dynamic client, so that
+// it works with any kind of resource.
+type AnnotationWriter struct {
+	client dynamic.Interface
+	dryRun bool
+	now    func() time.Time
+}
+
+// NewAnnotationWriter returns an AnnotationWriter patching resources with the given client.
+func NewAnnotationWriter(client dynamic.Interface, dryRun bool) *AnnotationWriter {
+	return &AnnotationWriter{client: client, dryRun: dryRun, now: time.Now}
+}
+
+// Synced sets the status annotation of each referenced resource to "synced@<timestamp>".
+// Resources are patched once, even if referenced several times. Failures are only logged,
+// as the records themselves were applied.
+func (w *AnnotationWriter) Synced(ctx context.Context, refs ...*events.ObjectReference) {
+	value := SyncedPrefix + w.now().UTC().Format(time.RFC3339)
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{annotations.StatusKey: value},
+		},
+	})
+	if err != nil {
+		log.Errorf("Failed to build status patch: %v", err)
+		return
+	}
+
+	var opts metav1.PatchOptions
+	if w.dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	seen := map[events.ObjectReference]bool{}
+	for _, ref := range refs {
+		if ref == nil || ref.Name == "" || seen[*ref] {
+			continue
+		}
+		seen[*ref] = true
+		gv, err := schema.ParseGroupVersion(ref.ApiVersion)
+		if err != nil {
+			log.Warnf("Failed to set status of %s %s/%s: %v", ref.Kind, ref.Namespace, ref.Name, err)
+			continue
+		}
+		gvr, _ := meta.UnsafeGuessKindToResource(gv.WithKind(ref.Kind))
+		_, err = w.client.Resource(gvr).Namespace(ref.Namespace).Patch(ctx, ref.Name, types.MergePatchType, patch, opts)
+		if err != nil {
+			log.Warnf("Failed to set status of %s %s/%s: %v", ref.Kind, ref.Namespace, ref.Name, err)
+			continue
+		}
+		log.Debugf("Set status of %s %s/%s to %q", ref.Kind, ref.Namespace, ref.Name, value)
+	}
+}
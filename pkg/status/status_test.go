@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestAnnotationWriterSynced(t *testing.T) {
+	svc := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	client := fakedynamic.NewSimpleDynamicClient(scheme, svc)
+	w := NewAnnotationWriter(client, false)
+	w.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	ref := &events.ObjectReference{Kind: "Service", ApiVersion: "v1", Namespace: "default", Name: "foo"}
+	missing := &events.ObjectReference{Kind: "Ingress", ApiVersion: "networking.k8s.io/v1", Namespace: "default", Name: "bar"}
+	w.Synced(context.Background(), ref, nil, ref, missing)
+
+	var patches int
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "patch" {
+			patches++
+		}
+	}
+	assert.Equal(t, 2, patches, "each resource is patched once")
+
+	obj, err := client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "services"}).
+		Namespace("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "synced@2025-01-02T03:04:05Z", obj.GetAnnotations()[annotations.StatusKey])
+}
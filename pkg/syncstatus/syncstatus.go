@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package syncstatus summarizes the synchronizations of ExternalDNS in a cluster-scoped ExternalDNSStatus object,
+// for GitOps health checks and dashboards.
+package syncstatus
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Reasons of the conditions of the ExternalDNSStatus.
+const (
+	ReasonSyncSucceeded    = "SyncSucceeded"
+	ReasonSyncFailed       = "SyncFailed"
+	ReasonUpToDate         = "UpToDate"
+	ReasonPaused           = "Paused"
+	ReasonAwaitingApproval = "AwaitingApproval"
+	ReasonDeferred         = "Deferred"
+)
+
+var statusesResource = v1alpha1.GroupVersion.WithResource("externaldnsstatuses")
+
+// Report is the outcome of a synchronization.
+type Report struct {
+	// Time is when the synchronization ended
+	Time time.Time
+	// Err is the error of the synchronization, if it failed
+	Err error
+	// LastSuccess is the time of the last successful synchronization of all records, zero before the first one
+	LastSuccess time.Time
+	// Zones maps the zones known from the domain filters to their last successful synchronization
+	Zones map[string]time.Time
+	// Records are the records owned by the instance, nil if the synchronization failed before listing them
+	Records []*endpoint.Endpoint
+	// Pending is the number of planned changes which weren't applied, for the PendingReason
+	Pending int
+	// PendingReason is why the pending changes weren't applied, one of ReasonPaused, ReasonAwaitingApproval or
+	// ReasonDeferred
+	PendingReason string
+	// Paused is set while applying changes is paused
+	Paused bool
+}
+
+// Reporter reports the outcome of the synchronizations.
+type Reporter interface {
+	// Report reports the outcome of a synchronization.
+	Report(ctx context.Context, report Report)
+}
+
+// CRDReporter reports the outcome of the synchronizations in the status of an ExternalDNSStatus object, which it
+// creates if needed.
+type CRDReporter struct {
+	client  dynamic.Interface
+	name    string
+	ownerID string
+	dryRun  bool
+
+	mu sync.Mutex
+	// records are the records of the last report listing them, kept when a synchronization fails before
+	records []*endpoint.Endpoint
+}
+
+// NewCRDReporter returns a CRDReporter updating the ExternalDNSStatus object of the given name.
+func NewCRDReporter(client dynamic.Interface, name, ownerID string, dryRun bool) *CRDReporter {
+	return &CRDReporter{client: client, name: name, ownerID: ownerID, dryRun: dryRun}
+}
+
+// Report updates the status of the ExternalDNSStatus object. Failures are only logged, as they don't affect the
+// synchronization itself.
+func (r *CRDReporter) Report(ctx context.Context, report Report) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if report.Records != nil {
+		r.records = report.Records
+	}
+	if err := r.write(ctx, report); err != nil {
+		log.Warnf("Failed to update ExternalDNSStatus %s: %v", r.name, err)
+	}
+}
+
+func (r *CRDReporter) write(ctx context.Context, report Report) error {
+	var dryRun []string
+	if r.dryRun {
+		dryRun = []string{metav1.DryRunAll}
+	}
+	resource := r.client.Resource(statusesResource)
+	obj, err := resource.Get(ctx, r.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&v1alpha1.ExternalDNSStatus{
+			TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "ExternalDNSStatus"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   r.name,
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "external-dns"},
+			},
+			Spec: v1alpha1.ExternalDNSStatusSpec{OwnerID: r.ownerID},
+		})
+		if err != nil {
+			return err
+		}
+		obj, err = resource.Create(ctx, &unstructured.Unstructured{Object: content}, metav1.CreateOptions{DryRun: dryRun})
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	var status v1alpha1.ExternalDNSStatus
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &status); err != nil {
+		return fmt.Errorf("decoding: %w", err)
+	}
+	r.update(&status.Status, report)
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return err
+	}
+	_, err = resource.UpdateStatus(ctx, &unstructured.Unstructured{Object: content}, metav1.UpdateOptions{DryRun: dryRun})
+	return err
+}
+
+// update sets the status from the report, keeping the transition times of the conditions which didn't change.
+func (r *CRDReporter) update(status *v1alpha1.ExternalDNSStatusStatus, report Report) {
+	status.LastAttemptTime = timeOrNil(report.Time)
+	status.LastSyncTime = timeOrNil(report.LastSuccess)
+	status.Paused = report.Paused
+	status.Records = len(r.records)
+	status.PendingChanges = report.Pending
+
+	zones := make([]string, 0, len(report.Zones))
+	for zone := range report.Zones {
+		zones = append(zones, zone)
+	}
+	counts := map[string]int{}
+	for _, ep := range r.records {
+		if zone := findZone(zones, ep.DNSName); zone != "" {
+			counts[zone]++
+		}
+	}
+	status.Zones = make([]v1alpha1.ZoneStatus, 0, len(zones))
+	for _, zone := range zones {
+		status.Zones = append(status.Zones, v1alpha1.ZoneStatus{
+			Name:         zone,
+			Records:      counts[zone],
+			LastSyncTime: timeOrNil(report.Zones[zone]),
+		})
+	}
+	slices.SortFunc(status.Zones, func(a, b v1alpha1.ZoneStatus) int { return cmp.Compare(a.Name, b.Name) })
+
+	ready := metav1.Condition{
+		Type:    v1alpha1.ExternalDNSStatusConditionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonSyncSucceeded,
+		Message: "The last synchronization succeeded",
+	}
+	if report.Err != nil {
+		ready.Status, ready.Reason, ready.Message = metav1.ConditionFalse, ReasonSyncFailed, report.Err.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, ready)
+
+	pending := metav1.Condition{
+		Type:    v1alpha1.ExternalDNSStatusConditionChangesPending,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonUpToDate,
+		Message: "All planned changes were applied",
+	}
+	if report.Pending > 0 {
+		pending.Status, pending.Reason = metav1.ConditionTrue, report.PendingReason
+		pending.Message = fmt.Sprintf("%d planned changes were not applied", report.Pending)
+	}
+	meta.SetStatusCondition(&status.Conditions, pending)
+}
+
+// findZone returns the longest of the zones the DNS name belongs to, or "" if none.
+func findZone(zones []string, dnsName string) string {
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	var found string
+	for _, z := range zones {
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(found) {
+			found = z
+		}
+	}
+	return found
+}
+
+func timeOrNil(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncstatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func getStatus(t *testing.T, client *fakedynamic.FakeDynamicClient) *v1alpha1.ExternalDNSStatus {
+	t.Helper()
+	obj, err := client.Resource(statusesResource).Get(context.Background(), "external-dns", metav1.GetOptions{})
+	require.NoError(t, err)
+	var status v1alpha1.ExternalDNSStatus
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &status))
+	return &status
+}
+
+func TestCRDReporter(t *testing.T) {
+	ctx := context.Background()
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{statusesResource: "ExternalDNSStatusList"})
+	reporter := NewCRDReporter(client, "external-dns", "default", false)
+
+	first := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	reporter.Report(ctx, Report{
+		Time:        first,
+		LastSuccess: first,
+		Zones:       map[string]time.Time{"example.com": first, "sub.example.com": first, "example.org": {}},
+		Records: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("a.sub.example.com", endpoint.RecordTypeA, "3.3.3.3"),
+			endpoint.NewEndpoint("other.net", endpoint.RecordTypeA, "4.4.4.4"),
+		},
+	})
+
+	status := getStatus(t, client)
+	assert.Equal(t, "default", status.Spec.OwnerID)
+	assert.Equal(t, 4, status.Status.Records)
+	assert.Equal(t, first, status.Status.LastSyncTime.UTC())
+	require.Len(t, status.Status.Zones, 3)
+	assert.Equal(t, "example.com", status.Status.Zones[0].Name)
+	assert.Equal(t, 2, status.Status.Zones[0].Records)
+	assert.Equal(t, "example.org", status.Status.Zones[1].Name)
+	assert.Zero(t, status.Status.Zones[1].Records)
+	assert.Nil(t, status.Status.Zones[1].LastSyncTime)
+	assert.Equal(t, 1, status.Status.Zones[2].Records)
+	assert.True(t, meta.IsStatusConditionTrue(status.Status.Conditions, v1alpha1.ExternalDNSStatusConditionReady))
+	assert.True(t, meta.IsStatusConditionFalse(status.Status.Conditions, v1alpha1.ExternalDNSStatusConditionChangesPending))
+
+	// a synchronization failing before listing the records keeps their counts
+	second := first.Add(time.Minute)
+	reporter.Report(ctx, Report{
+		Time:        second,
+		Err:         errors.New("provider unavailable"),
+		LastSuccess: first,
+		Zones:       map[string]time.Time{"example.com": first, "sub.example.com": first, "example.org": {}},
+	})
+
+	status = getStatus(t, client)
+	assert.Equal(t, 4, status.Status.Records)
+	assert.Equal(t, second, status.Status.LastAttemptTime.UTC())
+	assert.Equal(t, first, status.Status.LastSyncTime.UTC())
+	ready := meta.FindStatusCondition(status.Status.Conditions, v1alpha1.ExternalDNSStatusConditionReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, ReasonSyncFailed, ready.Reason)
+	assert.Equal(t, "provider unavailable", ready.Message)
+
+	reporter.Report(ctx, Report{
+		Time:          second.Add(time.Minute),
+		LastSuccess:   first,
+		Records:       []*endpoint.Endpoint{},
+		Pending:       3,
+		PendingReason: ReasonPaused,
+		Paused:        true,
+	})
+
+	status = getStatus(t, client)
+	assert.Zero(t, status.Status.Records)
+	assert.Equal(t, 3, status.Status.PendingChanges)
+	assert.True(t, status.Status.Paused)
+	pending := meta.FindStatusCondition(status.Status.Conditions, v1alpha1.ExternalDNSStatusConditionChangesPending)
+	require.NotNil(t, pending)
+	assert.Equal(t, metav1.ConditionTrue, pending.Status)
+	assert.Equal(t, ReasonPaused, pending.Reason)
+	assert.Equal(t, "3 planned changes were not applied", pending.Message)
+}
+
+func TestCRDReporterCreatesObject(t *testing.T) {
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{statusesResource: "ExternalDNSStatusList"})
+	reporter := NewCRDReporter(client, "external-dns", "default", false)
+	reporter.Report(context.Background(), Report{Time: time.Now()})
+	reporter.Report(context.Background(), Report{Time: time.Now()})
+
+	var verbs []string
+	for _, action := range client.Actions() {
+		verbs = append(verbs, action.GetVerb()+" "+action.GetSubresource())
+	}
+	assert.Equal(t, []string{"get ", "create ", "update status", "get ", "update status"}, verbs)
+	assert.Equal(t, "external-dns", getStatus(t, client).Labels["app.kubernetes.io/managed-by"])
+}
@@ -23,6 +23,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
 )
 
 const defaultMinVersion = 0
@@ -84,3 +88,72 @@ func loadRoots(caPath string) (*x509.CertPool, error) {
 	}
 	return roots, nil
 }
+
+// NewServerTLSConfig creates a tls.Config for serving with the certificate and key read from disk, reading them
+// again whenever the certificate file changes so that rotated certificates are served without a restart. When
+// clientCAPath is set, clients must present a certificate signed by one of its CAs.
+func NewServerTLSConfig(certPath, keyPath, clientCAPath string) (*tls.Config, error) {
+	if certPath == "" || keyPath == "" {
+		return nil, errors.New("both cert and key must be provided")
+	}
+	reloader := &certificateReloader{certPath: certPath, keyPath: keyPath}
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.getCertificate,
+	}
+	if clientCAPath != "" {
+		clientCAs, err := loadRoots(clientCAPath)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// certificateReloader serves a certificate read from disk, and reads it again when the certificate file is modified.
+type certificateReloader struct {
+	certPath string
+	keyPath  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func (r *certificateReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if info, err := os.Stat(r.certPath); err == nil && !info.ModTime().Equal(r.modTime) {
+		if err := r.reloadLocked(); err != nil {
+			// The key is usually written after the certificate, keep serving the previous pair until both match.
+			log.Warnf("Keeping the previous TLS certificate: %v", err)
+		}
+	}
+	return r.cert, nil
+}
+
+func (r *certificateReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reloadLocked()
+}
+
+func (r *certificateReloader) reloadLocked() error {
+	info, err := os.Stat(r.certPath)
+	if err != nil {
+		return fmt.Errorf("could not load TLS cert: %w", err)
+	}
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return fmt.Errorf("could not load TLS cert: %w", err)
+	}
+	r.cert = &cert
+	r.modTime = info.ModTime()
+	return nil
+}
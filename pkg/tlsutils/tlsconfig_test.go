@@ -17,10 +17,19 @@ limitations under the License.
 package tlsutils
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -193,3 +202,68 @@ func TestCreateTLSConfig(t *testing.T) {
 	}
 
 }
+
+func TestNewServerTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certPath := fmt.Sprintf("%s/tls.crt", dir)
+	keyPath := fmt.Sprintf("%s/tls.key", dir)
+	caPath := fmt.Sprintf("%s/ca.crt", dir)
+	utils.WriteToFile(certPath, rsaCertPEM)
+	utils.WriteToFile(keyPath, rsaKeyPEM)
+	utils.WriteToFile(caPath, rsaCertPEM)
+
+	_, err := NewServerTLSConfig(certPath, "", "")
+	require.Error(t, err)
+	_, err = NewServerTLSConfig(certPath, keyPath, "/path/does/not/exist")
+	require.ErrorContains(t, err, "error reading /path/does/not/exist")
+
+	config, err := NewServerTLSConfig(certPath, keyPath, "")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+	assert.Nil(t, config.ClientCAs)
+
+	config, err = NewServerTLSConfig(certPath, keyPath, caPath)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+	assert.NotNil(t, config.ClientCAs)
+
+	initial, err := config.GetCertificate(nil)
+	require.NoError(t, err)
+
+	// An invalid certificate keeps the previous one.
+	utils.WriteToFile(certPath, "invalid-cert")
+	require.NoError(t, os.Chtimes(certPath, time.Now(), time.Now().Add(time.Minute)))
+	cert, err := config.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Same(t, initial, cert)
+
+	// A valid certificate replaces it.
+	certPEM, keyPEM := generateCertificate(t)
+	utils.WriteToFile(certPath, certPEM)
+	utils.WriteToFile(keyPath, keyPEM)
+	require.NoError(t, os.Chtimes(certPath, time.Now(), time.Now().Add(2*time.Minute)))
+	cert, err = config.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.NotSame(t, initial, cert)
+	assert.NotEqual(t, initial.Certificate, cert.Certificate)
+}
+
+func generateCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "external-dns"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
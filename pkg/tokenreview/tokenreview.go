@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tokenreview authenticates the bearer tokens of Kubernetes service accounts with the TokenReview API.
+package tokenreview
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/lru"
+)
+
+// serviceAccountPrefix prefixes the usernames of service accounts, followed by their namespace and name.
+const serviceAccountPrefix = "system:serviceaccount:"
+
+// cacheTTL is how long the outcome of a review is reused for the same token, so that clients polling an
+// endpoint don't cause a TokenReview each time.
+const cacheTTL = time.Minute
+
+// rejectedCacheTTL is how long a rejection is reused for the same token. It's short, so that a token rejected
+// for a transient reason, such as a clock skew, is soon reviewed again, while still bounding the reviews caused by
+// a client retrying with an invalid token.
+const rejectedCacheTTL = 5 * time.Second
+
+// cacheSize is the number of reviews cached at most, the least recently used being evicted first, so that clients
+// sending many different tokens can't grow the cache without bound.
+const cacheSize = 1024
+
+// ErrUnauthorized is returned for the tokens which aren't valid, not bound to the audience, or of other service
+// accounts than the allowed ones.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// Authenticator authenticates service account tokens bound to an audience, such as projected tokens, and only
+// accepts those of the allowed service accounts.
+type Authenticator struct {
+	client          kubernetes.Interface
+	audience        string
+	serviceAccounts []string
+
+	cache *lru.Cache
+	now   func() time.Time
+}
+
+type review struct {
+	username string
+	err      error
+	expires  time.Time
+}
+
+// NewAuthenticator returns an Authenticator of the tokens bound to the audience of the given service accounts,
+// in namespace/name format.
+func NewAuthenticator(client kubernetes.Interface, audience string, serviceAccounts []string) *Authenticator {
+	return &Authenticator{
+		client:          client,
+		audience:        audience,
+		serviceAccounts: serviceAccounts,
+		cache:           lru.New(cacheSize),
+		now:             time.Now,
+	}
+}
+
+// Authenticate returns the username of the service account of the token, or ErrUnauthorized if it isn't allowed.
+// Failures to review the token are returned as is and aren't cached, and rejections are only cached briefly.
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (string, error) {
+	key := sha256.Sum256([]byte(token))
+	now := a.now()
+
+	if cached, ok := a.cache.Get(key); ok {
+		if r := cached.(review); now.Before(r.expires) {
+			return r.username, r.err
+		}
+		a.cache.Remove(key)
+	}
+
+	username, err := a.review(ctx, token)
+	if err != nil && !errors.Is(err, ErrUnauthorized) {
+		return "", err
+	}
+
+	ttl := cacheTTL
+	if err != nil {
+		ttl = rejectedCacheTTL
+	}
+	a.cache.Add(key, review{username: username, err: err, expires: now.Add(ttl)})
+	return username, err
+}
+
+// Username returns the username of the service account given in namespace/name format, as returned by Authenticate.
+func Username(serviceAccount string) string {
+	return serviceAccountPrefix + strings.Replace(serviceAccount, "/", ":", 1)
+}
+
+func (a *Authenticator) review(ctx context.Context, token string) (string, error) {
+	result, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: []string{a.audience}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("reviewing token: %w", err)
+	}
+	status := result.Status
+	if !status.Authenticated || !slices.Contains(status.Audiences, a.audience) {
+		return "", ErrUnauthorized
+	}
+	serviceAccount, ok := strings.CutPrefix(status.User.Username, serviceAccountPrefix)
+	if !ok || !slices.Contains(a.serviceAccounts, strings.Replace(serviceAccount, ":", "/", 1)) {
+		return "", fmt.Errorf("%w: %s is not allowed", ErrUnauthorized, status.User.Username)
+	}
+	return status.User.Username, nil
+}
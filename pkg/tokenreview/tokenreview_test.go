@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeClient returns a client reviewing the given tokens, authenticated as the given users for the given
+// audiences, and counting the reviews.
+func newFakeClient(users map[string]string, audiences []string, reviews *int, reviewErr *error) *fake.Clientset {
+	client := fake.NewClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		*reviews++
+		if *reviewErr != nil {
+			return true, nil, *reviewErr
+		}
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview).DeepCopy()
+		if user, ok := users[review.Spec.Token]; ok {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: user},
+				Audiences:     audiences,
+			}
+		}
+		return true, review, nil
+	})
+	return client
+}
+
+func TestAuthenticator(t *testing.T) {
+	var reviews int
+	var reviewErr error
+	client := newFakeClient(map[string]string{
+		"reader": "system:serviceaccount:monitoring:api-reader",
+		"other":  "system:serviceaccount:default:other",
+		"user":   "jane@example.com",
+	}, []string{"external-dns"}, &reviews, &reviewErr)
+	a := NewAuthenticator(client, "external-dns", []string{"monitoring/api-reader"})
+	now := time.Now()
+	a.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	username, err := a.Authenticate(ctx, "reader")
+	require.NoError(t, err)
+	assert.Equal(t, "system:serviceaccount:monitoring:api-reader", username)
+
+	for _, token := range []string{"other", "user", "invalid"} {
+		_, err := a.Authenticate(ctx, token)
+		assert.ErrorIs(t, err, ErrUnauthorized, token)
+	}
+	assert.Equal(t, 4, reviews)
+
+	// the outcomes are cached, including rejections
+	_, err = a.Authenticate(ctx, "reader")
+	require.NoError(t, err)
+	_, err = a.Authenticate(ctx, "invalid")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, 4, reviews)
+
+	// but rejections only briefly
+	now = now.Add(rejectedCacheTTL)
+	_, err = a.Authenticate(ctx, "reader")
+	require.NoError(t, err)
+	_, err = a.Authenticate(ctx, "invalid")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, 5, reviews)
+
+	// failures to review are not
+	now = now.Add(cacheTTL)
+	reviewErr = errors.New("apiserver unavailable")
+	_, err = a.Authenticate(ctx, "reader")
+	require.ErrorContains(t, err, "apiserver unavailable")
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+	reviewErr = nil
+	_, err = a.Authenticate(ctx, "reader")
+	require.NoError(t, err)
+	assert.Equal(t, 7, reviews)
+}
+
+func TestAuthenticatorCacheSize(t *testing.T) {
+	var reviews int
+	var reviewErr error
+	client := newFakeClient(map[string]string{"reader": "system:serviceaccount:monitoring:api-reader"}, []string{"external-dns"}, &reviews, &reviewErr)
+	a := NewAuthenticator(client, "external-dns", []string{"monitoring/api-reader"})
+	ctx := context.Background()
+
+	_, err := a.Authenticate(ctx, "reader")
+	require.NoError(t, err)
+	for i := range cacheSize + 10 {
+		_, err := a.Authenticate(ctx, fmt.Sprintf("invalid-%d", i))
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		if i%100 == 0 {
+			// the reader keeps polling, so its review stays among the most recently used
+			_, err = a.Authenticate(ctx, "reader")
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, cacheSize, a.cache.Len())
+	assert.Equal(t, cacheSize+11, reviews)
+
+	_, err = a.Authenticate(ctx, "reader")
+	require.NoError(t, err)
+	assert.Equal(t, cacheSize+11, reviews, "the review of the reader is still cached")
+	_, err = a.Authenticate(ctx, "invalid-0")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, cacheSize+12, reviews, "the least recently used reviews are evicted")
+}
+
+func TestAuthenticatorAudience(t *testing.T) {
+	var reviews int
+	var reviewErr error
+	// the token is valid, but not bound to the audience
+	client := newFakeClient(map[string]string{"reader": "system:serviceaccount:monitoring:api-reader"}, []string{"https://kubernetes.default.svc"}, &reviews, &reviewErr)
+	a := NewAuthenticator(client, "external-dns", []string{"monitoring/api-reader"})
+
+	_, err := a.Authenticate(context.Background(), "reader")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vault reads credentials from HashiCorp Vault, logging in with the Kubernetes auth method and keeping
+// its token renewed.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
+)
+
+const (
+	// DefaultAuthMount is the default mount path of the Kubernetes auth method.
+	DefaultAuthMount = "kubernetes"
+	// DefaultServiceAccountTokenPath is the path of the service account token mounted into pods.
+	DefaultServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// minRenewInterval bounds the renewal of tokens with very short leases
+	minRenewInterval = 5 * time.Second
+)
+
+// Config is the configuration of a Client.
+type Config struct {
+	// Address is the URL of Vault, e.g. https://vault.example.org:8200
+	Address string
+	// AuthMount is the mount path of the Kubernetes auth method
+	AuthMount string
+	// Role is the role of the Kubernetes auth method to log in with
+	Role string
+	// ServiceAccountTokenPath is the path of the service account token to log in with
+	ServiceAccountTokenPath string
+	// CACert is the path of the CA certificate of Vault, the system roots are used if empty
+	CACert string
+}
+
+// Client reads secrets from Vault.
+type Client struct {
+	config Config
+	client *http.Client
+
+	mu        sync.Mutex
+	token     string
+	lease     time.Duration
+	renewable bool
+}
+
+// NewClient returns a Client for the given configuration, which has to log in before reading secrets.
+func NewClient(config Config) (*Client, error) {
+	if config.AuthMount == "" {
+		config.AuthMount = DefaultAuthMount
+	}
+	if config.ServiceAccountTokenPath == "" {
+		config.ServiceAccountTokenPath = DefaultServiceAccountTokenPath
+	}
+	tlsConfig, err := tlsutils.NewTLSConfig("", "", config.CACert, "", false, tls.VersionTLS12)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		config: config,
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+		},
+	}, nil
+}
+
+// response is the part of the responses of Vault used by the Client.
+type response struct {
+	Data map[string]any `json:"data"`
+	Auth *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+// Login logs in with the Kubernetes auth method, using the service account token.
+func (c *Client) Login(ctx context.Context) error {
+	jwt, err := os.ReadFile(c.config.ServiceAccountTokenPath)
+	if err != nil {
+		return fmt.Errorf("reading service account token: %w", err)
+	}
+	body, err := json.Marshal(map[string]string{"role": c.config.Role, "jwt": strings.TrimSpace(string(jwt))})
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, "auth/"+strings.Trim(c.config.AuthMount, "/")+"/login", "", body)
+	if err != nil {
+		return fmt.Errorf("logging in to vault: %w", err)
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return errors.New("logging in to vault: no token returned")
+	}
+	c.setToken(resp)
+	return nil
+}
+
+// renew renews the token of the Client.
+func (c *Client) renew(ctx context.Context) error {
+	resp, err := c.do(ctx, http.MethodPost, "auth/token/renew-self", c.currentToken(), []byte("{}"))
+	if err != nil {
+		return fmt.Errorf("renewing vault token: %w", err)
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return errors.New("renewing vault token: no token returned")
+	}
+	c.setToken(resp)
+	return nil
+}
+
+func (c *Client) setToken(resp *response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = resp.Auth.ClientToken
+	c.lease = time.Duration(resp.Auth.LeaseDuration) * time.Second
+	c.renewable = resp.Auth.Renewable
+}
+
+func (c *Client) currentToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// renewInterval returns how long to wait before renewing the token, two thirds of its lease, and whether it is
+// renewable at all. Tokens without lease don't expire.
+func (c *Client) renewInterval() (time.Duration, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lease <= 0 {
+		return 0, false, false
+	}
+	return max(c.lease*2/3, minRenewInterval), c.renewable, true
+}
+
+// KeepAlive renews the token of the Client before its lease expires, or logs in again if it can't be renewed,
+// until the context is cancelled.
+func (c *Client) KeepAlive(ctx context.Context) {
+	for {
+		interval, renewable, expires := c.renewInterval()
+		if !expires {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		if renewable {
+			err := c.renew(ctx)
+			if err == nil {
+				continue
+			}
+			log.Warnf("Failed to renew the vault token, logging in again: %v", err)
+		}
+		if err := c.Login(ctx); err != nil {
+			log.Errorf("Failed to log in to vault: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(minRenewInterval):
+			}
+		}
+	}
+}
+
+// Read returns the value of a secret, referenced as <path>#<key>, e.g. secret/data/external-dns#api-key. Both
+// versions of the KV secrets engine are supported.
+func (c *Client) Read(ctx context.Context, ref string) (string, error) {
+	path, key, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || key == "" {
+		return "", fmt.Errorf("invalid vault secret %q, expected <path>#<key>", ref)
+	}
+	resp, err := c.do(ctx, http.MethodGet, strings.Trim(path, "/"), c.currentToken(), nil)
+	if err != nil {
+		return "", fmt.Errorf("reading vault secret %s: %w", path, err)
+	}
+	data := resp.Data
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			// KV version 2 nests the secret in data
+			data = nested
+		}
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no key %s", path, key)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %s of vault secret %s is not a string", key, path)
+	}
+	return s, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path, token string, body []byte) (*response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.config.Address, "/")+"/v1/"+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var resp response
+	err = json.NewDecoder(res.Body).Decode(&resp)
+	if res.StatusCode >= 300 {
+		if err == nil && len(resp.Errors) > 0 {
+			return nil, fmt.Errorf("status %d: %s", res.StatusCode, strings.Join(resp.Errors, "; "))
+		}
+		return nil, fmt.Errorf("status %d", res.StatusCode)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault serves the login, renewal and secrets endpoints used by the Client.
+func fakeVault(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/auth/k8s/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["role"] != "external-dns" || body["jwt"] != "sa-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"auth": {"client_token": "token-1", "lease_duration": 3600, "renewable": true}}`))
+	})
+	mux.HandleFunc("POST /v1/auth/token/renew-self", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token-1" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"auth": {"client_token": "token-1", "lease_duration": 60, "renewable": false}}`))
+	})
+	mux.HandleFunc("GET /v1/secret/data/dns", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token-1" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"data": {"token": "kv2-secret", "port": 53}, "metadata": {"version": 3}}}`))
+	})
+	mux.HandleFunc("GET /v1/kv/dns", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"token": "kv1-secret"}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(t *testing.T, address, jwt string) *Client {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte(jwt+"\n"), 0o600))
+	client, err := NewClient(Config{
+		Address:                 address,
+		AuthMount:               "k8s",
+		Role:                    "external-dns",
+		ServiceAccountTokenPath: path,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestClientRead(t *testing.T) {
+	server := fakeVault(t)
+	client := newTestClient(t, server.URL, "sa-token")
+	ctx := context.Background()
+
+	require.NoError(t, client.Login(ctx))
+
+	value, err := client.Read(ctx, "secret/data/dns#token")
+	require.NoError(t, err)
+	assert.Equal(t, "kv2-secret", value)
+
+	value, err = client.Read(ctx, "/kv/dns#token")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1-secret", value)
+
+	for ref, msg := range map[string]string{
+		"secret/data/dns":         "expected <path>#<key>",
+		"secret/data/dns#missing": "has no key missing",
+		"secret/data/dns#port":    "is not a string",
+		"secret/data/other#token": "status 404",
+	} {
+		_, err := client.Read(ctx, ref)
+		assert.ErrorContains(t, err, msg, ref)
+	}
+}
+
+func TestClientLoginDenied(t *testing.T) {
+	server := fakeVault(t)
+	client := newTestClient(t, server.URL, "other-token")
+
+	err := client.Login(context.Background())
+	assert.ErrorContains(t, err, "permission denied")
+}
+
+func TestClientRenew(t *testing.T) {
+	server := fakeVault(t)
+	client := newTestClient(t, server.URL, "sa-token")
+	ctx := context.Background()
+
+	_, _, expires := client.renewInterval()
+	assert.False(t, expires, "no token before logging in")
+
+	require.NoError(t, client.Login(ctx))
+	interval, renewable, expires := client.renewInterval()
+	assert.True(t, expires)
+	assert.True(t, renewable)
+	assert.Equal(t, 40*time.Minute, interval)
+
+	require.NoError(t, client.renew(ctx))
+	interval, renewable, _ = client.renewInterval()
+	assert.False(t, renewable)
+	assert.Equal(t, 40*time.Second, interval)
+}
@@ -17,7 +17,12 @@ limitations under the License.
 package plan
 
 import (
+	"cmp"
+	"math"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -32,6 +37,14 @@ type ConflictResolver interface {
 	ResolveRecordTypes(key planKey, row *planTableRow) map[string]*domainEndpoints
 }
 
+// ConflictResolvers is a registry of available conflict resolution strategies.
+var ConflictResolvers = map[string]ConflictResolver{
+	"targets":         PerResource{},
+	"oldest-resource": OldestResource{},
+	"priority":        PerPriority{},
+	"merge-targets":   MergeTargets{},
+}
+
 // PerResource allows only one resource to own a given dns name
 type PerResource struct{}
 
@@ -122,4 +135,219 @@ func (s PerResource) less(x, y *endpoint.Endpoint) bool {
 	return x.Targets.IsLess(y.Targets)
 }
 
-// TODO: with cross-resource/cross-cluster setup alternative variations of ConflictResolver can be used
+// OldestResource gives a contested dns name to the resource that was created first.
+// Resources without a known creation time are considered the newest. Ties are broken like PerResource.
+type OldestResource struct {
+	PerResource
+}
+
+// ResolveCreate takes the candidate created by the oldest resource.
+func (s OldestResource) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return resolveRanked("", candidates, compareCreationTimestamp, s.PerResource)
+}
+
+// ResolveUpdate takes the candidate created by the oldest resource, keeping the current owner when it is tied.
+func (s OldestResource) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return resolveRanked(current.Labels[endpoint.ResourceLabelKey], candidates, compareCreationTimestamp, s.PerResource)
+}
+
+// PerPriority gives a contested dns name to the resource with the highest priority.
+// Resources without a priority have priority 0. Ties are broken like PerResource.
+type PerPriority struct {
+	PerResource
+}
+
+// ResolveCreate takes the candidate with the highest priority.
+func (s PerPriority) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return resolveRanked("", candidates, comparePriority, s.PerResource)
+}
+
+// ResolveUpdate takes the candidate with the highest priority, keeping the current owner when it is tied.
+func (s PerPriority) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return resolveRanked(current.Labels[endpoint.ResourceLabelKey], candidates, comparePriority, s.PerResource)
+}
+
+// MergeTargets publishes the union of the targets of all A and AAAA candidates for a dns name, so that
+// independently deployed resources can share it for round robin. Each resource only contributes its own
+// targets, which are dropped once it no longer requests the name. The resources which requested each target are
+// stored with the merged record in its TargetResourcesLabelKey label, so that the registry keeps the owner of
+// every target. Other record types, as well as the TTL and provider specific properties of the merged record, are
+// resolved like PerResource.
+// If any candidate is weighted, each merged target weighs the sum of its weights in the candidates, so
+// that e.g. a canary resource with a weight of 1 gets a tenth of the traffic next to one with a weight of 9.
+type MergeTargets struct {
+	PerResource
+}
+
+// ResolveCreate merges the targets of all candidates.
+func (s MergeTargets) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return s.merge(s.PerResource.ResolveCreate(candidates), candidates)
+}
+
+// ResolveUpdate merges the targets of all candidates, using the candidate of the current resource as base.
+// The targets of the current record dropped as their resources no longer request them are logged.
+func (s MergeTargets) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	merged := s.merge(s.PerResource.ResolveUpdate(current, candidates), candidates)
+	if merged != nil {
+		requested := targetResources(merged)
+		for _, owned := range targetResources(current) {
+			resource, target, _ := strings.Cut(owned, targetResourceSeparator)
+			if !slices.Contains(requested, owned) && !slices.Contains(merged.Targets, target) {
+				log.Infof("Dropping target %s of %s, as %s no longer requests it", target, current.DNSName, resource)
+			}
+		}
+	}
+	return merged
+}
+
+func (s MergeTargets) merge(base *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	if base == nil || len(candidates) < 2 || (base.RecordType != endpoint.RecordTypeA && base.RecordType != endpoint.RecordTypeAAAA) {
+		return base
+	}
+	var targets []string
+	weighted := false
+	for _, ep := range candidates {
+		targets = append(targets, ep.Targets...)
+		weighted = weighted || ep.IsWeighted()
+	}
+	merged := base.DeepCopy()
+	merged.Targets = endpoint.NewTargets(targets...)
+	merged.Weights = nil
+	var owned []string
+	for _, ep := range candidates {
+		owned = append(owned, targetResources(ep)...)
+	}
+	slices.Sort(owned)
+	merged.WithLabel(endpoint.TargetResourcesLabelKey, strings.Join(slices.Compact(owned), targetResourcesSeparator))
+	if weighted {
+		merged.Weights = make(map[string]int64, len(merged.Targets))
+		for _, ep := range candidates {
+			for _, target := range ep.Targets {
+				merged.Weights[target] += ep.TargetWeight(target)
+			}
+		}
+	}
+	return merged
+}
+
+const (
+	// targetResourceSeparator separates the resource from the target in the TargetResourcesLabelKey label.
+	targetResourceSeparator = "@"
+	// targetResourcesSeparator separates the targets in the TargetResourcesLabelKey label.
+	targetResourcesSeparator = "+"
+)
+
+// targetResources returns the targets of the endpoint with the resources which requested them, as stored in the
+// TargetResourcesLabelKey label of a merged record, or with the resource of an endpoint requested by a single one.
+func targetResources(ep *endpoint.Endpoint) []string {
+	if label, ok := ep.Labels[endpoint.TargetResourcesLabelKey]; ok {
+		return strings.Split(label, targetResourcesSeparator)
+	}
+	resource := ep.Labels[endpoint.ResourceLabelKey]
+	if resource == "" {
+		return nil
+	}
+	owned := make([]string, 0, len(ep.Targets))
+	for _, target := range ep.Targets {
+		owned = append(owned, resource+targetResourceSeparator+target)
+	}
+	return owned
+}
+
+// addressFamilies keeps the A and AAAA records of a DNS name coming from the same resource, so that a
+// dual-stack name doesn't resolve to the IPv4 addresses of one resource and the IPv6 addresses of another.
+// It maps each resolved address record type to the resource of the resolved record.
+type addressFamilies map[string]string
+
+// candidates narrows the candidates of an address record type down to those of the resource which won the
+// other address family, if it has any. The candidates are returned as they are when merging targets, as the
+// merged records of both families already come from all the resources.
+func (f addressFamilies) candidates(resolver ConflictResolver, recordType string, candidates []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if _, merging := resolver.(MergeTargets); merging {
+		return candidates
+	}
+	var other string
+	switch recordType {
+	case endpoint.RecordTypeA:
+		other = endpoint.RecordTypeAAAA
+	case endpoint.RecordTypeAAAA:
+		other = endpoint.RecordTypeA
+	default:
+		return candidates
+	}
+	resource := f[other]
+	if resource == "" {
+		return candidates
+	}
+	var paired []*endpoint.Endpoint
+	for _, c := range candidates {
+		if c.Labels[endpoint.ResourceLabelKey] == resource {
+			paired = append(paired, c)
+		}
+	}
+	if len(paired) == 0 {
+		return candidates
+	}
+	return paired
+}
+
+// resolved records the resource of the record resolved for its record type.
+func (f addressFamilies) resolved(ep *endpoint.Endpoint) {
+	if ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA {
+		f[ep.RecordType] = ep.Labels[endpoint.ResourceLabelKey]
+	}
+}
+
+// resolveRanked returns the best ranked candidate according to compare. If currentResource
+// owns one of the best ranked candidates, that candidate is returned so ownership stays stable.
+func resolveRanked(currentResource string, candidates []*endpoint.Endpoint, compare func(x, y *endpoint.Endpoint) int, fallback PerResource) *endpoint.Endpoint {
+	var best, owned *endpoint.Endpoint
+	for _, ep := range candidates {
+		if best == nil {
+			best = ep
+			continue
+		}
+		if c := compare(ep, best); c < 0 || (c == 0 && fallback.less(ep, best)) {
+			best = ep
+		}
+	}
+	if best == nil || currentResource == "" {
+		return best
+	}
+	for _, ep := range candidates {
+		if ep.Labels[endpoint.ResourceLabelKey] != currentResource || compare(ep, best) != 0 {
+			continue
+		}
+		if owned == nil || fallback.less(ep, owned) {
+			owned = ep
+		}
+	}
+	if owned != nil {
+		return owned
+	}
+	return best
+}
+
+// compareCreationTimestamp orders endpoints from the oldest to the newest resource.
+func compareCreationTimestamp(x, y *endpoint.Endpoint) int {
+	return cmp.Compare(labelInt(x, endpoint.ResourceCreatedLabelKey, math.MaxInt64), labelInt(y, endpoint.ResourceCreatedLabelKey, math.MaxInt64))
+}
+
+// comparePriority orders endpoints from the highest to the lowest priority.
+func comparePriority(x, y *endpoint.Endpoint) int {
+	return cmp.Compare(labelInt(y, endpoint.PriorityLabelKey, 0), labelInt(x, endpoint.PriorityLabelKey, 0))
+}
+
+// labelInt returns the integer value of the label key, or def if it is missing or malformed.
+func labelInt(ep *endpoint.Endpoint, key string, def int64) int64 {
+	v, ok := ep.Labels[key]
+	if !ok {
+		return def
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		log.Debugf("Ignoring malformed %s label %q on endpoint %s", key, v, ep.DNSName)
+		return def
+	}
+	return i
+}
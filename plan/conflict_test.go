@@ -20,11 +20,18 @@ import (
 	"reflect"
 	"testing"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
 )
 
-var _ ConflictResolver = PerResource{}
+var (
+	_ ConflictResolver = PerResource{}
+	_ ConflictResolver = OldestResource{}
+	_ ConflictResolver = PerPriority{}
+	_ ConflictResolver = MergeTargets{}
+)
 
 type ResolverSuite struct {
 	// resolvers
@@ -292,6 +299,118 @@ func (suite *ResolverSuite) TestPerResource_ResolveRecordTypes() {
 	}
 }
 
+func (suite *ResolverSuite) TestPriorityResolver() {
+	resolver := PerPriority{}
+	high := &endpoint.Endpoint{
+		DNSName:    "bar",
+		Targets:    endpoint.Targets{"192.168.0.2"},
+		RecordType: "A",
+		Labels: map[string]string{
+			endpoint.ResourceLabelKey: "ingress/default/bar-high",
+			endpoint.PriorityLabelKey: "10",
+		},
+	}
+	invalid := &endpoint.Endpoint{
+		DNSName:    "bar",
+		Targets:    endpoint.Targets{"10.0.0.1"},
+		RecordType: "A",
+		Labels: map[string]string{
+			endpoint.ResourceLabelKey: "ingress/default/bar-invalid",
+			endpoint.PriorityLabelKey: "high",
+		},
+	}
+
+	suite.Equal(high, resolver.ResolveCreate([]*endpoint.Endpoint{suite.bar127A, high, suite.bar192A}), "should pick highest priority")
+	suite.Equal(suite.bar127A, resolver.ResolveCreate([]*endpoint.Endpoint{suite.bar192A, suite.bar127A}), "should pick min one without priorities")
+	suite.Equal(invalid, resolver.ResolveCreate([]*endpoint.Endpoint{suite.bar127A, invalid}), "should treat malformed priority as 0")
+	suite.Equal(high, resolver.ResolveUpdate(suite.bar127A, []*endpoint.Endpoint{suite.bar127A, high}), "should take over from lower priority owner")
+	suite.Equal(suite.bar192A, resolver.ResolveUpdate(suite.bar192A, []*endpoint.Endpoint{suite.bar127A, suite.bar192A}), "should keep owner on equal priority")
+}
+
+func (suite *ResolverSuite) TestOldestResourceResolver() {
+	resolver := OldestResource{}
+	old := &endpoint.Endpoint{
+		DNSName:    "bar",
+		Targets:    endpoint.Targets{"192.168.0.2"},
+		RecordType: "A",
+		Labels: map[string]string{
+			endpoint.ResourceLabelKey:        "ingress/default/bar-old",
+			endpoint.ResourceCreatedLabelKey: "1000",
+		},
+	}
+	young := &endpoint.Endpoint{
+		DNSName:    "bar",
+		Targets:    endpoint.Targets{"10.0.0.1"},
+		RecordType: "A",
+		Labels: map[string]string{
+			endpoint.ResourceLabelKey:        "ingress/default/bar-young",
+			endpoint.ResourceCreatedLabelKey: "2000",
+		},
+	}
+
+	suite.Equal(old, resolver.ResolveCreate([]*endpoint.Endpoint{young, old}), "should pick oldest resource")
+	suite.Equal(young, resolver.ResolveCreate([]*endpoint.Endpoint{suite.bar127A, young}), "should prefer resource with known creation time")
+	suite.Equal(suite.bar127A, resolver.ResolveCreate([]*endpoint.Endpoint{suite.bar192A, suite.bar127A}), "should pick min one without creation times")
+	suite.Equal(old, resolver.ResolveUpdate(young, []*endpoint.Endpoint{young, old}), "should give the name to the oldest resource")
+	suite.Equal(suite.bar192A, resolver.ResolveUpdate(suite.bar192A, []*endpoint.Endpoint{suite.bar127A, suite.bar192A}), "should keep owner when tied")
+}
+
+func (suite *ResolverSuite) TestMergeTargetsResolver() {
+	resolver := MergeTargets{}
+	owners := "ingress/default/bar-127@127.0.0.1+ingress/default/bar-192@192.168.0.1"
+	merged := suite.bar127A.DeepCopy().WithLabel(endpoint.TargetResourcesLabelKey, owners)
+	merged.Targets = endpoint.Targets{"127.0.0.1", "192.168.0.1"}
+	mergedOwnedBy192 := suite.bar192A.DeepCopy().WithLabel(endpoint.TargetResourcesLabelKey, owners)
+	mergedOwnedBy192.Targets = endpoint.Targets{"127.0.0.1", "192.168.0.1"}
+
+	suite.Equal(merged, resolver.ResolveCreate([]*endpoint.Endpoint{suite.bar192A, suite.bar127A}), "should merge targets of all candidates")
+	suite.Equal(mergedOwnedBy192, resolver.ResolveUpdate(suite.bar192A, []*endpoint.Endpoint{suite.bar127A, suite.bar192A}), "should keep current owner as base")
+	suite.Equal(suite.bar127A, resolver.ResolveUpdate(merged, []*endpoint.Endpoint{suite.bar127A}), "should drop targets of resources no longer present")
+	suite.Equal(suite.fooV1Cname, resolver.ResolveCreate([]*endpoint.Endpoint{suite.fooV2Cname, suite.fooV1Cname}), "should not merge CNAME records")
+	suite.Equal(endpoint.Targets{"127.0.0.1"}, suite.bar127A.Targets, "should not modify candidates")
+}
+
+func (suite *ResolverSuite) TestMergeTargetsResolverTargetResources() {
+	resolver := MergeTargets{}
+	shared := suite.bar127A.DeepCopy()
+	shared.Labels[endpoint.ResourceLabelKey] = "ingress/default/other"
+
+	merged := resolver.ResolveCreate([]*endpoint.Endpoint{suite.bar127A, suite.bar192A, shared})
+	suite.Equal("ingress/default/bar-127@127.0.0.1+ingress/default/bar-192@192.168.0.1+ingress/default/other@127.0.0.1", merged.Labels[endpoint.TargetResourcesLabelKey], "should record the resources of each target")
+
+	// removing a resource only drops its own targets, a shared target is kept for the other resource
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, suite.T())
+	updated := resolver.ResolveUpdate(merged, []*endpoint.Endpoint{suite.bar192A, shared})
+	suite.Equal(endpoint.Targets{"127.0.0.1", "192.168.0.1"}, updated.Targets)
+	suite.Equal("ingress/default/bar-192@192.168.0.1+ingress/default/other@127.0.0.1", updated.Labels[endpoint.TargetResourcesLabelKey])
+	suite.Empty(hook.AllEntries(), "should not log a target still requested by another resource")
+
+	updated = resolver.ResolveUpdate(merged, []*endpoint.Endpoint{shared})
+	suite.Equal(endpoint.Targets{"127.0.0.1"}, updated.Targets)
+	suite.NotContains(updated.Labels, endpoint.TargetResourcesLabelKey, "should not label a record requested by a single resource")
+	testutils.TestHelperLogContains("Dropping target 192.168.0.1 of bar, as ingress/default/bar-192 no longer requests it", hook, suite.T())
+}
+
+func (suite *ResolverSuite) TestMergeTargetsResolverWeights() {
+	resolver := MergeTargets{}
+	stable := suite.bar127A.DeepCopy().WithWeight(9)
+	canary := suite.bar192A.DeepCopy().WithWeight(1)
+
+	merged := resolver.ResolveCreate([]*endpoint.Endpoint{stable, canary})
+	suite.Equal(endpoint.Targets{"127.0.0.1", "192.168.0.1"}, merged.Targets)
+	suite.Equal(map[string]int64{"127.0.0.1": 9, "192.168.0.1": 1}, merged.Weights, "should keep the weight of each target")
+
+	merged = resolver.ResolveCreate([]*endpoint.Endpoint{stable, suite.bar192A})
+	suite.Equal(map[string]int64{"127.0.0.1": 9, "192.168.0.1": 1}, merged.Weights, "should weigh targets of unweighted candidates 1")
+
+	shared := suite.bar127A.DeepCopy().WithWeight(2)
+	shared.Labels[endpoint.ResourceLabelKey] = "ingress/default/other"
+	merged = resolver.ResolveCreate([]*endpoint.Endpoint{stable, shared})
+	suite.Equal(map[string]int64{"127.0.0.1": 11}, merged.Weights, "should sum the weights of a target shared by candidates")
+
+	suite.False(resolver.ResolveCreate([]*endpoint.Endpoint{suite.bar127A, suite.bar192A}).IsWeighted(), "should not weigh targets of unweighted candidates")
+}
+
 func TestConflictResolver(t *testing.T) {
 	suite.Run(t, new(ResolverSuite))
 }
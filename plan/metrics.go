@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+// Reasons for which endpoints from the sources are skipped.
+const (
+	// SkipReasonDomainFilter is for endpoints whose DNS name doesn't match the domain filters
+	SkipReasonDomainFilter = "domain-filter"
+	// SkipReasonShard is for endpoints whose DNS name belongs to another shard
+	SkipReasonShard = "shard"
+	// SkipReasonRecordType is for endpoints whose record type isn't managed or is excluded
+	SkipReasonRecordType = "record-type"
+	// SkipReasonTargetFilter is for endpoints all of whose targets are excluded by the target net filters
+	SkipReasonTargetFilter = "target-filter"
+	// SkipReasonAddressFamily is for A endpoints left out by the address family policy in favor of AAAA endpoints
+	SkipReasonAddressFamily = "address-family"
+	// SkipReasonConflict is for endpoints whose targets lost the conflict resolution for their DNS name
+	SkipReasonConflict = "conflict"
+	// SkipReasonOwner is for endpoints whose DNS name is owned by another instance
+	SkipReasonOwner = "owner"
+	// SkipReasonZoneApex is for NS endpoints at the apex of a zone, whose NS records are left to the provider
+	SkipReasonZoneApex = "zone-apex"
+	// SkipReasonWildcard is for endpoints with a wildcard DNS name denied by the wildcard policy
+	SkipReasonWildcard = "wildcard"
+	// SkipReasonNamespaceDomains is for endpoints whose DNS name the namespace of their resource may not request
+	SkipReasonNamespaceDomains = "namespace-domains"
+)
+
+// Wildcard policies for desired endpoints with a wildcard DNS name.
+const (
+	// WildcardPolicyAllow publishes wildcard records
+	WildcardPolicyAllow = "allow"
+	// WildcardPolicyDeny skips wildcard records
+	WildcardPolicyDeny = "deny"
+	// WildcardPolicyReplace publishes wildcard records with a placeholder instead of their "*" label
+	WildcardPolicyReplace = "replace"
+)
+
+// Reasons for which changes are left out of a plan, besides SkipReasonOwner. Changes left out
+// are not counted by the metrics.
+const (
+	// SkipReasonPolicy is for changes not allowed by the policy, e.g. deletions with upsert-only
+	SkipReasonPolicy = "policy"
+	// SkipReasonProtected is for changes of protected records
+	SkipReasonProtected = "protected"
+	// SkipReasonSourceGroup is for changes of records of another source group
+	SkipReasonSourceGroup = "source-group"
+	// SkipReasonPolicyRule is for changes denied by a policy rule evaluated over the plan
+	SkipReasonPolicyRule = "policy-rule"
+)
+
+var skippedEndpointsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Subsystem: "source",
+		Name:      "skipped_endpoints_total",
+		Help:      "Number of endpoints from the sources skipped by each synchronization, by reason and kind of source resource (vector).",
+	},
+	[]string{"reason", "source"},
+)
+
+var ownershipConflictsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Subsystem: "registry",
+		Name:      "ownership_conflicts_total",
+		Help:      "Number of desired endpoints skipped by each synchronization because their DNS name is owned by another owner ID, by zone and owner (vector).",
+	},
+	[]string{"zone", "owner"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(skippedEndpointsTotal)
+	metrics.RegisterMetric.MustRegister(ownershipConflictsTotal)
+}
+
+// CountSkipped counts the given endpoints as skipped for the given reason, labeled by the kind of their
+// source resource, or "unknown" if they have no resource label.
+func CountSkipped(reason string, endpoints ...*endpoint.Endpoint) {
+	for _, ep := range endpoints {
+		kind, _, _ := strings.Cut(ep.Labels[endpoint.ResourceLabelKey], "/")
+		if kind == "" {
+			kind = "unknown"
+		}
+		skippedEndpointsTotal.CounterVec.WithLabelValues(reason, kind).Inc()
+	}
+}
+
+// domainSkipReason returns why the domain filter doesn't match the DNS name, or an empty string if it does.
+func domainSkipReason(filter endpoint.DomainFilterInterface, dnsName string) string {
+	switch f := filter.(type) {
+	case nil:
+		return ""
+	case endpoint.MatchAllDomainFilters:
+		for _, sub := range f {
+			if reason := domainSkipReason(sub, dnsName); reason != "" {
+				return reason
+			}
+		}
+		return ""
+	case endpoint.ShardFilter:
+		if !f.Match(dnsName) {
+			return SkipReasonShard
+		}
+		return ""
+	}
+	if !filter.Match(dnsName) {
+		return SkipReasonDomainFilter
+	}
+	return ""
+}
+
+// conflictLosers returns the candidates with targets missing from the endpoint resolved from them.
+func conflictLosers(resolved *endpoint.Endpoint, candidates []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if resolved == nil {
+		return candidates
+	}
+	var losers []*endpoint.Endpoint
+	for _, c := range candidates {
+		for _, target := range c.Targets {
+			if !slices.Contains(resolved.Targets, target) {
+				losers = append(losers, c)
+				break
+			}
+		}
+	}
+	return losers
+}
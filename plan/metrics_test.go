@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestCalculateCountsSkipped(t *testing.T) {
+	service := func(ep *endpoint.Endpoint, name string) *endpoint.Endpoint {
+		return ep.WithLabel(endpoint.ResourceLabelKey, "service/default/"+name)
+	}
+	counter := func(reason, source string) float64 {
+		return testutil.ToFloat64(skippedEndpointsTotal.CounterVec.WithLabelValues(reason, source))
+	}
+	before := map[string]float64{
+		SkipReasonDomainFilter: counter(SkipReasonDomainFilter, "service"),
+		SkipReasonRecordType:   counter(SkipReasonRecordType, "unknown"),
+		SkipReasonConflict:     counter(SkipReasonConflict, "service"),
+		SkipReasonOwner:        counter(SkipReasonOwner, "ingress"),
+	}
+
+	p := &Plan{
+		Policies: []Policy{&SyncPolicy{}},
+		Current: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("owned.example.com", endpoint.RecordTypeA, "5.5.5.5").WithLabel(endpoint.OwnerLabelKey, "other"),
+		},
+		Desired: []*endpoint.Endpoint{
+			service(endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.1.1.1"), "foo"),
+			endpoint.NewEndpoint("txt.example.com", endpoint.RecordTypeTXT, "text"),
+			service(endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "1.1.1.1"), "a"),
+			service(endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "2.2.2.2"), "b"),
+			endpoint.NewEndpoint("owned.example.com", endpoint.RecordTypeAAAA, "::1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/owned"),
+		},
+		DomainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.com"})},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+		OwnerID:        "owner",
+	}
+	changes := p.Calculate().Changes
+
+	assert.Len(t, changes.Create, 1)
+	assert.InDelta(t, before[SkipReasonDomainFilter]+1, counter(SkipReasonDomainFilter, "service"), 0)
+	assert.InDelta(t, before[SkipReasonRecordType]+1, counter(SkipReasonRecordType, "unknown"), 0)
+	assert.InDelta(t, before[SkipReasonConflict]+1, counter(SkipReasonConflict, "service"), 0)
+	assert.InDelta(t, before[SkipReasonOwner]+1, counter(SkipReasonOwner, "ingress"), 0)
+}
+
+func TestCalculateCountsOwnershipConflicts(t *testing.T) {
+	counter := func(zone, owner string) float64 {
+		return testutil.ToFloat64(ownershipConflictsTotal.CounterVec.WithLabelValues(zone, owner))
+	}
+	before := counter("example.com", "other")
+
+	p := &Plan{
+		Policies: []Policy{&SyncPolicy{}},
+		Current: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("owned.example.com", endpoint.RecordTypeA, "5.5.5.5").WithLabel(endpoint.OwnerLabelKey, "other"),
+		},
+		Desired: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("owned.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("owned.example.com", endpoint.RecordTypeAAAA, "::1"),
+		},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+		OwnerID:        "owner",
+		ZoneApexes:     []string{"Example.com."},
+	}
+	calculated := p.Calculate()
+
+	assert.False(t, calculated.Changes.HasChanges())
+	assert.InDelta(t, before+2, counter("example.com", "other"), 0)
+	var actions []string
+	for _, skipped := range calculated.Skipped {
+		assert.Equal(t, SkipReasonOwner, skipped.Reason)
+		assert.Equal(t, `owned by "other" instead of "owner"`, skipped.Message)
+		actions = append(actions, skipped.Action)
+	}
+	assert.ElementsMatch(t, []string{"", ActionUpdate}, actions)
+}
+
+func TestDomainSkipReason(t *testing.T) {
+	shard := endpoint.NewShardFilter(0, 2)
+	var inShard, otherShard string
+	for _, name := range []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com"} {
+		if shard.Match(name) {
+			inShard = name
+		} else {
+			otherShard = name
+		}
+	}
+	filter := endpoint.MatchAllDomainFilters{
+		endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.com"}), shard},
+		nil,
+	}
+
+	assert.Empty(t, domainSkipReason(filter, inShard))
+	assert.Equal(t, SkipReasonShard, domainSkipReason(filter, otherShard))
+	assert.Equal(t, SkipReasonDomainFilter, domainSkipReason(filter, "foo.example.org"))
+	assert.Empty(t, domainSkipReason(nil, "foo.example.org"))
+}
+
+func TestConflictLosers(t *testing.T) {
+	a := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	b := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "2.2.2.2")
+	merged := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1", "2.2.2.2")
+
+	assert.Equal(t, []*endpoint.Endpoint{b}, conflictLosers(a, []*endpoint.Endpoint{a, b}))
+	assert.Empty(t, conflictLosers(merged, []*endpoint.Endpoint{a, b}))
+	assert.Equal(t, []*endpoint.Endpoint{a}, conflictLosers(nil, []*endpoint.Endpoint{a}))
+}
@@ -18,7 +18,10 @@ package plan
 
 import (
 	"fmt"
+	"maps"
+	"net/netip"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -26,7 +29,6 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
-	"sigs.k8s.io/external-dns/internal/idna"
 )
 
 // PropertyComparator is used in Plan for comparing the previous and current custom annotations.
@@ -52,6 +54,25 @@ type Plan struct {
 	ExcludeRecords []string
 	// OwnerID of records to manage
 	OwnerID string
+	// ConflictResolver decides which candidate acquires a DNS name claimed by several resources.
+	// Defaults to PerResource when not set.
+	ConflictResolver ConflictResolver
+	// SourceGroup identifies the sources of this external dns among several sharing the same OwnerID.
+	// When set, desired records are labeled with it and records of other groups are neither deleted nor updated.
+	SourceGroup string
+	// ZoneApexes are the DNS names of the zones. The NS records at their apex belong to the provider and are
+	// never changed, so that only the NS records delegating subdomains are managed.
+	ZoneApexes []string
+	// WildcardPolicy decides whether desired endpoints with a wildcard DNS name are published, skipped or
+	// published with WildcardPlaceholder as their first label. Defaults to WildcardPolicyAllow when not set.
+	WildcardPolicy string
+	// WildcardPlaceholder replaces the "*" label of wildcard DNS names with WildcardPolicyReplace.
+	WildcardPlaceholder string
+	// Skipped lists the desired endpoints and the changes left out of the plan, along with the reason why.
+	// Populated after calling Calculate()
+	Skipped []Skipped
+	// Annotations lists the messages policy rules attached to the changes, which are applied nonetheless.
+	Annotations []Annotation
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -95,8 +116,11 @@ type planTable struct {
 	resolver ConflictResolver
 }
 
-func newPlanTable() planTable { // TODO: make resolver configurable
-	return planTable{map[planKey]*planTableRow{}, PerResource{}}
+func newPlanTable(resolver ConflictResolver, size int) planTable {
+	if resolver == nil {
+		resolver = PerResource{}
+	}
+	return planTable{make(map[planKey]*planTableRow, size), resolver}
 }
 
 // planTableRow represents a set of current and desired domain resource records.
@@ -127,34 +151,40 @@ func (t planTableRow) String() string {
 }
 
 func (t *planTable) addCurrent(e *endpoint.Endpoint) {
-	key := t.newPlanKey(e)
-	t.rows[key].current = append(t.rows[key].current, e)
-	t.rows[key].records[e.RecordType].current = e
+	row, records := t.row(e)
+	row.current = append(row.current, e)
+	records.current = e
 }
 
 func (t *planTable) addCandidate(e *endpoint.Endpoint) {
-	key := t.newPlanKey(e)
-	t.rows[key].candidates = append(t.rows[key].candidates, e)
-	t.rows[key].records[e.RecordType].candidates = append(t.rows[key].records[e.RecordType].candidates, e)
+	row, records := t.row(e)
+	row.candidates = append(row.candidates, e)
+	records.candidates = append(records.candidates, e)
 }
 
-func (t *planTable) newPlanKey(e *endpoint.Endpoint) planKey {
+// row returns the row of the endpoint and its records of the same type, adding them if missing. The DNS name
+// is normalized once per endpoint, and both are looked up once, as large estates have as many rows as records.
+func (t *planTable) row(e *endpoint.Endpoint) (*planTableRow, *domainEndpoints) {
 	key := planKey{
 		dnsName:       normalizeDNSName(e.DNSName),
 		setIdentifier: e.SetIdentifier,
 	}
 
-	if _, ok := t.rows[key]; !ok {
-		t.rows[key] = &planTableRow{
-			records: make(map[string]*domainEndpoints),
+	row, ok := t.rows[key]
+	if !ok {
+		row = &planTableRow{
+			records: make(map[string]*domainEndpoints, 1),
 		}
+		t.rows[key] = row
 	}
 
-	if _, ok := t.rows[key].records[e.RecordType]; !ok {
-		t.rows[key].records[e.RecordType] = &domainEndpoints{}
+	records, ok := row.records[e.RecordType]
+	if !ok {
+		records = &domainEndpoints{}
+		row.records[e.RecordType] = records
 	}
 
-	return key
+	return row, records
 }
 
 func (c *Changes) HasChanges() bool {
@@ -168,28 +198,40 @@ func (c *Changes) HasChanges() bool {
 // state. It then passes those changes to the current policy for further
 // processing. It returns a copy of Plan with the changes populated.
 func (p *Plan) Calculate() *Plan {
-	t := newPlanTable()
+	t := newPlanTable(p.ConflictResolver, max(len(p.Current), len(p.Desired)))
+	var skipped skipLog
 
 	if p.DomainFilter == nil {
 		p.DomainFilter = endpoint.MatchAllDomainFilters(nil)
 	}
 
-	for _, current := range filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	for _, current := range filterZoneApexNS(filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords, nil), p.ZoneApexes, nil) {
 		t.addCurrent(current)
 	}
-	for _, desired := range filterRecordsForPlan(p.Desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	desired := filterWildcards(p.Desired, p.WildcardPolicy, p.WildcardPlaceholder, &skipped)
+	for _, desired := range filterZoneApexNS(filterRecordsForPlan(desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords, &skipped), p.ZoneApexes, &skipped) {
+		if p.SourceGroup != "" {
+			desired.WithLabel(endpoint.SourceGroupLabelKey, p.SourceGroup)
+		}
 		t.addCandidate(desired)
 	}
 
 	changes := &Changes{}
+	zones := normalizeZones(p.ZoneApexes)
 
 	for key, row := range t.rows {
 		// dns name not taken
 		if len(row.current) == 0 {
 			recordsByType := t.resolver.ResolveRecordTypes(key, row)
-			for _, records := range recordsByType {
+			skipped.source(SkipReasonConflict, discardedCandidates(row.records, recordsByType)...)
+			families := addressFamilies{}
+			for _, recordType := range recordTypes(recordsByType) {
+				records := recordsByType[recordType]
 				if len(records.candidates) > 0 {
-					changes.Create = append(changes.Create, t.resolver.ResolveCreate(records.candidates))
+					create := t.resolver.ResolveCreate(families.candidates(t.resolver, recordType, records.candidates))
+					families.resolved(create)
+					skipped.source(SkipReasonConflict, conflictLosers(create, records.candidates)...)
+					changes.Create = append(changes.Create, create)
 				}
 			}
 		}
@@ -205,7 +247,11 @@ func (p *Plan) Calculate() *Plan {
 
 			// apply changes for each record type
 			recordsByType := t.resolver.ResolveRecordTypes(key, row)
-			for _, records := range recordsByType {
+			skipped.source(SkipReasonConflict, discardedCandidates(row.records, recordsByType)...)
+			families := addressFamilies{}
+			// the record types are resolved in order, so that the AAAA record follows the resource of the A record
+			for _, recordType := range recordTypes(recordsByType) {
+				records := recordsByType[recordType]
 				// record type not desired
 				if records.current != nil && len(records.candidates) == 0 {
 					changes.Delete = append(changes.Delete, records.current)
@@ -213,7 +259,9 @@ func (p *Plan) Calculate() *Plan {
 
 				// new record type desired
 				if records.current == nil && len(records.candidates) > 0 {
-					update := t.resolver.ResolveCreate(records.candidates)
+					update := t.resolver.ResolveCreate(families.candidates(t.resolver, recordType, records.candidates))
+					families.resolved(update)
+					skipped.source(SkipReasonConflict, conflictLosers(update, records.candidates)...)
 					// creates are evaluated after all domain records have been processed to
 					// validate that this external dns has ownership claim on the domain before
 					// adding the records to planned changes.
@@ -222,9 +270,11 @@ func (p *Plan) Calculate() *Plan {
 
 				// update existing record
 				if records.current != nil && len(records.candidates) > 0 {
-					update := t.resolver.ResolveUpdate(records.current, records.candidates)
+					update := t.resolver.ResolveUpdate(records.current, families.candidates(t.resolver, recordType, records.candidates))
+					families.resolved(update)
+					skipped.source(SkipReasonConflict, conflictLosers(update, records.candidates)...)
 
-					if shouldUpdateTTL(update, records.current) || targetChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) {
+					if shouldUpdateTTL(update, records.current) || targetChanged(update, records.current) || weightsChanged(update, records.current) || geoChanged(update, records.current) || descriptionChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) || protectionChanged(update, records.current) || sourceGroupChanged(update, records.current) || resourceLabelsChanged(update, records.current) || targetResourcesChanged(update, records.current) {
 						inheritOwner(records.current, update)
 						changes.UpdateNew = append(changes.UpdateNew, update)
 						changes.UpdateOld = append(changes.UpdateOld, records.current)
@@ -243,9 +293,15 @@ func (p *Plan) Calculate() *Plan {
 
 				if ownersMatch {
 					changes.Create = append(changes.Create, creates...)
-				} else if log.GetLevel() == log.DebugLevel {
-					for _, current := range row.current {
-						log.Debugf(`Skipping endpoint %v because owner id does not match for one or more items to create, found: "%s", required: "%s"`, current, current.Labels[endpoint.OwnerLabelKey], p.OwnerID)
+				} else {
+					CountSkipped(SkipReasonOwner, creates...)
+					skipped.ownershipConflict(zones, p.OwnerID, "", func(*endpoint.Endpoint) string {
+						return otherOwner(row.current, p.OwnerID)
+					}, creates...)
+					if log.GetLevel() == log.DebugLevel {
+						for _, current := range row.current {
+							log.Debugf(`Skipping endpoint %v because owner id does not match for one or more items to create, found: "%s", required: "%s"`, current, current.Labels[endpoint.OwnerLabelKey], p.OwnerID)
+						}
 					}
 				}
 			}
@@ -253,21 +309,42 @@ func (p *Plan) Calculate() *Plan {
 	}
 
 	for _, pol := range p.Policies {
-		changes = pol.Apply(changes)
+		filtered := pol.Apply(changes)
+		skipped.changes(SkipReasonPolicy, changes, filtered)
+		changes = filtered
+	}
+
+	filtered := filterProtectedChanges(changes)
+	skipped.changes(SkipReasonProtected, changes, filtered)
+	changes = filtered
+	if p.SourceGroup != "" {
+		filtered = filterSourceGroupChanges(p.SourceGroup, changes)
+		skipped.changes(SkipReasonSourceGroup, changes, filtered)
+		changes = filtered
 	}
 
 	// filter out updates this external dns does not have ownership claim over
 	if p.OwnerID != "" {
-		changes.Delete = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.Delete)
+		filtered = &Changes{
+			Create:    changes.Create,
+			Delete:    endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.Delete),
+			UpdateOld: endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateOld),
+			UpdateNew: endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew),
+		}
+		// the updates carry the owner of the records they replace
+		skipped.ownershipConflict(zones, p.OwnerID, ActionUpdate, func(ep *endpoint.Endpoint) string {
+			return ep.Labels[endpoint.OwnerLabelKey]
+		}, removedEndpoints(changes.UpdateNew, filtered.UpdateNew)...)
+		skipped.changes(SkipReasonOwner, &Changes{Delete: changes.Delete}, &Changes{Delete: filtered.Delete})
+		changes = filtered
 		changes.Delete = endpoint.RemoveDuplicates(changes.Delete)
-		changes.UpdateOld = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateOld)
-		changes.UpdateNew = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew)
 	}
 
 	plan := &Plan{
 		Current: p.Current,
 		Desired: p.Desired,
 		Changes: changes,
+		Skipped: skipped,
 		// The default for ExternalDNS is to always only consider A/AAAA and CNAMEs.
 		// Everything else is an add on or something to be considered.
 		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
@@ -276,6 +353,17 @@ func (p *Plan) Calculate() *Plan {
 	return plan
 }
 
+// recordTypes returns the sorted record types of a row. Most rows hold a single record type, returned without
+// collecting and sorting the keys.
+func recordTypes(records map[string]*domainEndpoints) []string {
+	if len(records) == 1 {
+		for recordType := range records {
+			return []string{recordType}
+		}
+	}
+	return slices.Sorted(maps.Keys(records))
+}
+
 func inheritOwner(from, to *endpoint.Endpoint) {
 	if to.Labels == nil {
 		to.Labels = map[string]string{}
@@ -287,7 +375,190 @@ func inheritOwner(from, to *endpoint.Endpoint) {
 }
 
 func targetChanged(desired, current *endpoint.Endpoint) bool {
-	return !desired.Targets.Same(current.Targets)
+	if slices.Equal(desired.Targets, current.Targets) {
+		// identical targets are equal in canonical form, which spares normalizing the targets of up to date records
+		return false
+	}
+	return !slices.Equal(normalizeTargets(desired), normalizeTargets(current))
+}
+
+// normalizeTargets returns a sorted copy of the endpoint targets in canonical form, so that targets
+// which only differ in order, letter case, trailing dots or IPv6 notation compare as equal.
+// The fields of MX, SRV, SVCB, HTTPS, TLSA and NAPTR targets are compared individually, ignoring their spacing.
+// TXT values are case-sensitive and compared as values, whether given as is or as quoted character-strings.
+func normalizeTargets(ep *endpoint.Endpoint) []string {
+	normalized := make([]string, 0, len(ep.Targets))
+	for _, t := range ep.Targets {
+		normalized = append(normalized, normalizeTarget(ep.RecordType, t))
+	}
+	slices.Sort(normalized)
+	return normalized
+}
+
+// normalizeTarget returns the target of a record of the given type in canonical form.
+func normalizeTarget(recordType, t string) string {
+	switch recordType {
+	case endpoint.RecordTypeTXT:
+		t = endpoint.JoinTXT(t)
+	case endpoint.RecordTypeNAPTR:
+		// the flags and the replacement are case-insensitive, the service and the regexp are compared as is
+		if naptr, err := endpoint.NewNAPTRRecord(t); err == nil {
+			*naptr.GetFlags() = strings.ToLower(*naptr.GetFlags())
+			if replacement := naptr.GetReplacement(); *replacement != "." {
+				*replacement = strings.ToLower(strings.TrimSuffix(*replacement, "."))
+			}
+			t = naptr.String()
+		}
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		// the numeric fields are compared as numbers, so that e.g. "010 5 80 a" and "10 5 80 a." are equal
+		fields := strings.Fields(t)
+		for i, field := range fields[:max(len(fields)-1, 0)] {
+			if n, err := strconv.ParseUint(field, 10, 16); err == nil {
+				fields[i] = strconv.FormatUint(n, 10)
+			}
+		}
+		t = strings.ToLower(strings.TrimSuffix(strings.Join(fields, " "), "."))
+	case endpoint.RecordTypeSVCB, endpoint.RecordTypeHTTPS:
+		// only the target name is case-insensitive, parameters are compared without quotes
+		if svcb, err := endpoint.NewSVCBRecord(t); err == nil {
+			target := svcb.GetTarget()
+			if *target != "." {
+				*target = strings.ToLower(strings.TrimSuffix(*target, "."))
+			}
+			t = svcb.String()
+		}
+	case endpoint.RecordTypeTLSA:
+		// the certificate association data is case-insensitive and may be split in blocks
+		if tlsa, err := endpoint.NewTLSARecord(t); err == nil {
+			t = tlsa.String()
+		}
+	default:
+		if ip, err := netip.ParseAddr(t); err == nil {
+			t = ip.String()
+		} else {
+			t = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(t), "."))
+		}
+	}
+	return t
+}
+
+// weightsChanged returns true if the desired and current record differ in being weighted, or in the weight
+// of any of their targets, compared in canonical form.
+func weightsChanged(desired, current *endpoint.Endpoint) bool {
+	if desired.IsWeighted() != current.IsWeighted() {
+		return true
+	}
+	if !desired.IsWeighted() {
+		return false
+	}
+	weights := make(map[string]int64, len(current.Targets))
+	for _, t := range current.Targets {
+		weights[normalizeTarget(current.RecordType, t)] = current.TargetWeight(t)
+	}
+	for _, t := range desired.Targets {
+		if weight, ok := weights[normalizeTarget(desired.RecordType, t)]; ok && weight != desired.TargetWeight(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// geoChanged returns true if the desired and current record differ in their geo location.
+func geoChanged(desired, current *endpoint.Endpoint) bool {
+	if desired.Geo == nil || current.Geo == nil {
+		return (desired.Geo == nil) != (current.Geo == nil)
+	}
+	return !strings.EqualFold(desired.Geo.Continent, current.Geo.Continent) ||
+		!strings.EqualFold(desired.Geo.Country, current.Geo.Country) ||
+		!strings.EqualFold(desired.Geo.Region, current.Geo.Region)
+}
+
+// descriptionChanged returns true if the desired record has a description other than the one of the current record.
+// Records whose description is removed aren't updated, as some providers, such as PowerDNS, keep the comment of a
+// record unless given another one.
+func descriptionChanged(desired, current *endpoint.Endpoint) bool {
+	return desired.Description != "" && desired.Description != current.Description
+}
+
+// protectionChanged returns true if the desired record is protected and the current one is not, or
+// vice versa, so that the registry gets to store the new protection state.
+func protectionChanged(desired, current *endpoint.Endpoint) bool {
+	return desired.IsProtected() != current.IsProtected()
+}
+
+// filterProtectedChanges removes deletions of protected records, as well as updates of protected
+// records requested by a resource other than the one which created them.
+func filterProtectedChanges(changes *Changes) *Changes {
+	filtered := &Changes{
+		Create: changes.Create,
+	}
+	for _, del := range changes.Delete {
+		if del.IsProtected() {
+			log.Infof("Skipping deletion of protected record %s", del)
+			continue
+		}
+		filtered.Delete = append(filtered.Delete, del)
+	}
+	for i, old := range changes.UpdateOld {
+		update := changes.UpdateNew[i]
+		if old.IsProtected() && old.Labels[endpoint.ResourceLabelKey] != update.Labels[endpoint.ResourceLabelKey] {
+			log.Infof(`Skipping update of protected record %s requested by "%s", owned by "%s"`, old, update.Labels[endpoint.ResourceLabelKey], old.Labels[endpoint.ResourceLabelKey])
+			continue
+		}
+		filtered.UpdateOld = append(filtered.UpdateOld, old)
+		filtered.UpdateNew = append(filtered.UpdateNew, update)
+	}
+	return filtered
+}
+
+func sourceGroupChanged(desired, current *endpoint.Endpoint) bool {
+	return desired.Labels[endpoint.SourceGroupLabelKey] != current.Labels[endpoint.SourceGroupLabelKey]
+}
+
+// targetResourcesChanged returns true if the resources which requested the targets of a merged record changed, so
+// that the registry gets to store them.
+func targetResourcesChanged(desired, current *endpoint.Endpoint) bool {
+	return desired.Labels[endpoint.TargetResourcesLabelKey] != current.Labels[endpoint.TargetResourcesLabelKey]
+}
+
+// resourceLabelsChanged returns true if the resource labels copied to the desired record differ from the ones
+// stored with the current one, so that the registry gets to store them.
+func resourceLabelsChanged(desired, current *endpoint.Endpoint) bool {
+	return !maps.Equal(resourceLabels(desired), resourceLabels(current))
+}
+
+func resourceLabels(ep *endpoint.Endpoint) map[string]string {
+	labels := map[string]string{}
+	for key, value := range ep.Labels {
+		if strings.HasPrefix(key, endpoint.ResourceLabelPrefix) {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// filterSourceGroupChanges removes deletions of records not created by the given source group, as well as
+// updates of records created by another source group. Records without source group are adopted on update.
+func filterSourceGroupChanges(group string, changes *Changes) *Changes {
+	filtered := &Changes{
+		Create: changes.Create,
+	}
+	for _, del := range changes.Delete {
+		if owner := del.Labels[endpoint.SourceGroupLabelKey]; owner != group {
+			log.Debugf(`Skipping deletion of record %s because source group does not match, found: "%s", required: "%s"`, del, owner, group)
+			continue
+		}
+		filtered.Delete = append(filtered.Delete, del)
+	}
+	for i, old := range changes.UpdateOld {
+		if owner := old.Labels[endpoint.SourceGroupLabelKey]; owner != "" && owner != group {
+			log.Debugf(`Skipping update of record %s because source group does not match, found: "%s", required: "%s"`, old, owner, group)
+			continue
+		}
+		filtered.UpdateOld = append(filtered.UpdateOld, old)
+		filtered.UpdateNew = append(filtered.UpdateNew, changes.UpdateNew[i])
+	}
+	return filtered
 }
 
 func shouldUpdateTTL(desired, current *endpoint.Endpoint) bool {
@@ -324,39 +595,137 @@ func (p *Plan) shouldUpdateProviderSpecific(desired, current *endpoint.Endpoint)
 // Per RFC 1034, CNAME records conflict with all other records - it is the
 // only record with this property. The behavior of the planner may need to be
 // made more sophisticated to codify this.
-func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.MatchAllDomainFilters, managedRecords, excludeRecords []string) []*endpoint.Endpoint {
+func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.MatchAllDomainFilters, managedRecords, excludeRecords []string, skipped *skipLog) []*endpoint.Endpoint {
 	filtered := []*endpoint.Endpoint{}
 
 	for _, record := range records {
 		// Ignore records that do not match the domain filter provided
-		if !domainFilter.Match(record.DNSName) {
+		if reason := domainSkipReason(domainFilter, record.DNSName); reason != "" {
 			log.Debugf("ignoring record %s that does not match domain filter", record.DNSName)
+			if skipped != nil {
+				skipped.source(reason, record)
+			}
 			continue
 		}
 		if IsManagedRecord(record.RecordType, managedRecords, excludeRecords) {
 			filtered = append(filtered, record)
+		} else if skipped != nil {
+			skipped.source(SkipReasonRecordType, record)
 		}
 	}
 
 	return filtered
 }
 
-// normalizeDNSName converts a DNS name to a canonical form, so that we can use string equality
-// it: removes space, get ASCII version of dnsName complient with Section 5 of RFC 5891, ensures there is a trailing dot
-func normalizeDNSName(dnsName string) string {
-	s, err := idna.Profile.ToASCII(strings.TrimSpace(dnsName))
-	if err != nil {
-		log.Warnf(`Got error while parsing DNSName %s: %v`, dnsName, err)
+// otherOwner returns the owner ID of the first of the records which isn't owned by the given owner ID.
+func otherOwner(records []*endpoint.Endpoint, ownerID string) string {
+	for _, record := range records {
+		if !record.IsOwnedBy(ownerID) {
+			return record.Labels[endpoint.OwnerLabelKey]
+		}
+	}
+	return ""
+}
+
+// filterZoneApexNS removes the NS records at the apex of the given zones, which are managed by the provider
+// along with the SOA record. Changing them would break the resolution of the whole zone.
+func filterZoneApexNS(records []*endpoint.Endpoint, zoneApexes []string, skipped *skipLog) []*endpoint.Endpoint {
+	if len(zoneApexes) == 0 {
+		return records
+	}
+	apexes := make(map[string]bool, len(zoneApexes))
+	for _, apex := range zoneApexes {
+		apexes[normalizeDNSName(apex)] = true
+	}
+	filtered := make([]*endpoint.Endpoint, 0, len(records))
+	for _, record := range records {
+		if record.RecordType == endpoint.RecordTypeNS && apexes[normalizeDNSName(record.DNSName)] {
+			log.Debugf("ignoring NS record %s at the apex of its zone", record.DNSName)
+			if skipped != nil {
+				skipped.source(SkipReasonZoneApex, record)
+			}
+			continue
+		}
+		filtered = append(filtered, record)
 	}
-	if !strings.HasSuffix(s, ".") {
-		s += "."
+	return filtered
+}
+
+// filterWildcards applies the wildcard policy to the desired endpoints with a wildcard DNS name. Endpoints
+// with a replaced DNS name are copies, so that the desired endpoints of the plan keep their DNS names.
+func filterWildcards(records []*endpoint.Endpoint, policy, placeholder string, skipped *skipLog) []*endpoint.Endpoint {
+	if policy == "" || policy == WildcardPolicyAllow {
+		return records
 	}
-	return s
+	filtered := make([]*endpoint.Endpoint, 0, len(records))
+	for _, record := range records {
+		if record.DNSName != "*" && !strings.HasPrefix(record.DNSName, "*.") {
+			filtered = append(filtered, record)
+			continue
+		}
+		if policy == WildcardPolicyDeny {
+			log.Debugf("ignoring wildcard record %s denied by the wildcard policy", record.DNSName)
+			skipped.source(SkipReasonWildcard, record)
+			continue
+		}
+		replaced := record.DeepCopy()
+		replaced.DNSName = placeholder + strings.TrimPrefix(record.DNSName, "*")
+		log.Debugf("replacing wildcard record %s by %s", record.DNSName, replaced.DNSName)
+		filtered = append(filtered, replaced)
+	}
+	return filtered
 }
 
+// normalizeDNSName converts a DNS name to a canonical form, so that we can use string equality:
+// the canonical name of the endpoint package, with a trailing dot.
+func normalizeDNSName(dnsName string) string {
+	return endpoint.CanonicalName(dnsName) + "."
+}
+
+// IsManagedRecord returns whether records of the given type are managed: the type is one of the managed
+// record types and none of the excluded record types, which take precedence. Record types are case-insensitive.
 func IsManagedRecord(record string, managedRecords, excludeRecords []string) bool {
-	if slices.Contains(excludeRecords, record) {
+	if containsRecordType(excludeRecords, record) {
 		return false
 	}
-	return slices.Contains(managedRecords, record)
+	return containsRecordType(managedRecords, record)
+}
+
+func containsRecordType(recordTypes []string, record string) bool {
+	return slices.ContainsFunc(recordTypes, func(t string) bool {
+		return strings.EqualFold(t, record)
+	})
+}
+
+// ExcludeRecordTypes returns the changes without those of records with an excluded type. Registries apply it
+// to the changes they are given, so that excluded records are never touched, however the changes were made.
+func (c *Changes) ExcludeRecordTypes(excludeRecords []string) *Changes {
+	if len(excludeRecords) == 0 {
+		return c
+	}
+	filtered := &Changes{}
+	keep := func(ep *endpoint.Endpoint, action string) bool {
+		if containsRecordType(excludeRecords, ep.RecordType) {
+			log.Warnf("Skipping %s of record %s with excluded record type %s", action, ep.DNSName, ep.RecordType)
+			return false
+		}
+		return true
+	}
+	for _, ep := range c.Create {
+		if keep(ep, ActionCreate) {
+			filtered.Create = append(filtered.Create, ep)
+		}
+	}
+	for i, old := range c.UpdateOld {
+		if keep(old, ActionUpdate) && keep(c.UpdateNew[i], ActionUpdate) {
+			filtered.UpdateOld = append(filtered.UpdateOld, old)
+			filtered.UpdateNew = append(filtered.UpdateNew, c.UpdateNew[i])
+		}
+	}
+	for _, ep := range c.Delete {
+		if keep(ep, ActionDelete) {
+			filtered.Delete = append(filtered.Delete, ep)
+		}
+	}
+	return filtered
 }
@@ -19,6 +19,7 @@ package plan
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -1028,6 +1029,34 @@ func validateEntries(t *testing.T, entries, expected []*endpoint.Endpoint) {
 	}
 }
 
+func TestPlanMixedCaseIDNZone(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("shop.xn--bcher-kva.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("old.xn--bcher-kva.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("Shop.Bücher.EXAMPLE.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("www.BÜCHER.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("www.gopher.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}
+
+	for _, zone := range []string{"Bücher.Example.com", "XN--BCHER-KVA.example.com"} {
+		t.Run(zone, func(t *testing.T) {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        current,
+				Desired:        desired,
+				DomainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{zone})},
+				ManagedRecords: []string{endpoint.RecordTypeA},
+			}
+			changes := p.Calculate().Changes
+			validateEntries(t, changes.Create, []*endpoint.Endpoint{desired[1]})
+			assert.Empty(t, changes.UpdateNew)
+			validateEntries(t, changes.Delete, []*endpoint.Endpoint{current[1]})
+		})
+	}
+}
+
 func TestNormalizeDNSName(tt *testing.T) {
 	records := []struct {
 		dnsName string
@@ -1203,3 +1232,612 @@ func TestShouldUpdateProviderSpecific(tt *testing.T) {
 		})
 	}
 }
+
+func TestTargetChanged(tt *testing.T) {
+	for _, test := range []struct {
+		name       string
+		recordType string
+		current    endpoint.Targets
+		desired    endpoint.Targets
+		changed    bool
+	}{
+		{
+			name:       "same targets",
+			recordType: endpoint.RecordTypeA,
+			current:    endpoint.Targets{"1.2.3.4", "5.6.7.8"},
+			desired:    endpoint.Targets{"1.2.3.4", "5.6.7.8"},
+		},
+		{
+			name:       "different order",
+			recordType: endpoint.RecordTypeA,
+			current:    endpoint.Targets{"5.6.7.8", "1.2.3.4"},
+			desired:    endpoint.Targets{"1.2.3.4", "5.6.7.8"},
+		},
+		{
+			name:       "different case and order",
+			recordType: endpoint.RecordTypeCNAME,
+			current:    endpoint.Targets{"B.example.com", "a.example.com"},
+			desired:    endpoint.Targets{"b.example.com", "A.example.com"},
+		},
+		{
+			name:       "trailing dot",
+			recordType: endpoint.RecordTypeCNAME,
+			current:    endpoint.Targets{"lb.example.com."},
+			desired:    endpoint.Targets{"lb.example.com"},
+		},
+		{
+			name:       "shortened ipv6",
+			recordType: endpoint.RecordTypeAAAA,
+			current:    endpoint.Targets{"2001:db8:0:0:0:0:0:1", "2001:db8::2"},
+			desired:    endpoint.Targets{"2001:DB8::1", "2001:db8::2"},
+		},
+		{
+			name:       "ipv6 mismatch after shortened element",
+			recordType: endpoint.RecordTypeAAAA,
+			current:    endpoint.Targets{"2001:db8:0:0:0:0:0:1", "2001:db8::2"},
+			desired:    endpoint.Targets{"2001:db8::1", "2001:db8::3"},
+			changed:    true,
+		},
+		{
+			name:       "srv spacing, leading zeros and trailing dot",
+			recordType: endpoint.RecordTypeSRV,
+			current:    endpoint.Targets{"10 5 5060 SIP.example.com."},
+			desired:    endpoint.Targets{"10  05 5060 sip.example.com"},
+		},
+		{
+			name:       "srv weight changed",
+			recordType: endpoint.RecordTypeSRV,
+			current:    endpoint.Targets{"10 5 5060 sip.example.com."},
+			desired:    endpoint.Targets{"10 6 5060 sip.example.com"},
+			changed:    true,
+		},
+		{
+			name:       "https quotes, spacing and target case",
+			recordType: endpoint.RecordTypeHTTPS,
+			current:    endpoint.Targets{`1 Svc.example.com. alpn="h3,h2"`},
+			desired:    endpoint.Targets{"1  svc.example.com alpn=h3,h2"},
+		},
+		{
+			name:       "https alpn changed",
+			recordType: endpoint.RecordTypeHTTPS,
+			current:    endpoint.Targets{`1 . alpn="h3,h2"`},
+			desired:    endpoint.Targets{"1 . alpn=h2"},
+			changed:    true,
+		},
+		{
+			name:       "tlsa data case and blocks",
+			recordType: endpoint.RecordTypeTLSA,
+			current:    endpoint.Targets{"3 1 1 8CB0FC6C527506A053F4F14C8464BEBBD6DEDE2738D11468DD953D7D6A3021F1"},
+			desired:    endpoint.Targets{"3 1 1 8cb0fc6c527506a053f4f14c8464bebb d6dede2738d11468dd953d7d6a3021f1"},
+		},
+		{
+			name:       "tlsa data changed",
+			recordType: endpoint.RecordTypeTLSA,
+			current:    endpoint.Targets{"3 1 1 8cb0fc6c527506a053f4f14c8464bebbd6dede2738d11468dd953d7d6a3021f1"},
+			desired:    endpoint.Targets{"3 1 1 0000fc6c527506a053f4f14c8464bebbd6dede2738d11468dd953d7d6a3021f1"},
+			changed:    true,
+		},
+		{
+			name:       "naptr quotes, spacing and replacement case",
+			recordType: endpoint.RecordTypeNAPTR,
+			current:    endpoint.Targets{`100 10 "S" "SIP+D2U" "" _SIP._udp.example.com.`},
+			desired:    endpoint.Targets{`100  010 s SIP+D2U "" _sip._udp.example.com`},
+		},
+		{
+			name:       "naptr regexp is case sensitive",
+			recordType: endpoint.RecordTypeNAPTR,
+			current:    endpoint.Targets{`100 10 "U" "E2U+sip" "!^.*$!sip:Info@example.com!" .`},
+			desired:    endpoint.Targets{`100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+			changed:    true,
+		},
+		{
+			name:       "mx spacing",
+			recordType: endpoint.RecordTypeMX,
+			current:    endpoint.Targets{"10 mail.example.com."},
+			desired:    endpoint.Targets{" 10  mail.example.com"},
+		},
+		{
+			name:       "different targets",
+			recordType: endpoint.RecordTypeCNAME,
+			current:    endpoint.Targets{"a.example.com"},
+			desired:    endpoint.Targets{"b.example.com"},
+			changed:    true,
+		},
+		{
+			name:       "txt values are case sensitive",
+			recordType: endpoint.RecordTypeTXT,
+			current:    endpoint.Targets{"Hello"},
+			desired:    endpoint.Targets{"hello"},
+			changed:    true,
+		},
+		{
+			name:       "txt values split into character-strings",
+			recordType: endpoint.RecordTypeTXT,
+			current:    endpoint.Targets{`"v=DKIM1; " "p=MIIB"`},
+			desired:    endpoint.Targets{"v=DKIM1; p=MIIB"},
+		},
+		{
+			name:       "txt values split differently",
+			recordType: endpoint.RecordTypeTXT,
+			current:    endpoint.Targets{`"v=DKIM1; " "p=MIIB"`},
+			desired:    endpoint.Targets{`"v=DKIM1; p=" "MIIC"`},
+			changed:    true,
+		},
+	} {
+		tt.Run(test.name, func(t *testing.T) {
+			current := &endpoint.Endpoint{DNSName: "foo.example.com", RecordType: test.recordType, Targets: test.current}
+			desired := &endpoint.Endpoint{DNSName: "foo.example.com", RecordType: test.recordType, Targets: test.desired}
+			assert.Equal(t, test.changed, targetChanged(desired, current))
+		})
+	}
+}
+
+func TestWeightsChanged(tt *testing.T) {
+	targets := endpoint.Targets{"lb-a.example.com", "lb-b.example.com"}
+	for _, test := range []struct {
+		name           string
+		current        map[string]int64
+		desired        map[string]int64
+		desiredTargets endpoint.Targets
+		changed        bool
+	}{
+		{
+			name: "unweighted",
+		},
+		{
+			name:    "same weights",
+			current: map[string]int64{"lb-a.example.com": 3, "lb-b.example.com": 1},
+			desired: map[string]int64{"lb-a.example.com": 3, "lb-b.example.com": 1},
+		},
+		{
+			name:           "same weights of targets in other form",
+			current:        map[string]int64{"lb-a.example.com": 3, "lb-b.example.com": 1},
+			desired:        map[string]int64{"LB-A.example.com.": 3},
+			desiredTargets: endpoint.Targets{"LB-A.example.com.", "lb-b.example.com"},
+		},
+		{
+			name:    "different weight",
+			current: map[string]int64{"lb-a.example.com": 3, "lb-b.example.com": 1},
+			desired: map[string]int64{"lb-a.example.com": 3, "lb-b.example.com": 2},
+			changed: true,
+		},
+		{
+			name:    "weight of 1 dropped",
+			current: map[string]int64{"lb-a.example.com": 3, "lb-b.example.com": 1},
+			desired: map[string]int64{"lb-a.example.com": 3},
+		},
+		{
+			name:    "weights added",
+			desired: map[string]int64{"lb-a.example.com": 1},
+			changed: true,
+		},
+		{
+			name:    "weights removed",
+			current: map[string]int64{"lb-a.example.com": 1},
+			changed: true,
+		},
+	} {
+		tt.Run(test.name, func(t *testing.T) {
+			desiredTargets := targets
+			if test.desiredTargets != nil {
+				desiredTargets = test.desiredTargets
+			}
+			current := &endpoint.Endpoint{DNSName: "foo.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: targets, Weights: test.current}
+			desired := &endpoint.Endpoint{DNSName: "foo.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: desiredTargets, Weights: test.desired}
+			assert.Equal(t, test.changed, weightsChanged(desired, current))
+		})
+	}
+}
+
+func TestPlanWeightChanges(t *testing.T) {
+	current := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8").WithLabel(endpoint.OwnerLabelKey, "owner")
+	current.Weights = map[string]int64{"1.2.3.4": 9, "5.6.7.8": 1}
+	desired := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8")
+	desired.Weights = map[string]int64{"1.2.3.4": 5, "5.6.7.8": 5}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+	}
+	changes := p.Calculate().Changes
+
+	assert.Equal(t, []*endpoint.Endpoint{desired}, changes.UpdateNew)
+	assert.Equal(t, []*endpoint.Endpoint{current}, changes.UpdateOld)
+}
+
+func TestGeoChanged(tt *testing.T) {
+	for _, test := range []struct {
+		name    string
+		current *endpoint.GeoLocation
+		desired *endpoint.GeoLocation
+		changed bool
+	}{
+		{
+			name: "no geo",
+		},
+		{
+			name:    "same geo",
+			current: &endpoint.GeoLocation{Country: "US", Region: "CA"},
+			desired: &endpoint.GeoLocation{Country: "us", Region: "ca"},
+		},
+		{
+			name:    "different region",
+			current: &endpoint.GeoLocation{Country: "US", Region: "CA"},
+			desired: &endpoint.GeoLocation{Country: "US", Region: "NY"},
+			changed: true,
+		},
+		{
+			name:    "geo added",
+			desired: &endpoint.GeoLocation{Continent: "EU"},
+			changed: true,
+		},
+		{
+			name:    "geo removed",
+			current: &endpoint.GeoLocation{Continent: "EU"},
+			changed: true,
+		},
+	} {
+		tt.Run(test.name, func(t *testing.T) {
+			current := &endpoint.Endpoint{DNSName: "foo.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, Geo: test.current}
+			desired := &endpoint.Endpoint{DNSName: "foo.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, Geo: test.desired}
+			assert.Equal(t, test.changed, geoChanged(desired, current))
+		})
+	}
+}
+
+func TestDescriptionChanged(tt *testing.T) {
+	for _, test := range []struct {
+		name    string
+		current string
+		desired string
+		changed bool
+	}{
+		{
+			name: "no description",
+		},
+		{
+			name:    "same description",
+			current: "payments",
+			desired: "payments",
+		},
+		{
+			name:    "different description",
+			current: "payments",
+			desired: "checkout",
+			changed: true,
+		},
+		{
+			name:    "description added",
+			desired: "payments",
+			changed: true,
+		},
+		{
+			name:    "description removed",
+			current: "payments",
+		},
+	} {
+		tt.Run(test.name, func(t *testing.T) {
+			current := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4").WithDescription(test.current)
+			desired := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4").WithDescription(test.desired)
+			assert.Equal(t, test.changed, descriptionChanged(desired, current))
+		})
+	}
+}
+
+func TestPlanProtectedRecords(t *testing.T) {
+	protected := func(ep *endpoint.Endpoint) *endpoint.Endpoint {
+		return ep.WithLabel(endpoint.ProtectedLabelKey, "true")
+	}
+	newEndpoint := func(target, resource string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, target).
+			WithLabel(endpoint.OwnerLabelKey, "owner").
+			WithLabel(endpoint.ResourceLabelKey, resource)
+	}
+
+	for _, test := range []struct {
+		name              string
+		current           []*endpoint.Endpoint
+		desired           []*endpoint.Endpoint
+		expectedDelete    []*endpoint.Endpoint
+		expectedUpdateOld []*endpoint.Endpoint
+		expectedUpdateNew []*endpoint.Endpoint
+	}{
+		{
+			name:    "protected record is not deleted",
+			current: []*endpoint.Endpoint{protected(newEndpoint("1.1.1.1", "ingress/default/foo"))},
+		},
+		{
+			name:           "unprotected record is deleted",
+			current:        []*endpoint.Endpoint{newEndpoint("1.1.1.1", "ingress/default/foo")},
+			expectedDelete: []*endpoint.Endpoint{newEndpoint("1.1.1.1", "ingress/default/foo")},
+		},
+		{
+			name:    "protected record is not taken over by another resource",
+			current: []*endpoint.Endpoint{protected(newEndpoint("1.1.1.1", "ingress/default/foo"))},
+			desired: []*endpoint.Endpoint{newEndpoint("2.2.2.2", "ingress/default/bar")},
+		},
+		{
+			name:              "protected record is updated by its own resource",
+			current:           []*endpoint.Endpoint{protected(newEndpoint("1.1.1.1", "ingress/default/foo"))},
+			desired:           []*endpoint.Endpoint{protected(newEndpoint("2.2.2.2", "ingress/default/foo"))},
+			expectedUpdateOld: []*endpoint.Endpoint{protected(newEndpoint("1.1.1.1", "ingress/default/foo"))},
+			expectedUpdateNew: []*endpoint.Endpoint{protected(newEndpoint("2.2.2.2", "ingress/default/foo"))},
+		},
+		{
+			name:              "protection is added to an existing record",
+			current:           []*endpoint.Endpoint{newEndpoint("1.1.1.1", "ingress/default/foo")},
+			desired:           []*endpoint.Endpoint{protected(newEndpoint("1.1.1.1", "ingress/default/foo"))},
+			expectedUpdateOld: []*endpoint.Endpoint{newEndpoint("1.1.1.1", "ingress/default/foo")},
+			expectedUpdateNew: []*endpoint.Endpoint{protected(newEndpoint("1.1.1.1", "ingress/default/foo"))},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        test.current,
+				Desired:        test.desired,
+				ManagedRecords: []string{endpoint.RecordTypeA},
+				OwnerID:        "owner",
+			}
+			changes := p.Calculate().Changes
+			validateEntries(t, changes.Create, []*endpoint.Endpoint{})
+			validateEntries(t, changes.Delete, test.expectedDelete)
+			validateEntries(t, changes.UpdateOld, test.expectedUpdateOld)
+			validateEntries(t, changes.UpdateNew, test.expectedUpdateNew)
+		})
+	}
+}
+
+func TestPlanSourceGroup(t *testing.T) {
+	inGroup := func(ep *endpoint.Endpoint, group string) *endpoint.Endpoint {
+		return ep.WithLabel(endpoint.SourceGroupLabelKey, group)
+	}
+	newEndpoint := func(target string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, target).
+			WithLabel(endpoint.OwnerLabelKey, "owner")
+	}
+
+	for _, test := range []struct {
+		name              string
+		current           []*endpoint.Endpoint
+		desired           []*endpoint.Endpoint
+		expectedCreate    []*endpoint.Endpoint
+		expectedDelete    []*endpoint.Endpoint
+		expectedUpdateOld []*endpoint.Endpoint
+		expectedUpdateNew []*endpoint.Endpoint
+	}{
+		{
+			name:           "desired record is created with the group",
+			desired:        []*endpoint.Endpoint{newEndpoint("1.1.1.1")},
+			expectedCreate: []*endpoint.Endpoint{inGroup(newEndpoint("1.1.1.1"), "ingresses")},
+		},
+		{
+			name:           "record of the group is deleted",
+			current:        []*endpoint.Endpoint{inGroup(newEndpoint("1.1.1.1"), "ingresses")},
+			expectedDelete: []*endpoint.Endpoint{inGroup(newEndpoint("1.1.1.1"), "ingresses")},
+		},
+		{
+			name:    "record of another group is not deleted",
+			current: []*endpoint.Endpoint{inGroup(newEndpoint("1.1.1.1"), "nodes")},
+		},
+		{
+			name:    "record without group is not deleted",
+			current: []*endpoint.Endpoint{newEndpoint("1.1.1.1")},
+		},
+		{
+			name:    "record of another group is not updated",
+			current: []*endpoint.Endpoint{inGroup(newEndpoint("1.1.1.1"), "nodes")},
+			desired: []*endpoint.Endpoint{newEndpoint("2.2.2.2")},
+		},
+		{
+			name:              "record without group is adopted",
+			current:           []*endpoint.Endpoint{newEndpoint("1.1.1.1")},
+			desired:           []*endpoint.Endpoint{newEndpoint("1.1.1.1")},
+			expectedUpdateOld: []*endpoint.Endpoint{newEndpoint("1.1.1.1")},
+			expectedUpdateNew: []*endpoint.Endpoint{inGroup(newEndpoint("1.1.1.1"), "ingresses")},
+		},
+		{
+			name:    "record of the group is up to date",
+			current: []*endpoint.Endpoint{inGroup(newEndpoint("1.1.1.1"), "ingresses")},
+			desired: []*endpoint.Endpoint{newEndpoint("1.1.1.1")},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        test.current,
+				Desired:        test.desired,
+				ManagedRecords: []string{endpoint.RecordTypeA},
+				OwnerID:        "owner",
+				SourceGroup:    "ingresses",
+			}
+			changes := p.Calculate().Changes
+			validateEntries(t, changes.Create, test.expectedCreate)
+			validateEntries(t, changes.Delete, test.expectedDelete)
+			validateEntries(t, changes.UpdateOld, test.expectedUpdateOld)
+			validateEntries(t, changes.UpdateNew, test.expectedUpdateNew)
+			for _, ep := range append(changes.Create, changes.UpdateNew...) {
+				assert.Equal(t, "ingresses", ep.Labels[endpoint.SourceGroupLabelKey])
+			}
+		})
+	}
+}
+
+func TestPlanResourceLabels(t *testing.T) {
+	newEndpoint := func(labels map[string]string) *endpoint.Endpoint {
+		ep := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1").
+			WithLabel(endpoint.OwnerLabelKey, "owner")
+		for key, value := range labels {
+			ep.WithLabel(key, value)
+		}
+		return ep
+	}
+
+	for _, test := range []struct {
+		name    string
+		current map[string]string
+		desired map[string]string
+		update  bool
+	}{
+		{
+			name:    "unchanged labels",
+			current: map[string]string{"label/team": "payments"},
+			desired: map[string]string{"label/team": "payments"},
+		},
+		{
+			name:    "label added",
+			desired: map[string]string{"label/team": "payments"},
+			update:  true,
+		},
+		{
+			name:    "label changed",
+			current: map[string]string{"label/team": "payments"},
+			desired: map[string]string{"label/team": "checkout"},
+			update:  true,
+		},
+		{
+			name:    "label removed",
+			current: map[string]string{"label/team": "payments", "label/environment": "production"},
+			desired: map[string]string{"label/team": "payments"},
+			update:  true,
+		},
+		{
+			name:    "other labels are ignored",
+			current: map[string]string{endpoint.ResourceCreatedLabelKey: "1700000000"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        []*endpoint.Endpoint{newEndpoint(test.current)},
+				Desired:        []*endpoint.Endpoint{newEndpoint(test.desired)},
+				ManagedRecords: []string{endpoint.RecordTypeA},
+				OwnerID:        "owner",
+			}
+			changes := p.Calculate().Changes
+			if !test.update {
+				assert.Empty(t, changes.UpdateNew)
+				return
+			}
+			require.Len(t, changes.UpdateNew, 1)
+			for key, value := range test.desired {
+				assert.Equal(t, value, changes.UpdateNew[0].Labels[key])
+			}
+		})
+	}
+}
+
+func TestIsManagedRecord(t *testing.T) {
+	managed := []string{endpoint.RecordTypeA, endpoint.RecordTypeNS}
+	assert.True(t, IsManagedRecord(endpoint.RecordTypeA, managed, nil))
+	assert.True(t, IsManagedRecord("a", managed, nil))
+	assert.False(t, IsManagedRecord(endpoint.RecordTypeCNAME, managed, nil))
+	assert.False(t, IsManagedRecord(endpoint.RecordTypeNS, managed, []string{"ns"}))
+	assert.False(t, IsManagedRecord(endpoint.RecordTypeNS, managed, []string{endpoint.RecordTypeNS}))
+}
+
+func TestChangesExcludeRecordTypes(t *testing.T) {
+	a := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	ns := endpoint.NewEndpoint("sub.example.com", endpoint.RecordTypeNS, "ns1.example.net")
+	nsNew := endpoint.NewEndpoint("sub.example.com", endpoint.RecordTypeNS, "ns2.example.net")
+	soa := endpoint.NewEndpoint("example.com", "SOA", "ns1.example.net. hostmaster.example.com. 1 7200 900 1209600 86400")
+
+	changes := &Changes{
+		Create:    []*endpoint.Endpoint{a, ns},
+		UpdateOld: []*endpoint.Endpoint{ns, a},
+		UpdateNew: []*endpoint.Endpoint{nsNew, a},
+		Delete:    []*endpoint.Endpoint{soa, a},
+	}
+	assert.Same(t, changes, changes.ExcludeRecordTypes(nil))
+
+	filtered := changes.ExcludeRecordTypes([]string{endpoint.RecordTypeNS, "soa"})
+	assert.Equal(t, []*endpoint.Endpoint{a}, filtered.Create)
+	assert.Equal(t, []*endpoint.Endpoint{a}, filtered.UpdateOld)
+	assert.Equal(t, []*endpoint.Endpoint{a}, filtered.UpdateNew)
+	assert.Equal(t, []*endpoint.Endpoint{a}, filtered.Delete)
+}
+
+func TestCalculateDualStackFromSameResource(t *testing.T) {
+	resourceA := func(ep *endpoint.Endpoint) *endpoint.Endpoint {
+		return ep.WithLabel(endpoint.ResourceLabelKey, "service/default/a")
+	}
+	resourceB := func(ep *endpoint.Endpoint) *endpoint.Endpoint {
+		return ep.WithLabel(endpoint.ResourceLabelKey, "service/default/b")
+	}
+	// resource a wins the A record, whereas resource b would win the AAAA record on its own
+	aV4 := resourceA(endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1"))
+	aV6 := resourceA(endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeAAAA, "2001:db8::2"))
+	bV4 := resourceB(endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "2.2.2.2"))
+	bV6 := resourceB(endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"))
+	managed := []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA}
+
+	for range 10 {
+		p := &Plan{
+			Policies:       []Policy{&SyncPolicy{}},
+			Desired:        []*endpoint.Endpoint{bV6, aV6, bV4, aV4},
+			ManagedRecords: managed,
+		}
+		assert.ElementsMatch(t, []*endpoint.Endpoint{aV4, aV6}, p.Calculate().Changes.Create)
+	}
+
+	// the A record owned by resource b keeps the AAAA record created next to it on resource b
+	current := resourceB(endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "2.2.2.2"))
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{aV6, bV6, aV4, bV4},
+		ManagedRecords: managed,
+	}
+	changes := p.Calculate().Changes
+	assert.Equal(t, []*endpoint.Endpoint{bV6}, changes.Create)
+	assert.Empty(t, changes.UpdateNew)
+
+	// merged targets come from all the resources already
+	p = &Plan{
+		Policies:         []Policy{&SyncPolicy{}},
+		Desired:          []*endpoint.Endpoint{aV4, aV6, bV4, bV6},
+		ManagedRecords:   managed,
+		ConflictResolver: MergeTargets{},
+	}
+	for _, ep := range p.Calculate().Changes.Create {
+		assert.Len(t, ep.Targets, 2)
+	}
+}
+
+// BenchmarkCalculate plans a large estate: a quarter of the records is up to date, a quarter is updated, a
+// quarter is owned by another instance and a quarter is no longer desired.
+func BenchmarkCalculate(b *testing.B) {
+	const records = 100000
+	current := make([]*endpoint.Endpoint, 0, records)
+	desired := make([]*endpoint.Endpoint, 0, records)
+	for i := range records {
+		name := fmt.Sprintf("record-%d.example.com", i)
+		owner := "owner"
+		if i%4 == 2 {
+			owner = "other"
+		}
+		current = append(current, endpoint.NewEndpoint(name, endpoint.RecordTypeA, fmt.Sprintf("10.%d.%d.%d", i>>16&255, i>>8&255, i&255)).
+			WithLabel(endpoint.OwnerLabelKey, owner))
+		switch i % 4 {
+		case 0:
+			desired = append(desired, endpoint.NewEndpoint(name, endpoint.RecordTypeA, fmt.Sprintf("10.%d.%d.%d", i>>16&255, i>>8&255, i&255)))
+		case 1, 2:
+			desired = append(desired, endpoint.NewEndpoint(name, endpoint.RecordTypeA, "192.0.2.1"))
+		}
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		p := &Plan{
+			Policies:       []Policy{&SyncPolicy{}},
+			Current:        current,
+			Desired:        desired,
+			ManagedRecords: []string{endpoint.RecordTypeA},
+			OwnerID:        "owner",
+		}
+		p.Calculate()
+	}
+}
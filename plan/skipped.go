@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Actions of the changes left out of a plan.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Skipped is an endpoint left out of the changes of a plan, along with the reason why.
+type Skipped struct {
+	Endpoint *endpoint.Endpoint `json:"endpoint"`
+	Reason   string             `json:"reason"`
+	// Action is the change which was dropped, empty for desired endpoints skipped before calculating the changes
+	Action string `json:"action,omitempty"`
+	// Message details the reason, such as the policy rule which denied the change
+	Message string `json:"message,omitempty"`
+}
+
+// Annotation is a message which a policy rule attached to a change of a plan, without leaving it out.
+type Annotation struct {
+	Endpoint *endpoint.Endpoint `json:"endpoint"`
+	Action   string             `json:"action"`
+	Message  string             `json:"message"`
+}
+
+// skipLog collects the endpoints left out of a plan.
+type skipLog []Skipped
+
+// source records desired endpoints skipped for the given reason and counts them in the metrics.
+func (s *skipLog) source(reason string, endpoints ...*endpoint.Endpoint) {
+	CountSkipped(reason, endpoints...)
+	for _, ep := range endpoints {
+		*s = append(*s, Skipped{Endpoint: ep, Reason: reason})
+	}
+}
+
+// ownershipConflict records desired endpoints skipped because their DNS name is owned by another owner ID, given
+// by the owner function, and counts them by zone and owner. The action is empty for endpoints skipped before
+// calculating the changes.
+func (s *skipLog) ownershipConflict(zones []string, ownerID, action string, owner func(*endpoint.Endpoint) string, endpoints ...*endpoint.Endpoint) {
+	for _, ep := range endpoints {
+		other := owner(ep)
+		*s = append(*s, Skipped{Endpoint: ep, Reason: SkipReasonOwner, Action: action, Message: fmt.Sprintf("owned by %q instead of %q", other, ownerID)})
+		ownershipConflictsTotal.CounterVec.WithLabelValues(findZone(zones, ep.DNSName), other).Inc()
+	}
+}
+
+// changes records the changes dropped from before to after for the given reason.
+func (s *skipLog) changes(reason string, before, after *Changes) {
+	for _, ep := range removedEndpoints(before.Create, after.Create) {
+		*s = append(*s, Skipped{Endpoint: ep, Reason: reason, Action: ActionCreate})
+	}
+	for _, ep := range removedEndpoints(before.UpdateNew, after.UpdateNew) {
+		*s = append(*s, Skipped{Endpoint: ep, Reason: reason, Action: ActionUpdate})
+	}
+	for _, ep := range removedEndpoints(before.Delete, after.Delete) {
+		*s = append(*s, Skipped{Endpoint: ep, Reason: reason, Action: ActionDelete})
+	}
+}
+
+// smallEndpointSet is the number of endpoints up to which a linear search is cheaper than indexing them.
+const smallEndpointSet = 16
+
+// removedEndpoints returns the endpoints of before which are missing from after, a subset of before. The
+// endpoints of after are indexed, unless there are only a few of them as for the candidates of a row, so that
+// filtering the changes of large estates stays linear.
+func removedEndpoints(before, after []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if len(after) == len(before) {
+		return nil
+	}
+	var removed []*endpoint.Endpoint
+	if len(after) <= smallEndpointSet {
+		for _, ep := range before {
+			if !slices.Contains(after, ep) {
+				removed = append(removed, ep)
+			}
+		}
+		return removed
+	}
+	kept := make(map[*endpoint.Endpoint]struct{}, len(after))
+	for _, ep := range after {
+		kept[ep] = struct{}{}
+	}
+	for _, ep := range before {
+		if _, ok := kept[ep]; !ok {
+			removed = append(removed, ep)
+		}
+	}
+	return removed
+}
+
+// discardedCandidates returns the candidates of the rows which the resolved rows no longer hold.
+func discardedCandidates(rows, resolved map[string]*domainEndpoints) []*endpoint.Endpoint {
+	var discarded []*endpoint.Endpoint
+	for recordType, records := range rows {
+		if r, ok := resolved[recordType]; ok {
+			discarded = append(discarded, removedEndpoints(records.candidates, r.candidates)...)
+		} else {
+			discarded = append(discarded, records.candidates...)
+		}
+	}
+	return discarded
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestCalculateSkipped(t *testing.T) {
+	filtered := endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.1.1.1")
+	a := endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "service/default/a")
+	b := endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/default/b")
+	cname := endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeCNAME, "lb.example.com")
+	foreign := endpoint.NewEndpoint("foreign.example.com", endpoint.RecordTypeA, "5.5.5.5").WithLabel(endpoint.OwnerLabelKey, "other")
+	owned := endpoint.NewEndpoint("owned.example.com", endpoint.RecordTypeA, "6.6.6.6").WithLabel(endpoint.OwnerLabelKey, "owner")
+
+	p := &Plan{
+		Policies:       []Policy{&UpsertOnlyPolicy{}},
+		Current:        []*endpoint.Endpoint{foreign, owned},
+		Desired:        []*endpoint.Endpoint{filtered, a, b, cname},
+		DomainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.com"})},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		OwnerID:        "owner",
+	}
+	calculated := p.Calculate()
+
+	assert.Equal(t, []*endpoint.Endpoint{a}, calculated.Changes.Create)
+	assert.Empty(t, calculated.Changes.Delete)
+	assert.ElementsMatch(t, []Skipped{
+		{Endpoint: filtered, Reason: SkipReasonDomainFilter},
+		{Endpoint: cname, Reason: SkipReasonConflict},
+		{Endpoint: b, Reason: SkipReasonConflict},
+		{Endpoint: foreign, Reason: SkipReasonPolicy, Action: ActionDelete},
+		{Endpoint: owned, Reason: SkipReasonPolicy, Action: ActionDelete},
+	}, calculated.Skipped)
+}
+
+func TestCalculateSkippedByOwner(t *testing.T) {
+	foreign := endpoint.NewEndpoint("foreign.example.com", endpoint.RecordTypeA, "5.5.5.5").WithLabel(endpoint.OwnerLabelKey, "other")
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{foreign},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+	}
+	calculated := p.Calculate()
+
+	assert.Empty(t, calculated.Changes.Delete)
+	assert.Equal(t, []Skipped{{Endpoint: foreign, Reason: SkipReasonOwner, Action: ActionDelete}}, calculated.Skipped)
+}
+
+func TestCalculateSkippedZoneApexNS(t *testing.T) {
+	apexCurrent := endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.provider.net", "ns2.provider.net")
+	apexDesired := endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.example.net")
+	delegation := endpoint.NewEndpoint("team.example.com", endpoint.RecordTypeNS, "ns1.team.net", "ns2.team.net")
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{apexCurrent},
+		Desired:        []*endpoint.Endpoint{apexDesired, delegation},
+		DomainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.com"})},
+		ManagedRecords: []string{endpoint.RecordTypeNS},
+		ZoneApexes:     []string{"example.com"},
+	}
+	calculated := p.Calculate()
+
+	assert.Equal(t, []*endpoint.Endpoint{delegation}, calculated.Changes.Create)
+	assert.Empty(t, calculated.Changes.UpdateNew)
+	assert.Empty(t, calculated.Changes.Delete)
+	assert.Equal(t, []Skipped{{Endpoint: apexDesired, Reason: SkipReasonZoneApex}}, calculated.Skipped)
+}
+
+func TestCalculateSkippedWildcard(t *testing.T) {
+	wildcard := endpoint.NewEndpoint("*.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	plain := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "2.2.2.2")
+
+	for _, tt := range []struct {
+		policy  string
+		create  []*endpoint.Endpoint
+		skipped []Skipped
+	}{
+		{policy: "", create: []*endpoint.Endpoint{wildcard, plain}},
+		{policy: WildcardPolicyAllow, create: []*endpoint.Endpoint{wildcard, plain}},
+		{policy: WildcardPolicyDeny, create: []*endpoint.Endpoint{plain}, skipped: []Skipped{{Endpoint: wildcard, Reason: SkipReasonWildcard}}},
+		{policy: WildcardPolicyReplace, create: []*endpoint.Endpoint{endpoint.NewEndpoint("any.example.com", endpoint.RecordTypeA, "1.1.1.1"), plain}},
+	} {
+		t.Run(tt.policy, func(t *testing.T) {
+			p := &Plan{
+				Policies:            []Policy{&SyncPolicy{}},
+				Desired:             []*endpoint.Endpoint{wildcard, plain},
+				ManagedRecords:      []string{endpoint.RecordTypeA},
+				WildcardPolicy:      tt.policy,
+				WildcardPlaceholder: "any",
+			}
+			calculated := p.Calculate()
+
+			assert.ElementsMatch(t, tt.create, calculated.Changes.Create)
+			assert.Equal(t, tt.skipped, calculated.Skipped)
+			assert.Equal(t, "*.example.com", wildcard.DNSName)
+		})
+	}
+}
+
+func TestRemovedEndpoints(t *testing.T) {
+	for _, size := range []int{4, 100} {
+		var before, after, removed []*endpoint.Endpoint
+		for i := range size {
+			ep := endpoint.NewEndpoint(fmt.Sprintf("record-%d.example.com", i), endpoint.RecordTypeA, "1.1.1.1")
+			before = append(before, ep)
+			if i%3 == 0 {
+				removed = append(removed, ep)
+			} else {
+				after = append(after, ep)
+			}
+		}
+		assert.Equal(t, removed, removedEndpoints(before, after))
+		assert.Empty(t, removedEndpoints(before, before))
+		assert.Equal(t, before, removedEndpoints(before, nil))
+	}
+}
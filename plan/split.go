@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// changeUnit holds changes which must be applied as a whole: a creation, a deletion, or an update
+// along with the record it replaces, together with the changes of the other address family of the DNS name.
+type changeUnit struct {
+	create, updateOld, updateNew, delete []*endpoint.Endpoint
+}
+
+// Split divides the changes into two halves which can be applied independently, so that a change
+// failing to apply can be isolated by splitting repeatedly. Updates stay paired with the record they
+// replace, and the A and AAAA changes of a DNS name stay together, so that a dual-stack name never ends
+// up with a single address family. Changes which can't be divided any further are returned as they are.
+func (c *Changes) Split() []*Changes {
+	if len(c.UpdateOld) != len(c.UpdateNew) {
+		return []*Changes{c}
+	}
+	units := make([]*changeUnit, 0, len(c.Create)+len(c.UpdateNew)+len(c.Delete))
+	families := map[planKey]*changeUnit{}
+	unitOf := func(ep *endpoint.Endpoint) *changeUnit {
+		if ep.RecordType != endpoint.RecordTypeA && ep.RecordType != endpoint.RecordTypeAAAA {
+			units = append(units, &changeUnit{})
+			return units[len(units)-1]
+		}
+		key := planKey{dnsName: normalizeDNSName(ep.DNSName), setIdentifier: ep.SetIdentifier}
+		if _, ok := families[key]; !ok {
+			families[key] = &changeUnit{}
+			units = append(units, families[key])
+		}
+		return families[key]
+	}
+	for _, ep := range c.Create {
+		u := unitOf(ep)
+		u.create = append(u.create, ep)
+	}
+	for i := range c.UpdateOld {
+		u := unitOf(c.UpdateNew[i])
+		u.updateOld = append(u.updateOld, c.UpdateOld[i])
+		u.updateNew = append(u.updateNew, c.UpdateNew[i])
+	}
+	for _, ep := range c.Delete {
+		u := unitOf(ep)
+		u.delete = append(u.delete, ep)
+	}
+	if len(units) < 2 {
+		return []*Changes{c}
+	}
+
+	half := len(units) / 2
+	return []*Changes{fromUnits(units[:half]), fromUnits(units[half:])}
+}
+
+func fromUnits(units []*changeUnit) *Changes {
+	changes := &Changes{}
+	for _, u := range units {
+		changes.Create = append(changes.Create, u.create...)
+		changes.UpdateOld = append(changes.UpdateOld, u.updateOld...)
+		changes.UpdateNew = append(changes.UpdateNew, u.updateNew...)
+		changes.Delete = append(changes.Delete, u.delete...)
+	}
+	return changes
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestChangesSplit(t *testing.T) {
+	create1 := endpoint.NewEndpoint("create1.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	create2 := endpoint.NewEndpoint("create2.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	old := endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	updated := endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "2.2.2.2")
+	deleted := endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "1.1.1.1")
+
+	changes := &Changes{
+		Create:    []*endpoint.Endpoint{create1, create2},
+		UpdateOld: []*endpoint.Endpoint{old},
+		UpdateNew: []*endpoint.Endpoint{updated},
+		Delete:    []*endpoint.Endpoint{deleted},
+	}
+	assert.Equal(t, []*Changes{
+		{Create: []*endpoint.Endpoint{create1, create2}},
+		{UpdateOld: []*endpoint.Endpoint{old}, UpdateNew: []*endpoint.Endpoint{updated}, Delete: []*endpoint.Endpoint{deleted}},
+	}, changes.Split())
+
+	single := &Changes{UpdateOld: []*endpoint.Endpoint{old}, UpdateNew: []*endpoint.Endpoint{updated}}
+	assert.Equal(t, []*Changes{single}, single.Split())
+
+	unpaired := &Changes{UpdateOld: []*endpoint.Endpoint{old}, Create: []*endpoint.Endpoint{create1}}
+	assert.Equal(t, []*Changes{unpaired}, unpaired.Split())
+}
+
+func TestChangesSplitKeepsAddressFamiliesTogether(t *testing.T) {
+	v4 := endpoint.NewEndpoint("dual.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	v6 := endpoint.NewEndpoint("dual.example.com", endpoint.RecordTypeAAAA, "2001:db8::1")
+	cname := endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeCNAME, "dual.example.com")
+	oldV4 := endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	newV4 := endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeA, "2.2.2.2")
+	deletedV6 := endpoint.NewEndpoint("updated.example.com", endpoint.RecordTypeAAAA, "2001:db8::2")
+
+	changes := &Changes{
+		Create:    []*endpoint.Endpoint{v4, cname, v6},
+		UpdateOld: []*endpoint.Endpoint{oldV4},
+		UpdateNew: []*endpoint.Endpoint{newV4},
+		Delete:    []*endpoint.Endpoint{deletedV6},
+	}
+	assert.Equal(t, []*Changes{
+		{Create: []*endpoint.Endpoint{v4, v6}},
+		{Create: []*endpoint.Endpoint{cname}, UpdateOld: []*endpoint.Endpoint{oldV4}, UpdateNew: []*endpoint.Endpoint{newV4}, Delete: []*endpoint.Endpoint{deletedV6}},
+	}, changes.Split())
+
+	pair := &Changes{Create: []*endpoint.Endpoint{v4, v6}}
+	assert.Equal(t, []*Changes{pair}, pair.Split())
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ZoneChanges holds the changes belonging to a single zone.
+type ZoneChanges struct {
+	// Zone is the name of the zone, or empty for changes not matching any known zone
+	Zone    string
+	Changes *Changes
+}
+
+// SplitByZone groups the changes by the longest zone their DNS name belongs to, so that each group can be
+// applied independently. Changes which don't belong to any of the given zones are grouped under the empty
+// zone name. Groups are sorted by zone name and preserve the order of the changes, which keeps UpdateOld and
+// UpdateNew paired.
+func (c *Changes) SplitByZone(zones []string) []ZoneChanges {
+	normalized := normalizeZones(zones)
+
+	byZone := map[string]*Changes{}
+	group := func(ep *endpoint.Endpoint) *Changes {
+		zone := findZone(normalized, ep.DNSName)
+		if _, ok := byZone[zone]; !ok {
+			byZone[zone] = &Changes{}
+		}
+		return byZone[zone]
+	}
+	for _, ep := range c.Create {
+		g := group(ep)
+		g.Create = append(g.Create, ep)
+	}
+	for _, ep := range c.UpdateOld {
+		g := group(ep)
+		g.UpdateOld = append(g.UpdateOld, ep)
+	}
+	for _, ep := range c.UpdateNew {
+		g := group(ep)
+		g.UpdateNew = append(g.UpdateNew, ep)
+	}
+	for _, ep := range c.Delete {
+		g := group(ep)
+		g.Delete = append(g.Delete, ep)
+	}
+
+	result := make([]ZoneChanges, 0, len(byZone))
+	for zone, changes := range byZone {
+		result = append(result, ZoneChanges{Zone: zone, Changes: changes})
+	}
+	slices.SortFunc(result, func(a, b ZoneChanges) int {
+		return strings.Compare(a.Zone, b.Zone)
+	})
+	return result
+}
+
+// normalizeZones returns the distinct zone names, lower-cased and without leading or trailing dots.
+func normalizeZones(zones []string) []string {
+	normalized := make([]string, 0, len(zones))
+	for _, z := range zones {
+		z = strings.ToLower(strings.Trim(z, "."))
+		if z != "" && !slices.Contains(normalized, z) {
+			normalized = append(normalized, z)
+		}
+	}
+	return normalized
+}
+
+// findZone returns the longest of the zones the given DNS name belongs to.
+func findZone(zones []string, dnsName string) string {
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	var found string
+	for _, z := range zones {
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(found) {
+			found = z
+		}
+	}
+	return found
+}
+
+// LimitPerZone returns the changes keeping at most limit changes of each zone, an update counting once, and the
+// number of changes left out by zone. Creations are kept first, then updates and deletions last.
+func (c *Changes) LimitPerZone(zones []string, limit int) (*Changes, map[string]int) {
+	limited := &Changes{}
+	deferred := map[string]int{}
+	for _, zc := range c.SplitByZone(zones) {
+		budget := limit
+		take := func(n int) int {
+			kept := min(n, budget)
+			budget -= kept
+			deferred[zc.Zone] += n - kept
+			return kept
+		}
+		n := take(len(zc.Changes.Create))
+		limited.Create = append(limited.Create, zc.Changes.Create[:n]...)
+		n = take(len(zc.Changes.UpdateNew))
+		limited.UpdateOld = append(limited.UpdateOld, zc.Changes.UpdateOld[:min(n, len(zc.Changes.UpdateOld))]...)
+		limited.UpdateNew = append(limited.UpdateNew, zc.Changes.UpdateNew[:n]...)
+		n = take(len(zc.Changes.Delete))
+		limited.Delete = append(limited.Delete, zc.Changes.Delete[:n]...)
+		if deferred[zc.Zone] == 0 {
+			delete(deferred, zc.Zone)
+		}
+	}
+	return limited, deferred
+}
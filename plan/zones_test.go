@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestSplitByZone(t *testing.T) {
+	fooExample := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	fooExampleV2 := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "2.2.2.2")
+	barSubExample := endpoint.NewEndpoint("bar.sub.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	apexExample := endpoint.NewEndpoint("Example.com", endpoint.RecordTypeA, "1.1.1.1")
+	bazOrg := endpoint.NewEndpoint("baz.example.org", endpoint.RecordTypeA, "1.1.1.1")
+	other := endpoint.NewEndpoint("other.net", endpoint.RecordTypeA, "1.1.1.1")
+
+	changes := &Changes{
+		Create:    []*endpoint.Endpoint{barSubExample, apexExample, other},
+		UpdateOld: []*endpoint.Endpoint{fooExample},
+		UpdateNew: []*endpoint.Endpoint{fooExampleV2},
+		Delete:    []*endpoint.Endpoint{bazOrg},
+	}
+
+	assert.Equal(t, []ZoneChanges{
+		{Zone: "", Changes: &Changes{Create: []*endpoint.Endpoint{other}}},
+		{Zone: "example.com", Changes: &Changes{
+			Create:    []*endpoint.Endpoint{apexExample},
+			UpdateOld: []*endpoint.Endpoint{fooExample},
+			UpdateNew: []*endpoint.Endpoint{fooExampleV2},
+		}},
+		{Zone: "example.org", Changes: &Changes{Delete: []*endpoint.Endpoint{bazOrg}}},
+		{Zone: "sub.example.com", Changes: &Changes{Create: []*endpoint.Endpoint{barSubExample}}},
+	}, changes.SplitByZone([]string{"example.com", ".example.com", "sub.example.com.", "example.org"}))
+
+	assert.Equal(t, []ZoneChanges{{Zone: "", Changes: changes}}, changes.SplitByZone(nil))
+	assert.Empty(t, (&Changes{}).SplitByZone([]string{"example.com"}))
+}
+
+func TestLimitPerZone(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	b := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	cOld := endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	cNew := endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "2.2.2.2")
+	d := endpoint.NewEndpoint("d.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	org := endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.1.1.1")
+
+	changes := &Changes{
+		Create:    []*endpoint.Endpoint{a, org, b},
+		UpdateOld: []*endpoint.Endpoint{cOld},
+		UpdateNew: []*endpoint.Endpoint{cNew},
+		Delete:    []*endpoint.Endpoint{d},
+	}
+	zones := []string{"example.com", "example.org"}
+
+	limited, deferred := changes.LimitPerZone(zones, 3)
+	assert.Equal(t, &Changes{
+		Create:    []*endpoint.Endpoint{a, b, org},
+		UpdateOld: []*endpoint.Endpoint{cOld},
+		UpdateNew: []*endpoint.Endpoint{cNew},
+	}, limited)
+	assert.Equal(t, map[string]int{"example.com": 1}, deferred)
+
+	limited, deferred = changes.LimitPerZone(zones, 1)
+	assert.Equal(t, &Changes{Create: []*endpoint.Endpoint{a, org}}, limited)
+	assert.Equal(t, map[string]int{"example.com": 3}, deferred)
+
+	limited, deferred = changes.LimitPerZone(zones, 10)
+	assert.ElementsMatch(t, changes.Create, limited.Create)
+	assert.Equal(t, changes.Delete, limited.Delete)
+	assert.Empty(t, deferred)
+}
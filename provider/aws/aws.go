@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"sort"
@@ -47,6 +48,9 @@ const (
 	// As we are using the standard AWS client, this should already be compliant.
 	// Hence, if AWS ever decides to raise this limit, we will automatically reduce the pressure on rate limits
 	route53PageSize int32 = 300
+	// route53MaxValues is the maximum number of values of a record set, larger ones are split into several
+	// weighted record sets.
+	route53MaxValues = 400
 	// providerSpecificAlias specifies whether a CNAME endpoint maps to an AWS ALIAS record.
 	providerSpecificAlias            = "alias"
 	providerSpecificTargetHostedZone = "aws/target-hosted-zone"
@@ -310,6 +314,8 @@ type AWSProvider struct {
 	zoneMatchParent bool
 	preferCNAME     bool
 	zonesCache      *zonesListCache
+	// number of hosted zones whose records are listed at once
+	zoneListConcurrency int
 	// queue for collecting changes to submit them in the next iteration, but after all other changes
 	failedChangesQueue map[string]Route53Changes
 }
@@ -329,6 +335,7 @@ type AWSConfig struct {
 	PreferCNAME           bool
 	DryRun                bool
 	ZoneCacheDuration     time.Duration
+	ZoneListConcurrency   int
 }
 
 // NewAWSProvider initializes a new AWS Route53 based Provider.
@@ -348,6 +355,7 @@ func NewAWSProvider(awsConfig AWSConfig, clients map[string]Route53API) (*AWSPro
 		preferCNAME:           awsConfig.PreferCNAME,
 		dryRun:                awsConfig.DryRun,
 		zonesCache:            &zonesListCache{duration: awsConfig.ZoneCacheDuration},
+		zoneListConcurrency:   awsConfig.ZoneListConcurrency,
 		failedChangesQueue:    make(map[string]Route53Changes),
 	}
 
@@ -368,6 +376,28 @@ func (p *AWSProvider) Zones(ctx context.Context) (map[string]*route53types.Hoste
 	return result, nil
 }
 
+// ListZones returns the hosted zones as provider zones.
+func (p *AWSProvider) ListZones(ctx context.Context) ([]provider.Zone, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Zone, 0, len(zones))
+	for _, zone := range zones {
+		visibility := "public"
+		if zone.Config != nil && zone.Config.PrivateZone {
+			visibility = "private"
+		}
+		result = append(result, provider.Zone{
+			ID:         cleanZoneID(*zone.Id),
+			Name:       strings.TrimSuffix(*zone.Name, "."),
+			Visibility: visibility,
+			Records:    zone.ResourceRecordSetCount,
+		})
+	}
+	return result, nil
+}
+
 // zones returns the list of zones per AWS profile
 func (p *AWSProvider) zones(ctx context.Context) (map[string]*profiledZone, error) {
 	if p.zonesCache.zones != nil && time.Since(p.zonesCache.age) < p.zonesCache.duration {
@@ -484,102 +514,152 @@ func (p *AWSProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error)
 }
 
 func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZone) ([]*endpoint.Endpoint, error) {
-	endpoints := make([]*endpoint.Endpoint, 0)
+	endpoints, err := provider.ZoneRecords(ctx, slices.Collect(maps.Values(zones)), p.zoneListConcurrency, p.zoneRecords)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, z := range zones {
-		client := p.clients[z.profile]
+	return joinOversizedEndpoints(endpoints), nil
+}
 
-		paginator := route53.NewListResourceRecordSetsPaginator(client, &route53.ListResourceRecordSetsInput{
-			HostedZoneId: z.zone.Id,
-			MaxItems:     aws.Int32(route53PageSize),
-		})
+// StreamRecords calls fn with the records of each hosted zone in turn, page after page. The chunks of record sets
+// split because of their size are held until the end of their zone, and joined before being passed on.
+func (p *AWSProvider) StreamRecords(ctx context.Context, fn func(*endpoint.Endpoint) error) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return provider.NewSoftErrorf("records retrieval failed: %v", err)
+	}
 
-		for paginator.HasMorePages() {
-			resp, err := paginator.NextPage(ctx)
-			if err != nil {
-				return nil, provider.NewSoftErrorf("failed to list resource records sets for zone %s using aws profile %q: %w", *z.zone.Id, z.profile, err)
+	for _, z := range zones {
+		var chunks []*endpoint.Endpoint
+		err := p.streamZoneRecords(ctx, z, func(ep *endpoint.Endpoint) error {
+			if provider.IsTargetsChunk(ep) {
+				chunks = append(chunks, ep)
+				return nil
+			}
+			return fn(ep)
+		})
+		if err != nil {
+			return err
+		}
+		for _, ep := range joinOversizedEndpoints(chunks) {
+			if err := fn(ep); err != nil {
+				return err
 			}
+		}
+	}
+	return nil
+}
 
-			for _, r := range resp.ResourceRecordSets {
-				newEndpoints := make([]*endpoint.Endpoint, 0)
+// zoneRecords lists the records of a single hosted zone.
+func (p *AWSProvider) zoneRecords(ctx context.Context, z *profiledZone) ([]*endpoint.Endpoint, error) {
+	endpoints := make([]*endpoint.Endpoint, 0)
+	err := p.streamZoneRecords(ctx, z, func(ep *endpoint.Endpoint) error {
+		endpoints = append(endpoints, ep)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
 
-				if !p.SupportedRecordType(r.Type) {
-					continue
-				}
+// streamZoneRecords calls fn with each record of a single hosted zone.
+func (p *AWSProvider) streamZoneRecords(ctx context.Context, z *profiledZone, fn func(*endpoint.Endpoint) error) error {
+	client := p.clients[z.profile]
 
-				name := convertOctalToAscii(wildcardUnescape(*r.Name))
+	paginator := route53.NewListResourceRecordSetsPaginator(client, &route53.ListResourceRecordSetsInput{
+		HostedZoneId: z.zone.Id,
+		MaxItems:     aws.Int32(route53PageSize),
+	})
 
-				var ttl endpoint.TTL
-				if r.TTL != nil {
-					ttl = endpoint.TTL(*r.TTL)
-				}
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return provider.NewSoftErrorf("failed to list resource records sets for zone %s using aws profile %q: %w", *z.zone.Id, z.profile, err)
+		}
 
-				if len(r.ResourceRecords) > 0 {
-					targets := make([]string, len(r.ResourceRecords))
-					for idx, rr := range r.ResourceRecords {
-						targets[idx] = *rr.Value
-					}
+		for _, r := range resp.ResourceRecordSets {
+			newEndpoints := make([]*endpoint.Endpoint, 0)
 
-					ep := endpoint.NewEndpointWithTTL(name, string(r.Type), ttl, targets...)
-					if r.Type == endpoint.RecordTypeCNAME {
-						ep = ep.WithProviderSpecific(providerSpecificAlias, "false")
-					}
-					newEndpoints = append(newEndpoints, ep)
+			if !p.SupportedRecordType(r.Type) {
+				continue
+			}
+
+			name := convertOctalToAscii(wildcardUnescape(*r.Name))
+
+			var ttl endpoint.TTL
+			if r.TTL != nil {
+				ttl = endpoint.TTL(*r.TTL)
+			}
+
+			if len(r.ResourceRecords) > 0 {
+				targets := make([]string, len(r.ResourceRecords))
+				for idx, rr := range r.ResourceRecords {
+					targets[idx] = *rr.Value
 				}
 
-				if r.AliasTarget != nil {
-					// Alias records don't have TTLs so provide the default to match the TXT generation
-					if ttl == 0 {
-						ttl = defaultTTL
-					}
-					ep := endpoint.
-						NewEndpointWithTTL(name, string(r.Type), ttl, *r.AliasTarget.DNSName).
-						WithProviderSpecific(providerSpecificEvaluateTargetHealth, fmt.Sprintf("%t", r.AliasTarget.EvaluateTargetHealth)).
-						WithProviderSpecific(providerSpecificAlias, "true")
-					newEndpoints = append(newEndpoints, ep)
+				ep := endpoint.NewEndpointWithTTL(name, string(r.Type), ttl, targets...)
+				if r.Type == endpoint.RecordTypeCNAME {
+					ep = ep.WithProviderSpecific(providerSpecificAlias, "false")
 				}
+				newEndpoints = append(newEndpoints, ep)
+			}
+
+			if r.AliasTarget != nil {
+				// Alias records don't have TTLs so provide the default to match the TXT generation
+				if ttl == 0 {
+					ttl = defaultTTL
+				}
+				ep := endpoint.
+					NewEndpointWithTTL(name, string(r.Type), ttl, *r.AliasTarget.DNSName).
+					WithProviderSpecific(providerSpecificEvaluateTargetHealth, fmt.Sprintf("%t", r.AliasTarget.EvaluateTargetHealth)).
+					WithProviderSpecific(providerSpecificAlias, "true")
+				newEndpoints = append(newEndpoints, ep)
+			}
 
-				for _, ep := range newEndpoints {
-					if r.SetIdentifier != nil {
-						ep.SetIdentifier = *r.SetIdentifier
-						switch {
-						case r.Weight != nil:
-							ep.WithProviderSpecific(providerSpecificWeight, fmt.Sprintf("%d", *r.Weight))
-						case r.Region != "":
-							ep.WithProviderSpecific(providerSpecificRegion, string(r.Region))
-						case r.Failover != "":
-							ep.WithProviderSpecific(providerSpecificFailover, string(r.Failover))
-						case r.MultiValueAnswer != nil && *r.MultiValueAnswer:
-							ep.WithProviderSpecific(providerSpecificMultiValueAnswer, "")
-						case r.GeoLocation != nil:
-							if r.GeoLocation.ContinentCode != nil {
-								ep.WithProviderSpecific(providerSpecificGeolocationContinentCode, *r.GeoLocation.ContinentCode)
-							} else {
-								if r.GeoLocation.CountryCode != nil {
-									ep.WithProviderSpecific(providerSpecificGeolocationCountryCode, *r.GeoLocation.CountryCode)
-								}
-								if r.GeoLocation.SubdivisionCode != nil {
-									ep.WithProviderSpecific(providerSpecificGeolocationSubdivisionCode, *r.GeoLocation.SubdivisionCode)
-								}
+			for _, ep := range newEndpoints {
+				if r.SetIdentifier != nil {
+					ep.SetIdentifier = *r.SetIdentifier
+					switch {
+					case r.Weight != nil:
+						ep.WithProviderSpecific(providerSpecificWeight, fmt.Sprintf("%d", *r.Weight))
+					case r.Region != "":
+						ep.WithProviderSpecific(providerSpecificRegion, string(r.Region))
+					case r.Failover != "":
+						ep.WithProviderSpecific(providerSpecificFailover, string(r.Failover))
+					case r.MultiValueAnswer != nil && *r.MultiValueAnswer:
+						ep.WithProviderSpecific(providerSpecificMultiValueAnswer, "")
+					case r.GeoLocation != nil:
+						if r.GeoLocation.ContinentCode != nil {
+							ep.WithProviderSpecific(providerSpecificGeolocationContinentCode, *r.GeoLocation.ContinentCode)
+						} else {
+							if r.GeoLocation.CountryCode != nil {
+								ep.WithProviderSpecific(providerSpecificGeolocationCountryCode, *r.GeoLocation.CountryCode)
+							}
+							if r.GeoLocation.SubdivisionCode != nil {
+								ep.WithProviderSpecific(providerSpecificGeolocationSubdivisionCode, *r.GeoLocation.SubdivisionCode)
 							}
-						case r.GeoProximityLocation != nil:
-							handleGeoProximityLocationRecord(&r, ep)
-						default:
-							// one of the above needs to be set, otherwise SetIdentifier doesn't make sense
 						}
+					case r.GeoProximityLocation != nil:
+						handleGeoProximityLocationRecord(&r, ep)
+					default:
+						// one of the above needs to be set, otherwise SetIdentifier doesn't make sense
 					}
+				}
 
-					if r.HealthCheckId != nil {
-						ep.WithProviderSpecific(providerSpecificHealthCheckID, *r.HealthCheckId)
-					}
+				if r.HealthCheckId != nil {
+					ep.WithProviderSpecific(providerSpecificHealthCheckID, *r.HealthCheckId)
+				}
 
-					endpoints = append(endpoints, ep)
+				if err := fn(ep); err != nil {
+					return err
 				}
 			}
 		}
 	}
 
-	return endpoints, nil
+	return nil
 }
 
 func handleGeoProximityLocationRecord(r *route53types.ResourceRecordSet, ep *endpoint.Endpoint) {
@@ -686,16 +766,72 @@ func (p *AWSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) e
 		return provider.NewSoftErrorf("failed to list zones, not applying changes: %w", err)
 	}
 
-	updateChanges := p.createUpdateChanges(changes.UpdateNew, changes.UpdateOld)
+	updateNew, updateOld, creates, deletes := splitOversizedUpdates(changes.UpdateNew, changes.UpdateOld)
+	updateChanges := p.createUpdateChanges(updateNew, updateOld)
+	creates = append(splitOversizedEndpoints(changes.Create), creates...)
+	deletes = append(splitOversizedEndpoints(changes.Delete), deletes...)
 
-	combinedChanges := make(Route53Changes, 0, len(changes.Delete)+len(changes.Create)+len(updateChanges))
-	combinedChanges = append(combinedChanges, p.newChanges(route53types.ChangeActionCreate, changes.Create)...)
-	combinedChanges = append(combinedChanges, p.newChanges(route53types.ChangeActionDelete, changes.Delete)...)
+	combinedChanges := make(Route53Changes, 0, len(deletes)+len(creates)+len(updateChanges))
+	combinedChanges = append(combinedChanges, p.newChanges(route53types.ChangeActionCreate, creates)...)
+	combinedChanges = append(combinedChanges, p.newChanges(route53types.ChangeActionDelete, deletes)...)
 	combinedChanges = append(combinedChanges, updateChanges...)
 
 	return p.submitChanges(ctx, combinedChanges, zones)
 }
 
+// splitOversizedEndpoints splits the endpoints with more values than a record set can have into weighted record
+// sets of equal weight, so that every query is answered with one of the chunks. Endpoints with another routing
+// policy can't be split, as there can only be one record set per location, region or failover role.
+func splitOversizedEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var split []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		_, weighted := ep.GetProviderSpecificProperty(providerSpecificWeight)
+		if len(ep.Targets) <= route53MaxValues || (ep.SetIdentifier != "" && !weighted) {
+			split = append(split, ep)
+			continue
+		}
+		for _, chunk := range provider.SplitTargets(ep, route53MaxValues) {
+			if !weighted {
+				chunk.SetProviderSpecificProperty(providerSpecificWeight, "1")
+			}
+			split = append(split, chunk)
+		}
+	}
+	return split
+}
+
+// splitOversizedUpdates splits the old and new endpoints of updates, pairing their chunks. Chunks without
+// a counterpart, as the number of values grew or shrank, are returned to be created or deleted.
+func splitOversizedUpdates(newEndpoints, oldEndpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, []*endpoint.Endpoint, []*endpoint.Endpoint, []*endpoint.Endpoint) {
+	var updateNew, updateOld, creates, deletes []*endpoint.Endpoint
+	for i, newE := range newEndpoints {
+		if i >= len(oldEndpoints) || oldEndpoints[i] == nil {
+			updateNew, updateOld = append(updateNew, newE), append(updateOld, nil)
+			continue
+		}
+		newChunks := splitOversizedEndpoints([]*endpoint.Endpoint{newE})
+		oldChunks := splitOversizedEndpoints([]*endpoint.Endpoint{oldEndpoints[i]})
+		paired := min(len(newChunks), len(oldChunks))
+		updateNew = append(updateNew, newChunks[:paired]...)
+		updateOld = append(updateOld, oldChunks[:paired]...)
+		creates = append(creates, newChunks[paired:]...)
+		deletes = append(deletes, oldChunks[paired:]...)
+	}
+	return updateNew, updateOld, creates, deletes
+}
+
+// joinOversizedEndpoints rejoins the record sets split by splitOversizedEndpoints. Chunks of endpoints without a
+// set identifier lose the weight they only got for being split.
+func joinOversizedEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	joined := provider.JoinTargets(endpoints)
+	for _, ep := range joined {
+		if ep.SetIdentifier == "" {
+			ep.DeleteProviderSpecificProperty(providerSpecificWeight)
+		}
+	}
+	return joined
+}
+
 // submitChanges takes a zone and a collection of Changes and sends them as a single transaction.
 func (p *AWSProvider) submitChanges(ctx context.Context, changes Route53Changes, zones map[string]*profiledZone) error {
 	// return early if there is nothing to change
@@ -826,7 +962,9 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 	// hard coded to 'A' type aliases but we also need their 'AAAA' counterparts.
 	var aliasCnameAaaaEndpoints []*endpoint.Endpoint
 
+	endpoints = splitWeightedEndpoints(geoLocationEndpoints(provider.RejectChunkSetIdentifiers(endpoints)))
 	for _, ep := range endpoints {
+		provider.DiscardDescription(ep)
 		alias := false
 
 		if aliasString, ok := ep.GetProviderSpecificProperty(providerSpecificAlias); ok {
@@ -888,6 +1026,60 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 	return endpoints, nil
 }
 
+// geoLocationEndpoints translates the geo locations of endpoints into geolocation record sets, which are identified
+// by the location unless the endpoint has a set identifier. A record set can't be weighted and have a geolocation,
+// so the target weights of such endpoints are discarded.
+func geoLocationEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	for _, ep := range endpoints {
+		if ep.Geo == nil {
+			continue
+		}
+		if ep.IsWeighted() {
+			log.Warnf("Record sets can't be both weighted and have a geolocation, ignoring the target weights of %s", ep.DNSName)
+			provider.DiscardWeights(ep)
+		}
+		if ep.Geo.Continent != "" {
+			ep.SetProviderSpecificProperty(providerSpecificGeolocationContinentCode, ep.Geo.Continent)
+		} else {
+			ep.SetProviderSpecificProperty(providerSpecificGeolocationCountryCode, ep.Geo.Country)
+			if ep.Geo.Region != "" {
+				ep.SetProviderSpecificProperty(providerSpecificGeolocationSubdivisionCode, ep.Geo.Region)
+			}
+		}
+		if ep.SetIdentifier == "" {
+			ep.SetIdentifier = ep.Geo.String()
+		}
+		ep.Geo = nil
+	}
+	return endpoints
+}
+
+// splitWeightedEndpoints translates the target weights of endpoints into weighted record sets, one per target,
+// as Route 53 weighs whole record sets. A single target keeps the set identifier of its endpoint, several targets
+// are identified by the set identifier and the target, or only by the target if the endpoint has none.
+func splitWeightedEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var split []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if !ep.IsWeighted() {
+			split = append(split, ep)
+			continue
+		}
+		for _, target := range ep.Targets {
+			weighted := ep.DeepCopy()
+			weighted.Targets = endpoint.Targets{target}
+			weighted.Weights = nil
+			if len(ep.Targets) > 1 {
+				weighted.SetIdentifier = strings.TrimPrefix(ep.SetIdentifier+"/"+target, "/")
+			} else if weighted.SetIdentifier == "" {
+				weighted.SetIdentifier = target
+			}
+			weighted.SetProviderSpecificProperty(providerSpecificWeight, strconv.FormatInt(ep.TargetWeight(target), 10))
+			split = append(split, weighted)
+		}
+	}
+	return split
+}
+
 // if the endpoint is using geoproximity, set the bias to 0 if not set
 // this is needed to avoid unnecessary Upserts if the desired endpoint doesn't specify a bias
 func adjustGeoProximityLocationEndpoint(ep *endpoint.Endpoint) {
@@ -940,6 +1132,9 @@ func (p *AWSProvider) newChange(action route53types.ChangeAction, ep *endpoint.E
 		}
 		change.ResourceRecordSet.ResourceRecords = make([]route53types.ResourceRecord, len(ep.Targets))
 		for idx, val := range ep.Targets {
+			if ep.RecordType == endpoint.RecordTypeTXT {
+				val = endpoint.SplitLongTXT(val)
+			}
 			change.ResourceRecordSet.ResourceRecords[idx] = route53types.ResourceRecord{
 				Value: aws.String(val),
 			}
@@ -1346,6 +1541,16 @@ func useAlias(ep *endpoint.Endpoint, preferCNAME bool) bool {
 	return false
 }
 
+// ApexAlias turns a CNAME record at the apex of a zone, where Route 53 doesn't allow CNAME records, into an alias
+// record if its target is an AWS resource with a canonical hosted zone, and returns whether it did.
+func ApexAlias(ep *endpoint.Endpoint) bool {
+	if ep.RecordType != endpoint.RecordTypeCNAME || len(ep.Targets) == 0 || canonicalHostedZone(ep.Targets[0]) == "" {
+		return false
+	}
+	ep.SetProviderSpecificProperty(providerSpecificAlias, "true")
+	return true
+}
+
 // isAWSAlias determines if a given endpoint is supposed to create an AWS Alias record
 // and (if so) returns the target hosted zone ID
 func isAWSAlias(ep *endpoint.Endpoint) string {
@@ -1399,7 +1604,7 @@ func cleanZoneID(id string) string {
 
 func (p *AWSProvider) SupportedRecordType(recordType route53types.RRType) bool {
 	switch recordType {
-	case route53types.RRTypeMx:
+	case route53types.RRTypeMx, route53types.RRTypeSvcb, route53types.RRTypeHttps, route53types.RRTypeTlsa, route53types.RRTypeNaptr:
 		return true
 	default:
 		return provider.SupportedRecordType(string(recordType))
@@ -19,6 +19,7 @@ package aws
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net"
@@ -349,6 +350,81 @@ func TestAWSZones(t *testing.T) {
 	}
 }
 
+func TestAWSListZones(t *testing.T) {
+	p, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter("private"), defaultEvaluateTargetHealth, false, nil)
+	zones, err := provider.ListZones(context.Background(), p)
+	require.NoError(t, err)
+	assert.Equal(t, []provider.Zone{{ID: "zone-3.ext-dns-test-2.teapot.zalan.do.", Name: "zone-3.ext-dns-test-2.teapot.zalan.do", Visibility: "private"}}, zones)
+}
+
+func TestAWSRecordsZoneListConcurrency(t *testing.T) {
+	records := []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("list-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+		{
+			Name:            aws.String("list-test.zone-2.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("8.8.8.8")}},
+		},
+	}
+	sequential, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), false, false, records)
+	concurrent, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), false, false, records)
+	concurrent.zoneListConcurrency = 4
+
+	expected, err := sequential.Records(context.Background())
+	require.NoError(t, err)
+	actual, err := concurrent.Records(context.Background())
+	require.NoError(t, err)
+
+	assert.Len(t, actual, 2)
+	assert.ElementsMatch(t, expected, actual)
+}
+
+func TestAWSStreamRecords(t *testing.T) {
+	ctx := context.Background()
+	records := []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("list-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+		{
+			Name:            aws.String("list-test.zone-2.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("8.8.8.8")}},
+		},
+	}
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), false, false, records)
+
+	targets := make([]string, 0, route53MaxValues+1)
+	for i := range route53MaxValues + 1 {
+		targets = append(targets, fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+	}
+	large := endpoint.NewEndpointWithTTL("large.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, targets...)
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{large}}))
+
+	expected, err := provider.Records(ctx)
+	require.NoError(t, err)
+
+	var streamed []*endpoint.Endpoint
+	require.NoError(t, provider.StreamRecords(ctx, func(ep *endpoint.Endpoint) error {
+		streamed = append(streamed, ep)
+		return nil
+	}))
+	assert.Len(t, streamed, 3)
+	assert.ElementsMatch(t, expected, streamed)
+
+	errStop := errors.New("stop")
+	require.ErrorIs(t, provider.StreamRecords(ctx, func(*endpoint.Endpoint) error { return errStop }), errStop)
+}
+
 func TestAWSZonesWithTagFilterError(t *testing.T) {
 	client := NewRoute53APIStub(t)
 	provider := &AWSProvider{
@@ -731,6 +807,68 @@ func TestAWSAdjustEndpoints(t *testing.T) {
 	})
 }
 
+func TestAWSAdjustEndpointsWeights(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.1.1.1", "2.2.2.2"),
+		endpoint.NewEndpoint("canary.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "3.3.3.3").WithSetIdentifier("canary").WithWeight(10),
+		endpoint.NewEndpoint("single.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "4.4.4.4").WithWeight(0),
+	}
+	records[0].Weights = map[string]int64{"1.1.1.1": 3}
+
+	records, err := provider.AdjustEndpoints(records)
+	require.NoError(t, err)
+
+	validateEndpoints(t, provider, records, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("1.1.1.1").WithProviderSpecific(providerSpecificWeight, "3"),
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("2.2.2.2").WithProviderSpecific(providerSpecificWeight, "1"),
+		endpoint.NewEndpoint("canary.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "3.3.3.3").WithSetIdentifier("canary").WithProviderSpecific(providerSpecificWeight, "10"),
+		endpoint.NewEndpoint("single.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "4.4.4.4").WithSetIdentifier("4.4.4.4").WithProviderSpecific(providerSpecificWeight, "0"),
+	})
+	for _, record := range records {
+		assert.False(t, record.IsWeighted())
+	}
+}
+
+func TestAWSAdjustEndpointsChunkSetIdentifier(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	records, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("eu.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("eu#1").WithWeight(10),
+		endpoint.NewEndpoint("eu.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("eu").WithWeight(10),
+	})
+	require.NoError(t, err)
+
+	validateEndpoints(t, provider, records, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("eu.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("eu").WithProviderSpecific(providerSpecificWeight, "10"),
+	})
+}
+
+func TestAWSAdjustEndpointsGeo(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("continent.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.1.1.1").WithGeo(endpoint.GeoLocation{Continent: "EU"}),
+		endpoint.NewEndpoint("region.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "2.2.2.2").WithGeo(endpoint.GeoLocation{Country: "US", Region: "CA"}),
+		endpoint.NewEndpoint("default.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "3.3.3.3").WithSetIdentifier("fallback").WithGeo(endpoint.GeoLocation{Country: "*"}),
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "4.4.4.4", "5.5.5.5").WithWeight(1).WithGeo(endpoint.GeoLocation{Country: "DE"}),
+	}
+
+	records, err := provider.AdjustEndpoints(records)
+	require.NoError(t, err)
+
+	validateEndpoints(t, provider, records, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("continent.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("EU").WithProviderSpecific(providerSpecificGeolocationContinentCode, "EU"),
+		endpoint.NewEndpoint("region.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("US-CA").WithProviderSpecific(providerSpecificGeolocationCountryCode, "US").WithProviderSpecific(providerSpecificGeolocationSubdivisionCode, "CA"),
+		endpoint.NewEndpoint("default.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "3.3.3.3").WithSetIdentifier("fallback").WithProviderSpecific(providerSpecificGeolocationCountryCode, "*"),
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "4.4.4.4", "5.5.5.5").WithSetIdentifier("DE").WithProviderSpecific(providerSpecificGeolocationCountryCode, "DE"),
+	})
+	for _, record := range records {
+		assert.Nil(t, record.Geo)
+	}
+}
+
 func TestAWSApplyChanges(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1296,6 +1434,47 @@ func TestAWSApplyChanges(t *testing.T) {
 	}
 }
 
+func TestAWSApplyChangesOversized(t *testing.T) {
+	addresses := func(n int) []string {
+		var targets []string
+		for i := range n {
+			targets = append(targets, fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+		}
+		return targets
+	}
+	ctx := context.Background()
+	provider, stub := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	large := endpoint.NewEndpointWithTTL("large.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, addresses(route53MaxValues+1)...)
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{large}}))
+
+	recordSets := listAWSRecords(t, stub, "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.")
+	require.Len(t, recordSets, 2)
+	for _, rrs := range recordSets {
+		assert.Equal(t, int64(1), *rrs.Weight)
+		assert.LessOrEqual(t, len(rrs.ResourceRecords), route53MaxValues)
+	}
+
+	records, err := provider.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.ElementsMatch(t, large.Targets, records[0].Targets)
+	assert.Empty(t, records[0].SetIdentifier)
+	_, weighted := records[0].GetProviderSpecificProperty(providerSpecificWeight)
+	assert.False(t, weighted, "the weight of the chunks should be dropped")
+
+	small := endpoint.NewEndpointWithTTL("large.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, addresses(2)...)
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{UpdateOld: records, UpdateNew: []*endpoint.Endpoint{small}}))
+
+	records, err = provider.Records(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, provider, records, []*endpoint.Endpoint{small})
+
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{UpdateOld: records, UpdateNew: []*endpoint.Endpoint{large}}))
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{large}}))
+	assert.Empty(t, listAWSRecords(t, stub, "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."))
+}
+
 func TestAWSApplyChangesDryRun(t *testing.T) {
 	originalRecords := []route53types.ResourceRecordSet{
 		{
@@ -2071,6 +2250,46 @@ func TestAWSCreateRecordsWithCNAME(t *testing.T) {
 	})
 }
 
+func TestAWSCreateRecordsWithLongTXT(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	records := []*endpoint.Endpoint{
+		{DNSName: "create-test.zone-1.ext-dns-test-2.teapot.zalan.do", Targets: endpoint.Targets{strings.Repeat("a", 300)}, RecordType: endpoint.RecordTypeTXT},
+		{DNSName: "create-test-short.zone-1.ext-dns-test-2.teapot.zalan.do", Targets: endpoint.Targets{"\"foo\""}, RecordType: endpoint.RecordTypeTXT},
+	}
+
+	adjusted, err := provider.AdjustEndpoints(records)
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: adjusted,
+	}))
+
+	recordSets := listAWSRecords(t, provider.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.")
+
+	validateRecords(t, recordSets, []route53types.ResourceRecordSet{
+		{
+			Name: aws.String("create-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type: route53types.RRTypeTxt,
+			TTL:  aws.Int64(300),
+			ResourceRecords: []route53types.ResourceRecord{
+				{
+					Value: aws.String(fmt.Sprintf(`"%s" "%s"`, strings.Repeat("a", 255), strings.Repeat("a", 45))),
+				},
+			},
+		},
+		{
+			Name: aws.String("create-test-short.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type: route53types.RRTypeTxt,
+			TTL:  aws.Int64(300),
+			ResourceRecords: []route53types.ResourceRecord{
+				{
+					Value: aws.String("\"foo\""),
+				},
+			},
+		},
+	})
+}
+
 func TestAWSCreateRecordsWithALIAS(t *testing.T) {
 	for key, evaluateTargetHealth := range map[string]bool{
 		"true":  true,
@@ -2189,6 +2408,24 @@ func TestAWSisAWSAlias(t *testing.T) {
 	}
 }
 
+func TestAWSApexAlias(t *testing.T) {
+	elb := endpoint.NewEndpoint("example.org", endpoint.RecordTypeCNAME, "bar.eu-central-1.elb.amazonaws.com")
+	assert.True(t, ApexAlias(elb))
+	alias, _ := elb.GetProviderSpecificProperty(providerSpecificAlias)
+	assert.Equal(t, "true", alias)
+
+	external := endpoint.NewEndpoint("example.org", endpoint.RecordTypeCNAME, "lb.example.net")
+	assert.False(t, ApexAlias(external))
+	assert.Empty(t, external.ProviderSpecific)
+
+	// an alias survives --aws-prefer-cname
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+	provider.preferCNAME = true
+	records, err := provider.AdjustEndpoints([]*endpoint.Endpoint{elb})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.RecordTypeA, records[0].RecordType)
+}
+
 func TestAWSCanonicalHostedZone(t *testing.T) {
 	for suffix, id := range canonicalHostedZones {
 		zone := canonicalHostedZone(fmt.Sprintf("foo.%s", suffix))
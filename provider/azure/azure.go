@@ -64,12 +64,13 @@ type AzureProvider struct {
 	zonesCache                   *zonesCache[dns.Zone]
 	recordSetsClient             RecordSetsClient
 	maxRetriesCount              int
+	zoneListConcurrency          int
 }
 
 // NewAzureProvider creates a new Azure provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAzureProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, dryRun bool) (*AzureProvider, error) {
+func NewAzureProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, zoneListConcurrency int, dryRun bool) (*AzureProvider, error) {
 	cfg, err := getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %w", configFile, err)
@@ -100,6 +101,7 @@ func NewAzureProvider(configFile string, domainFilter *endpoint.DomainFilter, zo
 		zonesCache:                   &zonesCache[dns.Zone]{duration: zonesCacheDuration},
 		recordSetsClient:             recordSetsClient,
 		maxRetriesCount:              maxRetriesCount,
+		zoneListConcurrency:          zoneListConcurrency,
 	}, nil
 }
 
@@ -112,50 +114,82 @@ func (p *AzureProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, erro
 		return nil, err
 	}
 
-	endpoints := make([]*endpoint.Endpoint, 0)
+	return provider.ZoneRecords(ctx, zones, p.zoneListConcurrency, p.zoneRecords)
+}
+
+// StreamRecords calls fn with the records of each zone in turn, page after page.
+func (p *AzureProvider) StreamRecords(ctx context.Context, fn func(*endpoint.Endpoint) error) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
 
 	for _, zone := range zones {
-		pager := p.recordSetsClient.NewListAllByDNSZonePager(p.resourceGroup, *zone.Name, &dns.RecordSetsClientListAllByDNSZoneOptions{Top: nil})
-		for pager.More() {
-			nextResult, err := pager.NextPage(ctx)
-			if err != nil {
-				return nil, provider.NewSoftError(fmt.Errorf("failed to fetch dns records: %w", err))
+		if err := p.streamZoneRecords(ctx, zone, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zoneRecords lists the records of a single zone.
+func (p *AzureProvider) zoneRecords(ctx context.Context, zone dns.Zone) ([]*endpoint.Endpoint, error) {
+	endpoints := make([]*endpoint.Endpoint, 0)
+	err := p.streamZoneRecords(ctx, zone, func(ep *endpoint.Endpoint) error {
+		endpoints = append(endpoints, ep)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// streamZoneRecords calls fn with each record of a single zone.
+func (p *AzureProvider) streamZoneRecords(ctx context.Context, zone dns.Zone, fn func(*endpoint.Endpoint) error) error {
+	pager := p.recordSetsClient.NewListAllByDNSZonePager(p.resourceGroup, *zone.Name, &dns.RecordSetsClientListAllByDNSZoneOptions{Top: nil})
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to fetch dns records: %w", err))
+		}
+		for _, recordSet := range nextResult.Value {
+			if recordSet.Name == nil || recordSet.Type == nil {
+				log.Error("Skipping invalid record set with nil name or type.")
+				continue
 			}
-			for _, recordSet := range nextResult.Value {
-				if recordSet.Name == nil || recordSet.Type == nil {
-					log.Error("Skipping invalid record set with nil name or type.")
-					continue
-				}
-				recordType := strings.TrimPrefix(*recordSet.Type, "Microsoft.Network/dnszones/")
-				if !p.SupportedRecordType(recordType) {
-					continue
-				}
-				name := formatAzureDNSName(*recordSet.Name, *zone.Name)
-				if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
-					log.Debugf("Skipping return of record %s because it was filtered out by the specified --domain-filter", name)
-					continue
-				}
-				targets := extractAzureTargets(recordSet)
-				if len(targets) == 0 {
-					log.Debugf("Failed to extract targets for '%s' with type '%s'.", name, recordType)
-					continue
-				}
-				var ttl endpoint.TTL
-				if recordSet.Properties.TTL != nil {
-					ttl = endpoint.TTL(*recordSet.Properties.TTL)
-				}
-				ep := endpoint.NewEndpointWithTTL(name, recordType, ttl, targets...)
-				log.Debugf(
-					"Found %s record for '%s' with target '%s'.",
-					ep.RecordType,
-					ep.DNSName,
-					ep.Targets,
-				)
-				endpoints = append(endpoints, ep)
+			recordType := strings.TrimPrefix(*recordSet.Type, "Microsoft.Network/dnszones/")
+			if !p.SupportedRecordType(recordType) {
+				continue
+			}
+			name := formatAzureDNSName(*recordSet.Name, *zone.Name)
+			if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
+				log.Debugf("Skipping return of record %s because it was filtered out by the specified --domain-filter", name)
+				continue
+			}
+			targets := extractAzureTargets(recordSet)
+			if len(targets) == 0 {
+				log.Debugf("Failed to extract targets for '%s' with type '%s'.", name, recordType)
+				continue
+			}
+			var ttl endpoint.TTL
+			if recordSet.Properties.TTL != nil {
+				ttl = endpoint.TTL(*recordSet.Properties.TTL)
+			}
+			ep := endpoint.NewEndpointWithTTL(name, recordType, ttl, targets...)
+			log.Debugf(
+				"Found %s record for '%s' with target '%s'.",
+				ep.RecordType,
+				ep.DNSName,
+				ep.Targets,
+			)
+			if err := fn(ep); err != nil {
+				return err
 			}
 		}
 	}
-	return endpoints, nil
+
+	return nil
 }
 
 // ApplyChanges applies the given changes.
@@ -410,15 +444,28 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 				NsRecords: nsRecords,
 			},
 		}, nil
+	case dns.RecordTypeSRV:
+		srvRecords := make([]*dns.SrvRecord, len(endpoint.Targets))
+		for i, target := range endpoint.Targets {
+			srvRecord, err := parseSrvTarget[dns.SrvRecord](target)
+			if err != nil {
+				return dns.RecordSet{}, err
+			}
+			srvRecords[i] = &srvRecord
+		}
+		return dns.RecordSet{
+			Properties: &dns.RecordSetProperties{
+				TTL:        to.Ptr(ttl),
+				SrvRecords: srvRecords,
+			},
+		}, nil
 	case dns.RecordTypeTXT:
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
 				TTL: to.Ptr(ttl),
 				TxtRecords: []*dns.TxtRecord{
 					{
-						Value: []*string{
-							&endpoint.Targets[0],
-						},
+						Value: txtValue(endpoint.Targets[0]),
 					},
 				},
 			},
@@ -478,6 +525,16 @@ func extractAzureTargets(recordSet *dns.RecordSet) []string {
 		return targets
 	}
 
+	// Check for SRV records
+	srvRecords := properties.SrvRecords
+	if len(srvRecords) > 0 && (srvRecords)[0].Target != nil {
+		targets := make([]string, len(srvRecords))
+		for i, srvRecord := range srvRecords {
+			targets[i] = fmt.Sprintf("%d %d %d %s", *srvRecord.Priority, *srvRecord.Weight, *srvRecord.Port, *srvRecord.Target)
+		}
+		return targets
+	}
+
 	// Check for NS records
 	nsRecords := properties.NsRecords
 	if len(nsRecords) > 0 && (nsRecords)[0].Nsdname != nil {
@@ -493,7 +550,7 @@ func extractAzureTargets(recordSet *dns.RecordSet) []string {
 	if len(txtRecords) > 0 && (txtRecords)[0].Value != nil {
 		values := (txtRecords)[0].Value
 		if len(values) > 0 {
-			return []string{*(values)[0]}
+			return []string{joinTXTValue(values)}
 		}
 	}
 	return []string{}
@@ -59,12 +59,13 @@ type AzurePrivateDNSProvider struct {
 	zonesCache                   *zonesCache[privatedns.PrivateZone]
 	recordSetsClient             PrivateRecordSetsClient
 	maxRetriesCount              int
+	zoneListConcurrency          int
 }
 
 // NewAzurePrivateDNSProvider creates a new Azure Private DNS provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAzurePrivateDNSProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, dryRun bool) (*AzurePrivateDNSProvider, error) {
+func NewAzurePrivateDNSProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, zoneListConcurrency int, dryRun bool) (*AzurePrivateDNSProvider, error) {
 	cfg, err := getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %w", configFile, err)
@@ -95,6 +96,7 @@ func NewAzurePrivateDNSProvider(configFile string, domainFilter *endpoint.Domain
 		zonesCache:                   &zonesCache[privatedns.PrivateZone]{duration: zonesCacheDuration},
 		recordSetsClient:             recordSetsClient,
 		maxRetriesCount:              maxRetriesCount,
+		zoneListConcurrency:          zoneListConcurrency,
 	}, nil
 }
 
@@ -109,60 +111,97 @@ func (p *AzurePrivateDNSProvider) Records(ctx context.Context) ([]*endpoint.Endp
 
 	log.Debugf("Retrieving Azure Private DNS Records for resource group '%s'", p.resourceGroup)
 
-	endpoints := make([]*endpoint.Endpoint, 0)
+	endpoints, err := provider.ZoneRecords(ctx, zones, p.zoneListConcurrency, p.zoneRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Returning %d Azure Private DNS Records for resource group '%s'", len(endpoints), p.resourceGroup)
+
+	return endpoints, nil
+}
+
+// StreamRecords calls fn with the records of each private zone in turn, page after page.
+func (p *AzurePrivateDNSProvider) StreamRecords(ctx context.Context, fn func(*endpoint.Endpoint) error) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+
 	for _, zone := range zones {
-		pager := p.recordSetsClient.NewListPager(p.resourceGroup, *zone.Name, &privatedns.RecordSetsClientListOptions{Top: nil})
-		for pager.More() {
-			nextResult, err := pager.NextPage(ctx)
-			if err != nil {
-				return nil, provider.NewSoftErrorf("failed to fetch dns records: %v", err)
-			}
+		if err := p.streamZoneRecords(ctx, zone, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			for _, recordSet := range nextResult.Value {
-				var recordType string
-				if recordSet.Type == nil {
-					log.Debugf("Skipping invalid record set with missing type.")
-					continue
-				}
-				recordType = strings.TrimPrefix(*recordSet.Type, "Microsoft.Network/privateDnsZones/")
+// zoneRecords lists the records of a single private zone.
+func (p *AzurePrivateDNSProvider) zoneRecords(ctx context.Context, zone privatedns.PrivateZone) ([]*endpoint.Endpoint, error) {
+	endpoints := make([]*endpoint.Endpoint, 0)
+	err := p.streamZoneRecords(ctx, zone, func(ep *endpoint.Endpoint) error {
+		endpoints = append(endpoints, ep)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
 
-				var name string
-				if recordSet.Name == nil {
-					log.Debugf("Skipping invalid record set with missing name.")
-					continue
-				}
-				name = formatAzureDNSName(*recordSet.Name, *zone.Name)
+// streamZoneRecords calls fn with each record of a single private zone.
+func (p *AzurePrivateDNSProvider) streamZoneRecords(ctx context.Context, zone privatedns.PrivateZone, fn func(*endpoint.Endpoint) error) error {
+	pager := p.recordSetsClient.NewListPager(p.resourceGroup, *zone.Name, &privatedns.RecordSetsClientListOptions{Top: nil})
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return provider.NewSoftErrorf("failed to fetch dns records: %v", err)
+		}
 
-				if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
-					log.Debugf("Skipping return of record %s because it was filtered out by the specified --domain-filter", name)
-					continue
-				}
-				targets := extractAzurePrivateDNSTargets(recordSet)
-				if len(targets) == 0 {
-					log.Debugf("Failed to extract targets for '%s' with type '%s'.", name, recordType)
-					continue
-				}
+		for _, recordSet := range nextResult.Value {
+			var recordType string
+			if recordSet.Type == nil {
+				log.Debugf("Skipping invalid record set with missing type.")
+				continue
+			}
+			recordType = strings.TrimPrefix(*recordSet.Type, "Microsoft.Network/privateDnsZones/")
 
-				var ttl endpoint.TTL
-				if recordSet.Properties.TTL != nil {
-					ttl = endpoint.TTL(*recordSet.Properties.TTL)
-				}
+			var name string
+			if recordSet.Name == nil {
+				log.Debugf("Skipping invalid record set with missing name.")
+				continue
+			}
+			name = formatAzureDNSName(*recordSet.Name, *zone.Name)
 
-				ep := endpoint.NewEndpointWithTTL(name, recordType, ttl, targets...)
-				log.Debugf(
-					"Found %s record for '%s' with target '%s'.",
-					ep.RecordType,
-					ep.DNSName,
-					ep.Targets,
-				)
-				endpoints = append(endpoints, ep)
+			if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
+				log.Debugf("Skipping return of record %s because it was filtered out by the specified --domain-filter", name)
+				continue
+			}
+			targets := extractAzurePrivateDNSTargets(recordSet)
+			if len(targets) == 0 {
+				log.Debugf("Failed to extract targets for '%s' with type '%s'.", name, recordType)
+				continue
+			}
+
+			var ttl endpoint.TTL
+			if recordSet.Properties.TTL != nil {
+				ttl = endpoint.TTL(*recordSet.Properties.TTL)
+			}
+
+			ep := endpoint.NewEndpointWithTTL(name, recordType, ttl, targets...)
+			log.Debugf(
+				"Found %s record for '%s' with target '%s'.",
+				ep.RecordType,
+				ep.DNSName,
+				ep.Targets,
+			)
+			if err := fn(ep); err != nil {
+				return err
 			}
 		}
 	}
 
-	log.Debugf("Returning %d Azure Private DNS Records for resource group '%s'", len(endpoints), p.resourceGroup)
-
-	return endpoints, nil
+	return nil
 }
 
 // ApplyChanges applies the given changes.
@@ -403,15 +442,28 @@ func (p *AzurePrivateDNSProvider) newRecordSet(endpoint *endpoint.Endpoint) (pri
 				MxRecords: mxRecords,
 			},
 		}, nil
+	case privatedns.RecordTypeSRV:
+		srvRecords := make([]*privatedns.SrvRecord, len(endpoint.Targets))
+		for i, target := range endpoint.Targets {
+			srvRecord, err := parseSrvTarget[privatedns.SrvRecord](target)
+			if err != nil {
+				return privatedns.RecordSet{}, err
+			}
+			srvRecords[i] = &srvRecord
+		}
+		return privatedns.RecordSet{
+			Properties: &privatedns.RecordSetProperties{
+				TTL:        to.Ptr(ttl),
+				SrvRecords: srvRecords,
+			},
+		}, nil
 	case privatedns.RecordTypeTXT:
 		return privatedns.RecordSet{
 			Properties: &privatedns.RecordSetProperties{
 				TTL: to.Ptr(ttl),
 				TxtRecords: []*privatedns.TxtRecord{
 					{
-						Value: []*string{
-							&endpoint.Targets[0],
-						},
+						Value: txtValue(endpoint.Targets[0]),
 					},
 				},
 			},
@@ -463,12 +515,22 @@ func extractAzurePrivateDNSTargets(recordSet *privatedns.RecordSet) []string {
 		return targets
 	}
 
+	// Check for SRV records
+	srvRecords := properties.SrvRecords
+	if len(srvRecords) > 0 && (srvRecords)[0].Target != nil {
+		targets := make([]string, len(srvRecords))
+		for i, srvRecord := range srvRecords {
+			targets[i] = fmt.Sprintf("%d %d %d %s", *srvRecord.Priority, *srvRecord.Weight, *srvRecord.Port, *srvRecord.Target)
+		}
+		return targets
+	}
+
 	// Check for TXT records
 	txtRecords := properties.TxtRecords
 	if len(txtRecords) > 0 && (txtRecords)[0].Value != nil {
 		values := (txtRecords)[0].Value
 		if len(values) > 0 {
-			return []string{*(values)[0]}
+			return []string{joinTXTValue(values)}
 		}
 	}
 	return []string{}
@@ -172,6 +172,18 @@ func mxRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetPrope
 	}
 }
 
+func srvRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetProperties {
+	srvRecords := make([]*dns.SrvRecord, len(values))
+	for i, target := range values {
+		srvRecord, _ := parseSrvTarget[dns.SrvRecord](target)
+		srvRecords[i] = &srvRecord
+	}
+	return &dns.RecordSetProperties{
+		TTL:        to.Ptr(ttl),
+		SrvRecords: srvRecords,
+	}
+}
+
 func nsRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetProperties {
 	nsRecords := make([]*dns.NsRecord, len(values))
 	for i, value := range values {
@@ -222,6 +234,8 @@ func createMockRecordSetMultiWithTTL(name, recordType string, ttl int64, values
 		getterFunc = cNameRecordSetPropertiesGetter
 	case endpoint.RecordTypeMX:
 		getterFunc = mxRecordSetPropertiesGetter
+	case endpoint.RecordTypeSRV:
+		getterFunc = srvRecordSetPropertiesGetter
 	case endpoint.RecordTypeNS:
 		getterFunc = nsRecordSetPropertiesGetter
 	case endpoint.RecordTypeTXT:
@@ -281,6 +295,7 @@ func TestAzureRecord(t *testing.T) {
 			createMockRecordSetWithTTL("nginx", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default", recordTTL),
 			createMockRecordSetWithTTL("hack", endpoint.RecordTypeCNAME, "hack.azurewebsites.net", 10),
 			createMockRecordSetMultiWithTTL("mail", endpoint.RecordTypeMX, 4000, "10 example.com"),
+			createMockRecordSetMultiWithTTL("_sip._udp", endpoint.RecordTypeSRV, 4000, "10 5 5060 sip.example.com"),
 		}, 3)
 	if err != nil {
 		t.Fatal(err)
@@ -303,6 +318,7 @@ func TestAzureRecord(t *testing.T) {
 		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeTXT, recordTTL, "heritage=external-dns,external-dns/owner=default"),
 		endpoint.NewEndpointWithTTL("hack.example.com", endpoint.RecordTypeCNAME, 10, "hack.azurewebsites.net"),
 		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeMX, 4000, "10 example.com"),
+		endpoint.NewEndpointWithTTL("_sip._udp.example.com", endpoint.RecordTypeSRV, 4000, "10 5 5060 sip.example.com"),
 	}
 
 	validateAzureEndpoints(t, actual, expected)
@@ -18,30 +18,59 @@ limitations under the License.
 package azure
 
 import (
-	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 // Helper function (shared with test code)
 func parseMxTarget[T dns.MxRecord | privatedns.MxRecord](mxTarget string) (T, error) {
-	targetParts := strings.SplitN(mxTarget, " ", 2)
-	if len(targetParts) != 2 {
-		return T{}, fmt.Errorf("mx target needs to be of form '10 example.com'")
+	mx, err := endpoint.NewMXRecord(mxTarget)
+	if err != nil {
+		return T{}, err
 	}
 
-	preferenceRaw, exchange := targetParts[0], targetParts[1]
-	preference, err := strconv.ParseInt(preferenceRaw, 10, 32)
+	return T{
+		Preference: to.Ptr(int32(*mx.GetPriority())),
+		Exchange:   mx.GetHost(),
+	}, nil
+}
+
+// Helper function (shared with test code)
+func parseSrvTarget[T dns.SrvRecord | privatedns.SrvRecord](srvTarget string) (T, error) {
+	srv, err := endpoint.NewSRVRecord(srvTarget)
 	if err != nil {
-		return T{}, fmt.Errorf("invalid preference specified")
+		return T{}, err
 	}
 
 	return T{
-		Preference: to.Ptr(int32(preference)),
-		Exchange:   to.Ptr(exchange),
+		Priority: to.Ptr(int32(*srv.GetPriority())),
+		Weight:   to.Ptr(int32(*srv.GetWeight())),
+		Port:     to.Ptr(int32(*srv.GetPort())),
+		Target:   srv.GetHost(),
 	}, nil
 }
+
+// txtValue returns the strings of the value of a TXT record. Values longer than a single string are split into
+// strings of at most 255 bytes, shorter ones are kept as they are.
+func txtValue(target string) []*string {
+	if len(endpoint.JoinTXT(target)) <= endpoint.TXTStringMaxLength {
+		return []*string{to.Ptr(target)}
+	}
+	return to.SliceOfPtrs(endpoint.SplitTXT(target)...)
+}
+
+// joinTXTValue returns the value of a TXT record made of the given strings.
+func joinTXTValue(values []*string) string {
+	var value strings.Builder
+	for _, v := range values {
+		if v != nil {
+			value.WriteString(*v)
+		}
+	}
+	return value.String()
+}
@@ -18,6 +18,7 @@ package azure
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
@@ -68,6 +69,21 @@ func Test_parseMxTarget(t *testing.T) {
 			want:    dns.MxRecord{},
 			wantErr: assert.Error,
 		},
+		{
+			name: "valid mx target with extra spaces",
+			args: " 10  example.com",
+			want: dns.MxRecord{
+				Preference: to.Ptr(int32(10)),
+				Exchange:   to.Ptr("example.com"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid mx target with out of range preference",
+			args:    "65536 example.com",
+			want:    dns.MxRecord{},
+			wantErr: assert.Error,
+		},
 		{
 			name:    "invalid mx target with non numeric preference",
 			args:    "aa example.com",
@@ -85,3 +101,29 @@ func Test_parseMxTarget(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseSrvTarget(t *testing.T) {
+	got, err := parseSrvTarget[dns.SrvRecord]("10 5 5060 sip.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, dns.SrvRecord{
+		Priority: to.Ptr(int32(10)),
+		Weight:   to.Ptr(int32(5)),
+		Port:     to.Ptr(int32(5060)),
+		Target:   to.Ptr("sip.example.com"),
+	}, got)
+
+	_, err = parseSrvTarget[dns.SrvRecord]("10 5060 sip.example.com")
+	assert.Error(t, err)
+}
+
+func Test_txtValue(t *testing.T) {
+	assert.Equal(t, []*string{to.Ptr("short")}, txtValue("short"))
+	assert.Equal(t, []*string{to.Ptr(`"short"`)}, txtValue(`"short"`))
+	assert.Equal(t, to.SliceOfPtrs(strings.Repeat("a", 255), strings.Repeat("a", 45)), txtValue(strings.Repeat("a", 300)))
+}
+
+func Test_joinTXTValue(t *testing.T) {
+	assert.Equal(t, "short", joinTXTValue([]*string{to.Ptr("short")}))
+	assert.Equal(t, strings.Repeat("a", 300), joinTXTValue(to.SliceOfPtrs(strings.Repeat("a", 255), strings.Repeat("a", 45))))
+	assert.Empty(t, joinTXTValue(nil))
+}
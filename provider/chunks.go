@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// chunkSeparator separates the set identifier of a split endpoint from the number of the chunk.
+const chunkSeparator = "#"
+
+// SplitTargets splits an endpoint with more than maxTargets targets into chunks of its sorted targets, so that
+// a provider can publish record sets exceeding its limits as several record sets. The chunks are identified by
+// the set identifier of the endpoint followed by "#" and the number of the chunk, starting at 1. The split is
+// deterministic, so that the chunks of a current endpoint match the record sets created for it.
+func SplitTargets(ep *endpoint.Endpoint, maxTargets int) []*endpoint.Endpoint {
+	if maxTargets <= 0 || len(ep.Targets) <= maxTargets {
+		return []*endpoint.Endpoint{ep}
+	}
+	targets := slices.Clone(ep.Targets)
+	slices.Sort(targets)
+
+	var chunks []*endpoint.Endpoint
+	for i := 0; i*maxTargets < len(targets); i++ {
+		chunk := ep.DeepCopy()
+		chunk.Targets = targets[i*maxTargets : min((i+1)*maxTargets, len(targets))]
+		chunk.SetIdentifier = ep.SetIdentifier + chunkSeparator + strconv.Itoa(i+1)
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// JoinTargets rejoins the chunks of endpoints split by SplitTargets, so that they compare to the desired endpoints.
+// The joined endpoint takes the properties of its first chunk. Other endpoints are returned unchanged.
+func JoinTargets(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	type chunkKey struct {
+		dnsName, recordType, setIdentifier string
+	}
+	joined := make([]*endpoint.Endpoint, 0, len(endpoints))
+	index := map[chunkKey]int{}
+	chunks := map[chunkKey][]*endpoint.Endpoint{}
+	numbers := map[*endpoint.Endpoint]int{}
+	for _, ep := range endpoints {
+		setIdentifier, number, ok := chunkOf(ep)
+		if !ok {
+			joined = append(joined, ep)
+			continue
+		}
+		key := chunkKey{ep.DNSName, ep.RecordType, setIdentifier}
+		if _, seen := index[key]; !seen {
+			index[key] = len(joined)
+			joined = append(joined, nil)
+		}
+		chunks[key] = append(chunks[key], ep)
+		numbers[ep] = number
+	}
+
+	for key, parts := range chunks {
+		slices.SortFunc(parts, func(a, b *endpoint.Endpoint) int { return numbers[a] - numbers[b] })
+		whole := parts[0].DeepCopy()
+		whole.SetIdentifier = key.setIdentifier
+		for _, part := range parts[1:] {
+			whole.Targets = append(whole.Targets, part.Targets...)
+		}
+		joined[index[key]] = whole
+	}
+	return joined
+}
+
+// chunkOf returns the set identifier of the split endpoint and the number of the chunk, if the endpoint is one.
+func chunkOf(ep *endpoint.Endpoint) (string, int, bool) {
+	idx := strings.LastIndex(ep.SetIdentifier, chunkSeparator)
+	if idx < 0 {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(ep.SetIdentifier[idx+len(chunkSeparator):])
+	if err != nil || number < 1 {
+		return "", 0, false
+	}
+	return ep.SetIdentifier[:idx], number, true
+}
+
+// RejectChunkSetIdentifiers leaves out the endpoints with a set identifier ending in "#" and a number, which is
+// reserved for the chunks of split endpoints. Otherwise their record sets would be taken for chunks when they are
+// read, and joined with the record sets of another set identifier.
+func RejectChunkSetIdentifiers(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var accepted []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if IsTargetsChunk(ep) {
+			log.Warnf("Ignoring %s %s, as its set identifier %q ends like the set identifiers reserved for the chunks of large record sets", ep.DNSName, ep.RecordType, ep.SetIdentifier)
+			continue
+		}
+		accepted = append(accepted, ep)
+	}
+	return accepted
+}
+
+// IsTargetsChunk returns whether the endpoint is a chunk of an endpoint split by SplitTargets.
+func IsTargetsChunk(ep *endpoint.Endpoint) bool {
+	_, _, ok := chunkOf(ep)
+	return ok
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestSplitTargets(t *testing.T) {
+	ep := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.5", "1.1.1.1", "1.1.1.4", "1.1.1.2", "1.1.1.3")
+
+	assert.Equal(t, []*endpoint.Endpoint{ep}, SplitTargets(ep, 5))
+
+	chunks := SplitTargets(ep, 2)
+	require.Len(t, chunks, 3)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1", "1.1.1.2"}, chunks[0].Targets)
+	assert.Equal(t, endpoint.Targets{"1.1.1.3", "1.1.1.4"}, chunks[1].Targets)
+	assert.Equal(t, endpoint.Targets{"1.1.1.5"}, chunks[2].Targets)
+	assert.Equal(t, "#1", chunks[0].SetIdentifier)
+	assert.Equal(t, "#3", chunks[2].SetIdentifier)
+
+	ep.SetIdentifier = "blue"
+	assert.Equal(t, "blue#2", SplitTargets(ep, 2)[1].SetIdentifier)
+}
+
+func TestJoinTargets(t *testing.T) {
+	ep := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1", "1.1.1.2", "1.1.1.3").WithSetIdentifier("blue")
+	other := endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("green")
+	chunks := SplitTargets(ep, 1)
+
+	joined := JoinTargets([]*endpoint.Endpoint{chunks[2], other, chunks[0], chunks[1]})
+	require.Len(t, joined, 2)
+	assert.Equal(t, ep, joined[0])
+	assert.Equal(t, other, joined[1])
+
+	unsplit := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("release#beta")
+	assert.Equal(t, []*endpoint.Endpoint{unsplit}, JoinTargets([]*endpoint.Endpoint{unsplit}))
+}
+
+func TestRejectChunkSetIdentifiers(t *testing.T) {
+	reserved := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("eu#1")
+	allowed := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("eu")
+	other := endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "3.3.3.3").WithSetIdentifier("eu#beta")
+
+	assert.Equal(t, []*endpoint.Endpoint{allowed, other}, RejectChunkSetIdentifiers([]*endpoint.Endpoint{reserved, allowed, other}))
+}
@@ -86,12 +86,15 @@ type CustomHostnameIndex struct {
 type CustomHostnamesMap map[CustomHostnameIndex]cloudflarev0.CustomHostname
 
 var recordTypeProxyNotSupported = map[string]bool{
-	"LOC": true,
-	"MX":  true,
-	"NS":  true,
-	"SPF": true,
-	"TXT": true,
-	"SRV": true,
+	"LOC":   true,
+	"MX":    true,
+	"NS":    true,
+	"SPF":   true,
+	"TXT":   true,
+	"SRV":   true,
+	"SVCB":  true,
+	"HTTPS": true,
+	"TLSA":  true,
 }
 
 type CustomHostnamesConfig struct {
@@ -193,6 +196,8 @@ func listZonesV4Params() zones.ZoneListParams {
 type DNSRecordsConfig struct {
 	PerPage int
 	Comment string
+	// ListConcurrency is the number of zones whose records are listed at once.
+	ListConcurrency int
 }
 
 func (c *DNSRecordsConfig) trimAndValidateComment(dnsName, comment string, paidZone func(string) bool) string {
@@ -264,36 +269,96 @@ type RecordParamsTypes interface {
 
 // updateDNSRecordParam is a function that returns the appropriate Record Param based on the cloudFlareChange passed in
 func getUpdateDNSRecordParam(zoneID string, cfc cloudFlareChange) dns.RecordUpdateParams {
+	body := dns.RecordUpdateParamsBody{
+		Name:     cloudflare.F(cfc.ResourceRecord.Name),
+		TTL:      cloudflare.F(cfc.ResourceRecord.TTL),
+		Proxied:  cloudflare.F(cfc.ResourceRecord.Proxied),
+		Type:     cloudflare.F(dns.RecordUpdateParamsBodyType(cfc.ResourceRecord.Type)),
+		Content:  cloudflare.F(cfc.ResourceRecord.Content),
+		Priority: cloudflare.F(cfc.ResourceRecord.Priority),
+		Comment:  cloudflare.F(cfc.ResourceRecord.Comment),
+	}
+	if data := recordDataParam(cfc.ResourceRecord); data != nil {
+		body.Data = cloudflare.F(data)
+	}
 	return dns.RecordUpdateParams{
 		ZoneID: cloudflare.F(zoneID),
-		Body: dns.RecordUpdateParamsBody{
-			Name:     cloudflare.F(cfc.ResourceRecord.Name),
-			TTL:      cloudflare.F(cfc.ResourceRecord.TTL),
-			Proxied:  cloudflare.F(cfc.ResourceRecord.Proxied),
-			Type:     cloudflare.F(dns.RecordUpdateParamsBodyType(cfc.ResourceRecord.Type)),
-			Content:  cloudflare.F(cfc.ResourceRecord.Content),
-			Priority: cloudflare.F(cfc.ResourceRecord.Priority),
-			Comment:  cloudflare.F(cfc.ResourceRecord.Comment),
-		},
+		Body:   body,
 	}
 }
 
 // getCreateDNSRecordParam is a function that returns the appropriate Record Param based on the cloudFlareChange passed in
 func getCreateDNSRecordParam(zoneID string, cfc *cloudFlareChange) dns.RecordNewParams {
+	body := dns.RecordNewParamsBody{
+		Name:     cloudflare.F(cfc.ResourceRecord.Name),
+		TTL:      cloudflare.F(cfc.ResourceRecord.TTL),
+		Proxied:  cloudflare.F(cfc.ResourceRecord.Proxied),
+		Type:     cloudflare.F(dns.RecordNewParamsBodyType(cfc.ResourceRecord.Type)),
+		Content:  cloudflare.F(cfc.ResourceRecord.Content),
+		Priority: cloudflare.F(cfc.ResourceRecord.Priority),
+		Comment:  cloudflare.F(cfc.ResourceRecord.Comment),
+	}
+	if data := recordDataParam(cfc.ResourceRecord); data != nil {
+		body.Data = cloudflare.F(data)
+	}
 	return dns.RecordNewParams{
 		ZoneID: cloudflare.F(zoneID),
-		Body: dns.RecordNewParamsBody{
-			Name:     cloudflare.F(cfc.ResourceRecord.Name),
-			TTL:      cloudflare.F(cfc.ResourceRecord.TTL),
-			Proxied:  cloudflare.F(cfc.ResourceRecord.Proxied),
-			Type:     cloudflare.F(dns.RecordNewParamsBodyType(cfc.ResourceRecord.Type)),
-			Content:  cloudflare.F(cfc.ResourceRecord.Content),
-			Priority: cloudflare.F(cfc.ResourceRecord.Priority),
-			Comment:  cloudflare.F(cfc.ResourceRecord.Comment),
-		},
+		Body:   body,
 	}
 }
 
+// recordDataParam returns the data param of records which are created from their data rather than their content.
+func recordDataParam(record dns.RecordResponse) interface{} {
+	if data, ok := record.Data.(dns.SRVRecordData); ok {
+		return dns.SRVRecordDataParam{
+			Priority: cloudflare.F(data.Priority),
+			Weight:   cloudflare.F(data.Weight),
+			Port:     cloudflare.F(data.Port),
+			Target:   cloudflare.F(data.Target),
+		}
+	}
+	if data, ok := record.Data.(dns.HTTPSRecordData); ok {
+		return dns.HTTPSRecordDataParam{
+			Priority: cloudflare.F(data.Priority),
+			Target:   cloudflare.F(data.Target),
+			Value:    cloudflare.F(data.Value),
+		}
+	}
+	if data, ok := record.Data.(dns.TLSARecordData); ok {
+		return dns.TLSARecordDataParam{
+			Usage:        cloudflare.F(data.Usage),
+			Selector:     cloudflare.F(data.Selector),
+			MatchingType: cloudflare.F(data.MatchingType),
+			Certificate:  cloudflare.F(data.Certificate),
+		}
+	}
+	if data, ok := record.Data.(dns.SVCBRecordData); ok {
+		return dns.SVCBRecordDataParam{
+			Priority: cloudflare.F(data.Priority),
+			Target:   cloudflare.F(data.Target),
+			Value:    cloudflare.F(data.Value),
+		}
+	}
+	return nil
+}
+
+// recordContent returns the content of a record in the form used by external-dns. Cloudflare returns the
+// parameters of SVCB and HTTPS records quoted, e.g. `1 . alpn="h3,h2"`, which are compared without quotes,
+// and the certificate association data of TLSA records in any case.
+func recordContent(r dns.RecordResponse) string {
+	switch r.Type {
+	case dns.RecordResponseTypeSVCB, dns.RecordResponseTypeHTTPS:
+		if svcbRecord, err := endpoint.NewSVCBRecord(r.Content); err == nil {
+			return svcbRecord.String()
+		}
+	case dns.RecordResponseTypeTLSA:
+		if tlsaRecord, err := endpoint.NewTLSARecord(r.Content); err == nil {
+			return tlsaRecord.String()
+		}
+	}
+	return r.Content
+}
+
 func convertCloudflareError(err error) error {
 	var apiErr *cloudflarev0.Error
 	if errors.As(err, &apiErr) {
@@ -413,6 +478,19 @@ func (p *CloudFlareProvider) Zones(ctx context.Context) ([]zones.Zone, error) {
 	return result, nil
 }
 
+// ListZones returns the zones as provider zones.
+func (p *CloudFlareProvider) ListZones(ctx context.Context) ([]provider.Zone, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Zone, 0, len(zones))
+	for _, zone := range zones {
+		result = append(result, provider.Zone{ID: zone.ID, Name: zone.Name, Visibility: "public"})
+	}
+	return result, nil
+}
+
 // Records returns the list of records.
 func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.Zones(ctx)
@@ -420,32 +498,32 @@ func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 		return nil, err
 	}
 
-	var endpoints []*endpoint.Endpoint
-	for _, zone := range zones {
-		records, err := p.getDNSRecordsMap(ctx, zone.ID)
-		if err != nil {
-			return nil, err
-		}
+	return provider.ZoneRecords(ctx, zones, p.DNSRecordsConfig.ListConcurrency, p.zoneRecords)
+}
 
-		// nil if custom hostnames are not enabled
-		chs, chErr := p.listCustomHostnamesWithPagination(ctx, zone.ID)
-		if chErr != nil {
-			return nil, chErr
-		}
+// zoneRecords lists the records of a single zone, along with its custom hostnames.
+func (p *CloudFlareProvider) zoneRecords(ctx context.Context, zone zones.Zone) ([]*endpoint.Endpoint, error) {
+	records, err := p.getDNSRecordsMap(ctx, zone.ID)
+	if err != nil {
+		return nil, err
+	}
 
-		// As CloudFlare does not support "sets" of targets, but instead returns
-		// a single entry for each name/type/target, we have to group by name
-		// and record to allow the planner to calculate the correct plan. See #992.
-		zoneEndpoints := p.groupByNameAndTypeWithCustomHostnames(records, chs)
+	// nil if custom hostnames are not enabled
+	chs, chErr := p.listCustomHostnamesWithPagination(ctx, zone.ID)
+	if chErr != nil {
+		return nil, chErr
+	}
 
-		if err := p.addEnpointsProviderSpecificRegionKeyProperty(ctx, zone.ID, zoneEndpoints); err != nil {
-			return nil, err
-		}
+	// As CloudFlare does not support "sets" of targets, but instead returns
+	// a single entry for each name/type/target, we have to group by name
+	// and record to allow the planner to calculate the correct plan. See #992.
+	zoneEndpoints := p.groupByNameAndTypeWithCustomHostnames(records, chs)
 
-		endpoints = append(endpoints, zoneEndpoints...)
+	if err := p.addEnpointsProviderSpecificRegionKeyProperty(ctx, zone.ID, zoneEndpoints); err != nil {
+		return nil, err
 	}
 
-	return endpoints, nil
+	return zoneEndpoints, nil
 }
 
 // ApplyChanges applies a given set of changes in a given zone.
@@ -737,6 +815,14 @@ func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]
 		}
 
 		p.adjustEndpointProviderSpecificRegionKeyProperty(e)
+		// the description is published as the comment of the record, unless the comment is set explicitly
+		if _, ok := e.GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey); !ok && e.Description != "" {
+			e.SetProviderSpecificProperty(annotations.CloudflareRecordCommentKey, e.Description)
+		}
+		provider.DiscardDescription(e)
+		// weighted routing needs load balancer pools, which aren't managed
+		provider.DiscardWeights(e)
+		provider.DiscardGeo(e)
 
 		adjustedEndpoints = append(adjustedEndpoints, e)
 	}
@@ -766,7 +852,7 @@ func (p *CloudFlareProvider) changesByZone(zones []zones.Zone, changeSet []*clou
 }
 
 func (p *CloudFlareProvider) getRecordID(records DNSRecordsMap, record dns.RecordResponse) string {
-	if zoneRecord, ok := records[DNSRecordIndex{Name: record.Name, Type: string(record.Type), Content: record.Content}]; ok {
+	if zoneRecord, ok := records[newDNSRecordIndex(record)]; ok {
 		return zoneRecord.ID
 	}
 	return ""
@@ -831,6 +917,53 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 		}
 	}
 
+	// SRV records are created from their data, Cloudflare returns their content as "<weight> <port> <target>"
+	var data interface{}
+	if ep.RecordType == endpoint.RecordTypeSRV {
+		srvRecord, err := endpoint.NewSRVRecord(target)
+		if err != nil {
+			return &cloudFlareChange{}, fmt.Errorf("failed to parse SRV record target %q: %w", target, err)
+		}
+		priority = float64(*srvRecord.GetPriority())
+		target = fmt.Sprintf("%d %d %s", *srvRecord.GetWeight(), *srvRecord.GetPort(), *srvRecord.GetHost())
+		data = dns.SRVRecordData{
+			Priority: priority,
+			Weight:   float64(*srvRecord.GetWeight()),
+			Port:     float64(*srvRecord.GetPort()),
+			Target:   *srvRecord.GetHost(),
+		}
+	}
+
+	// SVCB and HTTPS records are created from their data as well, their content includes the priority
+	if ep.RecordType == endpoint.RecordTypeSVCB || ep.RecordType == endpoint.RecordTypeHTTPS {
+		svcbRecord, err := endpoint.NewSVCBRecord(target)
+		if err != nil {
+			return &cloudFlareChange{}, fmt.Errorf("failed to parse %s record target %q: %w", ep.RecordType, target, err)
+		}
+		target = svcbRecord.String()
+		svcbPriority := float64(*svcbRecord.GetPriority())
+		svcbValue := strings.Join(svcbRecord.GetParams(), " ")
+		if ep.RecordType == endpoint.RecordTypeHTTPS {
+			data = dns.HTTPSRecordData{Priority: svcbPriority, Target: *svcbRecord.GetTarget(), Value: svcbValue}
+		} else {
+			data = dns.SVCBRecordData{Priority: svcbPriority, Target: *svcbRecord.GetTarget(), Value: svcbValue}
+		}
+	}
+
+	if ep.RecordType == endpoint.RecordTypeTLSA {
+		tlsaRecord, err := endpoint.NewTLSARecord(target)
+		if err != nil {
+			return &cloudFlareChange{}, fmt.Errorf("failed to parse TLSA record target %q: %w", target, err)
+		}
+		target = tlsaRecord.String()
+		data = dns.TLSARecordData{
+			Usage:        float64(*tlsaRecord.GetUsage()),
+			Selector:     float64(*tlsaRecord.GetSelector()),
+			MatchingType: float64(*tlsaRecord.GetMatchingType()),
+			Certificate:  *tlsaRecord.GetData(),
+		}
+	}
+
 	return &cloudFlareChange{
 		Action: action,
 		ResourceRecord: dns.RecordResponse{
@@ -841,6 +974,7 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 			Content:  target,
 			Comment:  comment,
 			Priority: priority,
+			Data:     data,
 		},
 		RegionalHostname:    p.regionalHostname(ep),
 		CustomHostnamesPrev: prevCustomHostnames,
@@ -849,7 +983,7 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 }
 
 func newDNSRecordIndex(r dns.RecordResponse) DNSRecordIndex {
-	return DNSRecordIndex{Name: r.Name, Type: string(r.Type), Content: r.Content}
+	return DNSRecordIndex{Name: r.Name, Type: string(r.Type), Content: recordContent(r)}
 }
 
 // getDNSRecordsMap retrieves all DNS records for a given zone and returns them as a DNSRecordsMap.
@@ -979,10 +1113,10 @@ func (p *CloudFlareProvider) groupByNameAndTypeWithCustomHostnames(records DNSRe
 		}
 		targets := make([]string, len(records))
 		for i, record := range records {
-			if records[i].Type == "MX" {
+			if records[i].Type == "MX" || records[i].Type == "SRV" {
 				targets[i] = fmt.Sprintf("%v %v", record.Priority, record.Content)
 			} else {
-				targets[i] = record.Content
+				targets[i] = recordContent(record)
 			}
 		}
 		e := endpoint.NewEndpointWithTTL(
@@ -1013,7 +1147,7 @@ func (p *CloudFlareProvider) groupByNameAndTypeWithCustomHostnames(records DNSRe
 // SupportedRecordType returns true if the record type is supported by the provider
 func (p *CloudFlareProvider) SupportedAdditionalRecordTypes(recordType string) bool {
 	switch recordType {
-	case endpoint.RecordTypeMX:
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSVCB, endpoint.RecordTypeHTTPS, endpoint.RecordTypeTLSA:
 		return true
 	default:
 		return provider.SupportedRecordType(recordType)
@@ -743,6 +743,10 @@ func TestCloudflareSetProxied(t *testing.T) {
 			targets = endpoint.Targets{"10 mx.example.com"}
 			content = "mx.example.com"
 			priority = 10
+		} else if testCase.recordType == "SRV" {
+			targets = endpoint.Targets{"10 5 5060 sip.example.com"}
+			content = "5 5060 sip.example.com"
+			priority = 10
 		} else {
 			targets = endpoint.Targets{"127.0.0.1"}
 			content = "127.0.0.1"
@@ -770,7 +774,7 @@ func TestCloudflareSetProxied(t *testing.T) {
 			TTL:     1,
 			Proxied: testCase.proxiable,
 		}
-		if testCase.recordType == "MX" {
+		if testCase.recordType == "MX" || testCase.recordType == "SRV" {
 			recordData.Priority = priority
 		}
 		AssertActions(t, &CloudFlareProvider{}, endpoints, []MockAction{
@@ -1660,6 +1664,40 @@ func TestCloudflareComplexUpdate(t *testing.T) {
 	})
 }
 
+func TestCloudflareAdjustEndpointsDescription(t *testing.T) {
+	provider := &CloudFlareProvider{}
+	endpoints, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("described.bar.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithDescription("Managed by the payments team"),
+		endpoint.NewEndpoint("commented.bar.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithDescription("Managed by the payments team").
+			WithProviderSpecific(annotations.CloudflareRecordCommentKey, "Cloudflare comment"),
+	})
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+
+	comment, ok := endpoints[0].GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey)
+	assert.True(t, ok)
+	assert.Equal(t, "Managed by the payments team", comment)
+	assert.Empty(t, endpoints[0].Description)
+
+	comment, _ = endpoints[1].GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey)
+	assert.Equal(t, "Cloudflare comment", comment, "the comment annotation takes precedence")
+	assert.Empty(t, endpoints[1].Description)
+}
+
+func TestCloudflareAdjustEndpointsDropsWeights(t *testing.T) {
+	provider := &CloudFlareProvider{}
+	ep := endpoint.NewEndpoint("weighted.bar.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5", "1.2.3.6")
+	ep.Weights = map[string]int64{"1.2.3.4": 9, "1.2.3.5": 1, "1.2.3.6": 0}
+	endpoints, err := provider.AdjustEndpoints([]*endpoint.Endpoint{ep})
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	assert.Equal(t, endpoint.Targets{"1.2.3.4", "1.2.3.5"}, endpoints[0].Targets, "drained targets are left out")
+	assert.Nil(t, endpoints[0].Weights)
+}
+
 func TestCustomTTLWithEnabledProxyNotChanged(t *testing.T) {
 	client := NewMockCloudFlareClientWithRecords(map[string][]dns.RecordResponse{
 		"001": {
@@ -3340,6 +3378,68 @@ func TestGetUpdateDNSRecordParam(t *testing.T) {
 	assert.Equal(t, "test-comment", body.Comment.Value)
 }
 
+func TestGetCreateDNSRecordParamSRV(t *testing.T) {
+	change, err := (&CloudFlareProvider{}).newCloudFlareChange(cloudFlareCreate, endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "10 5 5060 sip.example.com"), "10 5 5060 sip.example.com", nil)
+	require.NoError(t, err)
+
+	params := getCreateDNSRecordParam("zone-123", change)
+	body := params.Body.(dns.RecordNewParamsBody)
+
+	assert.Equal(t, "5 5060 sip.example.com", body.Content.Value)
+	assert.InDelta(t, 10, body.Priority.Value, 0)
+	assert.Equal(t, dns.SRVRecordDataParam{
+		Priority: cloudflare.F(10.0),
+		Weight:   cloudflare.F(5.0),
+		Port:     cloudflare.F(5060.0),
+		Target:   cloudflare.F("sip.example.com"),
+	}, body.Data.Value)
+
+	_, err = (&CloudFlareProvider{}).newCloudFlareChange(cloudFlareCreate, endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "5060 sip.example.com"), "5060 sip.example.com", nil)
+	assert.Error(t, err)
+}
+
+func TestGetCreateDNSRecordParamHTTPS(t *testing.T) {
+	change, err := (&CloudFlareProvider{}).newCloudFlareChange(cloudFlareCreate, endpoint.NewEndpoint("example.com", endpoint.RecordTypeHTTPS, `1 . alpn="h3,h2"`), `1 . alpn="h3,h2"`, nil)
+	require.NoError(t, err)
+
+	params := getCreateDNSRecordParam("zone-123", change)
+	body := params.Body.(dns.RecordNewParamsBody)
+
+	assert.Equal(t, "1 . alpn=h3,h2", body.Content.Value)
+	assert.Equal(t, dns.HTTPSRecordDataParam{
+		Priority: cloudflare.F(1.0),
+		Target:   cloudflare.F("."),
+		Value:    cloudflare.F("alpn=h3,h2"),
+	}, body.Data.Value)
+
+	// the content returned by Cloudflare quotes the parameters
+	index := newDNSRecordIndex(dns.RecordResponse{Name: "example.com", Type: dns.RecordResponseTypeHTTPS, Content: `1 . alpn="h3,h2"`})
+	assert.Equal(t, newDNSRecordIndex(change.ResourceRecord), index)
+
+	_, err = (&CloudFlareProvider{}).newCloudFlareChange(cloudFlareCreate, endpoint.NewEndpoint("example.com", endpoint.RecordTypeSVCB, "0 . alpn=h2"), "0 . alpn=h2", nil)
+	assert.Error(t, err)
+}
+
+func TestGetCreateDNSRecordParamTLSA(t *testing.T) {
+	digest := "8cb0fc6c527506a053f4f14c8464bebbd6dede2738d11468dd953d7d6a3021f1"
+	change, err := (&CloudFlareProvider{}).newCloudFlareChange(cloudFlareCreate, endpoint.NewEndpoint("_443._tcp.example.com", endpoint.RecordTypeTLSA, "3 1 1 "+digest), "3 1 1 "+digest, nil)
+	require.NoError(t, err)
+
+	params := getCreateDNSRecordParam("zone-123", change)
+	body := params.Body.(dns.RecordNewParamsBody)
+
+	assert.Equal(t, "3 1 1 "+digest, body.Content.Value)
+	assert.Equal(t, dns.TLSARecordDataParam{
+		Usage:        cloudflare.F(3.0),
+		Selector:     cloudflare.F(1.0),
+		MatchingType: cloudflare.F(1.0),
+		Certificate:  cloudflare.F(digest),
+	}, body.Data.Value)
+
+	index := newDNSRecordIndex(dns.RecordResponse{Name: "_443._tcp.example.com", Type: dns.RecordResponseTypeTLSA, Content: "3 1 1 " + strings.ToUpper(digest)})
+	assert.Equal(t, newDNSRecordIndex(change.ResourceRecord), index)
+}
+
 func TestZoneService(t *testing.T) {
 	t.Parallel()
 
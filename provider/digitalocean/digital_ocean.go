@@ -116,6 +116,19 @@ func (p *DigitalOceanProvider) Zones(ctx context.Context) ([]godo.Domain, error)
 	return result, nil
 }
 
+// ListZones returns the domains as provider zones, identified by their name.
+func (p *DigitalOceanProvider) ListZones(ctx context.Context) ([]provider.Zone, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Zone, 0, len(zones))
+	for _, zone := range zones {
+		result = append(result, provider.Zone{ID: zone.Name, Name: zone.Name, Visibility: "public"})
+	}
+	return result, nil
+}
+
 // Merge Endpoints with the same Name and Type into a single endpoint with multiple Targets.
 func mergeEndpointsByNameType(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
 	endpointsByNameType := map[string][]*endpoint.Endpoint{}
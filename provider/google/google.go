@@ -19,7 +19,10 @@ package google
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/compute/metadata"
@@ -57,8 +60,13 @@ type resourceRecordSetsListCallInterface interface {
 	Pages(ctx context.Context, f func(*dns.ResourceRecordSetsListResponse) error) error
 }
 
+type resourceRecordSetsGetCallInterface interface {
+	Do(opts ...googleapi.CallOption) (*dns.ResourceRecordSet, error)
+}
+
 type resourceRecordSetsClientInterface interface {
 	List(project string, managedZone string) resourceRecordSetsListCallInterface
+	Get(project string, managedZone string, name string, recordType string) resourceRecordSetsGetCallInterface
 }
 
 type changesCreateCallInterface interface {
@@ -77,6 +85,10 @@ func (r resourceRecordSetsService) List(project string, managedZone string) reso
 	return r.service.List(project, managedZone)
 }
 
+func (r resourceRecordSetsService) Get(project string, managedZone string, name string, recordType string) resourceRecordSetsGetCallInterface {
+	return r.service.Get(project, managedZone, name, recordType)
+}
+
 type managedZonesService struct {
 	service *dns.ManagedZonesService
 }
@@ -120,12 +132,16 @@ type GoogleProvider struct {
 	managedZonesClient managedZonesServiceInterface
 	// A client for managing change sets
 	changesClient changesServiceInterface
+	// number of managed zones whose records are listed at once
+	zoneListConcurrency int
+	// record sets last listed from each managed zone, by SOA record, to skip listing unchanged zones
+	zoneRecordSets *provider.ZoneChangeTokens[[]*dns.ResourceRecordSet]
 	// The context parameter to be passed for gcloud API calls.
 	ctx context.Context
 }
 
 // NewGoogleProvider initializes a new Google CloudDNS based Provider.
-func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool) (*GoogleProvider, error) {
+func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, zoneListConcurrency int, zoneChangeTokens bool, dryRun bool) (*GoogleProvider, error) {
 	gcloud, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, err
@@ -149,6 +165,11 @@ func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoi
 
 	zoneTypeFilter := provider.NewZoneTypeFilter(zoneVisibility)
 
+	var zoneRecordSets *provider.ZoneChangeTokens[[]*dns.ResourceRecordSet]
+	if zoneChangeTokens {
+		zoneRecordSets = provider.NewZoneChangeTokens[[]*dns.ResourceRecordSet]()
+	}
+
 	return &GoogleProvider{
 		project:                  project,
 		dryRun:                   dryRun,
@@ -160,6 +181,8 @@ func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoi
 		resourceRecordSetsClient: resourceRecordSetsService{dnsClient.ResourceRecordSets},
 		managedZonesClient:       managedZonesService{dnsClient.ManagedZones},
 		changesClient:            changesService{dnsClient.Changes},
+		zoneListConcurrency:      zoneListConcurrency,
+		zoneRecordSets:           zoneRecordSets,
 		ctx:                      ctx,
 	}, nil
 }
@@ -201,6 +224,19 @@ func (p *GoogleProvider) Zones(ctx context.Context) (map[string]*dns.ManagedZone
 	return zones, nil
 }
 
+// ListZones returns the managed zones as provider zones, identified by their name.
+func (p *GoogleProvider) ListZones(ctx context.Context) ([]provider.Zone, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Zone, 0, len(zones))
+	for _, zone := range zones {
+		result = append(result, provider.Zone{ID: zone.Name, Name: strings.TrimSuffix(zone.DnsName, "."), Visibility: zone.Visibility})
+	}
+	return result, nil
+}
+
 // Records returns the list of records in all relevant zones.
 func (p *GoogleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.Zones(ctx)
@@ -208,26 +244,99 @@ func (p *GoogleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 		return nil, err
 	}
 
-	endpoints := make([]*endpoint.Endpoint, 0)
+	return provider.ZoneRecords(ctx, slices.Collect(maps.Values(zones)), p.zoneListConcurrency, p.zoneRecords)
+}
 
+// StreamRecords calls fn with the records of each managed zone in turn, page after page.
+func (p *GoogleProvider) StreamRecords(ctx context.Context, fn func(*endpoint.Endpoint) error) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, z := range zones {
+		if err := p.streamZoneRecords(ctx, z, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zoneRecords lists the records of a single managed zone, unless the zone is unchanged since it was last listed.
+func (p *GoogleProvider) zoneRecords(ctx context.Context, z *dns.ManagedZone) ([]*endpoint.Endpoint, error) {
+	rrsets, err := p.zoneRecordSets.Records(z.Name, p.zoneChangeToken(z), func() ([]*dns.ResourceRecordSet, error) {
+		rrsets := make([]*dns.ResourceRecordSet, 0)
+		err := p.streamZoneRecordSets(ctx, z, func(r *dns.ResourceRecordSet) error {
+			rrsets = append(rrsets, r)
+			return nil
+		})
+		return rrsets, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*endpoint.Endpoint, 0, len(rrsets))
+	for _, r := range rrsets {
+		endpoints = append(endpoints, newEndpoint(r))
+	}
+	return endpoints, nil
+}
+
+// zoneChangeToken returns the SOA record of the managed zone, whose serial Cloud DNS increments with every change,
+// when the record sets of unchanged zones are kept between listings, or an empty token to list the zone anyway.
+func (p *GoogleProvider) zoneChangeToken(z *dns.ManagedZone) string {
+	if p.zoneRecordSets == nil {
+		return ""
+	}
+
+	soa, err := p.resourceRecordSetsClient.Get(p.project, z.Name, z.DnsName, "SOA").Do()
+	if err != nil {
+		log.Warnf("Failed to get the SOA record of zone %s, listing it anyway: %v", z.Name, err)
+		return ""
+	}
+	return strings.Join(soa.Rrdatas, " ")
+}
+
+// streamZoneRecords calls fn with each record of a single managed zone.
+func (p *GoogleProvider) streamZoneRecords(ctx context.Context, z *dns.ManagedZone, fn func(*endpoint.Endpoint) error) error {
+	return p.streamZoneRecordSets(ctx, z, func(r *dns.ResourceRecordSet) error {
+		return fn(newEndpoint(r))
+	})
+}
+
+// streamZoneRecordSets calls fn with each record set of a supported type in a single managed zone.
+func (p *GoogleProvider) streamZoneRecordSets(ctx context.Context, z *dns.ManagedZone, fn func(*dns.ResourceRecordSet) error) error {
+	var fnErr error
 	f := func(resp *dns.ResourceRecordSetsListResponse) error {
 		for _, r := range resp.Rrsets {
 			if !p.SupportedRecordType(r.Type) {
 				continue
 			}
-			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.Ttl), r.Rrdatas...))
+			if fnErr = fn(r); fnErr != nil {
+				return fnErr
+			}
 		}
 
 		return nil
 	}
 
-	for _, z := range zones {
-		if err := p.resourceRecordSetsClient.List(p.project, z.Name).Pages(ctx, f); err != nil {
-			return nil, provider.NewSoftErrorf("failed to list records in zone %s: %v", z.Name, err)
+	if err := p.resourceRecordSetsClient.List(p.project, z.Name).Pages(ctx, f); err != nil {
+		if fnErr != nil {
+			return fnErr
 		}
+		return provider.NewSoftErrorf("failed to list records in zone %s: %v", z.Name, err)
 	}
 
-	return endpoints, nil
+	return nil
+}
+
+// newEndpoint converts a record set to an endpoint.
+func newEndpoint(r *dns.ResourceRecordSet) *endpoint.Endpoint {
+	if r.RoutingPolicy != nil && r.RoutingPolicy.Wrr != nil {
+		return newWeightedEndpoint(r)
+	}
+	return endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.Ttl), r.Rrdatas...)
 }
 
 // ApplyChanges applies a given set of changes in a given zone.
@@ -244,10 +353,20 @@ func (p *GoogleProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 	return p.submitChange(ctx, change)
 }
 
+// AdjustEndpoints keeps the target weights of the endpoints, which are published with a weighted round robin policy,
+// and discards their geo locations, as a record set can only have one routing policy, as well as their descriptions.
+func (p *GoogleProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		provider.DiscardGeo(ep)
+		provider.DiscardDescription(ep)
+	}
+	return endpoints, nil
+}
+
 // SupportedRecordType returns true if the record type is supported by the provider
 func (p *GoogleProvider) SupportedRecordType(recordType string) bool {
 	switch recordType {
-	case "MX":
+	case "MX", endpoint.RecordTypeSVCB, endpoint.RecordTypeHTTPS, endpoint.RecordTypeTLSA:
 		return true
 	default:
 		return provider.SupportedRecordType(recordType)
@@ -446,16 +565,77 @@ func newRecord(ep *endpoint.Endpoint) *dns.ResourceRecordSet {
 		}
 	}
 
+	if ep.RecordType == endpoint.RecordTypeTXT {
+		for i, txtRecord := range ep.Targets {
+			targets[i] = endpoint.SplitLongTXT(txtRecord)
+		}
+	}
+
+	if ep.RecordType == endpoint.RecordTypeSVCB || ep.RecordType == endpoint.RecordTypeHTTPS {
+		for i, svcbRecord := range ep.Targets {
+			if svcb, err := endpoint.NewSVCBRecord(svcbRecord); err == nil {
+				*svcb.GetTarget() = provider.EnsureTrailingDot(*svcb.GetTarget())
+				targets[i] = svcb.String()
+			}
+		}
+	}
+
 	// no annotation results in a Ttl of 0, default to 300 for backwards-compatibility
 	var ttl int64 = defaultTTL
 	if ep.RecordTTL.IsConfigured() {
 		ttl = int64(ep.RecordTTL)
 	}
 
-	return &dns.ResourceRecordSet{
+	record := &dns.ResourceRecordSet{
 		Name:    provider.EnsureTrailingDot(ep.DNSName),
 		Rrdatas: targets,
 		Ttl:     ttl,
 		Type:    ep.RecordType,
 	}
+	if ep.IsWeighted() {
+		record.RoutingPolicy = newWeightedRoutingPolicy(ep, targets)
+		record.Rrdatas = nil
+	}
+	return record
+}
+
+// newWeightedRoutingPolicy returns a weighted round robin policy with an item for each weight of the
+// endpoint's targets, whose given Cloud DNS representations are in the same order.
+func newWeightedRoutingPolicy(ep *endpoint.Endpoint, targets []string) *dns.RRSetRoutingPolicy {
+	itemsByWeight := map[int64]*dns.RRSetRoutingPolicyWrrPolicyWrrPolicyItem{}
+	for i, target := range ep.Targets {
+		weight := ep.TargetWeight(target)
+		item, ok := itemsByWeight[weight]
+		if !ok {
+			// a weight of 0 is omitted from the request unless forced
+			item = &dns.RRSetRoutingPolicyWrrPolicyWrrPolicyItem{Weight: float64(weight), ForceSendFields: []string{"Weight"}}
+			itemsByWeight[weight] = item
+		}
+		item.Rrdatas = append(item.Rrdatas, targets[i])
+	}
+	weights := slices.Sorted(maps.Keys(itemsByWeight))
+	policy := &dns.RRSetRoutingPolicyWrrPolicy{}
+	for _, weight := range weights {
+		policy.Items = append(policy.Items, itemsByWeight[weight])
+	}
+	return &dns.RRSetRoutingPolicy{Wrr: policy}
+}
+
+// newWeightedEndpoint returns the endpoint of a record set with a weighted round robin policy, whose
+// targets are weighted by their items.
+func newWeightedEndpoint(r *dns.ResourceRecordSet) *endpoint.Endpoint {
+	var targets []string
+	var weights []int64
+	for _, item := range r.RoutingPolicy.Wrr.Items {
+		for _, target := range item.Rrdatas {
+			targets = append(targets, target)
+			weights = append(weights, int64(item.Weight))
+		}
+	}
+	ep := endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.Ttl), targets...)
+	ep.Weights = make(map[string]int64, len(ep.Targets))
+	for i, target := range ep.Targets {
+		ep.Weights[target] = weights[i]
+	}
+	return ep
 }
@@ -39,6 +39,7 @@ import (
 var (
 	testZones                    = map[string]*dns.ManagedZone{}
 	testRecords                  = map[string]map[string]*dns.ResourceRecordSet{}
+	testSerials                  = map[string]int{}
 	googleDefaultBatchChangeSize = 4000
 )
 
@@ -118,14 +119,41 @@ func (m *mockResourceRecordSetsListCall) Pages(ctx context.Context, f func(*dns.
 	return f(&dns.ResourceRecordSetsListResponse{Rrsets: resp})
 }
 
+type mockResourceRecordSetsGetCall struct {
+	project     string
+	managedZone string
+	name        string
+	recordType  string
+}
+
+func (m *mockResourceRecordSetsGetCall) Do(opts ...googleapi.CallOption) (*dns.ResourceRecordSet, error) {
+	zoneKey := zoneKey(m.project, m.managedZone)
+
+	if _, ok := testZones[zoneKey]; !ok || m.recordType != "SOA" {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	return &dns.ResourceRecordSet{
+		Name:    m.name,
+		Type:    m.recordType,
+		Rrdatas: []string{fmt.Sprintf("ns-cloud-a1.googledomains.com. cloud-dns-hostmaster.google.com. %d 21600 3600 259200 300", testSerials[zoneKey])},
+	}, nil
+}
+
 type mockResourceRecordSetsClient struct {
 	recordsErr error
+	lists      int
 }
 
 func (m *mockResourceRecordSetsClient) List(project string, managedZone string) resourceRecordSetsListCallInterface {
+	m.lists++
 	return &mockResourceRecordSetsListCall{project: project, managedZone: managedZone, recordsListSoftErr: m.recordsErr}
 }
 
+func (m *mockResourceRecordSetsClient) Get(project string, managedZone string, name string, recordType string) resourceRecordSetsGetCallInterface {
+	return &mockResourceRecordSetsGetCall{project: project, managedZone: managedZone, name: name, recordType: recordType}
+}
+
 type mockChangesCreateCall struct {
 	project     string
 	managedZone string
@@ -162,6 +190,8 @@ func (m *mockChangesCreateCall) Do(opts ...googleapi.CallOption) (*dns.Change, e
 		testRecords[zoneKey][recordKey] = add
 	}
 
+	testSerials[zoneKey]++
+
 	return m.change, nil
 }
 
@@ -278,6 +308,51 @@ func TestGoogleRecords(t *testing.T) {
 	validateEndpoints(t, records, originalEndpoints)
 }
 
+func TestGoogleRecordsWeighted(t *testing.T) {
+	weighted := endpoint.NewEndpointWithTTL("weighted.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(60), "1.2.3.4", "5.6.7.8", "8.8.8.8")
+	weighted.Weights = map[string]int64{"1.2.3.4": 3, "5.6.7.8": 0, "8.8.8.8": 1}
+	originalEndpoints := []*endpoint.Endpoint{weighted}
+
+	provider := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{""}), false, originalEndpoints, nil, nil)
+
+	records, err := provider.Records(context.Background())
+	require.NoError(t, err)
+
+	validateEndpoints(t, records, originalEndpoints)
+}
+
+func TestGoogleRecordsZoneChangeTokens(t *testing.T) {
+	originalEndpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("list-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(1), "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("list-test.zone-2.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(2), "8.8.8.8"),
+	}
+
+	p := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{""}), false, originalEndpoints, nil, nil)
+	p.zoneRecordSets = provider.NewZoneChangeTokens[[]*dns.ResourceRecordSet]()
+	client := p.resourceRecordSetsClient.(*mockResourceRecordSetsClient)
+	client.lists = 0
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, records, originalEndpoints)
+	assert.Equal(t, 3, client.lists)
+
+	// unchanged zones aren't listed again
+	records, err = p.Records(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, records, originalEndpoints)
+	assert.Equal(t, 3, client.lists)
+
+	// only the changed zone is
+	created := endpoint.NewEndpointWithTTL("create-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.4.4")
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{created}}))
+
+	records, err = p.Records(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, records, append(originalEndpoints, created))
+	assert.Equal(t, 4, client.lists)
+}
+
 func TestGoogleRecordsFilter(t *testing.T) {
 	originalEndpoints := []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("update-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
@@ -470,9 +545,11 @@ func TestNewFilteredRecords(t *testing.T) {
 		// test fallback to Ttl:300 when Ttl==0 :
 		endpoint.NewEndpointWithTTL("update-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, 0, "8.8.8.8"),
 		endpoint.NewEndpointWithTTL("update-test-mx.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeMX, 6000, "10 mail.elb.amazonaws.com"),
+		endpoint.NewEndpointWithTTL("update-test-https.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeHTTPS, 60, "1 . alpn=h3,h2", "2 backup.elb.amazonaws.com alpn=h2"),
 		endpoint.NewEndpoint("delete-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
 		endpoint.NewEndpoint("delete-test-cname.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeCNAME, "qux.elb.amazonaws.com"),
 		endpoint.NewEndpoint("delete-test-ns.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeNS, "foo.elb.amazonaws.com"),
+		endpoint.NewEndpoint("weighted-test-cname.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeCNAME, "bar.elb.amazonaws.com").WithWeight(0),
 	})
 
 	validateChangeRecords(t, records, []*dns.ResourceRecordSet{
@@ -482,9 +559,13 @@ func TestNewFilteredRecords(t *testing.T) {
 		{Name: "update-test-ns.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"foo.elb.amazonaws.com."}, Type: "NS", Ttl: 120},
 		{Name: "update-test.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"8.8.8.8"}, Type: "A", Ttl: 300},
 		{Name: "update-test-mx.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"10 mail.elb.amazonaws.com."}, Type: "MX", Ttl: 6000},
+		{Name: "update-test-https.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"1 . alpn=h3,h2", "2 backup.elb.amazonaws.com. alpn=h2"}, Type: "HTTPS", Ttl: 60},
 		{Name: "delete-test.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"8.8.8.8"}, Type: "A", Ttl: 300},
 		{Name: "delete-test-cname.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"qux.elb.amazonaws.com."}, Type: "CNAME", Ttl: 300},
 		{Name: "delete-test-ns.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"foo.elb.amazonaws.com."}, Type: "NS", Ttl: 300},
+		{Name: "weighted-test-cname.zone-1.ext-dns-test-2.gcp.zalan.do.", Type: "CNAME", Ttl: 300, RoutingPolicy: &dns.RRSetRoutingPolicy{Wrr: &dns.RRSetRoutingPolicyWrrPolicy{Items: []*dns.RRSetRoutingPolicyWrrPolicyWrrPolicyItem{
+			{Weight: 0, Rrdatas: []string{"bar.elb.amazonaws.com."}, ForceSendFields: []string{"Weight"}},
+		}}}},
 	})
 }
 
@@ -675,6 +756,7 @@ func validateChangeRecord(t *testing.T, record *dns.ResourceRecordSet, expected
 	assert.Equal(t, expected.Rrdatas, record.Rrdatas)
 	assert.Equal(t, expected.Ttl, record.Ttl)
 	assert.Equal(t, expected.Type, record.Type)
+	assert.Equal(t, expected.RoutingPolicy, record.RoutingPolicy)
 }
 
 func newGoogleProviderZoneOverlap(t *testing.T, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, zoneTypeFilter provider.ZoneTypeFilter, dryRun bool, _ []*endpoint.Endpoint) *GoogleProvider {
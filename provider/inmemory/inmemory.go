@@ -121,6 +121,17 @@ func (im *InMemoryProvider) Zones() map[string]string {
 	return im.filter.Zones(im.client.Zones())
 }
 
+// ListZones returns the filtered zones as provider zones.
+func (im *InMemoryProvider) ListZones(_ context.Context) ([]provider.Zone, error) {
+	zones := im.Zones()
+	result := make([]provider.Zone, 0, len(zones))
+	for id, name := range zones {
+		records := int64(len(im.client.zones[id]))
+		result = append(result, provider.Zone{ID: id, Name: name, Records: &records})
+	}
+	return result, nil
+}
+
 // Records returns the list of endpoints
 func (im *InMemoryProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	defer im.OnRecords()
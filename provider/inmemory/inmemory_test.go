@@ -36,6 +36,7 @@ func TestInMemoryProvider(t *testing.T) {
 	t.Run("ApplyChanges", testInMemoryApplyChanges)
 	t.Run("NewInMemoryProvider", testNewInMemoryProvider)
 	t.Run("CreateZone", testInMemoryCreateZone)
+	t.Run("ListZones", testInMemoryListZones)
 }
 
 func testInMemoryRecords(t *testing.T) {
@@ -573,6 +574,18 @@ func testInMemoryCreateZone(t *testing.T) {
 	require.EqualError(t, err, ErrZoneAlreadyExists.Error())
 }
 
+func testInMemoryListZones(t *testing.T) {
+	im := NewInMemoryProvider(InMemoryInitZones([]string{"org", "com"}))
+	require.NoError(t, im.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "8.8.8.8")},
+	}))
+
+	zones, err := provider.ListZones(context.Background(), im)
+	require.NoError(t, err)
+	none, one := int64(0), int64(1)
+	assert.Equal(t, []provider.Zone{{ID: "com", Name: "com", Records: &none}, {ID: "org", Name: "org", Records: &one}}, zones)
+}
+
 func makeZone(s ...string) map[endpoint.EndpointKey]*endpoint.Endpoint {
 	if len(s)%3 != 0 {
 		panic("makeZone arguments must be multiple of 3")
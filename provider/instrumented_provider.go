@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/tracing"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var (
+	providerRequestsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "provider",
+			Name:      "requests_total",
+			Help:      "Number of calls to the provider, by provider and operation (vector).",
+		},
+		[]string{"provider", "operation"},
+	)
+	providerRequestErrorsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "provider",
+			Name:      "request_errors_total",
+			Help:      "Number of failed calls to the provider, by provider, operation and error code (vector).",
+		},
+		[]string{"provider", "operation", "code"},
+	)
+	providerRequestDuration = metrics.NewHistogramVecWithOpts(
+		prometheus.HistogramOpts{
+			Subsystem: "provider",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the calls to the provider in seconds, by provider and operation (vector).",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		},
+		[]string{"provider", "operation"},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(providerRequestsTotal)
+	metrics.RegisterMetric.MustRegister(providerRequestErrorsTotal)
+	metrics.RegisterMetric.MustRegister(providerRequestDuration)
+}
+
+// InstrumentedProvider records the number, errors and duration of the Records, StreamRecords and ApplyChanges
+// calls to the wrapped provider, labeled with the provider name and the operation. Zones are listed by providers
+// within these calls, so their latency is included.
+type InstrumentedProvider struct {
+	Provider
+	name string
+}
+
+// NewInstrumentedProvider returns an InstrumentedProvider wrapping the given provider.
+func NewInstrumentedProvider(provider Provider, name string) *InstrumentedProvider {
+	return &InstrumentedProvider{Provider: provider, name: name}
+}
+
+func (p *InstrumentedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	ctx, span := tracing.Start(ctx, "provider.Records", attribute.String("provider", p.name))
+	start := time.Now()
+	records, err := p.Provider.Records(ctx)
+	p.observe("Records", start, err)
+	tracing.End(span, err)
+	return records, err
+}
+
+// StreamRecords streams the records of the wrapped provider, which returns them all first if it can't stream them.
+func (p *InstrumentedProvider) StreamRecords(ctx context.Context, fn func(*endpoint.Endpoint) error) error {
+	ctx, span := tracing.Start(ctx, "provider.StreamRecords", attribute.String("provider", p.name))
+	start := time.Now()
+	err := StreamRecords(ctx, p.Provider, fn)
+	p.observe("StreamRecords", start, err)
+	tracing.End(span, err)
+	return err
+}
+
+func (p *InstrumentedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	ctx, span := tracing.Start(ctx, "provider.ApplyChanges", attribute.String("provider", p.name))
+	start := time.Now()
+	err := p.Provider.ApplyChanges(ctx, changes)
+	p.observe("ApplyChanges", start, err)
+	tracing.End(span, err)
+	return err
+}
+
+func (p *InstrumentedProvider) observe(operation string, start time.Time, err error) {
+	providerRequestsTotal.CounterVec.WithLabelValues(p.name, operation).Inc()
+	providerRequestDuration.HistogramVec.WithLabelValues(p.name, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		providerRequestErrorsTotal.CounterVec.WithLabelValues(p.name, operation, errorCode(err)).Inc()
+	}
+}
+
+// errorCode returns the HTTP status code of the error if the provider SDK exposes one, or a generic code.
+func errorCode(err error) string {
+	var httpErr interface{ HTTPStatusCode() int }
+	var statusErr interface{ StatusCode() int }
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &httpErr) && httpErr.HTTPStatusCode() > 0:
+		return strconv.Itoa(httpErr.HTTPStatusCode())
+	case errors.As(err, &statusErr) && statusErr.StatusCode() > 0:
+		return strconv.Itoa(statusErr.StatusCode())
+	case errors.Is(err, SoftError):
+		return "soft"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type statusCodeError int
+
+func (e statusCodeError) Error() string {
+	return fmt.Sprintf("status %d", int(e))
+}
+
+func (e statusCodeError) HTTPStatusCode() int {
+	return int(e)
+}
+
+func TestInstrumentedProvider(t *testing.T) {
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(_ context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1")}, nil
+	}
+	testProvider.applyChanges = func(_ context.Context, _ *plan.Changes) error {
+		return NewSoftError(fmt.Errorf("throttled: %w", statusCodeError(429)))
+	}
+	p := NewInstrumentedProvider(testProvider, "instrumented-test")
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	require.ErrorIs(t, p.ApplyChanges(context.Background(), &plan.Changes{}), SoftError)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(providerRequestsTotal.CounterVec.WithLabelValues("instrumented-test", "Records")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(providerRequestsTotal.CounterVec.WithLabelValues("instrumented-test", "ApplyChanges")), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(providerRequestErrorsTotal.CounterVec.WithLabelValues("instrumented-test", "Records", "unknown")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(providerRequestErrorsTotal.CounterVec.WithLabelValues("instrumented-test", "ApplyChanges", "429")), 0)
+	assert.Equal(t, 2, testutil.CollectAndCount(providerRequestDuration.HistogramVec, "external_dns_provider_request_duration_seconds"))
+}
+
+func TestErrorCode(t *testing.T) {
+	for _, tc := range []struct {
+		err      error
+		expected string
+	}{
+		{err: errors.New("failure"), expected: "unknown"},
+		{err: NewSoftErrorf("failure"), expected: "soft"},
+		{err: fmt.Errorf("listing zones: %w", statusCodeError(403)), expected: "403"},
+		{err: fmt.Errorf("listing zones: %w", context.DeadlineExceeded), expected: "timeout"},
+		{err: context.Canceled, expected: "canceled"},
+	} {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tc.expected, errorCode(tc.err))
+		})
+	}
+}
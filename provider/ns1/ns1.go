@@ -26,7 +26,9 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
@@ -50,6 +52,7 @@ type NS1DomainClient interface {
 	DeleteRecord(zone string, domain string, t string) (*http.Response, error)
 	UpdateRecord(r *dns.Record) (*http.Response, error)
 	GetZone(zone string) (*dns.Zone, *http.Response, error)
+	GetRecord(zone string, domain string, t string) (*dns.Record, *http.Response, error)
 	ListZones() ([]*dns.Zone, *http.Response, error)
 }
 
@@ -78,6 +81,11 @@ func (n NS1DomainService) GetZone(zone string) (*dns.Zone, *http.Response, error
 	return n.service.Zones.Get(zone, true)
 }
 
+// GetRecord wraps the Get method of the API's Record service
+func (n NS1DomainService) GetRecord(zone string, domain string, t string) (*dns.Record, *http.Response, error) {
+	return n.service.Records.Get(zone, domain, t)
+}
+
 // ListZones wraps the List method of the API's Zones service
 func (n NS1DomainService) ListZones() ([]*dns.Zone, *http.Response, error) {
 	return n.service.Zones.List()
@@ -161,26 +169,55 @@ func (p *NS1Provider) Records(ctx context.Context) ([]*endpoint.Endpoint, error)
 		}
 
 		for _, record := range zoneData.Records {
-			if provider.SupportedRecordType(record.Type) {
-				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(
-					record.Domain,
-					record.Type,
-					endpoint.TTL(record.TTL),
-					record.ShortAns...,
-				),
-				)
+			if !provider.SupportedRecordType(record.Type) {
+				continue
+			}
+			// records beyond the basic tier may have weighted answers, whose metadata the zone doesn't list
+			if tier, _ := record.Tier.Int64(); tier > 1 {
+				fullRecord, _, err := p.client.GetRecord(zone.Zone, record.Domain, record.Type)
+				if err != nil {
+					return nil, err
+				}
+				endpoints = append(endpoints, ns1RecordEndpoint(fullRecord))
+				continue
 			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(
+				record.Domain,
+				record.Type,
+				endpoint.TTL(record.TTL),
+				record.ShortAns...,
+			),
+			)
 		}
 	}
 
 	return endpoints, nil
 }
 
+// AdjustEndpoints keeps the target weights of the endpoints, which are published as weighted answers,
+// and discards their geo locations, as the answers of a record can't come from different endpoints, as well as their
+// descriptions.
+func (p *NS1Provider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		provider.DiscardGeo(ep)
+		provider.DiscardDescription(ep)
+	}
+	return endpoints, nil
+}
+
 // ns1BuildRecord returns a dns.Record for a change set
 func (p *NS1Provider) ns1BuildRecord(zoneName string, change *ns1Change) *dns.Record {
 	record := dns.NewRecord(zoneName, change.Endpoint.DNSName, change.Endpoint.RecordType, map[string]string{}, []string{})
 	for _, v := range change.Endpoint.Targets {
-		record.AddAnswer(dns.NewAnswer(strings.Split(v, " ")))
+		answer := dns.NewAnswer(strings.Split(v, " "))
+		if change.Endpoint.IsWeighted() {
+			answer.Meta = &data.Meta{Weight: float64(change.Endpoint.TargetWeight(v))}
+		}
+		record.AddAnswer(answer)
+	}
+	if change.Endpoint.IsWeighted() {
+		// answer a single target, picked randomly according to the weights
+		record.Filters = []*filter.Filter{filter.NewWeightedShuffle(), filter.NewSelFirstN(1)}
 	}
 	// set default ttl, but respect minTTLSeconds
 	ttl := defaultTTL
@@ -195,6 +232,34 @@ func (p *NS1Provider) ns1BuildRecord(zoneName string, change *ns1Change) *dns.Re
 	return record
 }
 
+// ns1RecordEndpoint returns the endpoint of a record, whose targets are weighted if any of its answers is.
+func ns1RecordEndpoint(record *dns.Record) *endpoint.Endpoint {
+	targets := make([]string, 0, len(record.Answers))
+	for _, answer := range record.Answers {
+		targets = append(targets, strings.Join(answer.Rdata, " "))
+	}
+	ep := endpoint.NewEndpointWithTTL(record.Domain, record.Type, endpoint.TTL(record.TTL), targets...)
+	for i, answer := range record.Answers {
+		if answer.Meta == nil {
+			continue
+		}
+		var weight int64
+		switch w := answer.Meta.Weight.(type) {
+		case float64:
+			weight = int64(w)
+		case int:
+			weight = int64(w)
+		default:
+			continue
+		}
+		if ep.Weights == nil {
+			ep.Weights = map[string]int64{}
+		}
+		ep.Weights[ep.Targets[i]] = weight
+	}
+	return ep
+}
+
 // ns1SubmitChanges takes an array of changes and sends them to NS1
 func (p *NS1Provider) ns1SubmitChanges(changes []*ns1Change) error {
 	// return early if there is nothing to change
@@ -27,7 +27,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
@@ -58,9 +60,17 @@ func (m *MockNS1DomainClient) GetZone(zone string) (*dns.Zone, *http.Response, e
 		Type:     "A",
 		ID:       "123456789abcdefghijklmno",
 	}
+	weighted := &dns.ZoneRecord{
+		Domain:   "weighted.foo.com",
+		ShortAns: []string{"3.3.3.3", "4.4.4.4"},
+		Tier:     "3",
+		TTL:      3600,
+		Type:     "A",
+		ID:       "123456789abcdefghijklmnp",
+	}
 	z := &dns.Zone{
 		Zone:    "foo.com",
-		Records: []*dns.ZoneRecord{r},
+		Records: []*dns.ZoneRecord{r, weighted},
 		TTL:     3600,
 		ID:      "12345678910111213141516a",
 	}
@@ -71,6 +81,15 @@ func (m *MockNS1DomainClient) GetZone(zone string) (*dns.Zone, *http.Response, e
 	return nil, nil, nil
 }
 
+func (m *MockNS1DomainClient) GetRecord(zone string, domain string, t string) (*dns.Record, *http.Response, error) {
+	r := dns.NewRecord(zone, domain, t, nil, nil)
+	r.TTL = 3600
+	r.AddAnswer(&dns.Answer{Rdata: []string{"3.3.3.3"}, Meta: &data.Meta{Weight: float64(3)}})
+	r.AddAnswer(&dns.Answer{Rdata: []string{"4.4.4.4"}})
+	r.Filters = []*filter.Filter{filter.NewWeightedShuffle(), filter.NewSelFirstN(1)}
+	return r, nil, nil
+}
+
 func (m *MockNS1DomainClient) ListZones() ([]*dns.Zone, *http.Response, error) {
 	zones := []*dns.Zone{
 		{Zone: "foo.com", ID: "12345678910111213141516a"},
@@ -97,6 +116,10 @@ func (m *MockNS1GetZoneFail) GetZone(zone string) (*dns.Zone, *http.Response, er
 	return nil, nil, api.ErrZoneMissing
 }
 
+func (m *MockNS1GetZoneFail) GetRecord(_ string, _ string, _ string) (*dns.Record, *http.Response, error) {
+	return nil, nil, api.ErrRecordMissing
+}
+
 func (m *MockNS1GetZoneFail) ListZones() ([]*dns.Zone, *http.Response, error) {
 	zones := []*dns.Zone{
 		{Zone: "foo.com", ID: "12345678910111213141516a"},
@@ -123,6 +146,10 @@ func (m *MockNS1ListZonesFail) GetZone(zone string) (*dns.Zone, *http.Response,
 	return &dns.Zone{}, &http.Response{}, nil
 }
 
+func (m *MockNS1ListZonesFail) GetRecord(_ string, _ string, _ string) (*dns.Record, *http.Response, error) {
+	return nil, nil, api.ErrRecordMissing
+}
+
 func (m *MockNS1ListZonesFail) ListZones() ([]*dns.Zone, *http.Response, error) {
 	return nil, nil, fmt.Errorf("no zones available")
 }
@@ -138,7 +165,10 @@ func TestNS1Records(t *testing.T) {
 
 	records, err := provider.Records(ctx)
 	require.NoError(t, err)
-	assert.Len(t, records, 1)
+	assert.Len(t, records, 2)
+	assert.False(t, records[0].IsWeighted())
+	assert.Equal(t, endpoint.Targets{"3.3.3.3", "4.4.4.4"}, records[1].Targets)
+	assert.Equal(t, map[string]int64{"3.3.3.3": 3}, records[1].Weights)
 
 	provider.client = &MockNS1GetZoneFail{}
 	_, err = provider.Records(ctx)
@@ -222,6 +252,22 @@ func TestNS1BuildRecord(t *testing.T) {
 	assert.Equal(t, "foo.com", record.Zone)
 	assert.Equal(t, "new-b.foo.com", record.Domain)
 	assert.Equal(t, 3600, record.TTL)
+	assert.Empty(t, record.Filters)
+
+	weightedChange := &ns1Change{
+		Action: ns1Create,
+		Endpoint: &endpoint.Endpoint{
+			DNSName:    "new-c",
+			Targets:    endpoint.Targets{"target-a", "target-b"},
+			RecordType: "CNAME",
+			Weights:    map[string]int64{"target-a": 9},
+		},
+	}
+	record = provider.ns1BuildRecord("foo.com", weightedChange)
+	require.Len(t, record.Answers, 2)
+	assert.Equal(t, float64(9), record.Answers[0].Meta.Weight)
+	assert.Equal(t, float64(1), record.Answers[1].Meta.Weight)
+	assert.Equal(t, []*filter.Filter{filter.NewWeightedShuffle(), filter.NewSelFirstN(1)}, record.Filters)
 }
 
 func TestNS1ApplyChanges(t *testing.T) {
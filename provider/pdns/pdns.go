@@ -46,6 +46,9 @@ const (
 
 	defaultTTL = 300
 
+	// commentAccount is the account of the comments holding the descriptions of the records
+	commentAccount = "external-dns"
+
 	// PdnsDelete and PdnsReplace are effectively an enum for "pgo.RrSet.changetype"
 	// TODO: Can we somehow get this from the pgo swagger client library itself?
 
@@ -273,7 +276,14 @@ func (p *PDNSProvider) convertRRSetToEndpoints(rr pgo.RrSet) ([]*endpoint.Endpoi
 	if rr.Type_ == "ALIAS" {
 		rrType_ = "CNAME"
 	}
-	endpoints = append(endpoints, endpoint.NewEndpointWithTTL(rr.Name, rrType_, endpoint.TTL(rr.Ttl), targets...))
+	ep := endpoint.NewEndpointWithTTL(rr.Name, rrType_, endpoint.TTL(rr.Ttl), targets...)
+	for _, comment := range rr.Comments {
+		if comment.Account == commentAccount {
+			ep.WithDescription(comment.Content)
+			break
+		}
+	}
+	endpoints = append(endpoints, ep)
 	return endpoints, nil
 }
 
@@ -323,6 +333,15 @@ func (p *PDNSProvider) ConvertEndpointsToZones(eps []*endpoint.Endpoint, changet
 					if ep.RecordType == "CNAME" || ep.RecordType == "ALIAS" || ep.RecordType == "MX" || ep.RecordType == "SRV" {
 						t = provider.EnsureTrailingDot(t)
 					}
+					// PowerDNS requires the character strings of NAPTR records to be quoted
+					if ep.RecordType == endpoint.RecordTypeNAPTR {
+						if naptr, err := endpoint.NewNAPTRRecord(t); err == nil {
+							if replacement := naptr.GetReplacement(); *replacement != "." {
+								*replacement = provider.EnsureTrailingDot(*replacement)
+							}
+							t = naptr.String()
+						}
+					}
 					records = append(records, pgo.Record{Content: t})
 				}
 
@@ -349,6 +368,10 @@ func (p *PDNSProvider) ConvertEndpointsToZones(eps []*endpoint.Endpoint, changet
 					} else {
 						rrset.Ttl = int32(ep.RecordTTL)
 					}
+					// replacing the comments of the rrset, which are kept when none are given
+					if ep.Description != "" {
+						rrset.Comments = []pgo.Comment{{Content: ep.Description, Account: commentAccount}}
+					}
 				}
 
 				zone.Rrsets = append(zone.Rrsets, rrset)
@@ -450,6 +473,8 @@ func (p *PDNSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 			log.Warnf("Ignoring Endpoint because of invalid %v record formatting: {Target: '%v'}", endpoints[i].RecordType, endpoints[i].Targets)
 			continue
 		}
+		provider.DiscardWeights(endpoints[i])
+		provider.DiscardGeo(endpoints[i])
 		validEndpoints = append(validEndpoints, endpoints[i])
 	}
 	return validEndpoints, nil
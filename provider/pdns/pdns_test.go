@@ -875,6 +875,24 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSRRSetToEndpoints() {
 	eps, err = p.convertRRSetToEndpoints(RRSetDisabledRecord)
 	suite.Require().NoError(err)
 	suite.Equal(endpointsDisabledRecord, eps)
+
+	/* Given an RRSet with comments, we test:
+	   - The comment of external-dns is converted into the description of the endpoint
+	   - Comments of other accounts are ignored
+	*/
+	eps, err = p.convertRRSetToEndpoints(pgo.RrSet{
+		Name:    "example.com.",
+		Type_:   "A",
+		Ttl:     300,
+		Records: []pgo.Record{{Content: "8.8.8.8"}},
+		Comments: []pgo.Comment{
+			{Content: "Added by hand", Account: "admin"},
+			{Content: "Managed by the payments team", Account: "external-dns"},
+		},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(eps, 1)
+	suite.Equal("Managed by the payments team", eps[0].Description)
 }
 
 func (suite *NewPDNSProviderTestSuite) TestPDNSRecords() {
@@ -981,10 +999,35 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSConvertEndpointsToZones() {
 		}
 	}
 
+	// Check endpoints of type NAPTR have their character strings quoted and their replacement end with a dot.
+	zlist, err = p.ConvertEndpointsToZones([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeNAPTR, endpoint.TTL(300), `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`, `102 10 S SIP+D2T "" _sip._tcp.example.com`),
+	}, PdnsReplace)
+	suite.NoError(err)
+	suite.Require().Len(zlist, 1)
+	suite.Equal([]pgo.Record{
+		{Content: `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+		{Content: `102 10 "S" "SIP+D2T" "" _sip._tcp.example.com.`},
+	}, zlist[0].Rrsets[0].Records)
+
 	// Check endpoints of type CNAME are converted to ALIAS on the domain apex
 	zlist, err = p.ConvertEndpointsToZones(endpointsApexRecords, PdnsReplace)
 	suite.NoError(err)
 	suite.Equal([]pgo.Zone{ZoneEmptyToApexPatch}, zlist)
+
+	// Check the description of endpoints is set as the comment of their rrset, unless they are deleted
+	described := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, endpoint.TTL(300), "8.8.8.8").
+			WithDescription("Managed by the payments team"),
+	}
+	zlist, err = p.ConvertEndpointsToZones(described, PdnsReplace)
+	suite.NoError(err)
+	suite.Require().Len(zlist, 1)
+	suite.Equal([]pgo.Comment{{Content: "Managed by the payments team", Account: "external-dns"}}, zlist[0].Rrsets[0].Comments)
+	zlist, err = p.ConvertEndpointsToZones(described, PdnsDelete)
+	suite.NoError(err)
+	suite.Require().Len(zlist, 1)
+	suite.Empty(zlist[0].Rrsets[0].Comments)
 }
 
 func (suite *NewPDNSProviderTestSuite) TestPDNSConvertEndpointsToZonesPartitionZones() {
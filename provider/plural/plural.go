@@ -80,7 +80,7 @@ func (p *PluralProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error
 }
 
 func (p *PluralProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
-	return endpoints, nil
+	return p.BaseProvider.AdjustEndpoints(endpoints)
 }
 
 func (p *PluralProvider) ApplyChanges(_ context.Context, diffs *plan.Changes) error {
@@ -23,6 +23,8 @@ import (
 	"net"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
+
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
 )
@@ -59,10 +61,56 @@ type Provider interface {
 
 type BaseProvider struct{}
 
+// AdjustEndpoints discards the target weights, geo locations and descriptions of the endpoints, as most providers
+// have neither weighted nor geolocation routing, nor record comments.
 func (b BaseProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		DiscardWeights(ep)
+		DiscardGeo(ep)
+		DiscardDescription(ep)
+	}
 	return endpoints, nil
 }
 
+// DiscardWeights translates the target weights of an endpoint for providers without weighted routing, which answer
+// all targets in rotation: targets with a weight of 0 are left out, unless all of them are, and other weights are
+// ignored. Without it, the weights would show up as changes of the records in every synchronization.
+func DiscardWeights(ep *endpoint.Endpoint) {
+	if !ep.IsWeighted() {
+		return
+	}
+	var targets endpoint.Targets
+	weights := map[int64]bool{}
+	for _, target := range ep.Targets {
+		if weight := ep.TargetWeight(target); weight > 0 {
+			targets = append(targets, target)
+			weights[weight] = true
+		}
+	}
+	if len(weights) > 1 {
+		log.Warnf("The provider doesn't support weighted records, the targets of %s get an equal share of the traffic", ep.DNSName)
+	}
+	if len(targets) > 0 {
+		ep.Targets = targets
+	}
+	ep.Weights = nil
+}
+
+// DiscardGeo removes the geo location of an endpoint for providers without geolocation routing, which
+// answer the same targets everywhere.
+func DiscardGeo(ep *endpoint.Endpoint) {
+	if ep.Geo == nil {
+		return
+	}
+	log.Debugf("The provider doesn't support geolocation routing, ignoring the location %s of %s", ep.Geo, ep.DNSName)
+	ep.Geo = nil
+}
+
+// DiscardDescription removes the description of an endpoint for providers without record comments.
+func DiscardDescription(ep *endpoint.Endpoint) {
+	ep.Description = ""
+}
+
 func (b BaseProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return &endpoint.DomainFilter{}
 }
@@ -23,6 +23,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 func TestMain(m *testing.M) {
@@ -54,3 +56,69 @@ func TestDifference(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, remove)
 	assert.Equal(t, []string{"bar"}, leave)
 }
+
+func TestDiscardWeights(t *testing.T) {
+	tests := []struct {
+		name     string
+		targets  endpoint.Targets
+		weights  map[string]int64
+		expected endpoint.Targets
+	}{
+		{
+			name:     "unweighted",
+			targets:  endpoint.Targets{"1.2.3.4", "2.3.4.5"},
+			expected: endpoint.Targets{"1.2.3.4", "2.3.4.5"},
+		},
+		{
+			name:     "drained target is left out",
+			targets:  endpoint.Targets{"1.2.3.4", "2.3.4.5"},
+			weights:  map[string]int64{"2.3.4.5": 0},
+			expected: endpoint.Targets{"1.2.3.4"},
+		},
+		{
+			name:     "other weights are ignored",
+			targets:  endpoint.Targets{"1.2.3.4", "2.3.4.5"},
+			weights:  map[string]int64{"1.2.3.4": 9},
+			expected: endpoint.Targets{"1.2.3.4", "2.3.4.5"},
+		},
+		{
+			name:     "all targets drained",
+			targets:  endpoint.Targets{"1.2.3.4", "2.3.4.5"},
+			weights:  map[string]int64{"1.2.3.4": 0, "2.3.4.5": 0},
+			expected: endpoint.Targets{"1.2.3.4", "2.3.4.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, tt.targets...)
+			ep.Weights = tt.weights
+
+			DiscardWeights(ep)
+
+			assert.Equal(t, tt.expected, ep.Targets)
+			assert.False(t, ep.IsWeighted())
+		})
+	}
+}
+
+func TestDiscardGeo(t *testing.T) {
+	ep := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4").WithGeo(endpoint.GeoLocation{Continent: "EU"})
+
+	DiscardGeo(ep)
+	assert.Nil(t, ep.Geo)
+
+	adjusted, err := BaseProvider{}.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4").WithGeo(endpoint.GeoLocation{Country: "US"}),
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, adjusted[0].Geo)
+}
+
+func TestDiscardDescription(t *testing.T) {
+	adjusted, err := BaseProvider{}.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4").WithDescription("payments"),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, adjusted[0].Description)
+}
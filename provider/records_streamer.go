@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// RecordsStreamer is implemented by providers which can pass their records on one at a time as they are listed,
+// rather than returning them all at once, so that very large zones don't need to be held in memory.
+type RecordsStreamer interface {
+	// StreamRecords calls fn with each record, never concurrently. An error returned by fn stops the listing and
+	// is returned.
+	StreamRecords(ctx context.Context, fn func(*endpoint.Endpoint) error) error
+}
+
+// StreamRecords calls fn with each record of the provider. Providers which can't stream their records, as well as
+// cached providers, return all their records first.
+func StreamRecords(ctx context.Context, p Provider, fn func(*endpoint.Endpoint) error) error {
+	if streamer, ok := p.(RecordsStreamer); ok {
+		return streamer.StreamRecords(ctx, fn)
+	}
+
+	records, err := p.Records(ctx)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// testStreamingProvider streams its records, failing the test if they are listed all at once.
+type testStreamingProvider struct {
+	*testProviderFunc
+	streamed []*endpoint.Endpoint
+}
+
+func (p *testStreamingProvider) StreamRecords(_ context.Context, fn func(*endpoint.Endpoint) error) error {
+	for _, record := range p.streamed {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func collectRecords(ctx context.Context, p Provider) ([]string, error) {
+	var names []string
+	err := StreamRecords(ctx, p, func(ep *endpoint.Endpoint) error {
+		names = append(names, ep.DNSName)
+		return nil
+	})
+	return names, err
+}
+
+func TestStreamRecords(t *testing.T) {
+	streaming := &testStreamingProvider{
+		testProviderFunc: newTestProviderFunc(t),
+		streamed: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+	}
+	names, err := collectRecords(context.Background(), streaming)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, names)
+
+	listing := newTestProviderFunc(t)
+	listing.records = func(_ context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.1.1.1")}, nil
+	}
+	names, err = collectRecords(context.Background(), listing)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c.example.com"}, names)
+
+	listing.records = func(_ context.Context) ([]*endpoint.Endpoint, error) {
+		return nil, errors.New("listing failed")
+	}
+	_, err = collectRecords(context.Background(), listing)
+	require.EqualError(t, err, "listing failed")
+}
+
+func TestStreamRecordsStopsOnError(t *testing.T) {
+	p := &testStreamingProvider{
+		testProviderFunc: newTestProviderFunc(t),
+		streamed: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+	}
+	errStop := errors.New("stop")
+	calls := 0
+	err := StreamRecords(context.Background(), NewInstrumentedProvider(p, "streaming-test"), func(*endpoint.Endpoint) error {
+		calls++
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+	assert.InDelta(t, 1, testutil.ToFloat64(providerRequestsTotal.CounterVec.WithLabelValues("streaming-test", "StreamRecords")), 0)
+}
@@ -72,6 +72,9 @@ type rfc2136Provider struct {
 	dryRun       bool
 	actions      rfc2136Actions
 
+	// records last transferred from each zone, by SOA serial
+	zoneRecords *provider.ZoneChangeTokens[[]dns.RR]
+
 	// Counter for load balancing, and error handling
 	counter int
 	mu      sync.Mutex // Mutex for thread-safe counter
@@ -107,10 +110,11 @@ var tsigAlgs = map[string]string{
 type rfc2136Actions interface {
 	SendMessage(msg *dns.Msg) error
 	IncomeTransfer(m *dns.Msg, nameserver string) (env chan *dns.Envelope, err error)
+	ZoneSerial(zone string, nameserver string) (uint32, error)
 }
 
 // NewRfc2136Provider is a factory function for OpenStack rfc2136 providers
-func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure bool, keyName string, secret string, secretAlg string, axfr bool, domainFilter *endpoint.DomainFilter, dryRun bool, minTTL time.Duration, createPTR bool, gssTsig bool, krb5Username string, krb5Password string, krb5Realm string, batchChangeSize int, tlsConfig TLSConfig, loadBalancingStrategy string, actions rfc2136Actions) (provider.Provider, error) {
+func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure bool, keyName string, secret string, secretAlg string, axfr bool, domainFilter *endpoint.DomainFilter, dryRun bool, minTTL time.Duration, createPTR bool, gssTsig bool, krb5Username string, krb5Password string, krb5Realm string, batchChangeSize int, tlsConfig TLSConfig, loadBalancingStrategy string, zoneChangeTokens bool, actions rfc2136Actions) (provider.Provider, error) {
 	secretAlgChecked, ok := tsigAlgs[secretAlg]
 	if !ok && !insecure && !gssTsig {
 		return nil, fmt.Errorf("%s is not supported TSIG algorithm", secretAlg)
@@ -157,6 +161,9 @@ func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure b
 	} else {
 		r.actions = r
 	}
+	if zoneChangeTokens {
+		r.zoneRecords = provider.NewZoneChangeTokens[[]dns.RR]()
+	}
 
 	if !insecure {
 		r.tsigKeyName = dns.Fqdn(keyName)
@@ -215,7 +222,7 @@ OuterLoop:
 			rrValues = []string{rr.(*dns.AAAA).AAAA.String()}
 			rrType = "AAAA"
 		case dns.TypeTXT:
-			rrValues = (rr.(*dns.TXT).Txt)
+			rrValues = []string{strings.Join(rr.(*dns.TXT).Txt, "")}
 			rrType = "TXT"
 		case dns.TypeNS:
 			rrValues = []string{rr.(*dns.NS).Ns}
@@ -223,6 +230,14 @@ OuterLoop:
 		case dns.TypePTR:
 			rrValues = []string{rr.(*dns.PTR).Ptr}
 			rrType = "PTR"
+		case dns.TypeNAPTR:
+			naptr := rr.(*dns.NAPTR)
+			replacement := naptr.Replacement
+			if replacement == "" {
+				replacement = "."
+			}
+			rrValues = []string{fmt.Sprintf(`%d %d "%s" "%s" "%s" %s`, naptr.Order, naptr.Preference, naptr.Flags, naptr.Service, naptr.Regexp, replacement)}
+			rrType = "NAPTR"
 		default:
 			continue // Unhandled record type
 		}
@@ -273,52 +288,110 @@ func (r *rfc2136Provider) List() ([]dns.RR, error) {
 
 	records := make([]dns.RR, 0)
 	for _, zone := range r.zoneNames {
-		log.Debugf("Fetching records for '%q'", zone)
-
-		m := new(dns.Msg)
-		m.SetAxfr(dns.Fqdn(zone))
-		if !r.insecure && !r.gssTsig {
-			m.SetTsig(r.tsigKeyName, r.tsigSecretAlg, clockSkew, time.Now().Unix())
+		zoneRecords, err := r.zoneRecords.Records(zone, r.zoneChangeToken(zone), func() ([]dns.RR, error) {
+			return r.transferZone(zone)
+		})
+		if err != nil {
+			return nil, err
 		}
+		records = append(records, zoneRecords...)
+	}
 
-		var lastErr error
-		for i := 0; i < len(r.nameservers); i++ {
-			nameserver := r.getNextNameserver()
-			log.Debugf("Fetching records from nameserver: %s", nameserver)
+	return records, nil
+}
 
-			env, err := r.actions.IncomeTransfer(m, nameserver)
-			if err != nil {
-				lastErr = fmt.Errorf("failed to fetch records via AXFR: %w", err)
-				r.lastErr = lastErr
-				continue
-			}
+// transferZone fetches the records of the zone via AXFR, trying each nameserver in turn.
+func (r *rfc2136Provider) transferZone(zone string) ([]dns.RR, error) {
+	log.Debugf("Fetching records for '%q'", zone)
 
-			for e := range env {
-				if e.Error != nil {
-					if errors.Is(e.Error, dns.ErrSoa) {
-						log.Error("AXFR error: unexpected response received from the server")
-					} else {
-						log.Errorf("AXFR error: %v", e.Error)
-					}
-					continue
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(zone))
+	if !r.insecure && !r.gssTsig {
+		m.SetTsig(r.tsigKeyName, r.tsigSecretAlg, clockSkew, time.Now().Unix())
+	}
+
+	records := make([]dns.RR, 0)
+	var lastErr error
+	for i := 0; i < len(r.nameservers); i++ {
+		nameserver := r.getNextNameserver()
+		log.Debugf("Fetching records from nameserver: %s", nameserver)
+
+		env, err := r.actions.IncomeTransfer(m, nameserver)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to fetch records via AXFR: %w", err)
+			r.lastErr = lastErr
+			continue
+		}
+
+		for e := range env {
+			if e.Error != nil {
+				if errors.Is(e.Error, dns.ErrSoa) {
+					log.Error("AXFR error: unexpected response received from the server")
+				} else {
+					log.Errorf("AXFR error: %v", e.Error)
 				}
-				records = append(records, e.RR...)
-			}
-			// If records were fetched successfully, break out of the loop
-			if len(records) > 0 {
-				break
+				continue
 			}
+			records = append(records, e.RR...)
 		}
-
-		if lastErr != nil {
-			r.lastErr = lastErr
-			return nil, lastErr
+		// If records were fetched successfully, break out of the loop
+		if len(records) > 0 {
+			break
 		}
 	}
 
+	if lastErr != nil {
+		r.lastErr = lastErr
+		return nil, lastErr
+	}
 	return records, nil
 }
 
+// zoneChangeToken returns the SOA serial of the zone when the records of unchanged zones are kept between
+// transfers, or an empty token to transfer the zone anyway.
+func (r *rfc2136Provider) zoneChangeToken(zone string) string {
+	if r.zoneRecords == nil {
+		return ""
+	}
+
+	nameserver := r.getNextNameserver()
+	serial, err := r.actions.ZoneSerial(zone, nameserver)
+	if err != nil {
+		log.Warnf("Failed to query the SOA serial of zone %s from nameserver %s, transferring it anyway: %v", zone, nameserver, err)
+		return ""
+	}
+	return strconv.FormatUint(uint64(serial), 10)
+}
+
+// ZoneSerial queries the nameserver for the serial of the SOA record of the zone.
+func (r *rfc2136Provider) ZoneSerial(zone string, nameserver string) (uint32, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(zone), dns.TypeSOA)
+
+	c, err := makeClient(r, nameserver)
+	if err != nil {
+		return 0, fmt.Errorf("error setting up TLS: %w", err)
+	}
+	if !r.insecure && !r.gssTsig {
+		c.TsigProvider = tsig.HMAC{r.tsigKeyName: r.tsigSecret}
+		m.SetTsig(r.tsigKeyName, r.tsigSecretAlg, clockSkew, time.Now().Unix())
+	}
+
+	resp, _, err := c.Exchange(m, nameserver)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return 0, fmt.Errorf("bad return code: %s", dns.RcodeToString[resp.Rcode])
+	}
+	for _, rr := range resp.Answer {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa.Serial, nil
+		}
+	}
+	return 0, errors.New("no SOA record in the answer")
+}
+
 func (r *rfc2136Provider) AddReverseRecord(ip string, hostname string) error {
 	changes := r.GenerateReverseRecord(ip, hostname)
 	return r.ApplyChanges(context.Background(), &plan.Changes{Create: changes})
@@ -490,6 +563,9 @@ func (r *rfc2136Provider) AddRecord(m *dns.Msg, ep *endpoint.Endpoint) error {
 	}
 
 	for _, target := range ep.Targets {
+		if ep.RecordType == endpoint.RecordTypeTXT {
+			target = endpoint.SplitLongTXT(target)
+		}
 		newRR := fmt.Sprintf("%s %d %s %s", ep.DNSName, ttl, ep.RecordType, target)
 		log.Infof("Adding RR: %s", newRR)
 
@@ -507,6 +583,9 @@ func (r *rfc2136Provider) AddRecord(m *dns.Msg, ep *endpoint.Endpoint) error {
 func (r *rfc2136Provider) RemoveRecord(m *dns.Msg, ep *endpoint.Endpoint) error {
 	log.Debugf("RemoveRecord.ep=%s", ep)
 	for _, target := range ep.Targets {
+		if ep.RecordType == endpoint.RecordTypeTXT {
+			target = endpoint.SplitLongTXT(target)
+		}
 		newRR := fmt.Sprintf("%s %d %s %s", ep.DNSName, ep.RecordTTL, ep.RecordType, target)
 		log.Infof("Removing RR: %s", newRR)
 
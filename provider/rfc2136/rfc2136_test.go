@@ -19,6 +19,7 @@ package rfc2136
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
@@ -48,6 +49,9 @@ type rfc2136Stub struct {
 	randGen               *rand.Rand
 	lastNameserver        string
 	loadBalancingStrategy string
+	serials               map[string]uint32
+	serialErr             error
+	transfers             int
 }
 
 func newStub() *rfc2136Stub {
@@ -149,7 +153,15 @@ func (r *rfc2136Stub) setOutput(output []string) error {
 	return nil
 }
 
+func (r *rfc2136Stub) ZoneSerial(zone string, nameserver string) (uint32, error) {
+	if r.serialErr != nil {
+		return 0, r.serialErr
+	}
+	return r.serials[zone], nil
+}
+
 func (r *rfc2136Stub) IncomeTransfer(m *dns.Msg, a string) (chan *dns.Envelope, error) {
+	r.transfers++
 	outChan := make(chan *dns.Envelope)
 	go func() {
 		for _, e := range r.output {
@@ -186,7 +198,7 @@ func createRfc2136StubProvider(stub *rfc2136Stub, zoneNames ...string) (provider
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{""}, 0, zoneNames, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zoneNames, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", false, stub)
 }
 
 func createRfc2136StubProviderWithHosts(stub *rfc2136Stub) (provider.Provider, error) {
@@ -197,15 +209,15 @@ func createRfc2136StubProviderWithHosts(stub *rfc2136Stub) (provider.Provider, e
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", false, stub)
 }
 
 func createRfc2136TLSStubProvider(stub *rfc2136Stub, tlsConfig TLSConfig) (provider.Provider, error) {
-	return NewRfc2136Provider([]string{"rfc2136-host"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", false, stub)
 }
 
 func createRfc2136TLSStubProviderWithHosts(stub *rfc2136Stub, tlsConfig TLSConfig) (provider.Provider, error) {
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", false, stub)
 }
 
 func createRfc2136StubProviderWithReverse(stub *rfc2136Stub) (provider.Provider, error) {
@@ -218,7 +230,7 @@ func createRfc2136StubProviderWithReverse(stub *rfc2136Stub) (provider.Provider,
 	}
 
 	zones := []string{"foo.com", "3.2.1.in-addr.arpa"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, true, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, true, false, "", "", "", 50, tlsConfig, "", false, stub)
 }
 
 func createRfc2136StubProviderWithZones(stub *rfc2136Stub) (provider.Provider, error) {
@@ -230,7 +242,7 @@ func createRfc2136StubProviderWithZones(stub *rfc2136Stub) (provider.Provider, e
 		ClientCertKeyFilePath: "",
 	}
 	zones := []string{"foo.com", "foobar.com"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", false, stub)
 }
 
 func createRfc2136StubProviderWithZonesFilters(stub *rfc2136Stub) (provider.Provider, error) {
@@ -242,7 +254,7 @@ func createRfc2136StubProviderWithZonesFilters(stub *rfc2136Stub) (provider.Prov
 		ClientCertKeyFilePath: "",
 	}
 	zones := []string{"foo.com", "foobar.com"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", false, stub)
 }
 
 func createRfc2136StubProviderWithStrategy(stub *rfc2136Stub, strategy string) (provider.Provider, error) {
@@ -253,7 +265,7 @@ func createRfc2136StubProviderWithStrategy(stub *rfc2136Stub, strategy string) (
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, strategy, stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, strategy, false, stub)
 }
 
 func createRfc2136StubProviderWithBatchChangeSize(stub *rfc2136Stub, batchChangeSize int) (provider.Provider, error) {
@@ -264,7 +276,7 @@ func createRfc2136StubProviderWithBatchChangeSize(stub *rfc2136Stub, batchChange
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{""}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", batchChangeSize, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", batchChangeSize, tlsConfig, "", false, stub)
 }
 
 func extractUpdateSectionFromMessage(msg fmt.Stringer) []string {
@@ -546,6 +558,113 @@ func TestRfc2136GetRecords(t *testing.T) {
 	assert.True(t, contains(recs, "v2.foo.com"))
 }
 
+func TestRfc2136GetRecordsZoneChangeTokens(t *testing.T) {
+	stub := newStub()
+	err := stub.setOutput([]string{
+		"v1.foo.com 3600 TXT test1",
+		"v1.bar.com 3600 A 8.8.8.8",
+	})
+	require.NoError(t, err)
+	stub.serials = map[string]uint32{"foo.com": 1, "bar.com": 1}
+
+	provider, err := NewRfc2136Provider([]string{""}, 0, []string{"foo.com", "bar.com"}, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, TLSConfig{}, "", true, stub)
+	require.NoError(t, err)
+
+	recs, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, recs, 2)
+	assert.Equal(t, 2, stub.transfers)
+
+	// unchanged zones aren't transferred again
+	recs, err = provider.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, recs, 2)
+	assert.Equal(t, 2, stub.transfers)
+
+	// only the changed zone is
+	require.NoError(t, stub.setOutput([]string{
+		"v1.foo.com 3600 TXT test1",
+		"v2.foo.com 3600 TXT test2",
+		"v1.bar.com 3600 A 8.8.8.8",
+	}))
+	stub.serials["foo.com"] = 2
+	recs, err = provider.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, recs, 3)
+	assert.True(t, contains(recs, "v2.foo.com"))
+	assert.Equal(t, 3, stub.transfers)
+
+	// zones are transferred anyway when their serial can't be queried
+	stub.serialErr = errors.New("timeout")
+	recs, err = provider.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, recs, 3)
+	assert.Equal(t, 5, stub.transfers)
+}
+
+func TestRfc2136GetRecordsNAPTR(t *testing.T) {
+	stub := newStub()
+	err := stub.setOutput([]string{
+		`v1.foo.com 3600 NAPTR 100 10 "U" "E2U+sip" "!^.*$!sip:info@foo.com!" .`,
+		`v1.foo.com 3600 NAPTR 102 10 "S" "SIP+D2T" "" _sip._tcp.foo.com.`,
+	})
+	assert.NoError(t, err)
+
+	provider, err := createRfc2136StubProvider(stub, "foo.com")
+	assert.NoError(t, err)
+
+	recs, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+
+	require.Len(t, recs, 1)
+	assert.Equal(t, endpoint.RecordTypeNAPTR, recs[0].RecordType)
+	assert.Equal(t, endpoint.Targets{
+		`100 10 "U" "E2U+sip" "!^.*$!sip:info@foo.com!" .`,
+		`102 10 "S" "SIP+D2T" "" _sip._tcp.foo.com.`,
+	}, recs[0].Targets)
+	assert.True(t, recs[0].CheckEndpoint())
+}
+
+func TestRfc2136GetRecordsLongTXT(t *testing.T) {
+	stub := newStub()
+	err := stub.setOutput([]string{
+		fmt.Sprintf(`v1.foo.com 3600 TXT "%s" "%s"`, strings.Repeat("a", 255), strings.Repeat("b", 45)),
+	})
+	assert.NoError(t, err)
+
+	provider, err := createRfc2136StubProvider(stub, "foo.com")
+	assert.NoError(t, err)
+
+	recs, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+
+	require.Len(t, recs, 1)
+	assert.Equal(t, endpoint.Targets{strings.Repeat("a", 255) + strings.Repeat("b", 45)}, recs[0].Targets)
+}
+
+func TestRfc2136ApplyChangesLongTXT(t *testing.T) {
+	stub := newStub()
+	provider, err := createRfc2136StubProvider(stub)
+	assert.NoError(t, err)
+
+	p := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{
+				DNSName:    "v1.foo.com",
+				RecordType: endpoint.RecordTypeTXT,
+				Targets:    []string{strings.Repeat("a", 300)},
+			},
+		},
+	}
+
+	err = provider.ApplyChanges(context.Background(), p)
+	assert.NoError(t, err)
+
+	require.Len(t, stub.createMsgs, 1)
+	require.Len(t, stub.createMsgs[0].Ns, 1)
+	assert.Equal(t, []string{strings.Repeat("a", 255), strings.Repeat("a", 45)}, stub.createMsgs[0].Ns[0].(*dns.TXT).Txt)
+}
+
 // Make sure the test version of SendMessage raises an error
 // if a zone update ever contains records outside of it's zone
 // as the TestRfc2136ApplyChanges tests all assume this
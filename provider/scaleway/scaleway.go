@@ -115,6 +115,9 @@ func (p *ScalewayProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*e
 		if _, ok := eps[i].GetProviderSpecificProperty(scalewayPriorityKey); !ok {
 			eps[i] = eps[i].WithProviderSpecific(scalewayPriorityKey, fmt.Sprintf("%d", scalewayDefaultPriority))
 		}
+		provider.DiscardWeights(eps[i])
+		provider.DiscardGeo(eps[i])
+		provider.DiscardDescription(eps[i])
 	}
 	return eps, nil
 }
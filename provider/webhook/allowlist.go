@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// unixSocketHost is the host of the requests sent to webhooks listening on a unix socket.
+const unixSocketHost = "unix"
+
+// DefaultAllowedHosts are the hosts the webhook provider may call when no other hosts are allowed: the loopback
+// addresses of the sidecar the webhook usually runs as.
+var DefaultAllowedHosts = []string{"localhost", "127.0.0.0/8", "::1/128"}
+
+// hostAllowlist holds the hosts and networks the webhook provider may connect to, since the controller holds
+// DNS credentials and its webhook URL often comes from templated values.
+type hostAllowlist struct {
+	any      bool
+	names    []string
+	prefixes []netip.Prefix
+}
+
+// newHostAllowlist parses host names, IP addresses and CIDRs. Names starting with *. match their sub-domains
+// and * matches any host.
+func newHostAllowlist(hosts []string) (*hostAllowlist, error) {
+	a := &hostAllowlist{}
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		switch {
+		case host == "":
+			continue
+		case host == "*":
+			a.any = true
+		case strings.Contains(host, "/"):
+			prefix, err := netip.ParsePrefix(host)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed webhook network %q: %w", host, err)
+			}
+			a.prefixes = append(a.prefixes, prefix.Masked())
+		default:
+			if addr, err := netip.ParseAddr(host); err == nil {
+				a.prefixes = append(a.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+				continue
+			}
+			a.names = append(a.names, strings.TrimSuffix(host, "."))
+		}
+	}
+	return a, nil
+}
+
+// allowsName returns whether the host name is allowed by name, in which case it may resolve to any address.
+func (a *hostAllowlist) allowsName(host string) bool {
+	if a.any {
+		return true
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, name := range a.names {
+		if host == name || (strings.HasPrefix(name, "*.") && strings.HasSuffix(host, name[1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsAddr returns whether the address belongs to an allowed network.
+func (a *hostAllowlist) allowsAddr(addr netip.Addr) bool {
+	if a.any {
+		return true
+	}
+	addr = addr.Unmap()
+	for _, prefix := range a.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsURL returns whether the URL may be requested: its scheme must be http or https, and its host either be
+// allowed by name or be an address of an allowed network. Other host names are checked when connecting, against
+// the addresses they resolve to.
+func (a *hostAllowlist) allowsURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported webhook URL scheme %q", u.Scheme)
+	}
+	host := u.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !a.allowsAddr(addr) {
+			return fmt.Errorf("webhook host %s is not allowed, see --webhook-provider-allowed-hosts", host)
+		}
+	}
+	return nil
+}
+
+// dialContext connects to the given address, and fails unless its host is allowed by name or it resolved to an
+// address of an allowed network, which prevents host names from pointing elsewhere once the URL was checked.
+func (a *hostAllowlist) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if host, _, err := net.SplitHostPort(address); err != nil || !a.allowsName(host) {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !a.allowsAddr(addrPort.Addr()) {
+				return fmt.Errorf("webhook address %s is not allowed, see --webhook-provider-allowed-hosts", addrPort.Addr())
+			}
+			return nil
+		}
+	}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// checkRedirect only follows redirects to allowed hosts.
+func (a *hostAllowlist) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return fmt.Errorf("stopped after %d redirects", len(via))
+	}
+	if req.URL.Host == unixSocketHost && via[0].URL.Host == unixSocketHost {
+		return nil
+	}
+	if err := a.allowsURL(req.URL); err != nil {
+		return fmt.Errorf("refusing redirect to %s: %w", req.URL.Redacted(), err)
+	}
+	return nil
+}
+
+// newClient returns the client calling the webhook at the given URL, and the base URL of its requests. Webhooks
+// listening on a unix socket are given by unix:// URLs with the path of the socket, which are always allowed.
+func newClient(u *url.URL, allowedHosts []string) (*http.Client, *url.URL, error) {
+	if len(allowedHosts) == 0 {
+		allowedHosts = DefaultAllowedHosts
+	}
+	allowlist, err := newHostAllowlist(allowedHosts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	client := &http.Client{Transport: transport, CheckRedirect: allowlist.checkRedirect}
+
+	if u.Scheme == "unix" {
+		socket := u.Path
+		if socket == "" {
+			return nil, nil, fmt.Errorf("missing socket path in webhook URL %s", u.Redacted())
+		}
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+		return client, &url.URL{Scheme: "http", Host: unixSocketHost}, nil
+	}
+
+	if err := allowlist.allowsURL(u); err != nil {
+		return nil, nil, err
+	}
+	transport.DialContext = allowlist.dialContext
+	return client, u, nil
+}
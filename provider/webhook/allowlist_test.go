@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+func negotiatingHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/" {
+		w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+		w.Write([]byte(`{}`))
+		return
+	}
+	w.Write([]byte(`[{"dnsName": "test.example.com"}]`))
+}
+
+func TestHostAllowlist(t *testing.T) {
+	_, err := newHostAllowlist([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+
+	a, err := newHostAllowlist([]string{"Webhook.Example.com", "*.svc.cluster.local", "10.0.0.0/8", "fd00::1", ""})
+	require.NoError(t, err)
+
+	assert.True(t, a.allowsName("webhook.example.com."))
+	assert.True(t, a.allowsName("dns.webhooks.svc.cluster.local"))
+	assert.False(t, a.allowsName("svc.cluster.local"))
+	assert.False(t, a.allowsName("example.com"))
+	assert.True(t, a.allowsAddr(netip.MustParseAddr("10.1.2.3")))
+	assert.True(t, a.allowsAddr(netip.MustParseAddr("::ffff:10.1.2.3")))
+	assert.True(t, a.allowsAddr(netip.MustParseAddr("fd00::1")))
+	assert.False(t, a.allowsAddr(netip.MustParseAddr("fd00::2")))
+	assert.False(t, a.allowsAddr(netip.MustParseAddr("169.254.169.254")))
+
+	assert.NoError(t, a.allowsURL(&url.URL{Scheme: "https", Host: "10.0.0.1:443"}))
+	assert.NoError(t, a.allowsURL(&url.URL{Scheme: "http", Host: "other.example.com"}), "names are checked on connection")
+	assert.Error(t, a.allowsURL(&url.URL{Scheme: "http", Host: "169.254.169.254"}))
+	assert.Error(t, a.allowsURL(&url.URL{Scheme: "file", Path: "/etc/passwd"}))
+
+	a, err = newHostAllowlist([]string{"*"})
+	require.NoError(t, err)
+	assert.True(t, a.allowsName("example.com"))
+	assert.True(t, a.allowsAddr(netip.MustParseAddr("169.254.169.254")))
+}
+
+func TestNewWebhookProviderAllowedHosts(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(negotiatingHandler))
+	defer svr.Close()
+	localhostURL := fmt.Sprintf("http://localhost:%d", svr.Listener.Addr().(*net.TCPAddr).Port)
+
+	_, err := NewWebhookProvider(svr.URL, []string{"10.0.0.0/8"})
+	require.ErrorContains(t, err, "not allowed")
+
+	// the name isn't allowed and resolves to an address which isn't either
+	_, err = NewWebhookProvider(localhostURL, []string{"10.0.0.0/8"})
+	require.ErrorContains(t, err, "not allowed")
+
+	_, err = NewWebhookProvider(localhostURL, []string{"localhost"})
+	require.NoError(t, err)
+
+	_, err = NewWebhookProvider(svr.URL, []string{"127.0.0.1"})
+	require.NoError(t, err)
+}
+
+func TestWebhookProviderRedirects(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metadata":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		case "/moved":
+			http.Redirect(w, r, "/", http.StatusFound)
+		default:
+			negotiatingHandler(w, r)
+		}
+	}))
+	defer svr.Close()
+
+	u, err := url.Parse(svr.URL)
+	require.NoError(t, err)
+	client, base, err := newClient(u, nil)
+	require.NoError(t, err)
+
+	_, err = client.Get(base.JoinPath("metadata").String())
+	require.ErrorContains(t, err, "refusing redirect")
+
+	resp, err := client.Get(base.JoinPath("moved").String())
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestWebhookProviderUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "webhook.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	svr := &httptest.Server{Listener: listener, Config: &http.Server{Handler: http.HandlerFunc(negotiatingHandler)}}
+	svr.Start()
+	defer svr.Close()
+
+	_, err = NewWebhookProvider("unix://", nil)
+	require.ErrorContains(t, err, "missing socket path")
+
+	p, err := NewWebhookProvider("unix://"+socket, []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "test.example.com", records[0].DNSName)
+}
@@ -100,21 +100,25 @@ func init() {
 	metrics.RegisterMetric.MustRegister(adjustEndpointsRequestsGauge)
 }
 
-func NewWebhookProvider(u string) (*WebhookProvider, error) {
+// NewWebhookProvider negotiates with the webhook at the given URL, which must be a unix:// socket or target one
+// of the allowed hosts, DefaultAllowedHosts if none.
+func NewWebhookProvider(u string, allowedHosts []string) (*WebhookProvider, error) {
 	parsedURL, err := url.Parse(u)
 	if err != nil {
 		return nil, err
 	}
+	client, parsedURL, err := newClient(parsedURL, allowedHosts)
+	if err != nil {
+		return nil, err
+	}
 
 	// negotiate API information
-	req, err := http.NewRequest(http.MethodGet, u, nil)
+	req, err := http.NewRequest(http.MethodGet, parsedURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
 
-	client := &http.Client{}
-
 	resp, err := requestWithRetry(client, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to webhook: %w", err)
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var zoneListingsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Subsystem: "provider",
+		Name:      "zone_listings_total",
+		Help:      "Number of zone record listings checked against the change token of the zone, by whether the listing was skipped (vector).",
+	},
+	[]string{"skipped"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(zoneListingsTotal)
+}
+
+// ZoneChangeTokens remembers the records last listed from each zone along with the change token the zone had at
+// the time, such as the serial of its SOA record, so that zones whose token is unchanged aren't listed again.
+// A nil *ZoneChangeTokens lists the zones every time.
+type ZoneChangeTokens[T any] struct {
+	mu    sync.Mutex
+	zones map[string]zoneSnapshot[T]
+}
+
+type zoneSnapshot[T any] struct {
+	token   string
+	records T
+}
+
+// NewZoneChangeTokens returns an empty set of zone snapshots.
+func NewZoneChangeTokens[T any]() *ZoneChangeTokens[T] {
+	return &ZoneChangeTokens[T]{zones: make(map[string]zoneSnapshot[T])}
+}
+
+// Records returns the records of the zone remembered at the given change token, or lists them with list and
+// remembers them along with the token. An empty token, as for zones whose token couldn't be read, always lists.
+func (c *ZoneChangeTokens[T]) Records(zone, token string, list func() (T, error)) (T, error) {
+	if c == nil || token == "" {
+		return list()
+	}
+
+	c.mu.Lock()
+	snapshot, ok := c.zones[zone]
+	c.mu.Unlock()
+	if ok && snapshot.token == token {
+		log.Debugf("Skipping listing of zone %s, unchanged since change token %s", zone, token)
+		zoneListingsTotal.CounterVec.WithLabelValues("true").Inc()
+		return snapshot.records, nil
+	}
+
+	records, err := list()
+	if err != nil {
+		return records, err
+	}
+	zoneListingsTotal.CounterVec.WithLabelValues("false").Inc()
+
+	c.mu.Lock()
+	c.zones[zone] = zoneSnapshot[T]{token: token, records: records}
+	c.mu.Unlock()
+	return records, nil
+}
+
+// Forget drops the records remembered for the given zones, so that they're listed again the next time, e.g.
+// after changing them.
+func (c *ZoneChangeTokens[T]) Forget(zones ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, zone := range zones {
+		delete(c.zones, zone)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneChangeTokens(t *testing.T) {
+	listings := 0
+	list := func(records ...string) func() ([]string, error) {
+		return func() ([]string, error) {
+			listings++
+			return records, nil
+		}
+	}
+
+	tokens := NewZoneChangeTokens[[]string]()
+
+	records, err := tokens.Records("example.org", "1", list("a"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, records)
+	assert.Equal(t, 1, listings)
+
+	// unchanged token, the zone isn't listed again
+	records, err = tokens.Records("example.org", "1", list("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, records)
+	assert.Equal(t, 1, listings)
+
+	// tokens are kept per zone
+	records, err = tokens.Records("example.com", "1", list("c"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, records)
+	assert.Equal(t, 2, listings)
+
+	// changed token
+	records, err = tokens.Records("example.org", "2", list("d"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d"}, records)
+	assert.Equal(t, 3, listings)
+
+	// empty token always lists
+	records, err = tokens.Records("example.org", "", list("e"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e"}, records)
+	records, err = tokens.Records("example.org", "2", list("f"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d"}, records)
+	assert.Equal(t, 4, listings)
+
+	// forgotten zones are listed again
+	tokens.Forget("example.org")
+	records, err = tokens.Records("example.org", "2", list("g"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g"}, records)
+	assert.Equal(t, 5, listings)
+}
+
+func TestZoneChangeTokensError(t *testing.T) {
+	tokens := NewZoneChangeTokens[[]string]()
+
+	_, err := tokens.Records("example.org", "1", func() ([]string, error) {
+		return nil, errors.New("failed")
+	})
+	require.Error(t, err)
+
+	// failed listings aren't remembered
+	records, err := tokens.Records("example.org", "1", func() ([]string, error) {
+		return []string{"a"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, records)
+}
+
+func TestZoneChangeTokensNil(t *testing.T) {
+	var tokens *ZoneChangeTokens[[]string]
+
+	listings := 0
+	for range 2 {
+		_, err := tokens.Records("example.org", "1", func() ([]string, error) {
+			listings++
+			return nil, nil
+		})
+		require.NoError(t, err)
+	}
+	tokens.Forget("example.org")
+	assert.Equal(t, 2, listings)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+)
+
+// ErrZoneListingNotSupported is returned by ListZones for providers which can't list their zones.
+var ErrZoneListingNotSupported = errors.New("the provider doesn't support listing its zones")
+
+// Zone is a zone managed by a provider.
+type Zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Visibility is public or private, empty if the provider doesn't tell
+	Visibility string `json:"visibility,omitempty"`
+	// Records is the number of records in the zone, nil if the provider doesn't tell
+	Records *int64 `json:"records,omitempty"`
+}
+
+// ZoneLister is implemented by providers which can list the zones they manage, after applying their zone filters.
+type ZoneLister interface {
+	ListZones(ctx context.Context) ([]Zone, error)
+}
+
+// ListZones returns the zones managed by a provider sorted by name, looking through the instrumented and cached
+// providers wrapping it.
+func ListZones(ctx context.Context, p Provider) ([]Zone, error) {
+	switch wrapper := p.(type) {
+	case ZoneLister:
+		zones, err := wrapper.ListZones(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(zones, func(a, b Zone) int {
+			return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+		})
+		return zones, nil
+	case *InstrumentedProvider:
+		return ListZones(ctx, wrapper.Provider)
+	case *CachedProvider:
+		return ListZones(ctx, wrapper.Provider)
+	}
+	return nil, ErrZoneListingNotSupported
+}
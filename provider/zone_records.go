@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ZoneRecords lists the records of each zone with list, running at most concurrency listings at once, and
+// returns the records in the order of zones. A concurrency below 2 lists the zones one after the other.
+// The first error cancels the context of the listings in flight and is returned.
+func ZoneRecords[Z any](ctx context.Context, zones []Z, concurrency int, list func(ctx context.Context, zone Z) ([]*endpoint.Endpoint, error)) ([]*endpoint.Endpoint, error) {
+	records := make([][]*endpoint.Endpoint, len(zones))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(concurrency, 1))
+	for i, zone := range zones {
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			zoneRecords, err := list(ctx, zone)
+			if err != nil {
+				return err
+			}
+			records[i] = zoneRecords
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*endpoint.Endpoint, 0)
+	for _, zoneRecords := range records {
+		endpoints = append(endpoints, zoneRecords...)
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestZoneRecords(t *testing.T) {
+	zones := []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com"}
+
+	for _, concurrency := range []int{0, 1, 2, 10} {
+		var running, peak atomic.Int32
+		list := func(_ context.Context, zone string) ([]*endpoint.Endpoint, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www."+zone, endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("api."+zone, endpoint.RecordTypeA, "1.2.3.4"),
+			}, nil
+		}
+
+		records, err := ZoneRecords(context.Background(), zones, concurrency, list)
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(records))
+		for _, ep := range records {
+			names = append(names, ep.DNSName)
+		}
+		assert.Equal(t, []string{
+			"www.a.example.com", "api.a.example.com",
+			"www.b.example.com", "api.b.example.com",
+			"www.c.example.com", "api.c.example.com",
+			"www.d.example.com", "api.d.example.com",
+			"www.e.example.com", "api.e.example.com",
+		}, names, "concurrency %d", concurrency)
+		assert.LessOrEqual(t, peak.Load(), int32(max(min(concurrency, len(zones)), 1)), "concurrency %d", concurrency)
+	}
+}
+
+func TestZoneRecordsEmpty(t *testing.T) {
+	records, err := ZoneRecords(context.Background(), []string{}, 4, func(context.Context, string) ([]*endpoint.Endpoint, error) {
+		t.Fatal("list called without zones")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, records)
+	assert.Empty(t, records)
+}
+
+func TestZoneRecordsError(t *testing.T) {
+	errList := errors.New("failed to list records")
+	var calls atomic.Int32
+
+	_, err := ZoneRecords(context.Background(), []string{"a.example.com", "b.example.com", "c.example.com"}, 1, func(_ context.Context, zone string) ([]*endpoint.Endpoint, error) {
+		calls.Add(1)
+		if zone == "a.example.com" {
+			return nil, errList
+		}
+		return []*endpoint.Endpoint{endpoint.NewEndpoint("www."+zone, endpoint.RecordTypeA, "1.2.3.4")}, nil
+	})
+	require.ErrorIs(t, err, errList)
+	assert.Equal(t, int32(1), calls.Load(), "zones after the failed one are not listed")
+}
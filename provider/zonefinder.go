@@ -61,7 +61,9 @@ func (z ZoneIDName) FindZone(hostname string) (string, string) {
 	var suitableZoneID, suitableZoneName string
 
 	for zoneID, zoneName := range z {
-		if name == zoneName || strings.HasSuffix(name, "."+zoneName) {
+		// zone names are compared in the same form as the hostname, whatever their case or encoding
+		normalized := idna.ToUnicode(zoneName)
+		if name == normalized || strings.HasSuffix(name, "."+normalized) {
 			if suitableZoneName == "" || len(zoneName) > len(suitableZoneName) {
 				suitableZoneID = zoneID
 				suitableZoneName = zoneName
@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 )
 
@@ -89,8 +90,55 @@ func TestZoneIDName(t *testing.T) {
 	assert.Equal(t, "*.example.com", zoneName)
 	assert.Equal(t, "123412", zoneID)
 
+	// mixed case and punycode zone names are matched whatever the form of the hostname
+	z.Add("246810", "XN--BCHER-KVA.Example.org")
+	z.Add("135791", "Gopher.Example.org")
+	zoneID, zoneName = z.FindZone("shop.bücher.example.org")
+	assert.Equal(t, "XN--BCHER-KVA.Example.org", zoneName)
+	assert.Equal(t, "246810", zoneID)
+	zoneID, _ = z.FindZone("Shop.Bücher.EXAMPLE.org")
+	assert.Equal(t, "246810", zoneID)
+	zoneID, _ = z.FindZone("shop.xn--bcher-kva.example.org")
+	assert.Equal(t, "246810", zoneID)
+	zoneID, _ = z.FindZone("www.gopher.example.org")
+	assert.Equal(t, "135791", zoneID)
+	delete(z, "246810")
+	delete(z, "135791")
+
 	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
 	_, _ = z.FindZone("xn--not-a-valid-punycode")
 
 	testutils.TestHelperLogContains("Failed to convert label \"xn--not-a-valid-punycode\" of hostname \"xn--not-a-valid-punycode\" to its Unicode form: idna: invalid label", hook, t)
 }
+
+func TestZoneIDNameGlobDomainFilter(t *testing.T) {
+	domainFilter := endpoint.NewDomainFilter([]string{"eu-*.example.com", "*.staging.example.com"})
+
+	// zones are selected like providers do, keeping those matched by the filter and the parents of its domains
+	z := ZoneIDName{}
+	for zoneID, zoneName := range map[string]string{
+		"1": "example.com",
+		"2": "eu-west.example.com",
+		"3": "staging.example.com",
+		"4": "us-east.example.com",
+		"5": "example.org",
+	} {
+		if domainFilter.Match(zoneName) || domainFilter.MatchParent(zoneName) {
+			z.Add(zoneID, zoneName)
+		}
+	}
+	assert.Equal(t, ZoneIDName{"1": "example.com", "2": "eu-west.example.com", "3": "staging.example.com"}, z)
+
+	for hostname, expected := range map[string]string{
+		"eu-west.example.com":        "eu-west.example.com",
+		"api.eu-west.example.com":    "eu-west.example.com",
+		"eu-central.example.com":     "example.com",
+		"api.staging.example.com":    "staging.example.com",
+		"*.staging.example.com":      "staging.example.com",
+		"API.EU-CENTRAL.example.com": "example.com",
+	} {
+		assert.True(t, domainFilter.Match(hostname), hostname)
+		_, zoneName := z.FindZone(hostname)
+		assert.Equal(t, expected, zoneName, hostname)
+	}
+}
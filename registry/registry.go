@@ -34,3 +34,10 @@ type Registry interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 	OwnerID() string
 }
+
+// ScopedRegistry is implemented by the registries able to return the records a plan needs without reading all the
+// records of the provider into memory.
+// ScopedRecords() returns the records of the given DNS names, and the records owned by this instance
+type ScopedRegistry interface {
+	ScopedRecords(ctx context.Context, dnsNames []string) ([]*endpoint.Endpoint, error)
+}
@@ -19,8 +19,10 @@ package registry
 import (
 	"context"
 	"errors"
-
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	b64 "encoding/base64"
@@ -47,6 +49,8 @@ type TXTRegistry struct {
 	recordsCache            []*endpoint.Endpoint
 	recordsCacheRefreshTime time.Time
 	cacheInterval           time.Duration
+	// protects recordsCache from concurrent ApplyChanges calls
+	cacheMutex sync.Mutex
 
 	// optional string to use to replace the asterisk in wildcard entries - without using this,
 	// registry TXT records corresponding to wildcard records will be invalid (and rejected by most providers), due to
@@ -67,8 +71,12 @@ type TXTRegistry struct {
 
 // existingTXTs stores pre‑existing TXT records to avoid duplicate creation.
 // It relies on the fact that Records() is always called **before** ApplyChanges()
-// within a single reconciliation cycle.
+// within a single reconciliation cycle, and is refreshed whenever Records() fetches from the provider.
+// In between, ApplyChanges() keeps it up to date with the TXT records it creates and deletes, as Records()
+// doesn't fetch from the provider while the records are cached. It is safe for concurrent use, as ApplyChanges()
+// is called for several zones concurrently.
 type existingTXTs struct {
+	mu      sync.Mutex
 	entries map[recordKey]struct{}
 }
 
@@ -83,26 +91,47 @@ func newExistingTXTs() *existingTXTs {
 	}
 }
 
-func (im *existingTXTs) add(r *endpoint.Endpoint) {
-	key := recordKey{
+func keyOf(r *endpoint.Endpoint) recordKey {
+	return recordKey{
 		dnsName:       r.DNSName,
 		setIdentifier: r.SetIdentifier,
 	}
-	im.entries[key] = struct{}{}
+}
+
+func (im *existingTXTs) add(r *endpoint.Endpoint) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	im.entries[keyOf(r)] = struct{}{}
 }
 
 // isAbsent returns true when there is no entry for the given name in the store.
 // This is intended for the "if absent -> create" pattern.
 func (im *existingTXTs) isAbsent(ep *endpoint.Endpoint) bool {
-	key := recordKey{
-		dnsName:       ep.DNSName,
-		setIdentifier: ep.SetIdentifier,
-	}
-	_, ok := im.entries[key]
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	_, ok := im.entries[keyOf(ep)]
 	return !ok
 }
 
+// update removes the TXT records deleted by the given changes from the store and adds those created.
+func (im *existingTXTs) update(changes *plan.Changes) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	for _, r := range slices.Concat(changes.Delete, changes.UpdateOld) {
+		if r.RecordType == endpoint.RecordTypeTXT {
+			delete(im.entries, keyOf(r))
+		}
+	}
+	for _, r := range slices.Concat(changes.Create, changes.UpdateNew) {
+		if r.RecordType == endpoint.RecordTypeTXT {
+			im.entries[keyOf(r)] = struct{}{}
+		}
+	}
+}
+
 func (im *existingTXTs) reset() {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	// Reset the existing TXT records for the next reconciliation loop.
 	// This is necessary because the existing TXT records are only relevant for the current reconciliation cycle.
 	im.entries = make(map[recordKey]struct{})
@@ -138,6 +167,13 @@ func NewTXTRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID st
 
 	mapper := newaffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement)
 
+	// the TXT records of NS records must stay out of the delegated subdomains, where the delegation hides them
+	if plan.IsManagedRecord(endpoint.RecordTypeNS, managedRecordTypes, excludeRecordTypes) {
+		if name := mapper.toTXTName("sub.example.com", endpoint.RecordTypeNS); strings.HasSuffix(name, ".sub.example.com") {
+			return nil, fmt.Errorf("txt-prefix %q places the TXT records of NS records below the delegated subdomains, use a prefix within the first label instead, e.g. %q", txtPrefix, recordTemplate+"-")
+		}
+	}
+
 	return &TXTRegistry{
 		provider:            provider,
 		ownerID:             ownerID,
@@ -153,7 +189,7 @@ func NewTXTRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID st
 }
 
 func getSupportedTypes() []string {
-	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypeMX}
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypeMX, endpoint.RecordTypeSRV, endpoint.RecordTypeSVCB, endpoint.RecordTypeHTTPS, endpoint.RecordTypeTLSA, endpoint.RecordTypeNAPTR}
 }
 
 func (im *TXTRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
@@ -175,78 +211,188 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		return im.recordsCache, nil
 	}
 
-	records, err := im.provider.Records(ctx)
+	// The existing TXT records are only relevant until the next reconciliation loop. They are kept for
+	// all ApplyChanges calls of a loop, as the changes may be applied in several batches.
+	im.existingTXTs.reset()
+
+	records := newTXTRecords()
+	err := provider.StreamRecords(ctx, im.provider, func(record *endpoint.Endpoint) error {
+		if record.RecordType == endpoint.RecordTypeTXT {
+			if ownership, err := im.addOwnershipRecord(records, record, nil); ownership || err != nil {
+				return err
+			}
+		}
+		records.endpoints = append(records.endpoints, record)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	endpoints := []*endpoint.Endpoint{}
+	endpoints := records.endpoints
+	im.labelEndpoints(records)
 
-	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
-	txtRecordsMap := map[string]struct{}{}
+	// Update the cache.
+	if im.cacheInterval > 0 {
+		im.recordsCache = endpoints
+		im.recordsCacheRefreshTime = time.Now()
+	}
 
-	for _, record := range records {
-		if record.RecordType != endpoint.RecordTypeTXT {
-			endpoints = append(endpoints, record)
-			continue
+	return endpoints, nil
+}
+
+// ScopedRecords returns the records of the given DNS names, along with the records owned by this instance, which
+// is all a plan needs. The records of the provider are streamed rather than held in memory: a first time to read
+// the ownership TXT records and the records of the given names, and a second time, only when this instance owns
+// records of other names, to read these records. The records cache is not used.
+func (im *TXTRegistry) ScopedRecords(ctx context.Context, dnsNames []string) ([]*endpoint.Endpoint, error) {
+	desired := make(map[string]bool, len(dnsNames))
+	for _, name := range dnsNames {
+		name = normalizeName(name)
+		desired[name] = true
+		if im.wildcardReplacement != "" && strings.HasPrefix(name, "*.") {
+			desired[im.wildcardReplacement+name[1:]] = true
 		}
-		// We simply assume that TXT records for the registry will always have only one target.
-		// If there are no targets (e.g for routing policy based records in google), direct targets will be empty
-		if len(record.Targets) == 0 {
-			log.Errorf("TXT record has no targets %s", record.DNSName)
-			continue
+	}
+	keep := func(key endpoint.EndpointKey, labels endpoint.Labels) bool {
+		return labels[endpoint.OwnerLabelKey] == im.ownerID || desired[normalizeName(key.DNSName)]
+	}
+
+	im.existingTXTs.reset()
+
+	records := newTXTRecords()
+	err := provider.StreamRecords(ctx, im.provider, func(record *endpoint.Endpoint) error {
+		if record.RecordType == endpoint.RecordTypeTXT {
+			if ownership, err := im.addOwnershipRecord(records, record, keep); ownership || err != nil {
+				return err
+			}
 		}
-		labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
-		if errors.Is(err, endpoint.ErrInvalidHeritage) {
-			// if no heritage is found or it is invalid
-			// case when value of txt record cannot be identified
-			// record will not be removed as it will have empty owner
-			endpoints = append(endpoints, record)
-			continue
+		if desired[normalizeName(record.DNSName)] {
+			records.endpoints = append(records.endpoints, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ownsOthers := false
+	for key, labels := range records.labelMap {
+		if labels[endpoint.OwnerLabelKey] == im.ownerID && !desired[normalizeName(key.DNSName)] {
+			ownsOthers = true
+			break
 		}
+	}
+	if ownsOthers {
+		err = provider.StreamRecords(ctx, im.provider, func(record *endpoint.Endpoint) error {
+			if desired[normalizeName(record.DNSName)] {
+				return nil
+			}
+			if record.RecordType == endpoint.RecordTypeTXT && len(record.Targets) > 0 {
+				if _, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey); !errors.Is(err, endpoint.ErrInvalidHeritage) {
+					return nil
+				}
+			}
+			if labels, ok := im.recordLabels(records.labelMap, record); ok && labels[endpoint.OwnerLabelKey] == im.ownerID {
+				records.endpoints = append(records.endpoints, record)
+			}
+			return nil
+		})
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		endpointName, recordType := im.mapper.toEndpointName(record.DNSName)
-		key := endpoint.EndpointKey{
-			DNSName:       endpointName,
-			RecordType:    recordType,
-			SetIdentifier: record.SetIdentifier,
-		}
-		labelMap[key] = labels
-		txtRecordsMap[record.DNSName] = struct{}{}
-		im.existingTXTs.add(record)
+	im.labelEndpoints(records)
+	return records.endpoints, nil
+}
+
+// txtRecords holds the records read from the provider, and the labels of the ownership TXT records among them.
+type txtRecords struct {
+	endpoints     []*endpoint.Endpoint
+	labelMap      map[endpoint.EndpointKey]endpoint.Labels
+	txtRecordsMap map[string]struct{}
+}
+
+func newTXTRecords() *txtRecords {
+	return &txtRecords{
+		endpoints:     []*endpoint.Endpoint{},
+		labelMap:      map[endpoint.EndpointKey]endpoint.Labels{},
+		txtRecordsMap: map[string]struct{}{},
 	}
+}
 
-	for _, ep := range endpoints {
-		if ep.Labels == nil {
-			ep.Labels = endpoint.NewLabels()
-		}
-		dnsNameSplit := strings.Split(ep.DNSName, ".")
-		// If specified, replace a leading asterisk in the generated txt record name with some other string
-		if im.wildcardReplacement != "" && dnsNameSplit[0] == "*" {
-			dnsNameSplit[0] = im.wildcardReplacement
-		}
-		dnsName := strings.Join(dnsNameSplit, ".")
-		key := endpoint.EndpointKey{
-			DNSName:       dnsName,
-			RecordType:    ep.RecordType,
-			SetIdentifier: ep.SetIdentifier,
-		}
+// addOwnershipRecord reads the labels of an ownership TXT record, keeping them unless keep is given and rejects
+// them. It returns false for TXT records which aren't ownership records, to be handled like other records.
+func (im *TXTRegistry) addOwnershipRecord(records *txtRecords, record *endpoint.Endpoint, keep func(endpoint.EndpointKey, endpoint.Labels) bool) (bool, error) {
+	// We simply assume that TXT records for the registry will always have only one target.
+	// If there are no targets (e.g for routing policy based records in google), direct targets will be empty
+	if len(record.Targets) == 0 {
+		log.Errorf("TXT record has no targets %s", record.DNSName)
+		return true, nil
+	}
+	labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
+	if errors.Is(err, endpoint.ErrInvalidHeritage) {
+		// if no heritage is found or it is invalid
+		// case when value of txt record cannot be identified
+		// record will not be removed as it will have empty owner
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
 
-		// AWS Alias records have "new" format encoded as type "cname"
-		if isAlias, found := ep.GetProviderSpecificProperty("alias"); found && isAlias == "true" && ep.RecordType == endpoint.RecordTypeA {
-			key.RecordType = endpoint.RecordTypeCNAME
-		}
+	endpointName, recordType := im.mapper.toEndpointName(record.DNSName)
+	key := endpoint.EndpointKey{
+		DNSName:       endpointName,
+		RecordType:    recordType,
+		SetIdentifier: record.SetIdentifier,
+	}
+	if keep != nil && !keep(key, labels) {
+		return true, nil
+	}
+	records.labelMap[key] = labels
+	records.txtRecordsMap[record.DNSName] = struct{}{}
+	im.existingTXTs.add(record)
+	return true, nil
+}
 
-		// Handle both new and old registry format with the preference for the new one
-		labels, labelsExist := labelMap[key]
-		if !labelsExist && ep.RecordType != endpoint.RecordTypeAAAA {
-			key.RecordType = ""
-			labels, labelsExist = labelMap[key]
+// recordLabels returns the labels of the ownership TXT record of a record, if there is one.
+func (im *TXTRegistry) recordLabels(labelMap map[endpoint.EndpointKey]endpoint.Labels, ep *endpoint.Endpoint) (endpoint.Labels, bool) {
+	dnsNameSplit := strings.Split(ep.DNSName, ".")
+	// If specified, replace a leading asterisk in the generated txt record name with some other string
+	if im.wildcardReplacement != "" && dnsNameSplit[0] == "*" {
+		dnsNameSplit[0] = im.wildcardReplacement
+	}
+	dnsName := strings.Join(dnsNameSplit, ".")
+	key := endpoint.EndpointKey{
+		DNSName:       dnsName,
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+	}
+
+	// AWS Alias records have "new" format encoded as type "cname"
+	if isAlias, found := ep.GetProviderSpecificProperty("alias"); found && isAlias == "true" && ep.RecordType == endpoint.RecordTypeA {
+		key.RecordType = endpoint.RecordTypeCNAME
+	}
+
+	// Handle both new and old registry format with the preference for the new one
+	labels, labelsExist := labelMap[key]
+	if !labelsExist && ep.RecordType != endpoint.RecordTypeAAAA {
+		key.RecordType = ""
+		labels, labelsExist = labelMap[key]
+	}
+	return labels, labelsExist
+}
+
+// labelEndpoints sets the labels of the records from their ownership TXT records, and flags the records owned by
+// this instance whose TXT records are missing for an update.
+func (im *TXTRegistry) labelEndpoints(records *txtRecords) {
+	for _, ep := range records.endpoints {
+		if ep.Labels == nil {
+			ep.Labels = endpoint.NewLabels()
 		}
-		if labelsExist {
+		if labels, labelsExist := im.recordLabels(records.labelMap, ep); labelsExist {
 			for k, v := range labels {
 				ep.Labels[k] = v
 			}
@@ -254,26 +400,23 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 
 		// Handle the migration of TXT records created before the new format (introduced in v0.12.0).
 		// The migration is done for the TXT records owned by this instance only.
-		if len(txtRecordsMap) > 0 && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID {
+		if len(records.txtRecordsMap) > 0 && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID {
 			if plan.IsManagedRecord(ep.RecordType, im.managedRecordTypes, im.excludeRecordTypes) {
 				// Get desired TXT records and detect the missing ones
 				desiredTXTs := im.generateTXTRecord(ep)
 				for _, desiredTXT := range desiredTXTs {
-					if _, exists := txtRecordsMap[desiredTXT.DNSName]; !exists {
+					if _, exists := records.txtRecordsMap[desiredTXT.DNSName]; !exists {
 						ep.WithProviderSpecific(providerSpecificForceUpdate, "true")
 					}
 				}
 			}
 		}
 	}
+}
 
-	// Update the cache.
-	if im.cacheInterval > 0 {
-		im.recordsCache = endpoints
-		im.recordsCacheRefreshTime = time.Now()
-	}
-
-	return endpoints, nil
+// normalizeName returns the lower case DNS name without its trailing dot, to compare names.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
 }
 
 // generateTXTRecord generates TXT records in either both formats (old and new) or new format only,
@@ -307,14 +450,21 @@ func (im *TXTRegistry) generateTXTRecordWithFilter(r *endpoint.Endpoint, filter
 // ApplyChanges updates dns provider with the changes
 // for each created/deleted record it will also take into account TXT records for creation/deletion
 func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
-	defer im.existingTXTs.reset() // reset existing TXTs for the next reconciliation loop
-
+	changes = changes.ExcludeRecordTypes(im.excludeRecordTypes)
 	filteredChanges := &plan.Changes{
 		Create:    changes.Create,
 		UpdateNew: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
 		UpdateOld: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
 		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
 	}
+	// the changes of the records themselves, without their TXT records, to update the cache with once applied
+	cacheChanges := &plan.Changes{
+		Create:    filteredChanges.Create,
+		UpdateNew: filteredChanges.UpdateNew,
+		UpdateOld: filteredChanges.UpdateOld,
+		Delete:    filteredChanges.Delete,
+	}
+
 	for _, r := range filteredChanges.Create {
 		if r.Labels == nil {
 			r.Labels = make(map[string]string)
@@ -322,10 +472,6 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
 
 		filteredChanges.Create = append(filteredChanges.Create, im.generateTXTRecordWithFilter(r, im.existingTXTs.isAbsent)...)
-
-		if im.cacheInterval > 0 {
-			im.addToCache(r)
-		}
 	}
 
 	for _, r := range filteredChanges.Delete {
@@ -333,10 +479,6 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
 		// !!! After migration to the new TXT registry format we can drop records in old format here!!!
 		filteredChanges.Delete = append(filteredChanges.Delete, im.generateTXTRecord(r)...)
-
-		if im.cacheInterval > 0 {
-			im.removeFromCache(r)
-		}
 	}
 
 	// make sure TXT records are consistently updated as well
@@ -344,26 +486,28 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		// when we updateOld TXT records for which value has changed (due to new label) this would still work because
 		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
 		filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecord(r)...)
-		// remove old version of record from cache
-		if im.cacheInterval > 0 {
-			im.removeFromCache(r)
-		}
 	}
 
 	// make sure TXT records are consistently updated as well
 	for _, r := range filteredChanges.UpdateNew {
 		filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecord(r)...)
-		// add new version of record to cache
-		if im.cacheInterval > 0 {
-			im.addToCache(r)
-		}
 	}
 
 	// when caching is enabled, disable the provider from using the cache
 	if im.cacheInterval > 0 {
 		ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
 	}
-	return im.provider.ApplyChanges(ctx, filteredChanges)
+	if err := im.provider.ApplyChanges(ctx, filteredChanges); err != nil {
+		// Part of the changes may have been applied, the records are listed again on the next call to Records.
+		im.resetCache()
+		return err
+	}
+	im.existingTXTs.update(filteredChanges)
+
+	if im.cacheInterval > 0 {
+		im.updateCache(cacheChanges)
+	}
+	return nil
 }
 
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
@@ -519,22 +663,39 @@ func (pr affixNameMapper) toTXTName(endpointDNSName, recordType string) string {
 	return prefix + DNSName[0] + suffix + "." + DNSName[1]
 }
 
-func (im *TXTRegistry) addToCache(ep *endpoint.Endpoint) {
-	if im.recordsCache != nil {
-		im.recordsCache = append(im.recordsCache, ep)
+// updateCache applies the changes to the cached records in a single pass, so that the cache stays in line with the
+// provider without listing its records again. The cache is replaced rather than changed in place, as the records
+// returned by Records may still be in use, e.g. by changes applied concurrently.
+func (im *TXTRegistry) updateCache(changes *plan.Changes) {
+	im.cacheMutex.Lock()
+	defer im.cacheMutex.Unlock()
+	if im.recordsCache == nil {
+		return
 	}
-}
 
-func (im *TXTRegistry) removeFromCache(ep *endpoint.Endpoint) {
-	if im.recordsCache == nil || ep == nil {
-		return
+	removed := make(map[endpoint.EndpointKey][]*endpoint.Endpoint, len(changes.UpdateOld)+len(changes.Delete))
+	for _, ep := range slices.Concat(changes.UpdateOld, changes.Delete) {
+		removed[ep.Key()] = append(removed[ep.Key()], ep)
 	}
 
-	for i, e := range im.recordsCache {
-		if e.DNSName == ep.DNSName && e.RecordType == ep.RecordType && e.SetIdentifier == ep.SetIdentifier && e.Targets.Same(ep.Targets) {
-			// We found a match delete the endpoint from the cache.
-			im.recordsCache = append(im.recordsCache[:i], im.recordsCache[i+1:]...)
-			return
+	records := make([]*endpoint.Endpoint, 0, len(im.recordsCache)+len(changes.Create)+len(changes.UpdateNew))
+	for _, e := range im.recordsCache {
+		key := e.Key()
+		if i := slices.IndexFunc(removed[key], func(ep *endpoint.Endpoint) bool { return e.Targets.Same(ep.Targets) }); i >= 0 {
+			// each change removes a single record
+			removed[key] = slices.Delete(removed[key], i, i+1)
+			continue
 		}
+		records = append(records, e)
 	}
+	records = append(records, changes.Create...)
+	records = append(records, changes.UpdateNew...)
+	im.recordsCache = records
+}
+
+// resetCache drops the cached records, so that they're listed again on the next call to Records.
+func (im *TXTRegistry) resetCache() {
+	im.cacheMutex.Lock()
+	defer im.cacheMutex.Unlock()
+	im.recordsCache = nil
 }
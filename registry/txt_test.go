@@ -18,9 +18,12 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -85,6 +88,15 @@ func testTXTRegistryNew(t *testing.T) {
 
 	_, ok = r.mapper.(affixNameMapper)
 	assert.True(t, ok)
+
+	_, err = NewTXTRegistry(p, "txt-%{record_type}.", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeNS}, []string{}, false, nil)
+	require.ErrorContains(t, err, "below the delegated subdomains")
+
+	_, err = NewTXTRegistry(p, "txt-%{record_type}.", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeNS}, []string{endpoint.RecordTypeNS}, false, nil)
+	require.NoError(t, err)
+
+	_, err = NewTXTRegistry(p, "txt.", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeNS}, []string{}, false, nil)
+	require.NoError(t, err)
 }
 
 func testTXTRegistryRecords(t *testing.T) {
@@ -1215,30 +1227,100 @@ func TestCacheMethods(t *testing.T) {
 		newEndpointWithOwner("thing5.com", "1.2.3.5", "A", "owner"),
 	}
 	// test add cache
-	registry.addToCache(newEndpointWithOwner("thing4.com", "2001:DB8::1", "AAAA", "owner"))
-	registry.addToCache(newEndpointWithOwner("thing5.com", "1.2.3.5", "A", "owner"))
+	registry.updateCache(&plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("thing4.com", "2001:DB8::1", "AAAA", "owner"),
+			newEndpointWithOwner("thing5.com", "1.2.3.5", "A", "owner"),
+		},
+	})
 
 	if !reflect.DeepEqual(expectedCacheAfterAdd, registry.recordsCache) {
 		t.Fatalf("expected endpoints should match endpoints from cache: expected %v, but got %v", expectedCacheAfterAdd, registry.recordsCache)
 	}
 
 	// test update cache
-	registry.removeFromCache(newEndpointWithOwner("thing.com", "1.2.3.4", "A", "owner"))
-	registry.addToCache(newEndpointWithOwner("thing.com", "1.2.3.6", "A", "owner2"))
-	registry.removeFromCache(newEndpointWithOwner("thing4.com", "2001:DB8::1", "AAAA", "owner"))
-	registry.addToCache(newEndpointWithOwner("thing4.com", "2001:DB8::2", "AAAA", "owner"))
+	registry.updateCache(&plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			newEndpointWithOwner("thing.com", "1.2.3.4", "A", "owner"),
+			newEndpointWithOwner("thing4.com", "2001:DB8::1", "AAAA", "owner"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			newEndpointWithOwner("thing.com", "1.2.3.6", "A", "owner2"),
+			newEndpointWithOwner("thing4.com", "2001:DB8::2", "AAAA", "owner"),
+		},
+	})
 	// ensure it was updated
 	if !reflect.DeepEqual(expectedCacheAfterUpdate, registry.recordsCache) {
 		t.Fatalf("expected endpoints should match endpoints from cache: expected %v, but got %v", expectedCacheAfterUpdate, registry.recordsCache)
 	}
 
 	// test deleting a record
-	registry.removeFromCache(newEndpointWithOwner("thing.com", "1.2.3.6", "A", "owner2"))
-	registry.removeFromCache(newEndpointWithOwner("thing4.com", "2001:DB8::2", "AAAA", "owner"))
+	registry.updateCache(&plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			newEndpointWithOwner("thing.com", "1.2.3.6", "A", "owner2"),
+			newEndpointWithOwner("thing4.com", "2001:DB8::2", "AAAA", "owner"),
+		},
+	})
 	// ensure it was deleted
 	if !reflect.DeepEqual(expectedCacheAfterDelete, registry.recordsCache) {
 		t.Fatalf("expected endpoints should match endpoints from cache: expected %v, but got %v", expectedCacheAfterDelete, registry.recordsCache)
 	}
+
+	// the records previously returned are left as they were
+	assert.Len(t, cache, 5)
+	assert.Equal(t, "thing.com", cache[0].DNSName)
+}
+
+type countingProvider struct {
+	provider.Provider
+	records  int
+	applyErr error
+}
+
+func (p *countingProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	p.records++
+	return p.Provider.Records(ctx)
+}
+
+func (p *countingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if p.applyErr != nil {
+		return p.applyErr
+	}
+	return p.Provider.ApplyChanges(ctx, changes)
+}
+
+func TestTXTRegistryApplyChangesUpdatesCache(t *testing.T) {
+	ctx := context.Background()
+	inmemoryProvider := inmemory.NewInMemoryProvider()
+	inmemoryProvider.CreateZone(testZone)
+	p := &countingProvider{Provider: inmemoryProvider}
+	r, err := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil)
+	require.NoError(t, err)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, 1, p.records)
+
+	// applied changes are reflected in the cache without listing the records again
+	created := newEndpointWithOwner("new-record-1.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{created}}))
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwner("new-record-1.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner"),
+	}))
+	assert.Equal(t, 1, p.records)
+
+	// failed changes drop the cache, as part of them may have been applied
+	p.applyErr = errors.New("failed to apply changes")
+	require.Error(t, r.ApplyChanges(ctx, &plan.Changes{Delete: records}))
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, 2, p.records)
 }
 
 func TestDropPrefix(t *testing.T) {
@@ -1771,6 +1853,30 @@ func TestApplyChangesWithNewFormatOnly(t *testing.T) {
 	}
 }
 
+func TestTXTRegistryApplyChangesExcludedRecordTypes(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	ctx := context.Background()
+
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeNS}, []string{"ns"}, false, nil)
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("new-record.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner"),
+			newEndpointWithOwner("sub.test-zone.example.org", "ns1.example.net", endpoint.RecordTypeNS, "owner"),
+		},
+	}
+	require.NoError(t, r.ApplyChanges(ctx, changes))
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		assert.NotEqual(t, "sub.test-zone.example.org", record.DNSName)
+		assert.NotEqual(t, endpoint.RecordTypeNS, record.RecordType)
+	}
+	assert.NotEmpty(t, records)
+}
+
 func TestTXTRegistryRecordsWithEmptyTargets(t *testing.T) {
 	ctx := context.Background()
 	p := inmemory.NewInMemoryProvider()
@@ -1809,6 +1915,65 @@ func TestTXTRegistryRecordsWithEmptyTargets(t *testing.T) {
 	testutils.TestHelperLogContains("TXT record has no targets empty-targets.test-zone.example.org", hook, t)
 }
 
+func TestTXTRegistryScopedRecords(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("bar.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("txt.a-bar.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner-2\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("baz.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("qux.test-zone.example.org", "random", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("*.wildcard.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("txt.cname-wc.wildcard.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner-2\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("mail.test-zone.example.org", "10 onemail.example.com", endpoint.RecordTypeMX, ""),
+			newEndpointWithOwner("txt.mx-mail.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	})
+
+	for _, tc := range []struct {
+		name     string
+		dnsNames []string
+		expected []string
+	}{
+		{
+			name:     "owned records only",
+			expected: []string{"foo.test-zone.example.org", "mail.test-zone.example.org"},
+		},
+		{
+			name:     "records of other owners and unowned records",
+			dnsNames: []string{"Bar.test-zone.example.org.", "baz.test-zone.example.org", "qux.test-zone.example.org"},
+			expected: []string{"foo.test-zone.example.org", "mail.test-zone.example.org", "bar.test-zone.example.org", "baz.test-zone.example.org", "qux.test-zone.example.org"},
+		},
+		{
+			name:     "wildcard records",
+			dnsNames: []string{"*.wildcard.test-zone.example.org", "foo.test-zone.example.org"},
+			expected: []string{"foo.test-zone.example.org", "mail.test-zone.example.org", "*.wildcard.test-zone.example.org"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, _ := NewTXTRegistry(p, "txt.%{record_type}-", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil)
+			all, err := r.Records(ctx)
+			require.NoError(t, err)
+			expected := make([]*endpoint.Endpoint, 0, len(tc.expected))
+			for _, ep := range all {
+				if slices.Contains(tc.expected, ep.DNSName) {
+					expected = append(expected, ep)
+				}
+			}
+			require.Len(t, expected, len(tc.expected))
+
+			r, _ = NewTXTRegistry(p, "txt.%{record_type}-", "", "owner", time.Hour, "wc", []string{}, []string{}, false, nil)
+			records, err := r.ScopedRecords(ctx, tc.dnsNames)
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(records, expected), "expected %v, got %v", expected, records)
+		})
+	}
+}
+
 // TestTXTRegistryRecreatesMissingRecords reproduces issue #4914.
 // It verifies that External‑DNS recreates A/CNAME records that were accidentally deleted while their corresponding TXT records remain.
 // An InMemoryProvider is used because, like Route53, it throws an error when attempting to create a duplicate record.
@@ -2028,3 +2193,128 @@ func TestTXTRegistryRecreatesMissingRecords(t *testing.T) {
 		}
 	}
 }
+
+func TestTXTRegistryApplyChangesInBatches(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("a-record-2.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, "owner"),
+	}}))
+
+	var created []*endpoint.Endpoint
+	p.OnApplyChanges = func(ctx context.Context, changes *plan.Changes) {
+		created = append(created, changes.Create...)
+	}
+
+	r, err := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA}, nil, false, nil)
+	require.NoError(t, err)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+
+	// the changes of a single reconciliation loop applied in two batches
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("record-1.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+	}}))
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("record-2.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, ""),
+	}}))
+
+	var names []string
+	for _, ep := range created {
+		names = append(names, ep.RecordType+" "+ep.DNSName)
+	}
+	assert.ElementsMatch(t, []string{
+		"A record-1.test-zone.example.org",
+		"TXT a-record-1.test-zone.example.org",
+		"A record-2.test-zone.example.org",
+	}, names, "existing TXT record should not be recreated by a later batch")
+}
+
+func TestTXTRegistryRecreatesDeletedRecordWithinCacheInterval(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+		newEndpointWithOwner("a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+	}}))
+
+	r, err := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA}, nil, false, nil)
+	require.NoError(t, err)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	// the record is deleted, then recreated by a later loop which gets the records from the cache
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Delete: records}))
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+	}}))
+
+	zoneRecords, err := p.Records(ctx)
+	require.NoError(t, err)
+	var names []string
+	for _, ep := range zoneRecords {
+		names = append(names, ep.RecordType+" "+ep.DNSName)
+	}
+	assert.ElementsMatch(t, []string{
+		"A foo.test-zone.example.org",
+		"TXT a-foo.test-zone.example.org",
+	}, names, "the ownership TXT record of the recreated record should be created again")
+}
+
+// concurrentProvider applies changes to nothing, as the in-memory provider isn't safe for concurrent use.
+type concurrentProvider struct {
+	provider.BaseProvider
+}
+
+func (concurrentProvider) Records(context.Context) ([]*endpoint.Endpoint, error) {
+	return nil, nil
+}
+
+func (concurrentProvider) ApplyChanges(context.Context, *plan.Changes) error {
+	return nil
+}
+
+func TestTXTRegistryConcurrentApplyChanges(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewTXTRegistry(concurrentProvider{}, "", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA}, nil, false, nil)
+	require.NoError(t, err)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+
+	// the controller applies the changes of several zones concurrently
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("foo.zone-%d.example.org", i)
+			assert.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+				newEndpointWithOwner(name, "1.1.1.1", endpoint.RecordTypeA, ""),
+			}}))
+		}()
+	}
+	wg.Wait()
+
+	for i := range 10 {
+		txt := newEndpointWithOwner(fmt.Sprintf("a-foo.zone-%d.example.org", i), "", endpoint.RecordTypeTXT, "")
+		assert.False(t, r.existingTXTs.isAbsent(txt), "the ownership TXT record of zone %d should be known", i)
+	}
+}
+
+func TestTXTRegistryIgnoresConflictLabels(t *testing.T) {
+	r, err := NewTXTRegistry(inmemory.NewInMemoryProvider(), "", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA}, nil, false, nil)
+	require.NoError(t, err)
+
+	record := newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "owner", "ingress/default/foo")
+	labeled := record.DeepCopy().
+		WithLabel(endpoint.ResourceCreatedLabelKey, "1700000000").
+		WithLabel(endpoint.PriorityLabelKey, "5")
+
+	// the labels only read by some conflict resolution strategies don't change the ownership TXT records
+	assert.Equal(t, r.generateTXTRecord(record), r.generateTXTRecord(labeled))
+}
@@ -174,6 +174,8 @@ func (sc *ambassadorHostSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			continue
 		}
 
+		decorateEndpoints(ctx, host, hostEndpoints)
+
 		log.Debugf("Endpoints generated from Host: %s: %v", fullname, hostEndpoints)
 		endpoints = append(endpoints, hostEndpoints...)
 	}
@@ -214,7 +216,7 @@ func (sc *ambassadorHostSource) targetsFromAmbassadorLoadBalancer(ctx context.Co
 		return nil, err
 	}
 
-	targets := extractLoadBalancerTargets(svc, false)
+	targets := extractLoadBalancerTargets(svc, false, "")
 
 	return targets, nil
 }
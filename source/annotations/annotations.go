@@ -44,6 +44,8 @@ const (
 	ControllerKey = AnnotationKeyPrefix + "controller"
 	// HostnameKey The annotation used for defining the desired hostname
 	HostnameKey = AnnotationKeyPrefix + "hostname"
+	// HostnameTemplateKey The annotation used for defining desired hostnames by a template evaluated against the resource
+	HostnameTemplateKey = AnnotationKeyPrefix + "hostname-template"
 	// AccessKey The annotation used for specifying whether the public or private interface address is used
 	AccessKey = AnnotationKeyPrefix + "access"
 	// EndpointsTypeKey The annotation used for specifying the type of endpoints to use for headless services
@@ -57,4 +59,58 @@ const (
 	ControllerValue = "dns-controller"
 	// InternalHostnameKey The annotation used for defining the desired hostname
 	InternalHostnameKey = AnnotationKeyPrefix + "internal-hostname"
+	// ProtectKey The annotation used for protecting records from deletion and takeover
+	ProtectKey = AnnotationKeyPrefix + "protect"
+	// PriorityKey The annotation used for resolving conflicts between resources requesting the same hostname
+	PriorityKey = AnnotationKeyPrefix + "priority"
+	// WeightKey The annotation used for defining the relative weight of the targets of a resource, for providers with weighted routing
+	WeightKey = AnnotationKeyPrefix + "weight"
+	// GeoContinentKey The annotation used for defining the continent whose clients the records of a resource answer, e.g. "EU"
+	GeoContinentKey = AnnotationKeyPrefix + "geo-continent"
+	// GeoCountryKey The annotation used for defining the country whose clients the records of a resource answer, e.g. "DE"
+	GeoCountryKey = AnnotationKeyPrefix + "geo-country"
+	// GeoRegionKey The annotation used for defining the region of the country whose clients the records of a resource answer, e.g. "CA"
+	GeoRegionKey = AnnotationKeyPrefix + "geo-region"
+	// DescriptionKey The annotation used for defining the human-readable description of the records of a resource,
+	// published as the comment of the records by providers supporting it
+	DescriptionKey = AnnotationKeyPrefix + "description"
+	// StatusKey The annotation set on resources once their records have been applied, when enabled
+	StatusKey = AnnotationKeyPrefix + "status"
+	// MXKey The annotation used for defining the MX records of the hostnames of a resource, e.g. "10 mail.example.com"
+	MXKey = AnnotationKeyPrefix + "mx"
+	// SRVKey The annotation used for defining the SRV records of the hostnames of a resource, e.g. "_sip._udp 10 5 5060 sip.example.com"
+	SRVKey = AnnotationKeyPrefix + "srv"
+	// HTTPSKey The annotation used for defining the HTTPS records of the hostnames of a resource, e.g. "1 . alpn=h3,h2"
+	HTTPSKey = AnnotationKeyPrefix + "https"
+	// TLSAKey The annotation used for defining the ports of the TLSA records published for a certificate, e.g. "_443._tcp"
+	TLSAKey = AnnotationKeyPrefix + "tlsa"
+	// ClusterKey The annotation used for defining the member cluster a resource was propagated from by a multi-cluster control plane, e.g. "member-1"
+	ClusterKey = AnnotationKeyPrefix + "cluster"
+	// PublishNotReadyAddressesKey The annotation used for defining whether the not ready addresses of a headless service are published,
+	// overriding its publishNotReadyAddresses field and --always-publish-not-ready-addresses
+	PublishNotReadyAddressesKey = AnnotationKeyPrefix + "publish-not-ready-addresses"
+	// HeadlessRecordsKey The annotation used for defining the records of a headless service, e.g. "aggregate,per-pod"
+	HeadlessRecordsKey = AnnotationKeyPrefix + "headless-records"
+	// HeadlessPodNameKey The annotation used for defining how the per-pod records of a headless service are named, "hostname" or "pod-name"
+	HeadlessPodNameKey = AnnotationKeyPrefix + "headless-pod-name"
+	// PreferTargetTypeKey The annotation used for defining which target of a load balancer with both an IP address and a hostname
+	// is published, "ip" or "hostname", overriding --prefer-target-type
+	PreferTargetTypeKey = AnnotationKeyPrefix + "prefer-target-type"
+)
+
+const (
+	// HeadlessRecordsAggregate is the record of the hostname of a headless service with the addresses of all its pods
+	HeadlessRecordsAggregate = "aggregate"
+	// HeadlessRecordsPerPod are the records of each pod of a headless service, named after the pod within its hostname
+	HeadlessRecordsPerPod = "per-pod"
+	// HeadlessRecordsPerOrdinal are the records of each StatefulSet pod of a headless service, named after its ordinal within its hostname
+	HeadlessRecordsPerOrdinal = "per-ordinal"
+	// HeadlessPodNameHostname names the per-pod records after the hostname of the pod spec, pods without one having none
+	HeadlessPodNameHostname = "hostname"
+	// HeadlessPodNamePodName names the per-pod records after the pod name
+	HeadlessPodNamePodName = "pod-name"
+	// TargetTypeIP prefers the IP address of a load balancer, published as an A or AAAA record
+	TargetTypeIP = "ip"
+	// TargetTypeHostname prefers the hostname of a load balancer, published as a CNAME record
+	TargetTypeHostname = "hostname"
 )
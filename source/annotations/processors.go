@@ -14,6 +14,7 @@ limitations under the License.
 package annotations
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -49,6 +50,207 @@ func TTLFromAnnotations(annotations map[string]string, resource string) endpoint
 	return endpoint.TTL(ttlValue)
 }
 
+// PriorityFromAnnotations extracts the conflict resolution priority from the annotations of the given resource.
+// The second return value is false if the annotation is missing or invalid.
+func PriorityFromAnnotations(annotations map[string]string, resource string) (int64, bool) {
+	priorityAnnotation, ok := annotations[PriorityKey]
+	if !ok {
+		return 0, false
+	}
+	priority, err := strconv.ParseInt(strings.TrimSpace(priorityAnnotation), 10, 64)
+	if err != nil {
+		log.Warnf("%s: %q is not a valid priority value: %v", resource, priorityAnnotation, err)
+		return 0, false
+	}
+	return priority, true
+}
+
+// WeightFromAnnotations extracts the weight of the targets from the annotations of the given resource.
+// The second return value is false if the annotation is missing or invalid.
+func WeightFromAnnotations(annotations map[string]string, resource string) (int64, bool) {
+	weightAnnotation, ok := annotations[WeightKey]
+	if !ok {
+		return 0, false
+	}
+	weight, err := parseWeight(weightAnnotation)
+	if err != nil {
+		log.Warnf("%s: %v", resource, err)
+		return 0, false
+	}
+	return weight, true
+}
+
+// ClusterFromAnnotations extracts the member cluster a resource was propagated from out of the annotations of the
+// given resource. The second return value is false if the annotation is missing or invalid.
+func ClusterFromAnnotations(annotations map[string]string, resource string) (string, bool) {
+	clusterAnnotation, ok := annotations[ClusterKey]
+	if !ok {
+		return "", false
+	}
+	cluster, err := parseCluster(clusterAnnotation)
+	if err != nil {
+		log.Warnf("%s: %v", resource, err)
+		return "", false
+	}
+	return cluster, true
+}
+
+// parseCluster parses a cluster name, which must be a valid DNS label so that it can be stored in registry labels.
+func parseCluster(value string) (string, error) {
+	cluster := strings.TrimSpace(value)
+	if err := validateLabel(cluster); err != nil {
+		return "", fmt.Errorf("%q is not a valid cluster name: %w", value, err)
+	}
+	return strings.ToLower(cluster), nil
+}
+
+// parseWeight parses a weight, which must be a non-negative integer.
+func parseWeight(value string) (int64, error) {
+	weight, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || weight < 0 {
+		return 0, fmt.Errorf("%q is not a valid weight, it must be a non-negative integer", value)
+	}
+	return weight, nil
+}
+
+// GeoFromAnnotations extracts the geo location from the annotations of the given resource, or returns nil if
+// the annotations are missing or invalid.
+func GeoFromAnnotations(annotations map[string]string, resource string) *endpoint.GeoLocation {
+	geo, err := geoFromAnnotations(annotations)
+	if err != nil {
+		log.Warnf("%s: %v", resource, err)
+		return nil
+	}
+	return geo
+}
+
+// geoFromAnnotations parses the geo annotations, whose codes are case-insensitive.
+func geoFromAnnotations(annotations map[string]string) (*endpoint.GeoLocation, error) {
+	geo := endpoint.GeoLocation{
+		Continent: strings.ToUpper(strings.TrimSpace(annotations[GeoContinentKey])),
+		Country:   strings.ToUpper(strings.TrimSpace(annotations[GeoCountryKey])),
+		Region:    strings.ToUpper(strings.TrimSpace(annotations[GeoRegionKey])),
+	}
+	if geo == (endpoint.GeoLocation{}) {
+		return nil, nil
+	}
+	if err := geo.Validate(); err != nil {
+		return nil, err
+	}
+	return &geo, nil
+}
+
+// PublishNotReadyAddressesFromAnnotations extracts whether the not ready addresses of a headless service are
+// published from the annotations of the given resource. The second return value is false if the annotation is
+// missing or invalid.
+func PublishNotReadyAddressesFromAnnotations(annotations map[string]string, resource string) (bool, bool) {
+	value, ok := annotations[PublishNotReadyAddressesKey]
+	if !ok {
+		return false, false
+	}
+	publish, err := parseBool(value)
+	if err != nil {
+		log.Warnf("%s: %s: %v", resource, PublishNotReadyAddressesKey, err)
+		return false, false
+	}
+	return publish, true
+}
+
+// HeadlessRecordsFromAnnotations extracts the set of records of a headless service from the annotations of the
+// given resource. Without a valid annotation, both the aggregate and the per-pod records are published.
+func HeadlessRecordsFromAnnotations(annotations map[string]string, resource string) map[string]bool {
+	defaultRecords := map[string]bool{HeadlessRecordsAggregate: true, HeadlessRecordsPerPod: true}
+	value, ok := annotations[HeadlessRecordsKey]
+	if !ok {
+		return defaultRecords
+	}
+	records, err := parseHeadlessRecords(value)
+	if err != nil {
+		log.Warnf("%s: %v", resource, err)
+		return defaultRecords
+	}
+	return records
+}
+
+// HeadlessPodNameFromAnnotations extracts how the per-pod records of a headless service are named from the annotations
+// of the given resource: after the hostname of the pod spec, unless the annotation validly says otherwise.
+func HeadlessPodNameFromAnnotations(annotations map[string]string, resource string) string {
+	value, ok := annotations[HeadlessPodNameKey]
+	if !ok {
+		return HeadlessPodNameHostname
+	}
+	podName, err := parseHeadlessPodName(value)
+	if err != nil {
+		log.Warnf("%s: %v", resource, err)
+		return HeadlessPodNameHostname
+	}
+	return podName
+}
+
+// parseBool parses a boolean, which must be true or false.
+func parseBool(value string) (bool, error) {
+	switch strings.TrimSpace(value) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not a valid boolean, expected true or false", value)
+}
+
+// parseHeadlessRecords parses a comma-separated list of headless records.
+func parseHeadlessRecords(value string) (map[string]bool, error) {
+	records := map[string]bool{}
+	for record := range strings.SplitSeq(value, ",") {
+		switch record = strings.TrimSpace(record); record {
+		case HeadlessRecordsAggregate, HeadlessRecordsPerPod, HeadlessRecordsPerOrdinal:
+			records[record] = true
+		default:
+			return nil, fmt.Errorf("%q is not a valid headless record, expected %s, %s or %s", record,
+				HeadlessRecordsAggregate, HeadlessRecordsPerPod, HeadlessRecordsPerOrdinal)
+		}
+	}
+	return records, nil
+}
+
+// parseHeadlessPodName parses how the per-pod records of a headless service are named.
+func parseHeadlessPodName(value string) (string, error) {
+	switch podName := strings.TrimSpace(value); podName {
+	case HeadlessPodNameHostname, HeadlessPodNamePodName:
+		return podName, nil
+	}
+	return "", fmt.Errorf("%q is not a valid headless pod name, expected %s or %s", value, HeadlessPodNameHostname, HeadlessPodNamePodName)
+}
+
+// PreferTargetTypeFromAnnotations extracts which target of a load balancer with both an IP address and a hostname is
+// published from the annotations of the given resource, the given default one without a valid annotation.
+func PreferTargetTypeFromAnnotations(annotations map[string]string, defaultTargetType, resource string) string {
+	value, ok := annotations[PreferTargetTypeKey]
+	if !ok {
+		return defaultTargetType
+	}
+	targetType, err := parseTargetType(value)
+	if err != nil {
+		log.Warnf("%s: %v", resource, err)
+		return defaultTargetType
+	}
+	return targetType
+}
+
+// parseTargetType parses a preferred target type.
+func parseTargetType(value string) (string, error) {
+	switch targetType := strings.TrimSpace(value); targetType {
+	case TargetTypeIP, TargetTypeHostname:
+		return targetType, nil
+	}
+	return "", fmt.Errorf("%q is not a valid target type, expected %s or %s", value, TargetTypeIP, TargetTypeHostname)
+}
+
+// IsProtectedFromAnnotations returns true if the protect annotation of the given resource is set to "true".
+func IsProtectedFromAnnotations(annotations map[string]string) bool {
+	return annotations[ProtectKey] == "true"
+}
+
 // parseTTL parses TTL from string, returning duration in seconds.
 // parseTTL supports both integers like "600" and durations based
 // on Go Duration like "10m", hence "600" and "10m" represent the same value.
@@ -98,6 +300,98 @@ func TargetsFromTargetAnnotation(annotations map[string]string) endpoint.Targets
 	return targets
 }
 
+// MXTargetsFromAnnotations returns the targets of the comma-separated "mx" annotation in the form
+// "<preference> <host>", or an error if one of them isn't valid.
+func MXTargetsFromAnnotations(annotations map[string]string) (endpoint.Targets, error) {
+	mxAnnotation, ok := annotations[MXKey]
+	if !ok || strings.TrimSpace(mxAnnotation) == "" {
+		return nil, nil
+	}
+	var targets endpoint.Targets
+	for _, target := range strings.Split(mxAnnotation, ",") {
+		mx, err := endpoint.NewMXRecord(target)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, fmt.Sprintf("%d %s", *mx.GetPriority(), strings.TrimSuffix(*mx.GetHost(), ".")))
+	}
+	return targets, nil
+}
+
+// HTTPSTargetsFromAnnotations returns the targets of the "https" annotation, separated by semicolons since
+// parameter values contain commas, in the form "<priority> <target> <params>", or an error if one of them isn't valid.
+func HTTPSTargetsFromAnnotations(annotations map[string]string) (endpoint.Targets, error) {
+	httpsAnnotation, ok := annotations[HTTPSKey]
+	if !ok || strings.TrimSpace(httpsAnnotation) == "" {
+		return nil, nil
+	}
+	var targets endpoint.Targets
+	for _, target := range strings.Split(httpsAnnotation, ";") {
+		svcb, err := endpoint.NewSVCBRecord(target)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, svcb.String())
+	}
+	return targets, nil
+}
+
+// SRVTargetsFromAnnotations returns the targets of the comma-separated "srv" annotation by service,
+// e.g. "_sip._udp", in the form "<priority> <weight> <port> <host>", or an error if one of them isn't valid.
+func SRVTargetsFromAnnotations(annotations map[string]string) (map[string]endpoint.Targets, error) {
+	srvAnnotation, ok := annotations[SRVKey]
+	if !ok || strings.TrimSpace(srvAnnotation) == "" {
+		return nil, nil
+	}
+	services := make(map[string]endpoint.Targets)
+	for _, value := range strings.Split(srvAnnotation, ",") {
+		service, target, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if err := validateSRVService(service); err != nil {
+			return nil, err
+		}
+		srv, err := endpoint.NewSRVRecord(target)
+		if err != nil {
+			return nil, err
+		}
+		// a host of "." means the service is not available at this domain
+		host := *srv.GetHost()
+		if host != "." {
+			host = strings.TrimSuffix(host, ".")
+		}
+		service = strings.ToLower(service)
+		services[service] = append(services[service], fmt.Sprintf("%d %d %d %s", *srv.GetPriority(), *srv.GetWeight(), *srv.GetPort(), host))
+	}
+	return services, nil
+}
+
+// TLSAPortsFromAnnotations returns the ports of the comma-separated "tlsa" annotation in the form
+// "_<port>._<proto>", e.g. "_443._tcp", or an error if one of them isn't valid.
+func TLSAPortsFromAnnotations(annotations map[string]string) ([]string, error) {
+	tlsaAnnotation, ok := annotations[TLSAKey]
+	if !ok || strings.TrimSpace(tlsaAnnotation) == "" {
+		return nil, nil
+	}
+	var ports []string
+	for _, value := range strings.Split(tlsaAnnotation, ",") {
+		port := strings.ToLower(strings.TrimSpace(value))
+		number, _, _ := strings.Cut(strings.TrimPrefix(port, "_"), ".")
+		if _, err := strconv.ParseUint(number, 10, 16); err != nil || validateSRVService(port) != nil {
+			return nil, fmt.Errorf("invalid TLSA port %q, it must be of the form '_port._proto', e.g. '_443._tcp'", value)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// validateSRVService checks that the given service is of the form "_service._proto".
+func validateSRVService(service string) error {
+	name, proto, ok := strings.Cut(service, ".")
+	if !ok || len(name) < 2 || len(proto) < 2 || name[0] != '_' || proto[0] != '_' || strings.Contains(proto, ".") {
+		return fmt.Errorf("invalid SRV service %q, it must be of the form '_service._proto', e.g. '_sip._udp'", service)
+	}
+	return nil
+}
+
 // HostnamesFromAnnotations extracts the hostnames from the given annotations map.
 // It returns a slice of hostnames if the HostnameKey annotation is present, otherwise it returns nil.
 func HostnamesFromAnnotations(input map[string]string) []string {
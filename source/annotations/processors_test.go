@@ -204,6 +204,290 @@ func TestTTLFromAnnotations(t *testing.T) {
 	}
 }
 
+func TestPriorityFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		expectedPriority int64
+		expectedOk       bool
+	}{
+		{
+			name:        "no priority annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name:             "valid priority annotation",
+			annotations:      map[string]string{PriorityKey: "10"},
+			expectedPriority: 10,
+			expectedOk:       true,
+		},
+		{
+			name:             "negative priority annotation",
+			annotations:      map[string]string{PriorityKey: "-5"},
+			expectedPriority: -5,
+			expectedOk:       true,
+		},
+		{
+			name:        "invalid priority annotation",
+			annotations: map[string]string{PriorityKey: "high"},
+		},
+		{
+			name:        "empty priority annotation",
+			annotations: map[string]string{PriorityKey: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priority, ok := PriorityFromAnnotations(tt.annotations, "test-resource")
+			assert.Equal(t, tt.expectedPriority, priority)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func TestWeightFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		expectedWeight int64
+		expectedOk     bool
+	}{
+		{
+			name:        "no weight annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name:           "valid weight annotation",
+			annotations:    map[string]string{WeightKey: " 10 "},
+			expectedWeight: 10,
+			expectedOk:     true,
+		},
+		{
+			name:        "zero weight annotation",
+			annotations: map[string]string{WeightKey: "0"},
+			expectedOk:  true,
+		},
+		{
+			name:        "negative weight annotation",
+			annotations: map[string]string{WeightKey: "-1"},
+		},
+		{
+			name:        "invalid weight annotation",
+			annotations: map[string]string{WeightKey: "heavy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weight, ok := WeightFromAnnotations(tt.annotations, "test-resource")
+			assert.Equal(t, tt.expectedWeight, weight)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func TestClusterFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectedCluster string
+		expectedOk      bool
+	}{
+		{
+			name:        "no cluster annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name:            "valid cluster annotation",
+			annotations:     map[string]string{ClusterKey: " Member-1 "},
+			expectedCluster: "member-1",
+			expectedOk:      true,
+		},
+		{
+			name:        "empty cluster annotation",
+			annotations: map[string]string{ClusterKey: ""},
+		},
+		{
+			name:        "invalid cluster annotation",
+			annotations: map[string]string{ClusterKey: "east+west"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster, ok := ClusterFromAnnotations(tt.annotations, "test-resource")
+			assert.Equal(t, tt.expectedCluster, cluster)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func TestMXTargetsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectedTargets endpoint.Targets
+		expectError     bool
+	}{
+		{
+			name:        "no mx annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name:            "single target",
+			annotations:     map[string]string{MXKey: "10 mail.example.com"},
+			expectedTargets: endpoint.Targets{"10 mail.example.com"},
+		},
+		{
+			name:            "multiple targets with spaces and trailing dots",
+			annotations:     map[string]string{MXKey: "10  mail.example.com., 20 backup.example.com"},
+			expectedTargets: endpoint.Targets{"10 mail.example.com", "20 backup.example.com"},
+		},
+		{
+			name:        "missing preference",
+			annotations: map[string]string{MXKey: "mail.example.com"},
+			expectError: true,
+		},
+		{
+			name:        "preference out of range",
+			annotations: map[string]string{MXKey: "65536 mail.example.com"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, err := MXTargetsFromAnnotations(tt.annotations)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTargets, targets)
+		})
+	}
+}
+
+func TestHTTPSTargetsFromAnnotations(t *testing.T) {
+	targets, err := HTTPSTargetsFromAnnotations(map[string]string{HTTPSKey: `1 . alpn="h3,h2" ipv4hint=192.0.2.1; 2 backup.example.com. alpn=h2`})
+	assert.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"1 . alpn=h3,h2 ipv4hint=192.0.2.1", "2 backup.example.com. alpn=h2"}, targets)
+
+	targets, err = HTTPSTargetsFromAnnotations(map[string]string{})
+	assert.NoError(t, err)
+	assert.Empty(t, targets)
+
+	_, err = HTTPSTargetsFromAnnotations(map[string]string{HTTPSKey: "1 . alpn=h3,h2, 2 . alpn=h2"})
+	assert.Error(t, err)
+}
+
+func TestTLSAPortsFromAnnotations(t *testing.T) {
+	ports, err := TLSAPortsFromAnnotations(map[string]string{TLSAKey: "_443._tcp, _25._TCP"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"_443._tcp", "_25._tcp"}, ports)
+
+	ports, err = TLSAPortsFromAnnotations(map[string]string{})
+	assert.NoError(t, err)
+	assert.Empty(t, ports)
+
+	for _, value := range []string{"443", "_443", "_443._tcp.example.com", "_70000._tcp"} {
+		_, err = TLSAPortsFromAnnotations(map[string]string{TLSAKey: value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestSRVTargetsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		expectedServices map[string]endpoint.Targets
+		expectError      bool
+	}{
+		{
+			name:        "no srv annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name:        "several services",
+			annotations: map[string]string{SRVKey: "_sip._udp 10 5 5060 sip.example.com., _SIP._UDP 20 0 5060 backup.example.com, _xmpp._tcp 0 0 5222 ."},
+			expectedServices: map[string]endpoint.Targets{
+				"_sip._udp":  {"10 5 5060 sip.example.com", "20 0 5060 backup.example.com"},
+				"_xmpp._tcp": {"0 0 5222 ."},
+			},
+		},
+		{
+			name:        "missing service",
+			annotations: map[string]string{SRVKey: "10 5 5060 sip.example.com"},
+			expectError: true,
+		},
+		{
+			name:        "service without protocol",
+			annotations: map[string]string{SRVKey: "_sip 10 5 5060 sip.example.com"},
+			expectError: true,
+		},
+		{
+			name:        "missing port",
+			annotations: map[string]string{SRVKey: "_sip._udp 10 5 sip.example.com"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services, err := SRVTargetsFromAnnotations(tt.annotations)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedServices, services)
+		})
+	}
+}
+
+func TestIsProtectedFromAnnotations(t *testing.T) {
+	assert.False(t, IsProtectedFromAnnotations(map[string]string{}))
+	assert.False(t, IsProtectedFromAnnotations(map[string]string{ProtectKey: "false"}))
+	assert.False(t, IsProtectedFromAnnotations(map[string]string{ProtectKey: "yes"}))
+	assert.True(t, IsProtectedFromAnnotations(map[string]string{ProtectKey: "true"}))
+}
+
+func TestPublishNotReadyAddressesFromAnnotations(t *testing.T) {
+	publish, ok := PublishNotReadyAddressesFromAnnotations(map[string]string{}, "service/default/web")
+	assert.False(t, ok)
+	assert.False(t, publish)
+	publish, ok = PublishNotReadyAddressesFromAnnotations(map[string]string{PublishNotReadyAddressesKey: "true"}, "service/default/web")
+	assert.True(t, ok)
+	assert.True(t, publish)
+	publish, ok = PublishNotReadyAddressesFromAnnotations(map[string]string{PublishNotReadyAddressesKey: "false"}, "service/default/web")
+	assert.True(t, ok)
+	assert.False(t, publish)
+	_, ok = PublishNotReadyAddressesFromAnnotations(map[string]string{PublishNotReadyAddressesKey: "yes"}, "service/default/web")
+	assert.False(t, ok)
+}
+
+func TestHeadlessRecordsFromAnnotations(t *testing.T) {
+	defaultRecords := map[string]bool{HeadlessRecordsAggregate: true, HeadlessRecordsPerPod: true}
+	assert.Equal(t, defaultRecords, HeadlessRecordsFromAnnotations(map[string]string{}, "service/default/web"))
+	assert.Equal(t, map[string]bool{HeadlessRecordsAggregate: true, HeadlessRecordsPerOrdinal: true},
+		HeadlessRecordsFromAnnotations(map[string]string{HeadlessRecordsKey: "aggregate, per-ordinal"}, "service/default/web"))
+	assert.Equal(t, defaultRecords, HeadlessRecordsFromAnnotations(map[string]string{HeadlessRecordsKey: "per-node"}, "service/default/web"))
+}
+
+func TestHeadlessPodNameFromAnnotations(t *testing.T) {
+	assert.Equal(t, HeadlessPodNameHostname, HeadlessPodNameFromAnnotations(map[string]string{}, "service/default/web"))
+	assert.Equal(t, HeadlessPodNamePodName, HeadlessPodNameFromAnnotations(map[string]string{HeadlessPodNameKey: "pod-name"}, "service/default/web"))
+	assert.Equal(t, HeadlessPodNameHostname, HeadlessPodNameFromAnnotations(map[string]string{HeadlessPodNameKey: "uid"}, "service/default/web"))
+}
+
+func TestPreferTargetTypeFromAnnotations(t *testing.T) {
+	assert.Empty(t, PreferTargetTypeFromAnnotations(map[string]string{}, "", "service/default/web"))
+	assert.Equal(t, TargetTypeIP, PreferTargetTypeFromAnnotations(map[string]string{}, TargetTypeIP, "service/default/web"))
+	assert.Equal(t, TargetTypeHostname, PreferTargetTypeFromAnnotations(map[string]string{PreferTargetTypeKey: "hostname"}, TargetTypeIP, "service/default/web"))
+	assert.Equal(t, TargetTypeIP, PreferTargetTypeFromAnnotations(map[string]string{PreferTargetTypeKey: "cname"}, TargetTypeIP, "service/default/web"))
+}
+
 func TestGetAliasFromAnnotations(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -354,3 +638,40 @@ func TestInternalHostnamesFromAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestGeoFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *endpoint.GeoLocation
+	}{
+		{
+			name:        "no geo annotations",
+			annotations: map[string]string{},
+		},
+		{
+			name:        "continent",
+			annotations: map[string]string{GeoContinentKey: "eu"},
+			expected:    &endpoint.GeoLocation{Continent: "EU"},
+		},
+		{
+			name:        "country and region",
+			annotations: map[string]string{GeoCountryKey: " us ", GeoRegionKey: "ca"},
+			expected:    &endpoint.GeoLocation{Country: "US", Region: "CA"},
+		},
+		{
+			name:        "region without country",
+			annotations: map[string]string{GeoRegionKey: "CA"},
+		},
+		{
+			name:        "continent and country",
+			annotations: map[string]string{GeoContinentKey: "EU", GeoCountryKey: "DE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GeoFromAnnotations(tt.annotations, "test-resource"))
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/source/fqdn"
+)
+
+// Validate checks the hostname, hostname-template, internal-hostname, target, mx, srv, https, tlsa, weight, cluster, geo,
+// priority, ttl, prefer-target-type and headless service annotations, and the values of the provider-specific annotations
+// with a known format, which are otherwise only reported in the logs, or ignored, when the resource is processed.
+func Validate(annotations map[string]string) error {
+	var errs []error
+	for _, key := range []string{HostnameKey, InternalHostnameKey} {
+		if _, ok := annotations[key]; !ok {
+			continue
+		}
+		for _, hostname := range extractHostnamesFromAnnotations(annotations, key) {
+			if err := ValidateHostname(hostname); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			}
+		}
+	}
+	if value, ok := annotations[HostnameTemplateKey]; ok {
+		if _, err := fqdn.ParseTemplate(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", HostnameTemplateKey, err))
+		}
+	}
+	if value, ok := annotations[TargetKey]; ok {
+		for _, target := range SplitHostnameAnnotation(value) {
+			if _, err := netip.ParseAddr(target); err == nil {
+				continue
+			}
+			if err := ValidateHostname(target); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", TargetKey, err))
+			}
+		}
+	}
+	if targets, err := MXTargetsFromAnnotations(annotations); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", MXKey, err))
+	} else {
+		for _, target := range targets {
+			_, host, _ := strings.Cut(target, " ")
+			if err := ValidateHostname(host); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", MXKey, err))
+			}
+		}
+	}
+	if services, err := SRVTargetsFromAnnotations(annotations); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", SRVKey, err))
+	} else {
+		for _, targets := range services {
+			for _, target := range targets {
+				host := target[strings.LastIndex(target, " ")+1:]
+				if host == "." {
+					continue
+				}
+				if err := ValidateHostname(host); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", SRVKey, err))
+				}
+			}
+		}
+	}
+	if _, err := HTTPSTargetsFromAnnotations(annotations); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", HTTPSKey, err))
+	}
+	if _, err := TLSAPortsFromAnnotations(annotations); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", TLSAKey, err))
+	}
+	if value, ok := annotations[WeightKey]; ok {
+		if _, err := parseWeight(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", WeightKey, err))
+		}
+	}
+	if value, ok := annotations[ClusterKey]; ok {
+		if _, err := parseCluster(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ClusterKey, err))
+		}
+	}
+	if _, err := geoFromAnnotations(annotations); err != nil {
+		errs = append(errs, fmt.Errorf("%sgeo-*: %w", AnnotationKeyPrefix, err))
+	}
+	if value, ok := annotations[PriorityKey]; ok {
+		if _, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %q is not a valid priority value", PriorityKey, value))
+		}
+	}
+	if value, ok := annotations[PublishNotReadyAddressesKey]; ok {
+		if _, err := parseBool(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", PublishNotReadyAddressesKey, err))
+		}
+	}
+	if value, ok := annotations[HeadlessRecordsKey]; ok {
+		if _, err := parseHeadlessRecords(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", HeadlessRecordsKey, err))
+		}
+	}
+	if value, ok := annotations[HeadlessPodNameKey]; ok {
+		if _, err := parseHeadlessPodName(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", HeadlessPodNameKey, err))
+		}
+	}
+	if value, ok := annotations[PreferTargetTypeKey]; ok {
+		if _, err := parseTargetType(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", PreferTargetTypeKey, err))
+		}
+	}
+	errs = append(errs, validateProviderSpecific(annotations)...)
+	if value, ok := annotations[TtlKey]; ok {
+		ttl, err := parseTTL(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %q is not a valid TTL value: %w", TtlKey, value, err))
+		} else if ttl < ttlMinimum || ttl > ttlMaximum {
+			errs = append(errs, fmt.Errorf("%s: TTL value %d must be between [%d, %d]", TtlKey, ttl, ttlMinimum, ttlMaximum))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateProviderSpecific checks the values of the provider-specific annotations with a known format, which the
+// providers would otherwise ignore or replace by their default.
+func validateProviderSpecific(annotations map[string]string) []error {
+	var errs []error
+	for _, key := range []string{CloudflareProxiedKey, AWSPrefix + "evaluate-target-health"} {
+		if value, ok := annotations[key]; ok && value != "true" && value != "false" {
+			errs = append(errs, fmt.Errorf("%s: %q is not a valid boolean, expected true or false", key, value))
+		}
+	}
+	if value, ok := annotations[AWSPrefix+"weight"]; ok {
+		if weight, err := strconv.ParseInt(value, 10, 64); err != nil || weight < 0 {
+			errs = append(errs, fmt.Errorf("%s: %q is not a valid weight, expected a non-negative integer", AWSPrefix+"weight", value))
+		}
+	}
+	return errs
+}
+
+// ValidateHostname checks that the given name is a valid DNS name. The leftmost label may be a wildcard,
+// and non-ASCII characters are accepted for internationalized names.
+func ValidateHostname(hostname string) error {
+	name := strings.TrimSuffix(hostname, ".")
+	if name == "" {
+		return errors.New("empty hostname")
+	}
+	if len(name) > 253 {
+		return fmt.Errorf("hostname %q is longer than 253 characters", hostname)
+	}
+	for i, label := range strings.Split(name, ".") {
+		if i == 0 && label == "*" {
+			continue
+		}
+		if err := validateLabel(label); err != nil {
+			return fmt.Errorf("hostname %q is invalid: %w", hostname, err)
+		}
+	}
+	return nil
+}
+
+func validateLabel(label string) error {
+	if label == "" {
+		return errors.New("empty label")
+	}
+	if len(label) > 63 {
+		return fmt.Errorf("label %q is longer than 63 characters", label)
+	}
+	if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+		return fmt.Errorf("label %q must not start or end with a hyphen", label)
+	}
+	for _, r := range label {
+		if r < 0x80 && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return fmt.Errorf("label %q contains the invalid character %q", label, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectErr   string
+	}{
+		{name: "no annotations"},
+		{
+			name: "valid annotations",
+			annotations: map[string]string{
+				HostnameKey:          "foo.example.com, *.bar.example.com.",
+				HostnameTemplateKey:  "{{ .Name }}-{{ .Namespace }}.apps.example.com",
+				InternalHostnameKey:  "foo.internal.example.com",
+				TargetKey:            "192.0.2.1,2001:db8::1,lb.example.com.",
+				TtlKey:               "10m",
+				MXKey:                "10 mail.example.com, 20 backup.example.com.",
+				SRVKey:               "_sip._udp 10 5 5060 sip.example.com, _xmpp._tcp 0 0 5222 .",
+				HTTPSKey:             "1 . alpn=h3,h2",
+				PriorityKey:          "10",
+				CloudflareProxiedKey: "true",
+				AWSPrefix + "weight": "100",
+			},
+		},
+		{
+			name:        "invalid hostname",
+			annotations: map[string]string{HostnameKey: "foo.example.com,foo..example.com"},
+			expectErr:   `hostname "foo..example.com" is invalid: empty label`,
+		},
+		{
+			name: "valid headless service annotations",
+			annotations: map[string]string{
+				PublishNotReadyAddressesKey: "true",
+				HeadlessRecordsKey:          "aggregate,per-ordinal",
+				HeadlessPodNameKey:          "pod-name",
+			},
+		},
+		{
+			name:        "invalid publish not ready addresses",
+			annotations: map[string]string{PublishNotReadyAddressesKey: "yes"},
+			expectErr:   PublishNotReadyAddressesKey + `: "yes" is not a valid boolean`,
+		},
+		{
+			name:        "invalid headless records",
+			annotations: map[string]string{HeadlessRecordsKey: "aggregate,per-node"},
+			expectErr:   HeadlessRecordsKey + `: "per-node" is not a valid headless record`,
+		},
+		{
+			name:        "invalid headless pod name",
+			annotations: map[string]string{HeadlessPodNameKey: "uid"},
+			expectErr:   HeadlessPodNameKey + `: "uid" is not a valid headless pod name`,
+		},
+		{
+			name:        "invalid prefer target type",
+			annotations: map[string]string{PreferTargetTypeKey: "cname"},
+			expectErr:   PreferTargetTypeKey + `: "cname" is not a valid target type`,
+		},
+		{
+			name:        "invalid hostname template",
+			annotations: map[string]string{HostnameTemplateKey: "{{ .Name }.example.com"},
+			expectErr:   HostnameTemplateKey + ": template: endpoint:1: unexpected",
+		},
+		{
+			name:        "invalid internal hostname",
+			annotations: map[string]string{InternalHostnameKey: "-foo.example.com"},
+			expectErr:   "must not start or end with a hyphen",
+		},
+		{
+			name:        "invalid target",
+			annotations: map[string]string{TargetKey: "lb/example.com"},
+			expectErr:   `contains the invalid character '/'`,
+		},
+		{
+			name:        "long label",
+			annotations: map[string]string{HostnameKey: strings.Repeat("a", 64) + ".example.com"},
+			expectErr:   "longer than 63 characters",
+		},
+		{
+			name:        "invalid mx preference",
+			annotations: map[string]string{MXKey: "high mail.example.com"},
+			expectErr:   "invalid integer value",
+		},
+		{
+			name:        "invalid mx host",
+			annotations: map[string]string{MXKey: "10 mail/example.com"},
+			expectErr:   "invalid character '/'",
+		},
+		{
+			name:        "invalid srv service",
+			annotations: map[string]string{SRVKey: "sip 10 5 5060 sip.example.com"},
+			expectErr:   "invalid SRV service",
+		},
+		{
+			name:        "invalid srv host",
+			annotations: map[string]string{SRVKey: "_sip._udp 10 5 5060 sip/example.com"},
+			expectErr:   "invalid character '/'",
+		},
+		{
+			name:        "invalid https parameter",
+			annotations: map[string]string{HTTPSKey: "1 . http3=true"},
+			expectErr:   `Unknown parameter "http3"`,
+		},
+		{
+			name:        "invalid tlsa port",
+			annotations: map[string]string{TLSAKey: "_https._tcp"},
+			expectErr:   `invalid TLSA port "_https._tcp"`,
+		},
+		{
+			name:        "invalid weight",
+			annotations: map[string]string{WeightKey: "-5"},
+			expectErr:   `"-5" is not a valid weight`,
+		},
+		{
+			name:        "invalid cluster",
+			annotations: map[string]string{ClusterKey: "east+west"},
+			expectErr:   `"east+west" is not a valid cluster name`,
+		},
+		{
+			name:        "invalid geo",
+			annotations: map[string]string{GeoContinentKey: "Atlantis"},
+			expectErr:   `invalid continent "ATLANTIS"`,
+		},
+		{
+			name:        "invalid priority",
+			annotations: map[string]string{PriorityKey: "high"},
+			expectErr:   `"high" is not a valid priority value`,
+		},
+		{
+			name:        "invalid cloudflare proxied",
+			annotations: map[string]string{CloudflareProxiedKey: "yes"},
+			expectErr:   `"yes" is not a valid boolean`,
+		},
+		{
+			name:        "invalid aws weight",
+			annotations: map[string]string{AWSPrefix + "weight": "heavy"},
+			expectErr:   `"heavy" is not a valid weight`,
+		},
+		{
+			name:        "invalid ttl",
+			annotations: map[string]string{TtlKey: "forever"},
+			expectErr:   `"forever" is not a valid TTL value`,
+		},
+		{
+			name:        "ttl out of range",
+			annotations: map[string]string{TtlKey: "0"},
+			expectErr:   "must be between",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.annotations)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+const (
+	// certManagerCertificateNameKey is set by cert-manager on the secrets of its Certificates.
+	certManagerCertificateNameKey = "cert-manager.io/certificate-name"
+	// tlsaDANEEE is the prefix of the TLSA records published for certificates: usage DANE-EE (3), selector
+	// SubjectPublicKeyInfo (1) and matching type SHA-256 (1), as recommended by RFC 7671.
+	tlsaDANEEE = "3 1 1 "
+)
+
+// certManagerTLSASource is an implementation of Source which publishes DANE TLSA records for the certificates
+// issued by cert-manager. Only the secrets of Certificates with the tlsa annotation, which can be set with
+// the secretTemplate of the Certificate, are considered. As the records are derived from the secrets, they are
+// updated when cert-manager renews the certificates.
+type certManagerTLSASource struct {
+	namespace        string
+	annotationFilter string
+	labelSelector    labels.Selector
+	secretInformer   coreinformers.SecretInformer
+}
+
+// NewCertManagerTLSASource creates a new certManagerTLSASource with the given config.
+func NewCertManagerTLSASource(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	namespace, annotationFilter string,
+	labelSelector labels.Selector) (Source, error) {
+	// Use shared informer to listen for add/update/delete of TLS secrets in the specified namespace.
+	// Set resync period to 0, to prevent processing when nothing has changed.
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = "type=" + string(v1.SecretTypeTLS)
+		}))
+	secretInformer := informerFactory.Core().V1().Secrets()
+
+	// Add default resource event handlers to properly initialize informer.
+	_, _ = secretInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &certManagerTLSASource{
+		namespace:        namespace,
+		annotationFilter: annotationFilter,
+		labelSelector:    labelSelector,
+		secretInformer:   secretInformer,
+	}, nil
+}
+
+// Endpoints returns a TLSA endpoint for each port of the tlsa annotation and each name of the certificates.
+func (cs *certManagerTLSASource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	secrets, err := cs.secretInformer.Lister().Secrets(cs.namespace).List(cs.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := annotations.ParseFilter(cs.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+
+	for _, secret := range secrets {
+		if secret.Type != v1.SecretTypeTLS || secret.Annotations[certManagerCertificateNameKey] == "" {
+			continue
+		}
+		if _, ok := secret.Annotations[annotations.TLSAKey]; !ok {
+			continue
+		}
+		if !selector.Empty() && !selector.Matches(labels.Set(secret.Annotations)) {
+			continue
+		}
+		// Check the controller annotation to see if we are responsible.
+		if controller, ok := secret.Annotations[controllerAnnotationKey]; ok && controller != controllerAnnotationValue {
+			log.Debugf("Skipping secret %s/%s because controller value does not match, found: %s, required: %s",
+				secret.Namespace, secret.Name, controller, controllerAnnotationValue)
+			continue
+		}
+
+		secretEndpoints, err := endpointsFromCertificateSecret(secret)
+		if err != nil {
+			// the certificate may not have been issued yet
+			log.Warnf("Skipping secret %s/%s: %v", secret.Namespace, secret.Name, err)
+			continue
+		}
+		decorateEndpoints(ctx, secret, secretEndpoints)
+
+		log.Debugf("Endpoints generated from secret: %s/%s: %v", secret.Namespace, secret.Name, secretEndpoints)
+		endpoints = append(endpoints, secretEndpoints...)
+	}
+
+	return endpoints, nil
+}
+
+func (cs *certManagerTLSASource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for secret")
+
+	// Right now there is no way to remove event handler from informer, see:
+	// https://github.com/kubernetes/kubernetes/issues/79610
+	_, _ = cs.secretInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
+
+// endpointsFromCertificateSecret returns the TLSA endpoints of the certificate stored in the given secret, at the
+// names of the hostname annotation if any, or else at the DNS names of the certificate.
+func endpointsFromCertificateSecret(secret *v1.Secret) ([]*endpoint.Endpoint, error) {
+	ports, err := annotations.TLSAPortsFromAnnotations(secret.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := parseCertificate(secret.Data[v1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	target := tlsaDANEEE + hex.EncodeToString(digest[:])
+
+	hostnames := hostnamesFromAnnotations(secret)
+	if len(hostnames) == 0 {
+		hostnames = cert.DNSNames
+	}
+
+	resource := fmt.Sprintf("secret/%s/%s", secret.Namespace, secret.Name)
+	ttl := annotations.TTLFromAnnotations(secret.Annotations, resource)
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(secret.Annotations)
+
+	var endpoints []*endpoint.Endpoint
+	for _, hostname := range hostnames {
+		hostname = strings.TrimSuffix(hostname, ".")
+		if strings.HasPrefix(hostname, "*.") {
+			log.Debugf("Skipping wildcard name %s of secret %s/%s, TLSA records can't be published for it", hostname, secret.Namespace, secret.Name)
+			continue
+		}
+		for _, port := range ports {
+			ep := endpoint.NewEndpointWithTTL(port+"."+hostname, endpoint.RecordTypeTLSA, ttl, target).
+				WithSetIdentifier(setIdentifier).
+				WithLabel(endpoint.ResourceLabelKey, resource)
+			ep.ProviderSpecific = providerSpecific
+			endpoints = append(endpoints, ep)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].DNSName < endpoints[j].DNSName
+	})
+	return endpoints, nil
+}
+
+// parseCertificate returns the first certificate of the given PEM data, which is the leaf certificate in the
+// secrets of cert-manager.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// newTestCertificate returns a PEM encoded self-signed certificate for the given names and the TLSA
+// target of its public key.
+func newTestCertificate(t *testing.T, names ...string) ([]byte, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     names,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), "3 1 1 " + hex.EncodeToString(digest[:])
+}
+
+func TestCertManagerTLSASource(t *testing.T) {
+	t.Parallel()
+
+	cert, target := newTestCertificate(t, "example.org", "www.example.org", "*.example.org")
+	renewed, renewedTarget := newTestCertificate(t, "example.org")
+
+	for _, tt := range []struct {
+		title            string
+		secrets          []*v1.Secret
+		annotationFilter string
+		expected         []*endpoint.Endpoint
+	}{
+		{
+			title: "records for each name and port of the certificate",
+			secrets: []*v1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example-tls", Annotations: map[string]string{
+					certManagerCertificateNameKey: "example",
+					annotations.TLSAKey:           "_443._tcp,_25._tcp",
+					annotations.TtlKey:            "300",
+				}},
+				Type: v1.SecretTypeTLS,
+				Data: map[string][]byte{v1.TLSCertKey: cert},
+			}},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "_25._tcp.example.org", RecordType: endpoint.RecordTypeTLSA, RecordTTL: 300, Targets: endpoint.Targets{target}},
+				{DNSName: "_25._tcp.www.example.org", RecordType: endpoint.RecordTypeTLSA, RecordTTL: 300, Targets: endpoint.Targets{target}},
+				{DNSName: "_443._tcp.example.org", RecordType: endpoint.RecordTypeTLSA, RecordTTL: 300, Targets: endpoint.Targets{target}},
+				{DNSName: "_443._tcp.www.example.org", RecordType: endpoint.RecordTypeTLSA, RecordTTL: 300, Targets: endpoint.Targets{target}},
+			},
+		},
+		{
+			title: "hostname annotation restricts the names",
+			secrets: []*v1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example-tls", Annotations: map[string]string{
+					certManagerCertificateNameKey: "example",
+					annotations.TLSAKey:           "_443._tcp",
+					annotations.HostnameKey:       "www.example.org",
+				}},
+				Type: v1.SecretTypeTLS,
+				Data: map[string][]byte{v1.TLSCertKey: renewed},
+			}},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "_443._tcp.www.example.org", RecordType: endpoint.RecordTypeTLSA, Targets: endpoint.Targets{renewedTarget}},
+			},
+		},
+		{
+			title: "secrets without the tlsa annotation, not issued by cert-manager or filtered out are ignored",
+			secrets: []*v1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "no-tlsa", Annotations: map[string]string{
+						certManagerCertificateNameKey: "example",
+					}},
+					Type: v1.SecretTypeTLS,
+					Data: map[string][]byte{v1.TLSCertKey: cert},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "not-cert-manager", Annotations: map[string]string{
+						annotations.TLSAKey: "_443._tcp",
+					}},
+					Type: v1.SecretTypeTLS,
+					Data: map[string][]byte{v1.TLSCertKey: cert},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other-controller", Annotations: map[string]string{
+						certManagerCertificateNameKey: "example",
+						annotations.TLSAKey:           "_443._tcp",
+						controllerAnnotationKey:       "other",
+					}},
+					Type: v1.SecretTypeTLS,
+					Data: map[string][]byte{v1.TLSCertKey: cert},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "filtered", Annotations: map[string]string{
+						certManagerCertificateNameKey: "example",
+						annotations.TLSAKey:           "_443._tcp",
+					}},
+					Type: v1.SecretTypeTLS,
+					Data: map[string][]byte{v1.TLSCertKey: cert},
+				},
+			},
+			annotationFilter: "dane=enabled",
+		},
+		{
+			title: "secrets without a certificate yet are skipped",
+			secrets: []*v1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pending", Annotations: map[string]string{
+					certManagerCertificateNameKey: "example",
+					annotations.TLSAKey:           "_443._tcp",
+				}},
+				Type: v1.SecretTypeTLS,
+			}},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubeClient := fake.NewClientset()
+			for _, secret := range tt.secrets {
+				_, err := kubeClient.CoreV1().Secrets(secret.Namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			src, err := NewCertManagerTLSASource(context.Background(), kubeClient, "", tt.annotationFilter, labels.Everything())
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+			for _, ep := range endpoints {
+				assert.True(t, ep.CheckEndpoint(), ep.DNSName)
+			}
+		})
+	}
+}
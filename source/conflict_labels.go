@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+)
+
+type conflictLabelsContextKey struct{}
+
+// WithConflictLabels returns a copy of ctx having the sources listing their endpoints with it label them with the
+// creation time and priority of their resources, which only some conflict resolution strategies read.
+func WithConflictLabels(ctx context.Context) context.Context {
+	return context.WithValue(ctx, conflictLabelsContextKey{}, true)
+}
+
+// conflictLabels returns whether ctx has the sources label the endpoints with the creation time and priority of
+// their resources.
+func conflictLabels(ctx context.Context) bool {
+	enabled, _ := ctx.Value(conflictLabelsContextKey{}).(bool)
+	return enabled
+}
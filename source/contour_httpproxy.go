@@ -167,6 +167,8 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			continue
 		}
 
+		decorateEndpoints(ctx, hp, hpEndpoints)
+
 		log.Debugf("Endpoints generated from HTTPProxy: %s/%s: %v", hp.Namespace, hp.Name, hpEndpoints)
 		endpoints = append(endpoints, hpEndpoints...)
 	}
@@ -264,7 +266,7 @@ func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTP
 
 	// Skip endpoints if we do not want entries from annotations
 	if !sc.ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(httpProxy.Annotations)
+		hostnameList := hostnamesFromAnnotations(httpProxy)
 		for _, hostname := range hostnameList {
 			endpoints = append(endpoints, EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
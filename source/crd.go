@@ -64,7 +64,7 @@ func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) err
 }
 
 // NewCRDClientForAPIVersionKind return rest client for the given apiVersion and kind of the CRD
-func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiServerURL, apiVersion, kind string) (*rest.RESTClient, *runtime.Scheme, error) {
+func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiServerURL, apiVersion, kind string, qps float32, burst int) (*rest.RESTClient, *runtime.Scheme, error) {
 	if kubeConfig == "" {
 		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
 			kubeConfig = clientcmd.RecommendedHomeFile
@@ -75,6 +75,7 @@ func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiS
 	if err != nil {
 		return nil, nil, err
 	}
+	rateLimit(config, qps, burst)
 
 	groupVersion, err := schema.ParseGroupVersion(apiVersion)
 	if err != nil {
@@ -188,6 +189,10 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 
 			illegalTarget := false
 			for _, target := range ep.Targets {
+				if ep.RecordType == endpoint.RecordTypeSVCB || ep.RecordType == endpoint.RecordTypeHTTPS {
+					// the target name of SVCB records may be "."
+					continue
+				}
 				isNAPTR := ep.RecordType == endpoint.RecordTypeNAPTR
 				hasDot := strings.HasSuffix(target, ".")
 				if (isNAPTR && !hasDot) || (!isNAPTR && hasDot) {
@@ -199,12 +204,21 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 				log.Warnf("Endpoint %s/%s with DNSName %s has an illegal target format.", dnsEndpoint.Namespace, dnsEndpoint.Name, ep.DNSName)
 				continue
 			}
+			if !ep.CheckEndpoint() {
+				log.Warnf("Endpoint %s/%s with DNSName %s has invalid %s targets.", dnsEndpoint.Namespace, dnsEndpoint.Name, ep.DNSName, ep.RecordType)
+				continue
+			}
 
 			ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("crd/%s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name))
+			if dnsEndpoint.Spec.Protected {
+				ep.WithLabel(endpoint.ProtectedLabelKey, "true")
+			}
 
 			crdEndpoints = append(crdEndpoints, ep)
 		}
 
+		decorateEndpoints(ctx, &dnsEndpoint, crdEndpoints)
+
 		endpoints = append(endpoints, crdEndpoints...)
 
 		if dnsEndpoint.Status.ObservedGeneration == dnsEndpoint.Generation {
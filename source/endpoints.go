@@ -14,12 +14,27 @@ limitations under the License.
 package source
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	networkv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/fqdn"
+	"sigs.k8s.io/external-dns/source/types"
 )
 
 // EndpointsForHostname returns the endpoint objects for each host-target combination.
@@ -81,6 +96,247 @@ func EndpointsForHostname(hostname string, targets endpoint.Targets, ttl endpoin
 	return endpoints
 }
 
+// hostnamesFromAnnotations returns the hostnames of the hostname annotation of the resource, followed by those of
+// its hostname template annotation, which is evaluated against the resource like the FQDN template but applies to
+// this resource only. A template which fails to parse or execute is logged and ignored.
+func hostnamesFromAnnotations(obj kubeObject) []string {
+	hostnames := annotations.HostnamesFromAnnotations(obj.GetAnnotations())
+	text, ok := obj.GetAnnotations()[annotations.HostnameTemplateKey]
+	if !ok {
+		return hostnames
+	}
+	tmpl, err := fqdn.ParseTemplate(text)
+	if err != nil {
+		log.Warnf("Ignoring the hostname template of %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+		return hostnames
+	}
+	if tmpl == nil {
+		return hostnames
+	}
+	templated, err := fqdn.ExecTemplate(tmpl, obj)
+	if err != nil {
+		log.Warnf("Ignoring the hostname template: %v", err)
+		return hostnames
+	}
+	for _, hostname := range templated {
+		if hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+// endpointsFromRecordAnnotations returns the endpoints of the mx, srv and https annotations of the given resource
+// for the DNS names of its endpoints.
+func endpointsFromRecordAnnotations(obj metav1.Object, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var annotated []*endpoint.Endpoint
+	annotated = append(annotated, endpointsFromMXAnnotation(obj, endpoints)...)
+	annotated = append(annotated, endpointsFromSRVAnnotation(obj, endpoints)...)
+	annotated = append(annotated, endpointsFromHTTPSAnnotation(obj, endpoints)...)
+	return annotated
+}
+
+// endpointsFromMXAnnotation returns MX endpoints with the targets of the mx annotation of the given resource
+// for the DNS names of its endpoints.
+func endpointsFromMXAnnotation(obj metav1.Object, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	targets, err := annotations.MXTargetsFromAnnotations(obj.GetAnnotations())
+	if err != nil {
+		log.Warnf("%s/%s: invalid %s annotation: %v", obj.GetNamespace(), obj.GetName(), annotations.MXKey, err)
+		return nil
+	}
+	return sameNameEndpoints(obj, endpoints, endpoint.RecordTypeMX, targets)
+}
+
+// endpointsFromHTTPSAnnotation returns HTTPS endpoints with the targets of the https annotation of the given resource
+// for the DNS names of its endpoints.
+func endpointsFromHTTPSAnnotation(obj metav1.Object, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	targets, err := annotations.HTTPSTargetsFromAnnotations(obj.GetAnnotations())
+	if err != nil {
+		log.Warnf("%s/%s: invalid %s annotation: %v", obj.GetNamespace(), obj.GetName(), annotations.HTTPSKey, err)
+		return nil
+	}
+	return sameNameEndpoints(obj, endpoints, endpoint.RecordTypeHTTPS, targets)
+}
+
+// sameNameEndpoints returns endpoints of the given record type and targets for the DNS names of the given endpoints.
+// DNS names with a CNAME record are skipped, since a CNAME can't coexist with other records.
+func sameNameEndpoints(obj metav1.Object, endpoints []*endpoint.Endpoint, recordType string, targets endpoint.Targets) []*endpoint.Endpoint {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	type key struct {
+		dnsName       string
+		setIdentifier string
+	}
+	cnames := make(map[key]bool)
+	for _, ep := range endpoints {
+		if ep.RecordType == endpoint.RecordTypeCNAME {
+			cnames[key{ep.DNSName, ep.SetIdentifier}] = true
+		}
+	}
+
+	var sameName []*endpoint.Endpoint
+	seen := make(map[key]bool)
+	for _, ep := range endpoints {
+		k := key{ep.DNSName, ep.SetIdentifier}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if cnames[k] {
+			log.Warnf("%s/%s: not creating %s records for %s, which has a CNAME record", obj.GetNamespace(), obj.GetName(), recordType, ep.DNSName)
+			continue
+		}
+		sameName = append(sameName, annotationEndpoint(ep, ep.DNSName, recordType, targets))
+	}
+	return sameName
+}
+
+// endpointsFromSRVAnnotation returns SRV endpoints with the records of the srv annotation of the given resource
+// for the DNS names of its endpoints, e.g. _sip._udp.example.com for the _sip._udp service of example.com.
+func endpointsFromSRVAnnotation(obj metav1.Object, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	services, err := annotations.SRVTargetsFromAnnotations(obj.GetAnnotations())
+	if err != nil {
+		log.Warnf("%s/%s: invalid %s annotation: %v", obj.GetNamespace(), obj.GetName(), annotations.SRVKey, err)
+		return nil
+	}
+	if len(services) == 0 {
+		return nil
+	}
+
+	type key struct {
+		dnsName       string
+		setIdentifier string
+	}
+	var srvEndpoints []*endpoint.Endpoint
+	seen := make(map[key]bool)
+	for _, ep := range endpoints {
+		k := key{ep.DNSName, ep.SetIdentifier}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		for _, service := range slices.Sorted(maps.Keys(services)) {
+			srvEndpoints = append(srvEndpoints, annotationEndpoint(ep, service+"."+ep.DNSName, endpoint.RecordTypeSRV, services[service]))
+		}
+	}
+	return srvEndpoints
+}
+
+// annotationEndpoint returns an endpoint with the given DNS name, record type and targets which has the TTL,
+// set identifier, provider specific properties and resource of the given endpoint.
+func annotationEndpoint(ep *endpoint.Endpoint, dnsName, recordType string, targets endpoint.Targets) *endpoint.Endpoint {
+	annotated := endpoint.NewEndpointWithTTL(dnsName, recordType, ep.RecordTTL, targets...)
+	annotated.SetIdentifier = ep.SetIdentifier
+	for _, ps := range ep.ProviderSpecific {
+		// only A, AAAA and CNAME records can be aliases
+		if ps.Name != "alias" {
+			annotated.ProviderSpecific = append(annotated.ProviderSpecific, ps)
+		}
+	}
+	if resource, ok := ep.Labels[endpoint.ResourceLabelKey]; ok {
+		annotated.Labels[endpoint.ResourceLabelKey] = resource
+	}
+	return annotated
+}
+
+// decorateEndpoints attaches metadata of the resource that generated the endpoints,
+// such as its protection, member cluster and the labels selected with WithResourceLabels, as well as its creation
+// time and conflict resolution priority with WithConflictLabels, as endpoint labels, as well as its target weights,
+// geo location and description.
+// Invalid annotations of the resource are reported to the InvalidAnnotations carried by ctx, if any.
+func decorateEndpoints(ctx context.Context, obj metav1.Object, endpoints []*endpoint.Endpoint) {
+	resource := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+	reportInvalidAnnotations(ctx, obj)
+	priority, hasPriority := annotations.PriorityFromAnnotations(obj.GetAnnotations(), resource)
+	protected := annotations.IsProtectedFromAnnotations(obj.GetAnnotations())
+	weight, hasWeight := annotations.WeightFromAnnotations(obj.GetAnnotations(), resource)
+	geo := annotations.GeoFromAnnotations(obj.GetAnnotations(), resource)
+	cluster, hasCluster := annotations.ClusterFromAnnotations(obj.GetAnnotations(), resource)
+	description := obj.GetAnnotations()[annotations.DescriptionKey]
+	created := obj.GetCreationTimestamp()
+	withConflictLabels := conflictLabels(ctx)
+	ref := objectReference(obj)
+	labels := resourceLabels(ctx, obj)
+
+	for _, ep := range endpoints {
+		if ref != nil {
+			ep.WithRefObject(ref)
+		}
+		if withConflictLabels && !created.IsZero() {
+			ep.WithLabel(endpoint.ResourceCreatedLabelKey, strconv.FormatInt(created.Unix(), 10))
+		}
+		if withConflictLabels && hasPriority {
+			ep.WithLabel(endpoint.PriorityLabelKey, strconv.FormatInt(priority, 10))
+		}
+		if protected {
+			ep.WithLabel(endpoint.ProtectedLabelKey, "true")
+		}
+		if hasWeight && (ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA || ep.RecordType == endpoint.RecordTypeCNAME) {
+			ep.WithWeight(weight)
+		}
+		if geo != nil {
+			ep.WithGeo(*geo)
+		}
+		if description != "" {
+			ep.WithDescription(description)
+		}
+		if hasCluster {
+			ep.WithLabel(endpoint.ClustersLabelKey, cluster)
+		}
+		for key, value := range labels {
+			ep.WithLabel(key, value)
+		}
+	}
+}
+
+// objectReference returns a reference to the given object for the events about its records, or nil if its kind
+// is unknown. Objects returned by typed informers don't carry their kind, so it is derived from their type.
+func objectReference(obj metav1.Object) *events.ObjectReference {
+	ref := &events.ObjectReference{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       obj.GetUID(),
+	}
+	switch obj.(type) {
+	case *v1.Service:
+		ref.Kind, ref.ApiVersion, ref.Source = "Service", "v1", types.Service
+	case *networkv1.Ingress:
+		ref.Kind, ref.ApiVersion, ref.Source = "Ingress", networkv1.SchemeGroupVersion.String(), types.Ingress
+	case *v1.Secret:
+		ref.Kind, ref.ApiVersion, ref.Source = "Secret", "v1", types.CertManagerTLSA
+	case *apiv1alpha1.DNSEndpoint:
+		ref.Kind, ref.ApiVersion, ref.Source = "DNSEndpoint", apiv1alpha1.GroupVersion.String(), types.CRD
+	default:
+		ro, ok := obj.(runtime.Object)
+		if !ok {
+			return nil
+		}
+		gvk := ro.GetObjectKind().GroupVersionKind()
+		if gvk.Kind == "" {
+			return nil
+		}
+		ref.Kind, ref.ApiVersion, ref.Source = gvk.Kind, gvk.GroupVersion().String(), strings.ToLower(gvk.Kind)
+	}
+	return ref
+}
+
+// preferredLoadBalancerTargets returns the IP address and hostname of a load balancer, of which only the one of the
+// preferred target type is kept when the load balancer has both. Both are kept without a preference.
+func preferredLoadBalancerTargets(ip, hostname, preferTargetType string) (string, string) {
+	if ip == "" || hostname == "" {
+		return ip, hostname
+	}
+	switch preferTargetType {
+	case annotations.TargetTypeIP:
+		return ip, ""
+	case annotations.TargetTypeHostname:
+		return "", hostname
+	}
+	return ip, hostname
+}
+
 func EndpointTargetsFromServices(svcInformer coreinformers.ServiceInformer, namespace string, selector map[string]string) (endpoint.Targets, error) {
 	targets := endpoint.Targets{}
 
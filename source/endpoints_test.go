@@ -18,12 +18,17 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	networkv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 func TestEndpointsForHostname(t *testing.T) {
@@ -123,6 +128,362 @@ func TestEndpointsForHostname(t *testing.T) {
 	}
 }
 
+func TestHostnamesFromAnnotations(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		annotations map[string]string
+		expected    []string
+	}{
+		{name: "no annotations"},
+		{
+			name:        "hostname",
+			annotations: map[string]string{annotations.HostnameKey: "foo.example.com"},
+			expected:    []string{"foo.example.com"},
+		},
+		{
+			name:        "hostname template",
+			annotations: map[string]string{annotations.HostnameTemplateKey: "{{ .Name }}-{{ .Namespace }}.apps.example.com"},
+			expected:    []string{"web-shop.apps.example.com"},
+		},
+		{
+			name: "hostname and hostname template",
+			annotations: map[string]string{
+				annotations.HostnameKey:         "foo.example.com",
+				annotations.HostnameTemplateKey: "{{ .Name }}.apps.example.com., {{ .Name }}.{{ .Labels.team }}.example.com",
+			},
+			expected: []string{"foo.example.com", "web.apps.example.com", "web.payments.example.com"},
+		},
+		{
+			name:        "empty hostname template",
+			annotations: map[string]string{annotations.HostnameTemplateKey: ""},
+		},
+		{
+			name: "invalid hostname template",
+			annotations: map[string]string{
+				annotations.HostnameKey:         "foo.example.com",
+				annotations.HostnameTemplateKey: "{{ .Name }.example.com",
+			},
+			expected: []string{"foo.example.com"},
+		},
+		{
+			name:        "failing hostname template",
+			annotations: map[string]string{annotations.HostnameTemplateKey: "{{ .Name.Missing }}.example.com"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:        "web",
+				Namespace:   "shop",
+				Labels:      map[string]string{"team": "payments"},
+				Annotations: tt.annotations,
+			}}
+			assert.Equal(t, tt.expected, hostnamesFromAnnotations(svc))
+		})
+	}
+}
+
+func TestDecorateEndpoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		meta           metav1.ObjectMeta
+		conflictLabels bool
+		expected       endpoint.Labels
+	}{
+		{
+			name:     "no metadata",
+			meta:     metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			expected: endpoint.Labels{endpoint.ResourceLabelKey: "service/default/foo"},
+		},
+		{
+			name: "creation timestamp and priority",
+			meta: metav1.ObjectMeta{
+				Name:              "foo",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Unix(1700000000, 0),
+				Annotations:       map[string]string{annotations.PriorityKey: "5"},
+			},
+			conflictLabels: true,
+			expected: endpoint.Labels{
+				endpoint.ResourceLabelKey:        "service/default/foo",
+				endpoint.ResourceCreatedLabelKey: "1700000000",
+				endpoint.PriorityLabelKey:        "5",
+			},
+		},
+		{
+			name: "creation timestamp and priority without conflict labels",
+			meta: metav1.ObjectMeta{
+				Name:              "foo",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Unix(1700000000, 0),
+				Annotations:       map[string]string{annotations.PriorityKey: "5"},
+			},
+			expected: endpoint.Labels{endpoint.ResourceLabelKey: "service/default/foo"},
+		},
+		{
+			name: "protected",
+			meta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   "default",
+				Annotations: map[string]string{annotations.ProtectKey: "true"},
+			},
+			expected: endpoint.Labels{
+				endpoint.ResourceLabelKey:  "service/default/foo",
+				endpoint.ProtectedLabelKey: "true",
+			},
+		},
+		{
+			name: "invalid priority",
+			meta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   "default",
+				Annotations: map[string]string{annotations.PriorityKey: "urgent"},
+			},
+			expected: endpoint.Labels{endpoint.ResourceLabelKey: "service/default/foo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+			ep.WithLabel(endpoint.ResourceLabelKey, "service/default/foo")
+			ctx := context.Background()
+			if tt.conflictLabels {
+				ctx = WithConflictLabels(ctx)
+			}
+			decorateEndpoints(ctx, &corev1.Service{ObjectMeta: tt.meta}, []*endpoint.Endpoint{ep})
+			assert.Equal(t, tt.expected, ep.Labels)
+		})
+	}
+}
+
+func TestDecorateEndpointsWeight(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{annotations.WeightKey: "10"},
+	}}
+	a := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2")
+	mx := endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com")
+
+	decorateEndpoints(context.Background(), svc, []*endpoint.Endpoint{a, mx})
+	assert.Equal(t, map[string]int64{"192.0.2.1": 10, "192.0.2.2": 10}, a.Weights)
+	assert.False(t, mx.IsWeighted(), "should only weigh address records")
+
+	svc.Annotations[annotations.WeightKey] = "-1"
+	a = endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+	decorateEndpoints(context.Background(), svc, []*endpoint.Endpoint{a})
+	assert.False(t, a.IsWeighted(), "should ignore an invalid weight")
+}
+
+func TestDecorateEndpointsGeo(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{annotations.GeoCountryKey: "us", annotations.GeoRegionKey: "ca"},
+	}}
+	a := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+
+	decorateEndpoints(context.Background(), svc, []*endpoint.Endpoint{a})
+	assert.Equal(t, &endpoint.GeoLocation{Country: "US", Region: "CA"}, a.Geo)
+}
+
+func TestDecorateEndpointsDescription(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{annotations.DescriptionKey: "Checkout API, owned by the payments team"},
+	}}
+	a := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+
+	decorateEndpoints(context.Background(), svc, []*endpoint.Endpoint{a})
+	assert.Equal(t, "Checkout API, owned by the payments team", a.Description)
+}
+
+func TestDecorateEndpointsCluster(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{annotations.ClusterKey: "member-1"},
+	}}
+	a := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+
+	decorateEndpoints(context.Background(), svc, []*endpoint.Endpoint{a})
+	assert.Equal(t, "member-1", a.Labels[endpoint.ClustersLabelKey])
+
+	svc.Annotations[annotations.ClusterKey] = "member 1"
+	a = endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+	decorateEndpoints(context.Background(), svc, []*endpoint.Endpoint{a})
+	assert.NotContains(t, a.Labels, endpoint.ClustersLabelKey, "should ignore an invalid cluster")
+}
+
+func TestDecorateEndpointsInvalidAnnotations(t *testing.T) {
+	invalid := NewInvalidAnnotations()
+	ctx := WithInvalidAnnotations(context.Background(), invalid)
+	valid := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "valid",
+		Namespace:   "default",
+		Annotations: map[string]string{annotations.TtlKey: "60"},
+	}}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      "foo",
+		Namespace: "default",
+		Annotations: map[string]string{
+			annotations.TtlKey:      "forever",
+			annotations.PriorityKey: "high",
+		},
+	}}
+
+	decorateEndpoints(ctx, valid, []*endpoint.Endpoint{endpoint.NewEndpoint("valid.example.com", endpoint.RecordTypeA, "192.0.2.1")})
+	decorateEndpoints(ctx, svc, []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")})
+
+	list := invalid.List()
+	require.Len(t, list, 1)
+	assert.Equal(t, "service/default/foo", list[0].Resource)
+	assert.Equal(t, "Service", list[0].Ref.Kind)
+	assert.Len(t, list[0].Errs, 2)
+	assert.Contains(t, list[0].Error(), `"high" is not a valid priority value; `)
+}
+
+func TestDecorateEndpointsResourceLabels(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      "foo",
+		Namespace: "default",
+		Labels: map[string]string{
+			"team":                      "payments",
+			"app.kubernetes.io/part-of": "checkout",
+			"tier":                      "frontend",
+		},
+	}}
+
+	ep := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+	decorateEndpoints(context.Background(), svc, []*endpoint.Endpoint{ep})
+	assert.Empty(t, ep.Labels)
+
+	ctx := WithResourceLabels(context.Background(), []string{"team", "app.kubernetes.io/part-of", "environment"})
+	ep = endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+	decorateEndpoints(ctx, svc, []*endpoint.Endpoint{ep})
+	assert.Equal(t, endpoint.Labels{
+		"label/team":                      "payments",
+		"label/app.kubernetes.io/part-of": "checkout",
+	}, ep.Labels)
+}
+
+func TestEndpointsFromMXAnnotation(t *testing.T) {
+	svc := func(mx string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "default",
+			Annotations: map[string]string{annotations.MXKey: mx},
+		}}
+	}
+	a := endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 300, "192.0.2.1").
+		WithLabel(endpoint.ResourceLabelKey, "service/default/foo").
+		WithProviderSpecific("alias", "false").
+		WithProviderSpecific("aws/weight", "10")
+	a.SetIdentifier = "blue"
+	aaaa := endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1")
+	aaaa.SetIdentifier = "blue"
+	cname := endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.com")
+
+	mx := endpointsFromMXAnnotation(svc("10 mail.example.com,20 backup.example.com"), []*endpoint.Endpoint{a, aaaa, cname})
+	expected := endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com", "20 backup.example.com").
+		WithLabel(endpoint.ResourceLabelKey, "service/default/foo").
+		WithProviderSpecific("aws/weight", "10")
+	expected.SetIdentifier = "blue"
+	assert.Equal(t, []*endpoint.Endpoint{expected}, mx)
+
+	assert.Empty(t, endpointsFromMXAnnotation(svc("mail.example.com"), []*endpoint.Endpoint{a}))
+	assert.Empty(t, endpointsFromMXAnnotation(&corev1.Service{}, []*endpoint.Endpoint{a}))
+}
+
+func TestEndpointsFromSRVAnnotation(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{annotations.SRVKey: "_xmpp._tcp 0 0 5222 xmpp.example.com, _sip._udp 10 5 5060 sip.example.com"},
+	}}
+	a := endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 300, "192.0.2.1").
+		WithLabel(endpoint.ResourceLabelKey, "service/default/foo")
+	aaaa := endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1")
+
+	srv := endpointsFromSRVAnnotation(svc, []*endpoint.Endpoint{a, aaaa})
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("_sip._udp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+		endpoint.NewEndpointWithTTL("_xmpp._tcp.example.com", endpoint.RecordTypeSRV, 300, "0 0 5222 xmpp.example.com").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+	}, srv)
+
+	svc.Annotations[annotations.SRVKey] = "_sip._udp sip.example.com"
+	assert.Empty(t, endpointsFromSRVAnnotation(svc, []*endpoint.Endpoint{a}))
+}
+
+func TestEndpointsFromRecordAnnotations(t *testing.T) {
+	ing := &networkv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:      "foo",
+		Namespace: "default",
+		Annotations: map[string]string{
+			annotations.MXKey:    "10 mail.example.com",
+			annotations.SRVKey:   "_sip._udp 10 5 5060 sip.example.com",
+			annotations.HTTPSKey: "1 . alpn=h3,h2",
+		},
+	}}
+	a := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+	cname := endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.com")
+
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com"),
+		endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpoint("_sip._udp.www.example.com", endpoint.RecordTypeSRV, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeHTTPS, "1 . alpn=h3,h2"),
+	}, endpointsFromRecordAnnotations(ing, []*endpoint.Endpoint{a, cname}))
+}
+
+func TestDecorateEndpointsObjectReference(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "foo", Namespace: "default", UID: "uid"}
+	tests := []struct {
+		name     string
+		obj      metav1.Object
+		expected *events.ObjectReference
+	}{
+		{
+			name:     "service",
+			obj:      &corev1.Service{ObjectMeta: meta},
+			expected: &events.ObjectReference{Kind: "Service", ApiVersion: "v1", Namespace: "default", Name: "foo", UID: "uid", Source: "service"},
+		},
+		{
+			name:     "ingress",
+			obj:      &networkv1.Ingress{ObjectMeta: meta},
+			expected: &events.ObjectReference{Kind: "Ingress", ApiVersion: "networking.k8s.io/v1", Namespace: "default", Name: "foo", UID: "uid", Source: "ingress"},
+		},
+		{
+			name:     "dns endpoint",
+			obj:      &apiv1alpha1.DNSEndpoint{ObjectMeta: meta},
+			expected: &events.ObjectReference{Kind: "DNSEndpoint", ApiVersion: "externaldns.k8s.io/v1alpha1", Namespace: "default", Name: "foo", UID: "uid", Source: "crd"},
+		},
+		{
+			name: "object with type meta",
+			obj: &corev1.Pod{
+				TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+				ObjectMeta: meta,
+			},
+			expected: &events.ObjectReference{Kind: "Pod", ApiVersion: "v1", Namespace: "default", Name: "foo", UID: "uid", Source: "pod"},
+		},
+		{
+			name: "object of unknown kind",
+			obj:  &corev1.Pod{ObjectMeta: meta},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "192.0.2.1")
+			decorateEndpoints(context.Background(), tt.obj, []*endpoint.Endpoint{ep})
+			assert.Equal(t, tt.expected, ep.RefObject())
+		})
+	}
+}
+
 func TestEndpointTargetsFromServices(t *testing.T) {
 	tests := []struct {
 		name      string
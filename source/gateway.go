@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -72,8 +73,8 @@ type gatewayRouteInformer interface {
 	Informer() cache.SharedIndexInformer
 }
 
-func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector) gwinformers.SharedInformerFactory {
-	var opts []gwinformers.SharedInformerOption
+func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector, transform cache.TransformFunc, resync time.Duration) gwinformers.SharedInformerFactory {
+	opts := []gwinformers.SharedInformerOption{gwinformers.WithTransform(transform)}
 	if namespace != "" {
 		opts = append(opts, gwinformers.WithNamespace(namespace))
 	}
@@ -83,7 +84,7 @@ func newGatewayInformerFactory(client gateway.Interface, namespace string, label
 			o.LabelSelector = lbls
 		}))
 	}
-	return gwinformers.NewSharedInformerFactoryWithOptions(client, 0, opts...)
+	return gwinformers.NewSharedInformerFactoryWithOptions(client, resync, opts...)
 }
 
 type gatewayRouteSource struct {
@@ -130,13 +131,14 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels)
+	transform := config.informerTransform("gateway-" + strings.ToLower(kind))
+	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels, transform, config.InformerResyncPeriod)
 	gwInformer := informerFactory.Gateway().V1beta1().Gateways() // TODO: Gateway informer should be shared across gateway sources.
 	gwInformer.Informer()                                        // Register with factory before starting.
 
 	rtInformerFactory := informerFactory
 	if config.Namespace != config.GatewayNamespace || !selectorsEqual(rtLabels, gwLabels) {
-		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels)
+		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels, transform, config.InformerResyncPeriod)
 	}
 	rtInformer := newInformerFn(rtInformerFactory)
 	rtInformer.Informer() // Register with factory before starting.
@@ -146,9 +148,12 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
-	nsInformer := kubeInformerFactory.Core().V1().Namespaces() // TODO: Namespace informer should be shared across gateway sources.
-	nsInformer.Informer()                                      // Register with factory before starting.
+	kubeInformerFactory, shared := informers.SharedFactory(config.sharedInformers(ctx, "gateway-"+strings.ToLower(kind)), kubeClient, "")
+	if !shared {
+		kubeInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, config.InformerResyncPeriod, kubeinformers.WithTransform(transform))
+	}
+	nsInformer := kubeInformerFactory.Core().V1().Namespaces()
+	nsInformer.Informer() // Register with factory before starting.
 
 	informerFactory.Start(wait.NeverStop)
 	kubeInformerFactory.Start(wait.NeverStop)
@@ -244,6 +249,8 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 		for host, targets := range hostTargets {
 			routeEndpoints = append(routeEndpoints, EndpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
+		decorateEndpoints(ctx, rt.Object(), routeEndpoints)
+
 		log.Debugf("Endpoints generated from %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, routeEndpoints)
 
 		endpoints = append(endpoints, routeEndpoints...)
@@ -407,7 +414,7 @@ func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
 	// TODO: The ignore-hostname-annotation flag help says "valid only when using fqdn-template"
 	// but other sources don't check if fqdn-template is set. Which should it be?
 	if !c.src.ignoreHostnameAnnotation {
-		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(rt.Metadata().Annotations)...)
+		hostnames = append(hostnames, hostnamesFromAnnotations(rt.Object())...)
 	}
 	// TODO: The combine-fqdn-annotation flag is similarly vague.
 	if c.src.fqdnTemplate != nil && (len(hostnames) == 0 || c.src.combineFQDNAnnotation) {
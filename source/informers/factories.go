@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"sync"
+
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+type factoryKey struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+// Factories hands out the Kubernetes informer factories shared by the sources, one per client and namespace, so
+// that sources watching the same resources, such as the Services, Pods and Nodes, share a single watch and cache
+// instead of each keeping their own.
+//
+// The informers of the shared factories cache the objects transformed by SharedObjectsTransform, which keeps the
+// fields any source reads. Sources must therefore not set transforms of their own on them.
+type Factories struct {
+	opts      []kubeinformers.SharedInformerOption
+	mu        sync.Mutex
+	factories map[factoryKey]kubeinformers.SharedInformerFactory
+}
+
+// NewFactories returns Factories creating the factories with the given options in addition to the shared
+// objects transform.
+func NewFactories(opts ...kubeinformers.SharedInformerOption) *Factories {
+	return &Factories{
+		opts:      append(opts, kubeinformers.WithTransform(SharedObjectsTransform())),
+		factories: map[factoryKey]kubeinformers.SharedInformerFactory{},
+	}
+}
+
+// Factory returns the factory of the informers of the given namespace, or of all namespaces if empty.
+func (f *Factories) Factory(client kubernetes.Interface, namespace string) kubeinformers.SharedInformerFactory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := factoryKey{client: client, namespace: namespace}
+	factory, ok := f.factories[key]
+	if !ok {
+		factory = kubeinformers.NewSharedInformerFactoryWithOptions(client, 0, append(f.opts, kubeinformers.WithNamespace(namespace))...)
+		f.factories[key] = factory
+	}
+	return factory
+}
+
+type factoriesContextKey struct{}
+
+// WithFactories returns a copy of ctx carrying the given factories, which the sources built with it share.
+func WithFactories(ctx context.Context, f *Factories) context.Context {
+	return context.WithValue(ctx, factoriesContextKey{}, f)
+}
+
+// SharedFactory returns the shared factory of the informers of the given namespace when ctx carries Factories.
+// Otherwise, false is returned and the source creates a factory of its own.
+func SharedFactory(ctx context.Context, client kubernetes.Interface, namespace string) (kubeinformers.SharedInformerFactory, bool) {
+	f, ok := ctx.Value(factoriesContextKey{}).(*Factories)
+	if !ok || f == nil {
+		return nil, false
+	}
+	return f.Factory(client, namespace), true
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSharedFactory(t *testing.T) {
+	client := fake.NewClientset()
+
+	_, ok := SharedFactory(context.Background(), client, "")
+	assert.False(t, ok, "no factories without shared informers")
+
+	ctx := WithFactories(context.Background(), NewFactories())
+	factory, ok := SharedFactory(ctx, client, "")
+	require.True(t, ok)
+
+	other, _ := SharedFactory(ctx, client, "")
+	assert.Same(t, factory, other)
+	other, _ = SharedFactory(ctx, client, "default")
+	assert.NotSame(t, factory, other)
+	other, _ = SharedFactory(ctx, fake.NewClientset(), "")
+	assert.NotSame(t, factory, other)
+}
+
+func TestFactoriesTransform(t *testing.T) {
+	ctx := t.Context()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "default", UID: "uid"},
+		Spec:       corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "app"}}},
+	}
+	client := fake.NewClientset(pod)
+
+	factory := NewFactories().Factory(client, "default")
+	podInformer := factory.Core().V1().Pods()
+	_, _ = podInformer.Informer().AddEventHandler(DefaultEventHandler())
+	factory.Start(ctx.Done())
+	require.NoError(t, WaitForCacheSync(ctx, factory))
+
+	cached, err := podInformer.Lister().Pods("default").Get("pod-1")
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", cached.Spec.NodeName)
+	assert.Empty(t, cached.Spec.Containers)
+}
@@ -14,6 +14,8 @@ limitations under the License.
 package informers
 
 import (
+	"maps"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
@@ -23,6 +25,7 @@ type TransformOptions struct {
 	specSelector    bool
 	specExternalIps bool
 	statusLb        bool
+	labels          []string
 }
 
 func TransformerWithOptions[T metav1.Object](optFns ...func(options *TransformOptions)) cache.TransformFunc {
@@ -58,6 +61,14 @@ func TransformerWithOptions[T metav1.Object](optFns ...func(options *TransformOp
 		if options.statusLb {
 			svc.Status.LoadBalancer = entity.Status.LoadBalancer
 		}
+		for _, key := range options.labels {
+			if value, ok := entity.Labels[key]; ok {
+				if svc.Labels == nil {
+					svc.Labels = map[string]string{}
+				}
+				svc.Labels[key] = value
+			}
+		}
 		return svc, nil
 	}
 }
@@ -82,3 +93,82 @@ func TransformWithStatusLoadBalancer() func(options *TransformOptions) {
 		options.statusLb = true
 	}
 }
+
+// TransformWithLabels enables copying the given labels of the Service.
+func TransformWithLabels(keys ...string) func(options *TransformOptions) {
+	return func(options *TransformOptions) {
+		options.labels = append(options.labels, keys...)
+	}
+}
+
+// lastAppliedConfigAnnotation is set by `kubectl apply` and holds a complete copy of the object.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// StripUnusedFields returns a transform that drops data no source reads from cached objects:
+// managed fields and the kubectl last-applied-configuration annotation of any object, and the
+// image, volume and config status of Nodes. Objects are modified in place and the transform is idempotent.
+func StripUnusedFields() cache.TransformFunc {
+	return func(obj any) (any, error) {
+		entity, ok := obj.(metav1.Object)
+		if !ok {
+			return obj, nil
+		}
+		entity.SetManagedFields(nil)
+		if annots := entity.GetAnnotations(); annots[lastAppliedConfigAnnotation] != "" {
+			delete(annots, lastAppliedConfigAnnotation)
+			entity.SetAnnotations(annots)
+		}
+		if node, ok := obj.(*corev1.Node); ok {
+			node.Status.Images = nil
+			node.Status.VolumesInUse = nil
+			node.Status.VolumesAttached = nil
+			node.Status.Config = nil
+		}
+		return obj, nil
+	}
+}
+
+// SharedObjectsTransform returns the transform of the objects cached by the shared informer factories. Fields no
+// source reads are stripped as by StripUnusedFields, and Pods are reduced to the fields read by the service and pod
+// sources, which share them.
+func SharedObjectsTransform() cache.TransformFunc {
+	strip := StripUnusedFields()
+	return func(obj any) (any, error) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return strip(obj)
+		}
+		if pod.UID == "" {
+			// Pod was already transformed and we must be idempotent.
+			return pod, nil
+		}
+		annots := pod.Annotations
+		if annots[lastAppliedConfigAnnotation] != "" {
+			annots = maps.Clone(annots)
+			delete(annots, lastAppliedConfigAnnotation)
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				// Name/namespace must always be kept for the informer to work.
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+				// Used to match services and by the pod source, which reads annotations without the external-dns prefix.
+				Labels:            pod.Labels,
+				Annotations:       annots,
+				DeletionTimestamp: pod.DeletionTimestamp,
+			},
+			Spec: corev1.PodSpec{
+				Hostname:    pod.Spec.Hostname,
+				HostNetwork: pod.Spec.HostNetwork,
+				NodeName:    pod.Spec.NodeName,
+			},
+			Status: corev1.PodStatus{
+				HostIP:     pod.Status.HostIP,
+				Phase:      pod.Status.Phase,
+				Conditions: pod.Status.Conditions,
+				PodIP:      pod.Status.PodIP,
+				PodIPs:     pod.Status.PodIPs,
+			},
+		}, nil
+	}
+}
@@ -76,6 +76,17 @@ func TestTransformerWithOptions_Service(t *testing.T) {
 				assert.NotEmpty(t, svc.Status.LoadBalancer.Ingress)
 			},
 		},
+		{
+			name:    "with labels",
+			options: []func(*TransformOptions){TransformWithLabels("env", "missing")},
+			asserts: func(obj any) {
+				svc, ok := obj.(*corev1.Service)
+				assert.True(t, ok)
+				assert.Equal(t, map[string]string{"env": "prod"}, svc.Labels)
+				assert.Empty(t, svc.Annotations)
+				assert.Empty(t, svc.Spec.Selector)
+			},
+		},
 		{
 			name: "all options",
 			options: []func(*TransformOptions){
@@ -174,3 +185,165 @@ func TestTransformer_Service_WithFakeClient(t *testing.T) {
 		assert.Equal(t, svc.Labels, got.Labels)
 	})
 }
+
+func TestStripUnusedFields(t *testing.T) {
+	managedFields := []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}}
+
+	t.Run("service", func(t *testing.T) {
+		svc := fakeService()
+		svc.ManagedFields = managedFields
+		svc.Annotations[lastAppliedConfigAnnotation] = `{"apiVersion":"v1","kind":"Service"}`
+
+		got, err := StripUnusedFields()(svc)
+		require.NoError(t, err)
+
+		out, ok := got.(*corev1.Service)
+		require.True(t, ok)
+		assert.Empty(t, out.ManagedFields)
+		assert.NotContains(t, out.Annotations, lastAppliedConfigAnnotation)
+		assert.Equal(t, "Enriched service object", out.Annotations["description"])
+		assert.Equal(t, map[string]string{"app": "demo"}, out.Spec.Selector)
+		assert.NotEmpty(t, out.Status.LoadBalancer.Ingress)
+	})
+
+	t.Run("node", func(t *testing.T) {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", ManagedFields: managedFields},
+			Status: corev1.NodeStatus{
+				Addresses:       []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: "1.2.3.4"}},
+				Images:          []corev1.ContainerImage{{Names: []string{"registry.k8s.io/pause:3.10"}}},
+				VolumesInUse:    []corev1.UniqueVolumeName{"kubernetes.io/csi/disk"},
+				VolumesAttached: []corev1.AttachedVolume{{Name: "kubernetes.io/csi/disk"}},
+			},
+		}
+
+		got, err := StripUnusedFields()(node)
+		require.NoError(t, err)
+
+		out, ok := got.(*corev1.Node)
+		require.True(t, ok)
+		assert.Empty(t, out.ManagedFields)
+		assert.Equal(t, node.Status.Addresses, out.Status.Addresses)
+		assert.Empty(t, out.Status.Images)
+		assert.Empty(t, out.Status.VolumesInUse)
+		assert.Empty(t, out.Status.VolumesAttached)
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		svc := fakeService()
+		svc.ManagedFields = managedFields
+
+		transform := StripUnusedFields()
+		first, err := transform(svc)
+		require.NoError(t, err)
+		second, err := transform(first)
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("non-object input", func(t *testing.T) {
+		got, err := StripUnusedFields()("not-an-object")
+		require.NoError(t, err)
+		assert.Equal(t, "not-an-object", got)
+	})
+}
+
+func TestStripUnusedFields_WithFakeClient(t *testing.T) {
+	ctx := t.Context()
+	svc := fakeService()
+	svc.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}}
+	svc.Annotations[lastAppliedConfigAnnotation] = `{"apiVersion":"v1","kind":"Service"}`
+	fakeClient := fake.NewClientset()
+
+	_, err := fakeClient.CoreV1().Services(svc.Namespace).Create(ctx, svc, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	factory := kubeinformers.NewSharedInformerFactoryWithOptions(fakeClient, 0,
+		kubeinformers.WithNamespace(svc.Namespace), kubeinformers.WithTransform(StripUnusedFields()))
+	serviceInformer := factory.Core().V1().Services()
+	serviceInformer.Informer()
+
+	factory.Start(ctx.Done())
+	err = WaitForCacheSync(ctx, factory)
+	require.NoError(t, err)
+
+	got, err := serviceInformer.Lister().Services(svc.Namespace).Get(svc.Name)
+	require.NoError(t, err)
+
+	assert.Empty(t, got.ManagedFields)
+	assert.NotContains(t, got.Annotations, lastAppliedConfigAnnotation)
+	assert.Equal(t, svc.Labels, got.Labels)
+	assert.Equal(t, svc.Spec.Selector, got.Spec.Selector)
+}
+
+func TestSharedObjectsTransform(t *testing.T) {
+	t.Run("pod", func(t *testing.T) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:          "pod-1",
+				Namespace:     "default",
+				UID:           "uid",
+				Labels:        map[string]string{"app": "demo"},
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+				Annotations: map[string]string{
+					"external-dns.alpha.kubernetes.io/hostname": "a.example.com",
+					"description":               "kept for the pod source",
+					lastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"Pod"}`,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Hostname:    "pod-1",
+				HostNetwork: true,
+				NodeName:    "node-1",
+				Containers:  []corev1.Container{{Name: "app", Image: "registry.k8s.io/pause:3.10"}},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				HostIP:     "10.0.0.1",
+				PodIP:      "10.1.0.1",
+				PodIPs:     []corev1.PodIP{{IP: "10.1.0.1"}},
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+
+		transform := SharedObjectsTransform()
+		got, err := transform(pod)
+		require.NoError(t, err)
+
+		out, ok := got.(*corev1.Pod)
+		require.True(t, ok)
+		assert.Empty(t, out.UID)
+		assert.Empty(t, out.ManagedFields)
+		assert.Empty(t, out.Spec.Containers)
+		assert.Equal(t, pod.Labels, out.Labels)
+		assert.Equal(t, map[string]string{
+			"external-dns.alpha.kubernetes.io/hostname": "a.example.com",
+			"description": "kept for the pod source",
+		}, out.Annotations)
+		assert.Contains(t, pod.Annotations, lastAppliedConfigAnnotation, "the received pod must not be modified")
+		assert.Equal(t, pod.Spec.Hostname, out.Spec.Hostname)
+		assert.True(t, out.Spec.HostNetwork)
+		assert.Equal(t, pod.Spec.NodeName, out.Spec.NodeName)
+		assert.Equal(t, pod.Status.Phase, out.Status.Phase)
+		assert.Equal(t, pod.Status.HostIP, out.Status.HostIP)
+		assert.Equal(t, pod.Status.PodIPs, out.Status.PodIPs)
+		assert.Equal(t, pod.Status.Conditions, out.Status.Conditions)
+
+		again, err := transform(out)
+		require.NoError(t, err)
+		assert.Same(t, out, again)
+	})
+
+	t.Run("service", func(t *testing.T) {
+		svc := fakeService()
+		svc.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+
+		got, err := SharedObjectsTransform()(svc)
+		require.NoError(t, err)
+
+		out, ok := got.(*corev1.Service)
+		require.True(t, ok)
+		assert.Empty(t, out.ManagedFields)
+		assert.Equal(t, map[string]string{"app": "demo"}, out.Spec.Selector)
+	})
+}
@@ -63,6 +63,7 @@ type ingressSource struct {
 	ignoreIngressTLSSpec     bool
 	ignoreIngressRulesSpec   bool
 	labelSelector            labels.Selector
+	preferTargetType         string
 }
 
 // NewIngressSource creates a new ingressSource with the given config.
@@ -72,7 +73,9 @@ func NewIngressSource(
 	namespace, annotationFilter, fqdnTemplate string,
 	combineFqdnAnnotation, ignoreHostnameAnnotation, ignoreIngressTLSSpec, ignoreIngressRulesSpec bool,
 	labelSelector labels.Selector,
-	ingressClassNames []string) (Source, error) {
+	ingressClassNames []string,
+	preferTargetType string,
+	opts ...kubeinformers.SharedInformerOption) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -95,7 +98,10 @@ func NewIngressSource(
 	}
 	// Use shared informer to listen for add/update/delete of ingresses in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory, shared := informers.SharedFactory(ctx, kubeClient, namespace)
+	if !shared {
+		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, append(opts, kubeinformers.WithNamespace(namespace))...)
+	}
 	ingressInformer := informerFactory.Networking().V1().Ingresses()
 
 	// Add default resource event handlers to properly initialize informer.
@@ -120,13 +126,14 @@ func NewIngressSource(
 		ignoreIngressTLSSpec:     ignoreIngressTLSSpec,
 		ignoreIngressRulesSpec:   ignoreIngressRulesSpec,
 		labelSelector:            labelSelector,
+		preferTargetType:         preferTargetType,
 	}
 	return sc, nil
 }
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all ingress resources on all namespaces
-func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (sc *ingressSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	ingresses, err := sc.ingressInformer.Lister().Ingresses(sc.namespace).List(sc.labelSelector)
 	if err != nil {
 		return nil, err
@@ -151,7 +158,7 @@ func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
-		ingEndpoints := endpointsFromIngress(ing, sc.ignoreHostnameAnnotation, sc.ignoreIngressTLSSpec, sc.ignoreIngressRulesSpec)
+		ingEndpoints := endpointsFromIngress(ing, sc.ignoreHostnameAnnotation, sc.ignoreIngressTLSSpec, sc.ignoreIngressRulesSpec, sc.preferTargetType)
 
 		// apply template if host is missing on ingress
 		if (sc.combineFQDNAnnotation || len(ingEndpoints) == 0) && sc.fqdnTemplate != nil {
@@ -168,6 +175,9 @@ func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
+		ingEndpoints = append(ingEndpoints, endpointsFromRecordAnnotations(ing, ingEndpoints)...)
+		decorateEndpoints(ctx, ing, ingEndpoints)
+
 		log.Debugf("Endpoints generated from ingress: %s/%s: %v", ing.Namespace, ing.Name, ingEndpoints)
 		endpoints = append(endpoints, ingEndpoints...)
 	}
@@ -191,7 +201,8 @@ func (sc *ingressSource) endpointsFromTemplate(ing *networkv1.Ingress) ([]*endpo
 
 	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
 	if len(targets) == 0 {
-		targets = targetsFromIngressStatus(ing.Status)
+		preferTargetType := annotations.PreferTargetTypeFromAnnotations(ing.Annotations, sc.preferTargetType, resource)
+		targets = targetsFromIngressStatus(ing.Status, preferTargetType)
 	}
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ing.Annotations)
@@ -272,7 +283,7 @@ func (sc *ingressSource) filterByIngressClass(ingresses []*networkv1.Ingress) ([
 }
 
 // endpointsFromIngress extracts the endpoints from ingress object
-func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool) []*endpoint.Endpoint {
+func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool, preferTargetType string) []*endpoint.Endpoint {
 	resource := fmt.Sprintf("ingress/%s/%s", ing.Namespace, ing.Name)
 
 	ttl := annotations.TTLFromAnnotations(ing.Annotations, resource)
@@ -280,7 +291,8 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
 
 	if len(targets) == 0 {
-		targets = targetsFromIngressStatus(ing.Status)
+		preferTargetType = annotations.PreferTargetTypeFromAnnotations(ing.Annotations, preferTargetType, resource)
+		targets = targetsFromIngressStatus(ing.Status, preferTargetType)
 	}
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ing.Annotations)
@@ -312,7 +324,7 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	// Gather endpoints defined on annotations in the ingress
 	var annotationEndpoints []*endpoint.Endpoint
 	if !ignoreHostnameAnnotation {
-		for _, hostname := range annotations.HostnamesFromAnnotations(ing.Annotations) {
+		for _, hostname := range hostnamesFromAnnotations(ing) {
 			annotationEndpoints = append(annotationEndpoints, EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 	}
@@ -334,15 +346,16 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	return endpoints
 }
 
-func targetsFromIngressStatus(status networkv1.IngressStatus) endpoint.Targets {
+func targetsFromIngressStatus(status networkv1.IngressStatus, preferTargetType string) endpoint.Targets {
 	var targets endpoint.Targets
 
 	for _, lb := range status.LoadBalancer.Ingress {
-		if lb.IP != "" {
-			targets = append(targets, lb.IP)
+		lbIP, lbHostname := preferredLoadBalancerTargets(lb.IP, lb.Hostname, preferTargetType)
+		if lbIP != "" {
+			targets = append(targets, lbIP)
 		}
-		if lb.Hostname != "" {
-			targets = append(targets, lb.Hostname)
+		if lbHostname != "" {
+			targets = append(targets, lbHostname)
 		}
 	}
 
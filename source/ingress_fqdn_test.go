@@ -72,6 +72,7 @@ func TestIngressSourceNewNodeSourceWithFqdn(t *testing.T) {
 				false,
 				labels.Everything(),
 				[]string{},
+				"",
 			)
 
 			if tt.expectError {
@@ -334,6 +335,7 @@ func TestIngressSourceFqdnTemplatingExamples(t *testing.T) {
 				false,
 				labels.Everything(),
 				[]string{},
+				"",
 			)
 
 			require.NoError(t, err)
@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // Validates that ingressSource is a Source
@@ -65,6 +66,7 @@ func (suite *IngressSuite) SetupTest() {
 		false,
 		labels.Everything(),
 		[]string{},
+		"",
 	)
 	suite.NoError(err, "should initialize ingress source")
 }
@@ -129,6 +131,7 @@ func TestNewIngressSource(t *testing.T) {
 				false,
 				labels.Everything(),
 				ti.ingressClassNames,
+				"",
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -260,7 +263,7 @@ func testEndpointsFromIngress(t *testing.T) {
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			realIngress := ti.ingress.Ingress()
-			validateEndpoints(t, endpointsFromIngress(realIngress, ti.ignoreHostnameAnnotation, ti.ignoreIngressTLSSpec, ti.ignoreIngressRulesSpec), ti.expected)
+			validateEndpoints(t, endpointsFromIngress(realIngress, ti.ignoreHostnameAnnotation, ti.ignoreIngressTLSSpec, ti.ignoreIngressRulesSpec, ""), ti.expected)
 		})
 	}
 }
@@ -326,6 +329,45 @@ func testEndpointsFromIngressHostnameSourceAnnotation(t *testing.T) {
 				},
 			},
 		},
+		{
+			title: "No ingress-hostname-source annotation, one rule.host, one hostname template",
+			ingress: fakeIngress{
+				name:        "shop",
+				namespace:   "payments",
+				dnsnames:    []string{"foo.bar"},
+				annotations: map[string]string{annotations.HostnameTemplateKey: "{{ .Name }}-{{ .Namespace }}.apps.example.com"},
+				hostnames:   []string{"lb.com"},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "foo.bar",
+					RecordType: endpoint.RecordTypeCNAME,
+					Targets:    endpoint.Targets{"lb.com"},
+				},
+				{
+					DNSName:    "shop-payments.apps.example.com",
+					RecordType: endpoint.RecordTypeCNAME,
+					Targets:    endpoint.Targets{"lb.com"},
+				},
+			},
+		},
+		{
+			title: "Ingress-hostname-source=annotation-only, one rule.host, one hostname template",
+			ingress: fakeIngress{
+				name:        "shop",
+				namespace:   "payments",
+				dnsnames:    []string{"foo.bar"},
+				annotations: map[string]string{annotations.HostnameTemplateKey: "{{ .Name }}.apps.example.com", ingressHostnameSourceKey: "annotation-only"},
+				hostnames:   []string{"lb.com"},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "shop.apps.example.com",
+					RecordType: endpoint.RecordTypeCNAME,
+					Targets:    endpoint.Targets{"lb.com"},
+				},
+			},
+		},
 		{
 			title: "Ingress-hostname-source=defined-hosts-only, one rule.host, one annotation host",
 			ingress: fakeIngress{
@@ -359,7 +401,49 @@ func testEndpointsFromIngressHostnameSourceAnnotation(t *testing.T) {
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			realIngress := ti.ingress.Ingress()
-			validateEndpoints(t, endpointsFromIngress(realIngress, false, false, false), ti.expected)
+			validateEndpoints(t, endpointsFromIngress(realIngress, false, false, false, ""), ti.expected)
+		})
+	}
+}
+
+func TestEndpointsFromIngressPreferTargetType(t *testing.T) {
+	t.Parallel()
+
+	for _, ti := range []struct {
+		title            string
+		preferTargetType string
+		annotations      map[string]string
+		expected         []*endpoint.Endpoint
+	}{
+		{
+			title: "both targets without preference",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title:            "ip preferred",
+			preferTargetType: "ip",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+			},
+		},
+		{
+			title:            "hostname preferred by annotation over ip",
+			preferTargetType: "ip",
+			annotations:      map[string]string{annotations.PreferTargetTypeKey: "hostname"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+	} {
+		t.Run(ti.title, func(t *testing.T) {
+			t.Parallel()
+
+			ingress := fakeIngress{dnsnames: []string{"foo.bar"}, annotations: ti.annotations}.Ingress()
+			ingress.Status.LoadBalancer.Ingress = []networkv1.IngressLoadBalancerIngress{{IP: "8.8.8.8", Hostname: "lb.com"}}
+			validateEndpoints(t, endpointsFromIngress(ingress, false, false, false, ti.preferTargetType), ti.expected)
 		})
 	}
 }
@@ -1426,6 +1510,7 @@ func testIngressEndpoints(t *testing.T) {
 				ti.ignoreIngressRulesSpec,
 				ti.ingressLabelSelector,
 				ti.ingressClassNames,
+				"",
 			)
 			// Informer cache has all of the ingresses. Retrieve and validate their endpoints.
 			res, err := source.Endpoints(t.Context())
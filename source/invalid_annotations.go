@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// InvalidAnnotation describes the invalid external-dns annotations of a resource read by a source.
+type InvalidAnnotation struct {
+	// Resource identifies the resource as kind/namespace/name, the kind being lowercase
+	Resource string
+	// Ref refers to the resource for events, nil if its kind is unknown
+	Ref *events.ObjectReference
+	// Errs describe each invalid annotation
+	Errs []error
+}
+
+// Error returns the descriptions of the invalid annotations, separated by semicolons.
+func (i InvalidAnnotation) Error() string {
+	msgs := make([]string, 0, len(i.Errs))
+	for _, err := range i.Errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// InvalidAnnotations collects the resources with invalid annotations read by the sources while they list their
+// endpoints.
+type InvalidAnnotations struct {
+	mu        sync.Mutex
+	resources map[string]InvalidAnnotation
+}
+
+// NewInvalidAnnotations returns an empty collection of resources with invalid annotations.
+func NewInvalidAnnotations() *InvalidAnnotations {
+	return &InvalidAnnotations{resources: map[string]InvalidAnnotation{}}
+}
+
+// List returns the resources with invalid annotations, sorted by resource.
+func (r *InvalidAnnotations) List() []InvalidAnnotation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := make([]InvalidAnnotation, 0, len(r.resources))
+	for _, key := range slices.Sorted(maps.Keys(r.resources)) {
+		list = append(list, r.resources[key])
+	}
+	return list
+}
+
+func (r *InvalidAnnotations) add(invalid InvalidAnnotation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resources[invalid.Resource] = invalid
+}
+
+type invalidAnnotationsContextKey struct{}
+
+// WithInvalidAnnotations returns a copy of ctx carrying r, to which the sources listing their endpoints with it
+// report the resources with invalid annotations.
+func WithInvalidAnnotations(ctx context.Context, r *InvalidAnnotations) context.Context {
+	return context.WithValue(ctx, invalidAnnotationsContextKey{}, r)
+}
+
+// reportInvalidAnnotations validates the annotations of obj, reporting them to the InvalidAnnotations carried by
+// ctx if they are invalid.
+func reportInvalidAnnotations(ctx context.Context, obj metav1.Object) {
+	r, ok := ctx.Value(invalidAnnotationsContextKey{}).(*InvalidAnnotations)
+	if !ok || r == nil {
+		return
+	}
+	err := annotations.Validate(obj.GetAnnotations())
+	if err == nil {
+		return
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	ref := objectReference(obj)
+	kind := "unknown"
+	if ref != nil {
+		kind = strings.ToLower(ref.Kind)
+	}
+	r.add(InvalidAnnotation{
+		Resource: kind + "/" + obj.GetNamespace() + "/" + obj.GetName(),
+		Ref:      ref,
+		Errs:     errs,
+	})
+}
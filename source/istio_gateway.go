@@ -19,11 +19,13 @@ package source
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
+	istionetworking "istio.io/api/networking/v1beta1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	istioclient "istio.io/client-go/pkg/clientset/versioned"
 	istioinformers "istio.io/client-go/pkg/informers/externalversions"
@@ -46,6 +48,10 @@ import (
 // instead of a standard LoadBalancer service type
 const IstioGatewayIngressSource = annotations.Ingress
 
+// istioNetworkLabel is the label Istio sets on the services of the gateways of a multi-network mesh to identify
+// the network whose workloads they expose.
+const istioNetworkLabel = "topology.istio.io/network"
+
 // gatewaySource is an implementation of Source for Istio Gateway objects.
 // The gateway implementation uses the spec.servers.hosts values for the hostnames.
 // Use targetAnnotationKey to explicitly set Endpoint.
@@ -57,6 +63,7 @@ type gatewaySource struct {
 	fqdnTemplate             *template.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
+	eastWestDomain           string
 	serviceInformer          coreinformers.ServiceInformer
 	gatewayInformer          networkingv1beta1informer.GatewayInformer
 	ingressInformer          netinformers.IngressInformer
@@ -72,6 +79,7 @@ func NewIstioGatewaySource(
 	fqdnTemplate string,
 	combineFQDNAnnotation bool,
 	ignoreHostnameAnnotation bool,
+	eastWestDomain string,
 ) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
@@ -80,7 +88,10 @@ func NewIstioGatewaySource(
 
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory, shared := informers.SharedFactory(ctx, kubeClient, namespace)
+	if !shared {
+		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	}
 	serviceInformer := informerFactory.Core().V1().Services()
 	istioInformerFactory := istioinformers.NewSharedInformerFactory(istioClient, 0)
 	gatewayInformer := istioInformerFactory.Networking().V1beta1().Gateways()
@@ -90,13 +101,17 @@ func NewIstioGatewaySource(
 
 	// Add default resource event handlers to properly initialize informer.
 	_, _ = serviceInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
-	err = serviceInformer.Informer().SetTransform(informers.TransformerWithOptions[*corev1.Service](
-		informers.TransformWithSpecSelector(),
-		informers.TransformWithSpecExternalIPs(),
-		informers.TransformWithStatusLoadBalancer(),
-	))
-	if err != nil {
-		return nil, err
+	// The shared service informer keeps the services whole for the service source.
+	if !shared {
+		err = serviceInformer.Informer().SetTransform(informers.TransformerWithOptions[*corev1.Service](
+			informers.TransformWithSpecSelector(),
+			informers.TransformWithSpecExternalIPs(),
+			informers.TransformWithStatusLoadBalancer(),
+			informers.TransformWithLabels(istioNetworkLabel),
+		))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	_, _ = gatewayInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
@@ -120,6 +135,7 @@ func NewIstioGatewaySource(
 		fqdnTemplate:             tmpl,
 		combineFQDNAnnotation:    combineFQDNAnnotation,
 		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
+		eastWestDomain:           strings.Trim(eastWestDomain, "."),
 		serviceInformer:          serviceInformer,
 		gatewayInformer:          gatewayInformer,
 		ingressInformer:          ingressInformer,
@@ -172,6 +188,18 @@ func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 			}
 		}
 
+		if sc.eastWestDomain != "" && isEastWestGateway(gateway) {
+			network, err := sc.networkFromGateway(gateway)
+			if err != nil {
+				return nil, err
+			}
+			if network != "" {
+				gwHostnames = append(gwHostnames, network+"."+sc.eastWestDomain)
+			} else {
+				log.Debugf("No network could be found for east-west gateway %s/%s", gateway.Namespace, gateway.Name)
+			}
+		}
+
 		log.Debugf("Processing gateway '%s/%s.%s' and hosts %q", gateway.Namespace, gateway.APIVersion, gateway.Name, strings.Join(gwHostnames, ","))
 
 		if len(gwHostnames) == 0 {
@@ -189,6 +217,8 @@ func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 			continue
 		}
 
+		decorateEndpoints(ctx, gateway, gwEndpoints)
+
 		log.Debugf("Endpoints generated from %q '%s/%s.%s': %q", gateway.Kind, gateway.Namespace, gateway.APIVersion, gateway.Name, gwEndpoints)
 		endpoints = append(endpoints, gwEndpoints...)
 	}
@@ -300,6 +330,10 @@ func (sc *gatewaySource) endpointsFromGateway(ctx context.Context, hostnames []s
 func (sc *gatewaySource) hostNamesFromGateway(gateway *networkingv1beta1.Gateway) ([]string, error) {
 	var hostnames []string
 	for _, server := range gateway.Spec.Servers {
+		// the hosts of the servers of east-west gateways are mesh services, published under their network instead
+		if sc.eastWestDomain != "" && isAutoPassthrough(server) {
+			continue
+		}
 		for _, host := range server.Hosts {
 			if host == "" {
 				continue
@@ -320,8 +354,49 @@ func (sc *gatewaySource) hostNamesFromGateway(gateway *networkingv1beta1.Gateway
 	}
 
 	if !sc.ignoreHostnameAnnotation {
-		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(gateway.Annotations)...)
+		hostnames = append(hostnames, hostnamesFromAnnotations(gateway)...)
 	}
 
 	return hostnames, nil
 }
+
+// isEastWestGateway returns true if the gateway exposes the services of its network to the other networks of the mesh,
+// which east-west gateways do with servers in AUTO_PASSTHROUGH TLS mode.
+func isEastWestGateway(gateway *networkingv1beta1.Gateway) bool {
+	for _, server := range gateway.Spec.Servers {
+		if isAutoPassthrough(server) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAutoPassthrough(server *istionetworking.Server) bool {
+	return server != nil && server.GetTls().GetMode() == istionetworking.ServerTLSSettings_AUTO_PASSTHROUGH
+}
+
+// networkFromGateway returns the mesh network of an east-west gateway, taken from the network label of its services,
+// or of the gateway itself when its services have none.
+func (sc *gatewaySource) networkFromGateway(gateway *networkingv1beta1.Gateway) (string, error) {
+	services, err := sc.serviceInformer.Lister().Services(sc.namespace).List(labels.Everything())
+	if err != nil {
+		return "", fmt.Errorf("failed to list services in namespace %q: %w", sc.namespace, err)
+	}
+	networks := make([]string, 0, 1)
+	for _, service := range services {
+		if network := service.Labels[istioNetworkLabel]; network != "" && MatchesServiceSelector(gateway.Spec.Selector, service.Spec.Selector) {
+			networks = append(networks, network)
+		}
+	}
+	sort.Strings(networks)
+	networks = slices.Compact(networks)
+	switch len(networks) {
+	case 0:
+		return gateway.Labels[istioNetworkLabel], nil
+	case 1:
+		return networks[0], nil
+	default:
+		log.Warnf("Skipping the network record of east-west gateway %s/%s, its services belong to the networks %q", gateway.Namespace, gateway.Name, networks)
+		return "", nil
+	}
+}
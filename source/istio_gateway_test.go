@@ -103,6 +103,7 @@ func (suite *GatewaySuite) SetupTest() {
 		"{{.Name}}",
 		false,
 		false,
+		"",
 	)
 	suite.NoError(err, "should initialize gateway source")
 	suite.NoError(err, "should succeed")
@@ -177,6 +178,7 @@ func TestNewIstioGatewaySource(t *testing.T) {
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				false,
+				"",
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -1515,6 +1517,7 @@ func testGatewayEndpoints(t *testing.T) {
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				ti.ignoreHostnameAnnotation,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -1628,6 +1631,7 @@ func TestGatewaySource_GWSelectorMatchServiceSelector(t *testing.T) {
 				"",
 				false,
 				false,
+				"",
 			)
 			require.NoError(t, err)
 			require.NotNil(t, src)
@@ -1712,7 +1716,8 @@ func TestTransformerInIstioGatewaySource(t *testing.T) {
 		"",
 		"",
 		false,
-		false)
+		false,
+		"")
 	require.NoError(t, err)
 	gwSource, ok := src.(*gatewaySource)
 	require.True(t, ok)
@@ -1870,6 +1875,7 @@ func TestSingleGatewayMultipleServicesPointingToSameLoadBalancer(t *testing.T) {
 		"",
 		false,
 		false,
+		"",
 	)
 	require.NoError(t, err)
 	require.NotNil(t, src)
@@ -1883,6 +1889,102 @@ func TestSingleGatewayMultipleServicesPointingToSameLoadBalancer(t *testing.T) {
 	})
 }
 
+func TestGatewaySourceEastWestGateway(t *testing.T) {
+	fakeKubeClient := fake.NewClientset()
+	fakeIstioClient := istiofake.NewSimpleClientset()
+
+	services := []*v1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "istio-eastwestgateway",
+				Namespace: "istio-system",
+				Labels:    map[string]string{"istio": "eastwestgateway", istioNetworkLabel: "network1"},
+			},
+			Spec: v1.ServiceSpec{Selector: map[string]string{"istio": "eastwestgateway"}},
+			Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{{IP: "192.0.2.10"}},
+			}},
+		},
+		(fakeIngressGatewayService{
+			ips:       []string{"192.0.2.20"},
+			namespace: "istio-system",
+			name:      "istio-ingressgateway",
+			selector:  map[string]string{"istio": "ingressgateway"},
+		}).Service(),
+	}
+	for _, svc := range services {
+		_, err := fakeKubeClient.CoreV1().Services(svc.Namespace).Create(t.Context(), svc, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	gateways := []*networkingv1beta1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cross-network-gateway", Namespace: "istio-system"},
+			Spec: istionetworking.Gateway{
+				Selector: map[string]string{"istio": "eastwestgateway"},
+				Servers: []*istionetworking.Server{{
+					Port:  &istionetworking.Port{Number: 15443, Name: "tls", Protocol: "TLS"},
+					Hosts: []string{"*.local"},
+					Tls:   &istionetworking.ServerTLSSettings{Mode: istionetworking.ServerTLSSettings_AUTO_PASSTHROUGH},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "labeled-gateway",
+				Namespace: "istio-system",
+				Labels:    map[string]string{istioNetworkLabel: "network2"},
+			},
+			Spec: istionetworking.Gateway{
+				Selector: map[string]string{"istio": "ingressgateway"},
+				Servers: []*istionetworking.Server{{
+					Hosts: []string{"*.global"},
+					Tls:   &istionetworking.ServerTLSSettings{Mode: istionetworking.ServerTLSSettings_AUTO_PASSTHROUGH},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress-gateway", Namespace: "istio-system"},
+			Spec: istionetworking.Gateway{
+				Selector: map[string]string{"istio": "ingressgateway"},
+				Servers:  []*istionetworking.Server{{Hosts: []string{"app.example.org"}}},
+			},
+		},
+	}
+	for _, gw := range gateways {
+		_, err := fakeIstioClient.NetworkingV1beta1().Gateways(gw.Namespace).Create(t.Context(), gw, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	src, err := NewIstioGatewaySource(t.Context(), fakeKubeClient, fakeIstioClient, "", "", "", false, false, "mesh.example.org.")
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("network1.mesh.example.org", endpoint.RecordTypeA, "192.0.2.10").
+			WithLabel(endpoint.ResourceLabelKey, "gateway/istio-system/cross-network-gateway"),
+		endpoint.NewEndpoint("network2.mesh.example.org", endpoint.RecordTypeA, "192.0.2.20").
+			WithLabel(endpoint.ResourceLabelKey, "gateway/istio-system/labeled-gateway"),
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.20").
+			WithLabel(endpoint.ResourceLabelKey, "gateway/istio-system/ingress-gateway"),
+	})
+
+	src, err = NewIstioGatewaySource(t.Context(), fakeKubeClient, fakeIstioClient, "", "", "", false, false, "")
+	require.NoError(t, err)
+
+	endpoints, err = src.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("*.local", endpoint.RecordTypeA, "192.0.2.10").
+			WithLabel(endpoint.ResourceLabelKey, "gateway/istio-system/cross-network-gateway"),
+		endpoint.NewEndpoint("*.global", endpoint.RecordTypeA, "192.0.2.20").
+			WithLabel(endpoint.ResourceLabelKey, "gateway/istio-system/labeled-gateway"),
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.20").
+			WithLabel(endpoint.ResourceLabelKey, "gateway/istio-system/ingress-gateway"),
+	})
+}
+
 // gateway specific helper functions
 func newTestGatewaySource(loadBalancerList []fakeIngressGatewayService, ingressList []fakeIngress) (*gatewaySource, error) {
 	fakeKubernetesClient := fake.NewClientset()
@@ -1912,6 +2014,7 @@ func newTestGatewaySource(loadBalancerList []fakeIngressGatewayService, ingressL
 		"{{.Name}}",
 		false,
 		false,
+		"",
 	)
 	if err != nil {
 		return nil, err
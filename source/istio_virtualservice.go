@@ -82,7 +82,10 @@ func NewIstioVirtualServiceSource(
 
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory, shared := informers.SharedFactory(ctx, kubeClient, namespace)
+	if !shared {
+		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	}
 	serviceInformer := informerFactory.Core().V1().Services()
 	istioInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, 0, istioinformers.WithNamespace(namespace))
 	virtualServiceInformer := istioInformerFactory.Networking().V1beta1().VirtualServices()
@@ -93,13 +96,16 @@ func NewIstioVirtualServiceSource(
 
 	// Add default resource event handlers to properly initialize informer.
 	_, _ = serviceInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
-	err = serviceInformer.Informer().SetTransform(informers.TransformerWithOptions[*corev1.Service](
-		informers.TransformWithSpecSelector(),
-		informers.TransformWithSpecExternalIPs(),
-		informers.TransformWithStatusLoadBalancer(),
-	))
-	if err != nil {
-		return nil, err
+	// The shared service informer keeps the services whole for the service source.
+	if !shared {
+		err = serviceInformer.Informer().SetTransform(informers.TransformerWithOptions[*corev1.Service](
+			informers.TransformWithSpecSelector(),
+			informers.TransformWithSpecExternalIPs(),
+			informers.TransformWithStatusLoadBalancer(),
+		))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	_, _ = virtualServiceInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
@@ -181,6 +187,8 @@ func (sc *virtualServiceSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			continue
 		}
 
+		decorateEndpoints(ctx, vService, gwEndpoints)
+
 		log.Debugf("Endpoints generated from %q '%s/%s.%s': %q", vService.Kind, vService.Namespace, vService.APIVersion, vService.Name, gwEndpoints)
 		endpoints = append(endpoints, gwEndpoints...)
 	}
@@ -347,7 +355,7 @@ func (sc *virtualServiceSource) endpointsFromVirtualService(ctx context.Context,
 
 	// Skip endpoints if we do not want entries from annotations
 	if !sc.ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(vService.Annotations)
+		hostnameList := hostnamesFromAnnotations(vService)
 		for _, hostname := range hostnameList {
 			targets := targetsFromAnnotation
 			if len(targets) == 0 {
@@ -19,6 +19,7 @@ package source
 import (
 	"context"
 	"fmt"
+	"strings"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
@@ -41,6 +42,7 @@ type nodeSource struct {
 	annotationFilter      string
 	fqdnTemplate          *template.Template
 	combineFQDNAnnotation bool
+	zoneDomain            string
 
 	nodeInformer         coreinformers.NodeInformer
 	labelSelector        labels.Selector
@@ -56,7 +58,9 @@ func NewNodeSource(
 	labelSelector labels.Selector,
 	exposeInternalIPv6,
 	excludeUnschedulable bool,
-	combineFQDNAnnotation bool) (Source, error) {
+	combineFQDNAnnotation bool,
+	zoneDomain string,
+	opts ...kubeinformers.SharedInformerOption) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -64,7 +68,10 @@ func NewNodeSource(
 
 	// Use shared informers to listen for add/update/delete of nodes.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0)
+	informerFactory, shared := informers.SharedFactory(ctx, kubeClient, "")
+	if !shared {
+		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, opts...)
+	}
 	nodeInformer := informerFactory.Core().V1().Nodes()
 
 	// Add default resource event handler to properly initialize informer.
@@ -82,6 +89,7 @@ func NewNodeSource(
 		annotationFilter:      annotationFilter,
 		fqdnTemplate:          tmpl,
 		combineFQDNAnnotation: combineFQDNAnnotation,
+		zoneDomain:            strings.TrimSuffix(zoneDomain, "."),
 		nodeInformer:          nodeInformer,
 		labelSelector:         labelSelector,
 		excludeUnschedulable:  excludeUnschedulable,
@@ -226,23 +234,61 @@ func (ns *nodeSource) filterByAnnotations(nodes []*v1.Node) ([]*v1.Node, error)
 	return filteredList, nil
 }
 
+// nodeTemplateData is what the FQDN template is evaluated against for a node: the node itself, along with its
+// topology from the well-known labels, so that names such as `{{ .Name }}.{{ .Zone }}.nodes.example.com` need no
+// label lookups.
+type nodeTemplateData struct {
+	*v1.Node
+	// Zone is the topology zone of the node, e.g. eu-west-1a.
+	Zone string
+	// Region is the topology region of the node, e.g. eu-west-1.
+	Region string
+	// InstanceType is the instance type of the node, e.g. m5.large.
+	InstanceType string
+}
+
+func newNodeTemplateData(node *v1.Node) nodeTemplateData {
+	return nodeTemplateData{
+		Node:         node,
+		Zone:         nodeLabel(node, v1.LabelTopologyZone, v1.LabelFailureDomainBetaZone),
+		Region:       nodeLabel(node, v1.LabelTopologyRegion, v1.LabelFailureDomainBetaRegion),
+		InstanceType: nodeLabel(node, v1.LabelInstanceTypeStable, v1.LabelInstanceType),
+	}
+}
+
+// nodeLabel returns the value of the first of the given labels the node has, the later ones being deprecated.
+func nodeLabel(node *v1.Node, keys ...string) string {
+	for _, key := range keys {
+		if value := node.Labels[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
 // collectDNSNames returns a set of DNS names associated with the given Kubernetes Node.
-// If an FQDN template is configured, it renders the template using the Node object
-// to generate one or more DNS names.
+// If an FQDN template is configured, it renders the template using the Node object, along with its
+// topology, to generate one or more DNS names.
 // If combineFQDNAnnotation is enabled, the Node's name is also included alongside
 // the templated names. If no FQDN template is provided, the result will include only
 // the Node's name.
+// With a zone domain, the name of the zone of the Node within this domain is included as well,
+// which aggregates the addresses of the Nodes of each zone.
 //
 // Returns an error if template rendering fails.
 func (ns *nodeSource) collectDNSNames(node *v1.Node) (map[string]bool, error) {
 	dnsNames := make(map[string]bool)
+	data := newNodeTemplateData(node)
+	if ns.zoneDomain != "" && data.Zone != "" {
+		dnsNames[data.Zone+"."+ns.zoneDomain] = true
+	}
 	// If no FQDN template is configured, fallback to the node name
 	if ns.fqdnTemplate == nil {
 		dnsNames[node.Name] = true
 		return dnsNames, nil
 	}
 
-	names, err := fqdn.ExecTemplate(ns.fqdnTemplate, node)
+	names, err := fqdn.ExecTemplate(ns.fqdnTemplate, data)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +65,7 @@ func TestNodeSourceNewNodeSourceWithFqdn(t *testing.T) {
 				true,
 				true,
 				false,
+				"",
 			)
 			if tt.expectError {
 				assert.Error(t, err)
@@ -82,6 +83,7 @@ func TestNodeSourceFqdnTemplatingExamples(t *testing.T) {
 		fqdnTemplate string
 		expected     []*endpoint.Endpoint
 		combineFQDN  bool
+		zoneDomain   string
 	}{
 		{
 			title: "templating expansion with multiple domains",
@@ -321,6 +323,118 @@ func TestNodeSourceFqdnTemplatingExamples(t *testing.T) {
 				{DNSName: "node-name-2", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.178"}},
 			},
 		},
+		{
+			title: "templating with topology labels",
+			nodes: []*v1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node-name-1",
+						Labels: map[string]string{
+							v1.LabelTopologyZone:       "eu-west-1a",
+							v1.LabelTopologyRegion:     "eu-west-1",
+							v1.LabelInstanceTypeStable: "m5.large",
+						},
+					},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "243.186.136.160"}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node-name-2",
+						Labels: map[string]string{
+							v1.LabelFailureDomainBetaZone:   "eu-west-1b",
+							v1.LabelFailureDomainBetaRegion: "eu-west-1",
+							v1.LabelInstanceType:            "m5.xlarge",
+						},
+					},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "243.186.136.178"}},
+					},
+				},
+			},
+			fqdnTemplate: "{{ .Name }}.{{ .Zone }}.{{ .Region }}.example.com,{{ .InstanceType | replace \".\" \"-\" }}.{{ .Region }}.example.com",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "node-name-1.eu-west-1a.eu-west-1.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.160"}},
+				{DNSName: "node-name-2.eu-west-1b.eu-west-1.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.178"}},
+				{DNSName: "m5-large.eu-west-1.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.160"}},
+				{DNSName: "m5-xlarge.eu-west-1.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.178"}},
+			},
+		},
+		{
+			title: "templating with topology labels only for the nodes which have them",
+			nodes: []*v1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "node-name-1",
+						Labels: map[string]string{v1.LabelTopologyZone: "eu-west-1a"},
+					},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "243.186.136.160"}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-name-2"},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "243.186.136.178"}},
+					},
+				},
+			},
+			fqdnTemplate: "{{ .Name }}.example.com{{ with .Zone }},{{ . }}.example.com{{ end }}",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "node-name-1.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.160"}},
+				{DNSName: "node-name-2.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.178"}},
+				{DNSName: "eu-west-1a.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.160"}},
+			},
+		},
+		{
+			title:      "zone domain aggregating the nodes of each zone",
+			zoneDomain: "nodes.example.com.",
+			nodes: []*v1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "node-name-1",
+						Labels: map[string]string{v1.LabelTopologyZone: "eu-west-1a"},
+					},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "243.186.136.160"}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "node-name-2",
+						Labels: map[string]string{v1.LabelTopologyZone: "eu-west-1a"},
+					},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "243.186.136.178"}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "node-name-3",
+						Labels: map[string]string{v1.LabelTopologyZone: "eu-west-1b"},
+					},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "243.186.136.190"}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-name-4"},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "243.186.136.200"}},
+					},
+				},
+			},
+			fqdnTemplate: "{{ .Name }}.example.com",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "node-name-1.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.160"}},
+				{DNSName: "node-name-2.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.178"}},
+				{DNSName: "node-name-3.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.190"}},
+				{DNSName: "node-name-4.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.200"}},
+				{DNSName: "eu-west-1a.nodes.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.160", "243.186.136.178"}},
+				{DNSName: "eu-west-1b.nodes.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"243.186.136.190"}},
+			},
+		},
 	} {
 		t.Run(tt.title, func(t *testing.T) {
 			kubeClient := fake.NewClientset()
@@ -339,6 +453,7 @@ func TestNodeSourceFqdnTemplatingExamples(t *testing.T) {
 				true,
 				true,
 				tt.combineFQDN,
+				tt.zoneDomain,
 			)
 			require.NoError(t, err)
 
@@ -99,6 +99,7 @@ func testNodeSourceNewNodeSource(t *testing.T) {
 				true,
 				true,
 				false,
+				"",
 			)
 
 			if ti.expectError {
@@ -445,6 +446,7 @@ func testNodeSourceEndpoints(t *testing.T) {
 				tc.exposeInternalIPv6,
 				tc.excludeUnschedulable,
 				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -563,6 +565,7 @@ func testNodeEndpointsWithIPv6(t *testing.T) {
 			tc.exposeInternalIPv6,
 			tc.excludeUnschedulable,
 			false,
+			"",
 		)
 		require.NoError(t, err)
 
@@ -611,6 +614,7 @@ func TestResourceLabelIsSetForEachNodeEndpoint(t *testing.T) {
 		false,
 		true,
 		false,
+		"",
 	)
 	require.NoError(t, err)
 
@@ -159,6 +159,8 @@ func (ors *ocpRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			continue
 		}
 
+		decorateEndpoints(ctx, ocpRoute, orEndpoints)
+
 		log.Debugf("Endpoints generated from OpenShift Route: %s/%s: %v", ocpRoute.Namespace, ocpRoute.Name, orEndpoints)
 		endpoints = append(endpoints, orEndpoints...)
 	}
@@ -241,7 +243,7 @@ func (ors *ocpRouteSource) endpointsFromOcpRoute(ocpRoute *routev1.Route, ignore
 
 	// Skip endpoints if we do not want entries from annotations
 	if !ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(ocpRoute.Annotations)
+		hostnameList := hostnamesFromAnnotations(ocpRoute)
 		for _, hostname := range hostnameList {
 			endpoints = append(endpoints, EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
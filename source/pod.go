@@ -62,8 +62,14 @@ func NewPodSource(
 	combineFqdnAnnotation bool,
 	annotationFilter string,
 	labelSelector labels.Selector,
+	opts ...kubeinformers.SharedInformerOption,
 ) (Source, error) {
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory, shared := informers.SharedFactory(ctx, kubeClient, namespace)
+	if !shared || fqdnTemplate != "" {
+		// FQDN templates are rendered with the entire pods, which the shared pod informer doesn't keep.
+		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, append(opts, kubeinformers.WithNamespace(namespace))...)
+		shared = false
+	}
 	podInformer := informerFactory.Core().V1().Pods()
 	nodeInformer := informerFactory.Core().V1().Nodes()
 
@@ -78,7 +84,7 @@ func NewPodSource(
 
 	_, _ = podInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
 
-	if fqdnTemplate == "" {
+	if fqdnTemplate == "" && !shared {
 		// Transformer is used to reduce the memory usage of the informer.
 		// The pod informer will otherwise store a full in-memory, go-typed copy of all pod schemas in the cluster.
 		// If watchList is not used it will not prevent memory bursts on the initial informer sync.
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+var readVerbs = []string{"get", "list", "watch"}
+
+// PolicyRules returns the RBAC rules the given sources need to read the resources they watch.
+// Sources which don't read Kubernetes resources, like fake and connector, need no rule.
+func PolicyRules(cfg *Config, names ...string) []rbacv1.PolicyRule {
+	var rules []rbacv1.PolicyRule
+	add := func(group string, resources ...string) {
+		for _, resource := range resources {
+			rule := rbacv1.PolicyRule{APIGroups: []string{group}, Resources: []string{resource}, Verbs: readVerbs}
+			if !slices.ContainsFunc(rules, func(r rbacv1.PolicyRule) bool {
+				return slices.Equal(r.APIGroups, rule.APIGroups) && slices.Equal(r.Resources, rule.Resources)
+			}) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	for _, name := range names {
+		switch name {
+		case types.Node:
+			add("", "nodes")
+		case types.Pod:
+			add("", "pods", "nodes")
+		case types.Service:
+			add("", "services", "pods", "nodes")
+			add("discovery.k8s.io", "endpointslices")
+		case types.Ingress:
+			add("networking.k8s.io", "ingresses")
+		case types.IstioGateway:
+			add("networking.istio.io", "gateways")
+			add("", "services")
+			add("networking.k8s.io", "ingresses")
+		case types.IstioVirtualService:
+			add("networking.istio.io", "virtualservices", "gateways")
+			add("", "services")
+			add("networking.k8s.io", "ingresses")
+		case types.GatewayHttpRoute, types.GatewayGrpcRoute, types.GatewayTlsRoute, types.GatewayTcpRoute, types.GatewayUdpRoute:
+			add("gateway.networking.k8s.io", "gateways", strings.TrimPrefix(name, "gateway-")+"s")
+			add("", "namespaces")
+		case types.AmbassadorHost:
+			add("getambassador.io", "hosts")
+			add("", "services")
+		case types.ContourHTTPProxy:
+			add("projectcontour.io", "httpproxies")
+		case types.GlooProxy:
+			add("gloo.solo.io", "proxies")
+			add("gateway.solo.io", "virtualservices")
+		case types.TraefikProxy:
+			if !cfg.TraefikDisableNew {
+				add("traefik.io", "ingressroutes", "ingressroutetcps", "ingressrouteudps")
+			}
+			if cfg.TraefikEnableLegacy {
+				add("traefik.containo.us", "ingressroutes", "ingressroutetcps", "ingressrouteudps")
+			}
+		case types.OpenShiftRoute:
+			add("route.openshift.io", "routes")
+		case types.SkipperRouteGroup:
+			add("zalando.org", "routegroups")
+		case types.KongTCPIngress:
+			add("configuration.konghq.com", "tcpingresses")
+		case types.F5VirtualServer:
+			add("cis.f5.com", "virtualservers")
+		case types.F5TransportServer:
+			add("cis.f5.com", "transportservers")
+		case types.CertManagerTLSA:
+			add("", "secrets")
+		case types.CRD:
+			gv, err := schema.ParseGroupVersion(cfg.CRDSourceAPIVersion)
+			if err != nil {
+				continue
+			}
+			resource := strings.ToLower(cfg.CRDSourceKind) + "s"
+			add(gv.Group, resource)
+			rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{gv.Group}, Resources: []string{resource + "/status"}, Verbs: []string{"update"}})
+		}
+	}
+	return rules
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestPolicyRules(t *testing.T) {
+	cfg := &Config{CRDSourceAPIVersion: "externaldns.k8s.io/v1alpha1", CRDSourceKind: "DNSEndpoint"}
+
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: readVerbs},
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: readVerbs},
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: readVerbs},
+		{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: readVerbs},
+		{APIGroups: []string{"gateway.networking.k8s.io"}, Resources: []string{"gateways"}, Verbs: readVerbs},
+		{APIGroups: []string{"gateway.networking.k8s.io"}, Resources: []string{"httproutes"}, Verbs: readVerbs},
+		{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: readVerbs},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsendpoints"}, Verbs: readVerbs},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsendpoints/status"}, Verbs: []string{"update"}},
+	}, PolicyRules(cfg, "node", "service", "gateway-httproute", "crd", "fake"))
+
+	assert.Empty(t, PolicyRules(cfg, "fake", "connector"))
+}
+
+func TestPolicyRulesTraefik(t *testing.T) {
+	newRules := []rbacv1.PolicyRule{
+		{APIGroups: []string{"traefik.io"}, Resources: []string{"ingressroutes"}, Verbs: readVerbs},
+		{APIGroups: []string{"traefik.io"}, Resources: []string{"ingressroutetcps"}, Verbs: readVerbs},
+		{APIGroups: []string{"traefik.io"}, Resources: []string{"ingressrouteudps"}, Verbs: readVerbs},
+	}
+	legacyRules := []rbacv1.PolicyRule{
+		{APIGroups: []string{"traefik.containo.us"}, Resources: []string{"ingressroutes"}, Verbs: readVerbs},
+		{APIGroups: []string{"traefik.containo.us"}, Resources: []string{"ingressroutetcps"}, Verbs: readVerbs},
+		{APIGroups: []string{"traefik.containo.us"}, Resources: []string{"ingressrouteudps"}, Verbs: readVerbs},
+	}
+
+	assert.Equal(t, newRules, PolicyRules(&Config{}, "traefik-proxy"))
+	assert.Equal(t, append(newRules, legacyRules...), PolicyRules(&Config{TraefikEnableLegacy: true}, "traefik-proxy"))
+	assert.Equal(t, legacyRules, PolicyRules(&Config{TraefikEnableLegacy: true, TraefikDisableNew: true}, "traefik-proxy"))
+}
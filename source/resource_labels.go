@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+type resourceLabelsContextKey struct{}
+
+// WithResourceLabels returns a copy of ctx carrying the keys of the resource labels that the sources listing their
+// endpoints with it copy to the labels of the endpoints, under endpoint.ResourceLabelPrefix.
+func WithResourceLabels(ctx context.Context, keys []string) context.Context {
+	return context.WithValue(ctx, resourceLabelsContextKey{}, keys)
+}
+
+// resourceLabels returns the labels of obj whose keys are carried by ctx, prefixed by endpoint.ResourceLabelPrefix.
+func resourceLabels(ctx context.Context, obj metav1.Object) map[string]string {
+	keys, _ := ctx.Value(resourceLabelsContextKey{}).([]string)
+	if len(keys) == 0 {
+		return nil
+	}
+	labels := map[string]string{}
+	for _, key := range keys {
+		if value, ok := obj.GetLabels()[key]; ok {
+			labels[endpoint.ResourceLabelPrefix+key] = value
+		}
+	}
+	return labels
+}
@@ -23,10 +23,12 @@ import (
 	"net"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -81,13 +83,14 @@ type serviceSource struct {
 	nodeInformer                   coreinformers.NodeInformer
 	serviceTypeFilter              *serviceTypes
 	exposeInternalIPv6             bool
+	preferTargetType               string
 
 	// process Services with legacy annotations
 	compatibility string
 }
 
 // NewServiceSource creates a new serviceSource with the given config.
-func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool) (Source, error) {
+func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool, preferTargetType string, opts ...kubeinformers.SharedInformerOption) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -95,7 +98,10 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set the resync period to 0 to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory, shared := informers.SharedFactory(ctx, kubeClient, namespace)
+	if !shared {
+		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, append(opts, kubeinformers.WithNamespace(namespace))...)
+	}
 	serviceInformer := informerFactory.Core().V1().Services()
 
 	// Add default resource event handlers to properly initialize informer.
@@ -136,47 +142,50 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 			return nil, err
 		}
 
-		// Transformer is used to reduce the memory usage of the informer.
-		// The pod informer will otherwise store a full in-memory, go-typed copy of all pod schemas in the cluster.
-		// If watchList is not used it will not prevent memory bursts on the initial informer sync.
-		podInformer.Informer().SetTransform(func(i interface{}) (interface{}, error) {
-			pod, ok := i.(*v1.Pod)
-			if !ok {
-				return nil, fmt.Errorf("object is not a pod")
-			}
-			if pod.UID == "" {
-				// Pod was already transformed and we must be idempotent.
-				return pod, nil
-			}
+		// The shared pod informer keeps the fields read by the service and pod sources already.
+		if !shared {
+			// Transformer is used to reduce the memory usage of the informer.
+			// The pod informer will otherwise store a full in-memory, go-typed copy of all pod schemas in the cluster.
+			// If watchList is not used it will not prevent memory bursts on the initial informer sync.
+			podInformer.Informer().SetTransform(func(i interface{}) (interface{}, error) {
+				pod, ok := i.(*v1.Pod)
+				if !ok {
+					return nil, fmt.Errorf("object is not a pod")
+				}
+				if pod.UID == "" {
+					// Pod was already transformed and we must be idempotent.
+					return pod, nil
+				}
 
-			// All pod level annotations we're interested in start with a common prefix
-			podAnnotations := map[string]string{}
-			for key, value := range pod.Annotations {
-				if strings.HasPrefix(key, annotations.AnnotationKeyPrefix) {
-					podAnnotations[key] = value
+				// All pod level annotations we're interested in start with a common prefix
+				podAnnotations := map[string]string{}
+				for key, value := range pod.Annotations {
+					if strings.HasPrefix(key, annotations.AnnotationKeyPrefix) {
+						podAnnotations[key] = value
+					}
 				}
-			}
-			return &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					// Name/namespace must always be kept for the informer to work.
-					Name:      pod.Name,
-					Namespace: pod.Namespace,
-					// Used to match services.
-					Labels:            pod.Labels,
-					Annotations:       podAnnotations,
-					DeletionTimestamp: pod.DeletionTimestamp,
-				},
-				Spec: v1.PodSpec{
-					Hostname: pod.Spec.Hostname,
-					NodeName: pod.Spec.NodeName,
-				},
-				Status: v1.PodStatus{
-					HostIP:     pod.Status.HostIP,
-					Phase:      pod.Status.Phase,
-					Conditions: pod.Status.Conditions,
-				},
-			}, nil
-		})
+				return &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						// Name/namespace must always be kept for the informer to work.
+						Name:      pod.Name,
+						Namespace: pod.Namespace,
+						// Used to match services.
+						Labels:            pod.Labels,
+						Annotations:       podAnnotations,
+						DeletionTimestamp: pod.DeletionTimestamp,
+					},
+					Spec: v1.PodSpec{
+						Hostname: pod.Spec.Hostname,
+						NodeName: pod.Spec.NodeName,
+					},
+					Status: v1.PodStatus{
+						HostIP:     pod.Status.HostIP,
+						Phase:      pod.Status.Phase,
+						Conditions: pod.Status.Conditions,
+					},
+				}, nil
+			})
+		}
 	}
 
 	var nodeInformer coreinformers.NodeInformer
@@ -212,11 +221,12 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		resolveLoadBalancerHostname:    resolveLoadBalancerHostname,
 		listenEndpointEvents:           listenEndpointEvents,
 		exposeInternalIPv6:             exposeInternalIPv6,
+		preferTargetType:               preferTargetType,
 	}, nil
 }
 
 // Endpoints return endpoint objects for each service that should be processed.
-func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (sc *serviceSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	services, err := sc.serviceInformer.Lister().Services(sc.namespace).List(sc.labelSelector)
 	if err != nil {
 		return nil, err
@@ -270,6 +280,9 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
+		svcEndpoints = append(svcEndpoints, endpointsFromRecordAnnotations(svc, svcEndpoints)...)
+		decorateEndpoints(ctx, svc, svcEndpoints)
+
 		log.Debugf("Endpoints generated from service: %s/%s: %v", svc.Namespace, svc.Name, svcEndpoints)
 		endpoints = append(endpoints, svcEndpoints...)
 	}
@@ -355,7 +368,13 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 
 	endpointsType := getEndpointsTypeFromAnnotations(svc.Annotations)
 	publishPodIPs := endpointsType != EndpointsTypeNodeExternalIP && endpointsType != EndpointsTypeHostIP && !sc.publishHostIP
+	resource := fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name)
 	publishNotReadyAddresses := svc.Spec.PublishNotReadyAddresses || sc.alwaysPublishNotReadyAddresses
+	if publish, ok := annotations.PublishNotReadyAddressesFromAnnotations(svc.Annotations, resource); ok {
+		publishNotReadyAddresses = publish
+	}
+	records := annotations.HeadlessRecordsFromAnnotations(svc.Annotations, resource)
+	podNameSource := annotations.HeadlessPodNameFromAnnotations(svc.Annotations, resource)
 
 	targetsByHeadlessDomainAndType := make(map[endpoint.EndpointKey]endpoint.Targets)
 	for _, endpointSlice := range endpointSlices {
@@ -388,9 +407,15 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 				continue
 			}
 
-			headlessDomains := []string{hostname}
-			if pod.Spec.Hostname != "" {
-				headlessDomains = append(headlessDomains, fmt.Sprintf("%s.%s", pod.Spec.Hostname, hostname))
+			var headlessDomains []string
+			if records[annotations.HeadlessRecordsAggregate] {
+				headlessDomains = append(headlessDomains, hostname)
+			}
+			if podName := headlessPodName(pod, podNameSource); records[annotations.HeadlessRecordsPerPod] && podName != "" {
+				headlessDomains = append(headlessDomains, fmt.Sprintf("%s.%s", podName, hostname))
+			}
+			if ordinal, ok := statefulSetOrdinal(pod); records[annotations.HeadlessRecordsPerOrdinal] && ok {
+				headlessDomains = append(headlessDomains, fmt.Sprintf("%d.%s", ordinal, hostname))
 			}
 
 			for _, headlessDomain := range headlessDomains {
@@ -469,7 +494,7 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 		}
 
 		if ep != nil {
-			ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name))
+			ep.WithLabel(endpoint.ResourceLabelKey, resource)
 			endpoints = append(endpoints, ep)
 		}
 	}
@@ -477,6 +502,32 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 	return endpoints
 }
 
+// headlessPodName returns the name of the per-pod records of the pod of a headless service, after the hostname of
+// its spec or its name as given, or an empty name when the pod has no hostname.
+func headlessPodName(pod *v1.Pod, podNameSource string) string {
+	if podNameSource == annotations.HeadlessPodNamePodName {
+		return pod.Name
+	}
+	return pod.Spec.Hostname
+}
+
+// statefulSetOrdinal returns the ordinal of a pod of a StatefulSet, from its pod index label or, on clusters which
+// don't set it, from the suffix of its name. The second return value is false for the other pods.
+func statefulSetOrdinal(pod *v1.Pod) (uint64, bool) {
+	index, ok := pod.Labels[appsv1.PodIndexLabel]
+	if !ok {
+		if _, ok := pod.Labels[appsv1.StatefulSetPodNameLabel]; !ok {
+			return 0, false
+		}
+		index = pod.Name[strings.LastIndex(pod.Name, "-")+1:]
+	}
+	ordinal, err := strconv.ParseUint(index, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return ordinal, true
+}
+
 func (sc *serviceSource) endpointsFromTemplate(svc *v1.Service) ([]*endpoint.Endpoint, error) {
 	hostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, svc)
 	if err != nil {
@@ -506,7 +557,7 @@ func (sc *serviceSource) endpoints(svc *v1.Service) []*endpoint.Endpoint {
 	var hostnameList []string
 	var internalHostnameList []string
 
-	hostnameList = annotations.HostnamesFromAnnotations(svc.Annotations)
+	hostnameList = hostnamesFromAnnotations(svc)
 	for _, hostname := range hostnameList {
 		endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, false)...)
 	}
@@ -575,7 +626,8 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 			if useClusterIP {
 				targets = extractServiceIps(svc)
 			} else {
-				targets = extractLoadBalancerTargets(svc, sc.resolveLoadBalancerHostname)
+				preferTargetType := annotations.PreferTargetTypeFromAnnotations(svc.Annotations, sc.preferTargetType, resource)
+				targets = extractLoadBalancerTargets(svc, sc.resolveLoadBalancerHostname, preferTargetType)
 			}
 		case v1.ServiceTypeClusterIP:
 			if svc.Spec.ClusterIP == v1.ClusterIPNone {
@@ -622,7 +674,7 @@ func extractServiceExternalName(svc *v1.Service) endpoint.Targets {
 	return endpoint.Targets{svc.Spec.ExternalName}
 }
 
-func extractLoadBalancerTargets(svc *v1.Service, resolveLoadBalancerHostname bool) endpoint.Targets {
+func extractLoadBalancerTargets(svc *v1.Service, resolveLoadBalancerHostname bool, preferTargetType string) endpoint.Targets {
 	if len(svc.Spec.ExternalIPs) > 0 {
 		return svc.Spec.ExternalIPs
 	}
@@ -630,21 +682,22 @@ func extractLoadBalancerTargets(svc *v1.Service, resolveLoadBalancerHostname boo
 	// Create a corresponding endpoint for each configured external entrypoint.
 	var targets endpoint.Targets
 	for _, lb := range svc.Status.LoadBalancer.Ingress {
-		if lb.IP != "" {
-			targets = append(targets, lb.IP)
+		lbIP, lbHostname := preferredLoadBalancerTargets(lb.IP, lb.Hostname, preferTargetType)
+		if lbIP != "" {
+			targets = append(targets, lbIP)
 		}
-		if lb.Hostname != "" {
+		if lbHostname != "" {
 			if resolveLoadBalancerHostname {
-				ips, err := net.LookupIP(lb.Hostname)
+				ips, err := net.LookupIP(lbHostname)
 				if err != nil {
-					log.Errorf("Unable to resolve %q: %v", lb.Hostname, err)
+					log.Errorf("Unable to resolve %q: %v", lbHostname, err)
 					continue
 				}
 				for _, ip := range ips {
 					targets = append(targets, ip.String())
 				}
 			} else {
-				targets = append(targets, lb.Hostname)
+				targets = append(targets, lbHostname)
 			}
 		}
 	}
@@ -91,6 +91,7 @@ func (suite *ServiceSuite) SetupTest() {
 		false,
 		false,
 		false,
+		"",
 	)
 	suite.NoError(err, "should initialize service source")
 }
@@ -174,6 +175,7 @@ func testServiceSourceNewServiceSource(t *testing.T) {
 				false,
 				false,
 				false,
+				"",
 			)
 
 			if ti.expectError {
@@ -1158,6 +1160,7 @@ func testServiceSourceEndpoints(t *testing.T) {
 				tc.resolveLoadBalancerHostname,
 				false,
 				false,
+				"",
 			)
 
 			require.NoError(t, err)
@@ -1374,6 +1377,7 @@ func testMultipleServicesEndpoints(t *testing.T) {
 				false,
 				false,
 				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -1679,6 +1683,7 @@ func TestClusterIpServices(t *testing.T) {
 				false,
 				false,
 				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -2456,6 +2461,7 @@ func TestServiceSourceNodePortServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -3268,6 +3274,7 @@ func TestHeadlessServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -3284,6 +3291,163 @@ func TestHeadlessServices(t *testing.T) {
 	}
 }
 
+func TestHeadlessServicesRecords(t *testing.T) {
+	t.Parallel()
+
+	type pod struct {
+		name     string
+		hostname string
+		labels   map[string]string
+		ip       string
+		ready    bool
+	}
+	statefulSetPods := []pod{
+		{name: "web-0", hostname: "web-0", labels: map[string]string{appsv1.PodIndexLabel: "0"}, ip: "10.0.0.1", ready: true},
+		{name: "web-1", hostname: "web-1", labels: map[string]string{appsv1.StatefulSetPodNameLabel: "web-1"}, ip: "10.0.0.2", ready: true},
+		{name: "web-2", hostname: "web-2", labels: map[string]string{appsv1.PodIndexLabel: "2"}, ip: "10.0.0.3", ready: false},
+	}
+	deploymentPods := []pod{
+		{name: "api-7d9f8-x2x4z", ip: "10.0.1.1", ready: true},
+		{name: "api-7d9f8-q8w2k", ip: "10.0.1.2", ready: false},
+	}
+
+	for _, tc := range []struct {
+		title                    string
+		annotations              map[string]string
+		publishNotReadyAddresses bool
+		pods                     []pod
+		expected                 []*endpoint.Endpoint
+	}{
+		{
+			title: "aggregate and per-pod records by default",
+			pods:  statefulSetPods,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2"}},
+				{DNSName: "web-0.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "web-1.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.2"}},
+			},
+		},
+		{
+			title:       "per-ordinal records",
+			annotations: map[string]string{annotations.HeadlessRecordsKey: "per-ordinal"},
+			pods:        statefulSetPods,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "0.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "1.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.2"}},
+			},
+		},
+		{
+			title:       "aggregate record only",
+			annotations: map[string]string{annotations.HeadlessRecordsKey: "aggregate"},
+			pods:        statefulSetPods,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		{
+			title:       "not ready addresses published by annotation",
+			annotations: map[string]string{annotations.PublishNotReadyAddressesKey: "true", annotations.HeadlessRecordsKey: "aggregate, per-ordinal"},
+			pods:        statefulSetPods,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+				{DNSName: "0.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "1.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.2"}},
+				{DNSName: "2.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.3"}},
+			},
+		},
+		{
+			title:                    "not ready addresses of the service spec withheld by annotation",
+			annotations:              map[string]string{annotations.PublishNotReadyAddressesKey: "false", annotations.HeadlessRecordsKey: "aggregate"},
+			publishNotReadyAddresses: true,
+			pods:                     statefulSetPods,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		{
+			title:                    "not ready addresses of the service spec",
+			annotations:              map[string]string{annotations.HeadlessRecordsKey: "aggregate"},
+			publishNotReadyAddresses: true,
+			pods:                     statefulSetPods,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+			},
+		},
+		{
+			title:       "per-pod records named after the pods without hostname",
+			annotations: map[string]string{annotations.HeadlessPodNameKey: "pod-name", annotations.HeadlessRecordsKey: "per-pod,per-ordinal"},
+			pods:        deploymentPods,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "api-7d9f8-x2x4z.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.1.1"}},
+			},
+		},
+		{
+			title: "no per-pod records for pods without hostname",
+			pods:  deploymentPods,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.1.1"}},
+			},
+		},
+		{
+			title:       "invalid annotations are ignored",
+			annotations: map[string]string{annotations.HeadlessRecordsKey: "per-node", annotations.HeadlessPodNameKey: "uid", annotations.PublishNotReadyAddressesKey: "yes"},
+			pods:        statefulSetPods[:1],
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "web-0.web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubeClient := fake.NewClientset()
+			selector := map[string]string{"app": "web"}
+			svcAnnotations := map[string]string{hostnameAnnotationKey: "web.example.org"}
+			maps.Copy(svcAnnotations, tc.annotations)
+			_, err := kubeClient.CoreV1().Services("default").Create(t.Context(), &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Annotations: svcAnnotations},
+				Spec: v1.ServiceSpec{
+					Type:                     v1.ServiceTypeClusterIP,
+					ClusterIP:                v1.ClusterIPNone,
+					Selector:                 selector,
+					PublishNotReadyAddresses: tc.publishNotReadyAddresses,
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			var sliceEndpoints []discoveryv1.Endpoint
+			for _, p := range tc.pods {
+				podLabels := maps.Clone(selector)
+				maps.Copy(podLabels, p.labels)
+				_, err := kubeClient.CoreV1().Pods("default").Create(t.Context(), &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: p.name, Labels: podLabels},
+					Spec:       v1.PodSpec{Hostname: p.hostname},
+					Status:     v1.PodStatus{PodIP: p.ip},
+				}, metav1.CreateOptions{})
+				require.NoError(t, err)
+				sliceEndpoints = append(sliceEndpoints, discoveryv1.Endpoint{
+					Addresses:  []string{p.ip},
+					TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: p.name},
+					Conditions: discoveryv1.EndpointConditions{Ready: &p.ready},
+				})
+			}
+			_, err = kubeClient.DiscoveryV1().EndpointSlices("default").Create(t.Context(), &discoveryv1.EndpointSlice{
+				ObjectMeta:  metav1.ObjectMeta{Namespace: "default", Name: "web", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints:   sliceEndpoints,
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			src, err := NewServiceSource(t.Context(), kubeClient, "", "", "", false, "", true, false, false, []string{}, false, labels.Everything(), false, false, false, "")
+			require.NoError(t, err)
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
 func TestMultipleServicesPointingToSameLoadBalancer(t *testing.T) {
 	kubernetes := fake.NewClientset()
 
@@ -3404,6 +3568,7 @@ func TestMultipleServicesPointingToSameLoadBalancer(t *testing.T) {
 		false,
 		false,
 		false,
+		"",
 	)
 	require.NoError(t, err)
 	assert.NotNil(t, src)
@@ -3416,6 +3581,79 @@ func TestMultipleServicesPointingToSameLoadBalancer(t *testing.T) {
 	})
 }
 
+func TestServicePreferTargetType(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		title            string
+		preferTargetType string
+		annotations      map[string]string
+		expected         []*endpoint.Endpoint
+	}{
+		{
+			title: "both targets without preference",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+			},
+		},
+		{
+			title:            "ip preferred",
+			preferTargetType: "ip",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:            "hostname preferred",
+			preferTargetType: "hostname",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+			},
+		},
+		{
+			title:            "ip preferred by annotation over hostname",
+			preferTargetType: "hostname",
+			annotations:      map[string]string{annotations.PreferTargetTypeKey: "ip"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:            "invalid annotation ignored",
+			preferTargetType: "hostname",
+			annotations:      map[string]string{annotations.PreferTargetTypeKey: "cname"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubeClient := fake.NewClientset()
+			svcAnnotations := map[string]string{hostnameAnnotationKey: "web.example.org"}
+			maps.Copy(svcAnnotations, tc.annotations)
+			_, err := kubeClient.CoreV1().Services("default").Create(t.Context(), &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Annotations: svcAnnotations},
+				Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+				Status: v1.ServiceStatus{
+					LoadBalancer: v1.LoadBalancerStatus{
+						Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4", Hostname: "lb.example.com"}},
+					},
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			src, err := NewServiceSource(t.Context(), kubeClient, "", "", "", false, "", false, false, false, []string{}, false, labels.Everything(), false, false, false, tc.preferTargetType)
+			require.NoError(t, err)
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
 func TestMultipleHeadlessServicesPointingToPodsOnTheSameNode(t *testing.T) {
 	kubernetes := fake.NewClientset()
 
@@ -3770,6 +4008,7 @@ func TestMultipleHeadlessServicesPointingToPodsOnTheSameNode(t *testing.T) {
 		false,
 		false,
 		false,
+		"",
 	)
 	require.NoError(t, err)
 	assert.NotNil(t, src)
@@ -4228,6 +4467,7 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 				false,
 				false,
 				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -4438,6 +4678,7 @@ func TestExternalServices(t *testing.T) {
 				false,
 				false,
 				false,
+				"",
 			)
 			require.NoError(t, err)
 
@@ -4500,6 +4741,7 @@ func BenchmarkServiceEndpoints(b *testing.B) {
 		false,
 		false,
 		false,
+		"",
 	)
 	require.NoError(b, err)
 
@@ -4599,6 +4841,7 @@ func TestNewServiceSourceInformersEnabled(t *testing.T) {
 				false,
 				false,
 				false,
+				"",
 			)
 			require.NoError(t, err)
 			svcSrc, ok := svc.(*serviceSource)
@@ -4630,6 +4873,7 @@ func TestNewServiceSourceWithServiceTypeFilters_Unsupported(t *testing.T) {
 		false,
 		false,
 		false,
+		"",
 	)
 	require.Errorf(t, err, "unsupported service type filter: \"UnknownType\". Supported types are: [\"ClusterIP\" \"NodePort\" \"LoadBalancer\" \"ExternalName\"]")
 	require.Nil(t, svc, "ServiceSource should be nil when an unsupported service type is provided")
@@ -4809,6 +5053,7 @@ func TestEndpointSlicesIndexer(t *testing.T) {
 		false,
 		false,
 		false,
+		"",
 	)
 	require.NoError(t, err)
 	ss, ok := src.(*serviceSource)
@@ -4896,6 +5141,7 @@ func TestPodTransformerInServiceSource(t *testing.T) {
 		false,
 		false,
 		false,
+		"",
 	)
 	require.NoError(t, err)
 	ss, ok := src.(*serviceSource)
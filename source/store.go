@@ -22,7 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-
+	"slices"
 	"sync"
 	"time"
 
@@ -30,13 +30,20 @@ import (
 	openshift "github.com/openshift/client-go/route/clientset/versioned"
 	log "github.com/sirupsen/logrus"
 	istioclient "istio.io/client-go/pkg/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/dynamic"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
+	"sigs.k8s.io/external-dns/source/informers"
 	"sigs.k8s.io/external-dns/source/types"
 
 	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
@@ -94,6 +101,9 @@ type Config struct {
 	GlooNamespaces                 []string
 	SkipperRouteGroupVersion       string
 	RequestTimeout                 time.Duration
+	KubeAPIQPS                     float32
+	KubeAPIBurst                   int
+	InformerResyncPeriod           time.Duration
 	DefaultTargets                 []string
 	ForceDefaultTargets            bool
 	OCPRouterName                  string
@@ -103,6 +113,13 @@ type Config struct {
 	TraefikDisableNew              bool
 	ExcludeUnschedulable           bool
 	ExposeInternalIPv6             bool
+	NodeZoneDomain                 string
+	InformerFullObjects            []string
+	IstioEastWestDomain            string
+	PreferTargetType               string
+
+	// informerFactories holds the Kubernetes informer factories shared by the sources built with the config.
+	informerFactories *informers.Factories
 }
 
 func NewSourceConfig(cfg *externaldns.Config) *Config {
@@ -140,6 +157,9 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		GlooNamespaces:                 cfg.GlooNamespaces,
 		SkipperRouteGroupVersion:       cfg.SkipperRouteGroupVersion,
 		RequestTimeout:                 cfg.RequestTimeout,
+		KubeAPIQPS:                     cfg.KubeAPIQPS,
+		KubeAPIBurst:                   cfg.KubeAPIBurst,
+		InformerResyncPeriod:           cfg.InformerResyncPeriod,
 		DefaultTargets:                 cfg.DefaultTargets,
 		ForceDefaultTargets:            cfg.ForceDefaultTargets,
 		OCPRouterName:                  cfg.OCPRouterName,
@@ -149,6 +169,57 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		TraefikDisableNew:              cfg.TraefikDisableNew,
 		ExcludeUnschedulable:           cfg.ExcludeUnschedulable,
 		ExposeInternalIPv6:             cfg.ExposeInternalIPV6,
+		NodeZoneDomain:                 cfg.NodeZoneDomain,
+		InformerFullObjects:            cfg.InformerFullObjects,
+		IstioEastWestDomain:            cfg.IstioEastWestDomain,
+		PreferTargetType:               cfg.PreferTargetType,
+		informerFactories:              informers.NewFactories(resyncOptions(cfg.InformerResyncPeriod)...),
+	}
+}
+
+// informerTransform returns the transform applied to objects cached by the informers of the given source.
+// Fields no source reads are stripped unless the source is listed in InformerFullObjects, in which case
+// nil is returned and objects are cached as received from the API server.
+func (cfg *Config) informerTransform(source string) cache.TransformFunc {
+	if slices.Contains(cfg.InformerFullObjects, source) {
+		return nil
+	}
+	return informers.StripUnusedFields()
+}
+
+// informerOptions returns the shared informer factory options for the given source.
+func (cfg *Config) informerOptions(source string) []kubeinformers.SharedInformerOption {
+	return append(resyncOptions(cfg.InformerResyncPeriod), kubeinformers.WithTransform(cfg.informerTransform(source)))
+}
+
+// sharedInformers returns a copy of ctx carrying the informer factories shared by the sources, from which the
+// given source gets its Kubernetes informers. Sources listed in InformerFullObjects keep informers of their own,
+// as the shared ones strip the objects.
+func (cfg *Config) sharedInformers(ctx context.Context, source string) context.Context {
+	if cfg.informerFactories == nil || slices.Contains(cfg.InformerFullObjects, source) {
+		return ctx
+	}
+	return informers.WithFactories(ctx, cfg.informerFactories)
+}
+
+// resyncOptions returns the shared informer factory options resyncing the objects at the given period, if set.
+func resyncOptions(period time.Duration) []kubeinformers.SharedInformerOption {
+	if period <= 0 {
+		return nil
+	}
+	return []kubeinformers.SharedInformerOption{kubeinformers.WithCustomResyncConfig(resyncConfig(period))}
+}
+
+// resyncConfig returns the resync period of each of the Kubernetes objects watched by the sources taking the
+// shared informer factory options, whose factories don't resync otherwise.
+func resyncConfig(period time.Duration) map[metav1.Object]time.Duration {
+	return map[metav1.Object]time.Duration{
+		&corev1.Service{}:            period,
+		&corev1.Pod{}:                period,
+		&corev1.Node{}:               period,
+		&discoveryv1.EndpointSlice{}: period,
+		&networkv1.Ingress{}:         period,
+		&corev1.Namespace{}:          period,
 	}
 }
 
@@ -185,12 +256,14 @@ type ClientGenerator interface {
 // Memory Efficiency: Prevents creating multiple instances of expensive client objects
 // that maintain their own connection pools and caches.
 //
-// Configuration: Clients are configured using KubeConfig, APIServerURL, and RequestTimeout
+// Configuration: Clients are configured using KubeConfig, APIServerURL, RequestTimeout, QPS and Burst
 // which are set during SingletonClientGenerator initialization.
 type SingletonClientGenerator struct {
 	KubeConfig      string
 	APIServerURL    string
 	RequestTimeout  time.Duration
+	QPS             float32
+	Burst           int
 	kubeClient      kubernetes.Interface
 	gatewayClient   gateway.Interface
 	istioClient     *istioclient.Clientset
@@ -209,7 +282,7 @@ type SingletonClientGenerator struct {
 func (p *SingletonClientGenerator) KubeClient() (kubernetes.Interface, error) {
 	var err error
 	p.kubeOnce.Do(func() {
-		p.kubeClient, err = NewKubeClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.kubeClient, err = NewKubeClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout, p.QPS, p.Burst)
 	})
 	return p.kubeClient, err
 }
@@ -218,13 +291,13 @@ func (p *SingletonClientGenerator) KubeClient() (kubernetes.Interface, error) {
 func (p *SingletonClientGenerator) GatewayClient() (gateway.Interface, error) {
 	var err error
 	p.gatewayOnce.Do(func() {
-		p.gatewayClient, err = newGatewayClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.gatewayClient, err = newGatewayClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout, p.QPS, p.Burst)
 	})
 	return p.gatewayClient, err
 }
 
-func newGatewayClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (gateway.Interface, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func newGatewayClient(kubeConfig, apiServerURL string, requestTimeout time.Duration, qps float32, burst int) (gateway.Interface, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout, qps, burst)
 	if err != nil {
 		return nil, err
 	}
@@ -240,7 +313,7 @@ func newGatewayClient(kubeConfig, apiServerURL string, requestTimeout time.Durat
 func (p *SingletonClientGenerator) IstioClient() (istioclient.Interface, error) {
 	var err error
 	p.istioOnce.Do(func() {
-		p.istioClient, err = NewIstioClient(p.KubeConfig, p.APIServerURL)
+		p.istioClient, err = NewIstioClient(p.KubeConfig, p.APIServerURL, p.QPS, p.Burst)
 	})
 	return p.istioClient, err
 }
@@ -273,7 +346,7 @@ func NewCFClient(cfAPIEndpoint string, cfUsername string, cfPassword string) (*c
 func (p *SingletonClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
 	var err error
 	p.dynCliOnce.Do(func() {
-		p.dynKubeClient, err = NewDynamicKubernetesClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.dynKubeClient, err = NewDynamicKubernetesClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout, p.QPS, p.Burst)
 	})
 	return p.dynKubeClient, err
 }
@@ -282,7 +355,7 @@ func (p *SingletonClientGenerator) DynamicKubernetesClient() (dynamic.Interface,
 func (p *SingletonClientGenerator) OpenShiftClient() (openshift.Interface, error) {
 	var err error
 	p.openshiftOnce.Do(func() {
-		p.openshiftClient, err = NewOpenShiftClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.openshiftClient, err = NewOpenShiftClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout, p.QPS, p.Burst)
 	})
 	return p.openshiftClient, err
 }
@@ -327,6 +400,7 @@ func ByNames(ctx context.Context, p ClientGenerator, names []string, cfg *Config
 // - "skipper-routegroup": Skipper RouteGroup resources
 // - "kong-tcpingress": Kong TCP Ingress resources
 // - "f5-*": F5 resources (virtualserver, transportserver)
+// - "cert-manager-tlsa": TLSA records for cert-manager certificates
 // - "fake": Fake source for testing
 // - "connector": Connector source for external systems
 //
@@ -382,6 +456,8 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		return buildF5VirtualServerSource(ctx, p, cfg)
 	case types.F5TransportServer:
 		return buildF5TransportServerSource(ctx, p, cfg)
+	case types.CertManagerTLSA:
+		return buildCertManagerTLSASource(ctx, p, cfg)
 	}
 	return nil, ErrSourceNotFound
 }
@@ -421,7 +497,7 @@ func buildNodeSource(ctx context.Context, p ClientGenerator, cfg *Config) (Sourc
 	if err != nil {
 		return nil, err
 	}
-	return NewNodeSource(ctx, client, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.LabelFilter, cfg.ExposeInternalIPv6, cfg.ExcludeUnschedulable, cfg.CombineFQDNAndAnnotation)
+	return NewNodeSource(cfg.sharedInformers(ctx, types.Node), client, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.LabelFilter, cfg.ExposeInternalIPv6, cfg.ExcludeUnschedulable, cfg.CombineFQDNAndAnnotation, cfg.NodeZoneDomain, cfg.informerOptions(types.Node)...)
 }
 
 // buildServiceSource creates a Service source for exposing Kubernetes services as DNS records.
@@ -431,7 +507,7 @@ func buildServiceSource(ctx context.Context, p ClientGenerator, cfg *Config) (So
 	if err != nil {
 		return nil, err
 	}
-	return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6)
+	return NewServiceSource(cfg.sharedInformers(ctx, types.Service), client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6, cfg.PreferTargetType, cfg.informerOptions(types.Service)...)
 }
 
 // buildIngressSource creates an Ingress source for exposing Kubernetes ingresses as DNS records.
@@ -441,7 +517,7 @@ func buildIngressSource(ctx context.Context, p ClientGenerator, cfg *Config) (So
 	if err != nil {
 		return nil, err
 	}
-	return NewIngressSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IgnoreIngressTLSSpec, cfg.IgnoreIngressRulesSpec, cfg.LabelFilter, cfg.IngressClassNames)
+	return NewIngressSource(cfg.sharedInformers(ctx, types.Ingress), client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IgnoreIngressTLSSpec, cfg.IgnoreIngressRulesSpec, cfg.LabelFilter, cfg.IngressClassNames, cfg.PreferTargetType, cfg.informerOptions(types.Ingress)...)
 }
 
 // buildPodSource creates a Pod source for exposing Kubernetes pods as DNS records.
@@ -451,7 +527,7 @@ func buildPodSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source
 	if err != nil {
 		return nil, err
 	}
-	return NewPodSource(ctx, client, cfg.Namespace, cfg.Compatibility, cfg.IgnoreNonHostNetworkPods, cfg.PodSourceDomain, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.AnnotationFilter, cfg.LabelFilter)
+	return NewPodSource(cfg.sharedInformers(ctx, types.Pod), client, cfg.Namespace, cfg.Compatibility, cfg.IgnoreNonHostNetworkPods, cfg.PodSourceDomain, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.AnnotationFilter, cfg.LabelFilter, cfg.informerOptions(types.Pod)...)
 }
 
 // buildIstioGatewaySource creates an Istio Gateway source for exposing Istio gateways as DNS records.
@@ -465,7 +541,7 @@ func buildIstioGatewaySource(ctx context.Context, p ClientGenerator, cfg *Config
 	if err != nil {
 		return nil, err
 	}
-	return NewIstioGatewaySource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+	return NewIstioGatewaySource(cfg.sharedInformers(ctx, types.IstioGateway), kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IstioEastWestDomain)
 }
 
 // buildIstioVirtualServiceSource creates an Istio VirtualService source for exposing virtual services as DNS records.
@@ -479,7 +555,7 @@ func buildIstioVirtualServiceSource(ctx context.Context, p ClientGenerator, cfg
 	if err != nil {
 		return nil, err
 	}
-	return NewIstioVirtualServiceSource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+	return NewIstioVirtualServiceSource(cfg.sharedInformers(ctx, types.IstioVirtualService), kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
 }
 
 // buildCloudFoundrySource creates a CloudFoundry source for exposing CF applications as DNS records.
@@ -555,7 +631,7 @@ func buildCRDSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source
 	if err != nil {
 		return nil, err
 	}
-	crdClient, scheme, err := NewCRDClientForAPIVersionKind(client, cfg.KubeConfig, cfg.APIServerURL, cfg.CRDSourceAPIVersion, cfg.CRDSourceKind)
+	crdClient, scheme, err := NewCRDClientForAPIVersionKind(client, cfg.KubeConfig, cfg.APIServerURL, cfg.CRDSourceAPIVersion, cfg.CRDSourceKind, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
 	if err != nil {
 		return nil, err
 	}
@@ -614,6 +690,16 @@ func buildF5TransportServerSource(ctx context.Context, p ClientGenerator, cfg *C
 	return NewF5TransportServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter)
 }
 
+// buildCertManagerTLSASource creates a source publishing TLSA records for the certificates issued by cert-manager.
+// Follows standard pattern: ctx, client, namespace, annotationFilter, labelFilter
+func buildCertManagerTLSASource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
+	client, err := p.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewCertManagerTLSASource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter)
+}
+
 // instrumentedRESTConfig creates a REST config with request instrumentation for monitoring.
 // Adds HTTP transport wrapper for Prometheus metrics collection and request timeout configuration.
 //
@@ -621,7 +707,9 @@ func buildF5TransportServerSource(ctx context.Context, p ClientGenerator, cfg *C
 // reducing cardinality of metric labels for better performance.
 //
 // Timeout: Applies the specified request timeout to prevent hanging requests.
-func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*rest.Config, error) {
+//
+// Rate Limiting: Applies the specified QPS and burst, see rateLimit.
+func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time.Duration, qps float32, burst int) (*rest.Config, error) {
 	config, err := GetRestConfig(kubeConfig, apiServerURL)
 	if err != nil {
 		return nil, err
@@ -632,9 +720,21 @@ func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time
 	}
 
 	config.Timeout = requestTimeout
+	rateLimit(config, qps, burst)
 	return config, nil
 }
 
+// rateLimit sets the maximum number of queries per second and the burst of a client to the Kubernetes API
+// server. Zero values leave the client-go defaults of 5 queries per second and a burst of 10.
+func rateLimit(config *rest.Config, qps float32, burst int) {
+	if qps > 0 {
+		config.QPS = qps
+	}
+	if burst > 0 {
+		config.Burst = burst
+	}
+}
+
 // GetRestConfig returns the REST client configuration for Kubernetes API access.
 // Supports both in-cluster and external cluster configurations.
 //
@@ -676,9 +776,9 @@ func GetRestConfig(kubeConfig, apiServerURL string) (*rest.Config, error) {
 // NewKubeClient returns a new Kubernetes client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewKubeClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*kubernetes.Clientset, error) {
+func NewKubeClient(kubeConfig, apiServerURL string, requestTimeout time.Duration, qps float32, burst int) (*kubernetes.Clientset, error) {
 	log.Infof("Instantiating new Kubernetes client")
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout, qps, burst)
 	if err != nil {
 		return nil, err
 	}
@@ -698,7 +798,7 @@ func NewKubeClient(kubeConfig, apiServerURL string, requestTimeout time.Duration
 // wrappers) to the client's config at this level. Furthermore, the Istio client
 // constructor does not expose the ability to override the Kubernetes API server endpoint,
 // so the apiServerURL config attribute has no effect.
-func NewIstioClient(kubeConfig string, apiServerURL string) (*istioclient.Clientset, error) {
+func NewIstioClient(kubeConfig string, apiServerURL string, qps float32, burst int) (*istioclient.Clientset, error) {
 	if kubeConfig == "" {
 		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
 			kubeConfig = clientcmd.RecommendedHomeFile
@@ -709,6 +809,7 @@ func NewIstioClient(kubeConfig string, apiServerURL string) (*istioclient.Client
 	if err != nil {
 		return nil, err
 	}
+	rateLimit(restCfg, qps, burst)
 
 	ic, err := istioclient.NewForConfig(restCfg)
 	if err != nil {
@@ -721,8 +822,8 @@ func NewIstioClient(kubeConfig string, apiServerURL string) (*istioclient.Client
 // NewDynamicKubernetesClient returns a new Dynamic Kubernetes client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewDynamicKubernetesClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (dynamic.Interface, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func NewDynamicKubernetesClient(kubeConfig, apiServerURL string, requestTimeout time.Duration, qps float32, burst int) (dynamic.Interface, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout, qps, burst)
 	if err != nil {
 		return nil, err
 	}
@@ -737,8 +838,8 @@ func NewDynamicKubernetesClient(kubeConfig, apiServerURL string, requestTimeout
 // NewOpenShiftClient returns a new Openshift client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewOpenShiftClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*openshift.Clientset, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func NewOpenShiftClient(kubeConfig, apiServerURL string, requestTimeout time.Duration, qps float32, burst int) (*openshift.Clientset, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout, qps, burst)
 	if err != nil {
 		return nil, err
 	}
@@ -20,10 +20,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/cloudfoundry-community/go-cfclient"
 	openshift "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	istioclient "istio.io/client-go/pkg/clientset/versioned"
 	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
@@ -34,6 +37,8 @@ import (
 	fakeDynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	fakeKube "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/external-dns/source/informers"
 	"sigs.k8s.io/external-dns/source/types"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 )
@@ -203,7 +208,7 @@ func (suite *ByNamesTestSuite) TestKubeClientFails() {
 	sourcesDependentOnKubeClient := []string{
 		types.Node, types.Service, types.Ingress, types.Pod, types.IstioGateway, types.IstioVirtualService,
 		types.AmbassadorHost, types.GlooProxy, types.TraefikProxy, types.CRD, types.KongTCPIngress,
-		types.F5VirtualServer, types.F5TransportServer,
+		types.F5VirtualServer, types.F5TransportServer, types.CertManagerTLSA,
 	}
 
 	for _, source := range sourcesDependentOnKubeClient {
@@ -279,3 +284,66 @@ func TestBuildWithConfig_InvalidSource(t *testing.T) {
 		t.Errorf("expected ErrSourceNotFound, got: %v", err)
 	}
 }
+
+func TestConfigInformerTransform(t *testing.T) {
+	cfg := &Config{InformerFullObjects: []string{types.Pod}}
+
+	assert.NotNil(t, cfg.informerTransform(types.Service))
+	assert.NotNil(t, cfg.informerTransform(types.GatewayHttpRoute))
+	assert.Nil(t, cfg.informerTransform(types.Pod))
+	assert.Len(t, cfg.informerOptions(types.Ingress), 1)
+
+	cfg.InformerResyncPeriod = 10 * time.Minute
+	assert.Len(t, cfg.informerOptions(types.Ingress), 2)
+}
+
+func TestConfigSharedInformers(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		fullObjects []string
+		expectLists map[string]int
+	}{
+		{
+			name:        "shared",
+			expectLists: map[string]int{"services": 1, "pods": 1, "nodes": 1, "endpointslices": 1},
+		},
+		{
+			name:        "pod source with full objects",
+			fullObjects: []string{types.Pod},
+			expectLists: map[string]int{"services": 1, "pods": 2, "nodes": 2, "endpointslices": 1},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fakeKube.NewClientset()
+			p := new(MockClientGenerator)
+			p.On("KubeClient").Return(client, nil)
+			cfg := &Config{
+				LabelFilter:         labels.Everything(),
+				InformerFullObjects: tt.fullObjects,
+				informerFactories:   informers.NewFactories(),
+			}
+
+			_, err := ByNames(context.Background(), p, []string{types.Service, types.Pod, types.Node}, cfg)
+			require.NoError(t, err)
+
+			lists := map[string]int{}
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "list" {
+					lists[action.GetResource().Resource]++
+				}
+			}
+			assert.Equal(t, tt.expectLists, lists)
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	config := &rest.Config{}
+	rateLimit(config, 0, 0)
+	assert.Zero(t, config.QPS)
+	assert.Zero(t, config.Burst)
+
+	rateLimit(config, 50, 100)
+	assert.InDelta(t, 50, config.QPS, 0)
+	assert.Equal(t, 100, config.Burst)
+}
@@ -43,4 +43,5 @@ const (
 	KongTCPIngress      Type = "kong-tcpingress"
 	F5VirtualServer     Type = "f5-virtualserver"
 	F5TransportServer   Type = "f5-transportserver"
+	CertManagerTLSA     Type = "cert-manager-tlsa"
 )
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Address family policies deciding which address records are published.
+const (
+	// AddressFamilyDualStack publishes both A and AAAA records
+	AddressFamilyDualStack = "dual-stack"
+	// AddressFamilyIPv6First publishes A records only for DNS names without AAAA records
+	AddressFamilyIPv6First = "ipv6-first"
+	// AddressFamilyIPv6Only publishes no A records
+	AddressFamilyIPv6Only = "ipv6-only"
+)
+
+// addressFamilySource is a Source that leaves out the A endpoints of its wrapped source according to an address family policy,
+// for IPv6-only environments where sources with addresses of both families would publish useless A records.
+type addressFamilySource struct {
+	source source.Source
+	policy string
+}
+
+// NewAddressFamilySource creates a new addressFamilySource wrapping the provided Source.
+func NewAddressFamilySource(source source.Source, policy string) source.Source {
+	return &addressFamilySource{source: source, policy: policy}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them without the A endpoints left out by the policy.
+func (s *addressFamilySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debugf("addressFamilySource: collecting endpoints and applying the %s policy", s.policy)
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.policy != AddressFamilyIPv6First && s.policy != AddressFamilyIPv6Only {
+		return endpoints, nil
+	}
+
+	type nameKey struct {
+		dnsName, setIdentifier string
+	}
+	key := func(ep *endpoint.Endpoint) nameKey {
+		return nameKey{strings.ToLower(strings.TrimSuffix(ep.DNSName, ".")), ep.SetIdentifier}
+	}
+	ipv6 := map[nameKey]bool{}
+	for _, ep := range endpoints {
+		if ep.RecordType == endpoint.RecordTypeAAAA {
+			ipv6[key(ep)] = true
+		}
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.RecordType == endpoint.RecordTypeA && (s.policy == AddressFamilyIPv6Only || ipv6[key(ep)]) {
+			log.WithField("endpoint", ep).Debugf("Skipping A endpoint because of the %s address family policy", s.policy)
+			plan.CountSkipped(plan.SkipReasonAddressFamily, ep)
+			continue
+		}
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+func (s *addressFamilySource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("addressFamilySource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
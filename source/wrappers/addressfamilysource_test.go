@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that addressFamilySource is a Source
+var _ source.Source = &addressFamilySource{}
+
+func TestAddressFamilySource(t *testing.T) {
+	dualStackA := endpoint.NewEndpoint("dual.example.org", endpoint.RecordTypeA, "192.0.2.1")
+	dualStackAAAA := endpoint.NewEndpoint("dual.example.org", endpoint.RecordTypeAAAA, "2001:db8::1")
+	ipv4Only := endpoint.NewEndpoint("legacy.example.org", endpoint.RecordTypeA, "192.0.2.2")
+	otherSet := endpoint.NewEndpoint("dual.example.org", endpoint.RecordTypeA, "192.0.2.3").WithSetIdentifier("blue")
+	cname := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "dual.example.org")
+
+	for _, tt := range []struct {
+		policy   string
+		expected []*endpoint.Endpoint
+	}{
+		{
+			policy:   AddressFamilyDualStack,
+			expected: []*endpoint.Endpoint{dualStackA, dualStackAAAA, ipv4Only, otherSet, cname},
+		},
+		{
+			policy:   AddressFamilyIPv6First,
+			expected: []*endpoint.Endpoint{dualStackAAAA, ipv4Only, otherSet, cname},
+		},
+		{
+			policy:   AddressFamilyIPv6Only,
+			expected: []*endpoint.Endpoint{dualStackAAAA, cname},
+		},
+	} {
+		t.Run(tt.policy, func(t *testing.T) {
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return([]*endpoint.Endpoint{dualStackA, dualStackAAAA, ipv4Only, otherSet, cname}, nil)
+
+			endpoints, err := NewAddressFamilySource(mockSource, tt.policy).Endpoints(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, endpoints)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/source"
+)
+
+var invalidAnnotations = metrics.NewGaugedVectorOpts(
+	prometheus.GaugeOpts{
+		Subsystem: "source",
+		Name:      "invalid_annotations",
+		Help:      "Number of invalid external-dns annotations of each resource read by the sources in the last synchronization, by resource as kind/namespace/name (vector).",
+	},
+	[]string{"resource"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(invalidAnnotations)
+}
+
+// annotationValidationSource is a Source that reports the resources read by its wrapped source whose external-dns
+// annotations are invalid.
+type annotationValidationSource struct {
+	source  source.Source
+	emitter events.EventEmitter
+
+	mu sync.Mutex
+	// reported holds the problems of each resource reported by the previous synchronization
+	reported map[string]string
+}
+
+// NewAnnotationValidationSource creates a new annotationValidationSource wrapping the provided Source. Resources
+// with invalid annotations are logged, counted by the source_invalid_annotations metric and, if an emitter is given,
+// reported by a warning event once their problems appear or change.
+func NewAnnotationValidationSource(source source.Source, emitter events.EventEmitter) source.Source {
+	return &annotationValidationSource{source: source, emitter: emitter, reported: map[string]string{}}
+}
+
+// Endpoints collects endpoints from its wrapped source, which reports the resources with invalid annotations it
+// reads, and returns them unchanged.
+func (as *annotationValidationSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	invalid := source.NewInvalidAnnotations()
+	endpoints, err := as.source.Endpoints(source.WithInvalidAnnotations(ctx, invalid))
+	if err != nil {
+		return nil, err
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	reported := map[string]string{}
+	invalidAnnotations.Gauge.Reset()
+	for _, r := range invalid.List() {
+		msg := r.Error()
+		reported[r.Resource] = msg
+		invalidAnnotations.SetWithLabels(float64(len(r.Errs)), r.Resource)
+		if as.reported[r.Resource] == msg {
+			continue
+		}
+		log.Warnf("Invalid annotations of %s: %s", r.Resource, msg)
+		if as.emitter != nil && r.Ref != nil {
+			as.emitter.Add(events.NewEvent(r.Ref, fmt.Sprintf("invalid annotations: %s", msg), events.ActionFailed, events.InvalidAnnotations))
+		}
+	}
+	as.reported = reported
+	return endpoints, nil
+}
+
+func (as *annotationValidationSource) AddEventHandler(ctx context.Context, handler func()) {
+	as.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestAnnotationValidationSource(t *testing.T) {
+	ctx := t.Context()
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			UID:       "uid",
+			Annotations: map[string]string{
+				annotations.HostnameKey: "web.example.com",
+				annotations.TtlKey:      "forever",
+				annotations.WeightKey:   "-1",
+			},
+		},
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{IP: "192.0.2.1"}},
+		}},
+	}
+	client := fake.NewClientset(svc)
+	svcSource, err := source.NewServiceSource(ctx, client, "", "", "", false, "", false, false, false, nil, false, labels.Everything(), false, false, false, "")
+	require.NoError(t, err)
+
+	emitter := &fakeEmitter{}
+	src := NewAnnotationValidationSource(svcSource, emitter)
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1, "the endpoints are returned unchanged")
+	assert.InDelta(t, 2, testutil.ToFloat64(invalidAnnotations.Gauge.WithLabelValues("service/default/web")), 0)
+	require.Len(t, emitter.events, 1)
+	assert.Equal(t, events.InvalidAnnotations, emitter.events[0].Reason())
+	assert.Equal(t, events.EventTypeWarning, emitter.events[0].EventType())
+
+	// unchanged problems are only reported once
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err)
+	assert.Len(t, emitter.events, 1)
+
+	svc.Annotations[annotations.WeightKey] = "10"
+	_, err = client.CoreV1().Services("default").Update(ctx, svc, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		_, err := src.Endpoints(ctx)
+		return err == nil && len(emitter.events) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	delete(svc.Annotations, annotations.TtlKey)
+	_, err = client.CoreV1().Services("default").Update(ctx, svc, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		_, err := src.Endpoints(ctx)
+		return err == nil && testutil.CollectAndCount(invalidAnnotations.Gauge) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, emitter.events, 2)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// resolver looks up the addresses of a hostname, as implemented by net.Resolver.
+type resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// apexCNAMESource is a Source that handles the CNAME records at the apex of zones, which DNS doesn't allow next
+// to the SOA and NS records there. The provider publishes them with its own alias, ANAME or flattening mechanism
+// if it has one, otherwise they are resolved and pinned as A and AAAA records.
+type apexCNAMESource struct {
+	source   source.Source
+	zones    []string
+	flatten  func(*endpoint.Endpoint) bool
+	resolve  bool
+	ttl      endpoint.TTL
+	resolver resolver
+}
+
+// NewApexCNAMESource creates a new apexCNAMESource wrapping the provided Source. The provider publishes CNAME records
+// at the apex of the given zones itself if flatten returns true for them, the others are resolved if resolve is set,
+// with a TTL of at most ttl unless it is 0.
+func NewApexCNAMESource(source source.Source, zones []string, flatten func(*endpoint.Endpoint) bool, resolve bool, ttl endpoint.TTL) source.Source {
+	normalized := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		if zone = strings.ToLower(strings.Trim(zone, ".")); zone != "" {
+			normalized = append(normalized, zone)
+		}
+	}
+	return &apexCNAMESource{source: source, zones: normalized, flatten: flatten, resolve: resolve, ttl: ttl, resolver: net.DefaultResolver}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them with the CNAME records at zone apexes
+// handed to the provider or replaced by the addresses of their targets.
+func (s *apexCNAMESource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debug("apexCNAMESource: collecting endpoints and handling CNAME records at zone apexes")
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeCNAME || !s.isApex(ep.DNSName) {
+			result = append(result, ep)
+			continue
+		}
+		if s.flatten != nil && s.flatten(ep) {
+			log.Debugf("CNAME record at zone apex %s is published by the provider", ep.DNSName)
+			result = append(result, ep)
+			continue
+		}
+		if !s.resolve {
+			log.Warnf("CNAME record at zone apex %s isn't supported by the provider and may be rejected", ep.DNSName)
+			result = append(result, ep)
+			continue
+		}
+		resolved, err := resolveCNAME(ctx, s.resolver, ep, s.ttl)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resolved...)
+	}
+	return result, nil
+}
+
+// resolveCNAME replaces a CNAME endpoint by A and AAAA endpoints with the addresses of its targets. Their TTL is
+// lowered to ttl unless it is 0, so that clients don't cache the addresses much longer than they are resolved.
+func resolveCNAME(ctx context.Context, r resolver, ep *endpoint.Endpoint, ttl endpoint.TTL) ([]*endpoint.Endpoint, error) {
+	var v4, v6 endpoint.Targets
+	for _, target := range ep.Targets {
+		addrs, err := r.LookupIPAddr(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("resolving the target %q of the CNAME record %s: %w", target, ep.DNSName, err)
+		}
+		for _, addr := range addrs {
+			if addr.IP.To4() != nil {
+				v4 = append(v4, addr.IP.String())
+			} else {
+				v6 = append(v6, addr.IP.String())
+			}
+		}
+	}
+
+	var resolved []*endpoint.Endpoint
+	for _, r := range []struct {
+		recordType string
+		targets    endpoint.Targets
+	}{{endpoint.RecordTypeA, v4}, {endpoint.RecordTypeAAAA, v6}} {
+		if len(r.targets) == 0 {
+			continue
+		}
+		pinned := ep.DeepCopy()
+		pinned.RecordType = r.recordType
+		pinned.Targets = endpoint.NewTargets(r.targets...)
+		pinned.Weights = nil
+		if ttl > 0 && (!pinned.RecordTTL.IsConfigured() || pinned.RecordTTL > ttl) {
+			pinned.RecordTTL = ttl
+		}
+		resolved = append(resolved, pinned)
+	}
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("the targets of the CNAME record %s have no addresses", ep.DNSName)
+	}
+	log.Debugf("Pinned the CNAME record %s to the addresses of %s", ep.DNSName, ep.Targets)
+	return resolved, nil
+}
+
+func (s *apexCNAMESource) isApex(dnsName string) bool {
+	return slices.Contains(s.zones, strings.ToLower(strings.TrimSuffix(dnsName, ".")))
+}
+
+func (s *apexCNAMESource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("apexCNAMESource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that apexCNAMESource is a Source
+var _ source.Source = &apexCNAMESource{}
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	var result []net.IPAddr
+	for _, addr := range addrs {
+		result = append(result, net.IPAddr{IP: net.ParseIP(addr)})
+	}
+	return result, nil
+}
+
+func TestApexCNAMESource(t *testing.T) {
+	resolver := fakeResolver{
+		"lb.example.net":     {"192.0.2.1", "2001:db8::1"},
+		"lb-v4.example.net":  {"192.0.2.2"},
+		"lb-aws.example.net": {"192.0.2.3"},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		endpoints []*endpoint.Endpoint
+		resolve   bool
+		ttl       endpoint.TTL
+		expected  []*endpoint.Endpoint
+		expectErr bool
+	}{
+		{
+			name: "records below the apex are unchanged",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+			},
+			resolve: true,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+			},
+		},
+		{
+			name: "apex CNAME is resolved",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("Example.com.", endpoint.RecordTypeCNAME, 300, "lb.example.net", "lb-v4.example.net").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+			},
+			resolve: true,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("Example.com.", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+				endpoint.NewEndpointWithTTL("Example.com.", endpoint.RecordTypeAAAA, 300, "2001:db8::1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+			},
+		},
+		{
+			name: "apex CNAME is resolved with a lower TTL",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeCNAME, 300, "lb-v4.example.net"),
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeCNAME, 30, "lb.example.net").WithSetIdentifier("short"),
+			},
+			resolve: true,
+			ttl:     60,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 60, "192.0.2.2"),
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 30, "192.0.2.1").WithSetIdentifier("short"),
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeAAAA, 30, "2001:db8::1").WithSetIdentifier("short"),
+			},
+		},
+		{
+			name: "apex CNAME is flattened by the provider",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "lb-aws.example.net"),
+			},
+			resolve: true,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "lb-aws.example.net"),
+			},
+		},
+		{
+			name: "apex CNAME is kept without resolution",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+			},
+		},
+		{
+			name: "unresolvable target",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "missing.example.net"),
+			},
+			resolve:   true,
+			expectErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return(tt.endpoints, nil)
+
+			flatten := func(ep *endpoint.Endpoint) bool {
+				return ep.Targets[0] == "lb-aws.example.net"
+			}
+			src := NewApexCNAMESource(mockSource, []string{"example.com."}, flatten, tt.resolve, tt.ttl)
+			src.(*apexCNAMESource).resolver = resolver
+
+			endpoints, err := src.Endpoints(context.Background())
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(tt.expected, endpoints), "expected %v, got %v", tt.expected, endpoints)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// coexistingCNAMESource is a Source that resolves the CNAME records sharing their DNS name with records of other
+// types, which DNS doesn't allow, to A and AAAA records, instead of letting the plan discard them.
+type coexistingCNAMESource struct {
+	source   source.Source
+	ttl      endpoint.TTL
+	resolver resolver
+}
+
+// NewCoexistingCNAMESource creates a new coexistingCNAMESource wrapping the provided Source. The resolved records
+// have a TTL of at most ttl unless it is 0.
+func NewCoexistingCNAMESource(source source.Source, ttl endpoint.TTL) source.Source {
+	return &coexistingCNAMESource{source: source, ttl: ttl, resolver: net.DefaultResolver}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them with the CNAME records sharing their DNS
+// name with records of other types replaced by the addresses of their targets.
+func (s *coexistingCNAMESource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debug("coexistingCNAMESource: collecting endpoints and resolving CNAME records next to other record types")
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ dnsName, setIdentifier string }
+	keyOf := func(ep *endpoint.Endpoint) key {
+		return key{strings.ToLower(strings.TrimSuffix(ep.DNSName, ".")), ep.SetIdentifier}
+	}
+	others := map[key]bool{}
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeCNAME {
+			others[keyOf(ep)] = true
+		}
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeCNAME || !others[keyOf(ep)] {
+			result = append(result, ep)
+			continue
+		}
+		resolved, err := resolveCNAME(ctx, s.resolver, ep, s.ttl)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resolved...)
+	}
+	return result, nil
+}
+
+func (s *coexistingCNAMESource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("coexistingCNAMESource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
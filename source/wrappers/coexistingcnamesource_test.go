@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that coexistingCNAMESource is a Source
+var _ source.Source = &coexistingCNAMESource{}
+
+func TestCoexistingCNAMESource(t *testing.T) {
+	resolver := fakeResolver{
+		"lb.example.net": {"192.0.2.1", "2001:db8::1"},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		endpoints []*endpoint.Endpoint
+		expected  []*endpoint.Endpoint
+		expectErr bool
+	}{
+		{
+			name: "lone CNAME is unchanged",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+				endpoint.NewEndpoint("mail.example.com", endpoint.RecordTypeMX, "10 mx.example.net"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net"),
+				endpoint.NewEndpoint("mail.example.com", endpoint.RecordTypeMX, "10 mx.example.net"),
+			},
+		},
+		{
+			name: "CNAME next to other record types is resolved",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.net"),
+				endpoint.NewEndpoint("WWW.example.com.", endpoint.RecordTypeTXT, "verification"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "192.0.2.1"),
+				endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeAAAA, 60, "2001:db8::1"),
+				endpoint.NewEndpoint("WWW.example.com.", endpoint.RecordTypeTXT, "verification"),
+			},
+		},
+		{
+			name: "records with other set identifiers don't coexist",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net").WithSetIdentifier("a"),
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.2").WithSetIdentifier("b"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net").WithSetIdentifier("a"),
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.2").WithSetIdentifier("b"),
+			},
+		},
+		{
+			name: "unresolvable target",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "missing.example.net"),
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.2"),
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return(tt.endpoints, nil)
+
+			src := NewCoexistingCNAMESource(mockSource, 60)
+			src.(*coexistingCNAMESource).resolver = resolver
+
+			endpoints, err := src.Endpoints(context.Background())
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(tt.expected, endpoints), "expected %v, got %v", tt.expected, endpoints)
+		})
+	}
+}
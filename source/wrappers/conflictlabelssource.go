@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// conflictLabelsSource is a Source that has its wrapped source label the endpoints with the creation time and
+// priority of their resources, for the conflict resolution strategies which compare them.
+type conflictLabelsSource struct {
+	source source.Source
+}
+
+// NewConflictLabelsSource creates a new conflictLabelsSource wrapping the provided Source.
+func NewConflictLabelsSource(source source.Source) source.Source {
+	return &conflictLabelsSource{source: source}
+}
+
+// Endpoints collects endpoints from its wrapped source, which labels them with the creation time and priority of
+// their resources.
+func (cs *conflictLabelsSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	return cs.source.Endpoints(source.WithConflictLabels(ctx))
+}
+
+func (cs *conflictLabelsSource) AddEventHandler(ctx context.Context, handler func()) {
+	cs.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestConflictLabelsSource(t *testing.T) {
+	ctx := t.Context()
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "web",
+			Namespace:         "default",
+			CreationTimestamp: metav1.Unix(1700000000, 0),
+			Annotations:       map[string]string{annotations.HostnameKey: "web.example.com", annotations.PriorityKey: "5"},
+		},
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{IP: "192.0.2.1"}},
+		}},
+	}
+	client := fake.NewClientset(svc)
+	svcSource, err := source.NewServiceSource(ctx, client, "", "", "", false, "", false, false, false, nil, false, labels.Everything(), false, false, false, "")
+	require.NoError(t, err)
+
+	endpoints, err := svcSource.Endpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.NotContains(t, endpoints[0].Labels, endpoint.ResourceCreatedLabelKey)
+	assert.NotContains(t, endpoints[0].Labels, endpoint.PriorityLabelKey)
+
+	endpoints, err = NewConflictLabelsSource(svcSource).Endpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "1700000000", endpoints[0].Labels[endpoint.ResourceCreatedLabelKey])
+	assert.Equal(t, "5", endpoints[0].Labels[endpoint.PriorityLabelKey])
+}
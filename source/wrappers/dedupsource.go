@@ -18,7 +18,6 @@ package wrappers
 
 import (
 	"context"
-	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -31,6 +30,14 @@ type dedupSource struct {
 	source source.Source
 }
 
+// dedupKey identifies duplicate endpoints.
+type dedupKey struct {
+	recordType    string
+	dnsName       string
+	setIdentifier string
+	targets       string
+}
+
 // NewDedupSource creates a new dedupSource wrapping the provided Source.
 func NewDedupSource(source source.Source) source.Source {
 	return &dedupSource{source: source}
@@ -39,31 +46,31 @@ func NewDedupSource(source source.Source) source.Source {
 // Endpoints collects endpoints from its wrapped source and returns them without duplicates.
 func (ms *dedupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	log.Debug("dedupSource: collecting endpoints and removing duplicates")
-	result := make([]*endpoint.Endpoint, 0)
-	collected := map[string]bool{}
-
 	endpoints, err := ms.source.Endpoints(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	collected := make(map[dedupKey]struct{}, len(endpoints))
+
 	for _, ep := range endpoints {
 		if ep == nil {
 			continue
 		}
 
 		if len(ep.Targets) > 1 {
-			ep.Targets = endpoint.NewTargets(ep.Targets...)
+			ep.Targets = ep.Targets.Normalize()
 		}
 
-		identifier := strings.Join([]string{ep.RecordType, ep.DNSName, ep.SetIdentifier, ep.Targets.String()}, "/")
+		identifier := dedupKey{recordType: ep.RecordType, dnsName: ep.DNSName, setIdentifier: ep.SetIdentifier, targets: ep.Targets.String()}
 
 		if _, ok := collected[identifier]; ok {
 			log.Debugf("Removing duplicate endpoint %s", ep)
 			continue
 		}
 
-		collected[identifier] = true
+		collected[identifier] = struct{}{}
 		result = append(result, ep)
 	}
 
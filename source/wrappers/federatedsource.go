@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// clustersSeparator joins the member clusters of the clusters label, as label values can't hold commas.
+const clustersSeparator = "+"
+
+// federatedSource is a Source that aggregates the endpoints propagated from the member clusters of a fleet.
+type federatedSource struct {
+	source source.Source
+}
+
+// NewFederatedSource creates a new federatedSource wrapping the provided Source.
+func NewFederatedSource(source source.Source) source.Source {
+	return &federatedSource{source: source}
+}
+
+// Endpoints collects endpoints from its wrapped source and merges those of the same record propagated from
+// distinct member clusters into a single endpoint holding the targets of all of them. The merged endpoint
+// records the contributing clusters in its clusters label, takes the lowest configured TTL and keeps the other
+// properties of the endpoint of the first cluster. CNAME records can't hold several targets and endpoints
+// without a member cluster aren't merged.
+func (s *federatedSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debug("federatedSource: collecting endpoints and merging those of the member clusters")
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	merged := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range endpoints {
+		cluster, ok := ep.Labels[endpoint.ClustersLabelKey]
+		if !ok || ep.RecordType == endpoint.RecordTypeCNAME {
+			result = append(result, ep)
+			continue
+		}
+		key := ep.Key()
+		existing, ok := merged[key]
+		if !ok {
+			merged[key] = ep
+			result = append(result, ep)
+			continue
+		}
+		log.Debugf("federatedSource: merging the targets of %s from cluster %s", ep.DNSName, cluster)
+		mergeClusterEndpoint(existing, ep)
+	}
+	return result, nil
+}
+
+// mergeClusterEndpoint adds the targets and clusters of the given endpoint to the merged one.
+func mergeClusterEndpoint(merged, ep *endpoint.Endpoint) {
+	merged.Targets = endpoint.NewTargets(slices.Concat(merged.Targets, ep.Targets)...)
+	if ep.IsWeighted() {
+		if merged.Weights == nil {
+			merged.Weights = map[string]int64{}
+		}
+		for target, weight := range ep.Weights {
+			if _, ok := merged.Weights[target]; !ok {
+				merged.Weights[target] = weight
+			}
+		}
+	}
+	if ep.RecordTTL.IsConfigured() && (!merged.RecordTTL.IsConfigured() || ep.RecordTTL < merged.RecordTTL) {
+		merged.RecordTTL = ep.RecordTTL
+	}
+
+	clusters := strings.Split(merged.Labels[endpoint.ClustersLabelKey], clustersSeparator)
+	for _, cluster := range strings.Split(ep.Labels[endpoint.ClustersLabelKey], clustersSeparator) {
+		if !slices.Contains(clusters, cluster) {
+			clusters = append(clusters, cluster)
+		}
+	}
+	slices.Sort(clusters)
+	merged.Labels[endpoint.ClustersLabelKey] = strings.Join(clusters, clustersSeparator)
+}
+
+func (s *federatedSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("federatedSource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that federatedSource is a Source
+var _ source.Source = &federatedSource{}
+
+func TestFederatedSourceEndpoints(t *testing.T) {
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 300, "192.0.2.2").
+			WithLabel(endpoint.ClustersLabelKey, "west").
+			WithLabel(endpoint.ResourceLabelKey, "crd/default/app-west"),
+		endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeA, 60, "192.0.2.1", "192.0.2.2").
+			WithLabel(endpoint.ClustersLabelKey, "east").
+			WithLabel(endpoint.ResourceLabelKey, "crd/default/app-east"),
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeAAAA, "2001:db8::1").
+			WithLabel(endpoint.ClustersLabelKey, "east"),
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "west.example.org").
+			WithLabel(endpoint.ClustersLabelKey, "west"),
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "east.example.org").
+			WithLabel(endpoint.ClustersLabelKey, "east"),
+		endpoint.NewEndpoint("local.example.org", endpoint.RecordTypeA, "192.0.2.10"),
+		endpoint.NewEndpoint("local.example.org", endpoint.RecordTypeA, "192.0.2.11"),
+	}, nil)
+
+	endpoints, err := NewFederatedSource(mockSource).Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 6)
+
+	merged := endpoints[0]
+	assert.Equal(t, endpoint.NewTargets("192.0.2.1", "192.0.2.2"), merged.Targets)
+	assert.Equal(t, endpoint.TTL(60), merged.RecordTTL)
+	assert.Equal(t, "east+west", merged.Labels[endpoint.ClustersLabelKey])
+	assert.Equal(t, "crd/default/app-west", merged.Labels[endpoint.ResourceLabelKey])
+
+	assert.Equal(t, endpoint.RecordTypeAAAA, endpoints[1].RecordType)
+	assert.Equal(t, "east", endpoints[1].Labels[endpoint.ClustersLabelKey])
+	assert.Equal(t, endpoint.NewTargets("west.example.org"), endpoints[2].Targets, "should not merge CNAME records")
+	assert.Equal(t, endpoint.NewTargets("east.example.org"), endpoints[3].Targets, "should not merge CNAME records")
+	assert.Equal(t, endpoint.NewTargets("192.0.2.10"), endpoints[4].Targets, "should not merge endpoints without a cluster")
+	assert.Equal(t, endpoint.NewTargets("192.0.2.11"), endpoints[5].Targets, "should not merge endpoints without a cluster")
+
+	mockSource.AssertExpectations(t)
+}
+
+func TestFederatedSourceEndpointsWeights(t *testing.T) {
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.1").
+			WithLabel(endpoint.ClustersLabelKey, "east"),
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "192.0.2.2").
+			WithLabel(endpoint.ClustersLabelKey, "west").
+			WithWeight(3),
+	}, nil)
+
+	endpoints, err := NewFederatedSource(mockSource).Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, int64(1), endpoints[0].TargetWeight("192.0.2.1"))
+	assert.Equal(t, int64(3), endpoints[0].TargetWeight("192.0.2.2"))
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// geoSource is a Source that sets the location of the cluster on endpoints without a geo location.
+type geoSource struct {
+	source source.Source
+	geo    endpoint.GeoLocation
+}
+
+// NewGeoSource creates a new geoSource wrapping the provided Source.
+func NewGeoSource(source source.Source, geo endpoint.GeoLocation) source.Source {
+	return &geoSource{source: source, geo: geo}
+}
+
+// Endpoints collects endpoints from its wrapped source and sets the cluster location
+// on those that don't have a geo location from their annotations.
+func (s *geoSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debug("geoSource: collecting endpoints and applying the cluster location")
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ep := range endpoints {
+		if ep.Geo == nil {
+			ep.WithGeo(s.geo)
+		}
+	}
+	return endpoints, nil
+}
+
+func (s *geoSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("geoSource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
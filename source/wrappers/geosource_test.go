@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that geoSource is a Source
+var _ source.Source = &geoSource{}
+
+func TestGeoSourceEndpoints(t *testing.T) {
+	clusterGeo := endpoint.GeoLocation{Country: "DE"}
+	annotatedGeo := endpoint.GeoLocation{Continent: "NA"}
+
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.5").WithGeo(annotatedGeo),
+	}, nil)
+
+	endpoints, err := NewGeoSource(mockSource, clusterGeo).Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, &clusterGeo, endpoints[0].Geo)
+	assert.Equal(t, &annotatedGeo, endpoints[1].Geo)
+
+	mockSource.AssertExpectations(t)
+}
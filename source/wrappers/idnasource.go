@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/idna"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// idnaSource is a Source that converts the DNS names of the endpoints of its wrapped source, as well as the targets
+// of their CNAME and NS records, to the lower case ASCII form published by the providers, with the labels of
+// internationalized names encoded in punycode. Hostnames given in Unicode or mixed case by annotations, FQDN
+// templates or route rules are thereby compared, deduplicated and published the same way whatever their source.
+type idnaSource struct {
+	source source.Source
+}
+
+// NewIDNASource creates a new idnaSource wrapping the provided Source.
+func NewIDNASource(source source.Source) source.Source {
+	return &idnaSource{source: source}
+}
+
+// Endpoints collects endpoints from its wrapped source and converts their DNS names and hostname targets.
+// Names which can't be converted are left as is.
+func (s *idnaSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debug("idnaSource: collecting endpoints and normalizing their DNS names")
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ep := range endpoints {
+		ep.DNSName = toASCII(ep.DNSName)
+		if ep.RecordType == endpoint.RecordTypeCNAME || ep.RecordType == endpoint.RecordTypeNS {
+			for i, target := range ep.Targets {
+				ep.Targets[i] = toASCII(target)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+func (s *idnaSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("idnaSource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
+
+func toASCII(name string) string {
+	converted, err := idna.ToASCII(name)
+	if err != nil {
+		log.Warnf("Failed to convert %q to its ASCII form, leaving it as is: %v", name, err)
+		return name
+	}
+	return converted
+}
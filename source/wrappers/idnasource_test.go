@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that idnaSource is a Source
+var _ source.Source = &idnaSource{}
+
+func TestIDNASourceEndpoints(t *testing.T) {
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("Bücher.Example.ORG", endpoint.RecordTypeA, "1.2.3.5"),
+		endpoint.NewEndpoint("*.Bücher.example.org", endpoint.RecordTypeCNAME, "Bücher.Example.org"),
+		endpoint.NewEndpoint("_sip._tcp.bücher.example.org", endpoint.RecordTypeSRV, "10 5 5060 Bücher.example.org"),
+		endpoint.NewEndpoint("xn--bcher-kva.example.org", endpoint.RecordTypeTXT, "Bücher"),
+	}, nil)
+
+	endpoints, err := NewIDNASource(mockSource).Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 5)
+	assert.Equal(t, "foo.example.org", endpoints[0].DNSName)
+	assert.Equal(t, "xn--bcher-kva.example.org", endpoints[1].DNSName)
+	assert.Equal(t, "*.xn--bcher-kva.example.org", endpoints[2].DNSName)
+	assert.Equal(t, endpoint.Targets{"xn--bcher-kva.example.org"}, endpoints[2].Targets)
+	assert.Equal(t, "_sip._tcp.xn--bcher-kva.example.org", endpoints[3].DNSName)
+	assert.Equal(t, endpoint.Targets{"10 5 5060 Bücher.example.org"}, endpoints[3].Targets, "only hostname targets are converted")
+	assert.Equal(t, endpoint.Targets{"Bücher"}, endpoints[4].Targets)
+
+	mockSource.AssertExpectations(t)
+}
@@ -18,10 +18,14 @@ package wrappers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/source"
@@ -32,20 +36,38 @@ type multiSource struct {
 	children            []source.Source
 	defaultTargets      []string
 	forceDefaultTargets bool
+	// parallelism is the number of nested Sources queried at once
+	parallelism int
+	// timeout bounds the time each nested Source takes to return its endpoints, unless zero
+	timeout time.Duration
 }
 
 // Endpoints collects endpoints of all nested Sources and returns them in a single slice.
+// Nested Sources are queried concurrently, up to parallelism at once, and their endpoints merged in order.
 func (ms *multiSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	log.Debugf("multiSource: collecting endpoints from %d child sources and removing duplicates", len(ms.children))
+	children := make([][]*endpoint.Endpoint, len(ms.children))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(ms.parallelism, 1))
+	for i, s := range ms.children {
+		g.Go(func() error {
+			endpoints, err := ms.childEndpoints(ctx, s)
+			if err != nil {
+				return err
+			}
+			children[i] = endpoints
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
 	result := []*endpoint.Endpoint{}
 	hasDefaultTargets := len(ms.defaultTargets) > 0
 
-	for _, s := range ms.children {
-		endpoints, err := s.Endpoints(ctx)
-		if err != nil {
-			return nil, err
-		}
-
+	for _, endpoints := range children {
 		if !hasDefaultTargets {
 			result = append(result, endpoints...)
 			continue
@@ -71,6 +93,38 @@ func (ms *multiSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 	return result, nil
 }
 
+// childEndpoints returns the endpoints of a nested Source, giving up once the timeout has elapsed.
+// Sources reading from informer caches don't watch the context, so they are waited for in a separate goroutine
+// which is left to finish on its own when the timeout elapses.
+func (ms *multiSource) childEndpoints(ctx context.Context, s source.Source) ([]*endpoint.Endpoint, error) {
+	if ms.timeout <= 0 {
+		return s.Endpoints(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ms.timeout)
+	defer cancel()
+
+	type result struct {
+		endpoints []*endpoint.Endpoint
+		err       error
+	}
+	done := make(chan result, 1)
+	go func() {
+		endpoints, err := s.Endpoints(ctx)
+		done <- result{endpoints, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.endpoints, r.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("source %s did not return its endpoints within %s: %w", reflect.TypeOf(s).String(), ms.timeout, ctx.Err())
+		}
+		return nil, ctx.Err()
+	}
+}
+
 func (ms *multiSource) AddEventHandler(ctx context.Context, handler func()) {
 	log.Debugf("multiSource: adding event handler for %d child sources", len(ms.children))
 	for _, s := range ms.children {
@@ -79,7 +133,8 @@ func (ms *multiSource) AddEventHandler(ctx context.Context, handler func()) {
 	}
 }
 
-// NewMultiSource creates a new multiSource.
-func NewMultiSource(children []source.Source, defaultTargets []string, forceDefaultTargets bool) source.Source {
-	return &multiSource{children: children, defaultTargets: defaultTargets, forceDefaultTargets: forceDefaultTargets}
+// NewMultiSource creates a new multiSource querying up to parallelism children at once, each within timeout
+// unless it is zero.
+func NewMultiSource(children []source.Source, defaultTargets []string, forceDefaultTargets bool, parallelism int, timeout time.Duration) source.Source {
+	return &multiSource{children: children, defaultTargets: defaultTargets, forceDefaultTargets: forceDefaultTargets, parallelism: parallelism, timeout: timeout}
 }
@@ -19,7 +19,9 @@ package wrappers
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +38,8 @@ func TestMultiSource(t *testing.T) {
 	t.Run("Endpoints", testMultiSourceEndpoints)
 	t.Run("EndpointsWithError", testMultiSourceEndpointsWithError)
 	t.Run("EndpointsDefaultTargets", testMultiSourceEndpointsDefaultTargets)
+	t.Run("EndpointsParallelism", testMultiSourceEndpointsParallelism)
+	t.Run("EndpointsTimeout", testMultiSourceEndpointsTimeout)
 }
 
 // testMultiSourceImplementsSource tests that multiSource is a valid Source.
@@ -90,7 +94,7 @@ func testMultiSourceEndpoints(t *testing.T) {
 			}
 
 			// Create our object under test and get the endpoints.
-			source := NewMultiSource(sources, nil, false)
+			source := NewMultiSource(sources, nil, false, 1, 0)
 
 			// Get endpoints from the source.
 			endpoints, err := source.Endpoints(context.Background())
@@ -117,7 +121,7 @@ func testMultiSourceEndpointsWithError(t *testing.T) {
 	src.On("Endpoints").Return(nil, errSomeError)
 
 	// Create our object under test and get the endpoints.
-	source := NewMultiSource([]source.Source{src}, nil, false)
+	source := NewMultiSource([]source.Source{src}, nil, false, 1, 0)
 
 	// Get endpoints from our source.
 	_, err := source.Endpoints(context.Background())
@@ -156,7 +160,7 @@ func testMultiSourceEndpointsDefaultTargets(t *testing.T) {
 		src.On("Endpoints").Return(sourceEndpoints, nil)
 
 		// Test with forceDefaultTargets=false (default behavior)
-		source := NewMultiSource([]source.Source{src}, defaultTargets, false)
+		source := NewMultiSource([]source.Source{src}, defaultTargets, false, 1, 0)
 
 		endpoints, err := source.Endpoints(context.Background())
 		require.NoError(t, err)
@@ -186,7 +190,7 @@ func testMultiSourceEndpointsDefaultTargets(t *testing.T) {
 		src.On("Endpoints").Return(sourceEndpoints, nil)
 
 		// Test with forceDefaultTargets=false (default behavior)
-		source := NewMultiSource([]source.Source{src}, defaultTargets, false)
+		source := NewMultiSource([]source.Source{src}, defaultTargets, false, 1, 0)
 
 		endpoints, err := source.Endpoints(context.Background())
 		require.NoError(t, err)
@@ -224,7 +228,7 @@ func testMultiSourceEndpointsDefaultTargets(t *testing.T) {
 		src.On("Endpoints").Return(sourceEndpoints, nil)
 
 		// Test with forceDefaultTargets=true (legacy behavior)
-		source := NewMultiSource([]source.Source{src}, defaultTargets, true)
+		source := NewMultiSource([]source.Source{src}, defaultTargets, true, 1, 0)
 
 		endpoints, err := source.Endpoints(context.Background())
 		require.NoError(t, err)
@@ -259,7 +263,7 @@ func testMultiSourceEndpointsDefaultTargets(t *testing.T) {
 		src.On("Endpoints").Return(sourceEndpoints, nil)
 
 		// Test with forceDefaultTargets=true
-		source := NewMultiSource([]source.Source{src}, defaultTargets, true)
+		source := NewMultiSource([]source.Source{src}, defaultTargets, true, 1, 0)
 
 		endpoints, err := source.Endpoints(context.Background())
 		require.NoError(t, err)
@@ -294,7 +298,7 @@ func TestMultiSource_AddEventHandler(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.title, func(t *testing.T) {
-			src := NewMultiSource(tt.sources, []string{}, true)
+			src := NewMultiSource(tt.sources, []string{}, true, 1, 0)
 			src.AddEventHandler(t.Context(), func() {})
 
 			count := 0
@@ -309,3 +313,73 @@ func TestMultiSource_AddEventHandler(t *testing.T) {
 		})
 	}
 }
+
+// blockingSource returns its endpoints once released, ignoring the context like sources reading from informer caches.
+type blockingSource struct {
+	endpoints []*endpoint.Endpoint
+	started   chan struct{}
+	release   chan struct{}
+}
+
+func (s *blockingSource) Endpoints(context.Context) ([]*endpoint.Endpoint, error) {
+	s.started <- struct{}{}
+	<-s.release
+	return s.endpoints, nil
+}
+
+func (s *blockingSource) AddEventHandler(context.Context, func()) {}
+
+// testMultiSourceEndpointsParallelism tests that children are queried concurrently and their endpoints merged in order.
+func testMultiSourceEndpointsParallelism(t *testing.T) {
+	foo := &endpoint.Endpoint{DNSName: "foo", Targets: endpoint.Targets{"8.8.8.8"}}
+	bar := &endpoint.Endpoint{DNSName: "bar", Targets: endpoint.Targets{"8.8.4.4"}}
+	baz := &endpoint.Endpoint{DNSName: "baz", Targets: endpoint.Targets{"1.1.1.1"}}
+
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	children := []source.Source{
+		&blockingSource{endpoints: []*endpoint.Endpoint{foo}, started: started, release: release},
+		&blockingSource{endpoints: []*endpoint.Endpoint{bar}, started: started, release: release},
+		&blockingSource{endpoints: []*endpoint.Endpoint{baz}, started: started, release: release},
+	}
+	src := NewMultiSource(children, nil, false, 2, 0)
+
+	var endpoints []*endpoint.Endpoint
+	var err error
+	var finished atomic.Bool
+	go func() {
+		endpoints, err = src.Endpoints(context.Background())
+		finished.Store(true)
+		close(started)
+	}()
+
+	// Two children are queried at once, the third only once one of them returned.
+	<-started
+	<-started
+	select {
+	case <-started:
+		t.Fatal("more children queried at once than allowed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	for range started {
+	}
+
+	require.True(t, finished.Load())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{foo, bar, baz})
+}
+
+// testMultiSourceEndpointsTimeout tests that a child not returning its endpoints in time fails the collection.
+func testMultiSourceEndpointsTimeout(t *testing.T) {
+	fast := new(testutils.MockSource)
+	fast.On("Endpoints").Return([]*endpoint.Endpoint{{DNSName: "foo", Targets: endpoint.Targets{"8.8.8.8"}}}, nil)
+	slow := &blockingSource{started: make(chan struct{}, 1), release: make(chan struct{})}
+	defer close(slow.release)
+
+	src := NewMultiSource([]source.Source{fast, slow}, nil, false, 2, 20*time.Millisecond)
+
+	_, err := src.Endpoints(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "source *wrappers.blockingSource did not return its endpoints within 20ms")
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// DefaultNamespaceDomainsKey is the key of the namespace domains ConfigMap whose DNS suffixes apply to the
+// namespaces without a key of their own.
+const DefaultNamespaceDomainsKey = "*"
+
+// namespaceDomainsSource is a Source that removes the endpoints whose DNS name the namespace of their resource
+// may not request, as given by a ConfigMap mapping namespaces to DNS suffixes.
+type namespaceDomainsSource struct {
+	source    source.Source
+	configMap corev1listers.ConfigMapNamespaceLister
+	informer  cache.SharedIndexInformer
+	name      string
+	emitter   events.EventEmitter
+}
+
+// NewNamespaceDomainsSource creates a new namespaceDomainsSource wrapping the provided Source, reading the
+// allowed domains from the given ConfigMap, which is watched for changes.
+func NewNamespaceDomainsSource(ctx context.Context, source source.Source, client kubernetes.Interface, namespace, name string, emitter events.EventEmitter) (source.Source, error) {
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(client, 0,
+		kubeinformers.WithNamespace(namespace),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector(metav1.ObjectNameField, name).String()
+		}))
+	configMapInformer := informerFactory.Core().V1().ConfigMaps()
+	_, _ = configMapInformer.Informer().AddEventHandler(informers.DefaultEventHandler())
+
+	informerFactory.Start(ctx.Done())
+	if err := informers.WaitForCacheSync(ctx, informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &namespaceDomainsSource{
+		source:    source,
+		configMap: configMapInformer.Lister().ConfigMaps(namespace),
+		informer:  configMapInformer.Informer(),
+		name:      name,
+		emitter:   emitter,
+	}, nil
+}
+
+// Endpoints collects endpoints from its wrapped source and returns those whose DNS name is within the domains
+// allowed for the namespace of their resource. Endpoints of cluster-scoped resources, or without a resource,
+// are kept. A missing ConfigMap fails the synchronization rather than allowing or denying every DNS name.
+func (ns *namespaceDomainsSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debug("namespaceDomainsSource: collecting endpoints from wrapped source and applying namespace domains")
+	cm, err := ns.configMap.Get(ns.name)
+	if err != nil {
+		return nil, fmt.Errorf("reading namespace domains: %w", err)
+	}
+	domains := parseNamespaceDomains(cm)
+
+	endpoints, err := ns.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		namespace := endpointNamespace(ep)
+		if namespace == "" {
+			result = append(result, ep)
+			continue
+		}
+		allowed, ok := domains[namespace]
+		if !ok {
+			allowed = domains[DefaultNamespaceDomainsKey]
+		}
+		if domainAllowed(ep.DNSName, allowed) {
+			result = append(result, ep)
+			continue
+		}
+
+		log.WithField("endpoint", ep).Warnf("Skipping endpoint because namespace %s may not request %s", namespace, ep.DNSName)
+		plan.CountSkipped(plan.SkipReasonNamespaceDomains, ep)
+		if ns.emitter != nil && ep.RefObject() != nil {
+			msg := fmt.Sprintf("%s: namespace %s may not request %s", ep.Describe(), namespace, ep.DNSName)
+			ns.emitter.Add(events.NewEvent(ep.RefObject(), msg, events.ActionFailed, events.RejectedDNSRecord))
+		}
+	}
+	return result, nil
+}
+
+func (ns *namespaceDomainsSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("namespaceDomainsSource: adding event handler")
+	// changes of the allowed domains change the endpoints
+	_, _ = ns.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { handler() },
+		UpdateFunc: func(any, any) { handler() },
+		DeleteFunc: func(any) { handler() },
+	})
+	ns.source.AddEventHandler(ctx, handler)
+}
+
+// parseNamespaceDomains returns the DNS suffixes of each namespace of the ConfigMap, whose keys are namespaces
+// and values lists of DNS suffixes separated by commas or whitespace.
+func parseNamespaceDomains(cm *corev1.ConfigMap) map[string][]string {
+	domains := make(map[string][]string, len(cm.Data))
+	for namespace, value := range cm.Data {
+		for _, domain := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' || r == '\t' }) {
+			domains[namespace] = append(domains[namespace], strings.ToLower(strings.Trim(domain, ".")))
+		}
+	}
+	return domains
+}
+
+// domainAllowed returns whether the DNS name is one of the domains or a sub-domain of one of them.
+func domainAllowed(dnsName string, domains []string) bool {
+	dnsName = strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	return slices.ContainsFunc(domains, func(domain string) bool {
+		return domain != "" && (dnsName == domain || strings.HasSuffix(dnsName, "."+domain))
+	})
+}
+
+// endpointNamespace returns the namespace of the resource of the endpoint, from its resource label in
+// kind/namespace/name format or its reference object, or an empty string for cluster-scoped resources.
+func endpointNamespace(ep *endpoint.Endpoint) string {
+	if parts := strings.Split(ep.Labels[endpoint.ResourceLabelKey], "/"); len(parts) == 3 {
+		return parts[1]
+	}
+	if ref := ep.RefObject(); ref != nil {
+		return ref.Namespace
+	}
+	return ""
+}
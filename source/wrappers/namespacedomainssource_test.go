@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that namespaceDomainsSource is a Source
+var _ source.Source = &namespaceDomainsSource{}
+
+func TestNamespaceDomainsSource(t *testing.T) {
+	ctx := t.Context()
+	client := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "namespace-domains"},
+		Data: map[string]string{
+			"team-a": "a.example.com, shared.example.com.",
+			"team-b": "b.example.com\nB.example.org",
+			"*":      "apps.example.com",
+		},
+	})
+	mockSource := testutils.NewMockSource(
+		endpoint.NewEndpoint("www.a.example.com", endpoint.RecordTypeA, "192.0.2.1").WithLabel(endpoint.ResourceLabelKey, "service/team-a/www"),
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.1").WithLabel(endpoint.ResourceLabelKey, "ingress/team-a/apex"),
+		endpoint.NewEndpoint("www.b.example.com", endpoint.RecordTypeA, "192.0.2.2").WithLabel(endpoint.ResourceLabelKey, "service/team-a/steal").
+			WithRefObject(&events.ObjectReference{Kind: "Service", Namespace: "team-a", Name: "steal"}),
+		endpoint.NewEndpoint("api.b.example.org", endpoint.RecordTypeCNAME, "lb.example.net").WithLabel(endpoint.ResourceLabelKey, "ingress/team-b/api"),
+		endpoint.NewEndpoint("notshared.example.com", endpoint.RecordTypeA, "192.0.2.3").WithLabel(endpoint.ResourceLabelKey, "service/team-a/other"),
+		endpoint.NewEndpoint("web.apps.example.com", endpoint.RecordTypeA, "192.0.2.4").WithLabel(endpoint.ResourceLabelKey, "service/team-c/web"),
+		endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "192.0.2.4").WithRefObject(&events.ObjectReference{Kind: "Service", Namespace: "team-c", Name: "web"}),
+		endpoint.NewEndpoint("node1.example.net", endpoint.RecordTypeA, "192.0.2.5").WithLabel(endpoint.ResourceLabelKey, "node/node1"),
+		endpoint.NewEndpoint("static.example.net", endpoint.RecordTypeA, "192.0.2.6"),
+	)
+	emitter := &fakeEmitter{}
+	src, err := NewNamespaceDomainsSource(ctx, mockSource, client, "external-dns", "namespace-domains", emitter)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err)
+	var names []string
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName)
+	}
+	assert.Equal(t, []string{"www.a.example.com", "a.example.com", "api.b.example.org", "web.apps.example.com", "node1.example.net", "static.example.net"}, names)
+
+	// only the rejected endpoints with a resource get an event
+	require.Len(t, emitter.events, 2)
+	assert.Equal(t, events.RejectedDNSRecord, emitter.events[0].Reason())
+	assert.Equal(t, events.EventTypeWarning, emitter.events[0].EventType())
+}
+
+func TestNamespaceDomainsSourceDeniesUnlistedNamespaces(t *testing.T) {
+	ctx := t.Context()
+	client := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "namespace-domains"},
+		Data:       map[string]string{"team-a": "a.example.com"},
+	})
+	mockSource := testutils.NewMockSource(
+		endpoint.NewEndpoint("www.a.example.com", endpoint.RecordTypeA, "192.0.2.1").WithLabel(endpoint.ResourceLabelKey, "service/team-b/www"),
+	)
+	src, err := NewNamespaceDomainsSource(ctx, mockSource, client, "external-dns", "namespace-domains", nil)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+}
+
+func TestNamespaceDomainsSourceMissingConfigMap(t *testing.T) {
+	ctx := t.Context()
+	mockSource := testutils.NewMockSource(endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1"))
+	src, err := NewNamespaceDomainsSource(ctx, mockSource, fake.NewClientset(), "external-dns", "namespace-domains", nil)
+	require.NoError(t, err)
+
+	_, err = src.Endpoints(ctx)
+	require.Error(t, err)
+}
+
+func TestNamespaceDomainsSourceAddEventHandler(t *testing.T) {
+	ctx := t.Context()
+	client := fake.NewClientset()
+	src, err := NewNamespaceDomainsSource(ctx, testutils.NewMockSource(), client, "external-dns", "namespace-domains", nil)
+	require.NoError(t, err)
+
+	triggered := make(chan struct{}, 1)
+	src.AddEventHandler(ctx, func() {
+		select {
+		case triggered <- struct{}{}:
+		default:
+		}
+	})
+
+	_, err = client.CoreV1().ConfigMaps("external-dns").Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "namespace-domains"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		select {
+		case <-triggered:
+			return true
+		default:
+			return false
+		}
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	"golang.org/x/sync/errgroup"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Protocols used to probe targets.
+const (
+	ProbeTCP  = "tcp"
+	ProbeICMP = "icmp"
+)
+
+// probeConcurrency is the number of targets probed at a time.
+const probeConcurrency = 32
+
+var unreachableTargetsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Subsystem: "source",
+		Name:      "unreachable_targets_total",
+		Help:      "Number of unreachable targets dropped from the endpoints of the sources by each synchronization, by kind of source resource (vector).",
+	},
+	[]string{"source"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(unreachableTargetsTotal)
+}
+
+// Prober checks whether an IP address is reachable.
+type Prober interface {
+	Probe(ctx context.Context, ip string) error
+}
+
+// NewProber returns the Prober for the given protocol.
+func NewProber(protocol string, port int, timeout time.Duration) (Prober, error) {
+	switch protocol {
+	case ProbeTCP:
+		return NewTCPProber(port, timeout), nil
+	case ProbeICMP:
+		return NewICMPProber(timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown target probe %q", protocol)
+	}
+}
+
+// tcpProber connects to a port of the address.
+type tcpProber struct {
+	port    string
+	timeout time.Duration
+}
+
+// NewTCPProber returns a Prober which connects to the given port of the address within the timeout.
+func NewTCPProber(port int, timeout time.Duration) Prober {
+	return &tcpProber{port: strconv.Itoa(port), timeout: timeout}
+}
+
+func (p *tcpProber) Probe(ctx context.Context, ip string) error {
+	dialer := net.Dialer{Timeout: p.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip, p.port))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// icmpProber sends an echo request to the address.
+type icmpProber struct {
+	timeout time.Duration
+	seq     int
+	mu      sync.Mutex
+}
+
+// NewICMPProber returns a Prober which expects an echo reply from the address within the timeout. It uses
+// unprivileged ICMP sockets, which must be allowed by the net.ipv4.ping_group_range sysctl.
+func NewICMPProber(timeout time.Duration) Prober {
+	return &icmpProber{timeout: timeout}
+}
+
+func (p *icmpProber) Probe(ctx context.Context, ip string) error {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return fmt.Errorf("invalid IP address %q", ip)
+	}
+	network, address, echo, reply, proto := "udp4", "0.0.0.0", icmp.Type(ipv4.ICMPTypeEcho), icmp.Type(ipv4.ICMPTypeEchoReply), 1
+	if addr.To4() == nil {
+		network, address, echo, reply, proto = "udp6", "::", ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply, 58
+	}
+	conn, err := icmp.ListenPacket(network, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(p.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.seq++
+	seq := p.seq & 0xffff
+	p.mu.Unlock()
+	msg, err := (&icmp.Message{
+		Type: echo,
+		Body: &icmp.Echo{ID: os.Getpid() & 0xffff, Seq: seq, Data: []byte("external-dns")},
+	}).Marshal(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.WriteTo(msg, &net.UDPAddr{IP: addr}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		received, err := icmp.ParseMessage(proto, buf[:n])
+		if err != nil {
+			continue
+		}
+		// the kernel sets the ID of unprivileged sockets, so only the sequence number identifies the reply
+		if body, ok := received.Body.(*icmp.Echo); ok && received.Type == reply && body.Seq == seq {
+			return nil
+		}
+	}
+}
+
+// reachabilitySource is a Source that drops the unreachable targets of the A and AAAA endpoints with several
+// targets of its wrapped source.
+type reachabilitySource struct {
+	source  source.Source
+	prober  Prober
+	emitter events.EventEmitter
+}
+
+// NewReachabilitySource creates a new reachabilitySource wrapping the provided Source. Unreachable targets are
+// reported by a warning event on the resource of their endpoint, if an emitter is given.
+func NewReachabilitySource(source source.Source, prober Prober, emitter events.EventEmitter) source.Source {
+	return &reachabilitySource{source: source, prober: prober, emitter: emitter}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them without their unreachable targets.
+// Endpoints with a single target, or none reachable, are returned as they are: these targets may be reachable
+// by the clients even though they aren't by ExternalDNS, and an unreachable target is better than no record.
+func (rs *reachabilitySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debug("reachabilitySource: collecting endpoints from wrapped source and probing their targets")
+	endpoints, err := rs.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	probed := map[string]error{}
+	for _, ep := range endpoints {
+		if probeable(ep) {
+			for _, target := range ep.Targets {
+				probed[target] = nil
+			}
+		}
+	}
+	if len(probed) == 0 {
+		return endpoints, nil
+	}
+	rs.probe(ctx, probed)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	for _, ep := range endpoints {
+		if !probeable(ep) {
+			continue
+		}
+		var alive, dead []string
+		for _, target := range ep.Targets {
+			if probed[target] != nil {
+				dead = append(dead, target)
+			} else {
+				alive = append(alive, target)
+			}
+		}
+		if len(dead) == 0 {
+			continue
+		}
+		if len(alive) == 0 {
+			log.Warnf("All the targets of %s are unreachable, publishing them anyway", ep.DNSName)
+			continue
+		}
+		log.Warnf("Dropping unreachable targets %s of %s: %v", strings.Join(dead, ","), ep.DNSName, probed[dead[0]])
+		kind, _, _ := strings.Cut(ep.Labels[endpoint.ResourceLabelKey], "/")
+		if kind == "" {
+			kind = "unknown"
+		}
+		unreachableTargetsTotal.CounterVec.WithLabelValues(kind).Add(float64(len(dead)))
+		if rs.emitter != nil && ep.RefObject() != nil {
+			msg := fmt.Sprintf("%s: dropped unreachable targets %s", ep.Describe(), strings.Join(dead, ","))
+			rs.emitter.Add(events.NewEvent(ep.RefObject(), msg, events.ActionUpdate, events.UnreachableTargets))
+		}
+		ep.Targets = alive
+		for _, target := range dead {
+			delete(ep.Weights, target)
+		}
+	}
+	return endpoints, nil
+}
+
+// probe probes the given targets concurrently, storing the error of each unreachable one.
+func (rs *reachabilitySource) probe(ctx context.Context, targets map[string]error) {
+	var mu sync.Mutex
+	var g errgroup.Group
+	g.SetLimit(probeConcurrency)
+	// the targets are collected first, as the map is written while they're probed
+	for _, target := range slices.Collect(maps.Keys(targets)) {
+		g.Go(func() error {
+			err := rs.prober.Probe(ctx, target)
+			mu.Lock()
+			targets[target] = err
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
+
+// probeable returns whether the targets of the endpoint are probed.
+func probeable(ep *endpoint.Endpoint) bool {
+	return (ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA) && len(ep.Targets) > 1
+}
+
+func (rs *reachabilitySource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("reachabilitySource: adding event handler")
+	rs.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that reachabilitySource is a Source
+var _ source.Source = &reachabilitySource{}
+
+// fakeProber fails to reach the addresses it holds.
+type fakeProber map[string]bool
+
+func (p fakeProber) Probe(_ context.Context, ip string) error {
+	if p[ip] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+type fakeEmitter struct {
+	events []events.Event
+}
+
+func (e *fakeEmitter) Add(evs ...events.Event) {
+	e.events = append(e.events, evs...)
+}
+
+func TestReachabilitySource(t *testing.T) {
+	ref := &events.ObjectReference{Kind: "Service", Namespace: "default", Name: "nodes"}
+	mockSource := testutils.NewMockSource(
+		endpoint.NewEndpoint("nodes.example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2", "192.0.2.3").WithRefObject(ref),
+		endpoint.NewEndpoint("all-dead.example.com", endpoint.RecordTypeA, "192.0.2.2", "192.0.2.3"),
+		endpoint.NewEndpoint("single.example.com", endpoint.RecordTypeA, "192.0.2.2"),
+		endpoint.NewEndpoint("v6.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "nodes.example.com"),
+	)
+	emitter := &fakeEmitter{}
+	src := NewReachabilitySource(mockSource, fakeProber{"192.0.2.2": true, "192.0.2.3": true, "2001:db8::2": true}, emitter)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 5)
+	assert.Equal(t, endpoint.Targets{"192.0.2.1"}, endpoints[0].Targets)
+	assert.Equal(t, endpoint.Targets{"192.0.2.2", "192.0.2.3"}, endpoints[1].Targets)
+	assert.Equal(t, endpoint.Targets{"192.0.2.2"}, endpoints[2].Targets)
+	assert.Equal(t, endpoint.Targets{"2001:db8::1"}, endpoints[3].Targets)
+	assert.Equal(t, endpoint.Targets{"nodes.example.com"}, endpoints[4].Targets)
+
+	// the endpoint without a resource gets no event
+	require.Len(t, emitter.events, 1)
+	assert.Equal(t, events.UnreachableTargets, emitter.events[0].Reason())
+	assert.Equal(t, events.EventTypeWarning, emitter.events[0].EventType())
+}
+
+func TestTCPProber(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	prober := NewTCPProber(port, time.Second)
+	require.NoError(t, prober.Probe(context.Background(), "127.0.0.1"))
+
+	require.NoError(t, listener.Close())
+	assert.Error(t, prober.Probe(context.Background(), "127.0.0.1"))
+}
+
+func TestNewProber(t *testing.T) {
+	for _, protocol := range []string{ProbeTCP, ProbeICMP} {
+		prober, err := NewProber(protocol, 443, time.Second)
+		require.NoError(t, err, protocol)
+		assert.NotNil(t, prober)
+	}
+	_, err := NewProber("udp", 443, time.Second)
+	require.Error(t, err)
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// resourceLabelsSource is a Source that has its wrapped source copy the given labels of the resources it reads to
+// the labels of their endpoints, which the registry persists along with the ownership of the records.
+type resourceLabelsSource struct {
+	source source.Source
+	keys   []string
+}
+
+// NewResourceLabelsSource creates a new resourceLabelsSource wrapping the provided Source, copying the labels with
+// the given keys under endpoint.ResourceLabelPrefix.
+func NewResourceLabelsSource(source source.Source, keys []string) source.Source {
+	return &resourceLabelsSource{source: source, keys: keys}
+}
+
+// Endpoints collects endpoints from its wrapped source, which copies the labels of the resources to them.
+func (rs *resourceLabelsSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	return rs.source.Endpoints(source.WithResourceLabels(ctx, rs.keys))
+}
+
+func (rs *resourceLabelsSource) AddEventHandler(ctx context.Context, handler func()) {
+	rs.source.AddEventHandler(ctx, handler)
+}
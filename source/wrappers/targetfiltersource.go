@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/source"
 )
 
@@ -63,6 +64,7 @@ func (ms *targetFilterSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoi
 		// If all targets are filtered out, skip the endpoint.
 		if len(filteredTargets) == 0 {
 			log.WithField("endpoint", ep).Debugf("Skipping endpoint because all targets were filtered out")
+			plan.CountSkipped(plan.SkipReasonTargetFilter, ep)
 			continue
 		}
 